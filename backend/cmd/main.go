@@ -1,17 +1,50 @@
 package main
 
 import (
+    "context"
     "fmt"
     "log"
+    "log/slog"
+    "os"
+    "os/signal"
+    "sync"
+    "syscall"
+    "time"
+    // Embed the IANA zone database so X-Timezone and TENANT_TIMEZONES are
+    // validated the same way on hosts without one.
+    _ "time/tzdata"
 
+    appaccount "backend/internal/application/account"
+    appapikey "backend/internal/application/apikey"
+    appattachment "backend/internal/application/attachment"
+    appcomment "backend/internal/application/comment"
+    appfeatureflag "backend/internal/application/featureflag"
+    apphealth "backend/internal/application/health"
     appprioritize "backend/internal/application/prioritize"
+    appproject "backend/internal/application/project"
+    apprevocation "backend/internal/application/revocation"
     apptask "backend/internal/application/task"
+    apptemplate "backend/internal/application/template"
+    apptenant "backend/internal/application/tenant"
+    apptimeline "backend/internal/application/timeline"
+    appuser "backend/internal/application/user"
+    "backend/internal/infrastructure/ai"
     "backend/internal/infrastructure/auth"
+    "backend/internal/infrastructure/blob"
+    "backend/internal/infrastructure/eventbus"
+    "backend/internal/infrastructure/export"
+    "backend/internal/infrastructure/importer"
+    "backend/internal/infrastructure/notify"
     pginfra "backend/internal/infrastructure/postgres"
+    "backend/internal/infrastructure/redisjob"
+    "backend/internal/infrastructure/telemetry"
     httpiface "backend/internal/interface/http"
+    "backend/internal/interface/http/middleware"
     "backend/internal/pkg/config"
 
     "github.com/gofiber/fiber/v2"
+    "github.com/redis/go-redis/v9"
+    "go.opentelemetry.io/otel"
 )
 
 func main() {
@@ -21,6 +54,23 @@ func main() {
 		log.Fatalf("config load: %v", err)
 	}
 
+	// Structured logger at the configured level (Load has validated it)
+	level, _ := config.ParseLogLevel(cfg.LogLevel)
+	opts := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler = slog.NewTextHandler(os.Stdout, opts)
+	if cfg.Env == "production" {
+		handler = slog.NewJSONHandler(os.Stdout, opts)
+	}
+	logger := slog.New(handler)
+	slog.SetDefault(logger)
+
+	// Metrics, exposed for Prometheus at /metrics
+	meterProvider, metricsHandler, err := telemetry.NewPrometheusMeterProvider()
+	if err != nil {
+		log.Fatalf("metrics: %v", err)
+	}
+	otel.SetMeterProvider(meterProvider)
+
 	// Connect DB (GORM) — also runs AutoMigrate(Task)
     gdb, err := pginfra.Connect(cfg)
     if err != nil {
@@ -31,21 +81,252 @@ func main() {
 	defer sqlDB.Close()
 
 	// Initialize infrastructure (GORM-backed repo instead of in-memory)
-    repo := pginfra.NewTaskRepository(gdb)
+    retryPolicy := pginfra.RetryPolicy{Attempts: cfg.DBRetryAttempts, Backoff: time.Duration(cfg.DBRetryBackoffMS) * time.Millisecond}
+    repo := pginfra.NewRetryingTaskRepository(pginfra.NewTaskRepository(gdb), retryPolicy)
+    projectRepo := pginfra.NewRetryingProjectRepository(pginfra.NewProjectRepository(gdb), retryPolicy)
+    commentRepo := pginfra.NewCommentRepository(gdb)
+    settingsRepo := pginfra.NewPrioritizeSettingsRepository(gdb)
+    tenantRepo := pginfra.NewTenantRepository(gdb)
+    apiKeyRepo := pginfra.NewAPIKeyRepository(gdb)
+    featureFlagRepo := pginfra.NewFeatureFlagRepository(gdb)
+    tenantSettingsRepo := pginfra.NewTenantSettingsRepository(gdb)
+    accountRepo := pginfra.NewAccountRepository(gdb)
+    templateRepo := pginfra.NewTemplateRepository(gdb)
+    attachmentRepo := pginfra.NewAttachmentRepository(gdb)
+    userRepo := pginfra.NewUserRepository(gdb)
+    revocationRepo := pginfra.NewRevocationRepository(gdb)
 
-	// Initialize application services
-	taskSvc := apptask.NewService(repo)
-	prioritizeSvc := appprioritize.NewService()
+	// The AI client, when configured, scores tasks for prioritization,
+	// summarizes task descriptions and breaks tasks into subtasks
+	var aiClient *ai.OpenAIClient
+	if cfg.AIEnabled() {
+		aiClient = ai.NewOpenAIClient(cfg.AIBaseURL, cfg.AIAPIKey, cfg.AIModel, time.Duration(cfg.AITimeoutMS)*time.Millisecond)
+	}
 
-	// Auth service (simple dev implementation)
-	authSvc := auth.NewSimpleAuthService()
+	// Background jobs run through Redis when REDIS_URL is set
+	var jobRunner *redisjob.RedisJobRunner
+	var jobWorker *redisjob.Worker
+	var redisClient *redis.Client
+	if cfg.RedisURL != "" {
+		redisOpts, err := redis.ParseURL(cfg.RedisURL)
+		if err != nil {
+			log.Fatalf("REDIS_URL: %v", err)
+		}
+		redisClient = redis.NewClient(redisOpts)
+		defer redisClient.Close()
+		jobRunner = redisjob.NewRedisJobRunner(redisClient)
+		jobWorker = redisjob.NewWorker(redisClient, logger)
+	}
+
+	// GET /health pings the database and, when configured, Redis and the AI
+	// provider behind prioritization; GET /readyz only the first two
+	readyDB := pginfra.NewReadinessChecker(sqlDB)
+	var readyCache apphealth.ReadinessChecker
+	if redisClient != nil {
+		readyCache = redisjob.NewReadinessChecker(redisClient)
+	}
+	health := apphealth.NewService(time.Duration(cfg.HealthCheckTimeoutMS) * time.Millisecond)
+	health.Register("db", readyDB)
+	if readyCache != nil {
+		health.Register("cache", readyCache)
+	}
+	if aiClient != nil {
+		health.Register("prioritize", aiClient)
+	}
+
+	// Initialize application services; task events fan out to stream listeners
+	// and task changes drop the tenant's cached prioritization results
+	taskEvents := eventbus.New(logger)
+	scoreCache := appprioritize.NewCache(time.Duration(cfg.PrioritizeCacheTTLMS) * time.Millisecond)
+	// Uploaded files go to the local filesystem, served at /uploads, or to
+	// an S3-compatible bucket
+	var blobs appattachment.BlobStore = blob.NewLocalStore(cfg.UploadDir, httpiface.UploadsPath)
+	if cfg.BlobStore == config.BlobStoreS3 {
+		blobs = blob.NewS3Store(cfg.S3Endpoint, cfg.S3Bucket)
+	}
+	taskOpts := []apptask.Option{
+		apptask.WithLogger(logger),
+		apptask.WithMeterProvider(meterProvider),
+		apptask.WithEventPublisher(taskEvents),
+		apptask.WithNotifier(notify.NewLogNotifier(logger)),
+		apptask.WithScoreCache(scoreCache),
+		apptask.WithLengthLimits(cfg.MaxTitleLen, cfg.MaxDescriptionLen),
+		apptask.WithTimezones(cfg.TenantTimezones),
+		apptask.WithDoneBlocking(cfg.DependenciesBlockDone),
+		apptask.WithReportWriter(export.NewPDFExporter()),
+		apptask.WithTenantNamer(accountRepo),
+		apptask.WithProjectLookup(projectRepo),
+		apptask.WithFileStore(blobs),
+		apptask.WithImportFormat(importer.FormatTrello, importer.NewTrelloImporter()),
+		apptask.WithImportFormat(importer.FormatJira, importer.NewJiraImporter()),
+	}
+	if aiClient != nil {
+		taskOpts = append(taskOpts, apptask.WithSummarizer(aiClient), apptask.WithSubtaskGenerator(aiClient))
+	}
+	// Imports only become jobs when clients can poll them
+	if jobRunner != nil && cfg.FeatureEnabled(config.FeatureJobs) {
+		taskOpts = append(taskOpts, apptask.WithJobRunner(jobRunner))
+	}
+	taskSvc := apptask.NewService(repo, taskOpts...)
+	if jobWorker != nil {
+		jobWorker.Register(apptask.ImportJobType, apptask.NewImportJobHandler(taskSvc))
+	}
+	commentSvc := appcomment.NewService(commentRepo)
+	projectSvc := appproject.NewService(projectRepo)
+	templateSvc := apptemplate.NewService(templateRepo, taskSvc)
+	userSvc := appuser.NewService(userRepo)
+	attachmentSvc := appattachment.NewService(attachmentRepo, blobs,
+		appattachment.WithMaxSize(int64(cfg.MaxAttachmentBytes())), appattachment.WithAllowedTypes(cfg.AttachmentTypes...))
+	timelineSvc := apptimeline.NewService(taskSvc, commentRepo, attachmentRepo)
+	prioritizeSvc := appprioritize.NewService().WithSettings(settingsRepo).WithCache(scoreCache).
+		WithBatching(appprioritize.Batching{Size: cfg.AIBatchSize, Concurrency: cfg.AIBatchConcurrency, RetryBackoff: appprioritize.DefaultBatching().RetryBackoff})
+	tenantOpts := []apptenant.Option{apptenant.WithFileStore(blobs), apptenant.WithScoreCache(scoreCache)}
+	if jobRunner != nil {
+		tenantOpts = append(tenantOpts, apptenant.WithJobStore(jobRunner))
+	}
+	tenantSvc := apptenant.NewService(tenantRepo, tenantOpts...)
+	apiKeySvc := appapikey.NewService(apiKeyRepo)
+	featureFlagSvc := appfeatureflag.NewService(featureFlagRepo)
+	tenantSettingsSvc := apptenant.NewSettingsService(tenantSettingsRepo)
+
+	// Auth service: signed JWTs, an identity provider's JWKS, or the simple
+	// dev implementation
+	leeway := time.Duration(cfg.JWTLeewaySeconds) * time.Second
+	jwtSvc := auth.NewJWTService([]byte(cfg.JWTSecret)).WithLeeway(leeway)
+	// Signed JWTs can be revoked; a revocation is kept until every token it
+	// covers has expired
+	var revocationSvc *apprevocation.Service
+	if cfg.AuthMode == config.AuthModeJWT {
+		revocationSvc = apprevocation.NewService(revocationRepo, time.Duration(cfg.JWTTTLMinutes)*time.Minute+leeway,
+			apprevocation.WithRefreshInterval(time.Duration(cfg.RevocationRefreshSeconds)*time.Second),
+			apprevocation.WithFailOpen(cfg.RevocationFailOpen))
+		jwtSvc = jwtSvc.WithRevocations(revocationSvc)
+	}
+	var authSvc middleware.AuthService = jwtSvc
+	var jwks *auth.JWKSService
+	switch cfg.AuthMode {
+	case config.AuthModeSimple:
+		logger.Warn("AUTH_MODE=simple: any bearer token is accepted as user u1 in tenant t1")
+		authSvc = auth.NewSimpleAuthService()
+	case config.AuthModeJWKS:
+		// JWKSConfig reads a zero leeway as the default
+		if leeway == 0 {
+			leeway = -1
+		}
+		jwks = auth.NewJWKSService(auth.JWKSConfig{
+			URL:             cfg.JWKSURL,
+			Issuer:          cfg.JWTIssuer,
+			Audience:        cfg.JWTAudience,
+			TenantClaim:     cfg.JWTTenantClaim,
+			RefreshInterval: time.Duration(cfg.JWKSRefreshMinutes) * time.Minute,
+			KeyTTL:          time.Duration(cfg.JWKSKeyTTLMinutes) * time.Minute,
+			Leeway:          leeway,
+			Logger:          logger,
+		})
+		// A failed first fetch is retried when the first token arrives
+		if err := jwks.Refresh(context.Background()); err != nil {
+			logger.Error("jwks fetch failed", "url", cfg.JWKSURL, "error", err)
+		}
+		authSvc = jwks
+	}
+	// Outside simple mode "Authorization: ApiKey <key>" works too: tokens are
+	// tried first, then tenant API keys
+	apiKeyAuth := auth.NewAPIKeyAuthService(apiKeySvc)
+	if cfg.AuthMode != config.AuthModeSimple {
+		authSvc = auth.NewChainAuthService(authSvc, apiKeyAuth)
+	}
+	// Internal services call maintenance endpoints with the service token,
+	// tried before everything else so simple mode cannot claim it
+	if cfg.ServiceToken != "" {
+		authSvc = auth.NewChainAuthService(auth.NewServiceTokenAuthService(cfg.ServiceToken), authSvc)
+	}
 
 	// Build HTTP app
-	app := fiber.New()
-	deps := httpiface.NewDependencies(authSvc, taskSvc, prioritizeSvc)
+	app := fiber.New(httpiface.AppConfig(cfg))
+	deps := httpiface.NewDependencies(authSvc, taskSvc, commentSvc, projectSvc, prioritizeSvc, tenantSvc)
+	deps.Logger = logger
+	deps.Config = cfg
+	deps.MeterProvider = meterProvider
+	deps.MetricsHandler = metricsHandler
+	deps.TaskEvents = taskEvents
+	deps.APIKeyService = apiKeySvc
+	deps.APIKeyAuth = apiKeyAuth
+	deps.FeatureFlags = featureFlagSvc
+	deps.TenantSettings = tenantSettingsSvc
+	deps.TemplateService = templateSvc
+	deps.UserService = userSvc
+	deps.AttachmentService = attachmentSvc
+	deps.TimelineService = timelineSvc
+	if cfg.BlobStore == config.BlobStoreLocal {
+		deps.UploadDir = cfg.UploadDir
+	}
+	deps.Health = health
+	deps.ReadyDB = readyDB
+	deps.ReadyCache = readyCache
+	// Registration and login sign tokens with JWT_SECRET, so they are only
+	// served when that is what verifies them
+	if cfg.AuthMode == config.AuthModeJWT {
+		deps.Accounts = appaccount.NewService(accountRepo, jwtSvc, time.Duration(cfg.JWTTTLMinutes)*time.Minute, time.Duration(cfg.RefreshTokenTTLHours)*time.Hour, appaccount.WithBcryptCost(cfg.BcryptCost))
+		deps.Revocations = revocationSvc
+	}
+	if jobRunner != nil {
+		deps.Jobs = jobRunner
+	}
+	if aiClient != nil {
+		deps.AIProvider = aiClient
+	}
 	httpiface.Build(app, deps)
 
+	// Stop accepting requests and background work on SIGINT or SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		_ = app.ShutdownWithTimeout(10 * time.Second)
+	}()
+
+	// Background work: JWKS refreshes in jwks mode, the job worker when
+	// Redis is configured, and scheduled re-prioritization, which shares the
+	// /all pipeline and its per-tenant lock and is disabled unless
+	// PRIORITIZE_SCHEDULE_MINUTES is set
+	var background sync.WaitGroup
+	if jwks != nil {
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			jwks.Run(ctx)
+		}()
+	}
+	if jobWorker != nil {
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			jobWorker.Start(ctx)
+		}()
+		logger.Info("job worker started", "queue", redisjob.QueueKey)
+	}
+	if cfg.PrioritizeScheduleMinutes > 0 {
+		schedSvc := prioritizeSvc
+		if aiClient != nil {
+			schedSvc = schedSvc.WithProvider(aiClient)
+		}
+		interval := time.Duration(cfg.PrioritizeScheduleMinutes) * time.Minute
+		scheduler := appprioritize.NewScheduler(schedSvc, taskSvc, interval, cfg.PrioritizeAllMaxTasks, logger)
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			scheduler.Run(ctx)
+		}()
+		logger.Info("prioritize scheduler started", "interval", interval)
+	}
+
 	addr := fmt.Sprintf(":%s", cfg.Port)
-	log.Printf("listening on %s", addr)
-	log.Fatal(app.Listen(addr))
+	logger.Info("listening", "addr", addr)
+	if err := app.Listen(addr); err != nil {
+		log.Fatal(err)
+	}
+	stop()
+	background.Wait()
+	apiKeySvc.Wait()
+	logger.Info("shut down")
 }
@@ -0,0 +1,80 @@
+package account
+
+import (
+    "context"
+    "errors"
+    "time"
+
+    domainaccount "backend/internal/domain/account"
+)
+
+var (
+    // ErrEmailTaken is returned when registering an email that already has
+    // a user, in any tenant.
+    ErrEmailTaken = errors.New("email is already registered")
+    // ErrInvalidCredentials is returned for a failed login, whether the
+    // email is unknown or the password wrong.
+    ErrInvalidCredentials = errors.New("invalid email or password")
+    // ErrInvalidEmail is returned when registering with an address that is
+    // not a plain email address.
+    ErrInvalidEmail = errors.New("email must be a valid address")
+    // ErrInvalidPassword is returned when registering with a password
+    // outside [MinPasswordLength, MaxPasswordLength].
+    ErrInvalidPassword = errors.New("password must be 8 to 72 characters long")
+    // ErrInvalidTenantName is returned when registering with a blank tenant
+    // name or one over MaxTenantNameLength.
+    ErrInvalidTenantName = errors.New("tenant name is required and must be at most 100 characters")
+    // ErrInvalidRefreshToken is returned for a refresh token that is
+    // unknown, expired or revoked.
+    ErrInvalidRefreshToken = errors.New("invalid refresh token")
+    // ErrRefreshTokenReused is returned when a refresh token that was
+    // already exchanged is presented again. Its whole family is revoked, as
+    // the token has likely been stolen.
+    ErrRefreshTokenReused = errors.New("refresh token reused")
+    // ErrNotFound is returned by Repository.FindUserByEmail and
+    // FindUserByID for an unknown user.
+    ErrNotFound = errors.New("user not found")
+    // ErrWrongPassword is returned by ChangePassword when the current
+    // password does not match.
+    ErrWrongPassword = errors.New("current password is incorrect")
+    // ErrWeakPassword is returned by ChangePassword for a new password
+    // outside [MinNewPasswordLength, MaxPasswordLength] or equal to the
+    // user's email.
+    ErrWeakPassword = errors.New("new password must be 10 to 72 characters long and differ from the email")
+)
+
+// Repository defines persistence operations for tenants and their users.
+type Repository interface {
+    // CreateTenant stores t and its first user owner together, or neither,
+    // returning ErrEmailTaken when owner's email is in use.
+    CreateTenant(ctx context.Context, t *domainaccount.Tenant, owner *domainaccount.User) error
+    // FindUserByEmail returns the user with the normalized email, or
+    // ErrNotFound.
+    FindUserByEmail(ctx context.Context, email string) (*domainaccount.User, error)
+    // FindUserByID returns the tenant's user, or ErrNotFound.
+    FindUserByID(ctx context.Context, tenantID, id string) (*domainaccount.User, error)
+    // SetPassword stores the user's new password hash and revokes all of
+    // the user's refresh tokens at at, atomically; ErrNotFound for an
+    // unknown user.
+    SetPassword(ctx context.Context, tenantID, userID, hash string, at time.Time) error
+
+    CreateRefreshToken(ctx context.Context, t *domainaccount.RefreshToken) error
+    // FindRefreshToken returns the token whose secret hashes to hash, or
+    // ErrInvalidRefreshToken.
+    FindRefreshToken(ctx context.Context, hash string) (*domainaccount.RefreshToken, error)
+    // RotateRefreshToken marks the token oldID rotated at at and stores next
+    // in its place, atomically. It returns ErrRefreshTokenReused, storing
+    // nothing, when oldID was already rotated or revoked.
+    RotateRefreshToken(ctx context.Context, oldID string, next *domainaccount.RefreshToken, at time.Time) error
+    // RevokeRefreshToken stamps the token revoked at at; revoked tokens keep
+    // their original time and unknown ids are ignored.
+    RevokeRefreshToken(ctx context.Context, id string, at time.Time) error
+    // RevokeRefreshFamily revokes every token of the family that is not
+    // revoked yet.
+    RevokeRefreshFamily(ctx context.Context, familyID string, at time.Time) error
+}
+
+// TokenIssuer signs access tokens for a user of a tenant.
+type TokenIssuer interface {
+    Mint(userID, tenantID string, ttl time.Duration) (string, error)
+}
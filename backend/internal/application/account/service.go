@@ -0,0 +1,261 @@
+package account
+
+import (
+    "context"
+    "errors"
+    "log/slog"
+    "net/mail"
+    "strings"
+    "sync"
+    "time"
+    "unicode/utf8"
+
+    domainaccount "backend/internal/domain/account"
+
+    "golang.org/x/crypto/bcrypt"
+)
+
+const (
+    // MinPasswordLength is the shortest password accepted, in characters.
+    MinPasswordLength = 8
+    // MinNewPasswordLength is the shortest password ChangePassword accepts,
+    // in characters.
+    MinNewPasswordLength = 10
+    // MaxPasswordLength is the longest password accepted, in bytes; bcrypt
+    // ignores anything past it.
+    MaxPasswordLength = 72
+    // MaxTenantNameLength bounds a tenant's name, in characters.
+    MaxTenantNameLength = 100
+)
+
+// Token is an access token and the time it stops being accepted, with the
+// refresh token that gets the next one.
+type Token struct {
+    Token            string    `json:"token"`
+    ExpiresAt        time.Time `json:"expiresAt"`
+    RefreshToken     string    `json:"refreshToken"`
+    RefreshExpiresAt time.Time `json:"refreshExpiresAt"`
+}
+
+// Session is what registering or logging in returns.
+type Session struct {
+    Token
+    User   domainaccount.User    `json:"user"`
+    Tenant *domainaccount.Tenant `json:"tenant,omitempty"`
+}
+
+// Service implements sign-up, sign-in and token refresh.
+type Service struct {
+    repo       Repository
+    tokens     TokenIssuer
+    ttl        time.Duration
+    refreshTTL time.Duration
+    now        func() time.Time
+    cost       int
+    // dummyHash is compared against when the email is unknown, so failed
+    // logins take as long whether or not the user exists.
+    dummyOnce sync.Once
+    dummyHash []byte
+}
+
+// Option configures optional Service behaviour.
+type Option func(*Service)
+
+// WithBcryptCost sets the bcrypt cost passwords are hashed with. By default
+// bcrypt.DefaultCost applies; values outside [bcrypt.MinCost,
+// bcrypt.MaxCost] keep it. Existing hashes keep the cost they were made
+// with.
+func WithBcryptCost(cost int) Option {
+    return func(s *Service) {
+        if cost >= bcrypt.MinCost && cost <= bcrypt.MaxCost {
+            s.cost = cost
+        }
+    }
+}
+
+// NewService returns a service issuing access tokens valid for ttl and
+// refresh tokens valid for refreshTTL.
+func NewService(repo Repository, tokens TokenIssuer, ttl, refreshTTL time.Duration, opts ...Option) *Service {
+    s := &Service{repo: repo, tokens: tokens, ttl: ttl, refreshTTL: refreshTTL, now: time.Now, cost: bcrypt.DefaultCost}
+    for _, opt := range opts {
+        opt(s)
+    }
+    return s
+}
+
+// Register creates a tenant named tenantName with a first user signing in
+// with email and password, and returns a token for that user.
+func (s *Service) Register(ctx context.Context, email, password, tenantName string) (*Session, error) {
+    email = domainaccount.NormalizeEmail(email)
+    tenantName = strings.TrimSpace(tenantName)
+    if err := validateRegistration(email, password, tenantName); err != nil {
+        return nil, err
+    }
+    hash, err := bcrypt.GenerateFromPassword([]byte(password), s.cost)
+    if err != nil {
+        return nil, err
+    }
+    t := domainaccount.NewTenant(tenantName)
+    u := domainaccount.NewUser(t.ID, email, string(hash))
+    u.Role = domainaccount.RoleOwner
+    if err := s.repo.CreateTenant(ctx, t, u); err != nil {
+        return nil, err
+    }
+    tok, err := s.issue(ctx, u.TenantID, u.ID)
+    if err != nil {
+        return nil, err
+    }
+    return &Session{Token: tok, User: *u, Tenant: t}, nil
+}
+
+// Login returns a token for the user with email and password. Unknown
+// emails and wrong passwords both get ErrInvalidCredentials, after the same
+// bcrypt work.
+func (s *Service) Login(ctx context.Context, email, password string) (*Session, error) {
+    u, err := s.repo.FindUserByEmail(ctx, domainaccount.NormalizeEmail(email))
+    if errors.Is(err, ErrNotFound) {
+        _ = bcrypt.CompareHashAndPassword(s.fallbackHash(), []byte(password))
+        return nil, ErrInvalidCredentials
+    }
+    if err != nil {
+        return nil, err
+    }
+    if bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)) != nil {
+        return nil, ErrInvalidCredentials
+    }
+    tok, err := s.issue(ctx, u.TenantID, u.ID)
+    if err != nil {
+        return nil, err
+    }
+    return &Session{Token: tok, User: *u}, nil
+}
+
+// Refresh exchanges a refresh token for a new access token and a new refresh
+// token of the same family; the presented one stops working. Presenting a
+// token that was already exchanged revokes its family and returns
+// ErrRefreshTokenReused.
+func (s *Service) Refresh(ctx context.Context, refreshToken string) (*Token, error) {
+    old, err := s.repo.FindRefreshToken(ctx, domainaccount.HashRefreshToken(refreshToken))
+    if err != nil {
+        return nil, err
+    }
+    now := s.now()
+    if old.RotatedAt != nil {
+        return nil, s.revokeFamily(ctx, old)
+    }
+    if !old.Usable(now) {
+        return nil, ErrInvalidRefreshToken
+    }
+    next, secret, err := domainaccount.NewRefreshToken(old.TenantID, old.UserID, old.FamilyID, now, s.refreshTTL)
+    if err != nil {
+        return nil, err
+    }
+    // A concurrent exchange of the same token may have won the race.
+    if err := s.repo.RotateRefreshToken(ctx, old.ID, next, now.UTC()); errors.Is(err, ErrRefreshTokenReused) {
+        return nil, s.revokeFamily(ctx, old)
+    } else if err != nil {
+        return nil, err
+    }
+    tok, err := s.accessToken(old.TenantID, old.UserID)
+    if err != nil {
+        return nil, err
+    }
+    tok.RefreshToken, tok.RefreshExpiresAt = secret, next.ExpiresAt
+    return &tok, nil
+}
+
+// Logout revokes the refresh token; unknown tokens are ignored. Access
+// tokens already issued stay valid until they expire.
+func (s *Service) Logout(ctx context.Context, refreshToken string) error {
+    t, err := s.repo.FindRefreshToken(ctx, domainaccount.HashRefreshToken(refreshToken))
+    if errors.Is(err, ErrInvalidRefreshToken) {
+        return nil
+    }
+    if err != nil {
+        return err
+    }
+    return s.repo.RevokeRefreshToken(ctx, t.ID, s.now().UTC())
+}
+
+// ChangePassword replaces the user's password after checking the current
+// one, and revokes all of the user's refresh tokens so other sessions end
+// once their access tokens expire. It returns ErrWrongPassword when current
+// does not match and ErrWeakPassword when next breaks the rules.
+func (s *Service) ChangePassword(ctx context.Context, tenantID, userID, current, next string) error {
+    u, err := s.repo.FindUserByID(ctx, tenantID, userID)
+    if err != nil {
+        return err
+    }
+    if bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(current)) != nil {
+        return ErrWrongPassword
+    }
+    if utf8.RuneCountInString(next) < MinNewPasswordLength || len(next) > MaxPasswordLength ||
+        domainaccount.NormalizeEmail(next) == u.Email {
+        return ErrWeakPassword
+    }
+    hash, err := bcrypt.GenerateFromPassword([]byte(next), s.cost)
+    if err != nil {
+        return err
+    }
+    return s.repo.SetPassword(ctx, tenantID, userID, string(hash), s.now().UTC())
+}
+
+// revokeFamily revokes every token descending from the same login as t and
+// returns ErrRefreshTokenReused.
+func (s *Service) revokeFamily(ctx context.Context, t *domainaccount.RefreshToken) error {
+    if err := s.repo.RevokeRefreshFamily(ctx, t.FamilyID, s.now().UTC()); err != nil {
+        return err
+    }
+    slog.WarnContext(ctx, "refresh token reused, family revoked", "tenant_id", t.TenantID, "user_id", t.UserID, "family_id", t.FamilyID)
+    return ErrRefreshTokenReused
+}
+
+// issue mints an access token for the user and stores a refresh token
+// starting a new family.
+func (s *Service) issue(ctx context.Context, tenantID, userID string) (Token, error) {
+    rt, secret, err := domainaccount.NewRefreshToken(tenantID, userID, "", s.now(), s.refreshTTL)
+    if err != nil {
+        return Token{}, err
+    }
+    if err := s.repo.CreateRefreshToken(ctx, rt); err != nil {
+        return Token{}, err
+    }
+    tok, err := s.accessToken(tenantID, userID)
+    if err != nil {
+        return Token{}, err
+    }
+    tok.RefreshToken, tok.RefreshExpiresAt = secret, rt.ExpiresAt
+    return tok, nil
+}
+
+// accessToken mints an access token for the user. ExpiresAt is taken before
+// minting and rounded down to the second like exp, so it is never later
+// than the token's.
+func (s *Service) accessToken(tenantID, userID string) (Token, error) {
+    expiresAt := s.now().Add(s.ttl).UTC().Truncate(time.Second)
+    token, err := s.tokens.Mint(userID, tenantID, s.ttl)
+    if err != nil {
+        return Token{}, err
+    }
+    return Token{Token: token, ExpiresAt: expiresAt}, nil
+}
+
+func (s *Service) fallbackHash() []byte {
+    s.dummyOnce.Do(func() {
+        s.dummyHash, _ = bcrypt.GenerateFromPassword([]byte("not a real password"), s.cost)
+    })
+    return s.dummyHash
+}
+
+func validateRegistration(email, password, tenantName string) error {
+    if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+        return ErrInvalidEmail
+    }
+    if utf8.RuneCountInString(password) < MinPasswordLength || len(password) > MaxPasswordLength {
+        return ErrInvalidPassword
+    }
+    if tenantName == "" || utf8.RuneCountInString(tenantName) > MaxTenantNameLength {
+        return ErrInvalidTenantName
+    }
+    return nil
+}
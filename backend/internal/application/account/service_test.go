@@ -0,0 +1,245 @@
+package account_test
+
+import (
+    "context"
+    "errors"
+    "strings"
+    "testing"
+    "time"
+
+    appaccount "backend/internal/application/account"
+    "backend/internal/infrastructure/auth"
+    "backend/internal/infrastructure/memory"
+)
+
+var testSecret = []byte(strings.Repeat("s", 32))
+
+func newTestService() *appaccount.Service {
+    return appaccount.NewService(memory.NewAccountRepository(), auth.NewJWTService(testSecret), time.Hour, 24*time.Hour)
+}
+
+// Test that registering creates a tenant and user whose token verifies, and
+// that the same email logs in regardless of case.
+func TestService_RegisterAndLogin(t *testing.T) {
+    ctx := context.Background()
+    s := newTestService()
+    before := time.Now()
+
+    reg, err := s.Register(ctx, " Ada@Example.com ", "correct horse", "Acme")
+    if err != nil {
+        t.Fatalf("register: %v", err)
+    }
+    if reg.Tenant == nil || reg.Tenant.Name != "Acme" || reg.User.TenantID != reg.Tenant.ID || reg.User.Email != "ada@example.com" {
+        t.Fatalf("expected ada@example.com in tenant Acme, got %+v", reg)
+    }
+    if reg.User.PasswordHash == "correct horse" {
+        t.Fatalf("expected the password to be hashed")
+    }
+    claims, err := auth.NewJWTService(testSecret).VerifyToken(reg.Token.Token)
+    if err != nil {
+        t.Fatalf("verify: %v", err)
+    }
+    if claims.UserID != reg.User.ID || claims.TenantID != reg.Tenant.ID {
+        t.Fatalf("expected claims for the new user, got %+v", claims)
+    }
+    if reg.ExpiresAt.Before(before.Add(time.Hour).Add(-time.Second)) || reg.ExpiresAt.After(time.Now().Add(time.Hour)) {
+        t.Fatalf("expected expiresAt an hour from now, got %v", reg.ExpiresAt)
+    }
+
+    login, err := s.Login(ctx, "ADA@example.com", "correct horse")
+    if err != nil {
+        t.Fatalf("login: %v", err)
+    }
+    if login.User.ID != reg.User.ID || login.Token.Token == "" {
+        t.Fatalf("expected a token for the registered user, got %+v", login)
+    }
+}
+
+// Test that an email can be registered only once, whatever its case.
+func TestService_Register_EmailTaken(t *testing.T) {
+    ctx := context.Background()
+    s := newTestService()
+    if _, err := s.Register(ctx, "ada@example.com", "correct horse", "Acme"); err != nil {
+        t.Fatalf("register: %v", err)
+    }
+    if _, err := s.Register(ctx, "ADA@EXAMPLE.COM", "another pass", "Other"); !errors.Is(err, appaccount.ErrEmailTaken) {
+        t.Fatalf("expected appaccount.ErrEmailTaken, got %v", err)
+    }
+}
+
+// Test that malformed emails, short or long passwords and bad tenant names
+// are refused.
+func TestService_Register_Validation(t *testing.T) {
+    s := newTestService()
+    for _, tc := range []struct {
+        email, password, tenant string
+        want                    error
+    }{
+        {"", "correct horse", "Acme", appaccount.ErrInvalidEmail},
+        {"not-an-email", "correct horse", "Acme", appaccount.ErrInvalidEmail},
+        {"Ada <ada@example.com>", "correct horse", "Acme", appaccount.ErrInvalidEmail},
+        {"ada@example.com", "short", "Acme", appaccount.ErrInvalidPassword},
+        {"ada@example.com", strings.Repeat("p", appaccount.MaxPasswordLength+1), "Acme", appaccount.ErrInvalidPassword},
+        {"ada@example.com", "correct horse", "  ", appaccount.ErrInvalidTenantName},
+        {"ada@example.com", "correct horse", strings.Repeat("a", appaccount.MaxTenantNameLength+1), appaccount.ErrInvalidTenantName},
+    } {
+        if _, err := s.Register(context.Background(), tc.email, tc.password, tc.tenant); !errors.Is(err, tc.want) {
+            t.Fatalf("%q/%q/%q: expected %v, got %v", tc.email, tc.password, tc.tenant, tc.want, err)
+        }
+    }
+}
+
+// Test that an unknown email and a wrong password fail with the same error.
+func TestService_Login_InvalidCredentials(t *testing.T) {
+    ctx := context.Background()
+    s := newTestService()
+    if _, err := s.Register(ctx, "ada@example.com", "correct horse", "Acme"); err != nil {
+        t.Fatalf("register: %v", err)
+    }
+    if _, err := s.Login(ctx, "ada@example.com", "wrong horse"); !errors.Is(err, appaccount.ErrInvalidCredentials) {
+        t.Fatalf("expected appaccount.ErrInvalidCredentials for a wrong password, got %v", err)
+    }
+    if _, err := s.Login(ctx, "bob@example.com", "correct horse"); !errors.Is(err, appaccount.ErrInvalidCredentials) {
+        t.Fatalf("expected appaccount.ErrInvalidCredentials for an unknown email, got %v", err)
+    }
+}
+
+// Test that refreshing returns a new access and refresh token, and that the
+// exchanged refresh token stops working.
+func TestService_Refresh_Rotates(t *testing.T) {
+    ctx := context.Background()
+    s := newTestService()
+    login, err := s.Register(ctx, "ada@example.com", "correct horse", "Acme")
+    if err != nil {
+        t.Fatalf("register: %v", err)
+    }
+    if login.RefreshToken == "" || !login.RefreshExpiresAt.After(login.ExpiresAt) {
+        t.Fatalf("expected a refresh token outliving the access token, got %+v", login.Token)
+    }
+
+    next, err := s.Refresh(ctx, login.RefreshToken)
+    if err != nil {
+        t.Fatalf("refresh: %v", err)
+    }
+    if next.RefreshToken == login.RefreshToken || next.Token == "" {
+        t.Fatalf("expected new tokens, got %+v", next)
+    }
+    claims, err := auth.NewJWTService(testSecret).VerifyToken(next.Token)
+    if err != nil || claims.UserID != login.User.ID || claims.TenantID != login.User.TenantID {
+        t.Fatalf("expected an access token for the user, got %+v (%v)", claims, err)
+    }
+    if _, err := s.Refresh(ctx, next.RefreshToken); err != nil {
+        t.Fatalf("expected the rotated-in token to refresh, got %v", err)
+    }
+}
+
+// Test that presenting an already exchanged refresh token fails and revokes
+// every token of its family, including the one it was exchanged for.
+func TestService_Refresh_ReuseRevokesFamily(t *testing.T) {
+    ctx := context.Background()
+    s := newTestService()
+    login, _ := s.Register(ctx, "ada@example.com", "correct horse", "Acme")
+    other, err := s.Login(ctx, "ada@example.com", "correct horse")
+    if err != nil {
+        t.Fatalf("login: %v", err)
+    }
+    next, err := s.Refresh(ctx, login.RefreshToken)
+    if err != nil {
+        t.Fatalf("refresh: %v", err)
+    }
+
+    if _, err := s.Refresh(ctx, login.RefreshToken); !errors.Is(err, appaccount.ErrRefreshTokenReused) {
+        t.Fatalf("expected ErrRefreshTokenReused, got %v", err)
+    }
+    if _, err := s.Refresh(ctx, next.RefreshToken); !errors.Is(err, appaccount.ErrInvalidRefreshToken) {
+        t.Fatalf("expected the family to be revoked, got %v", err)
+    }
+    if _, err := s.Refresh(ctx, other.RefreshToken); err != nil {
+        t.Fatalf("expected another login's token to keep working, got %v", err)
+    }
+}
+
+// Test that logging out revokes the refresh token, that unknown tokens are
+// ignored, and that expired tokens cannot be refreshed.
+func TestService_Logout(t *testing.T) {
+    ctx := context.Background()
+    s := newTestService()
+    login, _ := s.Register(ctx, "ada@example.com", "correct horse", "Acme")
+    if err := s.Logout(ctx, login.RefreshToken); err != nil {
+        t.Fatalf("logout: %v", err)
+    }
+    if _, err := s.Refresh(ctx, login.RefreshToken); !errors.Is(err, appaccount.ErrInvalidRefreshToken) {
+        t.Fatalf("expected ErrInvalidRefreshToken after logout, got %v", err)
+    }
+    if err := s.Logout(ctx, "mfr_unknown"); err != nil {
+        t.Fatalf("expected unknown tokens to be ignored, got %v", err)
+    }
+
+    short := appaccount.NewService(memory.NewAccountRepository(), auth.NewJWTService(testSecret), time.Hour, time.Nanosecond)
+    expired, err := short.Register(ctx, "bob@example.com", "correct horse", "Bobco")
+    if err != nil {
+        t.Fatalf("register: %v", err)
+    }
+    if _, err := short.Refresh(ctx, expired.RefreshToken); !errors.Is(err, appaccount.ErrInvalidRefreshToken) {
+        t.Fatalf("expected ErrInvalidRefreshToken for an expired token, got %v", err)
+    }
+}
+
+// Test that a wrong current password is refused and leaves the password
+// unchanged.
+func TestService_ChangePassword_WrongCurrent(t *testing.T) {
+    ctx := context.Background()
+    s := newTestService()
+    reg, _ := s.Register(ctx, "ada@example.com", "correct horse", "Acme")
+
+    err := s.ChangePassword(ctx, reg.User.TenantID, reg.User.ID, "wrong horse", "battery staple")
+    if !errors.Is(err, appaccount.ErrWrongPassword) {
+        t.Fatalf("expected ErrWrongPassword, got %v", err)
+    }
+    if _, err := s.Login(ctx, "ada@example.com", "correct horse"); err != nil {
+        t.Fatalf("expected the old password to keep working, got %v", err)
+    }
+}
+
+// Test that new passwords that are short, too long or equal to the email are
+// refused.
+func TestService_ChangePassword_Weak(t *testing.T) {
+    ctx := context.Background()
+    s := newTestService()
+    reg, _ := s.Register(ctx, "ada@example.com", "correct horse", "Acme")
+
+    for _, next := range []string{"short pw", strings.Repeat("x", appaccount.MaxPasswordLength+1), "ADA@example.com"} {
+        err := s.ChangePassword(ctx, reg.User.TenantID, reg.User.ID, "correct horse", next)
+        if !errors.Is(err, appaccount.ErrWeakPassword) {
+            t.Fatalf("expected ErrWeakPassword for %q, got %v", next, err)
+        }
+    }
+}
+
+// Test that changing the password makes it the one to sign in with and
+// revokes every refresh token of the user, but not of other users.
+func TestService_ChangePassword_RevokesSessions(t *testing.T) {
+    ctx := context.Background()
+    s := newTestService()
+    reg, _ := s.Register(ctx, "ada@example.com", "correct horse", "Acme")
+    other, _ := s.Login(ctx, "ada@example.com", "correct horse")
+    bob, _ := s.Register(ctx, "bob@example.com", "correct horse", "Bobco")
+
+    if err := s.ChangePassword(ctx, reg.User.TenantID, reg.User.ID, "correct horse", "battery staple"); err != nil {
+        t.Fatalf("change password: %v", err)
+    }
+    for _, tok := range []string{reg.RefreshToken, other.RefreshToken} {
+        if _, err := s.Refresh(ctx, tok); !errors.Is(err, appaccount.ErrInvalidRefreshToken) {
+            t.Fatalf("expected ErrInvalidRefreshToken after the change, got %v", err)
+        }
+    }
+    if _, err := s.Refresh(ctx, bob.RefreshToken); err != nil {
+        t.Fatalf("expected another user's token to keep working, got %v", err)
+    }
+    if _, err := s.Login(ctx, "ada@example.com", "correct horse"); !errors.Is(err, appaccount.ErrInvalidCredentials) {
+        t.Fatalf("expected the old password to be refused, got %v", err)
+    }
+    if _, err := s.Login(ctx, "ada@example.com", "battery staple"); err != nil {
+        t.Fatalf("expected the new password to sign in, got %v", err)
+    }
+}
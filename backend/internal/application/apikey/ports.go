@@ -0,0 +1,33 @@
+package apikey
+
+import (
+    "context"
+    "errors"
+    "time"
+
+    domainapikey "backend/internal/domain/apikey"
+)
+
+var (
+    // ErrNotFound is returned for an unknown key.
+    ErrNotFound = errors.New("api key not found")
+    // ErrRevoked is returned when authenticating with a revoked key.
+    ErrRevoked = errors.New("api key revoked")
+    // ErrNameRequired is returned when minting a key without a name.
+    ErrNameRequired = errors.New("api key name is required")
+)
+
+// Repository defines persistence operations for API keys.
+type Repository interface {
+    Create(ctx context.Context, k *domainapikey.APIKey) error
+    // FindByHash returns the key whose secret hashes to hash, or ErrNotFound.
+    FindByHash(ctx context.Context, hash string) (*domainapikey.APIKey, error)
+    // ListByTenant returns the tenant's keys, revoked ones included, oldest
+    // first.
+    ListByTenant(ctx context.Context, tenantID string) ([]domainapikey.APIKey, error)
+    // Revoke stamps the tenant's key as revoked at at, or returns ErrNotFound.
+    // Revoking a revoked key keeps the original time.
+    Revoke(ctx context.Context, tenantID, id string, at time.Time) error
+    // TouchLastUsed sets the key's last use to at. Unknown ids are ignored.
+    TouchLastUsed(ctx context.Context, id string, at time.Time) error
+}
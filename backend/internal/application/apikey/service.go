@@ -0,0 +1,98 @@
+package apikey
+
+import (
+    "context"
+    "crypto/subtle"
+    "log/slog"
+    "strings"
+    "sync"
+    "time"
+
+    domainapikey "backend/internal/domain/apikey"
+)
+
+// LastUsedResolution is how stale a key's LastUsedAt may get before
+// Authenticate records a new use, so busy keys are not written on every
+// request.
+const LastUsedResolution = time.Minute
+
+// Service implements API key use cases.
+type Service struct {
+    repo   Repository
+    now    func() time.Time
+    logger *slog.Logger
+    // touches tracks the LastUsedAt updates still being written.
+    touches sync.WaitGroup
+}
+
+func NewService(repo Repository) *Service {
+    return &Service{repo: repo, now: func() time.Time { return time.Now().UTC() }, logger: slog.Default()}
+}
+
+// Mint creates a key acting as userID, or as its own service user when
+// userID is empty, in the tenant and returns it with its secret, which is
+// shown only this once.
+func (s *Service) Mint(ctx context.Context, tenantID, userID, name string) (*domainapikey.APIKey, string, error) {
+    name = strings.TrimSpace(name)
+    if name == "" {
+        return nil, "", ErrNameRequired
+    }
+    k, secret, err := domainapikey.New(tenantID, strings.TrimSpace(userID), name)
+    if err != nil {
+        return nil, "", err
+    }
+    if err := s.repo.Create(ctx, k); err != nil {
+        return nil, "", err
+    }
+    return k, secret, nil
+}
+
+func (s *Service) List(ctx context.Context, tenantID string) ([]domainapikey.APIKey, error) {
+    return s.repo.ListByTenant(ctx, tenantID)
+}
+
+// Revoke stops the tenant's key from authenticating.
+func (s *Service) Revoke(ctx context.Context, tenantID, id string) error {
+    return s.repo.Revoke(ctx, tenantID, id, s.now())
+}
+
+// Authenticate returns the key matching secret, or ErrNotFound or
+// ErrRevoked. The stored hash is compared in constant time. The key's
+// LastUsedAt is updated in the background, at most once per
+// LastUsedResolution; see Wait.
+func (s *Service) Authenticate(ctx context.Context, secret string) (*domainapikey.APIKey, error) {
+    if secret == "" {
+        return nil, ErrNotFound
+    }
+    hash := domainapikey.Hash(secret)
+    k, err := s.repo.FindByHash(ctx, hash)
+    if err != nil {
+        return nil, err
+    }
+    if subtle.ConstantTimeCompare([]byte(k.Hash), []byte(hash)) != 1 {
+        return nil, ErrNotFound
+    }
+    if k.Revoked() {
+        return nil, ErrRevoked
+    }
+    now := s.now()
+    if k.LastUsedAt == nil || now.Sub(*k.LastUsedAt) >= LastUsedResolution {
+        s.touches.Add(1)
+        go s.touchLastUsed(k.ID, now)
+    }
+    return k, nil
+}
+
+func (s *Service) touchLastUsed(id string, at time.Time) {
+    defer s.touches.Done()
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+    if err := s.repo.TouchLastUsed(ctx, id, at); err != nil {
+        s.logger.WarnContext(ctx, "recording api key use failed", "api_key_id", id, "error", err)
+    }
+}
+
+// Wait blocks until the LastUsedAt updates started so far are written.
+func (s *Service) Wait() {
+    s.touches.Wait()
+}
@@ -0,0 +1,88 @@
+package apikey_test
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    appapikey "backend/internal/application/apikey"
+    "backend/internal/infrastructure/memory"
+)
+
+// Test that a minted key authenticates to its tenant until it is revoked,
+// and that unknown secrets are rejected.
+func TestService_Authenticate(t *testing.T) {
+    ctx := context.Background()
+    svc := appapikey.NewService(memory.NewAPIKeyRepository())
+
+    k, secret, err := svc.Mint(ctx, "t1", "", "ci")
+    if err != nil {
+        t.Fatalf("mint: %v", err)
+    }
+    got, err := svc.Authenticate(ctx, secret)
+    if err != nil {
+        t.Fatalf("authenticate: %v", err)
+    }
+    if got.TenantID != "t1" || got.ID != k.ID {
+        t.Fatalf("expected key %s of t1, got %s of %s", k.ID, got.ID, got.TenantID)
+    }
+
+    if _, err := svc.Authenticate(ctx, secret+"x"); !errors.Is(err, appapikey.ErrNotFound) {
+        t.Fatalf("expected ErrNotFound, got %v", err)
+    }
+
+    if err := svc.Revoke(ctx, "t2", k.ID); !errors.Is(err, appapikey.ErrNotFound) {
+        t.Fatalf("expected ErrNotFound revoking another tenant's key, got %v", err)
+    }
+    if err := svc.Revoke(ctx, "t1", k.ID); err != nil {
+        t.Fatalf("revoke: %v", err)
+    }
+    if _, err := svc.Authenticate(ctx, secret); !errors.Is(err, appapikey.ErrRevoked) {
+        t.Fatalf("expected ErrRevoked, got %v", err)
+    }
+}
+
+// Test that minting requires a name.
+func TestService_Mint_NameRequired(t *testing.T) {
+    svc := appapikey.NewService(memory.NewAPIKeyRepository())
+    if _, _, err := svc.Mint(context.Background(), "t1", "u1", "  "); !errors.Is(err, appapikey.ErrNameRequired) {
+        t.Fatalf("expected ErrNameRequired, got %v", err)
+    }
+}
+
+// Test that authenticating records the key's last use in the background,
+// and that uses within LastUsedResolution do not record it again.
+func TestService_Authenticate_LastUsed(t *testing.T) {
+    ctx := context.Background()
+    svc := appapikey.NewService(memory.NewAPIKeyRepository())
+    k, secret, err := svc.Mint(ctx, "t1", "u1", "ci")
+    if err != nil {
+        t.Fatalf("mint: %v", err)
+    }
+    if k.UserID != "u1" || k.ActingUserID() != "u1" || k.LastUsedAt != nil {
+        t.Fatalf("expected an unused key acting as u1, got %+v", k)
+    }
+
+    lastUsed := func() *time.Time {
+        svc.Wait()
+        keys, err := svc.List(ctx, "t1")
+        if err != nil || len(keys) != 1 {
+            t.Fatalf("expected 1 key, got %d (%v)", len(keys), err)
+        }
+        return keys[0].LastUsedAt
+    }
+    if _, err := svc.Authenticate(ctx, secret); err != nil {
+        t.Fatalf("authenticate: %v", err)
+    }
+    first := lastUsed()
+    if first == nil {
+        t.Fatalf("expected LastUsedAt to be set")
+    }
+    if _, err := svc.Authenticate(ctx, secret); err != nil {
+        t.Fatalf("authenticate: %v", err)
+    }
+    if second := lastUsed(); second == nil || !second.Equal(*first) {
+        t.Fatalf("expected LastUsedAt to stay %v, got %v", first, second)
+    }
+}
@@ -0,0 +1,43 @@
+package attachment
+
+import (
+    "context"
+    "errors"
+    "io"
+
+    domaintask "backend/internal/domain/task"
+)
+
+var (
+    // ErrTaskNotFound is returned when the task does not exist for the
+    // tenant.
+    ErrTaskNotFound = errors.New("task not found")
+    // ErrEmptyFile is returned for an upload without content.
+    ErrEmptyFile = errors.New("file is empty")
+    // ErrTooLarge is returned for an upload over the service's size limit.
+    ErrTooLarge = errors.New("file is too large")
+    // ErrTypeNotAllowed is returned for an upload whose content is not of an
+    // allowed media type.
+    ErrTypeNotAllowed = errors.New("file type is not allowed")
+)
+
+// Repository defines persistence operations for task attachments.
+type Repository interface {
+    // Create stores a, returning ErrTaskNotFound when a.TaskID is not a task
+    // of a.TenantID.
+    Create(ctx context.Context, a *domaintask.TaskAttachment) error
+    // ListByTask returns the task's attachments, oldest first.
+    ListByTask(ctx context.Context, tenantID, taskID string) ([]domaintask.TaskAttachment, error)
+}
+
+// BlobStore keeps the content of uploaded files.
+type BlobStore interface {
+    // Put stores r under key and returns the URL the file is served at.
+    Put(ctx context.Context, key, contentType string, r io.Reader) (string, error)
+    // Delete removes the file stored under key, if any.
+    Delete(ctx context.Context, key string) error
+    // DeletePrefix removes every file whose key lies below prefix, such as
+    // all of a tenant's files under "tenantID/". Attachments are keyed
+    // tenantID/taskID/name.
+    DeletePrefix(ctx context.Context, prefix string) error
+}
@@ -0,0 +1,128 @@
+package attachment
+
+import (
+    "bufio"
+    "context"
+    "fmt"
+    "io"
+    "mime"
+    "net/http"
+    "path"
+    "strings"
+
+    domaintask "backend/internal/domain/task"
+
+    "github.com/google/uuid"
+)
+
+// DefaultMaxSize bounds uploads when no WithMaxSize option is given.
+const DefaultMaxSize = 10 << 20
+
+// DefaultAllowedTypes are the media types accepted when no WithAllowedTypes
+// option is given: common images, PDFs and plain text.
+var DefaultAllowedTypes = []string{"image/png", "image/jpeg", "image/gif", "image/webp", "application/pdf", "text/plain"}
+
+// File is an uploaded file. Size is the length of Content in bytes.
+type File struct {
+    Name    string
+    Size    int64
+    Content io.Reader
+}
+
+// Service implements attachment-related application use cases.
+type Service struct {
+    repo    Repository
+    blobs   BlobStore
+    maxSize int64
+    allowed map[string]bool
+}
+
+// Option configures a Service.
+type Option func(*Service)
+
+// WithMaxSize bounds uploads to n bytes; n <= 0 keeps DefaultMaxSize.
+func WithMaxSize(n int64) Option {
+    return func(s *Service) {
+        if n > 0 {
+            s.maxSize = n
+        }
+    }
+}
+
+// WithAllowedTypes replaces the accepted media types; none keeps
+// DefaultAllowedTypes.
+func WithAllowedTypes(types ...string) Option {
+    return func(s *Service) {
+        if len(types) > 0 {
+            s.allowed = allowedSet(types)
+        }
+    }
+}
+
+func NewService(repo Repository, blobs BlobStore, opts ...Option) *Service {
+    s := &Service{repo: repo, blobs: blobs, maxSize: DefaultMaxSize, allowed: allowedSet(DefaultAllowedTypes)}
+    for _, opt := range opts {
+        opt(s)
+    }
+    return s
+}
+
+func allowedSet(types []string) map[string]bool {
+    out := make(map[string]bool, len(types))
+    for _, t := range types {
+        out[strings.ToLower(strings.TrimSpace(t))] = true
+    }
+    return out
+}
+
+// Upload stores f in the blob store and records it as an attachment of the
+// tenant's task. The media type is detected from the content, not taken
+// from the client, and must be allowed. The stored file is removed again
+// when the attachment cannot be recorded, e.g. because the task does not
+// exist.
+func (s *Service) Upload(ctx context.Context, tenantID, taskID string, f File) (*domaintask.TaskAttachment, error) {
+    switch {
+    case f.Size <= 0:
+        return nil, ErrEmptyFile
+    case f.Size > s.maxSize:
+        return nil, fmt.Errorf("%w: limit is %d bytes", ErrTooLarge, s.maxSize)
+    }
+    content := bufio.NewReaderSize(io.LimitReader(f.Content, s.maxSize), 512)
+    head, _ := content.Peek(512)
+    fileType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+    if err != nil || !s.allowed[fileType] {
+        return nil, ErrTypeNotAllowed
+    }
+
+    key := path.Join(tenantID, taskID, uuid.NewString()+extension(f.Name))
+    url, err := s.blobs.Put(ctx, key, fileType, content)
+    if err != nil {
+        return nil, err
+    }
+    a := domaintask.NewAttachment(tenantID, taskID, strings.TrimLeft(path.Base("/"+f.Name), "/"), url, fileType, f.Size)
+    if err := s.repo.Create(ctx, a); err != nil {
+        _ = s.blobs.Delete(ctx, key)
+        return nil, err
+    }
+    return a, nil
+}
+
+// List returns the task's attachments, oldest first.
+func (s *Service) List(ctx context.Context, tenantID, taskID string) ([]domaintask.TaskAttachment, error) {
+    return s.repo.ListByTask(ctx, tenantID, taskID)
+}
+
+// extension returns name's extension, lower-cased, when it is short and
+// alphanumeric, so stored keys never carry client-chosen path characters.
+func extension(name string) string {
+    ext := strings.ToLower(path.Ext(name))
+    if len(ext) < 2 || len(ext) > 10 {
+        return ""
+    }
+    for _, r := range ext[1:] {
+        if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+            return ""
+        }
+    }
+    return ext
+}
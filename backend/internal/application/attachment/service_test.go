@@ -0,0 +1,100 @@
+package attachment_test
+
+import (
+    "bytes"
+    "context"
+    "errors"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+
+    appattachment "backend/internal/application/attachment"
+    domaintask "backend/internal/domain/task"
+    "backend/internal/infrastructure/blob"
+    "backend/internal/infrastructure/memory"
+)
+
+// pngHeader is enough of a PNG for content sniffing.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func newService(t *testing.T, opts ...appattachment.Option) (*appattachment.Service, *domaintask.Task, string) {
+    t.Helper()
+    tasks := memory.NewTaskRepository()
+    tk := domaintask.New("t1", "u1", "task", "", 5)
+    if err := tasks.Create(context.Background(), tk); err != nil {
+        t.Fatalf("seed: %v", err)
+    }
+    dir := t.TempDir()
+    svc := appattachment.NewService(memory.NewAttachmentRepository(tasks), blob.NewLocalStore(dir, "/uploads"), opts...)
+    return svc, tk, dir
+}
+
+func file(name string, content []byte) appattachment.File {
+    return appattachment.File{Name: name, Size: int64(len(content)), Content: bytes.NewReader(content)}
+}
+
+// Test that an upload is stored under the tenant and task, recorded with the
+// sniffed type, and listed on the task.
+func TestService_Upload(t *testing.T) {
+    svc, tk, dir := newService(t)
+    ctx := context.Background()
+
+    a, err := svc.Upload(ctx, "t1", tk.ID, file("Screen Shot.PNG", pngHeader))
+    if err != nil {
+        t.Fatalf("upload: %v", err)
+    }
+    if a.FileType != "image/png" || a.Name != "Screen Shot.PNG" || a.Size != int64(len(pngHeader)) {
+        t.Fatalf("unexpected attachment %+v", a)
+    }
+    prefix := "/uploads/t1/" + tk.ID + "/"
+    if !strings.HasPrefix(a.URL, prefix) || !strings.HasSuffix(a.URL, ".png") {
+        t.Fatalf("expected a .png URL below %s, got %q", prefix, a.URL)
+    }
+    stored, err := os.ReadFile(filepath.Join(dir, strings.TrimPrefix(a.URL, "/uploads/")))
+    if err != nil || !bytes.Equal(stored, pngHeader) {
+        t.Fatalf("expected the file content on disk, got %q (%v)", stored, err)
+    }
+
+    items, err := svc.List(ctx, "t1", tk.ID)
+    if err != nil || len(items) != 1 || items[0].ID != a.ID {
+        t.Fatalf("expected the attachment listed, got %+v (%v)", items, err)
+    }
+}
+
+// Test that empty, oversized and disallowed files are refused, whatever type
+// the name suggests, and that nothing is left behind for a missing task.
+func TestService_Upload_Rejected(t *testing.T) {
+    svc, tk, dir := newService(t, appattachment.WithMaxSize(64), appattachment.WithAllowedTypes("image/png"))
+    ctx := context.Background()
+
+    cases := []struct {
+        name string
+        f    appattachment.File
+        want error
+    }{
+        {"empty", file("a.png", nil), appattachment.ErrEmptyFile},
+        {"too large", file("a.png", append(pngHeader, make([]byte, 64)...)), appattachment.ErrTooLarge},
+        {"text named png", file("a.png", []byte("just text")), appattachment.ErrTypeNotAllowed},
+        {"pdf", file("a.pdf", []byte("%PDF-1.4\n")), appattachment.ErrTypeNotAllowed},
+    }
+    for _, tc := range cases {
+        if _, err := svc.Upload(ctx, "t1", tk.ID, tc.f); !errors.Is(err, tc.want) {
+            t.Fatalf("%s: expected %v, got %v", tc.name, tc.want, err)
+        }
+    }
+
+    if _, err := svc.Upload(ctx, "t2", tk.ID, file("a.png", pngHeader)); !errors.Is(err, appattachment.ErrTaskNotFound) {
+        t.Fatalf("expected ErrTaskNotFound for another tenant, got %v", err)
+    }
+    var files []string
+    filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+        if err == nil && !d.IsDir() {
+            files = append(files, path)
+        }
+        return nil
+    })
+    if len(files) != 0 {
+        t.Fatalf("expected no stored files, got %v", files)
+    }
+}
@@ -0,0 +1,33 @@
+package comment
+
+import (
+    "context"
+    "errors"
+
+    domaintask "backend/internal/domain/task"
+)
+
+var (
+    // ErrNotFound is returned when a comment does not exist on the task.
+    ErrNotFound = errors.New("comment not found")
+    // ErrTaskNotFound is returned when the task being commented on does not
+    // exist for the tenant.
+    ErrTaskNotFound = errors.New("task not found")
+    // ErrForbidden is returned when a user may not change a comment.
+    ErrForbidden = errors.New("only the author or an admin can edit this comment")
+    // ErrInternalForbidden is returned when a user who is not an admin posts
+    // an internal comment.
+    ErrInternalForbidden = errors.New("only an admin can post internal comments")
+)
+
+// Repository defines persistence operations for task comments.
+type Repository interface {
+    // Create stores c, returning ErrTaskNotFound when c.TaskID is not a task
+    // of c.TenantID.
+    Create(ctx context.Context, c *domaintask.TaskComment) error
+    // ListByTask returns the task's comments, oldest first.
+    ListByTask(ctx context.Context, tenantID, taskID string) ([]domaintask.TaskComment, error)
+    Get(ctx context.Context, tenantID, taskID, id string) (*domaintask.TaskComment, error)
+    // UpdateContent replaces the content and edit time of an existing comment.
+    UpdateContent(ctx context.Context, c *domaintask.TaskComment) error
+}
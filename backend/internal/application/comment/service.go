@@ -0,0 +1,78 @@
+package comment
+
+import (
+    "context"
+    "time"
+
+    domaintask "backend/internal/domain/task"
+    "backend/internal/pkg/sanitize"
+)
+
+// Service implements comment-related application use cases.
+type Service struct {
+    repo Repository
+}
+
+func NewService(repo Repository) *Service {
+    return &Service{repo: repo}
+}
+
+// Add posts a comment by author on the tenant's task. Content is sanitized
+// like task descriptions before it is validated. Only admins may post
+// internal comments.
+func (s *Service) Add(ctx context.Context, tenantID, taskID, author string, isAdmin bool, content string, internal bool) (*domaintask.TaskComment, error) {
+    if internal && !isAdmin {
+        return nil, ErrInternalForbidden
+    }
+    content = sanitize.HTML(content)
+    if err := domaintask.ValidateCommentContent(content); err != nil {
+        return nil, err
+    }
+    c := domaintask.NewComment(tenantID, taskID, author, content, internal)
+    if err := s.repo.Create(ctx, c); err != nil {
+        return nil, err
+    }
+    return c, nil
+}
+
+// List returns the task's comments, oldest first. Internal comments are
+// left out unless the caller is an admin.
+func (s *Service) List(ctx context.Context, tenantID, taskID string, isAdmin bool) ([]domaintask.TaskComment, error) {
+    items, err := s.repo.ListByTask(ctx, tenantID, taskID)
+    if err != nil || isAdmin {
+        return items, err
+    }
+    public := items[:0]
+    for _, c := range items {
+        if !c.Internal {
+            public = append(public, c)
+        }
+    }
+    return public, nil
+}
+
+// Edit replaces a comment's content and stamps EditedAt, keeping CreatedAt.
+// Only the author, or an admin, may edit; anyone else gets ErrForbidden.
+func (s *Service) Edit(ctx context.Context, tenantID, taskID, commentID, editorID string, isAdmin bool, content string) (*domaintask.TaskComment, error) {
+    content = sanitize.HTML(content)
+    if err := domaintask.ValidateCommentContent(content); err != nil {
+        return nil, err
+    }
+    c, err := s.repo.Get(ctx, tenantID, taskID, commentID)
+    if err != nil {
+        return nil, err
+    }
+    if c.Internal && !isAdmin {
+        return nil, ErrNotFound
+    }
+    if c.Author != editorID && !isAdmin {
+        return nil, ErrForbidden
+    }
+    now := time.Now().UTC()
+    c.Content = content
+    c.EditedAt = &now
+    if err := s.repo.UpdateContent(ctx, c); err != nil {
+        return nil, err
+    }
+    return c, nil
+}
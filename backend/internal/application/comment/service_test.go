@@ -0,0 +1,104 @@
+package comment_test
+
+import (
+    "context"
+    "errors"
+    "testing"
+
+    appcomment "backend/internal/application/comment"
+    domaintask "backend/internal/domain/task"
+    "backend/internal/infrastructure/memory"
+)
+
+// seed returns a comment service over a tenant t1 task and that task's id.
+func seed(t *testing.T) (*appcomment.Service, string) {
+    t.Helper()
+    tasks := memory.NewTaskRepository()
+    tk := domaintask.New("t1", "u1", "task", "", 5)
+    if err := tasks.Create(context.Background(), tk); err != nil {
+        t.Fatalf("create task: %v", err)
+    }
+    return appcomment.NewService(memory.NewCommentRepository(tasks)), tk.ID
+}
+
+// Test that the author can edit a comment, which stamps EditedAt and keeps
+// CreatedAt.
+func TestService_Edit_ByAuthor(t *testing.T) {
+    ctx := context.Background()
+    svc, taskID := seed(t)
+    c, err := svc.Add(ctx, "t1", taskID, "u1", false, "teh typo", false)
+    if err != nil {
+        t.Fatalf("add: %v", err)
+    }
+
+    edited, err := svc.Edit(ctx, "t1", taskID, c.ID, "u1", false, "the typo")
+    if err != nil {
+        t.Fatalf("edit: %v", err)
+    }
+    if edited.Content != "the typo" || edited.EditedAt == nil {
+        t.Fatalf("expected new content and EditedAt, got %+v", edited)
+    }
+    if !edited.CreatedAt.Equal(c.CreatedAt) {
+        t.Fatalf("expected CreatedAt %v to be preserved, got %v", c.CreatedAt, edited.CreatedAt)
+    }
+    items, _ := svc.List(ctx, "t1", taskID, false)
+    if len(items) != 1 || items[0].Content != "the typo" {
+        t.Fatalf("expected stored edit, got %+v", items)
+    }
+}
+
+// Test that only the author or an admin may edit.
+func TestService_Edit_Authorization(t *testing.T) {
+    ctx := context.Background()
+    svc, taskID := seed(t)
+    c, _ := svc.Add(ctx, "t1", taskID, "u1", false, "original", false)
+
+    if _, err := svc.Edit(ctx, "t1", taskID, c.ID, "u2", false, "hijacked"); !errors.Is(err, appcomment.ErrForbidden) {
+        t.Fatalf("expected ErrForbidden, got %v", err)
+    }
+    if _, err := svc.Edit(ctx, "t1", taskID, c.ID, "root", true, "moderated"); err != nil {
+        t.Fatalf("admin edit: %v", err)
+    }
+    if _, err := svc.Edit(ctx, "t2", taskID, c.ID, "u1", false, "x"); !errors.Is(err, appcomment.ErrNotFound) {
+        t.Fatalf("expected ErrNotFound from another tenant, got %v", err)
+    }
+}
+
+// Test that comments cannot be added to unknown tasks.
+func TestService_Add_UnknownTask(t *testing.T) {
+    svc, _ := seed(t)
+    if _, err := svc.Add(context.Background(), "t1", "missing", "u1", false, "hi", false); !errors.Is(err, appcomment.ErrTaskNotFound) {
+        t.Fatalf("expected ErrTaskNotFound, got %v", err)
+    }
+}
+
+// Test that internal comments are hidden from regular users, who can
+// neither post nor edit them, and visible to admins.
+func TestService_InternalComments(t *testing.T) {
+    ctx := context.Background()
+    svc, taskID := seed(t)
+    if _, err := svc.Add(ctx, "t1", taskID, "u1", false, "public note", false); err != nil {
+        t.Fatalf("add public: %v", err)
+    }
+    if _, err := svc.Add(ctx, "t1", taskID, "u1", false, "sneaky", true); !errors.Is(err, appcomment.ErrInternalForbidden) {
+        t.Fatalf("expected ErrInternalForbidden, got %v", err)
+    }
+    internal, err := svc.Add(ctx, "t1", taskID, "root", true, "customer is churning", true)
+    if err != nil {
+        t.Fatalf("add internal: %v", err)
+    }
+
+    items, err := svc.List(ctx, "t1", taskID, false)
+    if err != nil {
+        t.Fatalf("list: %v", err)
+    }
+    if len(items) != 1 || items[0].Internal {
+        t.Fatalf("expected only the public comment, got %+v", items)
+    }
+    if items, _ := svc.List(ctx, "t1", taskID, true); len(items) != 2 {
+        t.Fatalf("expected an admin to see 2 comments, got %+v", items)
+    }
+    if _, err := svc.Edit(ctx, "t1", taskID, internal.ID, "u1", false, "x"); !errors.Is(err, appcomment.ErrNotFound) {
+        t.Fatalf("expected ErrNotFound editing an internal comment, got %v", err)
+    }
+}
@@ -0,0 +1,26 @@
+package featureflag
+
+import (
+    "context"
+    "errors"
+
+    domainfeatureflag "backend/internal/domain/featureflag"
+)
+
+var (
+    // ErrNotFound is returned for a flag the tenant never saved.
+    ErrNotFound = errors.New("feature flag not found")
+    // ErrInvalidName is returned for a flag name outside [a-z0-9._-], or
+    // longer than 64 characters.
+    ErrInvalidName = errors.New("feature flag name must be 1-64 characters of a-z, 0-9, '.', '_' or '-'")
+)
+
+// Repository defines persistence operations for feature flags.
+type Repository interface {
+    // Get returns the tenant's flag, or ErrNotFound.
+    Get(ctx context.Context, tenantID, name string) (*domainfeatureflag.FeatureFlag, error)
+    // ListByTenant returns the tenant's saved flags ordered by name.
+    ListByTenant(ctx context.Context, tenantID string) ([]domainfeatureflag.FeatureFlag, error)
+    // Save creates or replaces the flag.
+    Save(ctx context.Context, f *domainfeatureflag.FeatureFlag) error
+}
@@ -0,0 +1,99 @@
+package featureflag
+
+import (
+    "context"
+    "errors"
+    "sync"
+    "time"
+
+    domainfeatureflag "backend/internal/domain/featureflag"
+)
+
+// DefaultCacheTTL is how long IsEnabled serves a flag from memory.
+const DefaultCacheTTL = 30 * time.Second
+
+// maxNameLength bounds flag names; it matches the name column.
+const maxNameLength = 64
+
+// Service implements feature flag use cases. IsEnabled answers from an
+// in-memory cache, so a change made through another instance is seen here
+// within the TTL; Set drops this instance's entry at once.
+type Service struct {
+    repo Repository
+    ttl  time.Duration
+    now  func() time.Time
+
+    mu    sync.Mutex
+    cache map[flagKey]cachedFlag
+}
+
+type flagKey struct {
+    tenantID, name string
+}
+
+type cachedFlag struct {
+    enabled bool
+    expires time.Time
+}
+
+func NewService(repo Repository) *Service {
+    return &Service{repo: repo, ttl: DefaultCacheTTL, now: time.Now, cache: make(map[flagKey]cachedFlag)}
+}
+
+// IsEnabled reports whether the tenant has the flag on. Unknown flags are
+// off.
+func (s *Service) IsEnabled(ctx context.Context, tenantID, name string) (bool, error) {
+    key := flagKey{tenantID, name}
+    s.mu.Lock()
+    c, ok := s.cache[key]
+    s.mu.Unlock()
+    if ok && s.now().Before(c.expires) {
+        return c.enabled, nil
+    }
+
+    f, err := s.repo.Get(ctx, tenantID, name)
+    switch {
+    case errors.Is(err, ErrNotFound):
+        f = &domainfeatureflag.FeatureFlag{}
+    case err != nil:
+        return false, err
+    }
+    s.mu.Lock()
+    s.cache[key] = cachedFlag{enabled: f.Enabled, expires: s.now().Add(s.ttl)}
+    s.mu.Unlock()
+    return f.Enabled, nil
+}
+
+// List returns the tenant's saved flags ordered by name.
+func (s *Service) List(ctx context.Context, tenantID string) ([]domainfeatureflag.FeatureFlag, error) {
+    return s.repo.ListByTenant(ctx, tenantID)
+}
+
+// Set turns the tenant's flag on or off and returns it.
+func (s *Service) Set(ctx context.Context, tenantID, name string, enabled bool) (*domainfeatureflag.FeatureFlag, error) {
+    if !validName(name) {
+        return nil, ErrInvalidName
+    }
+    f := &domainfeatureflag.FeatureFlag{Name: name, TenantID: tenantID, Enabled: enabled, UpdatedAt: s.now().UTC()}
+    if err := s.repo.Save(ctx, f); err != nil {
+        return nil, err
+    }
+    s.mu.Lock()
+    delete(s.cache, flagKey{tenantID, name})
+    s.mu.Unlock()
+    return f, nil
+}
+
+func validName(name string) bool {
+    if name == "" || len(name) > maxNameLength {
+        return false
+    }
+    for _, r := range name {
+        switch {
+        case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '.', r == '_', r == '-':
+        default:
+            return false
+        }
+    }
+    return true
+}
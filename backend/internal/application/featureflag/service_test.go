@@ -0,0 +1,115 @@
+package featureflag
+
+import (
+    "context"
+    "errors"
+    "strings"
+    "testing"
+    "time"
+
+    domainfeatureflag "backend/internal/domain/featureflag"
+)
+
+// countingRepo is a Repository over a map that counts Get calls.
+type countingRepo struct {
+    flags map[string]domainfeatureflag.FeatureFlag // tenantID + "/" + name -> flag
+    gets  int
+}
+
+func (r *countingRepo) Get(_ context.Context, tenantID, name string) (*domainfeatureflag.FeatureFlag, error) {
+    r.gets++
+    f, ok := r.flags[tenantID+"/"+name]
+    if !ok {
+        return nil, ErrNotFound
+    }
+    return &f, nil
+}
+
+func (r *countingRepo) ListByTenant(context.Context, string) ([]domainfeatureflag.FeatureFlag, error) {
+    return nil, nil
+}
+
+func (r *countingRepo) Save(_ context.Context, f *domainfeatureflag.FeatureFlag) error {
+    r.flags[f.TenantID+"/"+f.Name] = *f
+    return nil
+}
+
+// newTestService returns a service over repo whose clock reads *now.
+func newTestService(repo Repository, now *time.Time) *Service {
+    s := NewService(repo)
+    s.now = func() time.Time { return *now }
+    return s
+}
+
+// Test that a saved flag is on for its tenant only and that unknown flags are
+// off.
+func TestService_IsEnabled(t *testing.T) {
+    ctx := context.Background()
+    now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+    s := newTestService(&countingRepo{flags: map[string]domainfeatureflag.FeatureFlag{}}, &now)
+    if _, err := s.Set(ctx, "t1", "graphql", true); err != nil {
+        t.Fatalf("set: %v", err)
+    }
+    if _, err := s.Set(ctx, "t1", "exports", false); err != nil {
+        t.Fatalf("set: %v", err)
+    }
+
+    for _, tc := range []struct {
+        tenantID, name string
+        want           bool
+    }{
+        {"t1", "graphql", true},
+        {"t1", "exports", false},
+        {"t1", "unknown", false},
+        {"t2", "graphql", false},
+    } {
+        on, err := s.IsEnabled(ctx, tc.tenantID, tc.name)
+        if err != nil {
+            t.Fatalf("is enabled: %v", err)
+        }
+        if on != tc.want {
+            t.Fatalf("%s/%s: expected %v, got %v", tc.tenantID, tc.name, tc.want, on)
+        }
+    }
+}
+
+// Test that IsEnabled reads a flag once per TTL, sees a change made behind
+// its back only after the entry expires, and sees its own Set at once.
+func TestService_IsEnabled_Cache(t *testing.T) {
+    ctx := context.Background()
+    now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+    repo := &countingRepo{flags: map[string]domainfeatureflag.FeatureFlag{}}
+    s := newTestService(repo, &now)
+
+    if on, _ := s.IsEnabled(ctx, "t1", "graphql"); on {
+        t.Fatalf("expected the unknown flag off")
+    }
+    // Another instance turns the flag on.
+    repo.flags["t1/graphql"] = domainfeatureflag.FeatureFlag{Name: "graphql", TenantID: "t1", Enabled: true}
+    now = now.Add(DefaultCacheTTL - time.Second)
+    if on, _ := s.IsEnabled(ctx, "t1", "graphql"); on || repo.gets != 1 {
+        t.Fatalf("expected the cached answer within the TTL, got %v after %d reads", on, repo.gets)
+    }
+    now = now.Add(time.Second)
+    if on, _ := s.IsEnabled(ctx, "t1", "graphql"); !on || repo.gets != 2 {
+        t.Fatalf("expected the flag re-read once the entry expired, got %v after %d reads", on, repo.gets)
+    }
+
+    if _, err := s.Set(ctx, "t1", "graphql", false); err != nil {
+        t.Fatalf("set: %v", err)
+    }
+    if on, _ := s.IsEnabled(ctx, "t1", "graphql"); on {
+        t.Fatalf("expected Set to take effect at once")
+    }
+}
+
+// Test that Set refuses names outside the allowed alphabet.
+func TestService_Set_InvalidName(t *testing.T) {
+    now := time.Now()
+    s := newTestService(&countingRepo{flags: map[string]domainfeatureflag.FeatureFlag{}}, &now)
+    for _, name := range []string{"", "GraphQL", "with space", strings.Repeat("a", maxNameLength+1)} {
+        if _, err := s.Set(context.Background(), "t1", name, true); !errors.Is(err, ErrInvalidName) {
+            t.Fatalf("%q: expected %v, got %v", name, ErrInvalidName, err)
+        }
+    }
+}
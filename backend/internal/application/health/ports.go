@@ -0,0 +1,19 @@
+package health
+
+import "context"
+
+// Checker reports whether one subsystem is usable; a nil error means up.
+type Checker interface {
+    Check(ctx context.Context) error
+}
+
+// CheckerFunc adapts a function to Checker.
+type CheckerFunc func(ctx context.Context) error
+
+func (f CheckerFunc) Check(ctx context.Context) error {
+    return f(ctx)
+}
+
+// ReadinessChecker is a Checker for a dependency requests cannot be served
+// without, such as the database; GET /readyz fails while any is down.
+type ReadinessChecker = Checker
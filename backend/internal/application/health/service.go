@@ -0,0 +1,123 @@
+package health
+
+import (
+    "context"
+    "sync"
+    "time"
+)
+
+// Subsystem and overall statuses.
+const (
+    StatusUp       = "up"
+    StatusDown     = "down"
+    StatusDegraded = "degraded"
+)
+
+// DefaultTimeout bounds each check when NewService is given none.
+const DefaultTimeout = 2 * time.Second
+
+// CheckResult is one subsystem's status and how long its check took.
+type CheckResult struct {
+    Status    string `json:"status"`
+    LatencyMS int64  `json:"latencyMs"`
+    Error     string `json:"error,omitempty"`
+}
+
+// Report is the outcome of every check. Status is StatusUp when all
+// subsystems are up and StatusDegraded otherwise.
+type Report struct {
+    Status string                 `json:"status"`
+    Checks map[string]CheckResult `json:"checks"`
+}
+
+// Service runs the registered subsystem checks.
+type Service struct {
+    timeout  time.Duration
+    checkers map[string]Checker
+}
+
+// NewService returns a Service giving each check at most timeout
+// (DefaultTimeout when not positive).
+func NewService(timeout time.Duration) *Service {
+    if timeout <= 0 {
+        timeout = DefaultTimeout
+    }
+    return &Service{timeout: timeout, checkers: map[string]Checker{}}
+}
+
+// Register adds the check for the subsystem called name, replacing any
+// earlier one. It must not be called once checks have started.
+func (s *Service) Register(name string, c Checker) {
+    s.checkers[name] = c
+}
+
+// Check runs every check concurrently, each under its own timeout. A check
+// that fails or runs out of time reports its subsystem down.
+func (s *Service) Check(ctx context.Context) Report {
+    rep := Report{Status: StatusUp, Checks: make(map[string]CheckResult, len(s.checkers))}
+    var (
+        mu sync.Mutex
+        wg sync.WaitGroup
+    )
+    for name, c := range s.checkers {
+        wg.Add(1)
+        go func(name string, c Checker) {
+            defer wg.Done()
+            res := s.run(ctx, c)
+            mu.Lock()
+            defer mu.Unlock()
+            rep.Checks[name] = res
+            if res.Status != StatusUp {
+                rep.Status = StatusDegraded
+            }
+        }(name, c)
+    }
+    wg.Wait()
+    return rep
+}
+
+// run checks one subsystem. Checks that ignore their context are abandoned
+// when the timeout passes.
+func (s *Service) run(ctx context.Context, c Checker) CheckResult {
+    ctx, cancel := context.WithTimeout(ctx, s.timeout)
+    defer cancel()
+    start := time.Now()
+    done := make(chan error, 1)
+    go func() { done <- c.Check(ctx) }()
+    var err error
+    select {
+    case err = <-done:
+    case <-ctx.Done():
+        err = ctx.Err()
+    }
+    res := CheckResult{Status: StatusUp, LatencyMS: time.Since(start).Milliseconds()}
+    if err != nil {
+        res.Status = StatusDown
+        res.Error = err.Error()
+    }
+    return res
+}
+
+// checkOK is what Readiness reports for a dependency that answered.
+const checkOK = "ok"
+
+// Readiness is the outcome of the readiness checks: Ready when every
+// dependency answered, and per dependency "ok" or why it did not.
+type Readiness struct {
+    Ready  bool              `json:"ready"`
+    Checks map[string]string `json:"checks"`
+}
+
+// Ready runs the registered checks like Check, condensed for readiness
+// probes.
+func (s *Service) Ready(ctx context.Context) Readiness {
+    rep := s.Check(ctx)
+    r := Readiness{Ready: rep.Status == StatusUp, Checks: make(map[string]string, len(rep.Checks))}
+    for name, res := range rep.Checks {
+        r.Checks[name] = checkOK
+        if res.Status != StatusUp {
+            r.Checks[name] = res.Error
+        }
+    }
+    return r
+}
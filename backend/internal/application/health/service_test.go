@@ -0,0 +1,66 @@
+package health_test
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    apphealth "backend/internal/application/health"
+)
+
+func up(context.Context) error { return nil }
+
+// Test that one subsystem down degrades the overall status while the others
+// still report up.
+func TestService_Check_Degraded(t *testing.T) {
+    svc := apphealth.NewService(time.Second)
+    svc.Register("db", apphealth.CheckerFunc(up))
+    svc.Register("cache", apphealth.CheckerFunc(func(context.Context) error { return errors.New("connection refused") }))
+
+    rep := svc.Check(context.Background())
+    if rep.Status != apphealth.StatusDegraded {
+        t.Fatalf("expected status %q, got %q", apphealth.StatusDegraded, rep.Status)
+    }
+    if got := rep.Checks["db"]; got.Status != apphealth.StatusUp {
+        t.Fatalf("expected db up, got %+v", got)
+    }
+    if got := rep.Checks["cache"]; got.Status != apphealth.StatusDown || got.Error != "connection refused" {
+        t.Fatalf("expected cache down with its error, got %+v", got)
+    }
+}
+
+// Test that a check that outlives the timeout reports its subsystem down
+// without holding up the report, and that the checks run concurrently.
+func TestService_Check_Timeout(t *testing.T) {
+    svc := apphealth.NewService(50 * time.Millisecond)
+    hang := apphealth.CheckerFunc(func(context.Context) error {
+        time.Sleep(time.Second)
+        return nil
+    })
+    svc.Register("a", hang)
+    svc.Register("b", hang)
+    svc.Register("c", apphealth.CheckerFunc(up))
+
+    start := time.Now()
+    rep := svc.Check(context.Background())
+    if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+        t.Fatalf("expected the checks to give up after the timeout, took %v", elapsed)
+    }
+    for _, name := range []string{"a", "b"} {
+        if got := rep.Checks[name]; got.Status != apphealth.StatusDown {
+            t.Fatalf("expected %s down, got %+v", name, got)
+        }
+    }
+    if rep.Status != apphealth.StatusDegraded || rep.Checks["c"].Status != apphealth.StatusUp {
+        t.Fatalf("expected c up in a degraded report, got %+v", rep)
+    }
+}
+
+// Test that a service without checks reports up.
+func TestService_Check_Empty(t *testing.T) {
+    rep := apphealth.NewService(0).Check(context.Background())
+    if rep.Status != apphealth.StatusUp || len(rep.Checks) != 0 {
+        t.Fatalf("expected up with no checks, got %+v", rep)
+    }
+}
@@ -0,0 +1,74 @@
+package job
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "time"
+)
+
+// ErrNotFound is returned by Store.Get for an unknown or expired job.
+var ErrNotFound = errors.New("job not found")
+
+// Status is where a job is in its life cycle.
+type Status string
+
+const (
+    StatusQueued     Status = "queued"
+    StatusProcessing Status = "processing"
+    StatusDone       Status = "done"
+    StatusFailed     Status = "failed"
+)
+
+// Job is the status of one enqueued job as clients poll it. Processed and
+// Total are set by handlers that report progress; Result holds a done job's
+// output and Error a failed job's reason.
+type Job struct {
+    ID        string          `json:"id"`
+    Type      string          `json:"type"`
+    TenantID  string          `json:"tenantId,omitempty"`
+    Status    Status          `json:"status"`
+    Processed int             `json:"processed,omitempty"`
+    Total     int             `json:"total,omitempty"`
+    Result    json.RawMessage `json:"result,omitempty"`
+    Error     string          `json:"error,omitempty"`
+    CreatedAt time.Time       `json:"createdAt"`
+    UpdatedAt time.Time       `json:"updatedAt"`
+}
+
+// JobRunner queues side effects such as webhook delivery, email and exports
+// to run outside the HTTP request. Enqueue returns the id to poll the job by.
+type JobRunner interface {
+    Enqueue(ctx context.Context, jobType string, payload []byte, opts ...EnqueueOption) (jobID string, err error)
+}
+
+// Store reads the status of enqueued jobs.
+type Store interface {
+    Get(ctx context.Context, id string) (*Job, error)
+}
+
+// EnqueueOption sets optional fields of a job being enqueued.
+type EnqueueOption func(*Job)
+
+// ForTenant records the tenant a job belongs to; only that tenant can poll
+// it.
+func ForTenant(tenantID string) EnqueueOption {
+    return func(j *Job) { j.TenantID = tenantID }
+}
+
+// ProgressFunc records that processed of total units of a job are done.
+type ProgressFunc func(ctx context.Context, processed, total int) error
+
+// JobHandler runs the jobs of one type. The returned result is stored as the
+// job's Result; an error marks the job failed. Handlers may call progress as
+// they go.
+type JobHandler interface {
+    Handle(ctx context.Context, j Job, payload []byte, progress ProgressFunc) (json.RawMessage, error)
+}
+
+// HandlerFunc adapts a function to JobHandler.
+type HandlerFunc func(ctx context.Context, j Job, payload []byte, progress ProgressFunc) (json.RawMessage, error)
+
+func (f HandlerFunc) Handle(ctx context.Context, j Job, payload []byte, progress ProgressFunc) (json.RawMessage, error) {
+    return f(ctx, j, payload, progress)
+}
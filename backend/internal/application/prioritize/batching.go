@@ -0,0 +1,121 @@
+package prioritize
+
+import (
+    "context"
+    "fmt"
+    "log/slog"
+    "sync"
+    "time"
+)
+
+// Batching controls how Rank splits open tasks into provider calls.
+// Non-positive Size or Concurrency fall back to DefaultBatching.
+type Batching struct {
+    // Size is the most tasks sent in one provider call.
+    Size int
+    // Concurrency is the most provider calls in flight at once.
+    Concurrency int
+    // RetryBackoff is the wait before a failed batch's single retry.
+    RetryBackoff time.Duration
+}
+
+// DefaultBatching sends 25 tasks per call, four calls at a time.
+func DefaultBatching() Batching {
+    return Batching{Size: 25, Concurrency: 4, RetryBackoff: 500 * time.Millisecond}
+}
+
+// withDefaults replaces non-positive sizes with the defaults.
+func (b Batching) withDefaults() Batching {
+    d := DefaultBatching()
+    if b.Size <= 0 {
+        b.Size = d.Size
+    }
+    if b.Concurrency <= 0 {
+        b.Concurrency = d.Concurrency
+    }
+    if b.RetryBackoff < 0 {
+        b.RetryBackoff = 0
+    }
+    return b
+}
+
+// WithBatching returns a copy of s that calls its provider as b describes.
+func (s *Service) WithBatching(b Batching) *Service {
+    cp := *s
+    cp.Batching = b
+    return &cp
+}
+
+// ScoringReport tells callers how the provider fared in a Rank call.
+type ScoringReport struct {
+    // Batches is how many provider calls were planned and FailedBatches how
+    // many still failed after their retry.
+    Batches       int `json:"batches"`
+    FailedBatches int `json:"failedBatches"`
+    // Fallback lists the open tasks scored by the rules because their batch
+    // failed or the provider left them out.
+    Fallback []string `json:"fallback"`
+    // Errors holds one message per failed batch.
+    Errors []string `json:"errors,omitempty"`
+}
+
+// Merge adds o's counts and lists to r.
+func (r *ScoringReport) Merge(o ScoringReport) {
+    r.Batches += o.Batches
+    r.FailedBatches += o.FailedBatches
+    r.Fallback = append(r.Fallback, o.Fallback...)
+    r.Errors = append(r.Errors, o.Errors...)
+}
+
+// scoreBatches sends summaries to the provider in batches, at most
+// Concurrency at a time, and returns the successful answers together with
+// the report. Each failed batch is retried once after RetryBackoff.
+func (s *Service) scoreBatches(ctx context.Context, summaries []TaskSummary) ([]ScoredTask, ScoringReport) {
+    b := s.Batching.withDefaults()
+    var batches [][]TaskSummary
+    for start := 0; start < len(summaries); start += b.Size {
+        batches = append(batches, summaries[start:min(start+b.Size, len(summaries))])
+    }
+
+    results := make([][]ScoredTask, len(batches))
+    errs := make([]error, len(batches))
+    sem := make(chan struct{}, b.Concurrency)
+    var wg sync.WaitGroup
+    for i, batch := range batches {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            sem <- struct{}{}
+            defer func() { <-sem }()
+            results[i], errs[i] = s.scoreBatch(ctx, batch, b.RetryBackoff)
+        }()
+    }
+    wg.Wait()
+
+    report := ScoringReport{Batches: len(batches), Fallback: []string{}}
+    var out []ScoredTask
+    for i, err := range errs {
+        if err != nil {
+            report.FailedBatches++
+            report.Errors = append(report.Errors, fmt.Sprintf("batch %d of %d: %v", i+1, len(batches), err))
+            slog.Default().WarnContext(ctx, "ai scoring failed, using rules", "batch", i+1, "tasks", len(batches[i]), "error", err)
+            continue
+        }
+        out = append(out, results[i]...)
+    }
+    return out, report
+}
+
+// scoreBatch calls the provider, retrying once after backoff on failure.
+func (s *Service) scoreBatch(ctx context.Context, batch []TaskSummary, backoff time.Duration) ([]ScoredTask, error) {
+    scored, err := s.Provider.ScoreTasks(ctx, batch)
+    if err == nil {
+        return scored, nil
+    }
+    select {
+    case <-ctx.Done():
+        return nil, err
+    case <-time.After(backoff):
+    }
+    return s.Provider.ScoreTasks(ctx, batch)
+}
@@ -0,0 +1,139 @@
+package prioritize
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "slices"
+    "sync"
+    "testing"
+    "time"
+
+    domaintask "backend/internal/domain/task"
+)
+
+// flakyProvider scores every task 50 after a delay, fails calls for
+// batches containing a task in failAlways, fails the first call for those
+// containing a task in failOnce, and tracks how many calls overlap.
+type flakyProvider struct {
+    delay      time.Duration
+    failAlways map[string]bool
+    failOnce   map[string]bool
+    skip       map[string]bool
+
+    mu          sync.Mutex
+    calls       int
+    sizes       []int
+    inFlight    int
+    maxInFlight int
+}
+
+func (p *flakyProvider) ScoreTasks(_ context.Context, tasks []TaskSummary) ([]ScoredTask, error) {
+    p.mu.Lock()
+    p.calls++
+    p.sizes = append(p.sizes, len(tasks))
+    p.inFlight++
+    p.maxInFlight = max(p.maxInFlight, p.inFlight)
+    var fail bool
+    for _, t := range tasks {
+        if p.failAlways[t.ID] {
+            fail = true
+        }
+        if p.failOnce[t.ID] {
+            delete(p.failOnce, t.ID)
+            fail = true
+        }
+    }
+    p.mu.Unlock()
+
+    time.Sleep(p.delay)
+
+    p.mu.Lock()
+    p.inFlight--
+    p.mu.Unlock()
+    if fail {
+        return nil, errors.New("rate limited")
+    }
+    var out []ScoredTask
+    for _, t := range tasks {
+        if !p.skip[t.ID] {
+            out = append(out, ScoredTask{TaskID: t.ID, Score: 50, Reason: "ai"})
+        }
+    }
+    return out, nil
+}
+
+func openTasks(n int) []domaintask.Task {
+    tasks := make([]domaintask.Task, n)
+    for i := range tasks {
+        tasks[i] = domaintask.Task{ID: fmt.Sprintf("t%02d", i), Priority: 5, Status: domaintask.StatusTodo, CreatedAt: testNow}
+    }
+    return tasks
+}
+
+// Test that tasks are split into batches of Size with at most Concurrency
+// calls in flight, and every task gets the provider's score.
+func TestService_RankWithReport_BoundedBatches(t *testing.T) {
+    p := &flakyProvider{delay: 20 * time.Millisecond}
+    s := newTestService().WithProvider(p).WithBatching(Batching{Size: 3, Concurrency: 2})
+
+    ranked, report := s.RankWithReport(context.Background(), openTasks(10))
+    if report.Batches != 4 || report.FailedBatches != 0 || len(report.Fallback) != 0 {
+        t.Fatalf("expected 4 clean batches, got %+v", report)
+    }
+    slices.Sort(p.sizes)
+    if !slices.Equal(p.sizes, []int{1, 3, 3, 3}) {
+        t.Fatalf("expected batch sizes 1,3,3,3, got %v", p.sizes)
+    }
+    if p.maxInFlight > 2 {
+        t.Fatalf("expected at most 2 concurrent calls, got %d", p.maxInFlight)
+    }
+    for _, r := range ranked {
+        if r.Score != 50 {
+            t.Fatalf("expected every task scored by the provider, got %+v", r)
+        }
+    }
+}
+
+// Test that a batch failing once is retried and succeeds, while a batch
+// that keeps failing falls back to the rules and is reported.
+func TestService_RankWithReport_RetryAndFallback(t *testing.T) {
+    p := &flakyProvider{
+        delay:      time.Millisecond,
+        failOnce:   map[string]bool{"t00": true},
+        failAlways: map[string]bool{"t03": true},
+        skip:       map[string]bool{"t07": true},
+    }
+    s := newTestService().WithProvider(p).WithBatching(Batching{Size: 3, Concurrency: 4})
+
+    ranked, report := s.RankWithReport(context.Background(), openTasks(9))
+    if report.Batches != 3 || report.FailedBatches != 1 || len(report.Errors) != 1 {
+        t.Fatalf("expected 1 of 3 batches to fail, got %+v", report)
+    }
+    // Two calls for the retried batch, two for the failing one, one for the last.
+    if p.calls != 5 {
+        t.Fatalf("expected 5 provider calls, got %d", p.calls)
+    }
+    slices.Sort(report.Fallback)
+    if want := []string{"t03", "t04", "t05", "t07"}; !slices.Equal(report.Fallback, want) {
+        t.Fatalf("expected fallback %v, got %v", want, report.Fallback)
+    }
+    rules, _ := s.Score(context.Background(), openTasks(1)[0])
+    for _, r := range ranked {
+        want := 50.0
+        if slices.Contains(report.Fallback, r.Task.ID) {
+            want = rules
+        }
+        if r.Score != want {
+            t.Fatalf("task %s: expected score %v, got %v", r.Task.ID, want, r.Score)
+        }
+    }
+}
+
+// Test that without a provider nothing is batched or reported.
+func TestService_RankWithReport_NoProvider(t *testing.T) {
+    _, report := newTestService().RankWithReport(context.Background(), openTasks(3))
+    if report.Batches != 0 || report.Fallback == nil || len(report.Fallback) != 0 {
+        t.Fatalf("expected an empty report, got %+v", report)
+    }
+}
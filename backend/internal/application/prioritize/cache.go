@@ -0,0 +1,115 @@
+package prioritize
+
+import (
+    "sync"
+    "time"
+)
+
+// maxCacheEntries bounds the results kept per tenant; the oldest is evicted.
+const maxCacheEntries = 32
+
+// Cache keeps each tenant's latest prioritization results for a TTL. All of
+// a tenant's entries are dropped by Invalidate when its tasks or settings
+// change. It is safe for concurrent use.
+type Cache struct {
+    ttl     time.Duration
+    mu      sync.Mutex
+    tenants map[string]*tenantCache
+}
+
+type tenantCache struct {
+    // generation counts invalidations, so a result computed across one is
+    // not stored.
+    generation uint64
+    entries    map[string]cacheEntry
+}
+
+type cacheEntry struct {
+    value      any
+    computedAt time.Time
+}
+
+// NewCache returns a cache whose entries are served for ttl. A non-positive
+// ttl disables caching.
+func NewCache(ttl time.Duration) *Cache {
+    return &Cache{ttl: ttl, tenants: make(map[string]*tenantCache)}
+}
+
+// Invalidate drops the tenant's cached results. It is a no-op on a nil Cache.
+func (c *Cache) Invalidate(tenantID string) {
+    if c == nil {
+        return
+    }
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    if tc, ok := c.tenants[tenantID]; ok {
+        tc.generation++
+        clear(tc.entries)
+    }
+}
+
+// lookup returns the entry under key if it is younger than the TTL at now,
+// along with the tenant's current generation.
+func (c *Cache) lookup(tenantID, key string, now time.Time) (cacheEntry, uint64, bool) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    tc, ok := c.tenants[tenantID]
+    if !ok {
+        tc = &tenantCache{entries: make(map[string]cacheEntry)}
+        c.tenants[tenantID] = tc
+    }
+    e, ok := tc.entries[key]
+    if !ok || now.Sub(e.computedAt) >= c.ttl {
+        return cacheEntry{}, tc.generation, false
+    }
+    return e, tc.generation, true
+}
+
+// store saves e under key unless the tenant was invalidated since generation.
+func (c *Cache) store(tenantID, key string, generation uint64, e cacheEntry) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    tc, ok := c.tenants[tenantID]
+    if !ok || tc.generation != generation {
+        return
+    }
+    if _, exists := tc.entries[key]; !exists && len(tc.entries) >= maxCacheEntries {
+        oldest := ""
+        for k, v := range tc.entries {
+            if oldest == "" || v.computedAt.Before(tc.entries[oldest].computedAt) {
+                oldest = k
+            }
+        }
+        delete(tc.entries, oldest)
+    }
+    tc.entries[key] = e
+}
+
+// WithCache returns a copy of s that serves results through c.
+func (s *Service) WithCache(c *Cache) *Service {
+    cp := *s
+    cp.Cache = c
+    return &cp
+}
+
+// Cached returns the tenant's result stored under key when it is younger
+// than the cache TTL and refresh is false. Otherwise it runs compute, caches
+// a successful result and returns it. computedAt tells callers how stale the
+// value is. Without a Cache, compute always runs.
+func (s *Service) Cached(tenantID, key string, refresh bool, compute func() (any, error)) (value any, computedAt time.Time, err error) {
+    if s.Cache == nil || s.Cache.ttl <= 0 {
+        value, err = compute()
+        return value, s.Now(), err
+    }
+    e, generation, ok := s.Cache.lookup(tenantID, key, s.Now())
+    if ok && !refresh {
+        return e.value, e.computedAt, nil
+    }
+    computedAt = s.Now()
+    value, err = compute()
+    if err != nil {
+        return nil, time.Time{}, err
+    }
+    s.Cache.store(tenantID, key, generation, cacheEntry{value: value, computedAt: computedAt})
+    return value, computedAt, nil
+}
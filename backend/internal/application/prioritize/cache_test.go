@@ -0,0 +1,101 @@
+package prioritize
+
+import (
+    "sync"
+    "testing"
+    "time"
+)
+
+// newCachedService returns a service with a one-minute cache and a clock the
+// test advances through *now.
+func newCachedService(now *time.Time) *Service {
+    s := newTestService().WithCache(NewCache(time.Minute))
+    s.Now = func() time.Time { return *now }
+    return s
+}
+
+// Test that a result is served from cache until the TTL passes, and that
+// refresh recomputes.
+func TestService_Cached_TTLAndRefresh(t *testing.T) {
+    now := testNow
+    s := newCachedService(&now)
+    calls := 0
+    compute := func() (any, error) {
+        calls++
+        return calls, nil
+    }
+    get := func(refresh bool) (int, time.Time) {
+        v, at, err := s.Cached("t1", "k", refresh, compute)
+        if err != nil {
+            t.Fatalf("cached: %v", err)
+        }
+        return v.(int), at
+    }
+
+    if v, at := get(false); v != 1 || !at.Equal(testNow) {
+        t.Fatalf("expected first result computed at %v, got %d at %v", testNow, v, at)
+    }
+    now = testNow.Add(59 * time.Second)
+    if v, at := get(false); v != 1 || !at.Equal(testNow) {
+        t.Fatalf("expected cached result from %v, got %d at %v", testNow, v, at)
+    }
+    if v, at := get(true); v != 2 || !at.Equal(now) {
+        t.Fatalf("expected refresh to recompute at %v, got %d at %v", now, v, at)
+    }
+    now = now.Add(time.Minute)
+    if v, _ := get(false); v != 3 {
+        t.Fatalf("expected expired result to be recomputed, got %d", v)
+    }
+}
+
+// Test that Invalidate drops only the given tenant's results, and that a
+// result computed across an invalidation is not stored.
+func TestService_Cached_Invalidate(t *testing.T) {
+    now := testNow
+    s := newCachedService(&now)
+    calls := 0
+    compute := func() (any, error) {
+        calls++
+        return calls, nil
+    }
+    s.Cached("t1", "k", false, compute)
+    s.Cached("t2", "k", false, compute)
+
+    s.Cache.Invalidate("t1")
+    if v, _, _ := s.Cached("t1", "k", false, compute); v != 3 {
+        t.Fatalf("expected t1 to recompute after invalidation, got %v", v)
+    }
+    if v, _, _ := s.Cached("t2", "k", false, compute); v != 2 {
+        t.Fatalf("expected t2 to stay cached, got %v", v)
+    }
+
+    s.Cached("t1", "racy", false, func() (any, error) {
+        s.Cache.Invalidate("t1")
+        return "stale", nil
+    })
+    if v, _, _ := s.Cached("t1", "racy", false, func() (any, error) { return "fresh", nil }); v != "fresh" {
+        t.Fatalf("expected a result computed across an invalidation to be dropped, got %v", v)
+    }
+}
+
+// Test that concurrent lookups, stores and invalidations are race-free and
+// always return a computed value.
+func TestService_Cached_Concurrent(t *testing.T) {
+    now := testNow
+    s := newCachedService(&now)
+    var wg sync.WaitGroup
+    for i := 0; i < 50; i++ {
+        wg.Add(1)
+        go func(i int) {
+            defer wg.Done()
+            if i%5 == 0 {
+                s.Cache.Invalidate("t1")
+            }
+            v, _, err := s.Cached("t1", "k", i%7 == 0, func() (any, error) { return "v", nil })
+            if err != nil || v != "v" {
+                t.Errorf("expected v, got %v, %v", v, err)
+            }
+        }(i)
+    }
+    wg.Wait()
+}
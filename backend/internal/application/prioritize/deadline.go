@@ -0,0 +1,105 @@
+package prioritize
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "math"
+    "sort"
+
+    domaintask "backend/internal/domain/task"
+)
+
+// ErrTasksUnavailable is returned when ranking by id on a Service without a
+// TaskLister.
+var ErrTasksUnavailable = errors.New("prioritize task source is not configured")
+
+// neutralDeadlineScore is the deadline score of undated tasks in
+// RankByDeadlineAndPriority.
+const neutralDeadlineScore = 0.5
+
+// PriorityWeights weigh the factors of RankByDeadlineAndPriority and must
+// sum to 1.
+type PriorityWeights struct {
+    PriorityWeight float64 `json:"priority"`
+    DueDateWeight  float64 `json:"dueDate"`
+    StatusWeight   float64 `json:"status"`
+}
+
+// Validate checks that the weights are non-negative and sum to 1.
+func (w PriorityWeights) Validate() error {
+    for name, v := range map[string]float64{"priority": w.PriorityWeight, "dueDate": w.DueDateWeight, "status": w.StatusWeight} {
+        if v < 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+            return fmt.Errorf("%w: %s weight must be a non-negative number", ErrInvalidWeights, name)
+        }
+    }
+    if sum := w.PriorityWeight + w.DueDateWeight + w.StatusWeight; math.Abs(sum-1) > 1e-9 {
+        return fmt.Errorf("%w: weights must sum to 1, got %g", ErrInvalidWeights, sum)
+    }
+    return nil
+}
+
+// RankedTask is a task's weighted score from RankByDeadlineAndPriority.
+type RankedTask struct {
+    TaskID string  `json:"taskId"`
+    Score  float64 `json:"score"`
+}
+
+// WithTasks returns a copy of s that loads tasks from l.
+func (s *Service) WithTasks(l TaskLister) *Service {
+    cp := *s
+    cp.Tasks = l
+    return &cp
+}
+
+// RankByDeadlineAndPriority scores the tenant's tasks named by taskIDs (all
+// open tasks when empty) as the weighted sum of priority, deadline and
+// status, each in [0, 1], and returns them from highest to lowest score out
+// of 100, ties broken by ID. Undated tasks get a neutral deadline score of
+// 0.5; done and archived tasks a status score of 0. Unknown ids are skipped.
+func (s *Service) RankByDeadlineAndPriority(ctx context.Context, tenantID string, taskIDs []string, weights PriorityWeights) ([]RankedTask, error) {
+    if err := weights.Validate(); err != nil {
+        return nil, err
+    }
+    if s.Tasks == nil {
+        return nil, ErrTasksUnavailable
+    }
+    all, err := s.Tasks.List(ctx, tenantID)
+    if err != nil {
+        return nil, err
+    }
+
+    wanted := make(map[string]bool, len(taskIDs))
+    for _, id := range taskIDs {
+        wanted[id] = true
+    }
+    now := s.Now()
+    out := []RankedTask{}
+    for _, t := range all {
+        closed := t.Status == domaintask.StatusDone || t.Status == domaintask.StatusArchived
+        if len(wanted) > 0 && !wanted[t.ID] || len(wanted) == 0 && closed {
+            continue
+        }
+        priority := clamp01(float64(t.Priority-domaintask.MinPriority) / float64(domaintask.MaxPriority-domaintask.MinPriority))
+        deadline := neutralDeadlineScore
+        if t.DueDate != nil {
+            deadline = dueFactor(t.DueDate.Sub(now))
+        }
+        status, ok := statusFactor[t.Status]
+        switch {
+        case closed:
+            status = 0
+        case !ok:
+            status = statusFactor[domaintask.StatusTodo]
+        }
+        score := weights.PriorityWeight*priority + weights.DueDateWeight*deadline + weights.StatusWeight*status
+        out = append(out, RankedTask{TaskID: t.ID, Score: math.Round(score*10000) / 100})
+    }
+    sort.Slice(out, func(i, j int) bool {
+        if out[i].Score != out[j].Score {
+            return out[i].Score > out[j].Score
+        }
+        return out[i].TaskID < out[j].TaskID
+    })
+    return out, nil
+}
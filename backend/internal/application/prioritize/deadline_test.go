@@ -0,0 +1,91 @@
+package prioritize
+
+import (
+    "context"
+    "errors"
+    "reflect"
+    "testing"
+    "time"
+
+    domaintask "backend/internal/domain/task"
+)
+
+// staticTasks is a TaskLister over a fixed slice for tenant t1.
+type staticTasks []domaintask.Task
+
+func (s staticTasks) List(_ context.Context, tenantID string) ([]domaintask.Task, error) {
+    if tenantID != "t1" {
+        return nil, nil
+    }
+    return s, nil
+}
+
+func deadlineTasks() staticTasks {
+    return staticTasks{
+        {ID: "b", Priority: 1, Status: domaintask.StatusTodo},
+        {ID: "a", Priority: 10, Status: domaintask.StatusInProgress, DueDate: at(-time.Hour)},
+        {ID: "c", Priority: 5, Status: domaintask.StatusTodo, DueDate: at(24 * time.Hour)},
+        {ID: "d", Priority: 5, Status: domaintask.StatusTodo, DueDate: at(30 * 24 * time.Hour)},
+        {ID: "e", Priority: 10, Status: domaintask.StatusDone},
+        {ID: "f", Priority: 1, Status: domaintask.StatusTodo},
+    }
+}
+
+var testPriorityWeights = PriorityWeights{PriorityWeight: 0.5, DueDateWeight: 0.3, StatusWeight: 0.2}
+
+// Test that open tasks are ranked by the weighted sum with fixed results,
+// undated tasks getting the neutral deadline score and ties ordered by ID.
+func TestService_RankByDeadlineAndPriority(t *testing.T) {
+    s := newTestService().WithTasks(deadlineTasks())
+
+    got, err := s.RankByDeadlineAndPriority(context.Background(), "t1", nil, testPriorityWeights)
+    if err != nil {
+        t.Fatalf("rank: %v", err)
+    }
+    want := []RankedTask{
+        {TaskID: "a", Score: 100},
+        {TaskID: "c", Score: 57.83},
+        {TaskID: "d", Score: 32.22},
+        {TaskID: "b", Score: 25},
+        {TaskID: "f", Score: 25},
+    }
+    if !reflect.DeepEqual(got, want) {
+        t.Fatalf("expected %+v, got %+v", want, got)
+    }
+
+    again, _ := s.RankByDeadlineAndPriority(context.Background(), "t1", nil, testPriorityWeights)
+    if !reflect.DeepEqual(again, got) {
+        t.Fatalf("expected a deterministic ranking, got %+v then %+v", got, again)
+    }
+}
+
+// Test that named ids are ranked even when closed, and unknown ids skipped.
+func TestService_RankByDeadlineAndPriority_ByIDs(t *testing.T) {
+    s := newTestService().WithTasks(deadlineTasks())
+
+    got, err := s.RankByDeadlineAndPriority(context.Background(), "t1", []string{"e", "b", "ghost"}, testPriorityWeights)
+    if err != nil {
+        t.Fatalf("rank: %v", err)
+    }
+    want := []RankedTask{{TaskID: "e", Score: 65}, {TaskID: "b", Score: 25}}
+    if !reflect.DeepEqual(got, want) {
+        t.Fatalf("expected %+v, got %+v", want, got)
+    }
+}
+
+// Test that weights must be non-negative and sum to 1, and that a service
+// without a task source says so.
+func TestService_RankByDeadlineAndPriority_Errors(t *testing.T) {
+    s := newTestService().WithTasks(deadlineTasks())
+    for _, w := range []PriorityWeights{
+        {PriorityWeight: 0.5, DueDateWeight: 0.3, StatusWeight: 0.1},
+        {PriorityWeight: 1.2, DueDateWeight: -0.2},
+    } {
+        if _, err := s.RankByDeadlineAndPriority(context.Background(), "t1", nil, w); !errors.Is(err, ErrInvalidWeights) {
+            t.Fatalf("%+v: expected ErrInvalidWeights, got %v", w, err)
+        }
+    }
+    if _, err := newTestService().RankByDeadlineAndPriority(context.Background(), "t1", nil, testPriorityWeights); !errors.Is(err, ErrTasksUnavailable) {
+        t.Fatalf("expected ErrTasksUnavailable, got %v", err)
+    }
+}
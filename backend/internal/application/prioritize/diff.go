@@ -0,0 +1,76 @@
+package prioritize
+
+import (
+    "cmp"
+    "math"
+    "slices"
+)
+
+// DefaultDiffThreshold is how many points a score must move by for a dry run
+// to count the task as moved.
+const DefaultDiffThreshold = 5.0
+
+// ScoreDiff is how a run would change one task's stored score. Delta is
+// ProposedScore minus CurrentScore, with a task that has no score yet
+// counting as 0.
+type ScoreDiff struct {
+    TaskID        string   `json:"taskId"`
+    CurrentScore  *float64 `json:"currentScore"`
+    ProposedScore float64  `json:"proposedScore"`
+    Delta         float64  `json:"delta"`
+    Reasons       []string `json:"reasons"`
+}
+
+// DiffSummary counts the tasks whose score would rise or fall by more than
+// Threshold points; the rest are Unchanged.
+type DiffSummary struct {
+    Threshold float64 `json:"threshold"`
+    MovedUp   int     `json:"movedUp"`
+    MovedDown int     `json:"movedDown"`
+    Unchanged int     `json:"unchanged"`
+}
+
+// Diff compares each ranked task's new score with the one it has stored.
+func Diff(ranked []Ranked) []ScoreDiff {
+    out := make([]ScoreDiff, 0, len(ranked))
+    for _, r := range ranked {
+        var current float64
+        if r.Task.AiScore != nil {
+            current = *r.Task.AiScore
+        }
+        out = append(out, ScoreDiff{
+            TaskID:        r.Task.ID,
+            CurrentScore:  r.Task.AiScore,
+            ProposedScore: r.Score,
+            Delta:         math.Round((r.Score-current)*100) / 100,
+            Reasons:       r.Reasons,
+        })
+    }
+    return out
+}
+
+// SortDiffs orders diffs by absolute delta, biggest first, then by task ID.
+func SortDiffs(diffs []ScoreDiff) {
+    slices.SortFunc(diffs, func(a, b ScoreDiff) int {
+        if c := cmp.Compare(math.Abs(b.Delta), math.Abs(a.Delta)); c != 0 {
+            return c
+        }
+        return cmp.Compare(a.TaskID, b.TaskID)
+    })
+}
+
+// SummarizeDiffs counts the diffs that move by more than threshold points.
+func SummarizeDiffs(diffs []ScoreDiff, threshold float64) DiffSummary {
+    sum := DiffSummary{Threshold: threshold}
+    for _, d := range diffs {
+        switch {
+        case d.Delta > threshold:
+            sum.MovedUp++
+        case d.Delta < -threshold:
+            sum.MovedDown++
+        default:
+            sum.Unchanged++
+        }
+    }
+    return sum
+}
@@ -0,0 +1,71 @@
+package prioritize
+
+import (
+    "context"
+    "testing"
+
+    domaintask "backend/internal/domain/task"
+)
+
+// Test that diffs count unscored tasks as 0, sort by absolute delta and are
+// summarized against the threshold.
+func TestDiff(t *testing.T) {
+    score := func(v float64) *float64 { return &v }
+    ranked := []Ranked{
+        {Task: domaintask.Task{ID: "a", AiScore: score(50)}, Score: 52},
+        {Task: domaintask.Task{ID: "b", AiScore: score(80)}, Score: 60},
+        {Task: domaintask.Task{ID: "c"}, Score: 30},
+        {Task: domaintask.Task{ID: "d", AiScore: score(40)}, Score: 46},
+    }
+    diffs := Diff(ranked)
+    SortDiffs(diffs)
+
+    var order string
+    for _, d := range diffs {
+        order += d.TaskID
+    }
+    if order != "cbda" {
+        t.Fatalf("expected order cbda, got %s", order)
+    }
+    if diffs[0].CurrentScore != nil || diffs[0].Delta != 30 || diffs[1].Delta != -20 {
+        t.Fatalf("unexpected deltas %+v", diffs)
+    }
+    sum := SummarizeDiffs(diffs, 5)
+    if sum.MovedUp != 2 || sum.MovedDown != 1 || sum.Unchanged != 1 || sum.Threshold != 5 {
+        t.Fatalf("expected 2 up, 1 down, 1 unchanged, got %+v", sum)
+    }
+}
+
+// Test that a dry run proposes exactly the scores a real run then stores,
+// without storing anything itself.
+func TestService_RunAll_DryRun(t *testing.T) {
+    store := &mapStore{
+        tasks: map[string][]domaintask.Task{"t1": {
+            {ID: "a", Priority: 9, Status: domaintask.StatusTodo, CreatedAt: testNow},
+            {ID: "b", Priority: 2, Status: domaintask.StatusInProgress, CreatedAt: testNow},
+            {ID: "c", Priority: 5, Status: domaintask.StatusTodo, CreatedAt: testNow},
+        }},
+        scores: map[string]map[string]float64{},
+    }
+    svc := newTestService()
+
+    preview, err := svc.RunAll(context.Background(), "t1", store, RunOptions{PageSize: 2, DryRun: true, DiffThreshold: 1})
+    if err != nil {
+        t.Fatalf("dry run: %v", err)
+    }
+    if len(store.scores) != 0 {
+        t.Fatalf("expected a dry run to store nothing, got %v", store.scores)
+    }
+    if preview.Scored != 3 || len(preview.Diffs) != 3 || preview.Summary == nil || preview.Summary.MovedUp != 3 {
+        t.Fatalf("expected 3 diffs all moving up, got %+v", preview)
+    }
+
+    if _, err := svc.RunAll(context.Background(), "t1", store, RunOptions{PageSize: 2}); err != nil {
+        t.Fatalf("run: %v", err)
+    }
+    for _, d := range preview.Diffs {
+        if store.scores["t1"][d.TaskID] != d.ProposedScore {
+            t.Fatalf("task %s: proposed %v but stored %v", d.TaskID, d.ProposedScore, store.scores["t1"][d.TaskID])
+        }
+    }
+}
@@ -0,0 +1,53 @@
+package prioritize
+
+import (
+    "strings"
+    "unicode/utf8"
+)
+
+// Factors named in explanations.
+const (
+    FactorPriority = "priority"
+    FactorDueDate  = "dueDate"
+    FactorAge      = "age"
+    FactorStatus   = "status"
+    FactorAI       = "ai"
+)
+
+// MaxRationaleLen is the longest provider rationale kept, in characters;
+// longer ones are cut to fit.
+const MaxRationaleLen = 280
+
+// Explanation is one factor's share of a task's score. Contribution is in
+// score points, so the contributions of a task add up to its score.
+type Explanation struct {
+    Factor       string  `json:"factor"`
+    Contribution float64 `json:"contribution"`
+    Detail       string  `json:"detail"`
+}
+
+// blendExplanations scales the rule contributions by the rules' share of a
+// blended score and adds the provider's share as the ai factor.
+func blendExplanations(rules []Explanation, share float64, r ScoredTask) []Explanation {
+    out := make([]Explanation, 0, len(rules)+1)
+    out = append(out, Explanation{Factor: FactorAI, Contribution: round2(share * r.Score), Detail: r.Reason})
+    for _, e := range rules {
+        e.Contribution = round2((1 - share) * e.Contribution)
+        out = append(out, e)
+    }
+    return out
+}
+
+// validRationale trims a provider rationale, substitutes a placeholder when
+// it is empty and cuts it to MaxRationaleLen characters.
+func validRationale(s string) string {
+    s = strings.Join(strings.Fields(s), " ")
+    if s == "" {
+        return "scored by AI"
+    }
+    if utf8.RuneCountInString(s) <= MaxRationaleLen {
+        return s
+    }
+    runes := []rune(s)
+    return strings.TrimSpace(string(runes[:MaxRationaleLen-1])) + "…"
+}
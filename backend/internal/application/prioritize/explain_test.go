@@ -0,0 +1,90 @@
+package prioritize
+
+import (
+    "context"
+    "math"
+    "strings"
+    "testing"
+    "time"
+    "unicode/utf8"
+
+    domaintask "backend/internal/domain/task"
+)
+
+// contributionsSum adds up the contributions of a task's explanations.
+func contributionsSum(es []Explanation) float64 {
+    var sum float64
+    for _, e := range es {
+        sum += e.Contribution
+    }
+    return sum
+}
+
+// Test that the rule factors' contributions add up to the score within
+// rounding tolerance and carry a readable detail.
+func TestService_Explain_SumsToScore(t *testing.T) {
+    day := 24 * time.Hour
+    tasks := []domaintask.Task{
+        {Priority: 10, Status: domaintask.StatusInProgress, DueDate: at(-2 * day), CreatedAt: testNow.Add(-40 * day)},
+        {Priority: 5, Status: domaintask.StatusTodo, CreatedAt: testNow},
+        {Priority: 1, Status: domaintask.StatusTodo, DueDate: at(7 * day), CreatedAt: testNow.Add(-15 * day)},
+        {Priority: 3, Status: domaintask.StatusTodo, DueDate: at(6 * time.Hour), CreatedAt: testNow.Add(-3 * day)},
+        {Priority: 10, Status: domaintask.StatusDone},
+    }
+    s := newTestService()
+    for _, tk := range tasks {
+        score, es := s.Explain(context.Background(), tk)
+        if sum := contributionsSum(es); math.Abs(sum-score) > 0.05 {
+            t.Fatalf("expected contributions to sum to %v, got %v (%+v)", score, sum, es)
+        }
+        for _, e := range es {
+            if e.Factor == "" || e.Detail == "" {
+                t.Fatalf("expected factor and detail, got %+v", e)
+            }
+        }
+    }
+
+    _, es := s.Explain(context.Background(), tasks[3])
+    if es[1].Factor != FactorDueDate || es[1].Detail != "due in 6 hours" {
+        t.Fatalf("expected dueDate factor due in 6 hours, got %+v", es[1])
+    }
+}
+
+// Test that blended AI scores are explained by the provider's share plus the
+// scaled rule factors, and provider-only scores by the ai factor alone.
+func TestService_Rank_ExplainsProviderScores(t *testing.T) {
+    tasks := []domaintask.Task{
+        {ID: "a", Priority: 4, Status: domaintask.StatusTodo, DueDate: at(30 * time.Hour), CreatedAt: testNow.Add(-72 * time.Hour)},
+    }
+    p := &fakeProvider{scores: []ScoredTask{{TaskID: "a", Score: 80, Reason: "blocks the release"}}}
+
+    for _, share := range []float64{0.3, 1} {
+        s := newTestService().WithProvider(p)
+        s.Weights.AI = share
+        r := s.Rank(context.Background(), tasks)[0]
+        if sum := contributionsSum(r.Explanations); math.Abs(sum-r.Score) > 0.05 {
+            t.Fatalf("share %v: expected contributions to sum to %v, got %v (%+v)", share, r.Score, sum, r.Explanations)
+        }
+        if r.Explanations[0].Factor != FactorAI || r.Rationale != "blocks the release" {
+            t.Fatalf("share %v: expected ai factor and rationale first, got %+v", share, r)
+        }
+        if share == 1 && len(r.Explanations) != 1 {
+            t.Fatalf("expected only the ai factor, got %+v", r.Explanations)
+        }
+    }
+}
+
+// Test that provider rationales are whitespace-normalized, defaulted when
+// empty and cut to MaxRationaleLen characters.
+func TestValidRationale(t *testing.T) {
+    if got := validRationale("  due \n soon "); got != "due soon" {
+        t.Fatalf("expected %q, got %q", "due soon", got)
+    }
+    if got := validRationale(" "); got != "scored by AI" {
+        t.Fatalf("expected placeholder, got %q", got)
+    }
+    got := validRationale(strings.Repeat("é", 400))
+    if n := utf8.RuneCountInString(got); n != MaxRationaleLen || !strings.HasSuffix(got, "…") {
+        t.Fatalf("expected %d characters ending in an ellipsis, got %d", MaxRationaleLen, n)
+    }
+}
@@ -0,0 +1,111 @@
+package prioritize
+
+import (
+    "errors"
+    "fmt"
+    "sort"
+    "time"
+
+    domaintask "backend/internal/domain/task"
+)
+
+// ErrInvalidThresholds is returned when matrix thresholds are out of range.
+var ErrInvalidThresholds = errors.New("invalid thresholds")
+
+// Thresholds decide the Eisenhower quadrant of a task.
+type Thresholds struct {
+    // UrgentWithin makes a task urgent when it is due within this long, or
+    // overdue. Tasks without a due date are never urgent.
+    UrgentWithin time.Duration
+    // ImportantPriority is the lowest priority counted as important.
+    ImportantPriority int
+}
+
+// DefaultThresholds treat tasks due within two days as urgent and priority 7
+// or above as important.
+func DefaultThresholds() Thresholds {
+    return Thresholds{UrgentWithin: 48 * time.Hour, ImportantPriority: 7}
+}
+
+// Validate checks that UrgentWithin is positive and ImportantPriority is a
+// valid task priority.
+func (th Thresholds) Validate() error {
+    if th.UrgentWithin <= 0 {
+        return fmt.Errorf("%w: urgency window must be positive", ErrInvalidThresholds)
+    }
+    if th.ImportantPriority < domaintask.MinPriority || th.ImportantPriority > domaintask.MaxPriority {
+        return fmt.Errorf("%w: important priority must be between %d and %d", ErrInvalidThresholds, domaintask.MinPriority, domaintask.MaxPriority)
+    }
+    return nil
+}
+
+// Matrix buckets tasks into the four Eisenhower quadrants.
+type Matrix struct {
+    UrgentImportant    []TaskSummary `json:"urgentImportant"`
+    NotUrgentImportant []TaskSummary `json:"notUrgentImportant"`
+    UrgentNotImportant []TaskSummary `json:"urgentNotImportant"`
+    Neither            []TaskSummary `json:"neither"`
+}
+
+// Matrix classifies the open tasks among tasks by s.Thresholds; done and
+// archived tasks are skipped. Each quadrant is ordered by due date (undated
+// last), then by descending priority.
+func (s *Service) Matrix(tasks []domaintask.Task) Matrix {
+    now := s.Now()
+    th := s.Thresholds
+    m := Matrix{
+        UrgentImportant:    []TaskSummary{},
+        NotUrgentImportant: []TaskSummary{},
+        UrgentNotImportant: []TaskSummary{},
+        Neither:            []TaskSummary{},
+    }
+    for _, t := range tasks {
+        if t.Status == domaintask.StatusDone || t.Status == domaintask.StatusArchived {
+            continue
+        }
+        urgent := t.DueDate != nil && t.DueDate.Sub(now) <= th.UrgentWithin
+        important := t.Priority >= th.ImportantPriority
+        sum := summarize(t)
+        switch {
+        case urgent && important:
+            m.UrgentImportant = append(m.UrgentImportant, sum)
+        case important:
+            m.NotUrgentImportant = append(m.NotUrgentImportant, sum)
+        case urgent:
+            m.UrgentNotImportant = append(m.UrgentNotImportant, sum)
+        default:
+            m.Neither = append(m.Neither, sum)
+        }
+    }
+    for _, q := range [][]TaskSummary{m.UrgentImportant, m.NotUrgentImportant, m.UrgentNotImportant, m.Neither} {
+        sortSummaries(q)
+    }
+    return m
+}
+
+func summarize(t domaintask.Task) TaskSummary {
+    return TaskSummary{
+        ID:          t.ID,
+        Title:       t.Title,
+        Description: t.Description,
+        Status:      t.Status,
+        Priority:    t.Priority,
+        DueDate:     t.DueDate,
+        CreatedAt:   t.CreatedAt,
+    }
+}
+
+func sortSummaries(q []TaskSummary) {
+    sort.SliceStable(q, func(i, j int) bool {
+        a, b := q[i], q[j]
+        switch {
+        case a.DueDate != nil && b.DueDate != nil && !a.DueDate.Equal(*b.DueDate):
+            return a.DueDate.Before(*b.DueDate)
+        case (a.DueDate == nil) != (b.DueDate == nil):
+            return a.DueDate != nil
+        case a.Priority != b.Priority:
+            return a.Priority > b.Priority
+        }
+        return a.ID < b.ID
+    })
+}
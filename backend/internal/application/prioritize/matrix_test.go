@@ -0,0 +1,67 @@
+package prioritize
+
+import (
+    "testing"
+    "time"
+
+    domaintask "backend/internal/domain/task"
+)
+
+func ids(q []TaskSummary) []string {
+    out := make([]string, 0, len(q))
+    for _, t := range q {
+        out = append(out, t.ID)
+    }
+    return out
+}
+
+// Test that tasks land in the quadrant implied by the thresholds, including
+// at the exact boundaries, and that closed tasks are left out.
+func TestService_Matrix(t *testing.T) {
+    s := newTestService()
+    s.Thresholds = Thresholds{UrgentWithin: 48 * time.Hour, ImportantPriority: 7}
+    m := s.Matrix([]domaintask.Task{
+        {ID: "ui", Priority: 7, Status: domaintask.StatusTodo, DueDate: at(48 * time.Hour)},
+        {ID: "ui-overdue", Priority: 9, Status: domaintask.StatusInProgress, DueDate: at(-time.Hour)},
+        {ID: "i", Priority: 10, Status: domaintask.StatusTodo, DueDate: at(49 * time.Hour)},
+        {ID: "i-undated", Priority: 8, Status: domaintask.StatusTodo},
+        {ID: "u", Priority: 6, Status: domaintask.StatusTodo, DueDate: at(time.Hour)},
+        {ID: "n", Priority: 1, Status: domaintask.StatusTodo},
+        {ID: "done", Priority: 10, Status: domaintask.StatusDone, DueDate: at(time.Hour)},
+    })
+
+    for name, tc := range map[string]struct {
+        got  []TaskSummary
+        want []string
+    }{
+        "urgentImportant":    {m.UrgentImportant, []string{"ui-overdue", "ui"}},
+        "notUrgentImportant": {m.NotUrgentImportant, []string{"i", "i-undated"}},
+        "urgentNotImportant": {m.UrgentNotImportant, []string{"u"}},
+        "neither":            {m.Neither, []string{"n"}},
+    } {
+        got := ids(tc.got)
+        if len(got) != len(tc.want) {
+            t.Fatalf("%s: expected %v, got %v", name, tc.want, got)
+        }
+        for i := range got {
+            if got[i] != tc.want[i] {
+                t.Fatalf("%s: expected %v, got %v", name, tc.want, got)
+            }
+        }
+    }
+}
+
+// Test that thresholds outside the allowed ranges are rejected.
+func TestThresholds_Validate(t *testing.T) {
+    if err := DefaultThresholds().Validate(); err != nil {
+        t.Fatalf("defaults: %v", err)
+    }
+    for _, th := range []Thresholds{
+        {UrgentWithin: 0, ImportantPriority: 5},
+        {UrgentWithin: time.Hour, ImportantPriority: domaintask.MaxPriority + 1},
+    } {
+        if err := th.Validate(); err == nil {
+            t.Fatalf("expected %+v to be rejected", th)
+        }
+    }
+}
@@ -0,0 +1,60 @@
+package prioritize
+
+import (
+    "context"
+    "errors"
+    "time"
+
+    domaintask "backend/internal/domain/task"
+)
+
+// ErrSettingsNotFound is returned by a SettingsRepository when the tenant has
+// not saved any settings.
+var ErrSettingsNotFound = errors.New("prioritize settings not found")
+
+// ErrSettingsUnavailable is returned when settings are saved on a Service
+// without a SettingsRepository.
+var ErrSettingsUnavailable = errors.New("prioritize settings are not configured")
+
+// SettingsRepository stores each tenant's prioritization settings.
+type SettingsRepository interface {
+    // GetSettings returns ErrSettingsNotFound when the tenant has no row.
+    GetSettings(ctx context.Context, tenantID string) (Settings, error)
+    // SaveSettings creates or replaces the tenant's settings.
+    SaveSettings(ctx context.Context, tenantID string, st Settings) error
+    // ListAutoPrioritized returns the tenants with AutoPrioritize on.
+    ListAutoPrioritized(ctx context.Context) ([]string, error)
+    // SaveLastAutoRun records when the scheduler last ran for the tenant.
+    SaveLastAutoRun(ctx context.Context, tenantID string, at time.Time) error
+}
+
+// TaskLister loads a tenant's tasks.
+type TaskLister interface {
+    List(ctx context.Context, tenantID string) ([]domaintask.Task, error)
+}
+
+// TaskSummary is the subset of a task sent to an AI provider for scoring.
+type TaskSummary struct {
+    ID          string     `json:"id"`
+    Title       string     `json:"title"`
+    Description string     `json:"description,omitempty"`
+    Status      string     `json:"status"`
+    Priority    int        `json:"priority"`
+    DueDate     *time.Time `json:"dueDate,omitempty"`
+    CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// ScoredTask is a provider's verdict on one task. Score is expected to be in
+// 0–100 but is clamped by the service before use.
+type ScoredTask struct {
+    TaskID string  `json:"taskId"`
+    Score  float64 `json:"score"`
+    Reason string  `json:"reason"`
+}
+
+// AIProvider scores tasks with an external model. Implementations may return
+// fewer results than summaries; missing tasks fall back to the rule-based
+// score.
+type AIProvider interface {
+    ScoreTasks(ctx context.Context, tasks []TaskSummary) ([]ScoredTask, error)
+}
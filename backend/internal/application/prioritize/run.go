@@ -0,0 +1,152 @@
+package prioritize
+
+import (
+    "context"
+    "errors"
+    "math"
+    "sync"
+    "time"
+
+    domaintask "backend/internal/domain/task"
+)
+
+// ErrRunInProgress is returned by RunAll while the tenant's previous run has
+// not finished.
+var ErrRunInProgress = errors.New("prioritization already running for this tenant")
+
+const (
+    // DefaultPageSize is how many tasks RunAll loads and scores at a time.
+    DefaultPageSize = 200
+    // DefaultMaxTasks caps a run when no positive cap is configured.
+    DefaultMaxTasks = 5000
+)
+
+// ScoreStore pages through a tenant's open tasks and stores their scores.
+type ScoreStore interface {
+    ListOpenPage(ctx context.Context, tenantID, afterID string, limit int) ([]domaintask.Task, error)
+    UpdateAIScores(ctx context.Context, tenantID string, scores map[string]float64) error
+}
+
+// RunOptions bounds a RunAll. Non-positive MaxTasks and PageSize fall back to
+// DefaultMaxTasks and DefaultPageSize.
+type RunOptions struct {
+    MaxTasks int
+    PageSize int
+    // AfterID resumes a run after the task with this id; open tasks are
+    // visited in id order.
+    AfterID string
+    // DryRun computes the scores and reports them as Diffs without storing
+    // them. Dry runs do not wait for or block other runs.
+    DryRun bool
+    // DiffThreshold is the move, in points, a dry run's Summary counts.
+    DiffThreshold float64
+}
+
+// RunSummary reports what one RunAll scored.
+type RunSummary struct {
+    Scored     int     `json:"scored"`
+    Min        float64 `json:"min"`
+    Max        float64 `json:"max"`
+    Mean       float64 `json:"mean"`
+    DurationMS int64   `json:"durationMs"`
+    // Truncated is true when the cap stopped the run before the backlog ended.
+    Truncated bool          `json:"truncated"`
+    Scoring   ScoringReport `json:"scoring"`
+    // LastID is the id of the last task scored, from which a truncated run
+    // can be resumed with RunOptions.AfterID.
+    LastID string `json:"-"`
+    // Diffs and Summary are set by dry runs only; Diffs is sorted by
+    // SortDiffs.
+    Diffs   []ScoreDiff  `json:"diffs,omitempty"`
+    Summary *DiffSummary `json:"summary,omitempty"`
+}
+
+// runs tracks the tenants with a RunAll in progress. Copies of a Service
+// share it, so the HTTP endpoint and the scheduler never overlap.
+type runs struct {
+    tenants sync.Map
+}
+
+// RunAll scores the tenant's open tasks with its settings page by page,
+// storing each page's scores before loading the next, or with opts.DryRun
+// collecting how they would change instead. At most opts.MaxTasks tasks are
+// scored, and a tenant can only have one storing run at a time; a second
+// concurrent call gets ErrRunInProgress.
+func (s *Service) RunAll(ctx context.Context, tenantID string, store ScoreStore, opts RunOptions) (RunSummary, error) {
+    maxTasks, pageSize := opts.MaxTasks, opts.PageSize
+    if maxTasks <= 0 {
+        maxTasks = DefaultMaxTasks
+    }
+    if pageSize <= 0 {
+        pageSize = DefaultPageSize
+    }
+    if s.running != nil && !opts.DryRun {
+        if _, busy := s.running.tenants.LoadOrStore(tenantID, struct{}{}); busy {
+            return RunSummary{}, ErrRunInProgress
+        }
+        defer s.running.tenants.Delete(tenantID)
+    }
+
+    svc, err := s.ForTenant(ctx, tenantID)
+    if err != nil {
+        return RunSummary{}, err
+    }
+    start := time.Now()
+    res := RunSummary{Min: math.Inf(1), Max: math.Inf(-1), Scoring: ScoringReport{Fallback: []string{}}}
+    var sum float64
+    var diffs []ScoreDiff
+    afterID := opts.AfterID
+    for {
+        limit := min(pageSize, maxTasks-res.Scored)
+        if limit <= 0 {
+            // Peek one task ahead to tell "cap hit" from "backlog exhausted".
+            more, err := store.ListOpenPage(ctx, tenantID, afterID, 1)
+            if err != nil {
+                return RunSummary{}, err
+            }
+            res.Truncated = len(more) > 0
+            break
+        }
+        page, err := store.ListOpenPage(ctx, tenantID, afterID, limit)
+        if err != nil {
+            return RunSummary{}, err
+        }
+        if len(page) == 0 {
+            break
+        }
+        afterID = page[len(page)-1].ID
+
+        scores := make(map[string]float64, len(page))
+        ranked, report := svc.RankWithReport(ctx, page)
+        res.Scoring.Merge(report)
+        for _, r := range ranked {
+            scores[r.Task.ID] = r.Score
+            sum += r.Score
+            res.Min = math.Min(res.Min, r.Score)
+            res.Max = math.Max(res.Max, r.Score)
+        }
+        if opts.DryRun {
+            diffs = append(diffs, Diff(ranked)...)
+        } else if err := store.UpdateAIScores(ctx, tenantID, scores); err != nil {
+            return RunSummary{}, err
+        }
+        res.Scored += len(page)
+        if len(page) < limit {
+            break
+        }
+    }
+
+    if res.Scored == 0 {
+        res.Min, res.Max = 0, 0
+    } else {
+        res.Mean = math.Round(sum/float64(res.Scored)*100) / 100
+    }
+    res.LastID = afterID
+    if opts.DryRun {
+        SortDiffs(diffs)
+        summary := SummarizeDiffs(diffs, opts.DiffThreshold)
+        res.Diffs, res.Summary = diffs, &summary
+    }
+    res.DurationMS = time.Since(start).Milliseconds()
+    return res, nil
+}
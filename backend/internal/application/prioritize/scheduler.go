@@ -0,0 +1,81 @@
+package prioritize
+
+import (
+    "context"
+    "errors"
+    "log/slog"
+    "time"
+)
+
+// Scheduler re-prioritizes the open tasks of every tenant with AutoPrioritize
+// on, once per interval, through the same RunAll as POST /prioritize/all.
+type Scheduler struct {
+    svc      *Service
+    store    ScoreStore
+    interval time.Duration
+    maxTasks int
+    logger   *slog.Logger
+}
+
+// NewScheduler returns a scheduler that runs svc over store every interval,
+// scoring at most maxTasks tasks per tenant (see RunOptions). Tenants are
+// read from svc's SettingsRepository. A nil logger means slog.Default().
+func NewScheduler(svc *Service, store ScoreStore, interval time.Duration, maxTasks int, logger *slog.Logger) *Scheduler {
+    if logger == nil {
+        logger = slog.Default()
+    }
+    return &Scheduler{svc: svc, store: store, interval: interval, maxTasks: maxTasks, logger: logger}
+}
+
+// Run calls RunOnce every interval until ctx is done, and returns once the
+// run in progress, if any, has stopped. Ticks that fall during a run are
+// dropped rather than queued.
+func (s *Scheduler) Run(ctx context.Context) {
+    ticker := time.NewTicker(s.interval)
+    defer ticker.Stop()
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+            s.RunOnce(ctx)
+        }
+    }
+}
+
+// RunOnce runs every opted-in tenant in turn. A tenant's failure is logged
+// and does not stop the others; a tenant whose previous run is still going
+// is skipped. Successful runs are recorded as the tenant's LastAutoRunAt.
+func (s *Scheduler) RunOnce(ctx context.Context) {
+    if s.svc.Settings == nil {
+        return
+    }
+    tenants, err := s.svc.Settings.ListAutoPrioritized(ctx)
+    if err != nil {
+        s.logger.ErrorContext(ctx, "scheduled prioritization: list tenants failed", "error", err)
+        return
+    }
+    start := time.Now()
+    var failed, skipped int
+    for _, tenantID := range tenants {
+        if ctx.Err() != nil {
+            break
+        }
+        res, err := s.svc.RunAll(ctx, tenantID, s.store, RunOptions{MaxTasks: s.maxTasks})
+        switch {
+        case errors.Is(err, ErrRunInProgress):
+            skipped++
+            s.logger.InfoContext(ctx, "scheduled prioritization skipped, previous run still going", "tenant_id", tenantID)
+            continue
+        case err != nil:
+            failed++
+            s.logger.ErrorContext(ctx, "scheduled prioritization failed", "tenant_id", tenantID, "error", err)
+            continue
+        }
+        if err := s.svc.Settings.SaveLastAutoRun(ctx, tenantID, s.svc.Now()); err != nil {
+            s.logger.WarnContext(ctx, "scheduled prioritization: recording run failed", "tenant_id", tenantID, "error", err)
+        }
+        s.logger.InfoContext(ctx, "scheduled prioritization finished", "tenant_id", tenantID, "scored", res.Scored, "truncated", res.Truncated, "failed_batches", res.Scoring.FailedBatches, "duration_ms", res.DurationMS)
+    }
+    s.logger.InfoContext(ctx, "scheduled prioritization pass finished", "tenants", len(tenants), "failed", failed, "skipped", skipped, "duration_ms", time.Since(start).Milliseconds())
+}
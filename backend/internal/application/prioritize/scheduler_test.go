@@ -0,0 +1,108 @@
+package prioritize
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    domaintask "backend/internal/domain/task"
+)
+
+// mapStore is a ScoreStore over each tenant's tasks. Tenants in fail cannot
+// be listed.
+type mapStore struct {
+    tasks  map[string][]domaintask.Task
+    fail   map[string]bool
+    scores map[string]map[string]float64
+}
+
+func (m *mapStore) ListOpenPage(_ context.Context, tenantID, afterID string, limit int) ([]domaintask.Task, error) {
+    if m.fail[tenantID] {
+        return nil, errors.New("database down")
+    }
+    var out []domaintask.Task
+    for _, t := range m.tasks[tenantID] {
+        if t.ID > afterID && len(out) < limit {
+            out = append(out, t)
+        }
+    }
+    return out, nil
+}
+
+func (m *mapStore) UpdateAIScores(_ context.Context, tenantID string, scores map[string]float64) error {
+    if m.scores[tenantID] == nil {
+        m.scores[tenantID] = map[string]float64{}
+    }
+    for id, v := range scores {
+        m.scores[tenantID][id] = v
+    }
+    return nil
+}
+
+// Test that a scheduled pass runs only opted-in tenants, carries on past a
+// failing tenant, skips a tenant with a run in progress and records the run
+// time of the tenants it scored.
+func TestScheduler_RunOnce(t *testing.T) {
+    open := func(id string) domaintask.Task {
+        return domaintask.Task{ID: id, Priority: 5, Status: domaintask.StatusTodo, CreatedAt: testNow}
+    }
+    store := &mapStore{
+        tasks: map[string][]domaintask.Task{
+            "broken": {open("x")},
+            "busy":   {open("y")},
+            "off":    {open("z")},
+            "on":     {open("a"), open("b")},
+        },
+        fail:   map[string]bool{"broken": true},
+        scores: map[string]map[string]float64{},
+    }
+    settings := mapSettings{
+        "broken": {AutoPrioritize: true},
+        "busy":   {AutoPrioritize: true},
+        "off":    {},
+        "on":     {AutoPrioritize: true},
+    }
+    for id, st := range settings {
+        st.Weights, st.Thresholds = DefaultWeights(), DefaultThresholds()
+        settings[id] = st
+    }
+    svc := newTestService().WithSettings(settings)
+    svc.running.tenants.Store("busy", struct{}{})
+
+    NewScheduler(svc, store, time.Hour, 0, nil).RunOnce(context.Background())
+
+    if len(store.scores["on"]) != 2 {
+        t.Fatalf("expected both tasks of the opted-in tenant scored, got %v", store.scores["on"])
+    }
+    for _, tenantID := range []string{"off", "busy", "broken"} {
+        if len(store.scores[tenantID]) != 0 {
+            t.Fatalf("expected tenant %s not to be scored, got %v", tenantID, store.scores[tenantID])
+        }
+    }
+    if at := settings["on"].LastAutoRunAt; at == nil || !at.Equal(testNow) {
+        t.Fatalf("expected the run recorded at %v, got %v", testNow, at)
+    }
+    for _, tenantID := range []string{"off", "busy", "broken"} {
+        if settings[tenantID].LastAutoRunAt != nil {
+            t.Fatalf("expected no run recorded for %s, got %v", tenantID, settings[tenantID].LastAutoRunAt)
+        }
+    }
+}
+
+// Test that Run returns once its context is cancelled.
+func TestScheduler_Run_StopsOnCancel(t *testing.T) {
+    ctx, cancel := context.WithCancel(context.Background())
+    done := make(chan struct{})
+    go func() {
+        NewScheduler(newTestService().WithSettings(mapSettings{}), &mapStore{}, time.Millisecond, 0, nil).Run(ctx)
+        close(done)
+    }()
+    time.Sleep(5 * time.Millisecond)
+    cancel()
+    select {
+    case <-done:
+    case <-time.After(time.Second):
+        t.Fatalf("expected Run to return after cancel")
+    }
+}
@@ -1,7 +1,298 @@
 package prioritize
 
-// Service is a placeholder for prioritization use cases.
-type Service struct{}
+import (
+    "context"
+    "fmt"
+    "math"
+    "sort"
+    "time"
 
-func NewService() *Service { return &Service{} }
+    domaintask "backend/internal/domain/task"
+)
 
+// Weights sets the relative importance of each scoring factor. The rule
+// weights need not sum to one; Score normalizes by their total. AI is the
+// share of a provider's score in the final score, from 0 (rules only) to 1
+// (provider only).
+type Weights struct {
+    Priority float64
+    DueDate  float64
+    Age      float64
+    Status   float64
+    AI       float64
+}
+
+// DefaultWeights favours explicit priority and deadlines over age and status,
+// and lets a configured AI provider decide alone.
+func DefaultWeights() Weights {
+    return Weights{Priority: 0.4, DueDate: 0.35, Age: 0.1, Status: 0.15, AI: 1}
+}
+
+const (
+    // dueHorizon is how far ahead a due date starts to raise the score.
+    dueHorizon = 14 * 24 * time.Hour
+    // dueRampWindow is the final stretch before the due date in which the
+    // due factor climbs steeply from dueRampStart to 1.
+    dueRampWindow = 48 * time.Hour
+    dueRampStart  = 0.5
+    // noDueDateFactor is the neutral due factor of undated tasks, so they
+    // rank alongside tasks due in about a week rather than last.
+    noDueDateFactor = 0.25
+    // ageHorizon is the age at which the age factor saturates.
+    ageHorizon = 30 * 24 * time.Hour
+)
+
+// statusFactor maps open statuses to their contribution; unknown open
+// statuses are treated like todo.
+var statusFactor = map[string]float64{
+    domaintask.StatusTodo:       0.5,
+    domaintask.StatusInProgress: 1,
+}
+
+// Service scores tasks with a transparent rule-based model, optionally
+// deferring to an AI provider for open tasks.
+type Service struct {
+    Weights Weights
+    // Thresholds classify tasks in Matrix.
+    Thresholds Thresholds
+    // Now returns the reference time for due-date and age calculations.
+    Now func() time.Time
+    // Provider, when set, scores open tasks in Rank. Tasks it fails on or
+    // leaves out are scored by the rules.
+    Provider AIProvider
+    // Batching splits the provider's work into concurrent calls.
+    Batching Batching
+    // Settings, when set, holds per-tenant settings (see ForTenant).
+    Settings SettingsRepository
+    // Cache, when set, keeps recent results for Cached.
+    Cache *Cache
+    // Tasks, when set, loads tasks for RankByDeadlineAndPriority.
+    Tasks TaskLister
+    // running guards RunAll against overlapping runs for a tenant.
+    running *runs
+}
+
+func NewService() *Service {
+    return &Service{Weights: DefaultWeights(), Thresholds: DefaultThresholds(), Batching: DefaultBatching(), running: &runs{}, Now: func() time.Time { return time.Now().UTC() }}
+}
+
+// Ranked is a task together with its score and the reasons behind it.
+// Rationale is the provider's explanation when it scored the task.
+type Ranked struct {
+    Task         domaintask.Task `json:"task"`
+    Score        float64         `json:"score"`
+    Reasons      []string        `json:"reasons"`
+    Explanations []Explanation   `json:"explanations"`
+    Rationale    string          `json:"rationale,omitempty"`
+}
+
+// Score rates a task from 0 to 100 and explains the factors that went into
+// it. Done and archived tasks always score 0.
+func (s *Service) Score(ctx context.Context, t domaintask.Task) (float64, []string) {
+    score, reasons, _ := s.score(t)
+    return score, reasons
+}
+
+// Explain is Score with each factor's share of the score; the contributions
+// add up to the score up to rounding.
+func (s *Service) Explain(ctx context.Context, t domaintask.Task) (float64, []Explanation) {
+    score, _, explanations := s.score(t)
+    return score, explanations
+}
+
+func (s *Service) score(t domaintask.Task) (float64, []string, []Explanation) {
+    if t.Status == domaintask.StatusDone || t.Status == domaintask.StatusArchived {
+        reason := fmt.Sprintf("task is %s", t.Status)
+        return 0, []string{reason}, []Explanation{{Factor: FactorStatus, Detail: reason}}
+    }
+    now := s.Now()
+    w := s.Weights
+    total := w.Priority + w.DueDate + w.Age + w.Status
+    if total <= 0 {
+        return 0, []string{"all weights are zero"}, []Explanation{}
+    }
+
+    var reasons []string
+
+    priority := clamp01(float64(t.Priority-domaintask.MinPriority) / float64(domaintask.MaxPriority-domaintask.MinPriority))
+    priorityDetail := fmt.Sprintf("priority %d/%d", t.Priority, domaintask.MaxPriority)
+    reasons = append(reasons, priorityDetail)
+
+    var due float64
+    var dueDetail string
+    switch {
+    case t.DueDate == nil:
+        due = noDueDateFactor
+        dueDetail = "no due date"
+    case !t.DueDate.After(now):
+        due = 1
+        dueDetail = "overdue by " + humanize(now.Sub(*t.DueDate))
+    default:
+        left := t.DueDate.Sub(now)
+        due = dueFactor(left)
+        dueDetail = "due in " + humanize(left)
+    }
+    reasons = append(reasons, dueDetail)
+
+    var age float64
+    ageDetail := "opened less than a day ago"
+    if !t.CreatedAt.IsZero() && t.CreatedAt.Before(now) {
+        open := now.Sub(t.CreatedAt)
+        age = clamp01(float64(open) / float64(ageHorizon))
+        if open >= 24*time.Hour {
+            ageDetail = "open for " + humanize(open)
+            reasons = append(reasons, ageDetail)
+        }
+    }
+
+    status, ok := statusFactor[t.Status]
+    if !ok {
+        status = statusFactor[domaintask.StatusTodo]
+    }
+    statusDetail := "not started"
+    if t.Status == domaintask.StatusInProgress {
+        statusDetail = "already in progress"
+        reasons = append(reasons, statusDetail)
+    }
+
+    explanations := []Explanation{
+        {Factor: FactorPriority, Contribution: round2(100 * w.Priority * priority / total), Detail: priorityDetail},
+        {Factor: FactorDueDate, Contribution: round2(100 * w.DueDate * due / total), Detail: dueDetail},
+        {Factor: FactorAge, Contribution: round2(100 * w.Age * age / total), Detail: ageDetail},
+        {Factor: FactorStatus, Contribution: round2(100 * w.Status * status / total), Detail: statusDetail},
+    }
+    score := 100 * (w.Priority*priority + w.DueDate*due + w.Age*age + w.Status*status) / total
+    return round2(score), reasons, explanations
+}
+
+// dueFactor rates a due date left away from 0 (beyond dueHorizon) to 1 (due
+// now). It rises linearly to dueRampStart at dueRampWindow, then steeply.
+func dueFactor(left time.Duration) float64 {
+    switch {
+    case left <= 0:
+        return 1
+    case left < dueRampWindow:
+        p := 1 - float64(left)/float64(dueRampWindow)
+        return dueRampStart + (1-dueRampStart)*math.Sqrt(p)
+    case left < dueHorizon:
+        return dueRampStart * (1 - float64(left-dueRampWindow)/float64(dueHorizon-dueRampWindow))
+    }
+    return 0
+}
+
+// WithProvider returns a copy of s that consults p in Rank.
+func (s *Service) WithProvider(p AIProvider) *Service {
+    cp := *s
+    cp.Provider = p
+    return &cp
+}
+
+// Rank scores every task and returns them from highest to lowest score.
+// Provider scores are blended with the rule score by Weights.AI. Ties are
+// broken by task ID so the order is deterministic.
+func (s *Service) Rank(ctx context.Context, tasks []domaintask.Task) []Ranked {
+    ranked, _ := s.RankWithReport(ctx, tasks)
+    return ranked
+}
+
+// RankWithReport is Rank that also reports which open tasks fell back to the
+// rules because the provider failed on or skipped them.
+func (s *Service) RankWithReport(ctx context.Context, tasks []domaintask.Task) ([]Ranked, ScoringReport) {
+    ai, report := s.aiScores(ctx, tasks)
+    share := clamp01(s.Weights.AI)
+    out := make([]Ranked, 0, len(tasks))
+    for _, t := range tasks {
+        r, ok := ai[t.ID]
+        if ok && share == 1 {
+            out = append(out, Ranked{
+                Task:         t,
+                Score:        r.Score,
+                Reasons:      []string{r.Reason},
+                Explanations: []Explanation{{Factor: FactorAI, Contribution: r.Score, Detail: r.Reason}},
+                Rationale:    r.Reason,
+            })
+            continue
+        }
+        score, reasons, explanations := s.score(t)
+        var rationale string
+        if ok {
+            score = round2(share*r.Score + (1-share)*score)
+            reasons = append([]string{r.Reason}, reasons...)
+            explanations = blendExplanations(explanations, share, r)
+            rationale = r.Reason
+        }
+        out = append(out, Ranked{Task: t, Score: score, Reasons: reasons, Explanations: explanations, Rationale: rationale})
+    }
+    sort.SliceStable(out, func(i, j int) bool {
+        if out[i].Score != out[j].Score {
+            return out[i].Score > out[j].Score
+        }
+        return out[i].Task.ID < out[j].Task.ID
+    })
+    return out, report
+}
+
+// aiScores asks the provider to score the open tasks in batches and returns
+// its validated answers by task ID; tasks without an answer fall back to the
+// rules and are listed in the report. The provider is not called when none
+// is set or Weights.AI is zero.
+func (s *Service) aiScores(ctx context.Context, tasks []domaintask.Task) (map[string]ScoredTask, ScoringReport) {
+    report := ScoringReport{Fallback: []string{}}
+    if s.Provider == nil || s.Weights.AI <= 0 {
+        return nil, report
+    }
+    open := make(map[string]bool, len(tasks))
+    summaries := make([]TaskSummary, 0, len(tasks))
+    for _, t := range tasks {
+        if t.Status == domaintask.StatusDone || t.Status == domaintask.StatusArchived {
+            continue
+        }
+        open[t.ID] = true
+        summaries = append(summaries, summarize(t))
+    }
+    if len(summaries) == 0 {
+        return nil, report
+    }
+
+    scored, report := s.scoreBatches(ctx, summaries)
+    out := make(map[string]ScoredTask, len(scored))
+    for _, r := range scored {
+        if !open[r.TaskID] || math.IsNaN(r.Score) {
+            continue
+        }
+        r.Score = round2(100 * clamp01(r.Score/100))
+        r.Reason = validRationale(r.Reason)
+        out[r.TaskID] = r
+    }
+    for _, sm := range summaries {
+        if _, ok := out[sm.ID]; !ok {
+            report.Fallback = append(report.Fallback, sm.ID)
+        }
+    }
+    return out, report
+}
+
+// round2 rounds v to two decimals, the precision of reported scores.
+func round2(v float64) float64 {
+    return math.Round(v*100) / 100
+}
+
+func clamp01(v float64) float64 {
+    return math.Max(0, math.Min(1, v))
+}
+
+// humanize renders d as whole days, or whole hours when under a day.
+func humanize(d time.Duration) string {
+    if d >= 24*time.Hour {
+        days := int(d / (24 * time.Hour))
+        if days == 1 {
+            return "1 day"
+        }
+        return fmt.Sprintf("%d days", days)
+    }
+    hours := int(d / time.Hour)
+    if hours == 1 {
+        return "1 hour"
+    }
+    return fmt.Sprintf("%d hours", hours)
+}
@@ -0,0 +1,252 @@
+package prioritize
+
+import (
+    "context"
+    "errors"
+    "reflect"
+    "testing"
+    "time"
+
+    domaintask "backend/internal/domain/task"
+)
+
+var testNow = time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+
+func newTestService() *Service {
+    s := NewService()
+    s.Now = func() time.Time { return testNow }
+    // Retry failed provider batches at once.
+    s.Batching.RetryBackoff = 0
+    return s
+}
+
+func at(d time.Duration) *time.Time {
+    t := testNow.Add(d)
+    return &t
+}
+
+// Test that representative tasks get the exact scores and reasons implied by
+// the default weights.
+func TestService_Score(t *testing.T) {
+    day := 24 * time.Hour
+    cases := []struct {
+        name    string
+        task    domaintask.Task
+        score   float64
+        reasons []string
+    }{
+        {
+            name:    "overdue high priority in progress",
+            task:    domaintask.Task{Priority: 10, Status: domaintask.StatusInProgress, DueDate: at(-2 * day), CreatedAt: testNow.Add(-40 * day)},
+            score:   100,
+            reasons: []string{"priority 10/10", "overdue by 2 days", "open for 40 days", "already in progress"},
+        },
+        {
+            name:    "no due date, new, default priority",
+            task:    domaintask.Task{Priority: 5, Status: domaintask.StatusTodo, CreatedAt: testNow},
+            score:   34.03,
+            reasons: []string{"priority 5/10", "no due date"},
+        },
+        {
+            name:    "due in a week, low priority, half-aged",
+            task:    domaintask.Task{Priority: 1, Status: domaintask.StatusTodo, DueDate: at(7 * day), CreatedAt: testNow.Add(-15 * day)},
+            score:   22.71,
+            reasons: []string{"priority 1/10", "due in 7 days", "open for 15 days"},
+        },
+        {
+            name:    "due beyond the horizon",
+            task:    domaintask.Task{Priority: 1, Status: domaintask.StatusTodo, DueDate: at(30 * day), CreatedAt: testNow},
+            score:   7.5,
+            reasons: []string{"priority 1/10", "due in 30 days"},
+        },
+        {
+            name:    "due in hours",
+            task:    domaintask.Task{Priority: 1, Status: domaintask.StatusTodo, DueDate: at(5 * time.Hour), CreatedAt: testNow},
+            score:   41.56,
+            reasons: []string{"priority 1/10", "due in 5 hours"},
+        },
+        {
+            name:    "done scores zero",
+            task:    domaintask.Task{Priority: 10, Status: domaintask.StatusDone, DueDate: at(-2 * day), CreatedAt: testNow.Add(-40 * day)},
+            score:   0,
+            reasons: []string{"task is done"},
+        },
+        {
+            name:    "archived scores zero",
+            task:    domaintask.Task{Priority: 10, Status: domaintask.StatusArchived},
+            score:   0,
+            reasons: []string{"task is archived"},
+        },
+    }
+    s := newTestService()
+    for _, tc := range cases {
+        score, reasons := s.Score(context.Background(), tc.task)
+        if score != tc.score {
+            t.Fatalf("%s: expected score %v, got %v", tc.name, tc.score, score)
+        }
+        if !reflect.DeepEqual(reasons, tc.reasons) {
+            t.Fatalf("%s: expected reasons %v, got %v", tc.name, tc.reasons, reasons)
+        }
+    }
+}
+
+// Test that, as a fake clock approaches a fixed due date, the score never
+// drops and climbs faster inside the last 48 hours than before them.
+func TestService_Score_DeadlineApproach(t *testing.T) {
+    s := newTestService()
+    due := testNow.Add(20 * 24 * time.Hour)
+    tk := domaintask.Task{Priority: 5, Status: domaintask.StatusTodo, DueDate: &due, CreatedAt: testNow}
+
+    scoreAt := func(before time.Duration) float64 {
+        s.Now = func() time.Time { return due.Add(-before) }
+        score, _ := s.Score(context.Background(), tk)
+        return score
+    }
+    prev := -1.0
+    for before := 20 * 24 * time.Hour; before >= -24*time.Hour; before -= time.Hour {
+        score := scoreAt(before)
+        if score < prev {
+            t.Fatalf("score dropped from %v to %v %v before the due date", prev, score, before)
+        }
+        prev = score
+    }
+
+    outside := scoreAt(72*time.Hour) - scoreAt(96*time.Hour)
+    inside := scoreAt(24*time.Hour) - scoreAt(48*time.Hour)
+    if inside <= 2*outside {
+        t.Fatalf("expected a sharp ramp inside 48h: %v over the last day-pair vs %v before", inside, outside)
+    }
+    s.Now = func() time.Time { return due.Add(72 * time.Hour) }
+    if _, reasons := s.Score(context.Background(), tk); reasons[1] != "overdue by 3 days" {
+        t.Fatalf("expected an overdue reason, got %v", reasons)
+    }
+}
+
+// Test that an undated task scores above one due far in the future but
+// below one due tomorrow.
+func TestService_Score_NoDueDateIsNeutral(t *testing.T) {
+    s := newTestService()
+    undated := domaintask.Task{Priority: 5, Status: domaintask.StatusTodo, CreatedAt: testNow}
+    far := undated
+    far.DueDate = at(60 * 24 * time.Hour)
+    soon := undated
+    soon.DueDate = at(24 * time.Hour)
+
+    u, _ := s.Score(context.Background(), undated)
+    f, _ := s.Score(context.Background(), far)
+    n, _ := s.Score(context.Background(), soon)
+    if !(f < u && u < n) {
+        t.Fatalf("expected far %v < undated %v < soon %v", f, u, n)
+    }
+}
+
+// Test that scores stay within 0–100 for extreme inputs.
+func TestService_Score_Bounds(t *testing.T) {
+    s := newTestService()
+    for _, tk := range []domaintask.Task{
+        {Priority: -50, Status: "weird", CreatedAt: testNow.Add(time.Hour)},
+        {Priority: 500, Status: domaintask.StatusInProgress, DueDate: at(-1000 * time.Hour), CreatedAt: testNow.Add(-10000 * time.Hour)},
+    } {
+        score, _ := s.Score(context.Background(), tk)
+        if score < 0 || score > 100 {
+            t.Fatalf("score %v out of range for %+v", score, tk)
+        }
+    }
+}
+
+// Test that changing the weights changes the outcome.
+func TestService_Score_CustomWeights(t *testing.T) {
+    s := newTestService()
+    s.Weights = Weights{DueDate: 1}
+    score, _ := s.Score(context.Background(), domaintask.Task{Priority: 1, Status: domaintask.StatusTodo, DueDate: at(-time.Hour)})
+    if score != 100 {
+        t.Fatalf("expected 100 with due-date-only weights, got %v", score)
+    }
+    s.Weights = Weights{}
+    if score, _ := s.Score(context.Background(), domaintask.Task{Priority: 10}); score != 0 {
+        t.Fatalf("expected 0 with zero weights, got %v", score)
+    }
+}
+
+// Test that Rank orders by descending score and breaks ties by ID.
+func TestService_Rank(t *testing.T) {
+    s := newTestService()
+    tasks := []domaintask.Task{
+        {ID: "done", Priority: 10, Status: domaintask.StatusDone},
+        {ID: "b", Priority: 5, Status: domaintask.StatusTodo, CreatedAt: testNow},
+        {ID: "urgent", Priority: 9, Status: domaintask.StatusTodo, DueDate: at(-time.Hour), CreatedAt: testNow},
+        {ID: "a", Priority: 5, Status: domaintask.StatusTodo, CreatedAt: testNow},
+    }
+    ranked := s.Rank(context.Background(), tasks)
+    var ids []string
+    for _, r := range ranked {
+        ids = append(ids, r.Task.ID)
+    }
+    want := []string{"urgent", "a", "b", "done"}
+    if !reflect.DeepEqual(ids, want) {
+        t.Fatalf("expected order %v, got %v", want, ids)
+    }
+}
+
+// fakeProvider returns canned scores or an error.
+type fakeProvider struct {
+    scores []ScoredTask
+    err    error
+    seen   []TaskSummary
+}
+
+func (f *fakeProvider) ScoreTasks(_ context.Context, tasks []TaskSummary) ([]ScoredTask, error) {
+    f.seen = tasks
+    return f.scores, f.err
+}
+
+// Test that provider scores are clamped, unknown ids ignored, and omitted
+// or closed tasks scored by the rules.
+func TestService_Rank_Provider(t *testing.T) {
+    p := &fakeProvider{scores: []ScoredTask{
+        {TaskID: "a", Score: 250, Reason: "critical"},
+        {TaskID: "b", Score: -3},
+        {TaskID: "ghost", Score: 99},
+    }}
+    s := newTestService().WithProvider(p)
+    tasks := []domaintask.Task{
+        {ID: "a", Priority: 1, Status: domaintask.StatusTodo, CreatedAt: testNow},
+        {ID: "b", Priority: 10, Status: domaintask.StatusTodo, CreatedAt: testNow},
+        {ID: "c", Priority: 5, Status: domaintask.StatusTodo, CreatedAt: testNow},
+        {ID: "done", Priority: 10, Status: domaintask.StatusDone},
+    }
+    ranked := s.Rank(context.Background(), tasks)
+
+    if len(p.seen) != 3 {
+        t.Fatalf("expected 3 open tasks sent to provider, got %d", len(p.seen))
+    }
+    got := map[string]Ranked{}
+    for _, r := range ranked {
+        got[r.Task.ID] = r
+    }
+    if got["a"].Score != 100 || !reflect.DeepEqual(got["a"].Reasons, []string{"critical"}) {
+        t.Fatalf("expected a clamped to 100 with provider reason, got %+v", got["a"])
+    }
+    if got["b"].Score != 0 {
+        t.Fatalf("expected b clamped to 0, got %v", got["b"].Score)
+    }
+    if got["c"].Score != 34.03 {
+        t.Fatalf("expected c to fall back to the rules, got %v", got["c"].Score)
+    }
+    if got["done"].Score != 0 {
+        t.Fatalf("expected done to score 0, got %v", got["done"].Score)
+    }
+}
+
+// Test that a failing provider leaves the rule-based ranking intact.
+func TestService_Rank_ProviderError(t *testing.T) {
+    tasks := []domaintask.Task{
+        {ID: "a", Priority: 2, Status: domaintask.StatusTodo, CreatedAt: testNow},
+        {ID: "b", Priority: 9, Status: domaintask.StatusTodo, CreatedAt: testNow},
+    }
+    want := newTestService().Rank(context.Background(), tasks)
+    got := newTestService().WithProvider(&fakeProvider{err: errors.New("boom")}).Rank(context.Background(), tasks)
+    if !reflect.DeepEqual(got, want) {
+        t.Fatalf("expected rule-based ranking %+v, got %+v", want, got)
+    }
+}
@@ -0,0 +1,121 @@
+package prioritize
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "math"
+    "time"
+)
+
+// ErrInvalidWeights is returned when weights are negative, all rule weights
+// are zero, or the AI weight exceeds one.
+var ErrInvalidWeights = errors.New("invalid weights")
+
+// Normalize validates w and scales the rule weights (priority, due date, age
+// and status) so they sum to one. AI is a share in [0, 1] and kept as is.
+func (w Weights) Normalize() (Weights, error) {
+    for name, v := range map[string]float64{
+        "priority": w.Priority,
+        "dueDate":  w.DueDate,
+        "age":      w.Age,
+        "status":   w.Status,
+        "ai":       w.AI,
+    } {
+        if v < 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+            return Weights{}, fmt.Errorf("%w: %s weight must be a non-negative number", ErrInvalidWeights, name)
+        }
+    }
+    if w.AI > 1 {
+        return Weights{}, fmt.Errorf("%w: ai weight must be at most 1", ErrInvalidWeights)
+    }
+    total := w.Priority + w.DueDate + w.Age + w.Status
+    if total <= 0 {
+        return Weights{}, fmt.Errorf("%w: at least one rule weight must be positive", ErrInvalidWeights)
+    }
+    return Weights{
+        Priority: w.Priority / total,
+        DueDate:  w.DueDate / total,
+        Age:      w.Age / total,
+        Status:   w.Status / total,
+        AI:       w.AI,
+    }, nil
+}
+
+// Settings is a tenant's prioritization configuration.
+type Settings struct {
+    Weights    Weights
+    Thresholds Thresholds
+    // AutoPrioritize opts the tenant into scheduled re-prioritization.
+    AutoPrioritize bool
+    // LastAutoRunAt is when the scheduler last finished a run for the
+    // tenant. It is kept by the repository and ignored on save.
+    LastAutoRunAt *time.Time
+}
+
+// Normalize normalizes the weights and validates the thresholds.
+func (st Settings) Normalize() (Settings, error) {
+    w, err := st.Weights.Normalize()
+    if err != nil {
+        return Settings{}, err
+    }
+    if err := st.Thresholds.Validate(); err != nil {
+        return Settings{}, err
+    }
+    st.Weights = w
+    return st, nil
+}
+
+// WithSettings returns a copy of s that reads tenant settings from repo.
+func (s *Service) WithSettings(repo SettingsRepository) *Service {
+    cp := *s
+    cp.Settings = repo
+    return &cp
+}
+
+// TenantSettings returns the settings the tenant has saved, or s.Weights and
+// s.Thresholds when it has none (or no SettingsRepository is configured).
+func (s *Service) TenantSettings(ctx context.Context, tenantID string) (Settings, error) {
+    defaults := Settings{Weights: s.Weights, Thresholds: s.Thresholds}
+    if s.Settings == nil {
+        return defaults, nil
+    }
+    st, err := s.Settings.GetSettings(ctx, tenantID)
+    if errors.Is(err, ErrSettingsNotFound) {
+        return defaults, nil
+    }
+    if err != nil {
+        return Settings{}, err
+    }
+    return st, nil
+}
+
+// SaveTenantSettings normalizes st and stores it for the tenant, dropping its
+// cached results. Stored task scores are not recomputed; the new weights
+// apply from the next run.
+func (s *Service) SaveTenantSettings(ctx context.Context, tenantID string, st Settings) (Settings, error) {
+    if s.Settings == nil {
+        return Settings{}, ErrSettingsUnavailable
+    }
+    st, err := st.Normalize()
+    if err != nil {
+        return Settings{}, err
+    }
+    if err := s.Settings.SaveSettings(ctx, tenantID, st); err != nil {
+        return Settings{}, err
+    }
+    s.Cache.Invalidate(tenantID)
+    return st, nil
+}
+
+// ForTenant returns a copy of s that uses the tenant's settings.
+func (s *Service) ForTenant(ctx context.Context, tenantID string) (*Service, error) {
+    st, err := s.TenantSettings(ctx, tenantID)
+    if err != nil {
+        return nil, err
+    }
+    cp := *s
+    cp.Weights = st.Weights
+    cp.Thresholds = st.Thresholds
+    return &cp, nil
+}
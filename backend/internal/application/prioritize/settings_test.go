@@ -0,0 +1,132 @@
+package prioritize
+
+import (
+    "context"
+    "errors"
+    "math"
+    "slices"
+    "testing"
+    "time"
+
+    domaintask "backend/internal/domain/task"
+)
+
+// mapSettings is a SettingsRepository backed by a map.
+type mapSettings map[string]Settings
+
+func (m mapSettings) GetSettings(_ context.Context, tenantID string) (Settings, error) {
+    st, ok := m[tenantID]
+    if !ok {
+        return Settings{}, ErrSettingsNotFound
+    }
+    return st, nil
+}
+
+func (m mapSettings) SaveSettings(_ context.Context, tenantID string, st Settings) error {
+    st.LastAutoRunAt = m[tenantID].LastAutoRunAt
+    m[tenantID] = st
+    return nil
+}
+
+func (m mapSettings) ListAutoPrioritized(context.Context) ([]string, error) {
+    var out []string
+    for tenantID, st := range m {
+        if st.AutoPrioritize {
+            out = append(out, tenantID)
+        }
+    }
+    slices.Sort(out)
+    return out, nil
+}
+
+func (m mapSettings) SaveLastAutoRun(_ context.Context, tenantID string, at time.Time) error {
+    st, ok := m[tenantID]
+    if !ok {
+        return ErrSettingsNotFound
+    }
+    st.LastAutoRunAt = &at
+    m[tenantID] = st
+    return nil
+}
+
+// Test that Normalize scales the rule weights to sum to one and rejects
+// negative, all-zero and oversized AI weights.
+func TestWeights_Normalize(t *testing.T) {
+    w, err := Weights{Priority: 2, DueDate: 1, Age: 1, AI: 0.5}.Normalize()
+    if err != nil {
+        t.Fatalf("normalize: %v", err)
+    }
+    if w.Priority != 0.5 || w.DueDate != 0.25 || w.Age != 0.25 || w.Status != 0 || w.AI != 0.5 {
+        t.Fatalf("unexpected normalized weights %+v", w)
+    }
+    for _, bad := range []Weights{
+        {Priority: -1, DueDate: 1},
+        {AI: 1},
+        {Priority: 1, AI: 1.5},
+        {Priority: math.NaN()},
+    } {
+        if _, err := bad.Normalize(); !errors.Is(err, ErrInvalidWeights) {
+            t.Fatalf("expected ErrInvalidWeights for %+v, got %v", bad, err)
+        }
+    }
+}
+
+// Test that ForTenant uses the defaults until the tenant saves settings, and
+// only that tenant's settings afterwards.
+func TestService_ForTenant(t *testing.T) {
+    s := newTestService().WithSettings(mapSettings{})
+    tk := domaintask.Task{Priority: 1, Status: domaintask.StatusTodo, DueDate: at(-time.Hour), CreatedAt: testNow}
+
+    before, err := s.ForTenant(context.Background(), "t1")
+    if err != nil {
+        t.Fatalf("for tenant: %v", err)
+    }
+    if before.Weights != DefaultWeights() {
+        t.Fatalf("expected default weights, got %+v", before.Weights)
+    }
+    saved := Settings{Weights: Weights{DueDate: 3}, Thresholds: Thresholds{UrgentWithin: time.Hour, ImportantPriority: 9}}
+    if _, err := s.SaveTenantSettings(context.Background(), "t1", saved); err != nil {
+        t.Fatalf("save: %v", err)
+    }
+
+    after, _ := s.ForTenant(context.Background(), "t1")
+    if score, _ := after.Score(context.Background(), tk); score != 100 {
+        t.Fatalf("expected due-date-only score 100, got %v", score)
+    }
+    if after.Thresholds != saved.Thresholds {
+        t.Fatalf("expected saved thresholds, got %+v", after.Thresholds)
+    }
+    other, _ := s.ForTenant(context.Background(), "t2")
+    if other.Weights != DefaultWeights() || other.Thresholds != DefaultThresholds() {
+        t.Fatalf("expected other tenant to keep defaults, got %+v", other.Weights)
+    }
+}
+
+// Test that saving without a repository is reported rather than ignored.
+func TestService_SaveTenantSettings_Unavailable(t *testing.T) {
+    st := Settings{Weights: DefaultWeights(), Thresholds: DefaultThresholds()}
+    if _, err := newTestService().SaveTenantSettings(context.Background(), "t1", st); !errors.Is(err, ErrSettingsUnavailable) {
+        t.Fatalf("expected ErrSettingsUnavailable, got %v", err)
+    }
+}
+
+// Test that the AI weight blends provider and rule scores, and that a zero
+// AI weight skips the provider.
+func TestService_Rank_AIWeight(t *testing.T) {
+    tk := domaintask.Task{ID: "a", Priority: 5, Status: domaintask.StatusTodo, CreatedAt: testNow}
+    p := &fakeProvider{scores: []ScoredTask{{TaskID: "a", Score: 84.03, Reason: "ai"}}}
+    s := newTestService().WithProvider(p)
+
+    s.Weights.AI = 0.5
+    ranked := s.Rank(context.Background(), []domaintask.Task{tk})
+    if ranked[0].Score != 59.03 || ranked[0].Reasons[0] != "ai" {
+        t.Fatalf("expected blended score 59.03 led by the AI reason, got %+v", ranked[0])
+    }
+
+    p.seen = nil
+    s.Weights.AI = 0
+    ranked = s.Rank(context.Background(), []domaintask.Task{tk})
+    if p.seen != nil || ranked[0].Score != 34.03 {
+        t.Fatalf("expected rules only without calling the provider, got %+v", ranked[0])
+    }
+}
@@ -0,0 +1,49 @@
+package project
+
+import (
+    "context"
+    "errors"
+    "fmt"
+
+    domainproject "backend/internal/domain/project"
+)
+
+// DeleteMode selects what happens to a project's tasks when it is deleted.
+type DeleteMode string
+
+const (
+    // DeleteModeUnset refuses to delete a project that still has tasks.
+    DeleteModeUnset DeleteMode = ""
+    // DeleteModeDetach clears ProjectID on the contained tasks.
+    DeleteModeDetach DeleteMode = "detach"
+    // DeleteModeCascade soft-deletes the contained tasks.
+    DeleteModeCascade DeleteMode = "cascade"
+)
+
+// ErrNotFound is returned when a project does not exist for the tenant.
+var ErrNotFound = errors.New("project not found")
+
+// HasTasksError is returned when a project with tasks is deleted without a mode.
+type HasTasksError struct {
+    Count int64
+}
+
+func (e *HasTasksError) Error() string {
+    return fmt.Sprintf("project still has %d tasks", e.Count)
+}
+
+// Repository defines persistence operations for projects.
+type Repository interface {
+    ListByTenant(ctx context.Context, tenantID string) ([]domainproject.Project, error)
+    Get(ctx context.Context, tenantID, id string) (*domainproject.Project, error)
+    Create(ctx context.Context, p *domainproject.Project) error
+    UpdatePosition(ctx context.Context, tenantID, id string, position float64) error
+    // SetFavorite marks or unmarks a project as a favorite of the given user.
+    // It is idempotent in both directions.
+    SetFavorite(ctx context.Context, tenantID, userID, projectID string, favorite bool) error
+    ListFavoriteIDs(ctx context.Context, tenantID, userID string) ([]string, error)
+    // DeleteWithTasks removes the project and its favorites, applying mode to
+    // its tasks, in a single transaction. With DeleteModeUnset it returns
+    // *HasTasksError when the project is not empty.
+    DeleteWithTasks(ctx context.Context, tenantID, id string, mode DeleteMode) error
+}
@@ -0,0 +1,174 @@
+package project
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "sort"
+    "strings"
+
+    domainproject "backend/internal/domain/project"
+)
+
+// Service implements project-related application use cases.
+type Service struct {
+    repo Repository
+}
+
+func NewService(repo Repository) *Service {
+    return &Service{repo: repo}
+}
+
+// ParseDeleteMode converts a query value into a DeleteMode.
+func ParseDeleteMode(s string) (DeleteMode, error) {
+    switch m := DeleteMode(strings.ToLower(strings.TrimSpace(s))); m {
+    case DeleteModeUnset, DeleteModeDetach, DeleteModeCascade:
+        return m, nil
+    default:
+        return "", fmt.Errorf("unknown delete mode %q", s)
+    }
+}
+
+// ProjectView is a project as seen by a particular user.
+type ProjectView struct {
+    domainproject.Project
+    IsFavorite bool `json:"isFavorite"`
+}
+
+// ErrInvalidMove is returned when a move request does not name exactly one
+// anchor, or names the project itself.
+var ErrInvalidMove = errors.New("exactly one of beforeId or afterId must reference another project")
+
+// List returns the tenant's projects for userID, favorites first and then in
+// manual position order.
+func (s *Service) List(ctx context.Context, tenantID, userID string) ([]ProjectView, error) {
+    items, err := s.repo.ListByTenant(ctx, tenantID)
+    if err != nil {
+        return nil, err
+    }
+    favIDs, err := s.repo.ListFavoriteIDs(ctx, tenantID, userID)
+    if err != nil {
+        return nil, err
+    }
+    favs := make(map[string]bool, len(favIDs))
+    for _, id := range favIDs {
+        favs[id] = true
+    }
+    out := make([]ProjectView, 0, len(items))
+    for _, p := range items {
+        out = append(out, ProjectView{Project: p, IsFavorite: favs[p.ID]})
+    }
+    sort.SliceStable(out, func(i, j int) bool {
+        if out[i].IsFavorite != out[j].IsFavorite {
+            return out[i].IsFavorite
+        }
+        if out[i].Position != out[j].Position {
+            return out[i].Position < out[j].Position
+        }
+        return out[i].CreatedAt.Before(out[j].CreatedAt)
+    })
+    return out, nil
+}
+
+func (s *Service) Create(ctx context.Context, tenantID, name, description string) (*domainproject.Project, error) {
+    if strings.TrimSpace(name) == "" {
+        return nil, errors.New("name is required")
+    }
+    existing, err := s.repo.ListByTenant(ctx, tenantID)
+    if err != nil {
+        return nil, err
+    }
+    p := domainproject.New(tenantID, name, description)
+    // Append to the end of the manual ordering.
+    for _, e := range existing {
+        if e.Position >= p.Position {
+            p.Position = e.Position + 1
+        }
+    }
+    if err := s.repo.Create(ctx, p); err != nil {
+        return nil, err
+    }
+    return p, nil
+}
+
+func (s *Service) Get(ctx context.Context, tenantID, id string) (*domainproject.Project, error) {
+    return s.repo.Get(ctx, tenantID, id)
+}
+
+// SetFavorite marks or unmarks the project as one of userID's favorites.
+func (s *Service) SetFavorite(ctx context.Context, tenantID, userID, id string, favorite bool) error {
+    if _, err := s.repo.Get(ctx, tenantID, id); err != nil {
+        return err
+    }
+    return s.repo.SetFavorite(ctx, tenantID, userID, id, favorite)
+}
+
+// Move places a project directly before beforeID or directly after afterID
+// in the tenant's manual ordering. Exactly one anchor must be given. The new
+// position is the midpoint between the anchor and its neighbour, so only the
+// moved project is written.
+func (s *Service) Move(ctx context.Context, tenantID, id, beforeID, afterID string) (*domainproject.Project, error) {
+    if (beforeID == "") == (afterID == "") {
+        return nil, ErrInvalidMove
+    }
+    anchorID := beforeID
+    if afterID != "" {
+        anchorID = afterID
+    }
+    if anchorID == id {
+        return nil, ErrInvalidMove
+    }
+
+    items, err := s.repo.ListByTenant(ctx, tenantID)
+    if err != nil {
+        return nil, err
+    }
+    sort.SliceStable(items, func(i, j int) bool { return items[i].Position < items[j].Position })
+
+    var target *domainproject.Project
+    others := items[:0:0]
+    for i := range items {
+        if items[i].ID == id {
+            target = &items[i]
+            continue
+        }
+        others = append(others, items[i])
+    }
+    if target == nil {
+        return nil, ErrNotFound
+    }
+    anchor := -1
+    for i := range others {
+        if others[i].ID == anchorID {
+            anchor = i
+            break
+        }
+    }
+    if anchor < 0 {
+        return nil, ErrNotFound
+    }
+
+    var pos float64
+    if afterID != "" {
+        pos = others[anchor].Position + 1
+        if anchor+1 < len(others) {
+            pos = (others[anchor].Position + others[anchor+1].Position) / 2
+        }
+    } else {
+        pos = others[anchor].Position - 1
+        if anchor > 0 {
+            pos = (others[anchor-1].Position + others[anchor].Position) / 2
+        }
+    }
+
+    if err := s.repo.UpdatePosition(ctx, tenantID, id, pos); err != nil {
+        return nil, err
+    }
+    target.Position = pos
+    return target, nil
+}
+
+// Delete removes a project, handling its tasks according to mode.
+func (s *Service) Delete(ctx context.Context, tenantID, id string, mode DeleteMode) error {
+    return s.repo.DeleteWithTasks(ctx, tenantID, id, mode)
+}
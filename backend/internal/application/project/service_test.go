@@ -0,0 +1,211 @@
+package project_test
+
+import (
+    "context"
+    "errors"
+    "strings"
+    "testing"
+
+    appproject "backend/internal/application/project"
+    domaintask "backend/internal/domain/task"
+    "backend/internal/infrastructure/memory"
+)
+
+// seed creates a project with n tasks attached to it and returns the
+// service, the task repository and the project id.
+func seed(t *testing.T, n int) (*appproject.Service, *memory.TaskRepository, string) {
+    t.Helper()
+    tasks := memory.NewTaskRepository()
+    svc := appproject.NewService(memory.NewProjectRepository(tasks))
+    p, err := svc.Create(context.Background(), "t1", "Launch", "")
+    if err != nil {
+        t.Fatalf("create project: %v", err)
+    }
+    for i := 0; i < n; i++ {
+        tk := domaintask.New("t1", "u1", "task", "", 5)
+        tk.ProjectID = &p.ID
+        if err := tasks.Create(context.Background(), tk); err != nil {
+            t.Fatalf("create task: %v", err)
+        }
+    }
+    return svc, tasks, p.ID
+}
+
+// Test that an empty project is deleted even when no mode is given.
+func TestService_Delete_EmptyProject(t *testing.T) {
+    svc, _, id := seed(t, 0)
+    if err := svc.Delete(context.Background(), "t1", id, appproject.DeleteModeUnset); err != nil {
+        t.Fatalf("delete: %v", err)
+    }
+    if _, err := svc.Get(context.Background(), "t1", id); !errors.Is(err, appproject.ErrNotFound) {
+        t.Fatalf("expected ErrNotFound, got %v", err)
+    }
+}
+
+// Test that omitting the mode on a non-empty project reports the task count
+// and leaves everything in place.
+func TestService_Delete_UnsetWithTasks(t *testing.T) {
+    svc, tasks, id := seed(t, 3)
+    err := svc.Delete(context.Background(), "t1", id, appproject.DeleteModeUnset)
+    var hasTasks *appproject.HasTasksError
+    if !errors.As(err, &hasTasks) {
+        t.Fatalf("expected HasTasksError, got %v", err)
+    }
+    if hasTasks.Count != 3 {
+        t.Fatalf("expected count 3, got %d", hasTasks.Count)
+    }
+    if _, err := svc.Get(context.Background(), "t1", id); err != nil {
+        t.Fatalf("project should still exist: %v", err)
+    }
+    items, _ := tasks.ListByTenant(context.Background(), "t1")
+    if len(items) != 3 {
+        t.Fatalf("expected 3 tasks, got %d", len(items))
+    }
+}
+
+// Test that detach keeps the tasks but clears their project reference.
+func TestService_Delete_Detach(t *testing.T) {
+    svc, tasks, id := seed(t, 2)
+    if err := svc.Delete(context.Background(), "t1", id, appproject.DeleteModeDetach); err != nil {
+        t.Fatalf("delete: %v", err)
+    }
+    items, _ := tasks.ListByTenant(context.Background(), "t1")
+    if len(items) != 2 {
+        t.Fatalf("expected 2 tasks, got %d", len(items))
+    }
+    for _, tk := range items {
+        if tk.ProjectID != nil {
+            t.Fatalf("task %s still references project %s", tk.ID, *tk.ProjectID)
+        }
+    }
+}
+
+// Test that cascade removes the contained tasks along with the project.
+func TestService_Delete_Cascade(t *testing.T) {
+    svc, tasks, id := seed(t, 2)
+    if err := svc.Delete(context.Background(), "t1", id, appproject.DeleteModeCascade); err != nil {
+        t.Fatalf("delete: %v", err)
+    }
+    items, _ := tasks.ListByTenant(context.Background(), "t1")
+    if len(items) != 0 {
+        t.Fatalf("expected no tasks, got %d", len(items))
+    }
+    if _, err := svc.Get(context.Background(), "t1", id); !errors.Is(err, appproject.ErrNotFound) {
+        t.Fatalf("expected ErrNotFound, got %v", err)
+    }
+}
+
+// Test that ParseDeleteMode accepts the known modes and rejects others.
+func TestParseDeleteMode(t *testing.T) {
+    for _, s := range []string{"", "detach", "Cascade"} {
+        if _, err := appproject.ParseDeleteMode(s); err != nil {
+            t.Fatalf("mode %q: unexpected error %v", s, err)
+        }
+    }
+    if _, err := appproject.ParseDeleteMode("purge"); err == nil {
+        t.Fatalf("expected error for unknown mode")
+    }
+}
+
+// names returns the project names of views in order.
+func names(views []appproject.ProjectView) []string {
+    out := make([]string, 0, len(views))
+    for _, v := range views {
+        out = append(out, v.Name)
+    }
+    return out
+}
+
+// Test that List puts the caller's favorites first, then orders by position,
+// and that favorites are per user.
+func TestService_List_FavoritesFirst(t *testing.T) {
+    ctx := context.Background()
+    svc := appproject.NewService(memory.NewProjectRepository(memory.NewTaskRepository()))
+    var ids []string
+    for _, n := range []string{"a", "b", "c"} {
+        p, err := svc.Create(ctx, "t1", n, "")
+        if err != nil {
+            t.Fatalf("create: %v", err)
+        }
+        ids = append(ids, p.ID)
+    }
+    if err := svc.SetFavorite(ctx, "t1", "u1", ids[2], true); err != nil {
+        t.Fatalf("favorite: %v", err)
+    }
+
+    got, err := svc.List(ctx, "t1", "u1")
+    if err != nil {
+        t.Fatalf("list: %v", err)
+    }
+    if strings.Join(names(got), ",") != "c,a,b" {
+        t.Fatalf("expected c,a,b, got %v", names(got))
+    }
+    if !got[0].IsFavorite || got[1].IsFavorite {
+        t.Fatalf("unexpected isFavorite flags: %+v", got)
+    }
+
+    other, _ := svc.List(ctx, "t1", "u2")
+    if strings.Join(names(other), ",") != "a,b,c" {
+        t.Fatalf("expected a,b,c for another user, got %v", names(other))
+    }
+
+    if err := svc.SetFavorite(ctx, "t1", "u1", ids[2], false); err != nil {
+        t.Fatalf("unfavorite: %v", err)
+    }
+    got, _ = svc.List(ctx, "t1", "u1")
+    if strings.Join(names(got), ",") != "a,b,c" {
+        t.Fatalf("expected a,b,c after unfavorite, got %v", names(got))
+    }
+}
+
+// Test that Move honours before/after anchors and rejects ambiguous requests.
+func TestService_Move(t *testing.T) {
+    ctx := context.Background()
+    svc := appproject.NewService(memory.NewProjectRepository(memory.NewTaskRepository()))
+    ids := map[string]string{}
+    for _, n := range []string{"a", "b", "c", "d"} {
+        p, _ := svc.Create(ctx, "t1", n, "")
+        ids[n] = p.ID
+    }
+
+    if _, err := svc.Move(ctx, "t1", ids["d"], ids["a"], ""); err != nil {
+        t.Fatalf("move before: %v", err)
+    }
+    got, _ := svc.List(ctx, "t1", "u1")
+    if strings.Join(names(got), ",") != "d,a,b,c" {
+        t.Fatalf("expected d,a,b,c, got %v", names(got))
+    }
+
+    if _, err := svc.Move(ctx, "t1", ids["d"], "", ids["b"]); err != nil {
+        t.Fatalf("move after: %v", err)
+    }
+    got, _ = svc.List(ctx, "t1", "u1")
+    if strings.Join(names(got), ",") != "a,b,d,c" {
+        t.Fatalf("expected a,b,d,c, got %v", names(got))
+    }
+
+    if _, err := svc.Move(ctx, "t1", ids["a"], ids["b"], ids["c"]); !errors.Is(err, appproject.ErrInvalidMove) {
+        t.Fatalf("expected ErrInvalidMove, got %v", err)
+    }
+    if _, err := svc.Move(ctx, "t1", ids["a"], "missing", ""); !errors.Is(err, appproject.ErrNotFound) {
+        t.Fatalf("expected ErrNotFound, got %v", err)
+    }
+}
+
+// Test that deleting a project removes it from users' favorites.
+func TestService_Delete_CleansFavorites(t *testing.T) {
+    ctx := context.Background()
+    repo := memory.NewProjectRepository(memory.NewTaskRepository())
+    svc := appproject.NewService(repo)
+    p, _ := svc.Create(ctx, "t1", "a", "")
+    if err := svc.SetFavorite(ctx, "t1", "u1", p.ID, true); err != nil {
+        t.Fatalf("favorite: %v", err)
+    }
+    if err := svc.Delete(ctx, "t1", p.ID, appproject.DeleteModeUnset); err != nil {
+        t.Fatalf("delete: %v", err)
+    }
+    favs, _ := repo.ListFavoriteIDs(ctx, "t1", "u1")
+    if len(favs) != 0 {
+        t.Fatalf("expected no favorites, got %v", favs)
+    }
+}
@@ -0,0 +1,48 @@
+package revocation
+
+import (
+    "context"
+    "errors"
+    "time"
+)
+
+var (
+    // ErrTargetRequired is returned when a revocation names neither or
+    // both of a token and a user.
+    ErrTargetRequired = errors.New("exactly one of jti and userId is required")
+    // ErrUnavailable is returned by Service.Revoked when the revocation list
+    // cannot be loaded and the service fails closed.
+    ErrUnavailable = errors.New("revocation list unavailable")
+)
+
+// TokenRevocation revokes one access token by its jti. It is kept until
+// ExpiresAt, by which time the token has expired anyway.
+type TokenRevocation struct {
+    TenantID  string
+    TokenID   string
+    RevokedAt time.Time
+    ExpiresAt time.Time
+}
+
+// UserRevocation revokes every access token of a user issued at or before
+// RevokedBefore. It is kept until ExpiresAt, by which time those tokens have
+// expired anyway.
+type UserRevocation struct {
+    TenantID      string
+    UserID        string
+    RevokedBefore time.Time
+    ExpiresAt     time.Time
+}
+
+// Repository defines persistence operations for revoked tokens.
+type Repository interface {
+    // RevokeToken records r; revoking a revoked token again is a no-op.
+    RevokeToken(ctx context.Context, r *TokenRevocation) error
+    // RevokeUser records r, replacing an earlier revocation of the user, and
+    // revokes all of the user's refresh tokens at r.RevokedBefore so they
+    // cannot mint new access tokens.
+    RevokeUser(ctx context.Context, r *UserRevocation) error
+    // ListActive returns the token and user revocations that have not
+    // expired at now.
+    ListActive(ctx context.Context, now time.Time) ([]TokenRevocation, []UserRevocation, error)
+}
@@ -0,0 +1,210 @@
+package revocation
+
+import (
+    "context"
+    "fmt"
+    "log/slog"
+    "sync"
+    "time"
+)
+
+const (
+    // DefaultRefreshInterval is how often Revoked reloads the revocation
+    // list, so revocations made through other instances apply within it.
+    DefaultRefreshInterval = 10 * time.Second
+    // refreshTimeout bounds one reload of the revocation list.
+    refreshTimeout = 2 * time.Second
+)
+
+// Service revokes access tokens before they expire, one at a time by jti or
+// all of a user's at once. Revoked is called for every authenticated
+// request, so it answers from an in-memory copy of the revocation list that
+// is reloaded every refresh interval. When a reload fails the service fails
+// closed, reporting ErrUnavailable, unless WithFailOpen is set, in which case
+// the last list loaded keeps being used. Revoking always writes through to
+// the repository and fails with it.
+type Service struct {
+    repo      Repository
+    retention time.Duration
+    interval  time.Duration
+    failOpen  bool
+    now       func() time.Time
+
+    // refreshMu lets one caller at a time reload the list.
+    refreshMu sync.Mutex
+
+    mu          sync.RWMutex
+    tokens      map[tokenKey]bool
+    users       map[userKey]time.Time // -> RevokedBefore
+    loadedAt    time.Time             // last successful load; zero before the first
+    attemptedAt time.Time             // last load, successful or not
+    loadErr     error                 // error of the last load
+}
+
+type tokenKey struct {
+    tenantID, tokenID string
+}
+
+type userKey struct {
+    tenantID, userID string
+}
+
+// Option configures optional Service behaviour.
+type Option func(*Service)
+
+// WithRefreshInterval sets how often the revocation list is reloaded;
+// values below a second keep DefaultRefreshInterval.
+func WithRefreshInterval(d time.Duration) Option {
+    return func(s *Service) {
+        if d >= time.Second {
+            s.interval = d
+        }
+    }
+}
+
+// WithFailOpen makes Revoked keep using the last list loaded, rather than
+// fail, while the list cannot be reloaded. Before the first successful load
+// it fails either way.
+func WithFailOpen(failOpen bool) Option {
+    return func(s *Service) { s.failOpen = failOpen }
+}
+
+// WithClock sets the clock revocations are dated with.
+func WithClock(now func() time.Time) Option {
+    return func(s *Service) { s.now = now }
+}
+
+// NewService returns a service keeping revocations for retention, which
+// must be at least the lifetime of the longest-lived access token plus the
+// verifier's leeway.
+func NewService(repo Repository, retention time.Duration, opts ...Option) *Service {
+    s := &Service{
+        repo:      repo,
+        retention: retention,
+        interval:  DefaultRefreshInterval,
+        now:       time.Now,
+        tokens:    make(map[tokenKey]bool),
+        users:     make(map[userKey]time.Time),
+    }
+    for _, opt := range opts {
+        opt(s)
+    }
+    return s
+}
+
+// RevokeToken revokes the tenant's access token with the jti tokenID.
+func (s *Service) RevokeToken(ctx context.Context, tenantID, tokenID string) error {
+    if tokenID == "" {
+        return ErrTargetRequired
+    }
+    now := s.now().UTC()
+    r := &TokenRevocation{TenantID: tenantID, TokenID: tokenID, RevokedAt: now, ExpiresAt: now.Add(s.retention)}
+    if err := s.repo.RevokeToken(ctx, r); err != nil {
+        return err
+    }
+    s.mu.Lock()
+    s.tokens[tokenKey{tenantID, tokenID}] = true
+    s.mu.Unlock()
+    slog.InfoContext(ctx, "access token revoked", "tenant_id", tenantID, "jti", tokenID)
+    return nil
+}
+
+// RevokeUser revokes every access token issued to the user until now, and
+// the user's refresh tokens. Tokens issued afterwards, by signing in again,
+// are not affected.
+func (s *Service) RevokeUser(ctx context.Context, tenantID, userID string) error {
+    if userID == "" {
+        return ErrTargetRequired
+    }
+    now := s.now().UTC()
+    r := &UserRevocation{TenantID: tenantID, UserID: userID, RevokedBefore: now, ExpiresAt: now.Add(s.retention)}
+    if err := s.repo.RevokeUser(ctx, r); err != nil {
+        return err
+    }
+    s.mu.Lock()
+    s.users[userKey{tenantID, userID}] = now
+    s.mu.Unlock()
+    slog.InfoContext(ctx, "user tokens revoked", "tenant_id", tenantID, "user_id", userID)
+    return nil
+}
+
+// Revoked reports whether the access token with jti tokenID, issued to the
+// user at issuedAt, was revoked. Tokens without a jti can only be revoked
+// with their user; tokens without an issue time count as issued before any
+// user revocation. Issue times are whole seconds, so a token issued in the
+// second its user was revoked counts as revoked too.
+func (s *Service) Revoked(tenantID, userID, tokenID string, issuedAt time.Time) (bool, error) {
+    if err := s.ensureLoaded(); err != nil {
+        return false, err
+    }
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+    if tokenID != "" && s.tokens[tokenKey{tenantID, tokenID}] {
+        return true, nil
+    }
+    before, ok := s.users[userKey{tenantID, userID}]
+    return ok && (issuedAt.IsZero() || !issuedAt.After(before)), nil
+}
+
+// Refresh reloads the revocation list.
+func (s *Service) Refresh(ctx context.Context) error {
+    now := s.now()
+    tokens, users, err := s.repo.ListActive(ctx, now.UTC())
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    s.attemptedAt, s.loadErr = now, err
+    if err != nil {
+        return err
+    }
+    s.tokens = make(map[tokenKey]bool, len(tokens))
+    for _, r := range tokens {
+        s.tokens[tokenKey{r.TenantID, r.TokenID}] = true
+    }
+    s.users = make(map[userKey]time.Time, len(users))
+    for _, r := range users {
+        s.users[userKey{r.TenantID, r.UserID}] = r.RevokedBefore
+    }
+    s.loadedAt = now
+    return nil
+}
+
+// ensureLoaded reloads the list when the last load is older than the
+// refresh interval, and reports whether Revoked can answer. A failed load is
+// not retried before the interval has passed again.
+func (s *Service) ensureLoaded() error {
+    if fresh, err := s.lastLoad(); fresh && err == nil {
+        return nil
+    }
+    s.refreshMu.Lock()
+    defer s.refreshMu.Unlock()
+    // Another caller may have reloaded the list while this one waited
+    fresh, err := s.lastLoad()
+    if !fresh {
+        ctx, cancel := context.WithTimeout(context.Background(), refreshTimeout)
+        defer cancel()
+        if err = s.Refresh(ctx); err != nil {
+            slog.Error("revocation list refresh failed", "error", err, "fail_open", s.failOpen)
+        }
+    }
+    if err == nil {
+        return nil
+    }
+    s.mu.RLock()
+    loaded := !s.loadedAt.IsZero()
+    s.mu.RUnlock()
+    if s.failOpen && loaded {
+        return nil
+    }
+    return fmt.Errorf("%w: %v", ErrUnavailable, err)
+}
+
+// lastLoad reports whether the last load is recent enough to go by, and the
+// error it failed with.
+func (s *Service) lastLoad() (fresh bool, err error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+    if s.attemptedAt.IsZero() || s.now().Sub(s.attemptedAt) >= s.interval {
+        return false, nil
+    }
+    return true, s.loadErr
+}
@@ -0,0 +1,147 @@
+package revocation_test
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    apprevocation "backend/internal/application/revocation"
+    domainaccount "backend/internal/domain/account"
+    "backend/internal/infrastructure/memory"
+)
+
+// flakyRepository fails ListActive while down is set.
+type flakyRepository struct {
+    *memory.RevocationRepository
+    down bool
+}
+
+func (r *flakyRepository) ListActive(ctx context.Context, now time.Time) ([]apprevocation.TokenRevocation, []apprevocation.UserRevocation, error) {
+    if r.down {
+        return nil, nil, errors.New("database down")
+    }
+    return r.RevocationRepository.ListActive(ctx, now)
+}
+
+// clock is a settable time source.
+type clock struct{ now time.Time }
+
+func (c *clock) Now() time.Time { return c.now }
+
+var start = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// Test that a revoked jti is refused while other tokens of the user pass.
+func TestService_RevokeToken(t *testing.T) {
+    ctx := context.Background()
+    svc := apprevocation.NewService(memory.NewRevocationRepository(memory.NewAccountRepository()), time.Hour)
+    if err := svc.RevokeToken(ctx, "t1", "jti-1"); err != nil {
+        t.Fatalf("revoke: %v", err)
+    }
+
+    cases := []struct {
+        tenantID, tokenID string
+        want              bool
+    }{
+        {"t1", "jti-1", true},
+        {"t1", "jti-2", false},
+        {"t2", "jti-1", false},
+        {"t1", "", false},
+    }
+    for _, tc := range cases {
+        got, err := svc.Revoked(tc.tenantID, "u1", tc.tokenID, time.Now())
+        if err != nil || got != tc.want {
+            t.Fatalf("%s/%q: expected revoked %v, got %v, %v", tc.tenantID, tc.tokenID, tc.want, got, err)
+        }
+    }
+    if err := svc.RevokeToken(ctx, "t1", ""); !errors.Is(err, apprevocation.ErrTargetRequired) {
+        t.Fatalf("expected ErrTargetRequired, got %v", err)
+    }
+}
+
+// Test that revoking a user refuses the tokens issued until then, including
+// those without an issue time, but not later ones, and revokes the user's
+// refresh tokens.
+func TestService_RevokeUser(t *testing.T) {
+    ctx := context.Background()
+    accounts := memory.NewAccountRepository()
+    refresh, _, _ := domainaccount.NewRefreshToken("t1", "u1", "", start, time.Hour)
+    accounts.CreateRefreshToken(ctx, refresh)
+    c := &clock{now: start}
+    svc := apprevocation.NewService(memory.NewRevocationRepository(accounts), time.Hour, apprevocation.WithClock(c.Now))
+    if err := svc.RevokeUser(ctx, "t1", "u1"); err != nil {
+        t.Fatalf("revoke: %v", err)
+    }
+
+    cases := []struct {
+        userID   string
+        issuedAt time.Time
+        want     bool
+    }{
+        {"u1", start.Add(-time.Minute), true},
+        {"u1", start, true},
+        {"u1", time.Time{}, true},
+        {"u1", start.Add(time.Second), false},
+        {"u2", start.Add(-time.Minute), false},
+    }
+    for _, tc := range cases {
+        got, err := svc.Revoked("t1", tc.userID, "jti", tc.issuedAt)
+        if err != nil || got != tc.want {
+            t.Fatalf("%s issued %v: expected revoked %v, got %v, %v", tc.userID, tc.issuedAt, tc.want, got, err)
+        }
+    }
+    if got, _ := accounts.FindRefreshToken(ctx, refresh.Hash); got.RevokedAt == nil {
+        t.Fatalf("expected the user's refresh token to be revoked")
+    }
+}
+
+// Test that a revocation made through another instance applies once the
+// list is reloaded, and that revocations are dropped after the retention.
+func TestService_Refresh(t *testing.T) {
+    ctx := context.Background()
+    repo := memory.NewRevocationRepository(memory.NewAccountRepository())
+    c := &clock{now: start}
+    svc := apprevocation.NewService(repo, time.Hour, apprevocation.WithClock(c.Now), apprevocation.WithRefreshInterval(10*time.Second))
+    other := apprevocation.NewService(repo, time.Hour, apprevocation.WithClock(c.Now))
+
+    if got, _ := svc.Revoked("t1", "u1", "jti-1", start); got {
+        t.Fatalf("expected nothing revoked yet")
+    }
+    other.RevokeToken(ctx, "t1", "jti-1")
+    if got, _ := svc.Revoked("t1", "u1", "jti-1", start); got {
+        t.Fatalf("expected the cached list to be used within the interval")
+    }
+    c.now = start.Add(10 * time.Second)
+    if got, _ := svc.Revoked("t1", "u1", "jti-1", start); !got {
+        t.Fatalf("expected the revocation to apply after a reload")
+    }
+    c.now = start.Add(time.Hour + 20*time.Second)
+    if got, _ := svc.Revoked("t1", "u1", "jti-1", start); got {
+        t.Fatalf("expected the revocation to be dropped after the retention")
+    }
+}
+
+// Test that an unreachable revocation list refuses every check by default,
+// and that with WithFailOpen the last list loaded keeps answering.
+func TestService_Revoked_Unavailable(t *testing.T) {
+    ctx := context.Background()
+    for _, failOpen := range []bool{false, true} {
+        repo := &flakyRepository{RevocationRepository: memory.NewRevocationRepository(memory.NewAccountRepository())}
+        c := &clock{now: start}
+        svc := apprevocation.NewService(repo, time.Hour, apprevocation.WithClock(c.Now), apprevocation.WithFailOpen(failOpen))
+        svc.RevokeToken(ctx, "t1", "jti-1")
+        if _, err := svc.Revoked("t1", "u1", "jti-2", start); err != nil {
+            t.Fatalf("expected the first load to succeed, got %v", err)
+        }
+
+        repo.down = true
+        c.now = start.Add(time.Minute)
+        revoked, err := svc.Revoked("t1", "u1", "jti-1", start)
+        switch {
+        case !failOpen && !errors.Is(err, apprevocation.ErrUnavailable):
+            t.Fatalf("expected ErrUnavailable when failing closed, got %v", err)
+        case failOpen && (err != nil || !revoked):
+            t.Fatalf("expected the last list to answer when failing open, got %v, %v", revoked, err)
+        }
+    }
+}
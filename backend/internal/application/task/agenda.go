@@ -0,0 +1,98 @@
+package task
+
+import (
+    "context"
+    "errors"
+    "time"
+
+    domaintask "backend/internal/domain/task"
+)
+
+// ErrInvalidTimezone is returned for a zone that is not an IANA time zone
+// name such as "Asia/Jakarta".
+var ErrInvalidTimezone = errors.New("invalid timezone")
+
+// Location resolves the zone a request reads dates in: zone when it is not
+// empty, else the tenant's configured zone, else UTC. "Local" is refused
+// because it names the server's zone, not the caller's.
+func (s *Service) Location(tenantID, zone string) (*time.Location, error) {
+    if zone == "" {
+        return s.location(tenantID), nil
+    }
+    if zone == "Local" {
+        return nil, ErrInvalidTimezone
+    }
+    loc, err := time.LoadLocation(zone)
+    if err != nil {
+        return nil, ErrInvalidTimezone
+    }
+    return loc, nil
+}
+
+// Agenda groups open tasks by when they are due. Each bucket is ordered by
+// due date.
+type Agenda struct {
+    // Timezone is the IANA name of the zone the days were read in.
+    Timezone string
+    // Overdue tasks were due before now.
+    Overdue []domaintask.Task
+    // Today holds tasks due from now to the end of today.
+    Today []domaintask.Task
+    // Tomorrow holds tasks due at any time tomorrow.
+    Tomorrow []domaintask.Task
+    // Upcoming holds tasks due in the five days after tomorrow.
+    Upcoming []domaintask.Task
+    // Later holds tasks due after that, and NoDueDate undated tasks.
+    Later     []domaintask.Task
+    NoDueDate []domaintask.Task
+}
+
+// BuildAgenda sorts tasks into an Agenda around now. Days start at midnight
+// in now's location, so the same task can be due today in one zone and
+// tomorrow in another. Closed tasks are left out.
+func BuildAgenda(tasks []domaintask.Task, now time.Time) Agenda {
+    today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+    tomorrow := today.AddDate(0, 0, 1)
+    dayAfter := today.AddDate(0, 0, 2)
+    week := today.AddDate(0, 0, 7)
+    a := Agenda{
+        Timezone:  now.Location().String(),
+        Overdue:   []domaintask.Task{},
+        Today:     []domaintask.Task{},
+        Tomorrow:  []domaintask.Task{},
+        Upcoming:  []domaintask.Task{},
+        Later:     []domaintask.Task{},
+        NoDueDate: []domaintask.Task{},
+    }
+    for _, t := range tasks {
+        if t.Status == domaintask.StatusDone || t.Status == domaintask.StatusArchived {
+            continue
+        }
+        switch due := t.DueDate; {
+        case due == nil:
+            a.NoDueDate = append(a.NoDueDate, t)
+        case due.Before(now):
+            a.Overdue = append(a.Overdue, t)
+        case due.Before(tomorrow):
+            a.Today = append(a.Today, t)
+        case due.Before(dayAfter):
+            a.Tomorrow = append(a.Tomorrow, t)
+        case due.Before(week):
+            a.Upcoming = append(a.Upcoming, t)
+        default:
+            a.Later = append(a.Later, t)
+        }
+    }
+    return a
+}
+
+// Agenda returns the tenant's open tasks matching f as an Agenda around now,
+// whose location sets where days begin and end (see Location).
+func (s *Service) Agenda(ctx context.Context, tenantID string, f FilterOptions, now time.Time) (Agenda, error) {
+    f.Open = true
+    items, _, err := s.repo.List(ctx, tenantID, f, SortOptions{Field: SortDueDate}, ListOptions{})
+    if err != nil {
+        return Agenda{}, err
+    }
+    return BuildAgenda(items, now), nil
+}
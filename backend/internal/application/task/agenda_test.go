@@ -0,0 +1,117 @@
+package task_test
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    apptask "backend/internal/application/task"
+    domaintask "backend/internal/domain/task"
+    "backend/internal/infrastructure/memory"
+)
+
+func bucketTitles(a apptask.Agenda) map[string]string {
+    return map[string]string{
+        "overdue":   titles(a.Overdue),
+        "today":     titles(a.Today),
+        "tomorrow":  titles(a.Tomorrow),
+        "upcoming":  titles(a.Upcoming),
+        "later":     titles(a.Later),
+        "noDueDate": titles(a.NoDueDate),
+    }
+}
+
+// Test that the same tasks fall into different agenda buckets in
+// Asia/Jakarta and UTC, because days start at local midnight.
+func TestBuildAgenda_Timezones(t *testing.T) {
+    jakarta, err := time.LoadLocation("Asia/Jakarta")
+    if err != nil {
+        t.Fatalf("load zone: %v", err)
+    }
+    now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC) // 19:00 in Jakarta
+    var tasks []domaintask.Task
+    for _, spec := range []struct {
+        title  string
+        due    string
+        status string
+    }{
+        {"d", "2026-03-10T09:00:00Z", domaintask.StatusTodo},
+        {"b", "2026-03-10T16:30:00Z", domaintask.StatusTodo},
+        {"a", "2026-03-10T20:00:00Z", domaintask.StatusInProgress},
+        {"c", "2026-03-11T18:00:00Z", domaintask.StatusTodo},
+        {"f", "2026-03-16T20:00:00Z", domaintask.StatusTodo},
+        {"g", "2026-03-10T20:00:00Z", domaintask.StatusDone},
+        {"e", "", domaintask.StatusTodo},
+    } {
+        tk := domaintask.New("t1", "u1", spec.title, "", 5)
+        tk.Status = spec.status
+        if spec.due != "" {
+            due, _ := time.Parse(time.RFC3339, spec.due)
+            tk.DueDate = &due
+        }
+        tasks = append(tasks, *tk)
+    }
+
+    for _, tc := range []struct {
+        loc  *time.Location
+        want map[string]string
+    }{
+        {time.UTC, map[string]string{"overdue": "d", "today": "ba", "tomorrow": "c", "upcoming": "f", "later": "", "noDueDate": "e"}},
+        {jakarta, map[string]string{"overdue": "d", "today": "b", "tomorrow": "a", "upcoming": "c", "later": "f", "noDueDate": "e"}},
+    } {
+        a := apptask.BuildAgenda(tasks, now.In(tc.loc))
+        if a.Timezone != tc.loc.String() {
+            t.Fatalf("expected timezone %s, got %s", tc.loc, a.Timezone)
+        }
+        got := bucketTitles(a)
+        for bucket, want := range tc.want {
+            if got[bucket] != want {
+                t.Fatalf("%s %s: expected %q, got %q", tc.loc, bucket, want, got[bucket])
+            }
+        }
+    }
+}
+
+// Test that an explicit zone wins over the tenant's, that tenants without one
+// use UTC and that unknown or server-local zones are refused.
+func TestService_Location(t *testing.T) {
+    jakarta, _ := time.LoadLocation("Asia/Jakarta")
+    svc := apptask.NewService(memory.NewTaskRepository(), apptask.WithTimezones(map[string]*time.Location{"t1": jakarta}))
+
+    for _, tc := range []struct {
+        tenant, zone, want string
+    }{
+        {"t1", "", "Asia/Jakarta"},
+        {"t1", "Europe/Berlin", "Europe/Berlin"},
+        {"t2", "", "UTC"},
+    } {
+        loc, err := svc.Location(tc.tenant, tc.zone)
+        if err != nil || loc.String() != tc.want {
+            t.Fatalf("%s %q: expected %s, got %v (%v)", tc.tenant, tc.zone, tc.want, loc, err)
+        }
+    }
+    for _, zone := range []string{"Mars/Olympus", "Local", "../etc/passwd"} {
+        if _, err := svc.Location("t1", zone); !errors.Is(err, apptask.ErrInvalidTimezone) {
+            t.Fatalf("%q: expected ErrInvalidTimezone, got %v", zone, err)
+        }
+    }
+}
+
+// Test that the service's agenda lists only the tenant's open tasks.
+func TestService_Agenda(t *testing.T) {
+    repo := memory.NewTaskRepository()
+    now := time.Now().UTC()
+    seedTasks(t, repo, "t1", now)
+    a, err := apptask.NewService(repo).Agenda(context.Background(), "t1", apptask.FilterOptions{}, now)
+    if err != nil {
+        t.Fatalf("agenda: %v", err)
+    }
+    var n int
+    for _, titles := range bucketTitles(a) {
+        n += len(titles)
+    }
+    if n != 3 {
+        t.Fatalf("expected the 3 open tasks of t1, got %+v", bucketTitles(a))
+    }
+}
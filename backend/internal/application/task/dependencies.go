@@ -0,0 +1,138 @@
+package task
+
+import (
+    "context"
+    "errors"
+    "fmt"
+
+    domaintask "backend/internal/domain/task"
+)
+
+var (
+    // ErrSelfDependency is returned when a task is made to depend on itself.
+    ErrSelfDependency = errors.New("a task cannot depend on itself")
+    // ErrDependencyCycle is returned when a new dependency would close a
+    // loop, such as A blocking B while B already blocks A.
+    ErrDependencyCycle = errors.New("dependency would create a cycle")
+    // ErrDependencyNotFound is returned when the blocking task does not
+    // exist in the tenant.
+    ErrDependencyNotFound = errors.New("blocking task not found")
+    // ErrBlockedByDependencies is returned when a task is moved to done
+    // while one of its blockers is still open and done-blocking is enabled.
+    ErrBlockedByDependencies = errors.New("task has unfinished blockers")
+)
+
+// AddDependency records that dependsOnID blocks taskID. Adding an existing
+// dependency again is not an error.
+func (s *Service) AddDependency(ctx context.Context, tenantID, taskID, dependsOnID string) (*domaintask.TaskDependency, error) {
+    if taskID == dependsOnID {
+        return nil, ErrSelfDependency
+    }
+    if _, err := s.repo.Get(ctx, tenantID, taskID); err != nil {
+        return nil, err
+    }
+    if _, err := s.repo.Get(ctx, tenantID, dependsOnID); err != nil {
+        return nil, ErrDependencyNotFound
+    }
+    cycle, err := s.dependsOn(ctx, tenantID, dependsOnID, taskID)
+    if err != nil {
+        s.logFailure(ctx, "add dependency", err)
+        return nil, err
+    }
+    if cycle {
+        return nil, ErrDependencyCycle
+    }
+    d := domaintask.NewDependency(tenantID, taskID, dependsOnID)
+    if err := s.repo.AddDependency(ctx, d); err != nil {
+        s.logFailure(ctx, "add dependency", err)
+        return nil, err
+    }
+    return d, nil
+}
+
+// RemoveDependency drops the dependency of taskID on dependsOnID, if any.
+func (s *Service) RemoveDependency(ctx context.Context, tenantID, taskID, dependsOnID string) error {
+    if _, err := s.repo.Get(ctx, tenantID, taskID); err != nil {
+        return err
+    }
+    if err := s.repo.RemoveDependency(ctx, tenantID, taskID, dependsOnID); err != nil {
+        s.logFailure(ctx, "remove dependency", err)
+        return err
+    }
+    return nil
+}
+
+// ListDependencies returns the tasks blocking the tenant's task (dependsOn)
+// and the tasks it blocks. Deleted tasks are left out.
+func (s *Service) ListDependencies(ctx context.Context, tenantID, taskID string) (dependsOn, blocks []domaintask.Task, err error) {
+    if _, err := s.repo.Get(ctx, tenantID, taskID); err != nil {
+        return nil, nil, err
+    }
+    deps, err := s.repo.ListDependencies(ctx, tenantID, taskID)
+    if err != nil {
+        s.logFailure(ctx, "list dependencies", err)
+        return nil, nil, err
+    }
+    dependents, err := s.repo.ListDependents(ctx, tenantID, taskID)
+    if err != nil {
+        s.logFailure(ctx, "list dependencies", err)
+        return nil, nil, err
+    }
+    dependsOn = make([]domaintask.Task, 0, len(deps))
+    for _, d := range deps {
+        if t, err := s.repo.Get(ctx, tenantID, d.DependsOnID); err == nil {
+            dependsOn = append(dependsOn, *t)
+        }
+    }
+    blocks = make([]domaintask.Task, 0, len(dependents))
+    for _, d := range dependents {
+        if t, err := s.repo.Get(ctx, tenantID, d.TaskID); err == nil {
+            blocks = append(blocks, *t)
+        }
+    }
+    return dependsOn, blocks, nil
+}
+
+// dependsOn reports whether from depends on to, directly or through other
+// tasks.
+func (s *Service) dependsOn(ctx context.Context, tenantID, from, to string) (bool, error) {
+    seen := map[string]bool{from: true}
+    queue := []string{from}
+    for len(queue) > 0 {
+        deps, err := s.repo.ListDependencies(ctx, tenantID, queue[0])
+        if err != nil {
+            return false, err
+        }
+        queue = queue[1:]
+        for _, d := range deps {
+            if d.DependsOnID == to {
+                return true, nil
+            }
+            if !seen[d.DependsOnID] {
+                seen[d.DependsOnID] = true
+                queue = append(queue, d.DependsOnID)
+            }
+        }
+    }
+    return false, nil
+}
+
+// checkBlockers returns ErrBlockedByDependencies when one of the task's
+// blockers is neither done nor archived. Deleted blockers do not count.
+func (s *Service) checkBlockers(ctx context.Context, tenantID, taskID string) error {
+    deps, err := s.repo.ListDependencies(ctx, tenantID, taskID)
+    if err != nil {
+        return err
+    }
+    open := 0
+    for _, d := range deps {
+        t, err := s.repo.Get(ctx, tenantID, d.DependsOnID)
+        if err == nil && t.Status != domaintask.StatusDone && t.Status != domaintask.StatusArchived {
+            open++
+        }
+    }
+    if open > 0 {
+        return fmt.Errorf("%w: %d still open", ErrBlockedByDependencies, open)
+    }
+    return nil
+}
@@ -0,0 +1,102 @@
+package task_test
+
+import (
+    "context"
+    "errors"
+    "testing"
+
+    apptask "backend/internal/application/task"
+    domaintask "backend/internal/domain/task"
+    "backend/internal/infrastructure/memory"
+)
+
+// Test that self-dependencies and dependencies closing a direct or
+// transitive cycle are refused while other dependencies are recorded.
+func TestService_AddDependency_PreventsCycles(t *testing.T) {
+    ctx := context.Background()
+    svc := apptask.NewService(memory.NewTaskRepository())
+    a, _ := svc.Create(ctx, "t1", "u1", "a", "", 5)
+    b, _ := svc.Create(ctx, "t1", "u1", "b", "", 5)
+    c, _ := svc.Create(ctx, "t1", "u1", "c", "", 5)
+    other, _ := svc.Create(ctx, "t2", "u1", "other", "", 5)
+
+    if _, err := svc.AddDependency(ctx, "t1", a.ID, a.ID); !errors.Is(err, apptask.ErrSelfDependency) {
+        t.Fatalf("expected ErrSelfDependency, got %v", err)
+    }
+    if _, err := svc.AddDependency(ctx, "t1", a.ID, other.ID); !errors.Is(err, apptask.ErrDependencyNotFound) {
+        t.Fatalf("expected ErrDependencyNotFound for another tenant's task, got %v", err)
+    }
+    // c depends on b, b depends on a.
+    if _, err := svc.AddDependency(ctx, "t1", c.ID, b.ID); err != nil {
+        t.Fatalf("add c->b: %v", err)
+    }
+    if _, err := svc.AddDependency(ctx, "t1", b.ID, a.ID); err != nil {
+        t.Fatalf("add b->a: %v", err)
+    }
+    if _, err := svc.AddDependency(ctx, "t1", b.ID, a.ID); err != nil {
+        t.Fatalf("expected re-adding to be a no-op, got %v", err)
+    }
+    if _, err := svc.AddDependency(ctx, "t1", a.ID, b.ID); !errors.Is(err, apptask.ErrDependencyCycle) {
+        t.Fatalf("expected a direct cycle to be refused, got %v", err)
+    }
+    if _, err := svc.AddDependency(ctx, "t1", a.ID, c.ID); !errors.Is(err, apptask.ErrDependencyCycle) {
+        t.Fatalf("expected a transitive cycle to be refused, got %v", err)
+    }
+    if _, err := svc.AddDependency(ctx, "t1", c.ID, a.ID); err != nil {
+        t.Fatalf("expected a shortcut without a cycle to be allowed, got %v", err)
+    }
+
+    dependsOn, blocks, err := svc.ListDependencies(ctx, "t1", b.ID)
+    if err != nil {
+        t.Fatalf("list: %v", err)
+    }
+    if len(dependsOn) != 1 || dependsOn[0].ID != a.ID || len(blocks) != 1 || blocks[0].ID != c.ID {
+        t.Fatalf("expected b to depend on a and block c, got %+v and %+v", dependsOn, blocks)
+    }
+
+    if err := svc.RemoveDependency(ctx, "t1", b.ID, a.ID); err != nil {
+        t.Fatalf("remove: %v", err)
+    }
+    if _, err := svc.AddDependency(ctx, "t1", a.ID, b.ID); err != nil {
+        t.Fatalf("expected a->b to be allowed once b->a is gone, got %v", err)
+    }
+}
+
+// Test that with done-blocking a task cannot be finished while a blocker is
+// open, can once the blocker is done or deleted, and that without it the
+// rule does not apply.
+func TestService_Update_DoneBlocking(t *testing.T) {
+    ctx := context.Background()
+    done := domaintask.StatusDone
+    for _, enabled := range []bool{true, false} {
+        svc := apptask.NewService(memory.NewTaskRepository(), apptask.WithDoneBlocking(enabled))
+        task, _ := svc.Create(ctx, "t1", "u1", "deploy", "", 5)
+        build, _ := svc.Create(ctx, "t1", "u1", "build", "", 5)
+        review, _ := svc.Create(ctx, "t1", "u1", "review", "", 5)
+        svc.AddDependency(ctx, "t1", task.ID, build.ID)
+        svc.AddDependency(ctx, "t1", task.ID, review.ID)
+
+        _, err := svc.Update(ctx, "t1", task.ID, owner, apptask.UpdateTaskInput{Status: &done})
+        if !enabled {
+            if err != nil {
+                t.Fatalf("expected no blocking when disabled, got %v", err)
+            }
+            continue
+        }
+        if !errors.Is(err, apptask.ErrBlockedByDependencies) {
+            t.Fatalf("expected ErrBlockedByDependencies, got %v", err)
+        }
+        if _, err := svc.Update(ctx, "t1", build.ID, owner, apptask.UpdateTaskInput{Status: &done}); err != nil {
+            t.Fatalf("finish build: %v", err)
+        }
+        if _, err := svc.Update(ctx, "t1", task.ID, owner, apptask.UpdateTaskInput{Status: &done}); !errors.Is(err, apptask.ErrBlockedByDependencies) {
+            t.Fatalf("expected review to still block, got %v", err)
+        }
+        if err := svc.Delete(ctx, "t1", review.ID, owner); err != nil {
+            t.Fatalf("delete review: %v", err)
+        }
+        if _, err := svc.Update(ctx, "t1", task.ID, owner, apptask.UpdateTaskInput{Status: &done}); err != nil {
+            t.Fatalf("expected the task to be finishable, got %v", err)
+        }
+    }
+}
@@ -0,0 +1,74 @@
+package task
+
+import (
+    "time"
+
+    domaintask "backend/internal/domain/task"
+)
+
+// FilterOptions narrows a task listing. The zero value keeps every task of
+// the tenant; set fields are combined with AND.
+type FilterOptions struct {
+    // UserID, when set, keeps tasks created by or assigned to this user.
+    UserID *string
+    // ParentID, when set, keeps the subtasks of this task.
+    ParentID *string
+    // Statuses, when not empty, keeps tasks in one of these statuses.
+    Statuses []string
+    // Open keeps tasks that are neither done nor archived.
+    Open bool
+    // DueFrom and DueBefore, when set, keep tasks due at or after DueFrom
+    // and before DueBefore; undated tasks are dropped. Open with DueBefore
+    // set to now lists overdue tasks.
+    DueFrom   *time.Time
+    DueBefore *time.Time
+}
+
+// Matches reports whether t passes every condition of f. Repositories that
+// filter in memory use it to match the conditions of the database.
+func (f FilterOptions) Matches(t domaintask.Task) bool {
+    if u := f.UserID; u != nil && t.UserID != *u && (t.AssigneeID == nil || *t.AssigneeID != *u) {
+        return false
+    }
+    if p := f.ParentID; p != nil && (t.ParentID == nil || *t.ParentID != *p) {
+        return false
+    }
+    if len(f.Statuses) > 0 && !contains(f.Statuses, t.Status) {
+        return false
+    }
+    if f.Open && (t.Status == domaintask.StatusDone || t.Status == domaintask.StatusArchived) {
+        return false
+    }
+    if f.DueFrom != nil && (t.DueDate == nil || t.DueDate.Before(*f.DueFrom)) {
+        return false
+    }
+    if f.DueBefore != nil && (t.DueDate == nil || !t.DueDate.Before(*f.DueBefore)) {
+        return false
+    }
+    return true
+}
+
+// ListOptions selects a page of a task listing. A zero Limit means no limit.
+type ListOptions struct {
+    Limit  int
+    Offset int
+}
+
+// Window returns the page of items selected by p.
+func (p ListOptions) Window(items []domaintask.Task) []domaintask.Task {
+    start := min(p.Offset, len(items))
+    end := len(items)
+    if p.Limit > 0 {
+        end = min(start+p.Limit, end)
+    }
+    return items[start:end]
+}
+
+func contains(list []string, s string) bool {
+    for _, v := range list {
+        if v == s {
+            return true
+        }
+    }
+    return false
+}
@@ -0,0 +1,137 @@
+package task_test
+
+import (
+    "context"
+    "testing"
+    "time"
+
+    apptask "backend/internal/application/task"
+    domaintask "backend/internal/domain/task"
+    "backend/internal/infrastructure/memory"
+)
+
+// seedTasks stores tasks a to e of tenantID, created a minute apart, and a
+// task of another tenant that no listing of tenantID may return.
+func seedTasks(t *testing.T, repo apptask.Repository, tenantID string, now time.Time) map[string]*domaintask.Task {
+    t.Helper()
+    ctx := context.Background()
+    at := func(h int) *time.Time { d := now.Add(time.Duration(h) * time.Hour); return &d }
+    score := func(v float64) *float64 { return &v }
+    u2 := "u2"
+    tasks := map[string]*domaintask.Task{}
+    for i, spec := range []struct {
+        title    string
+        user     string
+        status   string
+        priority int
+        due      *time.Time
+        score    *float64
+    }{
+        {"a", "u1", domaintask.StatusTodo, 5, at(-2), score(40)},
+        {"b", "u2", domaintask.StatusInProgress, 9, at(3), nil},
+        {"c", "u1", domaintask.StatusDone, 1, at(-5), score(90)},
+        {"d", "u2", domaintask.StatusTodo, 5, nil, score(60)},
+        {"e", "u3", domaintask.StatusArchived, 3, at(24), nil},
+    } {
+        tk := domaintask.New(tenantID, spec.user, spec.title, "", spec.priority)
+        tk.Status, tk.DueDate, tk.AiScore = spec.status, spec.due, spec.score
+        tk.CreatedAt = now.Add(time.Duration(i) * time.Minute)
+        tasks[spec.title] = tk
+    }
+    tasks["e"].AssigneeID = &u2
+    tasks["d"].ParentID = &tasks["a"].ID
+    tasks["b"].ParentID = &tasks["a"].ID
+    for _, tk := range tasks {
+        if err := repo.Create(ctx, tk); err != nil {
+            t.Fatalf("create %s: %v", tk.Title, err)
+        }
+    }
+    other := domaintask.New(tenantID+"-other", "u1", "other", "", 5)
+    if err := repo.Create(ctx, other); err != nil {
+        t.Fatalf("create other: %v", err)
+    }
+    return tasks
+}
+
+func titles(items []domaintask.Task) string {
+    var s string
+    for _, t := range items {
+        s += t.Title
+    }
+    return s
+}
+
+// Test that filters combine with AND and that every sort key orders both
+// ways with missing values last.
+func TestService_ListFiltered(t *testing.T) {
+    ctx := context.Background()
+    now := time.Now().UTC().Truncate(time.Second)
+    repo := memory.NewTaskRepository()
+    tasks := seedTasks(t, repo, "t1", now)
+    svc := apptask.NewService(repo)
+    u2 := "u2"
+    from, before := now.Add(-3*time.Hour), now.Add(3*time.Hour)
+
+    cases := []struct {
+        name   string
+        filter apptask.FilterOptions
+        sort   string
+        want   string
+    }{
+        {"all", apptask.FilterOptions{}, "", "abcde"},
+        {"user or assignee", apptask.FilterOptions{UserID: &u2}, "", "bde"},
+        {"parent", apptask.FilterOptions{ParentID: &tasks["a"].ID}, "", "bd"},
+        {"statuses", apptask.FilterOptions{Statuses: []string{domaintask.StatusTodo, domaintask.StatusDone}}, "", "acd"},
+        {"open", apptask.FilterOptions{Open: true}, "", "abd"},
+        {"due range", apptask.FilterOptions{DueFrom: &from, DueBefore: &before}, "", "a"},
+        {"due range end is exclusive", apptask.FilterOptions{DueBefore: tasks["b"].DueDate}, "", "ac"},
+        {"overdue", apptask.FilterOptions{Open: true, DueBefore: &now}, "", "a"},
+        {"user and open", apptask.FilterOptions{UserID: &u2, Open: true}, "", "bd"},
+        {"aiScore", apptask.FilterOptions{}, "aiScore", "adcbe"},
+        {"-aiScore", apptask.FilterOptions{}, "-aiScore", "cdabe"},
+        {"dueDate", apptask.FilterOptions{}, "dueDate", "cabed"},
+        {"-dueDate", apptask.FilterOptions{}, "-dueDate", "ebacd"},
+        {"priority", apptask.FilterOptions{}, "priority", "ceadb"},
+        {"-priority", apptask.FilterOptions{}, "-priority", "badec"},
+        {"createdAt", apptask.FilterOptions{}, "createdAt", "abcde"},
+        {"-createdAt", apptask.FilterOptions{}, "-createdAt", "edcba"},
+        {"open by priority", apptask.FilterOptions{Open: true}, "-priority", "bad"},
+    }
+    for _, tc := range cases {
+        items, total, err := svc.ListFiltered(ctx, "t1", tc.filter, tc.sort, apptask.ListOptions{})
+        if err != nil {
+            t.Fatalf("%s: %v", tc.name, err)
+        }
+        if got := titles(items); got != tc.want || total != int64(len(tc.want)) {
+            t.Fatalf("%s: expected %s (total %d), got %s (total %d)", tc.name, tc.want, len(tc.want), got, total)
+        }
+    }
+}
+
+// Test that a page holds at most Limit tasks from Offset on while the total
+// counts every matching task.
+func TestService_ListFiltered_Paging(t *testing.T) {
+    ctx := context.Background()
+    repo := memory.NewTaskRepository()
+    seedTasks(t, repo, "t1", time.Now().UTC())
+    svc := apptask.NewService(repo)
+
+    for _, tc := range []struct {
+        page apptask.ListOptions
+        want string
+    }{
+        {apptask.ListOptions{Limit: 2}, "ab"},
+        {apptask.ListOptions{Limit: 2, Offset: 2}, "cd"},
+        {apptask.ListOptions{Limit: 2, Offset: 4}, "e"},
+        {apptask.ListOptions{Offset: 3}, "de"},
+        {apptask.ListOptions{Limit: 2, Offset: 9}, ""},
+    } {
+        items, total, err := svc.ListFiltered(ctx, "t1", apptask.FilterOptions{}, "createdAt", tc.page)
+        if err != nil {
+            t.Fatalf("list %+v: %v", tc.page, err)
+        }
+        if got := titles(items); got != tc.want || total != 5 {
+            t.Fatalf("%+v: expected %s of 5, got %s of %d", tc.page, tc.want, got, total)
+        }
+    }
+}
@@ -0,0 +1,78 @@
+package task
+
+import (
+    "bufio"
+    "context"
+    "fmt"
+    "io"
+    "strings"
+    "unicode/utf8"
+
+    domaintask "backend/internal/domain/task"
+)
+
+// icalLineLimit is the longest content line RFC 5545 allows, in octets,
+// before it must be folded.
+const icalLineLimit = 75
+
+// ExportIcal writes the tenant's tasks matching f that have a due date to w
+// as an iCalendar (RFC 5545) feed, one all-day VEVENT per task on its due
+// day in the tenant's zone (see Location). Undated tasks are left out.
+func (s *Service) ExportIcal(ctx context.Context, tenantID string, f FilterOptions, w io.Writer) error {
+    items, _, err := s.repo.List(ctx, tenantID, f, SortOptions{Field: SortDueDate}, ListOptions{})
+    if err != nil {
+        return err
+    }
+    loc := s.location(tenantID)
+    bw := bufio.NewWriter(w)
+    writeIcalLine(bw, "BEGIN:VCALENDAR")
+    writeIcalLine(bw, "VERSION:2.0")
+    writeIcalLine(bw, "PRODID:-//MauFlow//Tasks//EN")
+    writeIcalLine(bw, "CALSCALE:GREGORIAN")
+    for _, t := range items {
+        if t.DueDate == nil {
+            continue
+        }
+        writeIcalEvent(bw, t, t.DueDate.In(loc).Format("20060102"))
+    }
+    writeIcalLine(bw, "END:VCALENDAR")
+    return bw.Flush()
+}
+
+func writeIcalEvent(w *bufio.Writer, t domaintask.Task, day string) {
+    writeIcalLine(w, "BEGIN:VEVENT")
+    writeIcalLine(w, fmt.Sprintf("UID:%s@mauflow", t.ID))
+    writeIcalLine(w, "DTSTAMP:"+t.UpdatedAt.UTC().Format("20060102T150405Z"))
+    writeIcalLine(w, "DTSTART;VALUE=DATE:"+day)
+    writeIcalLine(w, "SUMMARY:"+escapeIcalText(t.Title))
+    if t.Description != "" {
+        writeIcalLine(w, "DESCRIPTION:"+escapeIcalText(t.Description))
+    }
+    writeIcalLine(w, "END:VEVENT")
+}
+
+// writeIcalLine writes line ended by CRLF, folding it into continuation lines
+// that start with a space so none exceeds icalLineLimit octets. Folds never
+// split a UTF-8 sequence.
+func writeIcalLine(w *bufio.Writer, line string) {
+    limit := icalLineLimit
+    for len(line) > limit {
+        cut := limit
+        for cut > 0 && !utf8.RuneStart(line[cut]) {
+            cut--
+        }
+        fmt.Fprintf(w, "%s\r\n ", line[:cut])
+        line = line[cut:]
+        // The leading space counts towards the continuation line.
+        limit = icalLineLimit - 1
+    }
+    fmt.Fprintf(w, "%s\r\n", line)
+}
+
+var icalTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// escapeIcalText escapes a TEXT value: backslashes, semicolons and commas
+// are backslash-escaped and line breaks become \n.
+func escapeIcalText(s string) string {
+    return icalTextEscaper.Replace(s)
+}
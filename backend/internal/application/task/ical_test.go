@@ -0,0 +1,67 @@
+package task_test
+
+import (
+    "bytes"
+    "context"
+    "strings"
+    "testing"
+    "time"
+
+    apptask "backend/internal/application/task"
+    domaintask "backend/internal/domain/task"
+    "backend/internal/infrastructure/memory"
+)
+
+// Test that the export is a VCALENDAR with one all-day VEVENT per dated
+// task, with escaped text, CRLF line endings and folded long lines.
+func TestService_ExportIcal(t *testing.T) {
+    ctx := context.Background()
+    jakarta, err := time.LoadLocation("Asia/Jakarta")
+    if err != nil {
+        t.Fatalf("load zone: %v", err)
+    }
+    repo := memory.NewTaskRepository()
+    svc := apptask.NewService(repo, apptask.WithTimezones(map[string]*time.Location{"t1": jakarta}))
+    due := time.Date(2026, 3, 10, 20, 0, 0, 0, time.UTC) // March 11th in Jakarta
+    dated := domaintask.New("t1", "u1", "Ship v2; then, rest", "line one\nline two "+strings.Repeat("x", 80), 5)
+    dated.DueDate = &due
+    undated := domaintask.New("t1", "u1", "Someday", "", 5)
+    other := domaintask.New("t2", "u1", "Not ours", "", 5)
+    other.DueDate = &due
+    for _, tk := range []*domaintask.Task{dated, undated, other} {
+        if err := repo.Create(ctx, tk); err != nil {
+            t.Fatalf("seed: %v", err)
+        }
+    }
+
+    var buf bytes.Buffer
+    if err := svc.ExportIcal(ctx, "t1", apptask.FilterOptions{}, &buf); err != nil {
+        t.Fatalf("export: %v", err)
+    }
+    out := buf.String()
+    if !strings.HasPrefix(out, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n") || !strings.HasSuffix(out, "END:VCALENDAR\r\n") {
+        t.Fatalf("expected a VCALENDAR wrapper, got %q", out)
+    }
+    if n := strings.Count(out, "BEGIN:VEVENT\r\n"); n != 1 || strings.Count(out, "END:VEVENT\r\n") != 1 {
+        t.Fatalf("expected 1 event, got %d in %q", n, out)
+    }
+    for _, want := range []string{
+        "UID:" + dated.ID + "@mauflow\r\n",
+        "DTSTART;VALUE=DATE:20260311\r\n",
+        `SUMMARY:Ship v2\; then\, rest` + "\r\n",
+        `DESCRIPTION:line one\nline two `,
+    } {
+        if !strings.Contains(out, want) {
+            t.Fatalf("expected %q in %q", want, out)
+        }
+    }
+    for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+        if len(line) > 75 || strings.Contains(line, "\n") {
+            t.Fatalf("expected folded CRLF lines of at most 75 octets, got %q", line)
+        }
+    }
+    unfolded := strings.ReplaceAll(out, "\r\n ", "")
+    if !strings.Contains(unfolded, `DESCRIPTION:line one\nline two `+strings.Repeat("x", 80)+"\r\n") {
+        t.Fatalf("expected the description to unfold intact, got %q", unfolded)
+    }
+}
@@ -0,0 +1,249 @@
+package task
+
+import (
+    "context"
+    "encoding/csv"
+    "errors"
+    "fmt"
+    "io"
+    "strconv"
+    "strings"
+    "time"
+
+    domaintask "backend/internal/domain/task"
+)
+
+// MaxImportRows bounds the data rows one ImportTasks call accepts.
+const MaxImportRows = 1000
+
+var (
+    // ErrInvalidCSV is returned for input that is not well-formed CSV.
+    ErrInvalidCSV = errors.New("invalid CSV")
+    // ErrImportTooLarge is returned for input with more than MaxImportRows
+    // data rows.
+    ErrImportTooLarge = fmt.Errorf("import exceeds %d rows", MaxImportRows)
+)
+
+// importColumns are the CSV columns ImportTasks reads, matched against the
+// header row without regard to case. Only title is required.
+var importColumns = []string{"title", "description", "priority", "dueDate", "assigneeId"}
+
+// ImportOptions controls ImportTasks. DryRun validates every row without
+// creating anything. Location is the zone YYYY-MM-DD due dates start the day
+// in; nil means the tenant's zone.
+type ImportOptions struct {
+    DryRun   bool
+    Location *time.Location
+}
+
+// ImportError reports a problem with one CSV row. Row counts from 1, the
+// header; Field names the column, if any. Imports in other formats (see
+// ImportFormat) number their items from 1 instead.
+type ImportError struct {
+    Row     int    `json:"row"`
+    Field   string `json:"field,omitempty"`
+    Message string `json:"message"`
+}
+
+// ImportResult reports an import. Valid is true when no row had an error;
+// RowCount counts the data rows, without the header. Created holds the new
+// tasks of a valid import that was not a dry run.
+type ImportResult struct {
+    Valid    bool              `json:"valid"`
+    RowCount int               `json:"rowCount"`
+    Errors   []ImportError     `json:"errors"`
+    Created  []domaintask.Task `json:"-"`
+}
+
+// ImportTasks reads tasks from CSV with a header row naming importColumns and
+// creates them for userID. Every row is validated before anything is created,
+// so an import with any error, like a dry run, creates nothing and reports all
+// errors at once. Rows are then created one by one through CreateTask; a
+// storage failure part way returns the error together with the tasks created
+// so far.
+func (s *Service) ImportTasks(ctx context.Context, tenantID, userID string, r io.Reader, opts ImportOptions) (ImportResult, error) {
+    p, err := s.parseImport(tenantID, r, opts.Location)
+    if err != nil {
+        return ImportResult{}, err
+    }
+    return s.createImport(ctx, tenantID, userID, p, opts.DryRun)
+}
+
+// createImport creates the rows of p unless any row has an error or dryRun
+// is set.
+func (s *Service) createImport(ctx context.Context, tenantID, userID string, p parsedImport, dryRun bool) (ImportResult, error) {
+    res := ImportResult{RowCount: p.rowCount, Errors: p.errors()}
+    res.Valid = len(res.Errors) == 0
+    if !res.Valid || dryRun {
+        return res, nil
+    }
+    for _, row := range p.rows {
+        t, err := s.CreateTask(ctx, tenantID, userID, row.in)
+        if err != nil {
+            return res, err
+        }
+        res.Created = append(res.Created, *t)
+    }
+    return res, nil
+}
+
+// parsedImport is a CSV import read and checked row by row.
+type parsedImport struct {
+    rowCount   int
+    headerErrs []ImportError
+    // rows is empty when the header has errors.
+    rows []parsedRow
+}
+
+type parsedRow struct {
+    in   CreateTaskInput
+    errs []ImportError
+}
+
+// errors returns the header's and every row's errors in row order.
+func (p parsedImport) errors() []ImportError {
+    out := append([]ImportError{}, p.headerErrs...)
+    for _, row := range p.rows {
+        out = append(out, row.errs...)
+    }
+    return out
+}
+
+// parseImport reads r and validates each row, with YYYY-MM-DD dates read in
+// loc or, when nil, the tenant's zone. Malformed CSV and too many rows are
+// returned as errors; everything else is reported on its row.
+func (s *Service) parseImport(tenantID string, r io.Reader, loc *time.Location) (parsedImport, error) {
+    if loc == nil {
+        loc = s.location(tenantID)
+    }
+    cr := csv.NewReader(r)
+    cr.FieldsPerRecord = -1
+    cr.TrimLeadingSpace = true
+
+    var p parsedImport
+    header, err := cr.Read()
+    if errors.Is(err, io.EOF) {
+        p.headerErrs = []ImportError{{Row: 1, Message: "missing header row"}}
+        return p, nil
+    }
+    if err != nil {
+        return parsedImport{}, fmt.Errorf("%w: %v", ErrInvalidCSV, err)
+    }
+    columns, headerErrs := importHeader(header)
+    p.headerErrs = headerErrs
+
+    for row := 2; ; row++ {
+        record, err := cr.Read()
+        if errors.Is(err, io.EOF) {
+            break
+        }
+        if err != nil {
+            return parsedImport{}, fmt.Errorf("%w: %v", ErrInvalidCSV, err)
+        }
+        if p.rowCount++; p.rowCount > MaxImportRows {
+            return parsedImport{}, ErrImportTooLarge
+        }
+        if len(headerErrs) > 0 {
+            continue
+        }
+        if len(record) != len(header) {
+            p.rows = append(p.rows, parsedRow{errs: []ImportError{{Row: row, Message: fmt.Sprintf("expected %d fields, got %d", len(header), len(record))}}})
+            continue
+        }
+        in, errs := s.importRow(row, columns, record, loc)
+        p.rows = append(p.rows, parsedRow{in: in, errs: errs})
+    }
+    return p, nil
+}
+
+// importHeader maps each known column to its index in header and reports
+// unknown, repeated and missing columns as errors on row 1.
+func importHeader(header []string) (map[string]int, []ImportError) {
+    known := make(map[string]string, len(importColumns))
+    for _, col := range importColumns {
+        known[strings.ToLower(col)] = col
+    }
+    columns := map[string]int{}
+    var errs []ImportError
+    for i, name := range header {
+        name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+        col, ok := known[strings.ToLower(name)]
+        switch _, seen := columns[col]; {
+        case !ok:
+            errs = append(errs, ImportError{Row: 1, Field: name, Message: "unknown column"})
+        case seen:
+            errs = append(errs, ImportError{Row: 1, Field: col, Message: "repeated column"})
+        default:
+            columns[col] = i
+        }
+    }
+    if _, ok := columns["title"]; !ok {
+        errs = append(errs, ImportError{Row: 1, Field: "title", Message: "column is required"})
+    }
+    return columns, errs
+}
+
+// importRow turns one record into the input CreateTask would get and reports
+// every field that would fail.
+func (s *Service) importRow(row int, columns map[string]int, record []string, loc *time.Location) (CreateTaskInput, []ImportError) {
+    field := func(col string) string {
+        if i, ok := columns[col]; ok {
+            return strings.TrimSpace(record[i])
+        }
+        return ""
+    }
+    var errs []ImportError
+    in := CreateTaskInput{Title: field("title"), Description: field("description")}
+    // Check the fields the way CreateTask will.
+    if err := s.limits.ValidateTitle(in.Title); err != nil {
+        errs = append(errs, importFieldError(row, err))
+    }
+    if err := s.limits.ValidateDescription(sanitizeDescription(in.Description)); err != nil {
+        errs = append(errs, importFieldError(row, err))
+    }
+    if v := field("priority"); v != "" {
+        p, err := strconv.Atoi(v)
+        switch {
+        case err != nil:
+            errs = append(errs, ImportError{Row: row, Field: "priority", Message: "must be a whole number"})
+        case p == 0 && s.normalizeZero:
+        case domaintask.ValidatePriority(p) != nil:
+            errs = append(errs, ImportError{Row: row, Field: "priority", Message: fmt.Sprintf("must be %d-%d", domaintask.MinPriority, domaintask.MaxPriority)})
+        }
+        in.Priority = p
+    } else if !s.normalizeZero {
+        errs = append(errs, ImportError{Row: row, Field: "priority", Message: "is required"})
+    }
+    if v := field("dueDate"); v != "" {
+        due, err := parseImportDate(v, loc)
+        if err != nil {
+            errs = append(errs, ImportError{Row: row, Field: "dueDate", Message: "must be an RFC 3339 time or a YYYY-MM-DD date"})
+        }
+        in.DueDate = due
+    }
+    if v := field("assigneeId"); v != "" {
+        in.AssigneeID = &v
+    }
+    return in, errs
+}
+
+func importFieldError(row int, err error) ImportError {
+    var fe *domaintask.FieldError
+    if errors.As(err, &fe) {
+        return ImportError{Row: row, Field: fe.Field, Message: fe.Err.Error()}
+    }
+    return ImportError{Row: row, Message: err.Error()}
+}
+
+// parseImportDate reads an RFC 3339 time, or a YYYY-MM-DD date as the start
+// of that day in loc.
+func parseImportDate(v string, loc *time.Location) (*time.Time, error) {
+    if t, err := time.Parse(time.RFC3339, v); err == nil {
+        return &t, nil
+    }
+    t, err := time.ParseInLocation(time.DateOnly, v, loc)
+    if err != nil {
+        return nil, err
+    }
+    return &t, nil
+}
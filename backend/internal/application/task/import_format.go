@@ -0,0 +1,71 @@
+package task
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "io"
+
+    domaintask "backend/internal/domain/task"
+)
+
+var (
+    // ErrUnknownImportFormat is returned by ImportFormat for a format no
+    // TaskDecoder was registered for.
+    ErrUnknownImportFormat = errors.New("unknown import format")
+    // ErrInvalidImport is returned when a TaskDecoder cannot read its input.
+    ErrInvalidImport = errors.New("invalid import")
+)
+
+// TaskDecoder reads the tasks of another tool's export, such as a Trello
+// board, as the input CreateTask would get for each.
+type TaskDecoder interface {
+    DecodeTasks(r io.Reader) ([]CreateTaskInput, error)
+}
+
+// WithImportFormat registers d as the decoder ImportFormat uses for format.
+func WithImportFormat(format string, d TaskDecoder) Option {
+    return func(s *Service) {
+        if s.decoders == nil {
+            s.decoders = map[string]TaskDecoder{}
+        }
+        s.decoders[format] = d
+    }
+}
+
+// ImportFormat creates tasks for userID from r in a registered format, with
+// the same all-or-nothing validation and dry runs as ImportTasks. Errors are
+// reported on the item's position, counting from 1.
+func (s *Service) ImportFormat(ctx context.Context, tenantID, userID, format string, r io.Reader, opts ImportOptions) (ImportResult, error) {
+    d, ok := s.decoders[format]
+    if !ok {
+        return ImportResult{}, ErrUnknownImportFormat
+    }
+    inputs, err := d.DecodeTasks(r)
+    if err != nil {
+        return ImportResult{}, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+    }
+    if len(inputs) > MaxImportRows {
+        return ImportResult{}, ErrImportTooLarge
+    }
+    p := parsedImport{rowCount: len(inputs)}
+    for i, in := range inputs {
+        p.rows = append(p.rows, parsedRow{in: in, errs: s.checkImportInput(i+1, in)})
+    }
+    return s.createImport(ctx, tenantID, userID, p, opts.DryRun)
+}
+
+// checkImportInput reports every field of in that CreateTask would reject.
+func (s *Service) checkImportInput(row int, in CreateTaskInput) []ImportError {
+    var errs []ImportError
+    if err := s.limits.ValidateTitle(in.Title); err != nil {
+        errs = append(errs, importFieldError(row, err))
+    }
+    if err := s.limits.ValidateDescription(sanitizeDescription(in.Description)); err != nil {
+        errs = append(errs, importFieldError(row, err))
+    }
+    if !(in.Priority == 0 && s.normalizeZero) && domaintask.ValidatePriority(in.Priority) != nil {
+        errs = append(errs, ImportError{Row: row, Field: "priority", Message: fmt.Sprintf("must be %d-%d", domaintask.MinPriority, domaintask.MaxPriority)})
+    }
+    return errs
+}
@@ -0,0 +1,129 @@
+package task
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "fmt"
+    "time"
+
+    appjob "backend/internal/application/job"
+)
+
+const (
+    // ImportJobType is the job type of imports run in the background.
+    ImportJobType = "tasks.import"
+    // AsyncImportRows is the most data rows SubmitImport imports within the
+    // request; larger imports become jobs when a job runner is configured.
+    AsyncImportRows = 100
+    // ImportBatchSize is how many rows an import job creates between
+    // progress updates.
+    ImportBatchSize = 100
+)
+
+// importJobPayload is what SubmitImport enqueues. An empty Timezone means
+// the tenant's zone.
+type importJobPayload struct {
+    TenantID string `json:"tenantId"`
+    UserID   string `json:"userId"`
+    Timezone string `json:"timezone,omitempty"`
+    CSV      string `json:"csv"`
+}
+
+// ImportJobResult is the result of a finished import job. Imported counts
+// the created tasks and Errors the rows that were skipped, which RowErrors
+// explains.
+type ImportJobResult struct {
+    Imported  int           `json:"imported"`
+    Errors    int           `json:"errors"`
+    RowErrors []ImportError `json:"rowErrors"`
+}
+
+// SubmitImport imports data like ImportTasks, except that when a job runner
+// is configured and data has more than AsyncImportRows rows, the import is
+// enqueued as an ImportJobType job for the tenant and its id returned
+// instead of a result. Malformed CSV and too many rows are reported before
+// anything is enqueued; dry runs always run in the request.
+func (s *Service) SubmitImport(ctx context.Context, tenantID, userID string, data []byte, opts ImportOptions) (ImportResult, string, error) {
+    p, err := s.parseImport(tenantID, bytes.NewReader(data), opts.Location)
+    if err != nil {
+        return ImportResult{}, "", err
+    }
+    if s.jobs == nil || opts.DryRun || p.rowCount <= AsyncImportRows {
+        res, err := s.createImport(ctx, tenantID, userID, p, opts.DryRun)
+        return res, "", err
+    }
+    payload := importJobPayload{TenantID: tenantID, UserID: userID, CSV: string(data)}
+    if opts.Location != nil {
+        payload.Timezone = opts.Location.String()
+    }
+    b, err := json.Marshal(payload)
+    if err != nil {
+        return ImportResult{}, "", err
+    }
+    jobID, err := s.jobs.Enqueue(ctx, ImportJobType, b, appjob.ForTenant(tenantID))
+    if err != nil {
+        return ImportResult{}, "", err
+    }
+    return ImportResult{}, jobID, nil
+}
+
+// ImportJobHandler runs the import jobs SubmitImport enqueues. Unlike
+// ImportTasks it creates every valid row and skips the others, reporting
+// progress after each ImportBatchSize rows.
+type ImportJobHandler struct {
+    svc *Service
+}
+
+func NewImportJobHandler(svc *Service) *ImportJobHandler {
+    return &ImportJobHandler{svc: svc}
+}
+
+var _ appjob.JobHandler = (*ImportJobHandler)(nil)
+
+// Handle imports the payload's CSV and returns an ImportJobResult. A storage
+// failure stops the job with an error.
+func (h *ImportJobHandler) Handle(ctx context.Context, j appjob.Job, payload []byte, progress appjob.ProgressFunc) (json.RawMessage, error) {
+    var in importJobPayload
+    if err := json.Unmarshal(payload, &in); err != nil {
+        return nil, fmt.Errorf("import payload: %w", err)
+    }
+    var loc *time.Location
+    if in.Timezone != "" {
+        var err error
+        if loc, err = time.LoadLocation(in.Timezone); err != nil {
+            return nil, fmt.Errorf("import payload: %w", err)
+        }
+    }
+    p, err := h.svc.parseImport(in.TenantID, bytes.NewReader([]byte(in.CSV)), loc)
+    if err != nil {
+        return nil, err
+    }
+
+    res := ImportJobResult{RowErrors: append([]ImportError{}, p.headerErrs...)}
+    report := func(processed int) {
+        if err := progress(ctx, processed, p.rowCount); err != nil {
+            h.svc.logger.WarnContext(ctx, "import job: recording progress failed", "job_id", j.ID, "error", err)
+        }
+    }
+    for start := 0; start < len(p.rows); start += ImportBatchSize {
+        for _, row := range p.rows[start:min(start+ImportBatchSize, len(p.rows))] {
+            if len(row.errs) > 0 {
+                res.Errors++
+                res.RowErrors = append(res.RowErrors, row.errs...)
+                continue
+            }
+            if _, err := h.svc.CreateTask(ctx, in.TenantID, in.UserID, row.in); err != nil {
+                return nil, fmt.Errorf("import stopped after %d tasks: %w", res.Imported, err)
+            }
+            res.Imported++
+        }
+        report(min(start+ImportBatchSize, len(p.rows)))
+    }
+    // With header errors no row was read, and every one is skipped.
+    if len(p.headerErrs) > 0 {
+        res.Errors = p.rowCount
+        report(p.rowCount)
+    }
+    return json.Marshal(res)
+}
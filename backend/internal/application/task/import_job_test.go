@@ -0,0 +1,120 @@
+package task_test
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "strings"
+    "testing"
+
+    appjob "backend/internal/application/job"
+    apptask "backend/internal/application/task"
+    "backend/internal/infrastructure/memory"
+)
+
+// queuedJob is a job captured by jobQueue.
+type queuedJob struct {
+    job     appjob.Job
+    payload []byte
+}
+
+// jobQueue is a JobRunner that keeps enqueued jobs for the test to run.
+type jobQueue struct {
+    jobs []queuedJob
+}
+
+func (q *jobQueue) Enqueue(_ context.Context, jobType string, payload []byte, opts ...appjob.EnqueueOption) (string, error) {
+    j := appjob.Job{ID: fmt.Sprintf("job-%d", len(q.jobs)+1), Type: jobType, Status: appjob.StatusQueued}
+    for _, opt := range opts {
+        opt(&j)
+    }
+    q.jobs = append(q.jobs, queuedJob{job: j, payload: payload})
+    return j.ID, nil
+}
+
+// importCSV returns an import of rows tasks, of which the ones at the given
+// indexes have an invalid priority.
+func importCSV(rows int, invalid ...int) string {
+    bad := map[int]bool{}
+    for _, i := range invalid {
+        bad[i] = true
+    }
+    var b strings.Builder
+    b.WriteString("title,priority\n")
+    for i := 0; i < rows; i++ {
+        priority := "5"
+        if bad[i] {
+            priority = "99"
+        }
+        fmt.Fprintf(&b, "Task %d,%s\n", i, priority)
+    }
+    return b.String()
+}
+
+// Test that a large import is enqueued for the tenant without creating
+// anything, and that running the job creates the valid rows in batches,
+// skips the invalid ones and reports progress along the way.
+func TestService_SubmitImport_Job(t *testing.T) {
+    ctx := context.Background()
+    repo := memory.NewTaskRepository()
+    queue := &jobQueue{}
+    svc := apptask.NewService(repo, apptask.WithJobRunner(queue))
+
+    _, jobID, err := svc.SubmitImport(ctx, "t1", "u1", []byte(importCSV(200, 3, 50, 120, 150, 199)), apptask.ImportOptions{})
+    if err != nil {
+        t.Fatalf("submit: %v", err)
+    }
+    if jobID == "" || len(queue.jobs) != 1 {
+        t.Fatalf("expected one job enqueued, got id %q and %d jobs", jobID, len(queue.jobs))
+    }
+    queued := queue.jobs[0]
+    if queued.job.Type != apptask.ImportJobType || queued.job.TenantID != "t1" {
+        t.Fatalf("expected an import job of t1, got %+v", queued.job)
+    }
+    if tasks, _ := repo.ListByTenant(ctx, "t1"); len(tasks) != 0 {
+        t.Fatalf("expected nothing created before the job runs, got %d tasks", len(tasks))
+    }
+
+    var progress []string
+    record := func(_ context.Context, processed, total int) error {
+        progress = append(progress, fmt.Sprintf("%d/%d", processed, total))
+        return nil
+    }
+    out, err := apptask.NewImportJobHandler(svc).Handle(ctx, queued.job, queued.payload, record)
+    if err != nil {
+        t.Fatalf("handle: %v", err)
+    }
+    var res apptask.ImportJobResult
+    if err := json.Unmarshal(out, &res); err != nil {
+        t.Fatalf("decode result: %v", err)
+    }
+    if res.Imported != 195 || res.Errors != 5 || len(res.RowErrors) != 5 || res.RowErrors[0].Row != 5 {
+        t.Fatalf("expected 195 imported and 5 errors from row 5 on, got %d, %d, %+v", res.Imported, res.Errors, res.RowErrors)
+    }
+    if got := strings.Join(progress, " "); got != "100/200 200/200" {
+        t.Fatalf("expected progress after each batch, got %q", got)
+    }
+    if tasks, _ := repo.ListByTenant(ctx, "t1"); len(tasks) != 195 {
+        t.Fatalf("expected 195 tasks, got %d", len(tasks))
+    }
+}
+
+// Test that small imports and dry runs run in the request even with a job
+// runner configured.
+func TestService_SubmitImport_Inline(t *testing.T) {
+    ctx := context.Background()
+    queue := &jobQueue{}
+    svc := apptask.NewService(memory.NewTaskRepository(), apptask.WithJobRunner(queue))
+
+    res, jobID, err := svc.SubmitImport(ctx, "t1", "u1", []byte(importCSV(apptask.AsyncImportRows)), apptask.ImportOptions{})
+    if err != nil || jobID != "" || len(res.Created) != apptask.AsyncImportRows {
+        t.Fatalf("expected %d tasks created inline, got %d, job %q (%v)", apptask.AsyncImportRows, len(res.Created), jobID, err)
+    }
+    res, jobID, err = svc.SubmitImport(ctx, "t1", "u1", []byte(importCSV(apptask.AsyncImportRows+1)), apptask.ImportOptions{DryRun: true})
+    if err != nil || jobID != "" || !res.Valid || len(res.Created) != 0 {
+        t.Fatalf("expected a valid dry run inline, got %+v, job %q (%v)", res, jobID, err)
+    }
+    if len(queue.jobs) != 0 {
+        t.Fatalf("expected nothing enqueued, got %d jobs", len(queue.jobs))
+    }
+}
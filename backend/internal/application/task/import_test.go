@@ -0,0 +1,154 @@
+package task_test
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "io"
+    "strings"
+    "testing"
+    "time"
+
+    apptask "backend/internal/application/task"
+    domaintask "backend/internal/domain/task"
+    "backend/internal/infrastructure/memory"
+)
+
+const validImport = `title,description,priority,dueDate,assigneeId
+Ship report,"Quarterly, for finance",8,2026-03-01T12:00:00Z,u2
+Call bank,,,2026-03-02,
+`
+
+// Test that a valid import creates every row with its fields, reading
+// date-only due dates at the start of the day in the given zone.
+func TestService_ImportTasks(t *testing.T) {
+    ctx := context.Background()
+    svc := apptask.NewService(memory.NewTaskRepository())
+    jakarta, _ := time.LoadLocation("Asia/Jakarta")
+
+    res, err := svc.ImportTasks(ctx, "t1", "u1", strings.NewReader(validImport), apptask.ImportOptions{Location: jakarta})
+    if err != nil {
+        t.Fatalf("import: %v", err)
+    }
+    if !res.Valid || res.RowCount != 2 || len(res.Created) != 2 {
+        t.Fatalf("expected 2 valid rows created, got %+v", res)
+    }
+    first, second := res.Created[0], res.Created[1]
+    if first.Title != "Ship report" || first.Description != "Quarterly, for finance" || first.Priority != 8 || first.AssigneeID == nil || *first.AssigneeID != "u2" {
+        t.Fatalf("expected the first row's fields, got %+v", first)
+    }
+    if want := time.Date(2026, 3, 2, 0, 0, 0, 0, jakarta); second.Priority != 5 || second.DueDate == nil || !second.DueDate.Equal(want) {
+        t.Fatalf("expected default priority and a due date of %v, got %+v", want, second)
+    }
+}
+
+// Test that a dry run validates but creates nothing, even for valid input.
+func TestService_ImportTasks_DryRun(t *testing.T) {
+    ctx := context.Background()
+    svc := apptask.NewService(memory.NewTaskRepository())
+
+    res, err := svc.ImportTasks(ctx, "t1", "u1", strings.NewReader(validImport), apptask.ImportOptions{DryRun: true})
+    if err != nil {
+        t.Fatalf("import: %v", err)
+    }
+    if !res.Valid || res.RowCount != 2 || len(res.Errors) != 0 || len(res.Created) != 0 {
+        t.Fatalf("expected a valid report and nothing created, got %+v", res)
+    }
+    if items, _ := svc.List(ctx, "t1"); len(items) != 0 {
+        t.Fatalf("expected no tasks, got %d", len(items))
+    }
+}
+
+// Test that every bad row and header is reported and that an import with any
+// error creates nothing.
+func TestService_ImportTasks_Errors(t *testing.T) {
+    ctx := context.Background()
+    svc := apptask.NewService(memory.NewTaskRepository())
+    input := `title,priority,dueDate
+Good,3,
+Bad priority,11,
+,x,tomorrow
+Too,many,fields,here
+`
+    res, err := svc.ImportTasks(ctx, "t1", "u1", strings.NewReader(input), apptask.ImportOptions{})
+    if err != nil {
+        t.Fatalf("import: %v", err)
+    }
+    var got []string
+    for _, e := range res.Errors {
+        got = append(got, fmt.Sprintf("%d:%s", e.Row, e.Field))
+    }
+    if want := "3:priority 4:title 4:priority 4:dueDate 5:"; res.Valid || res.RowCount != 4 || strings.Join(got, " ") != want {
+        t.Fatalf("expected errors %s over 4 rows, got %v (%+v)", want, got, res)
+    }
+    if res.Errors[0].Message != "must be 1-10" {
+        t.Fatalf("expected the priority range in the message, got %q", res.Errors[0].Message)
+    }
+    if items, _ := svc.List(ctx, "t1"); len(items) != 0 {
+        t.Fatalf("expected no tasks, got %d", len(items))
+    }
+
+    res, _ = svc.ImportTasks(ctx, "t1", "u1", strings.NewReader("name,priority\nx,1\n"), apptask.ImportOptions{})
+    if len(res.Errors) != 2 || res.Errors[0].Field != "name" || res.Errors[1].Field != "title" {
+        t.Fatalf("expected the unknown and the missing column reported, got %+v", res.Errors)
+    }
+    if _, err := svc.ImportTasks(ctx, "t1", "u1", strings.NewReader("title\n\"unterminated\n"), apptask.ImportOptions{}); !errors.Is(err, apptask.ErrInvalidCSV) {
+        t.Fatalf("expected %v, got %v", apptask.ErrInvalidCSV, err)
+    }
+    big := "title\n" + strings.Repeat("x\n", apptask.MaxImportRows+1)
+    if _, err := svc.ImportTasks(ctx, "t1", "u1", strings.NewReader(big), apptask.ImportOptions{}); !errors.Is(err, apptask.ErrImportTooLarge) {
+        t.Fatalf("expected %v, got %v", apptask.ErrImportTooLarge, err)
+    }
+}
+
+// staticDecoder decodes every input to the same tasks, or fails with err.
+type staticDecoder struct {
+    inputs []apptask.CreateTaskInput
+    err    error
+}
+
+func (d staticDecoder) DecodeTasks(io.Reader) ([]apptask.CreateTaskInput, error) {
+    return d.inputs, d.err
+}
+
+// Test that imports in a registered format create the decoded tasks with
+// their status, report invalid items by position and reject unknown formats
+// and undecodable input.
+func TestService_ImportFormat(t *testing.T) {
+    ctx := context.Background()
+    valid := staticDecoder{inputs: []apptask.CreateTaskInput{
+        {Title: "Open card", Tags: []string{"design"}},
+        {Title: "Closed card", Status: domaintask.StatusDone},
+    }}
+    svc := apptask.NewService(memory.NewTaskRepository(),
+        apptask.WithImportFormat("valid", valid),
+        apptask.WithImportFormat("invalid", staticDecoder{inputs: []apptask.CreateTaskInput{{Title: "ok"}, {Title: " "}}}),
+        apptask.WithImportFormat("broken", staticDecoder{err: errors.New("unexpected EOF")}),
+    )
+
+    res, err := svc.ImportFormat(ctx, "t1", "u1", "valid", nil, apptask.ImportOptions{})
+    if err != nil {
+        t.Fatalf("import: %v", err)
+    }
+    if !res.Valid || len(res.Created) != 2 {
+        t.Fatalf("expected 2 tasks created, got %+v", res)
+    }
+    if res.Created[0].Status != domaintask.StatusTodo || res.Created[0].Priority != domaintask.DefaultPriority || res.Created[1].Status != domaintask.StatusDone {
+        t.Fatalf("expected a todo and a done task at the default priority, got %+v", res.Created)
+    }
+
+    res, err = svc.ImportFormat(ctx, "t1", "u1", "invalid", nil, apptask.ImportOptions{})
+    if err != nil || res.Valid || len(res.Errors) != 1 || res.Errors[0].Row != 2 || res.Errors[0].Field != "title" {
+        t.Fatalf("expected a title error on item 2, got %+v (%v)", res, err)
+    }
+    if items, _ := svc.List(ctx, "t1"); len(items) != 2 {
+        t.Fatalf("expected the invalid import to create nothing, got %d tasks", len(items))
+    }
+
+    if _, err := svc.ImportFormat(ctx, "t1", "u1", "broken", nil, apptask.ImportOptions{}); !errors.Is(err, apptask.ErrInvalidImport) {
+        t.Fatalf("expected %v, got %v", apptask.ErrInvalidImport, err)
+    }
+    if _, err := svc.ImportFormat(ctx, "t1", "u1", "asana", nil, apptask.ImportOptions{}); !errors.Is(err, apptask.ErrUnknownImportFormat) {
+        t.Fatalf("expected %v, got %v", apptask.ErrUnknownImportFormat, err)
+    }
+}
@@ -0,0 +1,73 @@
+package task
+
+import (
+    "context"
+    "errors"
+
+    domaintask "backend/internal/domain/task"
+
+    "go.opentelemetry.io/otel/attribute"
+    "go.opentelemetry.io/otel/metric"
+)
+
+// meterName identifies the instruments registered by this package.
+const meterName = "backend/internal/application/task"
+
+// metrics holds the business counters emitted by the service.
+type metrics struct {
+    created metric.Int64Counter
+    deleted metric.Int64Counter
+    errors  metric.Int64Counter
+}
+
+func newMetrics(mp metric.MeterProvider) (metrics, error) {
+    m := mp.Meter(meterName)
+    var out metrics
+    var err error
+    if out.created, err = m.Int64Counter("tasks_created_total", metric.WithDescription("Tasks created, by tenant.")); err != nil {
+        return metrics{}, err
+    }
+    if out.deleted, err = m.Int64Counter("tasks_deleted_total", metric.WithDescription("Tasks deleted, by tenant.")); err != nil {
+        return metrics{}, err
+    }
+    if out.errors, err = m.Int64Counter("task_operation_errors_total", metric.WithDescription("Failed task operations, by operation and error type.")); err != nil {
+        return metrics{}, err
+    }
+    return out, nil
+}
+
+func (m metrics) taskCreated(ctx context.Context, tenantID string) {
+    m.created.Add(ctx, 1, metric.WithAttributes(attribute.String("tenantID", tenantID)))
+}
+
+func (m metrics) taskDeleted(ctx context.Context, tenantID string) {
+    m.deleted.Add(ctx, 1, metric.WithAttributes(attribute.String("tenantID", tenantID)))
+}
+
+func (m metrics) operationFailed(ctx context.Context, op string, err error) {
+    m.errors.Add(ctx, 1, metric.WithAttributes(
+        attribute.String("operation", op),
+        attribute.String("errorType", errorType(err)),
+    ))
+}
+
+// errorType buckets err into a low-cardinality label value.
+func errorType(err error) string {
+    switch {
+    case errors.Is(err, domaintask.ErrRequired),
+        errors.Is(err, domaintask.ErrTooLong),
+        errors.Is(err, domaintask.ErrInvalidPriority),
+        errors.Is(err, ErrParentNotFound),
+        errors.Is(err, ErrProjectNotFound),
+        errors.Is(err, ErrNotCompleted),
+        errors.Is(err, ErrInvalidReopenStatus),
+        errors.Is(err, ErrBlockedByDependencies):
+        return "validation"
+    case errors.Is(err, ErrForbidden):
+        return "forbidden"
+    case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+        return "canceled"
+    default:
+        return "repository"
+    }
+}
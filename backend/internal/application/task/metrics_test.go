@@ -0,0 +1,75 @@
+package task_test
+
+import (
+    "context"
+    "testing"
+
+    apptask "backend/internal/application/task"
+    "backend/internal/infrastructure/memory"
+
+    "go.opentelemetry.io/otel/attribute"
+    sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+    "go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// counterValue sums the data points of the named counter whose attributes
+// include every key/value in want.
+func counterValue(t *testing.T, rm metricdata.ResourceMetrics, name string, want ...attribute.KeyValue) int64 {
+    t.Helper()
+    var total int64
+    for _, sm := range rm.ScopeMetrics {
+        for _, m := range sm.Metrics {
+            if m.Name != name {
+                continue
+            }
+            sum, ok := m.Data.(metricdata.Sum[int64])
+            if !ok {
+                t.Fatalf("%s: expected int64 sum, got %T", name, m.Data)
+            }
+        points:
+            for _, dp := range sum.DataPoints {
+                for _, kv := range want {
+                    if v, ok := dp.Attributes.Value(kv.Key); !ok || v != kv.Value {
+                        continue points
+                    }
+                }
+                total += dp.Value
+            }
+        }
+    }
+    return total
+}
+
+// Test that creates, deletes and failures are counted with their labels.
+func TestService_Metrics(t *testing.T) {
+    reader := sdkmetric.NewManualReader()
+    svc := apptask.NewService(memory.NewTaskRepository(), apptask.WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))))
+    ctx := context.Background()
+
+    a, _ := svc.Create(ctx, "t1", "u1", "a", "", 5)
+    svc.Create(ctx, "t1", "u1", "b", "", 5)
+    svc.Create(ctx, "t2", "u1", "c", "", 5)
+    svc.Create(ctx, "t1", "u1", "", "", 5)
+    svc.Delete(ctx, "t1", a.ID, owner)
+    svc.Delete(ctx, "t1", "missing", owner)
+
+    var rm metricdata.ResourceMetrics
+    if err := reader.Collect(ctx, &rm); err != nil {
+        t.Fatalf("collect: %v", err)
+    }
+    if got := counterValue(t, rm, "tasks_created_total", attribute.String("tenantID", "t1")); got != 2 {
+        t.Fatalf("expected 2 creates for t1, got %d", got)
+    }
+    if got := counterValue(t, rm, "tasks_created_total"); got != 3 {
+        t.Fatalf("expected 3 creates in total, got %d", got)
+    }
+    if got := counterValue(t, rm, "tasks_deleted_total", attribute.String("tenantID", "t1")); got != 1 {
+        t.Fatalf("expected 1 delete, got %d", got)
+    }
+    if got := counterValue(t, rm, "task_operation_errors_total", attribute.String("operation", "create"), attribute.String("errorType", "validation")); got != 1 {
+        t.Fatalf("expected 1 create validation error, got %d", got)
+    }
+    if got := counterValue(t, rm, "task_operation_errors_total", attribute.String("operation", "delete"), attribute.String("errorType", "repository")); got != 1 {
+        t.Fatalf("expected 1 delete repository error, got %d", got)
+    }
+}
@@ -0,0 +1,74 @@
+package task
+
+import (
+    "context"
+    "errors"
+    "time"
+
+    domaintask "backend/internal/domain/task"
+)
+
+// ErrProjectNotFound is returned by Move when the tenant has no project with
+// the target id.
+var ErrProjectNotFound = errors.New("project not found")
+
+// ProjectLookup tells whether a tenant has a project.
+type ProjectLookup interface {
+    ProjectExists(ctx context.Context, tenantID, id string) (bool, error)
+}
+
+// WithProjectLookup sets where Move checks target projects. By default there
+// is none and every target counts as unknown.
+func WithProjectLookup(p ProjectLookup) Option {
+    return func(s *Service) { s.projects = p }
+}
+
+// Move transfers the tenant's task to the project targetProjectID, returning
+// ErrProjectNotFound when the tenant has no such project and ErrForbidden
+// when the policy does not let actor change the task. Moving a task to the
+// project it is in changes nothing.
+func (s *Service) Move(ctx context.Context, tenantID, taskID string, actor Actor, targetProjectID string) (*domaintask.Task, error) {
+    t, err := s.repo.Get(ctx, tenantID, taskID)
+    if err != nil {
+        return nil, err
+    }
+    if err := s.authorize(actor, *t); err != nil {
+        s.metrics.operationFailed(ctx, "move", err)
+        return nil, err
+    }
+    if err := s.checkProject(ctx, tenantID, targetProjectID); err != nil {
+        return nil, err
+    }
+    from := t.ProjectID
+    if from != nil && *from == targetProjectID {
+        return t, nil
+    }
+    t.ProjectID = &targetProjectID
+    t.Position = 0
+    if err := s.repo.Update(ctx, t, FieldProjectID, FieldPosition); err != nil {
+        s.logFailure(ctx, "move", err)
+        return nil, err
+    }
+    s.scores.Invalidate(tenantID)
+    e := domaintask.TaskMoved{TenantID: tenantID, TaskID: taskID, FromProjectID: from, ToProjectID: targetProjectID, OccurredAt: time.Now().UTC()}
+    s.events.Publish(ctx, e)
+    s.notifyWatchers(ctx, s.watchersOf(ctx, tenantID, taskID), taskID, e)
+    return t, nil
+}
+
+// checkProject returns ErrProjectNotFound unless the tenant has the project.
+func (s *Service) checkProject(ctx context.Context, tenantID, id string) error {
+    ok := false
+    if s.projects != nil {
+        var err error
+        if ok, err = s.projects.ProjectExists(ctx, tenantID, id); err != nil {
+            s.logFailure(ctx, "move", err)
+            return err
+        }
+    }
+    if !ok {
+        s.metrics.operationFailed(ctx, "move", ErrProjectNotFound)
+        return ErrProjectNotFound
+    }
+    return nil
+}
@@ -0,0 +1,120 @@
+package task_test
+
+import (
+    "context"
+    "errors"
+    "testing"
+
+    apptask "backend/internal/application/task"
+    domainproject "backend/internal/domain/project"
+    domaintask "backend/internal/domain/task"
+    "backend/internal/infrastructure/memory"
+)
+
+// newMoveService returns a service whose tenant t1 has the projects from and
+// to, and t2 the project other, with a task of u1 in t1's project from.
+func newMoveService(t *testing.T, opts ...apptask.Option) (svc *apptask.Service, task *domaintask.Task, from, to, other *domainproject.Project) {
+    t.Helper()
+    ctx := context.Background()
+    tasks := memory.NewTaskRepository()
+    projects := memory.NewProjectRepository(tasks)
+    from, to, other = domainproject.New("t1", "From", ""), domainproject.New("t1", "To", ""), domainproject.New("t2", "Other", "")
+    for _, p := range []*domainproject.Project{from, to, other} {
+        if err := projects.Create(ctx, p); err != nil {
+            t.Fatalf("create project: %v", err)
+        }
+    }
+    svc = apptask.NewService(tasks, append([]apptask.Option{apptask.WithProjectLookup(projects)}, opts...)...)
+    task, err := svc.CreateTask(ctx, "t1", "u1", apptask.CreateTaskInput{Title: "a", Priority: 5})
+    if err != nil {
+        t.Fatalf("create: %v", err)
+    }
+    if _, err := svc.Move(ctx, "t1", task.ID, owner, from.ID); err != nil {
+        t.Fatalf("move into from: %v", err)
+    }
+    return svc, task, from, to, other
+}
+
+// Test that moving a task stores its new project and publishes TaskMoved
+// with both project ids.
+func TestService_Move(t *testing.T) {
+    ctx := context.Background()
+    pub := &recordingPublisher{}
+    svc, task, from, to, _ := newMoveService(t, apptask.WithEventPublisher(pub))
+
+    moved, err := svc.Move(ctx, "t1", task.ID, owner, to.ID)
+    if err != nil {
+        t.Fatalf("move: %v", err)
+    }
+    if moved.ProjectID == nil || *moved.ProjectID != to.ID {
+        t.Fatalf("expected project %s, got %v", to.ID, moved.ProjectID)
+    }
+    if got, _ := svc.Get(ctx, "t1", task.ID); got.ProjectID == nil || *got.ProjectID != to.ID {
+        t.Fatalf("expected the move to be stored, got %v", got.ProjectID)
+    }
+    e, ok := pub.events[len(pub.events)-1].(domaintask.TaskMoved)
+    if !ok {
+        t.Fatalf("expected a TaskMoved event, got %+v", pub.events[len(pub.events)-1])
+    }
+    if e.FromProjectID == nil || *e.FromProjectID != from.ID || e.ToProjectID != to.ID || e.TaskID != task.ID {
+        t.Fatalf("unexpected event %+v", e)
+    }
+
+    n := len(pub.events)
+    if _, err := svc.Move(ctx, "t1", task.ID, owner, to.ID); err != nil {
+        t.Fatalf("move again: %v", err)
+    }
+    if len(pub.events) != n {
+        t.Fatalf("expected no event for a move to the current project, got %+v", pub.events[n:])
+    }
+}
+
+// Test that a moved task goes to the end of the target project, with its
+// position reset to 0.
+func TestService_Move_ResetsPosition(t *testing.T) {
+    ctx := context.Background()
+    tasks := memory.NewTaskRepository()
+    projects := memory.NewProjectRepository(tasks)
+    to := domainproject.New("t1", "To", "")
+    if err := projects.Create(ctx, to); err != nil {
+        t.Fatalf("create project: %v", err)
+    }
+    svc := apptask.NewService(tasks, apptask.WithProjectLookup(projects))
+    task, err := svc.CreateTask(ctx, "t1", "u1", apptask.CreateTaskInput{Title: "a", Priority: 5})
+    if err != nil {
+        t.Fatalf("create: %v", err)
+    }
+    task.Position = 3
+    if err := tasks.Update(ctx, task, apptask.FieldPosition); err != nil {
+        t.Fatalf("update: %v", err)
+    }
+
+    if _, err := svc.Move(ctx, "t1", task.ID, owner, to.ID); err != nil {
+        t.Fatalf("move: %v", err)
+    }
+    if got, _ := svc.Get(ctx, "t1", task.ID); got.Position != 0 {
+        t.Fatalf("expected position 0, got %d", got.Position)
+    }
+}
+
+// Test that unknown projects and other tenants' projects are refused
+// without moving the task.
+func TestService_Move_UnknownProject(t *testing.T) {
+    ctx := context.Background()
+    svc, task, from, _, other := newMoveService(t)
+
+    for _, target := range []string{"00000000-0000-0000-0000-000000000000", other.ID} {
+        if _, err := svc.Move(ctx, "t1", task.ID, owner, target); !errors.Is(err, apptask.ErrProjectNotFound) {
+            t.Fatalf("%s: expected apptask.ErrProjectNotFound, got %v", target, err)
+        }
+    }
+    if got, _ := svc.Get(ctx, "t1", task.ID); got.ProjectID == nil || *got.ProjectID != from.ID {
+        t.Fatalf("expected the task to stay in %s, got %v", from.ID, got.ProjectID)
+    }
+    if _, err := svc.Move(ctx, "t1", "00000000-0000-0000-0000-000000000000", owner, from.ID); !errors.Is(err, apptask.ErrNotFound) {
+        t.Fatalf("expected apptask.ErrNotFound, got %v", err)
+    }
+    if _, err := svc.Move(ctx, "t1", task.ID, apptask.Actor{UserID: "u2"}, from.ID); !errors.Is(err, apptask.ErrForbidden) {
+        t.Fatalf("expected apptask.ErrForbidden, got %v", err)
+    }
+}
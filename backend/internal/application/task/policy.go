@@ -0,0 +1,49 @@
+package task
+
+import (
+    "errors"
+
+    domaintask "backend/internal/domain/task"
+)
+
+// ErrForbidden is returned when the actor may not change or delete a task.
+var ErrForbidden = errors.New("only the task's creator, its assignee or an admin can change it")
+
+// Actor is the user asking for a change, and whether they are an admin.
+type Actor struct {
+    UserID string
+    Admin  bool
+}
+
+// Policy decides who may change or delete a task. Reading tasks is open to
+// the whole tenant and not subject to it.
+type Policy interface {
+    CanModify(actor Actor, t domaintask.Task) bool
+}
+
+// OwnerPolicy lets a task's creator, its assignee and admins change it.
+type OwnerPolicy struct{}
+
+func (OwnerPolicy) CanModify(actor Actor, t domaintask.Task) bool {
+    if actor.Admin {
+        return true
+    }
+    if actor.UserID == "" {
+        return false
+    }
+    return t.UserID == actor.UserID || (t.AssigneeID != nil && *t.AssigneeID == actor.UserID)
+}
+
+// WithPolicy sets who may change and delete tasks. By default OwnerPolicy
+// applies.
+func WithPolicy(p Policy) Option {
+    return func(s *Service) { s.policy = p }
+}
+
+// authorize returns ErrForbidden unless actor may change t.
+func (s *Service) authorize(actor Actor, t domaintask.Task) error {
+    if !s.policy.CanModify(actor, t) {
+        return ErrForbidden
+    }
+    return nil
+}
@@ -2,16 +2,140 @@ package task
 
 import (
     "context"
+    "errors"
+    "time"
 
     domaintask "backend/internal/domain/task"
 )
 
+// TaskField names a task field written by Repository.Update.
+type TaskField string
+
+const (
+    FieldTitle       TaskField = "title"
+    FieldDescription TaskField = "description"
+    FieldStatus      TaskField = "status"
+    FieldPriority    TaskField = "priority"
+    FieldDueDate     TaskField = "dueDate"
+    FieldSummary     TaskField = "summary"
+    FieldProjectID   TaskField = "projectId"
+    FieldPosition    TaskField = "position"
+)
+
+// ErrNotFound is returned by Repository.Get and Delete when the tenant has
+// no task with the id.
+var ErrNotFound = errors.New("task not found")
+
+// ErrParentNotFound is returned when a subtask names a parent task the tenant
+// does not have.
+var ErrParentNotFound = errors.New("parent task not found")
+
 // Repository defines persistence operations for tasks.
 type Repository interface {
-    ListByTenant(ctx context.Context, tenantID string) ([]domaintask.Task, error)
+    // List returns the page of the tenant's tasks matching f, ordered by s,
+    // together with the number of matching tasks on all pages.
+    List(ctx context.Context, tenantID string, f FilterOptions, s SortOptions, page ListOptions) ([]domaintask.Task, int64, error)
     Get(ctx context.Context, tenantID, id string) (*domaintask.Task, error)
+    // GetMany returns the tenant's tasks with the given ids in the order the
+    // ids are listed, each once. Ids that are unknown, deleted or belong to
+    // another tenant are left out without error.
+    GetMany(ctx context.Context, tenantID string, ids []string) ([]domaintask.Task, error)
     Create(ctx context.Context, t *domaintask.Task) error
-    Update(ctx context.Context, t *domaintask.Task) error
+    // Update writes the listed fields of t, and a new UpdatedAt, to the
+    // stored task. Zero values such as an empty description or a nil due
+    // date are written as well; unlisted fields are left alone.
+    Update(ctx context.Context, t *domaintask.Task, fields ...TaskField) error
     Delete(ctx context.Context, tenantID, id string) error
+    // BulkAssign sets the assignee of every task in ids that belongs to the
+    // tenant, in one transaction, and returns the ids that were updated.
+    BulkAssign(ctx context.Context, tenantID string, ids []string, assigneeID *string) ([]string, error)
+    // UpdateAIScores stores the given score (by task id) on each task of the
+    // tenant. Ids that are unknown, deleted or belong to another tenant are
+    // skipped without error.
+    UpdateAIScores(ctx context.Context, tenantID string, scores map[string]float64) error
+    // ListOpenPage returns up to limit tasks of the tenant that are neither
+    // done nor archived, ordered by id and starting after afterID ("" for the
+    // first page).
+    ListOpenPage(ctx context.Context, tenantID, afterID string, limit int) ([]domaintask.Task, error)
+    // Watch records w; watching an already watched task is a no-op.
+    Watch(ctx context.Context, w *domaintask.TaskWatcher) error
+    // Unwatch removes the user's watch on the task, if any.
+    Unwatch(ctx context.Context, tenantID, taskID, userID string) error
+    // ListWatchers returns the task's watchers, oldest first.
+    ListWatchers(ctx context.Context, tenantID, taskID string) ([]domaintask.TaskWatcher, error)
+    // AddDependency records d; adding an existing dependency is a no-op.
+    AddDependency(ctx context.Context, d *domaintask.TaskDependency) error
+    // RemoveDependency removes the dependency of taskID on dependsOnID, if
+    // any.
+    RemoveDependency(ctx context.Context, tenantID, taskID, dependsOnID string) error
+    // ListDependencies returns the dependencies of taskID on other tasks,
+    // oldest first.
+    ListDependencies(ctx context.Context, tenantID, taskID string) ([]domaintask.TaskDependency, error)
+    // ListDependents returns the dependencies of other tasks on taskID,
+    // oldest first.
+    ListDependents(ctx context.Context, tenantID, taskID string) ([]domaintask.TaskDependency, error)
+}
+
+// EventPublisher delivers domain events raised by the service.
+type EventPublisher interface {
+    Publish(ctx context.Context, e domaintask.Event)
+}
+
+// EventSubscriber streams a tenant's domain events to a listener. The
+// listener must call unsubscribe when done; the channel is then closed.
+type EventSubscriber interface {
+    Subscribe(tenantID string) (events <-chan domaintask.Event, unsubscribe func())
+}
+
+type noopPublisher struct{}
+
+func (noopPublisher) Publish(context.Context, domaintask.Event) {}
+
+// ScoreCache holds results derived from a tenant's tasks, such as
+// prioritization scores, that go stale when a task is created, updated or
+// deleted.
+type ScoreCache interface {
+    Invalidate(tenantID string)
 }
 
+type noopScoreCache struct{}
+
+func (noopScoreCache) Invalidate(string) {}
+
+// Notification tells a watcher that a task they watch changed. Event is the
+// domain event name, such as task.updated.
+type Notification struct {
+    TenantID   string    `json:"tenantId"`
+    TaskID     string    `json:"taskId"`
+    UserID     string    `json:"userId"`
+    Event      string    `json:"event"`
+    OccurredAt time.Time `json:"occurredAt"`
+}
+
+// Notifier queues notifications for delivery to watchers.
+type Notifier interface {
+    Enqueue(ctx context.Context, n Notification) error
+}
+
+type noopNotifier struct{}
+
+func (noopNotifier) Enqueue(context.Context, Notification) error { return nil }
+
+// FileStore removes uploaded files, which are kept apart from the task's
+// records.
+type FileStore interface {
+    // DeletePrefix removes every file whose key lies below prefix.
+    DeletePrefix(ctx context.Context, prefix string) error
+}
+
+// SubtaskGenerator breaks a task into actionable steps with an external
+// model.
+type SubtaskGenerator interface {
+    GenerateSubtasks(ctx context.Context, title, description string) ([]string, error)
+}
+
+// Summarizer condenses a task's title and description into a short summary
+// with an external model.
+type Summarizer interface {
+    Summarize(ctx context.Context, title, description string) (string, error)
+}
@@ -0,0 +1,233 @@
+package task
+
+import (
+    "context"
+    "regexp"
+    "strconv"
+    "strings"
+    "time"
+
+    domaintask "backend/internal/domain/task"
+)
+
+// QuickAdd is the interpretation of a quick-add line such as
+// "Ship invoices report by friday 5pm #billing p1 @alex". Fields the line
+// does not mention are left zero.
+type QuickAdd struct {
+    Title      string     `json:"title"`
+    DueDate    *time.Time `json:"dueDate,omitempty"`
+    Tags       []string   `json:"tags,omitempty"`
+    Priority   int        `json:"priority,omitempty"`
+    AssigneeID *string    `json:"assigneeId,omitempty"`
+}
+
+// quickPriorities maps the p1 (most urgent) to p4 markers onto task
+// priorities.
+var quickPriorities = map[string]int{"p1": 10, "p2": 7, "p3": 5, "p4": 3}
+
+// quickConnectors are dropped from the title when they introduce a date or
+// time, as in "by friday" or "at 5pm".
+var quickConnectors = map[string]bool{"by": true, "due": true, "on": true, "at": true}
+
+var (
+    quickName    = regexp.MustCompile(`^[\p{L}\p{N}_-]+$`)
+    quickClock   = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm)$`)
+    quickClock24 = regexp.MustCompile(`^(\d{1,2}):(\d{2})$`)
+)
+
+// ParseQuickAdd reads a quick-add line without any AI: #tag adds a tag,
+// @user sets the assignee, p1 to p4 set the priority, and "today",
+// "tomorrow", a weekday, "in 3 days", "in 2 weeks" or 2026-05-01 with an
+// optional time such as 5pm, 5:30pm or 17:00 set the due date. Only the
+// first priority, assignee, date and time count; everything else, including
+// fragments that do not parse, stays in the title.
+//
+// Dates are read in now's location. A weekday is its next occurrence that is
+// not already past, a time without a date is its next occurrence, and a date
+// without a time is due at the end of that day. DueDate is returned in UTC.
+func ParseQuickAdd(text string, now time.Time) QuickAdd {
+    var out QuickAdd
+    tokens := strings.Fields(text)
+    used := make([]bool, len(tokens))
+    when := make([]bool, len(tokens))
+    var (
+        day     *time.Time
+        weekday = -1
+        clock   *time.Duration
+    )
+    today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+    for i := 0; i < len(tokens); i++ {
+        tok := strings.TrimRight(tokens[i], ",.;!?")
+        word := strings.ToLower(tok)
+        switch {
+        case strings.HasPrefix(word, "#") && quickName.MatchString(word[1:]):
+            if !contains(out.Tags, word[1:]) {
+                out.Tags = append(out.Tags, word[1:])
+            }
+            used[i] = true
+        case strings.HasPrefix(tok, "@") && quickName.MatchString(tok[1:]) && out.AssigneeID == nil:
+            assignee := tok[1:]
+            out.AssigneeID = &assignee
+            used[i] = true
+        case quickPriorities[word] != 0 && out.Priority == 0:
+            out.Priority = quickPriorities[word]
+            used[i] = true
+        case clock == nil && parseClock(word) != nil:
+            clock = parseClock(word)
+            used[i], when[i] = true, true
+        case day == nil && weekday < 0:
+            if d, wd, n := parseDay(tokens[i:], today); n > 0 {
+                day, weekday = d, wd
+                for j := i; j < i+n; j++ {
+                    used[j], when[j] = true, true
+                }
+                i += n - 1
+            }
+        }
+    }
+    for i := 0; i+1 < len(tokens); i++ {
+        if !used[i] && when[i+1] && quickConnectors[strings.ToLower(tokens[i])] {
+            used[i] = true
+        }
+    }
+
+    var title []string
+    for i, tok := range tokens {
+        if !used[i] {
+            title = append(title, tok)
+        }
+    }
+    out.Title = strings.Join(title, " ")
+    out.DueDate = resolveDue(now, today, day, weekday, clock)
+    return out
+}
+
+// parseDay reads a date at the start of tokens and returns how many tokens
+// it took. A weekday is returned as wd, to be resolved once the time is
+// known; other dates as day.
+func parseDay(tokens []string, today time.Time) (day *time.Time, wd int, n int) {
+    word := strings.ToLower(strings.TrimRight(tokens[0], ",.;!?"))
+    switch word {
+    case "today":
+        return &today, -1, 1
+    case "tomorrow":
+        d := today.AddDate(0, 0, 1)
+        return &d, -1, 1
+    }
+    for w := time.Sunday; w <= time.Saturday; w++ {
+        if word == strings.ToLower(w.String()) {
+            return nil, int(w), 1
+        }
+    }
+    if d, err := time.ParseInLocation("2006-01-02", word, today.Location()); err == nil {
+        return &d, -1, 1
+    }
+    if word == "in" && len(tokens) >= 3 {
+        count, err := strconv.Atoi(tokens[1])
+        unit := strings.ToLower(strings.TrimRight(tokens[2], ",.;!?"))
+        if err == nil && count > 0 {
+            switch unit {
+            case "day", "days":
+                d := today.AddDate(0, 0, count)
+                return &d, -1, 3
+            case "week", "weeks":
+                d := today.AddDate(0, 0, 7*count)
+                return &d, -1, 3
+            }
+        }
+    }
+    return nil, -1, 0
+}
+
+// parseClock reads 5pm, 5:30pm or 17:00 as the time since midnight, or nil.
+func parseClock(word string) *time.Duration {
+    var hour, minute int
+    if m := quickClock.FindStringSubmatch(word); m != nil {
+        hour, _ = strconv.Atoi(m[1])
+        if m[2] != "" {
+            minute, _ = strconv.Atoi(m[2])
+        }
+        if hour < 1 || hour > 12 {
+            return nil
+        }
+        hour %= 12
+        if m[3] == "pm" {
+            hour += 12
+        }
+    } else if m := quickClock24.FindStringSubmatch(word); m != nil {
+        hour, _ = strconv.Atoi(m[1])
+        minute, _ = strconv.Atoi(m[2])
+        if hour > 23 {
+            return nil
+        }
+    } else {
+        return nil
+    }
+    if minute > 59 {
+        return nil
+    }
+    d := time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute
+    return &d
+}
+
+// resolveDue combines the parsed date and time into a due date in UTC.
+func resolveDue(now, today time.Time, day *time.Time, weekday int, clock *time.Duration) *time.Time {
+    if day == nil && weekday < 0 && clock == nil {
+        return nil
+    }
+    at := func(d time.Time) time.Time {
+        if clock == nil {
+            return time.Date(d.Year(), d.Month(), d.Day(), 23, 59, 59, 0, d.Location())
+        }
+        return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, d.Location()).Add(*clock)
+    }
+    var due time.Time
+    switch {
+    case day != nil:
+        due = at(*day)
+    case weekday >= 0:
+        due = at(today.AddDate(0, 0, (weekday-int(today.Weekday())+7)%7))
+        if due.Before(now) {
+            due = at(today.AddDate(0, 0, (weekday-int(today.Weekday())+7)%7+7))
+        }
+    default:
+        due = at(today)
+        if due.Before(now) {
+            due = at(today.AddDate(0, 0, 1))
+        }
+    }
+    due = due.UTC()
+    return &due
+}
+
+// QuickAdd parses text with ParseQuickAdd in loc, or the tenant's time zone
+// when loc is nil, and, unless dryRun is set, creates the task it describes
+// for userID.
+func (s *Service) QuickAdd(ctx context.Context, tenantID, userID, text string, loc *time.Location, dryRun bool) (QuickAdd, *domaintask.Task, error) {
+    if loc == nil {
+        loc = s.location(tenantID)
+    }
+    parsed := ParseQuickAdd(text, time.Now().In(loc))
+    if dryRun {
+        return parsed, nil, nil
+    }
+    t, err := s.CreateTask(ctx, tenantID, userID, CreateTaskInput{
+        Title:      parsed.Title,
+        Priority:   parsed.Priority,
+        DueDate:    parsed.DueDate,
+        AssigneeID: parsed.AssigneeID,
+        Tags:       parsed.Tags,
+    })
+    if err != nil {
+        return parsed, nil, err
+    }
+    return parsed, t, nil
+}
+
+// location is the zone tenantID's relative dates are read in.
+func (s *Service) location(tenantID string) *time.Location {
+    if loc := s.timezones[tenantID]; loc != nil {
+        return loc
+    }
+    return time.UTC
+}
@@ -0,0 +1,123 @@
+package task_test
+
+import (
+    "context"
+    "strings"
+    "testing"
+    "time"
+
+    apptask "backend/internal/application/task"
+    "backend/internal/infrastructure/memory"
+)
+
+// Test that quick-add lines yield title, due date, tags, priority and
+// assignee, and that fragments which do not parse stay in the title.
+func TestParseQuickAdd(t *testing.T) {
+    // Wednesday, 10:00 UTC.
+    now := time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC)
+    date := func(day, hour, min, sec int) *time.Time {
+        d := time.Date(2026, 10, day, hour, min, sec, 0, time.UTC)
+        return &d
+    }
+    cases := []struct {
+        text     string
+        title    string
+        due      *time.Time
+        tags     string
+        priority int
+        assignee string
+    }{
+        {"Ship invoices report by friday 5pm #billing p1 @alex", "Ship invoices report", date(16, 17, 0, 0), "billing", 10, "alex"},
+        {"Call bank tomorrow", "Call bank", date(15, 23, 59, 59), "", 0, ""},
+        {"Standup at 9am", "Standup", date(15, 9, 0, 0), "", 0, ""},
+        {"Review wednesday 8am", "Review", date(21, 8, 0, 0), "", 0, ""},
+        {"Review Wednesday, 11:30am", "Review", date(14, 11, 30, 0), "", 0, ""},
+        {"Renew cert in 2 weeks #ops #OPS #infra", "Renew cert", date(28, 23, 59, 59), "ops,infra", 0, ""},
+        {"Pay rent due 2026-10-31 17:30", "Pay rent", date(31, 17, 30, 0), "", 0, ""},
+        {"Write docs p2 p1 @bo @cy", "Write docs p1 @cy", nil, "", 7, "bo"},
+        {"Book p9 room for 25pm on thursdayish @ # in two days", "Book p9 room for 25pm on thursdayish @ # in two days", nil, "", 0, ""},
+        {"Look at the logs", "Look at the logs", nil, "", 0, ""},
+    }
+    for _, tc := range cases {
+        got := apptask.ParseQuickAdd(tc.text, now)
+        if got.Title != tc.title {
+            t.Fatalf("%q: expected title %q, got %q", tc.text, tc.title, got.Title)
+        }
+        if (got.DueDate == nil) != (tc.due == nil) || (tc.due != nil && !got.DueDate.Equal(*tc.due)) {
+            t.Fatalf("%q: expected due %v, got %v", tc.text, tc.due, got.DueDate)
+        }
+        if tags := strings.Join(got.Tags, ","); tags != tc.tags {
+            t.Fatalf("%q: expected tags %q, got %q", tc.text, tc.tags, tags)
+        }
+        if got.Priority != tc.priority {
+            t.Fatalf("%q: expected priority %d, got %d", tc.text, tc.priority, got.Priority)
+        }
+        var assignee string
+        if got.AssigneeID != nil {
+            assignee = *got.AssigneeID
+        }
+        if assignee != tc.assignee {
+            t.Fatalf("%q: expected assignee %q, got %q", tc.text, tc.assignee, assignee)
+        }
+    }
+}
+
+// Test that relative dates follow the tenant's time zone: at 20:00 UTC on a
+// Wednesday it is already Thursday in Jakarta, so "today" and "friday 5pm"
+// land on different instants there.
+func TestParseQuickAdd_TenantTimezone(t *testing.T) {
+    jakarta, err := time.LoadLocation("Asia/Jakarta")
+    if err != nil {
+        t.Skipf("time zone database unavailable: %v", err)
+    }
+    now := time.Date(2026, 10, 14, 20, 0, 0, 0, time.UTC)
+    cases := []struct {
+        text string
+        loc  *time.Location
+        want time.Time
+    }{
+        {"pay today", time.UTC, time.Date(2026, 10, 14, 23, 59, 59, 0, time.UTC)},
+        {"pay today", jakarta, time.Date(2026, 10, 15, 16, 59, 59, 0, time.UTC)},
+        {"ship friday 5pm", time.UTC, time.Date(2026, 10, 16, 17, 0, 0, 0, time.UTC)},
+        {"ship friday 5pm", jakarta, time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)},
+    }
+    for _, tc := range cases {
+        got := apptask.ParseQuickAdd(tc.text, now.In(tc.loc))
+        if got.DueDate == nil || !got.DueDate.Equal(tc.want) || got.DueDate.Location() != time.UTC {
+            t.Fatalf("%q in %s: expected %v, got %v", tc.text, tc.loc, tc.want, got.DueDate)
+        }
+    }
+}
+
+// Test that a dry run only parses while a real quick add creates the task
+// with the parsed tags, priority and assignee.
+func TestService_QuickAdd(t *testing.T) {
+    ctx := context.Background()
+    repo := memory.NewTaskRepository()
+    svc := apptask.NewService(repo)
+
+    parsed, created, err := svc.QuickAdd(ctx, "t1", "u1", "Ship report #billing p2 @alex", nil, true)
+    if err != nil || created != nil || parsed.Title != "Ship report" {
+        t.Fatalf("expected a parse only, got %+v, %+v, %v", parsed, created, err)
+    }
+    if items, _ := repo.ListByTenant(ctx, "t1"); len(items) != 0 {
+        t.Fatalf("dry run created %d tasks", len(items))
+    }
+
+    _, created, err = svc.QuickAdd(ctx, "t1", "u1", "Ship report #billing p2 @alex", nil, false)
+    if err != nil {
+        t.Fatalf("quick add: %v", err)
+    }
+    stored, err := repo.Get(ctx, "t1", created.ID)
+    if err != nil {
+        t.Fatalf("get: %v", err)
+    }
+    if stored.Title != "Ship report" || stored.Priority != 7 || len(stored.Tags) != 1 || stored.Tags[0] != "billing" ||
+        stored.AssigneeID == nil || *stored.AssigneeID != "alex" || stored.UserID != "u1" {
+        t.Fatalf("unexpected task %+v", stored)
+    }
+
+    if _, _, err := svc.QuickAdd(ctx, "t1", "u1", "#only p1", nil, false); err == nil {
+        t.Fatalf("expected an error for a line without a title")
+    }
+}
@@ -0,0 +1,56 @@
+package task
+
+import (
+    "context"
+    "errors"
+    "time"
+
+    domaintask "backend/internal/domain/task"
+)
+
+var (
+    // ErrNotCompleted is returned by Reopen for a task that is neither done
+    // nor archived.
+    ErrNotCompleted = errors.New("task is not done or archived")
+    // ErrInvalidReopenStatus is returned by Reopen for a status other than
+    // todo or in progress.
+    ErrInvalidReopenStatus = errors.New("a task can only be reopened as todo or in_progress")
+)
+
+// Reopen moves the tenant's done or archived task back to status, which is
+// domaintask.StatusInProgress when empty or domaintask.StatusTodo. It
+// returns ErrNotCompleted for an open task and ErrForbidden when the policy
+// does not let actor change it.
+func (s *Service) Reopen(ctx context.Context, tenantID, id string, actor Actor, status string) (*domaintask.Task, error) {
+    switch status {
+    case "":
+        status = domaintask.StatusInProgress
+    case domaintask.StatusTodo, domaintask.StatusInProgress:
+    default:
+        s.metrics.operationFailed(ctx, "reopen", ErrInvalidReopenStatus)
+        return nil, ErrInvalidReopenStatus
+    }
+    t, err := s.repo.Get(ctx, tenantID, id)
+    if err != nil {
+        return nil, err
+    }
+    if err := s.authorize(actor, *t); err != nil {
+        s.metrics.operationFailed(ctx, "reopen", err)
+        return nil, err
+    }
+    if t.Status != domaintask.StatusDone && t.Status != domaintask.StatusArchived {
+        s.metrics.operationFailed(ctx, "reopen", ErrNotCompleted)
+        return nil, ErrNotCompleted
+    }
+    from := t.Status
+    t.SetStatus(status, time.Now().UTC())
+    if err := s.repo.Update(ctx, t, FieldStatus); err != nil {
+        s.logFailure(ctx, "reopen", err)
+        return nil, err
+    }
+    s.scores.Invalidate(tenantID)
+    e := domaintask.TaskReopened{TenantID: tenantID, TaskID: id, FromStatus: from, Status: status, OccurredAt: time.Now().UTC()}
+    s.events.Publish(ctx, e)
+    s.notifyWatchers(ctx, s.watchersOf(ctx, tenantID, id), id, e)
+    return t, nil
+}
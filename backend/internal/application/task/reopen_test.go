@@ -0,0 +1,76 @@
+package task_test
+
+import (
+    "context"
+    "errors"
+    "testing"
+
+    apptask "backend/internal/application/task"
+    domaintask "backend/internal/domain/task"
+    "backend/internal/infrastructure/memory"
+)
+
+// Test that a done task reopens as in progress, or as todo when asked,
+// raising TaskReopened, and that an open task or another status is refused.
+func TestService_Reopen(t *testing.T) {
+    ctx := context.Background()
+    pub := &recordingPublisher{}
+    svc := apptask.NewService(memory.NewTaskRepository(), apptask.WithEventPublisher(pub))
+    tk, _ := svc.Create(ctx, "t1", "u1", "a", "", 5)
+
+    if _, err := svc.Reopen(ctx, "t1", tk.ID, owner, ""); !errors.Is(err, apptask.ErrNotCompleted) {
+        t.Fatalf("expected apptask.ErrNotCompleted for a todo task, got %v", err)
+    }
+
+    done := domaintask.StatusDone
+    if _, err := svc.Update(ctx, "t1", tk.ID, owner, apptask.UpdateTaskInput{Status: &done}); err != nil {
+        t.Fatalf("update: %v", err)
+    }
+    if _, err := svc.Reopen(ctx, "t1", tk.ID, owner, domaintask.StatusDone); !errors.Is(err, apptask.ErrInvalidReopenStatus) {
+        t.Fatalf("expected apptask.ErrInvalidReopenStatus, got %v", err)
+    }
+    reopened, err := svc.Reopen(ctx, "t1", tk.ID, owner, "")
+    if err != nil {
+        t.Fatalf("reopen: %v", err)
+    }
+    if reopened.Status != domaintask.StatusInProgress {
+        t.Fatalf("expected %s, got %s", domaintask.StatusInProgress, reopened.Status)
+    }
+    e, ok := pub.events[len(pub.events)-1].(domaintask.TaskReopened)
+    if !ok || e.FromStatus != domaintask.StatusDone || e.Status != domaintask.StatusInProgress {
+        t.Fatalf("expected a TaskReopened event from done, got %+v", pub.events[len(pub.events)-1])
+    }
+
+    archived := domaintask.StatusArchived
+    if _, err := svc.Update(ctx, "t1", tk.ID, owner, apptask.UpdateTaskInput{Status: &archived}); err != nil {
+        t.Fatalf("update: %v", err)
+    }
+    if _, err := svc.Reopen(ctx, "t1", tk.ID, apptask.Actor{UserID: "u2"}, ""); !errors.Is(err, apptask.ErrForbidden) {
+        t.Fatalf("expected apptask.ErrForbidden, got %v", err)
+    }
+    if reopened, err = svc.Reopen(ctx, "t1", tk.ID, owner, domaintask.StatusTodo); err != nil || reopened.Status != domaintask.StatusTodo {
+        t.Fatalf("expected the archived task reopened as todo, got %+v (%v)", reopened, err)
+    }
+}
+
+// Test that completing a task stamps CompletedAt and reopening it clears
+// the stored timestamp.
+func TestService_Reopen_ClearsCompletedAt(t *testing.T) {
+    ctx := context.Background()
+    svc := apptask.NewService(memory.NewTaskRepository())
+    tk, _ := svc.Create(ctx, "t1", "u1", "a", "", 5)
+
+    done := domaintask.StatusDone
+    if _, err := svc.Update(ctx, "t1", tk.ID, owner, apptask.UpdateTaskInput{Status: &done}); err != nil {
+        t.Fatalf("update: %v", err)
+    }
+    if got, _ := svc.Get(ctx, "t1", tk.ID); got.CompletedAt == nil {
+        t.Fatalf("expected CompletedAt to be set on a done task, got nil")
+    }
+    if _, err := svc.Reopen(ctx, "t1", tk.ID, owner, ""); err != nil {
+        t.Fatalf("reopen: %v", err)
+    }
+    if got, _ := svc.Get(ctx, "t1", tk.ID); got.CompletedAt != nil {
+        t.Fatalf("expected CompletedAt to be cleared, got %v", got.CompletedAt)
+    }
+}
@@ -0,0 +1,100 @@
+package task
+
+import (
+    "context"
+    "errors"
+    "io"
+    "time"
+
+    domaintask "backend/internal/domain/task"
+)
+
+// ErrReportWriterUnavailable is returned by ExportReport when the service has
+// no ReportWriter.
+var ErrReportWriterUnavailable = errors.New("report writer is not configured")
+
+// reportStatuses lists the statuses a report counts, in display order.
+var reportStatuses = []string{
+    domaintask.StatusTodo,
+    domaintask.StatusInProgress,
+    domaintask.StatusDone,
+    domaintask.StatusArchived,
+}
+
+// StatusCount is the number of reported tasks in one status.
+type StatusCount struct {
+    Status string
+    Count  int
+}
+
+// Report is a printable snapshot of a tenant's tasks. Dates are meant to be
+// shown in Location.
+type Report struct {
+    TenantID     string
+    TenantName   string
+    GeneratedAt  time.Time
+    Location     *time.Location
+    StatusCounts []StatusCount
+    Tasks        []domaintask.Task
+}
+
+// ReportWriter renders a Report in a document format such as PDF.
+type ReportWriter interface {
+    WriteReport(w io.Writer, r Report) error
+}
+
+// TenantNamer looks up the display name of a tenant.
+type TenantNamer interface {
+    TenantName(ctx context.Context, tenantID string) (string, error)
+}
+
+// WithReportWriter sets the renderer used by ExportReport. By default there
+// is none and ExportReport returns ErrReportWriterUnavailable.
+func WithReportWriter(rw ReportWriter) Option {
+    return func(s *Service) { s.reports = rw }
+}
+
+// WithTenantNamer sets where report titles get the tenant's name from. By
+// default, and when the lookup fails, the tenant ID is shown instead.
+func WithTenantNamer(n TenantNamer) Option {
+    return func(s *Service) { s.tenantNames = n }
+}
+
+// ExportReport writes a report of the tenant's tasks matching f to w: counts
+// per status followed by every task, ordered by due date.
+func (s *Service) ExportReport(ctx context.Context, tenantID string, f FilterOptions, w io.Writer) error {
+    if s.reports == nil {
+        return ErrReportWriterUnavailable
+    }
+    items, _, err := s.repo.List(ctx, tenantID, f, SortOptions{Field: SortDueDate}, ListOptions{})
+    if err != nil {
+        return err
+    }
+    counts := make(map[string]int, len(reportStatuses))
+    for _, t := range items {
+        counts[t.Status]++
+    }
+    statusCounts := make([]StatusCount, 0, len(reportStatuses))
+    for _, st := range reportStatuses {
+        statusCounts = append(statusCounts, StatusCount{Status: st, Count: counts[st]})
+    }
+    return s.reports.WriteReport(w, Report{
+        TenantID:     tenantID,
+        TenantName:   s.tenantName(ctx, tenantID),
+        GeneratedAt:  time.Now().UTC(),
+        Location:     s.location(tenantID),
+        StatusCounts: statusCounts,
+        Tasks:        items,
+    })
+}
+
+func (s *Service) tenantName(ctx context.Context, tenantID string) string {
+    if s.tenantNames == nil {
+        return tenantID
+    }
+    name, err := s.tenantNames.TenantName(ctx, tenantID)
+    if err != nil || name == "" {
+        return tenantID
+    }
+    return name
+}
@@ -0,0 +1,74 @@
+package task_test
+
+import (
+    "context"
+    "errors"
+    "io"
+    "testing"
+
+    apptask "backend/internal/application/task"
+    domainaccount "backend/internal/domain/account"
+    domaintask "backend/internal/domain/task"
+    "backend/internal/infrastructure/memory"
+)
+
+// captureReportWriter keeps the last report it was asked to write.
+type captureReportWriter struct {
+    report apptask.Report
+}
+
+func (c *captureReportWriter) WriteReport(_ io.Writer, r apptask.Report) error {
+    c.report = r
+    return nil
+}
+
+// Test that the report counts the tenant's tasks per status, carries the
+// tenant's registered name and falls back to the tenant ID without one.
+func TestService_ExportReport(t *testing.T) {
+    ctx := context.Background()
+    repo := memory.NewTaskRepository()
+    done := domaintask.New("t1", "u1", "Done", "", 5)
+    done.Status = domaintask.StatusDone
+    for _, tk := range []*domaintask.Task{
+        domaintask.New("t1", "u1", "Open", "", 5),
+        domaintask.New("t1", "u1", "Also open", "", 5),
+        done,
+        domaintask.New("t2", "u1", "Not ours", "", 5),
+    } {
+        if err := repo.Create(ctx, tk); err != nil {
+            t.Fatalf("seed: %v", err)
+        }
+    }
+    accounts := memory.NewAccountRepository()
+    tenant := domainaccount.NewTenant("Acme")
+    if err := accounts.CreateTenant(ctx, tenant, domainaccount.NewUser(tenant.ID, "ada@example.com", "hash")); err != nil {
+        t.Fatalf("seed tenant: %v", err)
+    }
+
+    rw := &captureReportWriter{}
+    svc := apptask.NewService(repo, apptask.WithReportWriter(rw), apptask.WithTenantNamer(accounts))
+    if err := svc.ExportReport(ctx, "t1", apptask.FilterOptions{}, io.Discard); err != nil {
+        t.Fatalf("export: %v", err)
+    }
+    if len(rw.report.Tasks) != 3 || rw.report.TenantName != "t1" {
+        t.Fatalf("expected t1's 3 tasks under its ID, got %d under %q", len(rw.report.Tasks), rw.report.TenantName)
+    }
+    counts := map[string]int{}
+    for _, sc := range rw.report.StatusCounts {
+        counts[sc.Status] = sc.Count
+    }
+    if len(rw.report.StatusCounts) != 4 || counts[domaintask.StatusTodo] != 2 || counts[domaintask.StatusDone] != 1 {
+        t.Fatalf("expected 2 todo and 1 done of 4 statuses, got %+v", rw.report.StatusCounts)
+    }
+
+    if err := svc.ExportReport(ctx, tenant.ID, apptask.FilterOptions{}, io.Discard); err != nil {
+        t.Fatalf("export: %v", err)
+    }
+    if rw.report.TenantName != "Acme" {
+        t.Fatalf("expected Acme, got %q", rw.report.TenantName)
+    }
+
+    if err := apptask.NewService(repo).ExportReport(ctx, "t1", apptask.FilterOptions{}, io.Discard); !errors.Is(err, apptask.ErrReportWriterUnavailable) {
+        t.Fatalf("expected ErrReportWriterUnavailable, got %v", err)
+    }
+}
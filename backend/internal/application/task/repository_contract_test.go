@@ -0,0 +1,180 @@
+package task_test
+
+import (
+    "context"
+    "errors"
+    "os"
+    "testing"
+    "time"
+
+    apptask "backend/internal/application/task"
+    domaintask "backend/internal/domain/task"
+    "backend/internal/infrastructure/memory"
+    pginfra "backend/internal/infrastructure/postgres"
+    "backend/internal/pkg/config"
+
+    "github.com/google/uuid"
+)
+
+// repositoryFactory returns a repository under contract and a tenant no
+// other test writes to.
+type repositoryFactory func(t *testing.T) (apptask.Repository, string)
+
+// repositories lists the Repository implementations every contract test runs
+// against. Postgres joins when TEST_DATABASE_URL names a database the tests
+// may migrate and write to; each test then uses a fresh tenant and purges it
+// afterwards.
+func repositories(t *testing.T) map[string]repositoryFactory {
+    t.Helper()
+    impls := map[string]repositoryFactory{
+        "memory": func(t *testing.T) (apptask.Repository, string) {
+            return memory.NewTaskRepository(), "t1"
+        },
+    }
+    dsn := os.Getenv("TEST_DATABASE_URL")
+    if dsn == "" {
+        return impls
+    }
+    db, err := pginfra.Connect(config.Config{DatabaseURL: dsn})
+    if err != nil {
+        t.Fatalf("connect %s: %v", dsn, err)
+    }
+    impls["postgres"] = func(t *testing.T) (apptask.Repository, string) {
+        tenantID := "contract-" + uuid.NewString()
+        t.Cleanup(func() {
+            tenants := pginfra.NewTenantRepository(db)
+            for _, id := range []string{tenantID, tenantID + "-other"} {
+                if _, err := tenants.PurgeData(context.Background(), id); err != nil {
+                    t.Errorf("purge %s: %v", id, err)
+                }
+            }
+        })
+        return pginfra.NewTaskRepository(db), tenantID
+    }
+    return impls
+}
+
+// Test that every repository filters, sorts and pages a listing the same
+// way, so the in-memory repository can stand in for postgres in tests.
+func TestRepositoryContract_List(t *testing.T) {
+    for name, newRepo := range repositories(t) {
+        t.Run(name, func(t *testing.T) {
+            ctx := context.Background()
+            repo, tenantID := newRepo(t)
+            now := time.Now().UTC().Truncate(time.Second)
+            tasks := seedTasks(t, repo, tenantID, now)
+            u2 := "u2"
+            from, before := now.Add(-3*time.Hour), now.Add(3*time.Hour)
+
+            for _, tc := range []struct {
+                name   string
+                filter apptask.FilterOptions
+                sort   apptask.SortOptions
+                page   apptask.ListOptions
+                want   string
+                total  int64
+            }{
+                {"all", apptask.FilterOptions{}, apptask.SortOptions{}, apptask.ListOptions{}, "abcde", 5},
+                {"user or assignee", apptask.FilterOptions{UserID: &u2}, apptask.SortOptions{}, apptask.ListOptions{}, "bde", 3},
+                {"parent", apptask.FilterOptions{ParentID: &tasks["a"].ID}, apptask.SortOptions{}, apptask.ListOptions{}, "bd", 2},
+                {"statuses", apptask.FilterOptions{Statuses: []string{domaintask.StatusTodo, domaintask.StatusDone}}, apptask.SortOptions{}, apptask.ListOptions{}, "acd", 3},
+                {"open", apptask.FilterOptions{Open: true}, apptask.SortOptions{}, apptask.ListOptions{}, "abd", 3},
+                {"due range", apptask.FilterOptions{DueFrom: &from, DueBefore: &before}, apptask.SortOptions{}, apptask.ListOptions{}, "a", 1},
+                {"aiScore nulls last", apptask.FilterOptions{}, apptask.SortOptions{Field: apptask.SortAIScore}, apptask.ListOptions{}, "adcbe", 5},
+                {"-aiScore nulls last", apptask.FilterOptions{}, apptask.SortOptions{Field: apptask.SortAIScore, Desc: true}, apptask.ListOptions{}, "cdabe", 5},
+                {"dueDate", apptask.FilterOptions{}, apptask.SortOptions{Field: apptask.SortDueDate}, apptask.ListOptions{}, "cabed", 5},
+                {"-priority ties by creation", apptask.FilterOptions{}, apptask.SortOptions{Field: apptask.SortPriority, Desc: true}, apptask.ListOptions{}, "badec", 5},
+                {"-createdAt", apptask.FilterOptions{}, apptask.SortOptions{Field: apptask.SortCreatedAt, Desc: true}, apptask.ListOptions{}, "edcba", 5},
+                {"page", apptask.FilterOptions{}, apptask.SortOptions{}, apptask.ListOptions{Limit: 2, Offset: 2}, "cd", 5},
+                {"page past the end", apptask.FilterOptions{Open: true}, apptask.SortOptions{}, apptask.ListOptions{Limit: 2, Offset: 9}, "", 3},
+            } {
+                items, total, err := repo.List(ctx, tenantID, tc.filter, tc.sort, tc.page)
+                if err != nil {
+                    t.Fatalf("%s: %v", tc.name, err)
+                }
+                if got := titles(items); got != tc.want || total != tc.total {
+                    t.Fatalf("%s: expected %s (total %d), got %s (total %d)", tc.name, tc.want, tc.total, got, total)
+                }
+            }
+        })
+    }
+}
+
+// Test that Update writes only the listed fields, zero values included, that
+// Get and Delete stay within the tenant and that missing tasks report
+// ErrNotFound.
+func TestRepositoryContract_UpdateAndDelete(t *testing.T) {
+    for name, newRepo := range repositories(t) {
+        t.Run(name, func(t *testing.T) {
+            ctx := context.Background()
+            repo, tenantID := newRepo(t)
+            due := time.Now().UTC().Truncate(time.Second)
+            tk := domaintask.New(tenantID, "u1", "title", "description", 5)
+            tk.DueDate = &due
+            if err := repo.Create(ctx, tk); err != nil {
+                t.Fatalf("create: %v", err)
+            }
+
+            changed := *tk
+            changed.Title, changed.Description, changed.DueDate, changed.Priority = "renamed", "", nil, 9
+            if err := repo.Update(ctx, &changed, apptask.FieldTitle, apptask.FieldDescription, apptask.FieldDueDate); err != nil {
+                t.Fatalf("update: %v", err)
+            }
+            got, err := repo.Get(ctx, tenantID, tk.ID)
+            if err != nil {
+                t.Fatalf("get: %v", err)
+            }
+            if got.Title != "renamed" || got.Description != "" || got.DueDate != nil || got.Priority != 5 {
+                t.Fatalf("expected title, description and due date written and priority kept, got %+v", got)
+            }
+
+            if _, err := repo.Get(ctx, tenantID+"-other", tk.ID); !errors.Is(err, apptask.ErrNotFound) {
+                t.Fatalf("expected another tenant not to see the task, got %v", err)
+            }
+            if err := repo.Delete(ctx, tenantID, tk.ID); err != nil {
+                t.Fatalf("delete: %v", err)
+            }
+            if _, err := repo.Get(ctx, tenantID, tk.ID); !errors.Is(err, apptask.ErrNotFound) {
+                t.Fatalf("expected the deleted task to be gone, got %v", err)
+            }
+            if err := repo.Delete(ctx, tenantID, tk.ID); !errors.Is(err, apptask.ErrNotFound) {
+                t.Fatalf("expected deleting it again to report %v, got %v", apptask.ErrNotFound, err)
+            }
+        })
+    }
+}
+
+// Test that GetMany returns only the tenant's tasks among a mixed id list,
+// in the order asked and each once, leaving out missing and foreign ids.
+func TestRepositoryContract_GetMany(t *testing.T) {
+    for name, newRepo := range repositories(t) {
+        t.Run(name, func(t *testing.T) {
+            ctx := context.Background()
+            repo, tenantID := newRepo(t)
+            a := domaintask.New(tenantID, "u1", "a", "", 5)
+            b := domaintask.New(tenantID, "u1", "b", "", 5)
+            foreign := domaintask.New(tenantID+"-other", "u1", "foreign", "", 5)
+            for _, tk := range []*domaintask.Task{a, b, foreign} {
+                if err := repo.Create(ctx, tk); err != nil {
+                    t.Fatalf("create %s: %v", tk.Title, err)
+                }
+            }
+
+            got, err := repo.GetMany(ctx, tenantID, []string{b.ID, uuid.NewString(), foreign.ID, a.ID, b.ID, "not-a-uuid"})
+            if err != nil {
+                t.Fatalf("get many: %v", err)
+            }
+            var titles string
+            for _, tk := range got {
+                titles += tk.Title
+            }
+            if titles != "ba" {
+                t.Fatalf("expected ba, got %q", titles)
+            }
+
+            if got, err := repo.GetMany(ctx, tenantID, nil); err != nil || len(got) != 0 {
+                t.Fatalf("expected no tasks for no ids, got %d (%v)", len(got), err)
+            }
+        })
+    }
+}
@@ -0,0 +1,9 @@
+package task
+
+import "backend/internal/pkg/sanitize"
+
+// sanitizeDescription removes HTML that could execute in a browser from a
+// task description before it is validated and stored.
+func sanitizeDescription(s string) string {
+    return sanitize.HTML(s)
+}
@@ -3,52 +3,376 @@ package task
 import (
     "context"
     "errors"
-    "strings"
+    "log/slog"
+    "sort"
+    "time"
 
+    appjob "backend/internal/application/job"
     domaintask "backend/internal/domain/task"
+    "backend/internal/pkg/ctxkeys"
+    "backend/internal/pkg/requestid"
+
+    "go.opentelemetry.io/otel"
+    "go.opentelemetry.io/otel/metric"
+    "go.opentelemetry.io/otel/metric/noop"
 )
 
 // Service implements task-related application use cases.
 type Service struct {
-    repo Repository
+    repo          Repository
+    events        EventPublisher
+    notifier      Notifier
+    scores        ScoreCache
+    summarizer    Summarizer
+    subtasks      SubtaskGenerator
+    jobs          appjob.JobRunner
+    logger        *slog.Logger
+    meters        metric.MeterProvider
+    metrics       metrics
+    limits        domaintask.Limits
+    normalizeZero bool
+    blockDone     bool
+    timezones     map[string]*time.Location
+    reports       ReportWriter
+    decoders      map[string]TaskDecoder
+    policy        Policy
+    tenantNames   TenantNamer
+    projects      ProjectLookup
+    files         FileStore
+}
+
+// Option configures optional Service behaviour.
+type Option func(*Service)
+
+// WithNormalizeZero controls whether a zero priority on create is replaced by
+// domaintask.DefaultPriority (enabled by default) or rejected as out of range.
+func WithNormalizeZero(enabled bool) Option {
+    return func(s *Service) { s.normalizeZero = enabled }
+}
+
+// WithLengthLimits sets the maximum title and description lengths in runes.
+// By default domaintask.DefaultLimits() applies; non-positive values keep
+// the default for that field.
+func WithLengthLimits(maxTitle, maxDescription int) Option {
+    return func(s *Service) {
+        if maxTitle > 0 {
+            s.limits.Title = maxTitle
+        }
+        if maxDescription > 0 {
+            s.limits.Description = maxDescription
+        }
+    }
+}
+
+// WithFileStore sets where the files attached to tasks are kept, so they
+// are removed with their task. By default they are left in place.
+func WithFileStore(f FileStore) Option {
+    return func(s *Service) { s.files = f }
+}
+
+// WithEventPublisher sets where domain events are published. By default they
+// are discarded.
+func WithEventPublisher(p EventPublisher) Option {
+    return func(s *Service) { s.events = p }
+}
+
+// WithNotifier sets where watcher notifications are queued. By default they
+// are discarded.
+func WithNotifier(n Notifier) Option {
+    return func(s *Service) { s.notifier = n }
+}
+
+// WithScoreCache sets the cache invalidated whenever a task is created,
+// updated or deleted. By default there is none.
+func WithScoreCache(c ScoreCache) Option {
+    return func(s *Service) { s.scores = c }
 }
 
-func NewService(repo Repository) *Service {
-    return &Service{repo: repo}
+// WithSummarizer sets the model used by Summarize. By default there is none
+// and Summarize returns ErrSummarizerUnavailable.
+func WithSummarizer(sm Summarizer) Option {
+    return func(s *Service) { s.summarizer = sm }
 }
 
-// UpdateTaskInput describes partial updates for a task.
+// WithSubtaskGenerator sets the model used by GenerateSubtasks. By default
+// there is none and GenerateSubtasks returns ErrSubtaskGeneratorUnavailable.
+func WithSubtaskGenerator(g SubtaskGenerator) Option {
+    return func(s *Service) { s.subtasks = g }
+}
+
+// WithJobRunner sets the queue SubmitImport hands large imports to. By
+// default there is none and every import runs in the request.
+func WithJobRunner(r appjob.JobRunner) Option {
+    return func(s *Service) { s.jobs = r }
+}
+
+// WithDoneBlocking controls whether Update refuses to move a task to done
+// while one of its blockers is still open (disabled by default).
+func WithDoneBlocking(enabled bool) Option {
+    return func(s *Service) { s.blockDone = enabled }
+}
+
+// WithTimezones sets the zone each tenant's relative dates are read in, such
+// as "friday" in QuickAdd. Tenants without an entry use UTC.
+func WithTimezones(byTenant map[string]*time.Location) Option {
+    return func(s *Service) { s.timezones = byTenant }
+}
+
+// WithLogger sets the logger used to report failed operations. By default
+// slog.Default() is used.
+func WithLogger(l *slog.Logger) Option {
+    return func(s *Service) { s.logger = l }
+}
+
+// WithMeterProvider sets where the service's counters are registered. By
+// default the global otel MeterProvider is used.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+    return func(s *Service) { s.meters = mp }
+}
+
+func NewService(repo Repository, opts ...Option) *Service {
+    s := &Service{repo: repo, events: noopPublisher{}, notifier: noopNotifier{}, scores: noopScoreCache{}, logger: slog.Default(), meters: otel.GetMeterProvider(), limits: domaintask.DefaultLimits(), normalizeZero: true, policy: OwnerPolicy{}}
+    for _, opt := range opts {
+        opt(s)
+    }
+    m, err := newMetrics(s.meters)
+    if err != nil {
+        // Instrument creation only fails on invalid names; fall back to no-ops.
+        s.logger.Error("register task metrics", "error", err)
+        m, _ = newMetrics(noop.NewMeterProvider())
+    }
+    s.metrics = m
+    return s
+}
+
+// CreateTaskInput describes a new task. DueDate is optional; ParentID makes
+// the task a subtask of another task of the tenant. AssigneeID and Tags are
+// stored as given. Status, when set, replaces the initial todo, as for tasks
+// imported already done.
+type CreateTaskInput struct {
+    Title       string
+    Description string
+    Priority    int
+    DueDate     *time.Time
+    ParentID    *string
+    AssigneeID  *string
+    Tags        []string
+    Status      string
+}
+
+// UpdateTaskInput describes partial updates for a task. ClearDueDate removes
+// the due date and takes precedence over DueDate.
 type UpdateTaskInput struct {
-    Title       *string
-    Description *string
-    Status      *string
-    Priority    *int
+    Title        *string
+    Description  *string
+    Status       *string
+    Priority     *int
+    DueDate      *time.Time
+    ClearDueDate bool
+}
+
+// fields lists the task fields in changes.
+func (in UpdateTaskInput) fields() []TaskField {
+    var out []TaskField
+    if in.Title != nil {
+        out = append(out, FieldTitle)
+    }
+    if in.Description != nil {
+        out = append(out, FieldDescription)
+    }
+    if in.Status != nil {
+        out = append(out, FieldStatus)
+    }
+    if in.Priority != nil {
+        out = append(out, FieldPriority)
+    }
+    if in.DueDate != nil || in.ClearDueDate {
+        out = append(out, FieldDueDate)
+    }
+    return out
+}
+
+// logFailure records a failed repository call together with the request ID
+// carried by ctx so it can be correlated with the error returned to the client.
+// The failure is also counted in task_operation_errors_total.
+func (s *Service) logFailure(ctx context.Context, op string, err error) {
+    s.metrics.operationFailed(ctx, op, err)
+    s.logger.ErrorContext(ctx, "task operation failed",
+        "op", op,
+        "request_id", requestid.FromContext(ctx),
+        "correlation_id", ctxkeys.CorrelationIDFromCtx(ctx),
+        "error", err,
+    )
 }
 
+// List returns every task of the tenant, oldest first.
 func (s *Service) List(ctx context.Context, tenantID string) ([]domaintask.Task, error) {
-    return s.repo.ListByTenant(ctx, tenantID)
+    items, _, err := s.repo.List(ctx, tenantID, FilterOptions{}, SortOptions{}, ListOptions{})
+    if err != nil {
+        s.logFailure(ctx, "list", err)
+        return nil, err
+    }
+    return items, nil
+}
+
+// GetMany returns the tenant's tasks with the given ids, in their order and
+// each once; unknown ids are left out.
+func (s *Service) GetMany(ctx context.Context, tenantID string, ids []string) ([]domaintask.Task, error) {
+    items, err := s.repo.GetMany(ctx, tenantID, ids)
+    if err != nil {
+        s.logFailure(ctx, "get many", err)
+        return nil, err
+    }
+    return items, nil
+}
+
+// ListFiltered returns the page of the tenant's tasks matching f, ordered by
+// sortKey (see ParseSort), and the number of matching tasks.
+func (s *Service) ListFiltered(ctx context.Context, tenantID string, f FilterOptions, sortKey string, page ListOptions) ([]domaintask.Task, int64, error) {
+    order, err := ParseSort(sortKey)
+    if err != nil {
+        return nil, 0, err
+    }
+    items, total, err := s.repo.List(ctx, tenantID, f, order, page)
+    if err != nil {
+        s.logFailure(ctx, "list", err)
+        return nil, 0, err
+    }
+    return items, total, nil
 }
 
+// ListOpenPage returns one page of the tenant's open tasks in id order; pass
+// the last id of the previous page as afterID to continue.
+func (s *Service) ListOpenPage(ctx context.Context, tenantID, afterID string, limit int) ([]domaintask.Task, error) {
+    items, err := s.repo.ListOpenPage(ctx, tenantID, afterID, limit)
+    if err != nil {
+        s.logFailure(ctx, "list open page", err)
+        return nil, err
+    }
+    return items, nil
+}
+
+// ListMine returns the tenant's tasks assigned to userID, plus unassigned
+// tasks userID created, ordered by due date (undated last) and then by
+// descending priority.
+func (s *Service) ListMine(ctx context.Context, tenantID, userID string) ([]domaintask.Task, error) {
+    items, err := s.List(ctx, tenantID)
+    if err != nil {
+        return nil, err
+    }
+    mine := make([]domaintask.Task, 0, len(items))
+    for _, t := range items {
+        owner := t.UserID
+        if t.AssigneeID != nil {
+            owner = *t.AssigneeID
+        }
+        if owner == userID {
+            mine = append(mine, t)
+        }
+    }
+    sort.SliceStable(mine, func(i, j int) bool {
+        a, b := mine[i], mine[j]
+        switch {
+        case a.DueDate != nil && b.DueDate != nil && !a.DueDate.Equal(*b.DueDate):
+            return a.DueDate.Before(*b.DueDate)
+        case (a.DueDate == nil) != (b.DueDate == nil):
+            return a.DueDate != nil
+        case a.Priority != b.Priority:
+            return a.Priority > b.Priority
+        }
+        return a.ID < b.ID
+    })
+    return mine, nil
+}
+
+// Create is CreateTask without a due date.
 func (s *Service) Create(ctx context.Context, tenantID, userID, title, description string, priority int) (*domaintask.Task, error) {
-    if strings.TrimSpace(title) == "" {
-        return nil, errors.New("title is required")
+    return s.CreateTask(ctx, tenantID, userID, CreateTaskInput{Title: title, Description: description, Priority: priority})
+}
+
+// CreateTask validates in and stores it as a new task of the tenant owned by
+// userID.
+func (s *Service) CreateTask(ctx context.Context, tenantID, userID string, in CreateTaskInput) (*domaintask.Task, error) {
+    description := sanitizeDescription(in.Description)
+    priority, err := s.validateCreate(in.Title, description, in.Priority)
+    if err != nil {
+        s.metrics.operationFailed(ctx, "create", err)
+        return nil, err
+    }
+    if in.ParentID != nil {
+        if _, err := s.repo.Get(ctx, tenantID, *in.ParentID); err != nil {
+            s.metrics.operationFailed(ctx, "create", ErrParentNotFound)
+            return nil, ErrParentNotFound
+        }
+    }
+    t := domaintask.New(tenantID, userID, in.Title, description, priority)
+    t.ParentID = in.ParentID
+    t.AssigneeID = in.AssigneeID
+    t.Tags = in.Tags
+    if in.Status != "" {
+        t.SetStatus(in.Status, t.CreatedAt)
+    }
+    if in.DueDate != nil {
+        due := in.DueDate.UTC()
+        t.DueDate = &due
     }
-    t := domaintask.New(tenantID, userID, title, description, priority)
     if err := s.repo.Create(ctx, t); err != nil {
+        s.logFailure(ctx, "create", err)
         return nil, err
     }
+    s.metrics.taskCreated(ctx, tenantID)
+    s.scores.Invalidate(tenantID)
+    s.events.Publish(ctx, domaintask.TaskCreated{TenantID: tenantID, Task: *t, OccurredAt: time.Now().UTC()})
     return t, nil
 }
 
+// validateCreate checks the fields of a new task and returns the priority to
+// store, applying the zero-priority default when enabled.
+func (s *Service) validateCreate(title, description string, priority int) (int, error) {
+    if err := s.limits.ValidateTitle(title); err != nil {
+        return 0, err
+    }
+    if err := s.limits.ValidateDescription(description); err != nil {
+        return 0, err
+    }
+    if priority == 0 && s.normalizeZero {
+        priority = domaintask.DefaultPriority
+    }
+    if err := domaintask.ValidatePriority(priority); err != nil {
+        return 0, err
+    }
+    return priority, nil
+}
+
 func (s *Service) Get(ctx context.Context, tenantID, id string) (*domaintask.Task, error) {
     return s.repo.Get(ctx, tenantID, id)
 }
 
-func (s *Service) Update(ctx context.Context, tenantID, id string, in UpdateTaskInput) (*domaintask.Task, error) {
+// Update applies in to the tenant's task, returning ErrForbidden when the
+// policy does not let actor change it.
+func (s *Service) Update(ctx context.Context, tenantID, id string, actor Actor, in UpdateTaskInput) (*domaintask.Task, error) {
+    if in.Description != nil {
+        clean := sanitizeDescription(*in.Description)
+        in.Description = &clean
+    }
+    if err := s.validateUpdate(in); err != nil {
+        s.metrics.operationFailed(ctx, "update", err)
+        return nil, err
+    }
     t, err := s.repo.Get(ctx, tenantID, id)
     if err != nil {
         return nil, err
     }
+    if err := s.authorize(actor, *t); err != nil {
+        s.metrics.operationFailed(ctx, "update", err)
+        return nil, err
+    }
+    if s.blockDone && in.Status != nil && *in.Status == domaintask.StatusDone && t.Status != domaintask.StatusDone {
+        if err := s.checkBlockers(ctx, tenantID, id); err != nil {
+            s.metrics.operationFailed(ctx, "update", err)
+            return nil, err
+        }
+    }
     if in.Title != nil {
         t.Title = *in.Title
     }
@@ -56,18 +380,136 @@ func (s *Service) Update(ctx context.Context, tenantID, id string, in UpdateTask
         t.Description = *in.Description
     }
     if in.Status != nil {
-        t.Status = *in.Status
+        t.SetStatus(*in.Status, time.Now().UTC())
     }
     if in.Priority != nil {
         t.Priority = *in.Priority
     }
-    if err := s.repo.Update(ctx, t); err != nil {
+    switch {
+    case in.ClearDueDate:
+        t.DueDate = nil
+    case in.DueDate != nil:
+        due := in.DueDate.UTC()
+        t.DueDate = &due
+    }
+    if err := s.repo.Update(ctx, t, in.fields()...); err != nil {
+        s.logFailure(ctx, "update", err)
         return nil, err
     }
+    s.scores.Invalidate(tenantID)
+    e := domaintask.TaskUpdated{TenantID: tenantID, Task: *t, OccurredAt: time.Now().UTC()}
+    s.events.Publish(ctx, e)
+    s.notifyWatchers(ctx, s.watchersOf(ctx, tenantID, id), id, e)
     return t, nil
 }
 
-func (s *Service) Delete(ctx context.Context, tenantID, id string) error {
-    return s.repo.Delete(ctx, tenantID, id)
+// validateUpdate checks the fields present in a partial update.
+func (s *Service) validateUpdate(in UpdateTaskInput) error {
+    if in.Title != nil {
+        if err := s.limits.ValidateTitle(*in.Title); err != nil {
+            return err
+        }
+    }
+    if in.Description != nil {
+        if err := s.limits.ValidateDescription(*in.Description); err != nil {
+            return err
+        }
+    }
+    if in.Priority != nil {
+        if err := domaintask.ValidatePriority(*in.Priority); err != nil {
+            return err
+        }
+    }
+    if in.Status != nil {
+        if err := domaintask.ValidateStatus(*in.Status); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// Delete removes the tenant's task, returning ErrForbidden when the policy
+// does not let actor delete it.
+func (s *Service) Delete(ctx context.Context, tenantID, id string, actor Actor) error {
+    t, err := s.repo.Get(ctx, tenantID, id)
+    if err != nil {
+        s.metrics.operationFailed(ctx, "delete", err)
+        return err
+    }
+    if err := s.authorize(actor, *t); err != nil {
+        s.metrics.operationFailed(ctx, "delete", err)
+        return err
+    }
+    // Watchers are looked up first since deleting the task may drop them.
+    watchers := s.watchersOf(ctx, tenantID, id)
+    if err := s.repo.Delete(ctx, tenantID, id); err != nil {
+        s.logFailure(ctx, "delete", err)
+        return err
+    }
+    if s.files != nil {
+        if err := s.files.DeletePrefix(ctx, tenantID+"/"+id+"/"); err != nil {
+            s.logFailure(ctx, "delete", err)
+            return err
+        }
+    }
+    s.metrics.taskDeleted(ctx, tenantID)
+    s.scores.Invalidate(tenantID)
+    e := domaintask.TaskDeleted{TenantID: tenantID, TaskID: id, OccurredAt: time.Now().UTC()}
+    s.events.Publish(ctx, e)
+    s.notifyWatchers(ctx, watchers, id, e)
+    return nil
+}
+
+
+// BulkAssign sets the assignee (nil to unassign) on every tenant task in ids
+// that actor may change and returns the ids updated and the ids skipped by
+// the policy. Ids that do not belong to the tenant are ignored. A
+// TaskAssigned event is published for each updated task.
+func (s *Service) BulkAssign(ctx context.Context, tenantID string, actor Actor, ids []string, assigneeID *string) (updated, skipped []string, err error) {
+    if len(ids) == 0 {
+        return nil, nil, errors.New("ids are required")
+    }
+    allowed := make([]string, 0, len(ids))
+    for _, id := range ids {
+        t, err := s.repo.Get(ctx, tenantID, id)
+        switch {
+        case errors.Is(err, ErrNotFound):
+            continue
+        case err != nil:
+            s.logFailure(ctx, "bulk assign", err)
+            return nil, nil, err
+        case !s.policy.CanModify(actor, *t):
+            skipped = append(skipped, id)
+        default:
+            allowed = append(allowed, id)
+        }
+    }
+    if len(allowed) == 0 {
+        return []string{}, skipped, nil
+    }
+    updated, err = s.repo.BulkAssign(ctx, tenantID, allowed, assigneeID)
+    if err != nil {
+        s.logFailure(ctx, "bulk assign", err)
+        return nil, nil, err
+    }
+    now := time.Now().UTC()
+    for _, id := range updated {
+        e := domaintask.TaskAssigned{TenantID: tenantID, TaskID: id, AssigneeID: assigneeID, OccurredAt: now}
+        s.events.Publish(ctx, e)
+        s.notifyWatchers(ctx, s.watchersOf(ctx, tenantID, id), id, e)
+    }
+    return updated, skipped, nil
 }
 
+// UpdateAIScores persists the scores from a prioritization run onto the
+// tenant's tasks. Tasks deleted in the meantime are skipped.
+func (s *Service) UpdateAIScores(ctx context.Context, tenantID string, scores map[string]float64) error {
+    if len(scores) == 0 {
+        return nil
+    }
+    if err := s.repo.UpdateAIScores(ctx, tenantID, scores); err != nil {
+        s.logFailure(ctx, "update ai scores", err)
+        return err
+    }
+    return nil
+}
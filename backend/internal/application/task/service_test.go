@@ -0,0 +1,498 @@
+package task_test
+
+import (
+    "context"
+    "errors"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+
+    apptask "backend/internal/application/task"
+    domaintask "backend/internal/domain/task"
+    "backend/internal/infrastructure/blob"
+    "backend/internal/infrastructure/memory"
+)
+
+// owner is the actor the tests change tasks as: u1 creates them.
+var owner = apptask.Actor{UserID: "u1"}
+
+// Test that Create enforces the priority range at its boundaries.
+func TestService_Create_PriorityBounds(t *testing.T) {
+    svc := apptask.NewService(memory.NewTaskRepository())
+    cases := []struct {
+        priority int
+        valid    bool
+    }{
+        {-1, false},
+        {1, true},
+        {5, true},
+        {10, true},
+        {11, false},
+    }
+    for _, tc := range cases {
+        _, err := svc.Create(context.Background(), "t1", "u1", "title", "", tc.priority)
+        if tc.valid && err != nil {
+            t.Fatalf("priority %d: unexpected error %v", tc.priority, err)
+        }
+        if !tc.valid && !errors.Is(err, domaintask.ErrInvalidPriority) {
+            t.Fatalf("priority %d: expected ErrInvalidPriority, got %v", tc.priority, err)
+        }
+    }
+}
+
+// Test that a zero priority defaults to DefaultPriority unless normalization
+// is disabled.
+func TestService_Create_NormalizeZero(t *testing.T) {
+    svc := apptask.NewService(memory.NewTaskRepository())
+    tk, err := svc.Create(context.Background(), "t1", "u1", "title", "", 0)
+    if err != nil {
+        t.Fatalf("create: %v", err)
+    }
+    if tk.Priority != domaintask.DefaultPriority {
+        t.Fatalf("expected priority %d, got %d", domaintask.DefaultPriority, tk.Priority)
+    }
+
+    strict := apptask.NewService(memory.NewTaskRepository(), apptask.WithNormalizeZero(false))
+    if _, err := strict.Create(context.Background(), "t1", "u1", "title", "", 0); !errors.Is(err, domaintask.ErrInvalidPriority) {
+        t.Fatalf("expected ErrInvalidPriority, got %v", err)
+    }
+}
+
+// Test that clearing a description to "" persists, and that fields absent
+// from the update keep their stored value.
+func TestService_Update_ClearsDescription(t *testing.T) {
+    ctx := context.Background()
+    svc := apptask.NewService(memory.NewTaskRepository())
+    tk, err := svc.Create(ctx, "t1", "u1", "title", "old", 3)
+    if err != nil {
+        t.Fatalf("create: %v", err)
+    }
+    empty := ""
+    if _, err := svc.Update(ctx, "t1", tk.ID, owner, apptask.UpdateTaskInput{Description: &empty}); err != nil {
+        t.Fatalf("update: %v", err)
+    }
+    got, err := svc.Get(ctx, "t1", tk.ID)
+    if err != nil {
+        t.Fatalf("get: %v", err)
+    }
+    if got.Description != "" {
+        t.Fatalf("expected empty description, got %q", got.Description)
+    }
+    if got.Title != "title" || got.Priority != 3 {
+        t.Fatalf("expected title and priority to be kept, got %q and %d", got.Title, got.Priority)
+    }
+}
+
+// Test that Update rejects an out-of-range priority and leaves the task as is.
+func TestService_Update_InvalidPriority(t *testing.T) {
+    svc := apptask.NewService(memory.NewTaskRepository())
+    tk, err := svc.Create(context.Background(), "t1", "u1", "title", "", 3)
+    if err != nil {
+        t.Fatalf("create: %v", err)
+    }
+    bad := 11
+    if _, err := svc.Update(context.Background(), "t1", tk.ID, owner, apptask.UpdateTaskInput{Priority: &bad}); !errors.Is(err, domaintask.ErrInvalidPriority) {
+        t.Fatalf("expected ErrInvalidPriority, got %v", err)
+    }
+    got, err := svc.Get(context.Background(), "t1", tk.ID)
+    if err != nil {
+        t.Fatalf("get: %v", err)
+    }
+    if got.Priority != 3 {
+        t.Fatalf("expected priority to stay 3, got %d", got.Priority)
+    }
+}
+
+// Test that Create and Update enforce the title and description limits.
+func TestService_LengthLimits(t *testing.T) {
+    svc := apptask.NewService(memory.NewTaskRepository())
+    ctx := context.Background()
+
+    if _, err := svc.Create(ctx, "t1", "u1", "", "", 5); !errors.Is(err, domaintask.ErrRequired) {
+        t.Fatalf("empty title: expected ErrRequired, got %v", err)
+    }
+    long := strings.Repeat("ü", domaintask.MaxTitleLength+1)
+    if _, err := svc.Create(ctx, "t1", "u1", long, "", 5); !errors.Is(err, domaintask.ErrTooLong) {
+        t.Fatalf("long title: expected ErrTooLong, got %v", err)
+    }
+
+    tk, err := svc.Create(ctx, "t1", "u1", strings.Repeat("ü", domaintask.MaxTitleLength), "", 5)
+    if err != nil {
+        t.Fatalf("title at limit: %v", err)
+    }
+    desc := strings.Repeat("ü", domaintask.MaxDescriptionLength+1)
+    if _, err := svc.Update(ctx, "t1", tk.ID, owner, apptask.UpdateTaskInput{Description: &desc}); !errors.Is(err, domaintask.ErrTooLong) {
+        t.Fatalf("long description: expected ErrTooLong, got %v", err)
+    }
+}
+
+// recordingPublisher collects published events for assertions.
+type recordingPublisher struct {
+    events []domaintask.Event
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, e domaintask.Event) {
+    p.events = append(p.events, e)
+}
+
+// Test that create, update and delete each publish their event with the
+// task's tenant.
+func TestService_LifecycleEvents(t *testing.T) {
+    ctx := context.Background()
+    pub := &recordingPublisher{}
+    svc := apptask.NewService(memory.NewTaskRepository(), apptask.WithEventPublisher(pub))
+
+    tk, _ := svc.Create(ctx, "t1", "u1", "a", "", 5)
+    title := "b"
+    if _, err := svc.Update(ctx, "t1", tk.ID, owner, apptask.UpdateTaskInput{Title: &title}); err != nil {
+        t.Fatalf("update: %v", err)
+    }
+    if err := svc.Delete(ctx, "t1", tk.ID, owner); err != nil {
+        t.Fatalf("delete: %v", err)
+    }
+
+    var names []string
+    for _, e := range pub.events {
+        if e.EventTenantID() != "t1" {
+            t.Fatalf("expected tenant t1, got %+v", e)
+        }
+        names = append(names, e.EventName())
+    }
+    if strings.Join(names, ",") != "task.created,task.updated,task.deleted" {
+        t.Fatalf("unexpected events %v", names)
+    }
+    if ev := pub.events[1].(domaintask.TaskUpdated); ev.Task.Title != "b" {
+        t.Fatalf("expected updated title in event, got %q", ev.Task.Title)
+    }
+}
+
+// recordingCache counts invalidations per tenant.
+type recordingCache map[string]int
+
+func (c recordingCache) Invalidate(tenantID string) { c[tenantID]++ }
+
+// Test that create, update and delete each invalidate the tenant's cached
+// scores, and failed operations do not.
+func TestService_InvalidatesScoreCache(t *testing.T) {
+    ctx := context.Background()
+    cache := recordingCache{}
+    svc := apptask.NewService(memory.NewTaskRepository(), apptask.WithScoreCache(cache))
+
+    tk, _ := svc.Create(ctx, "t1", "u1", "a", "", 5)
+    title := "b"
+    if _, err := svc.Update(ctx, "t1", tk.ID, owner, apptask.UpdateTaskInput{Title: &title}); err != nil {
+        t.Fatalf("update: %v", err)
+    }
+    if err := svc.Delete(ctx, "t1", tk.ID, owner); err != nil {
+        t.Fatalf("delete: %v", err)
+    }
+    if _, err := svc.Update(ctx, "t1", tk.ID, owner, apptask.UpdateTaskInput{Title: &title}); err == nil {
+        t.Fatalf("expected updating a deleted task to fail")
+    }
+    if cache["t1"] != 3 || len(cache) != 1 {
+        t.Fatalf("expected 3 invalidations of t1, got %v", cache)
+    }
+}
+
+// Test that BulkAssign updates only the caller's tenant tasks, emits one
+// event per updated task, and clears the assignee when given nil.
+func TestService_BulkAssign(t *testing.T) {
+    ctx := context.Background()
+    pub := &recordingPublisher{}
+    svc := apptask.NewService(memory.NewTaskRepository(), apptask.WithEventPublisher(pub))
+
+    a, _ := svc.Create(ctx, "t1", "u1", "a", "", 5)
+    b, _ := svc.Create(ctx, "t1", "u1", "b", "", 5)
+    other, _ := svc.Create(ctx, "t2", "u9", "other", "", 5)
+    pub.events = nil
+
+    alex := "alex"
+    updated, _, err := svc.BulkAssign(ctx, "t1", owner, []string{a.ID, b.ID, other.ID, "missing"}, &alex)
+    if err != nil {
+        t.Fatalf("bulk assign: %v", err)
+    }
+    if len(updated) != 2 {
+        t.Fatalf("expected 2 updated, got %v", updated)
+    }
+    if len(pub.events) != 2 {
+        t.Fatalf("expected 2 events, got %d", len(pub.events))
+    }
+    for _, e := range pub.events {
+        ev, ok := e.(domaintask.TaskAssigned)
+        if !ok || ev.TenantID != "t1" || ev.AssigneeID == nil || *ev.AssigneeID != alex {
+            t.Fatalf("unexpected event %+v", e)
+        }
+    }
+    got, _ := svc.Get(ctx, "t1", a.ID)
+    if got.AssigneeID == nil || *got.AssigneeID != alex {
+        t.Fatalf("expected assignee %q, got %v", alex, got.AssigneeID)
+    }
+    untouched, _ := svc.Get(ctx, "t2", other.ID)
+    if untouched.AssigneeID != nil {
+        t.Fatalf("cross-tenant task was assigned")
+    }
+
+    if _, _, err := svc.BulkAssign(ctx, "t1", owner, []string{a.ID, b.ID}, nil); err != nil {
+        t.Fatalf("bulk unassign: %v", err)
+    }
+    for _, id := range []string{a.ID, b.ID} {
+        got, _ := svc.Get(ctx, "t1", id)
+        if got.AssigneeID != nil {
+            t.Fatalf("task %s still assigned to %q", id, *got.AssigneeID)
+        }
+    }
+}
+
+// Test that UpdateAIScores only touches the tenant's live tasks and that
+// ListFiltered orders by score with unscored tasks last.
+func TestService_UpdateAIScores(t *testing.T) {
+    ctx := context.Background()
+    repo := memory.NewTaskRepository()
+    svc := apptask.NewService(repo)
+    a, _ := svc.Create(ctx, "t1", "u1", "a", "", 5)
+    b, _ := svc.Create(ctx, "t1", "u1", "b", "", 5)
+    c, _ := svc.Create(ctx, "t1", "u1", "c", "", 5)
+    gone, _ := svc.Create(ctx, "t1", "u1", "gone", "", 5)
+    other, _ := svc.Create(ctx, "t2", "u1", "other", "", 5)
+    if err := svc.Delete(ctx, "t1", gone.ID, owner); err != nil {
+        t.Fatalf("delete: %v", err)
+    }
+
+    err := svc.UpdateAIScores(ctx, "t1", map[string]float64{a.ID: 10, b.ID: 80, gone.ID: 50, other.ID: 99})
+    if err != nil {
+        t.Fatalf("update scores: %v", err)
+    }
+    if got, _ := repo.Get(ctx, "t2", other.ID); got.AiScore != nil {
+        t.Fatalf("other tenant's task was scored: %v", *got.AiScore)
+    }
+
+    desc, _, err := svc.ListFiltered(ctx, "t1", apptask.FilterOptions{}, "-aiScore", apptask.ListOptions{})
+    if err != nil {
+        t.Fatalf("list: %v", err)
+    }
+    if len(desc) != 3 || desc[0].ID != b.ID || desc[1].ID != a.ID || desc[2].ID != c.ID {
+        t.Fatalf("expected b, a, c, got %+v", desc)
+    }
+    asc, _, _ := svc.ListFiltered(ctx, "t1", apptask.FilterOptions{}, "aiScore", apptask.ListOptions{})
+    if asc[0].ID != a.ID || asc[1].ID != b.ID || asc[2].ID != c.ID {
+        t.Fatalf("expected a, b, c, got %+v", asc)
+    }
+    if _, _, err := svc.ListFiltered(ctx, "t1", apptask.FilterOptions{}, "title", apptask.ListOptions{}); !errors.Is(err, apptask.ErrInvalidSort) {
+        t.Fatalf("expected ErrInvalidSort, got %v", err)
+    }
+}
+
+// Test that descriptions lose executable HTML on create and update while
+// basic formatting survives.
+func TestService_SanitizesDescription(t *testing.T) {
+    ctx := context.Background()
+    svc := apptask.NewService(memory.NewTaskRepository())
+
+    tk, err := svc.Create(ctx, "t1", "u1", "title", `<b>bold</b><img src=x onerror=alert(1)>`, 5)
+    if err != nil {
+        t.Fatalf("create: %v", err)
+    }
+    if tk.Description != "<b>bold</b>" {
+        t.Fatalf("expected only <b>bold</b> to remain, got %q", tk.Description)
+    }
+
+    evil := `<p onclick="steal()">hi</p><script>alert(1)</script>`
+    tk, err = svc.Update(ctx, "t1", tk.ID, owner, apptask.UpdateTaskInput{Description: &evil})
+    if err != nil {
+        t.Fatalf("update: %v", err)
+    }
+    if tk.Description != "<p>hi</p>" {
+        t.Fatalf("expected <p>hi</p>, got %q", tk.Description)
+    }
+}
+
+// Test that "&" and "<" in titles and descriptions are stored as typed,
+// not as HTML entities, while escaped markup is still stripped.
+func TestService_SanitizeKeepsPlainText(t *testing.T) {
+    ctx := context.Background()
+    svc := apptask.NewService(memory.NewTaskRepository())
+
+    tk, err := svc.Create(ctx, "t1", "u1", "Tom & Jerry < 3", "R&D: a < b && <b>c</b> &lt;script&gt;alert(1)&lt;/script&gt;", 5)
+    if err != nil {
+        t.Fatalf("create: %v", err)
+    }
+    if tk.Title != "Tom & Jerry < 3" {
+        t.Fatalf("expected the title as typed, got %q", tk.Title)
+    }
+    if tk.Description != "R&D: a < b && <b>c</b> " {
+        t.Fatalf("expected plain text kept and the script stripped, got %q", tk.Description)
+    }
+}
+
+// recordingNotifier collects queued notifications for assertions.
+type recordingNotifier struct {
+    sent []apptask.Notification
+}
+
+func (n *recordingNotifier) Enqueue(_ context.Context, note apptask.Notification) error {
+    n.sent = append(n.sent, note)
+    return nil
+}
+
+// Test that watching is idempotent, unwatching stops notifications, and each
+// mutation notifies every current watcher once.
+func TestService_Watchers(t *testing.T) {
+    ctx := context.Background()
+    notes := &recordingNotifier{}
+    svc := apptask.NewService(memory.NewTaskRepository(), apptask.WithNotifier(notes))
+    tk, _ := svc.Create(ctx, "t1", "u1", "a", "", 5)
+
+    for _, user := range []string{"u2", "u3", "u2"} {
+        if err := svc.WatchTask(ctx, "t1", tk.ID, user); err != nil {
+            t.Fatalf("watch: %v", err)
+        }
+    }
+    if err := svc.WatchTask(ctx, "t2", tk.ID, "u2"); err == nil {
+        t.Fatalf("expected watching another tenant's task to fail")
+    }
+    watchers, _ := svc.ListWatchers(ctx, "t1", tk.ID)
+    if len(watchers) != 2 || watchers[0].UserID != "u2" || watchers[1].UserID != "u3" {
+        t.Fatalf("expected watchers u2 and u3, got %+v", watchers)
+    }
+
+    title := "b"
+    svc.Update(ctx, "t1", tk.ID, owner, apptask.UpdateTaskInput{Title: &title})
+    if len(notes.sent) != 2 || notes.sent[0].Event != "task.updated" || notes.sent[0].TaskID != tk.ID {
+        t.Fatalf("expected two task.updated notifications, got %+v", notes.sent)
+    }
+
+    if err := svc.UnwatchTask(ctx, "t1", tk.ID, "u3"); err != nil {
+        t.Fatalf("unwatch: %v", err)
+    }
+    notes.sent = nil
+    if err := svc.Delete(ctx, "t1", tk.ID, owner); err != nil {
+        t.Fatalf("delete: %v", err)
+    }
+    if len(notes.sent) != 1 || notes.sent[0].UserID != "u2" || notes.sent[0].Event != "task.deleted" {
+        t.Fatalf("expected one task.deleted notification for u2, got %+v", notes.sent)
+    }
+}
+
+// Test that configured length limits replace the defaults on create and
+// update: exactly at the limit is accepted, one rune over is rejected.
+func TestService_ConfiguredLengthLimits(t *testing.T) {
+    ctx := context.Background()
+    svc := apptask.NewService(memory.NewTaskRepository(), apptask.WithLengthLimits(10, 20))
+
+    tk, err := svc.Create(ctx, "t1", "u1", strings.Repeat("ü", 10), strings.Repeat("d", 20), 5)
+    if err != nil {
+        t.Fatalf("at limit: %v", err)
+    }
+    if _, err := svc.Create(ctx, "t1", "u1", strings.Repeat("ü", 11), "", 5); !errors.Is(err, domaintask.ErrTooLong) {
+        t.Fatalf("title over limit: expected ErrTooLong, got %v", err)
+    }
+    desc := strings.Repeat("d", 21)
+    if _, err := svc.Update(ctx, "t1", tk.ID, owner, apptask.UpdateTaskInput{Description: &desc}); !errors.Is(err, domaintask.ErrTooLong) {
+        t.Fatalf("description over limit: expected ErrTooLong, got %v", err)
+    }
+}
+
+// Test that only a task's creator, its assignee or an admin can change or
+// delete it, and that BulkAssign skips the tasks the actor may not change.
+func TestService_OwnerPolicy(t *testing.T) {
+    ctx := context.Background()
+    svc := apptask.NewService(memory.NewTaskRepository())
+    tk, _ := svc.Create(ctx, "t1", "u1", "task", "", 5)
+    bob := "bob"
+    if _, _, err := svc.BulkAssign(ctx, "t1", owner, []string{tk.ID}, &bob); err != nil {
+        t.Fatalf("assign: %v", err)
+    }
+    mine, _ := svc.Create(ctx, "t1", "u2", "mine", "", 5)
+
+    title := "renamed"
+    cases := []struct {
+        actor   apptask.Actor
+        allowed bool
+    }{
+        {apptask.Actor{UserID: "u1"}, true},
+        {apptask.Actor{UserID: "bob"}, true},
+        {apptask.Actor{UserID: "u2", Admin: true}, true},
+        {apptask.Actor{UserID: "u2"}, false},
+        {apptask.Actor{}, false},
+    }
+    for _, tc := range cases {
+        _, err := svc.Update(ctx, "t1", tk.ID, tc.actor, apptask.UpdateTaskInput{Title: &title})
+        if tc.allowed && err != nil {
+            t.Fatalf("%+v: unexpected error %v", tc.actor, err)
+        }
+        if !tc.allowed && !errors.Is(err, apptask.ErrForbidden) {
+            t.Fatalf("%+v: expected ErrForbidden, got %v", tc.actor, err)
+        }
+    }
+
+    u2 := apptask.Actor{UserID: "u2"}
+    updated, skipped, err := svc.BulkAssign(ctx, "t1", u2, []string{tk.ID, mine.ID, "missing"}, nil)
+    if err != nil {
+        t.Fatalf("bulk assign: %v", err)
+    }
+    if len(updated) != 1 || updated[0] != mine.ID {
+        t.Fatalf("expected only %s updated, got %v", mine.ID, updated)
+    }
+    if len(skipped) != 1 || skipped[0] != tk.ID {
+        t.Fatalf("expected %s skipped, got %v", tk.ID, skipped)
+    }
+
+    if err := svc.Delete(ctx, "t1", tk.ID, u2); !errors.Is(err, apptask.ErrForbidden) {
+        t.Fatalf("expected ErrForbidden, got %v", err)
+    }
+    if _, err := svc.Get(ctx, "t1", tk.ID); err != nil {
+        t.Fatalf("expected task to survive a forbidden delete, got %v", err)
+    }
+    if err := svc.Delete(ctx, "t1", tk.ID, apptask.Actor{UserID: "bob"}); err != nil {
+        t.Fatalf("delete as assignee: %v", err)
+    }
+}
+
+// policyFunc adapts a function to apptask.Policy.
+type policyFunc func(actor apptask.Actor, t domaintask.Task) bool
+
+func (f policyFunc) CanModify(actor apptask.Actor, t domaintask.Task) bool {
+    return f(actor, t)
+}
+
+// Test that WithPolicy replaces the default policy.
+func TestService_WithPolicy(t *testing.T) {
+    ctx := context.Background()
+    adminsOnly := policyFunc(func(actor apptask.Actor, _ domaintask.Task) bool { return actor.Admin })
+    svc := apptask.NewService(memory.NewTaskRepository(), apptask.WithPolicy(adminsOnly))
+    tk, _ := svc.Create(ctx, "t1", "u1", "task", "", 5)
+
+    if err := svc.Delete(ctx, "t1", tk.ID, owner); !errors.Is(err, apptask.ErrForbidden) {
+        t.Fatalf("expected ErrForbidden for the creator, got %v", err)
+    }
+    if err := svc.Delete(ctx, "t1", tk.ID, apptask.Actor{UserID: "u9", Admin: true}); err != nil {
+        t.Fatalf("delete as admin: %v", err)
+    }
+}
+
+// Test that deleting a task removes its uploaded files and leaves those of
+// other tasks.
+func TestService_Delete_RemovesFiles(t *testing.T) {
+    ctx := context.Background()
+    dir := t.TempDir()
+    store := blob.NewLocalStore(dir, "/uploads")
+    svc := apptask.NewService(memory.NewTaskRepository(), apptask.WithFileStore(store))
+    doomed, _ := svc.Create(ctx, "t1", "u1", "doomed", "", 5)
+    kept, _ := svc.Create(ctx, "t1", "u1", "kept", "", 5)
+    for _, tk := range []*domaintask.Task{doomed, kept} {
+        if _, err := store.Put(ctx, "t1/"+tk.ID+"/spec.txt", "text/plain", strings.NewReader("spec")); err != nil {
+            t.Fatalf("put: %v", err)
+        }
+    }
+
+    if err := svc.Delete(ctx, "t1", doomed.ID, owner); err != nil {
+        t.Fatalf("delete: %v", err)
+    }
+    if _, err := os.Stat(filepath.Join(dir, "t1", doomed.ID)); !errors.Is(err, os.ErrNotExist) {
+        t.Fatalf("expected the deleted task's files to be gone, got %v", err)
+    }
+    if _, err := os.Stat(filepath.Join(dir, "t1", kept.ID, "spec.txt")); err != nil {
+        t.Fatalf("expected the other task's file to stay, got %v", err)
+    }
+}
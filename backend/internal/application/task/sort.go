@@ -0,0 +1,102 @@
+package task
+
+import (
+    "cmp"
+    "errors"
+    "strings"
+    "time"
+
+    domaintask "backend/internal/domain/task"
+)
+
+// ErrInvalidSort is returned for a sort key the list endpoint doesn't support.
+var ErrInvalidSort = errors.New("invalid sort")
+
+// Sort keys accepted by ParseSort and SortOptions.Field.
+const (
+    SortAIScore   = "aiScore"
+    SortDueDate   = "dueDate"
+    SortPriority  = "priority"
+    SortCreatedAt = "createdAt"
+)
+
+// SortOptions orders a task listing. Tasks without a value for Field always
+// come last; ties fall back to creation time and then ID. The zero value
+// orders by creation time and ID alone, so pages stay stable between
+// requests.
+type SortOptions struct {
+    Field string
+    Desc  bool
+}
+
+// ParseSort reads a sort key such as "-aiScore", where a leading "-" means
+// descending. An empty key is the zero SortOptions.
+func ParseSort(key string) (SortOptions, error) {
+    s := SortOptions{Field: strings.TrimPrefix(key, "-"), Desc: strings.HasPrefix(key, "-")}
+    switch s.Field {
+    case "":
+        return SortOptions{}, nil
+    case SortAIScore, SortDueDate, SortPriority, SortCreatedAt:
+        return s, nil
+    }
+    return SortOptions{}, ErrInvalidSort
+}
+
+// Compare orders a before b (negative), after b (positive) or, only for the
+// same task, alongside it (zero), for use with slices.SortFunc. Repositories
+// that sort in memory use it to match the ordering of the database.
+func (s SortOptions) Compare(a, b domaintask.Task) int {
+    var c int
+    switch s.Field {
+    case SortAIScore:
+        c = compareNullable(a.AiScore, b.AiScore, s.Desc)
+    case SortDueDate:
+        c = compareNullable(unixNano(a.DueDate), unixNano(b.DueDate), s.Desc)
+    case SortPriority:
+        c = directed(cmp.Compare(a.Priority, b.Priority), s.Desc)
+    case SortCreatedAt:
+        if s.Desc {
+            return compareCreated(b, a)
+        }
+    }
+    if c != 0 {
+        return c
+    }
+    return compareCreated(a, b)
+}
+
+// compareNullable compares a and b in the given direction; nil values come
+// last whatever the direction.
+func compareNullable[T cmp.Ordered](a, b *T, desc bool) int {
+    switch {
+    case a == nil && b == nil:
+        return 0
+    case a == nil:
+        return 1
+    case b == nil:
+        return -1
+    }
+    return directed(cmp.Compare(*a, *b), desc)
+}
+
+func directed(c int, desc bool) int {
+    if desc {
+        return -c
+    }
+    return c
+}
+
+func unixNano(t *time.Time) *int64 {
+    if t == nil {
+        return nil
+    }
+    n := t.UnixNano()
+    return &n
+}
+
+func compareCreated(a, b domaintask.Task) int {
+    if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+        return c
+    }
+    return strings.Compare(a.ID, b.ID)
+}
@@ -0,0 +1,81 @@
+package task
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "strings"
+
+    domaintask "backend/internal/domain/task"
+)
+
+// MaxGeneratedSubtasks caps how many generated steps are returned and
+// created for one task.
+const MaxGeneratedSubtasks = 8
+
+var (
+    // ErrSubtaskGeneratorUnavailable is returned by GenerateSubtasks when the
+    // service has no SubtaskGenerator.
+    ErrSubtaskGeneratorUnavailable = errors.New("subtask generator is not configured")
+    // ErrSubtaskGeneratorTimeout is returned when the generator does not
+    // answer in time.
+    ErrSubtaskGeneratorTimeout = errors.New("subtask generator timed out")
+    // ErrSubtaskGeneratorFailed wraps any other generator failure, such as
+    // an unusable answer.
+    ErrSubtaskGeneratorFailed = errors.New("subtask generator failed")
+)
+
+// GenerateSubtasks asks the generator to break t into steps. Blank steps,
+// repeats and steps matching the title of an existing subtask of t are
+// dropped, and at most MaxGeneratedSubtasks are kept. With apply set, each
+// step is created through CreateTask as a subtask of t owned by userID, with
+// t's priority; creation stops at the first step that fails validation.
+func (s *Service) GenerateSubtasks(ctx context.Context, userID string, t *domaintask.Task, apply bool) (steps []string, created []*domaintask.Task, err error) {
+    if s.subtasks == nil {
+        return nil, nil, ErrSubtaskGeneratorUnavailable
+    }
+    generated, err := s.subtasks.GenerateSubtasks(ctx, t.Title, t.Description)
+    if errors.Is(err, context.DeadlineExceeded) {
+        s.logFailure(ctx, "generate_subtasks", err)
+        return nil, nil, ErrSubtaskGeneratorTimeout
+    }
+    if err != nil {
+        s.logFailure(ctx, "generate_subtasks", err)
+        return nil, nil, fmt.Errorf("%w: %v", ErrSubtaskGeneratorFailed, err)
+    }
+
+    existing, _, err := s.repo.List(ctx, t.TenantID, FilterOptions{ParentID: &t.ID}, SortOptions{}, ListOptions{})
+    if err != nil {
+        return nil, nil, err
+    }
+    seen := make(map[string]bool)
+    for _, sub := range existing {
+        seen[strings.ToLower(strings.TrimSpace(sub.Title))] = true
+    }
+    steps = []string{}
+    for _, step := range generated {
+        step = strings.Join(strings.Fields(step), " ")
+        key := strings.ToLower(step)
+        if step == "" || seen[key] {
+            continue
+        }
+        seen[key] = true
+        steps = append(steps, step)
+        if len(steps) == MaxGeneratedSubtasks {
+            break
+        }
+    }
+    if !apply {
+        return steps, nil, nil
+    }
+
+    created = make([]*domaintask.Task, 0, len(steps))
+    for _, step := range steps {
+        sub, err := s.CreateTask(ctx, t.TenantID, userID, CreateTaskInput{Title: step, Priority: t.Priority, ParentID: &t.ID})
+        if err != nil {
+            return steps, created, err
+        }
+        created = append(created, sub)
+    }
+    return steps, created, nil
+}
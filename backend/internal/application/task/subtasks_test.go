@@ -0,0 +1,96 @@
+package task_test
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "testing"
+
+    apptask "backend/internal/application/task"
+    "backend/internal/infrastructure/memory"
+)
+
+// fakeGenerator returns canned steps or an error.
+type fakeGenerator struct {
+    steps []string
+    err   error
+}
+
+func (f fakeGenerator) GenerateSubtasks(context.Context, string, string) ([]string, error) {
+    return f.steps, f.err
+}
+
+// Test that generated steps are cleaned, deduplicated against each other and
+// existing subtasks, capped, and only created as subtasks on apply.
+func TestService_GenerateSubtasks(t *testing.T) {
+    ctx := context.Background()
+    repo := memory.NewTaskRepository()
+    steps := []string{"  Write  tests ", "", "write tests", "Existing step"}
+    for i := 0; i < 10; i++ {
+        steps = append(steps, fmt.Sprintf("step %d", i))
+    }
+    svc := apptask.NewService(repo, apptask.WithSubtaskGenerator(fakeGenerator{steps: steps}))
+    parent, err := svc.Create(ctx, "t1", "u1", "ship release", "", 7)
+    if err != nil {
+        t.Fatalf("create: %v", err)
+    }
+    if _, err := svc.CreateTask(ctx, "t1", "u1", apptask.CreateTaskInput{Title: "existing step", ParentID: &parent.ID}); err != nil {
+        t.Fatalf("create subtask: %v", err)
+    }
+
+    preview, created, err := svc.GenerateSubtasks(ctx, "u2", parent, false)
+    if err != nil {
+        t.Fatalf("preview: %v", err)
+    }
+    if len(preview) != apptask.MaxGeneratedSubtasks || preview[0] != "Write tests" || preview[1] != "step 0" || created != nil {
+        t.Fatalf("unexpected preview %q, created %v", preview, created)
+    }
+    if all, _ := repo.ListByTenant(ctx, "t1"); len(all) != 2 {
+        t.Fatalf("expected preview to create nothing, got %d tasks", len(all))
+    }
+
+    _, created, err = svc.GenerateSubtasks(ctx, "u2", parent, true)
+    if err != nil {
+        t.Fatalf("apply: %v", err)
+    }
+    if len(created) != apptask.MaxGeneratedSubtasks {
+        t.Fatalf("expected %d subtasks, got %d", apptask.MaxGeneratedSubtasks, len(created))
+    }
+    for _, sub := range created {
+        if sub.ParentID == nil || *sub.ParentID != parent.ID || sub.Priority != 7 || sub.UserID != "u2" {
+            t.Fatalf("unexpected subtask %+v", sub)
+        }
+    }
+
+    again, _, err := svc.GenerateSubtasks(ctx, "u2", parent, false)
+    if err != nil {
+        t.Fatalf("preview: %v", err)
+    }
+    if len(again) != 3 || again[0] != "step 7" {
+        t.Fatalf("expected only the steps not yet created, got %q", again)
+    }
+}
+
+// Test that generation fails with the dedicated errors without a generator
+// or when it fails, and that subtasks need a parent of the same tenant.
+func TestService_GenerateSubtasks_Errors(t *testing.T) {
+    ctx := context.Background()
+    repo := memory.NewTaskRepository()
+    parent, err := apptask.NewService(repo).Create(ctx, "t1", "u1", "ship", "", 5)
+    if err != nil {
+        t.Fatalf("create: %v", err)
+    }
+
+    if _, _, err := apptask.NewService(repo).GenerateSubtasks(ctx, "u1", parent, true); !errors.Is(err, apptask.ErrSubtaskGeneratorUnavailable) {
+        t.Fatalf("expected ErrSubtaskGeneratorUnavailable, got %v", err)
+    }
+    broken := apptask.NewService(repo, apptask.WithSubtaskGenerator(fakeGenerator{err: errors.New("bad json")}))
+    if _, _, err := broken.GenerateSubtasks(ctx, "u1", parent, true); !errors.Is(err, apptask.ErrSubtaskGeneratorFailed) {
+        t.Fatalf("expected ErrSubtaskGeneratorFailed, got %v", err)
+    }
+
+    _, err = apptask.NewService(repo).CreateTask(ctx, "t2", "u1", apptask.CreateTaskInput{Title: "sub", ParentID: &parent.ID})
+    if !errors.Is(err, apptask.ErrParentNotFound) {
+        t.Fatalf("expected ErrParentNotFound, got %v", err)
+    }
+}
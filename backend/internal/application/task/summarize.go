@@ -0,0 +1,64 @@
+package task
+
+import (
+    "context"
+    "errors"
+    "strings"
+    "time"
+    "unicode/utf8"
+
+    domaintask "backend/internal/domain/task"
+)
+
+// MaxSummaryLen is the longest summary kept, in characters; longer answers
+// from the summarizer are cut to fit.
+const MaxSummaryLen = 280
+
+var (
+    // ErrSummarizerUnavailable is returned by Summarize when the service has
+    // no Summarizer.
+    ErrSummarizerUnavailable = errors.New("summarizer is not configured")
+    // ErrSummarizeTimeout is returned when the summarizer does not answer in
+    // time.
+    ErrSummarizeTimeout = errors.New("summarizer timed out")
+)
+
+// Summarize asks the summarizer for a summary of t's title and description
+// and, when persist is set, stores it as t.Summary. The task is left
+// untouched when summarizing fails.
+func (s *Service) Summarize(ctx context.Context, t *domaintask.Task, persist bool) (string, error) {
+    if s.summarizer == nil {
+        return "", ErrSummarizerUnavailable
+    }
+    summary, err := s.summarizer.Summarize(ctx, t.Title, t.Description)
+    if errors.Is(err, context.DeadlineExceeded) {
+        s.logFailure(ctx, "summarize", err)
+        return "", ErrSummarizeTimeout
+    }
+    if err != nil {
+        s.logFailure(ctx, "summarize", err)
+        return "", err
+    }
+    summary = truncateSummary(summary)
+    if !persist {
+        return summary, nil
+    }
+    t.Summary = summary
+    if err := s.repo.Update(ctx, t, FieldSummary); err != nil {
+        s.logFailure(ctx, "summarize", err)
+        return "", err
+    }
+    s.events.Publish(ctx, domaintask.TaskUpdated{TenantID: t.TenantID, Task: *t, OccurredAt: time.Now().UTC()})
+    return summary, nil
+}
+
+// truncateSummary collapses whitespace and cuts s to MaxSummaryLen
+// characters.
+func truncateSummary(s string) string {
+    s = strings.Join(strings.Fields(s), " ")
+    if utf8.RuneCountInString(s) <= MaxSummaryLen {
+        return s
+    }
+    runes := []rune(s)
+    return strings.TrimSpace(string(runes[:MaxSummaryLen-1])) + "…"
+}
@@ -0,0 +1,74 @@
+package task_test
+
+import (
+    "context"
+    "errors"
+    "strings"
+    "testing"
+    "unicode/utf8"
+
+    apptask "backend/internal/application/task"
+    "backend/internal/infrastructure/memory"
+)
+
+// fakeSummarizer returns a canned summary or error.
+type fakeSummarizer struct {
+    summary string
+    err     error
+}
+
+func (f fakeSummarizer) Summarize(context.Context, string, string) (string, error) {
+    return f.summary, f.err
+}
+
+// Test that a summary is cut to MaxSummaryLen and stored only when persist
+// is set.
+func TestService_Summarize(t *testing.T) {
+    ctx := context.Background()
+    repo := memory.NewTaskRepository()
+    svc := apptask.NewService(repo, apptask.WithSummarizer(fakeSummarizer{summary: strings.Repeat("word ", 100)}))
+    tk, err := svc.Create(ctx, "t1", "u1", "title", "long description", 5)
+    if err != nil {
+        t.Fatalf("create: %v", err)
+    }
+
+    summary, err := svc.Summarize(ctx, tk, false)
+    if err != nil {
+        t.Fatalf("summarize: %v", err)
+    }
+    if n := utf8.RuneCountInString(summary); n != apptask.MaxSummaryLen {
+        t.Fatalf("expected %d characters, got %d", apptask.MaxSummaryLen, n)
+    }
+    if got, _ := repo.Get(ctx, "t1", tk.ID); got.Summary != "" {
+        t.Fatalf("expected summary not stored, got %q", got.Summary)
+    }
+
+    if _, err := svc.Summarize(ctx, tk, true); err != nil {
+        t.Fatalf("summarize: %v", err)
+    }
+    if got, _ := repo.Get(ctx, "t1", tk.ID); got.Summary != summary || got.Description != "long description" {
+        t.Fatalf("expected summary stored beside the description, got %+v", got)
+    }
+}
+
+// Test that summarizing without a summarizer or past the deadline fails
+// with the dedicated errors and leaves the task untouched.
+func TestService_Summarize_Errors(t *testing.T) {
+    ctx := context.Background()
+    repo := memory.NewTaskRepository()
+    tk, err := apptask.NewService(repo).Create(ctx, "t1", "u1", "title", "", 5)
+    if err != nil {
+        t.Fatalf("create: %v", err)
+    }
+
+    if _, err := apptask.NewService(repo).Summarize(ctx, tk, true); !errors.Is(err, apptask.ErrSummarizerUnavailable) {
+        t.Fatalf("expected ErrSummarizerUnavailable, got %v", err)
+    }
+    slow := apptask.NewService(repo, apptask.WithSummarizer(fakeSummarizer{err: context.DeadlineExceeded}))
+    if _, err := slow.Summarize(ctx, tk, true); !errors.Is(err, apptask.ErrSummarizeTimeout) {
+        t.Fatalf("expected ErrSummarizeTimeout, got %v", err)
+    }
+    if got, _ := repo.Get(ctx, "t1", tk.ID); got.Summary != "" || !got.UpdatedAt.Equal(tk.UpdatedAt) {
+        t.Fatalf("expected task untouched, got %+v", got)
+    }
+}
@@ -0,0 +1,69 @@
+package task
+
+import (
+    "context"
+    "time"
+
+    domaintask "backend/internal/domain/task"
+)
+
+// WatchTask subscribes userID to changes of the tenant's task. Watching twice
+// is not an error.
+func (s *Service) WatchTask(ctx context.Context, tenantID, taskID, userID string) error {
+    if _, err := s.repo.Get(ctx, tenantID, taskID); err != nil {
+        return err
+    }
+    if err := s.repo.Watch(ctx, domaintask.NewWatcher(tenantID, taskID, userID)); err != nil {
+        s.logFailure(ctx, "watch", err)
+        return err
+    }
+    return nil
+}
+
+// UnwatchTask removes userID's subscription to the task, if any.
+func (s *Service) UnwatchTask(ctx context.Context, tenantID, taskID, userID string) error {
+    if _, err := s.repo.Get(ctx, tenantID, taskID); err != nil {
+        return err
+    }
+    if err := s.repo.Unwatch(ctx, tenantID, taskID, userID); err != nil {
+        s.logFailure(ctx, "unwatch", err)
+        return err
+    }
+    return nil
+}
+
+// ListWatchers returns the users watching the tenant's task, oldest first.
+func (s *Service) ListWatchers(ctx context.Context, tenantID, taskID string) ([]domaintask.TaskWatcher, error) {
+    if _, err := s.repo.Get(ctx, tenantID, taskID); err != nil {
+        return nil, err
+    }
+    watchers, err := s.repo.ListWatchers(ctx, tenantID, taskID)
+    if err != nil {
+        s.logFailure(ctx, "list watchers", err)
+        return nil, err
+    }
+    return watchers, nil
+}
+
+// watchersOf returns the task's watchers for notification. A lookup failure
+// is logged and yields none: notifications never fail the mutation.
+func (s *Service) watchersOf(ctx context.Context, tenantID, taskID string) []domaintask.TaskWatcher {
+    watchers, err := s.repo.ListWatchers(ctx, tenantID, taskID)
+    if err != nil {
+        s.logFailure(ctx, "list watchers", err)
+        return nil
+    }
+    return watchers
+}
+
+// notifyWatchers queues one notification of e per watcher. Enqueue failures
+// are logged and otherwise ignored.
+func (s *Service) notifyWatchers(ctx context.Context, watchers []domaintask.TaskWatcher, taskID string, e domaintask.Event) {
+    now := time.Now().UTC()
+    for _, w := range watchers {
+        n := Notification{TenantID: w.TenantID, TaskID: taskID, UserID: w.UserID, Event: e.EventName(), OccurredAt: now}
+        if err := s.notifier.Enqueue(ctx, n); err != nil {
+            s.logFailure(ctx, "notify watcher", err)
+        }
+    }
+}
@@ -0,0 +1,31 @@
+package template
+
+import (
+    "context"
+    "errors"
+
+    apptask "backend/internal/application/task"
+    domaintask "backend/internal/domain/task"
+)
+
+// ErrNotFound is returned when a template does not exist for the tenant.
+var ErrNotFound = errors.New("task template not found")
+
+// Repository defines persistence operations for task templates.
+type Repository interface {
+    Create(ctx context.Context, t *domaintask.TaskTemplate) error
+    // ListByTenant returns the tenant's templates ordered by name.
+    ListByTenant(ctx context.Context, tenantID string) ([]domaintask.TaskTemplate, error)
+    // Get returns the tenant's template, or ErrNotFound.
+    Get(ctx context.Context, tenantID, id string) (*domaintask.TaskTemplate, error)
+    // Update replaces the fields of an existing template, or returns
+    // ErrNotFound.
+    Update(ctx context.Context, t *domaintask.TaskTemplate) error
+    // Delete removes the tenant's template, or returns ErrNotFound.
+    Delete(ctx context.Context, tenantID, id string) error
+}
+
+// TaskCreator creates the tasks templates are instantiated into.
+type TaskCreator interface {
+    CreateTask(ctx context.Context, tenantID, userID string, in apptask.CreateTaskInput) (*domaintask.Task, error)
+}
@@ -0,0 +1,160 @@
+package template
+
+import (
+    "context"
+    "errors"
+    "strings"
+    "time"
+
+    apptask "backend/internal/application/task"
+    domaintask "backend/internal/domain/task"
+)
+
+// Service implements task template use cases.
+type Service struct {
+    repo  Repository
+    tasks TaskCreator
+    now   func() time.Time
+}
+
+func NewService(repo Repository, tasks TaskCreator) *Service {
+    return &Service{repo: repo, tasks: tasks, now: func() time.Time { return time.Now().UTC() }}
+}
+
+// Input holds the fields of a template being created or replaced.
+type Input struct {
+    Name         string
+    TitlePattern string
+    Description  string
+    Priority     int
+    Tags         []string
+}
+
+// Overrides replace template fields for one instantiation. Nil fields keep
+// the template's value; Tags replace the template's tags when non-nil.
+type Overrides struct {
+    Title       *string
+    Description *string
+    Priority    *int
+    DueDate     *time.Time
+    AssigneeID  *string
+    Tags        []string
+}
+
+func (s *Service) Create(ctx context.Context, tenantID string, in Input) (*domaintask.TaskTemplate, error) {
+    in, err := validate(in)
+    if err != nil {
+        return nil, err
+    }
+    t := domaintask.NewTemplate(tenantID, in.Name, in.TitlePattern, in.Description, in.Priority, in.Tags)
+    if err := s.repo.Create(ctx, t); err != nil {
+        return nil, err
+    }
+    return t, nil
+}
+
+func (s *Service) List(ctx context.Context, tenantID string) ([]domaintask.TaskTemplate, error) {
+    return s.repo.ListByTenant(ctx, tenantID)
+}
+
+func (s *Service) Get(ctx context.Context, tenantID, id string) (*domaintask.TaskTemplate, error) {
+    return s.repo.Get(ctx, tenantID, id)
+}
+
+// Update replaces every field of the tenant's template with in.
+func (s *Service) Update(ctx context.Context, tenantID, id string, in Input) (*domaintask.TaskTemplate, error) {
+    in, err := validate(in)
+    if err != nil {
+        return nil, err
+    }
+    t, err := s.repo.Get(ctx, tenantID, id)
+    if err != nil {
+        return nil, err
+    }
+    t.Name, t.TitlePattern, t.Description, t.Priority, t.Tags = in.Name, in.TitlePattern, in.Description, in.Priority, in.Tags
+    t.UpdatedAt = s.now()
+    if err := s.repo.Update(ctx, t); err != nil {
+        return nil, err
+    }
+    return t, nil
+}
+
+func (s *Service) Delete(ctx context.Context, tenantID, id string) error {
+    return s.repo.Delete(ctx, tenantID, id)
+}
+
+// Instantiate creates a task for userID from the tenant's template, with o
+// taking precedence over the template's fields. The task is validated like
+// any other new task.
+func (s *Service) Instantiate(ctx context.Context, tenantID, userID, id string, o Overrides) (*domaintask.Task, error) {
+    t, err := s.repo.Get(ctx, tenantID, id)
+    if err != nil {
+        return nil, err
+    }
+    in := apptask.CreateTaskInput{
+        Title:       t.Title(s.now()),
+        Description: t.Description,
+        Priority:    t.Priority,
+        DueDate:     o.DueDate,
+        AssigneeID:  o.AssigneeID,
+        Tags:        t.Tags,
+    }
+    if o.Title != nil {
+        in.Title = *o.Title
+    }
+    if o.Description != nil {
+        in.Description = *o.Description
+    }
+    if o.Priority != nil {
+        in.Priority = *o.Priority
+    }
+    if o.Tags != nil {
+        in.Tags = normalizeTags(o.Tags)
+    }
+    return s.tasks.CreateTask(ctx, tenantID, userID, in)
+}
+
+// validate checks in and returns it with its name and pattern trimmed and
+// its tags normalized.
+func validate(in Input) (Input, error) {
+    in.Name = strings.TrimSpace(in.Name)
+    in.TitlePattern = strings.TrimSpace(in.TitlePattern)
+    if err := domaintask.ValidateTemplateName(in.Name); err != nil {
+        return in, err
+    }
+    if err := domaintask.ValidateTitle(in.TitlePattern); err != nil {
+        // Report the field by its template name.
+        var fe *domaintask.FieldError
+        if errors.As(err, &fe) {
+            return in, &domaintask.FieldError{Field: "titlePattern", Err: fe.Err}
+        }
+        return in, err
+    }
+    if err := domaintask.ValidateDescription(in.Description); err != nil {
+        return in, err
+    }
+    // Zero leaves tasks at the default priority.
+    if in.Priority != 0 {
+        if err := domaintask.ValidatePriority(in.Priority); err != nil {
+            return in, err
+        }
+    }
+    in.Tags = normalizeTags(in.Tags)
+    return in, nil
+}
+
+// normalizeTags lower-cases tags, drops a leading # and blanks, and removes
+// duplicates, keeping the first occurrence.
+func normalizeTags(tags []string) []string {
+    out := make([]string, 0, len(tags))
+    seen := map[string]bool{}
+    for _, tag := range tags {
+        tag = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+        if tag == "" || seen[tag] {
+            continue
+        }
+        seen[tag] = true
+        out = append(out, tag)
+    }
+    return out
+}
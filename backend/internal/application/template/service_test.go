@@ -0,0 +1,122 @@
+package template_test
+
+import (
+    "context"
+    "errors"
+    "reflect"
+    "strings"
+    "testing"
+    "time"
+
+    apptask "backend/internal/application/task"
+    apptemplate "backend/internal/application/template"
+    domaintask "backend/internal/domain/task"
+    "backend/internal/infrastructure/memory"
+)
+
+func newService() *apptemplate.Service {
+    return apptemplate.NewService(memory.NewTemplateRepository(), apptask.NewService(memory.NewTaskRepository()))
+}
+
+// Test that instantiating a template creates a task with its title, with
+// {date} filled in, description, priority and tags.
+func TestService_Instantiate(t *testing.T) {
+    ctx := context.Background()
+    svc := newService()
+    tpl, err := svc.Create(ctx, "t1", apptemplate.Input{
+        Name:         "Bug report",
+        TitlePattern: "Bug {date}",
+        Description:  "- [ ] Steps to reproduce\n- [ ] Expected\n- [ ] Actual",
+        Priority:     8,
+        Tags:         []string{"#Bug", "triage", "bug"},
+    })
+    if err != nil {
+        t.Fatalf("create: %v", err)
+    }
+    if !reflect.DeepEqual(tpl.Tags, []string{"bug", "triage"}) {
+        t.Fatalf("expected normalized tags [bug triage], got %v", tpl.Tags)
+    }
+
+    task, err := svc.Instantiate(ctx, "t1", "u1", tpl.ID, apptemplate.Overrides{})
+    if err != nil {
+        t.Fatalf("instantiate: %v", err)
+    }
+    if want := "Bug " + time.Now().UTC().Format(time.DateOnly); task.Title != want {
+        t.Fatalf("expected title %q, got %q", want, task.Title)
+    }
+    if task.Description != tpl.Description || task.Priority != 8 || !reflect.DeepEqual(task.Tags, tpl.Tags) || task.UserID != "u1" {
+        t.Fatalf("expected the template's fields, got %+v", task)
+    }
+}
+
+// Test that overrides take precedence over the template's fields.
+func TestService_Instantiate_Overrides(t *testing.T) {
+    ctx := context.Background()
+    svc := newService()
+    tpl, err := svc.Create(ctx, "t1", apptemplate.Input{Name: "Bug report", TitlePattern: "Bug", Description: "checklist", Priority: 8, Tags: []string{"bug"}})
+    if err != nil {
+        t.Fatalf("create: %v", err)
+    }
+    title, description, priority, assignee := "Login fails", "On Safari only", 3, "u2"
+    due := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+    task, err := svc.Instantiate(ctx, "t1", "u1", tpl.ID, apptemplate.Overrides{
+        Title:       &title,
+        Description: &description,
+        Priority:    &priority,
+        DueDate:     &due,
+        AssigneeID:  &assignee,
+        Tags:        []string{"Safari"},
+    })
+    if err != nil {
+        t.Fatalf("instantiate: %v", err)
+    }
+    if task.Title != title || task.Description != description || task.Priority != priority || task.DueDate == nil || !task.DueDate.Equal(due) || task.AssigneeID == nil || *task.AssigneeID != assignee || !reflect.DeepEqual(task.Tags, []string{"safari"}) {
+        t.Fatalf("expected the overrides, got %+v", task)
+    }
+
+    blank := " "
+    if _, err := svc.Instantiate(ctx, "t1", "u1", tpl.ID, apptemplate.Overrides{Title: &blank}); !errors.Is(err, domaintask.ErrRequired) {
+        t.Fatalf("expected a blank title override to be rejected, got %v", err)
+    }
+}
+
+// Test that templates are validated, replaced as a whole, scoped to their
+// tenant and gone after deletion.
+func TestService_CRUD(t *testing.T) {
+    ctx := context.Background()
+    svc := newService()
+    for _, in := range []apptemplate.Input{
+        {Name: " ", TitlePattern: "x"},
+        {Name: "x", TitlePattern: ""},
+        {Name: "x", TitlePattern: "x", Priority: 11},
+        {Name: strings.Repeat("x", domaintask.MaxTemplateNameLength+1), TitlePattern: "x"},
+    } {
+        if _, err := svc.Create(ctx, "t1", in); err == nil {
+            t.Fatalf("%+v: expected error", in)
+        }
+    }
+
+    tpl, err := svc.Create(ctx, "t1", apptemplate.Input{Name: "Weekly review", TitlePattern: "Review {date}", Tags: []string{"ops"}})
+    if err != nil {
+        t.Fatalf("create: %v", err)
+    }
+    if _, err := svc.Get(ctx, "t2", tpl.ID); !errors.Is(err, apptemplate.ErrNotFound) {
+        t.Fatalf("expected another tenant's template to be hidden, got %v", err)
+    }
+    updated, err := svc.Update(ctx, "t1", tpl.ID, apptemplate.Input{Name: "Monthly review", TitlePattern: "Review"})
+    if err != nil {
+        t.Fatalf("update: %v", err)
+    }
+    if updated.Name != "Monthly review" || len(updated.Tags) != 0 || !updated.CreatedAt.Equal(tpl.CreatedAt) {
+        t.Fatalf("expected the template replaced, got %+v", updated)
+    }
+    if items, _ := svc.List(ctx, "t1"); len(items) != 1 || items[0].Name != "Monthly review" {
+        t.Fatalf("expected the updated template listed, got %+v", items)
+    }
+    if err := svc.Delete(ctx, "t1", tpl.ID); err != nil {
+        t.Fatalf("delete: %v", err)
+    }
+    if _, err := svc.Instantiate(ctx, "t1", "u1", tpl.ID, apptemplate.Overrides{}); !errors.Is(err, apptemplate.ErrNotFound) {
+        t.Fatalf("expected ErrNotFound, got %v", err)
+    }
+}
@@ -0,0 +1,85 @@
+package tenant
+
+import (
+    "context"
+    "errors"
+
+    domaintenant "backend/internal/domain/tenant"
+)
+
+var (
+    // ErrSettingsNotFound is returned by SettingsRepository.GetSettings for
+    // a tenant that never saved settings.
+    ErrSettingsNotFound = errors.New("tenant settings not found")
+    // ErrInvalidRegion is returned for a region outside domaintenant.Regions.
+    ErrInvalidRegion = errors.New("region must be one of us-east-1, eu-west-1, ap-southeast-1")
+)
+
+// PurgeResult reports how many rows of each entity a purge removed. The
+// tenant's uploaded files and cached prioritization results are removed as
+// well but not counted. Access-token revocations are kept until they expire:
+// tokens are verified without looking up their user, so a revoked token
+// would otherwise work again.
+type PurgeResult struct {
+    Tasks            int64 `json:"tasks"`
+    Comments         int64 `json:"comments"`
+    Attachments      int64 `json:"attachments"`
+    Watchers         int64 `json:"watchers"`
+    Dependencies     int64 `json:"dependencies"`
+    Projects         int64 `json:"projects"`
+    ProjectFavorites int64 `json:"projectFavorites"`
+    // PrioritizeSettings is 1 when the tenant had saved prioritization
+    // weights.
+    PrioritizeSettings int64 `json:"prioritizeSettings"`
+    // APIKeys counts revoked keys as well.
+    APIKeys      int64 `json:"apiKeys"`
+    FeatureFlags int64 `json:"featureFlags"`
+    Templates    int64 `json:"templates"`
+    // TenantSettings is 1 when the tenant had a data residency region
+    // configured.
+    TenantSettings int64 `json:"tenantSettings"`
+    Users          int64 `json:"users"`
+    RefreshTokens  int64 `json:"refreshTokens"`
+    // Tenants is 1 when the tenant signed up through registration and so
+    // had a tenant record of its own.
+    Tenants int64 `json:"tenants"`
+    // Jobs counts the background job statuses removed. Jobs still waiting
+    // in the queue run anyway but can no longer be polled.
+    Jobs int64 `json:"jobs"`
+}
+
+// Repository defines tenant-wide persistence operations.
+type Repository interface {
+    // PurgeData permanently deletes every record owned by the tenant in a
+    // single transaction, bypassing soft deletes.
+    PurgeData(ctx context.Context, tenantID string) (PurgeResult, error)
+}
+
+// FileStore removes uploaded files, which are kept apart from the tenant's
+// records.
+type FileStore interface {
+    // DeletePrefix removes every file whose key lies below prefix.
+    DeletePrefix(ctx context.Context, prefix string) error
+}
+
+// ScoreCache holds prioritization results computed from the tenant's tasks.
+type ScoreCache interface {
+    // Invalidate drops the tenant's cached results.
+    Invalidate(tenantID string)
+}
+
+// JobStore keeps the status of background jobs, which is kept apart from
+// the tenant's records.
+type JobStore interface {
+    // DeleteTenant removes the status of every job of the tenant and
+    // returns how many it removed.
+    DeleteTenant(ctx context.Context, tenantID string) (int64, error)
+}
+
+// SettingsRepository defines persistence operations for tenant settings.
+type SettingsRepository interface {
+    // GetSettings returns the tenant's settings, or ErrSettingsNotFound.
+    GetSettings(ctx context.Context, tenantID string) (*domaintenant.TenantSettings, error)
+    // SaveSettings creates or replaces the tenant's settings.
+    SaveSettings(ctx context.Context, st *domaintenant.TenantSettings) error
+}
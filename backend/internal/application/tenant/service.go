@@ -0,0 +1,79 @@
+package tenant
+
+import (
+    "context"
+    "errors"
+    "log/slog"
+    "strings"
+)
+
+// ErrConfirmationMismatch is returned when the confirmation token does not
+// match the tenant being purged.
+var ErrConfirmationMismatch = errors.New("confirmation token must equal the tenant id")
+
+// Service implements tenant administration use cases.
+type Service struct {
+    repo   Repository
+    files  FileStore
+    scores ScoreCache
+    jobs   JobStore
+}
+
+// Option configures optional Service behaviour.
+type Option func(*Service)
+
+// WithFileStore sets where the tenant's uploaded files are kept, so a purge
+// removes them too. Files are keyed below "tenantID/".
+func WithFileStore(f FileStore) Option {
+    return func(s *Service) { s.files = f }
+}
+
+// WithScoreCache sets the prioritization cache a purge clears, so no
+// ranking of the purged tasks is served afterwards.
+func WithScoreCache(c ScoreCache) Option {
+    return func(s *Service) { s.scores = c }
+}
+
+// WithJobStore sets where background job statuses are kept, so a purge
+// removes the tenant's jobs too.
+func WithJobStore(j JobStore) Option {
+    return func(s *Service) { s.jobs = j }
+}
+
+func NewService(repo Repository, opts ...Option) *Service {
+    s := &Service{repo: repo}
+    for _, opt := range opts {
+        opt(s)
+    }
+    return s
+}
+
+// PurgeData hard-deletes all of a tenant's data. The caller must echo the
+// tenant id as confirm, which guards against purging the wrong tenant.
+func (s *Service) PurgeData(ctx context.Context, tenantID, confirm string) (PurgeResult, error) {
+    if strings.TrimSpace(tenantID) == "" || confirm != tenantID {
+        return PurgeResult{}, ErrConfirmationMismatch
+    }
+    res, err := s.repo.PurgeData(ctx, tenantID)
+    if err != nil {
+        return PurgeResult{}, err
+    }
+    if s.scores != nil {
+        s.scores.Invalidate(tenantID)
+    }
+    // Files and job statuses go after the records that point at them; a
+    // failure here is returned so the purge is retried, which finds no
+    // records left.
+    if s.files != nil {
+        if err := s.files.DeletePrefix(ctx, tenantID+"/"); err != nil {
+            return PurgeResult{}, err
+        }
+    }
+    if s.jobs != nil {
+        if res.Jobs, err = s.jobs.DeleteTenant(ctx, tenantID); err != nil {
+            return PurgeResult{}, err
+        }
+    }
+    slog.InfoContext(ctx, "tenant data purged", "tenant_id", tenantID, "result", res)
+    return res, nil
+}
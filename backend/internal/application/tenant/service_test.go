@@ -0,0 +1,261 @@
+package tenant_test
+
+import (
+    "context"
+    "errors"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+    "time"
+
+    appapikey "backend/internal/application/apikey"
+    appprioritize "backend/internal/application/prioritize"
+    appproject "backend/internal/application/project"
+    apprevocation "backend/internal/application/revocation"
+    apptask "backend/internal/application/task"
+    apptenant "backend/internal/application/tenant"
+    domainaccount "backend/internal/domain/account"
+    domainfeatureflag "backend/internal/domain/featureflag"
+    domaintask "backend/internal/domain/task"
+    domaintenant "backend/internal/domain/tenant"
+    "backend/internal/infrastructure/blob"
+    "backend/internal/infrastructure/memory"
+)
+
+// Test that purging removes every record of the tenant, reports the counts,
+// and leaves other tenants untouched.
+func TestService_PurgeData(t *testing.T) {
+    ctx := context.Background()
+    tasks := memory.NewTaskRepository()
+    projects := memory.NewProjectRepository(tasks)
+    taskSvc := apptask.NewService(tasks)
+    projectSvc := appproject.NewService(projects)
+    settings := memory.NewPrioritizeSettingsRepository()
+    keys := memory.NewAPIKeyRepository()
+    flags := memory.NewFeatureFlagRepository()
+    accounts := memory.NewAccountRepository()
+    templates := memory.NewTemplateRepository()
+    tenantSettings := memory.NewTenantSettingsRepository()
+    revocations := apprevocation.NewService(memory.NewRevocationRepository(accounts), time.Hour)
+    keySvc := appapikey.NewService(keys)
+    defer keySvc.Wait()
+    dir := t.TempDir()
+    files := blob.NewLocalStore(dir, "/uploads")
+    svc := apptenant.NewService(memory.NewTenantRepository(tasks, projects).With(settings, keys, flags, accounts, templates, tenantSettings), apptenant.WithFileStore(files))
+
+    secrets, hashes := map[string]string{}, map[string]string{}
+    for _, tenantID := range []string{"t1", "t2"} {
+        owner := &domainaccount.User{ID: "owner-" + tenantID, TenantID: tenantID, Email: "owner@" + tenantID + ".test", Role: domainaccount.RoleOwner}
+        if err := accounts.CreateTenant(ctx, &domainaccount.Tenant{ID: tenantID, Name: "Acme " + tenantID}, owner); err != nil {
+            t.Fatalf("create tenant: %v", err)
+        }
+        refresh, _, err := domainaccount.NewRefreshToken(tenantID, owner.ID, "", time.Now(), time.Hour)
+        if err != nil {
+            t.Fatalf("new refresh token: %v", err)
+        }
+        if err := accounts.CreateRefreshToken(ctx, refresh); err != nil {
+            t.Fatalf("create refresh token: %v", err)
+        }
+        hashes[tenantID] = refresh.Hash
+        if err := revocations.RevokeToken(ctx, tenantID, "jti-1"); err != nil {
+            t.Fatalf("revoke token: %v", err)
+        }
+        if err := revocations.RevokeUser(ctx, tenantID, "u1"); err != nil {
+            t.Fatalf("revoke user: %v", err)
+        }
+        if err := templates.Create(ctx, &domaintask.TaskTemplate{ID: "tpl-" + tenantID, TenantID: tenantID, Name: "Bug report", TitlePattern: "Bug"}); err != nil {
+            t.Fatalf("create template: %v", err)
+        }
+        _, secret, err := keySvc.Mint(ctx, tenantID, "u1", "ci")
+        if err != nil {
+            t.Fatalf("mint key: %v", err)
+        }
+        secrets[tenantID] = secret
+        if _, err := files.Put(ctx, tenantID+"/task/spec.txt", "text/plain", strings.NewReader("spec")); err != nil {
+            t.Fatalf("put file: %v", err)
+        }
+        if err := flags.Save(ctx, &domainfeatureflag.FeatureFlag{Name: "sse", TenantID: tenantID, Enabled: true}); err != nil {
+            t.Fatalf("save flag: %v", err)
+        }
+        if err := tenantSettings.SaveSettings(ctx, &domaintenant.TenantSettings{TenantID: tenantID, Region: domaintenant.RegionEUWest1}); err != nil {
+            t.Fatalf("save tenant settings: %v", err)
+        }
+        if err := settings.SaveSettings(ctx, tenantID, appprioritize.Settings{AutoPrioritize: true}); err != nil {
+            t.Fatalf("save settings: %v", err)
+        }
+        p, err := projectSvc.Create(ctx, tenantID, "p", "")
+        if err != nil {
+            t.Fatalf("create project: %v", err)
+        }
+        if err := projectSvc.SetFavorite(ctx, tenantID, "u1", p.ID, true); err != nil {
+            t.Fatalf("favorite: %v", err)
+        }
+        for i := 0; i < 2; i++ {
+            if _, err := taskSvc.Create(ctx, tenantID, "u1", "task", "", 5); err != nil {
+                t.Fatalf("create task: %v", err)
+            }
+        }
+    }
+
+    res, err := svc.PurgeData(ctx, "t1", "t1")
+    if err != nil {
+        t.Fatalf("purge: %v", err)
+    }
+    want := apptenant.PurgeResult{Tasks: 2, Projects: 1, ProjectFavorites: 1, PrioritizeSettings: 1, APIKeys: 1, FeatureFlags: 1, Templates: 1, TenantSettings: 1, Users: 1, RefreshTokens: 1, Tenants: 1}
+    if res != want {
+        t.Fatalf("expected %+v, got %+v", want, res)
+    }
+
+    if items, _ := taskSvc.List(ctx, "t1"); len(items) != 0 {
+        t.Fatalf("expected no t1 tasks, got %d", len(items))
+    }
+    if items, _ := projectSvc.List(ctx, "t1", "u1"); len(items) != 0 {
+        t.Fatalf("expected no t1 projects, got %d", len(items))
+    }
+    if items, _ := projects.ListFavoriteIDs(ctx, "t1", "u1"); len(items) != 0 {
+        t.Fatalf("expected no t1 favorites, got %d", len(items))
+    }
+    if _, err := settings.GetSettings(ctx, "t1"); !errors.Is(err, appprioritize.ErrSettingsNotFound) {
+        t.Fatalf("expected no t1 prioritize settings, got %v", err)
+    }
+    if _, err := keySvc.Authenticate(ctx, secrets["t1"]); err == nil {
+        t.Fatalf("expected t1's API key to stop working")
+    }
+    if items, _ := flags.ListByTenant(ctx, "t1"); len(items) != 0 {
+        t.Fatalf("expected no t1 feature flags, got %d", len(items))
+    }
+    if u, err := accounts.FindUserByEmail(ctx, "owner@t1.test"); err == nil {
+        t.Fatalf("expected t1's users to be gone, got %+v", u)
+    }
+    if _, err := accounts.TenantName(ctx, "t1"); err == nil {
+        t.Fatalf("expected t1's tenant record to be gone")
+    }
+    if _, err := accounts.FindRefreshToken(ctx, hashes["t1"]); err == nil {
+        t.Fatalf("expected t1's refresh tokens to be gone")
+    }
+    if items, _ := templates.ListByTenant(ctx, "t1"); len(items) != 0 {
+        t.Fatalf("expected no t1 templates, got %d", len(items))
+    }
+    if _, err := tenantSettings.GetSettings(ctx, "t1"); !errors.Is(err, apptenant.ErrSettingsNotFound) {
+        t.Fatalf("expected no t1 tenant settings, got %v", err)
+    }
+    // Unexpired access tokens of the tenant must stay refused
+    if err := revocations.Refresh(ctx); err != nil {
+        t.Fatalf("refresh revocations: %v", err)
+    }
+    if revoked, err := revocations.Revoked("t1", "u2", "jti-1", time.Now()); err != nil || !revoked {
+        t.Fatalf("expected t1's revoked token to stay revoked, got %v (%v)", revoked, err)
+    }
+    if revoked, err := revocations.Revoked("t1", "u1", "", time.Now().Add(-time.Minute)); err != nil || !revoked {
+        t.Fatalf("expected t1's revoked user to stay revoked, got %v (%v)", revoked, err)
+    }
+    if _, err := os.Stat(filepath.Join(dir, "t1")); !errors.Is(err, os.ErrNotExist) {
+        t.Fatalf("expected t1's files to be gone, got %v", err)
+    }
+
+    if items, _ := taskSvc.List(ctx, "t2"); len(items) != 2 {
+        t.Fatalf("expected t2 tasks untouched, got %d", len(items))
+    }
+    if items, _ := projectSvc.List(ctx, "t2", "u1"); len(items) != 1 || !items[0].IsFavorite {
+        t.Fatalf("expected t2 project and favorite untouched, got %+v", items)
+    }
+    if st, err := settings.GetSettings(ctx, "t2"); err != nil || !st.AutoPrioritize {
+        t.Fatalf("expected t2 prioritize settings untouched, got %+v (%v)", st, err)
+    }
+    if k, err := keySvc.Authenticate(ctx, secrets["t2"]); err != nil || k.TenantID != "t2" {
+        t.Fatalf("expected t2's API key to keep working, got %v", err)
+    }
+    if items, _ := flags.ListByTenant(ctx, "t2"); len(items) != 1 {
+        t.Fatalf("expected t2 feature flags untouched, got %d", len(items))
+    }
+    if u, err := accounts.FindUserByEmail(ctx, "owner@t2.test"); err != nil || u.TenantID != "t2" {
+        t.Fatalf("expected t2's users untouched, got %v", err)
+    }
+    if name, err := accounts.TenantName(ctx, "t2"); err != nil || name != "Acme t2" {
+        t.Fatalf("expected t2's tenant record untouched, got %q (%v)", name, err)
+    }
+    if _, err := accounts.FindRefreshToken(ctx, hashes["t2"]); err != nil {
+        t.Fatalf("expected t2's refresh tokens untouched, got %v", err)
+    }
+    if items, _ := templates.ListByTenant(ctx, "t2"); len(items) != 1 {
+        t.Fatalf("expected t2 templates untouched, got %d", len(items))
+    }
+    if st, err := tenantSettings.GetSettings(ctx, "t2"); err != nil || st.Region != domaintenant.RegionEUWest1 {
+        t.Fatalf("expected t2 tenant settings untouched, got %+v (%v)", st, err)
+    }
+    if _, err := os.Stat(filepath.Join(dir, "t2", "task", "spec.txt")); err != nil {
+        t.Fatalf("expected t2's files untouched, got %v", err)
+    }
+}
+
+// jobStore records the tenants whose jobs were deleted and reports n each.
+type jobStore struct {
+    tenants []string
+    n       int64
+    err     error
+}
+
+func (j *jobStore) DeleteTenant(_ context.Context, tenantID string) (int64, error) {
+    j.tenants = append(j.tenants, tenantID)
+    return j.n, j.err
+}
+
+// Test that a purge drops the tenant's cached rankings and job statuses,
+// counting the jobs, and is reported as failed when the jobs remain.
+func TestService_PurgeData_CacheAndJobs(t *testing.T) {
+    ctx := context.Background()
+    tasks := memory.NewTaskRepository()
+    repo := memory.NewTenantRepository(tasks, memory.NewProjectRepository(tasks))
+    cache := appprioritize.NewCache(time.Hour)
+    prioritizeSvc := appprioritize.NewService().WithCache(cache)
+    jobs := &jobStore{n: 2}
+    svc := apptenant.NewService(repo, apptenant.WithScoreCache(cache), apptenant.WithJobStore(jobs))
+
+    computed := 0
+    rank := func() (any, error) { computed++; return computed, nil }
+    for _, tenantID := range []string{"t1", "t2"} {
+        if _, _, err := prioritizeSvc.Cached(tenantID, "rank", false, rank); err != nil {
+            t.Fatalf("cache: %v", err)
+        }
+    }
+
+    res, err := svc.PurgeData(ctx, "t1", "t1")
+    if err != nil {
+        t.Fatalf("purge: %v", err)
+    }
+    if res.Jobs != 2 || len(jobs.tenants) != 1 || jobs.tenants[0] != "t1" {
+        t.Fatalf("expected t1's 2 jobs removed, got %d for %q", res.Jobs, jobs.tenants)
+    }
+    if v, _, _ := prioritizeSvc.Cached("t1", "rank", false, rank); v != 3 {
+        t.Fatalf("expected t1's ranking recomputed after the purge, got %v", v)
+    }
+    if v, _, _ := prioritizeSvc.Cached("t2", "rank", false, rank); v != 2 {
+        t.Fatalf("expected t2's ranking still cached, got %v", v)
+    }
+
+    jobs.err = errors.New("redis down")
+    if _, err := svc.PurgeData(ctx, "t1", "t1"); !errors.Is(err, jobs.err) {
+        t.Fatalf("expected the job store error, got %v", err)
+    }
+}
+
+// Test that a missing or wrong confirmation token prevents the purge.
+func TestService_PurgeData_RequiresConfirmation(t *testing.T) {
+    ctx := context.Background()
+    tasks := memory.NewTaskRepository()
+    taskSvc := apptask.NewService(tasks)
+    svc := apptenant.NewService(memory.NewTenantRepository(tasks, memory.NewProjectRepository(tasks)))
+    if _, err := taskSvc.Create(ctx, "t1", "u1", "task", "", 5); err != nil {
+        t.Fatalf("create task: %v", err)
+    }
+
+    for _, confirm := range []string{"", "t2"} {
+        if _, err := svc.PurgeData(ctx, "t1", confirm); !errors.Is(err, apptenant.ErrConfirmationMismatch) {
+            t.Fatalf("confirm %q: expected ErrConfirmationMismatch, got %v", confirm, err)
+        }
+    }
+    if items, _ := taskSvc.List(ctx, "t1"); len(items) != 1 {
+        t.Fatalf("expected task to survive, got %d", len(items))
+    }
+}
@@ -0,0 +1,80 @@
+package tenant
+
+import (
+    "context"
+    "errors"
+    "sync"
+    "time"
+
+    domaintenant "backend/internal/domain/tenant"
+)
+
+// DefaultSettingsCacheTTL is how long GetRegion serves a region from memory.
+const DefaultSettingsCacheTTL = 30 * time.Second
+
+// SettingsService implements tenant settings use cases. GetRegion is
+// consulted on every task write, so it answers from an in-memory cache; a
+// change made through another instance is seen here within the TTL.
+type SettingsService struct {
+    repo SettingsRepository
+    ttl  time.Duration
+    now  func() time.Time
+
+    mu    sync.Mutex
+    cache map[string]cachedRegion // tenantID -> region
+}
+
+type cachedRegion struct {
+    region  string
+    expires time.Time
+}
+
+func NewSettingsService(repo SettingsRepository) *SettingsService {
+    return &SettingsService{repo: repo, ttl: DefaultSettingsCacheTTL, now: time.Now, cache: make(map[string]cachedRegion)}
+}
+
+// Get returns the tenant's settings; a tenant that never saved any gets
+// empty ones.
+func (s *SettingsService) Get(ctx context.Context, tenantID string) (*domaintenant.TenantSettings, error) {
+    st, err := s.repo.GetSettings(ctx, tenantID)
+    if errors.Is(err, ErrSettingsNotFound) {
+        return &domaintenant.TenantSettings{TenantID: tenantID}, nil
+    }
+    return st, err
+}
+
+// GetRegion returns the tenant's data residency region, or "" when none is
+// configured.
+func (s *SettingsService) GetRegion(ctx context.Context, tenantID string) (string, error) {
+    s.mu.Lock()
+    c, ok := s.cache[tenantID]
+    s.mu.Unlock()
+    if ok && s.now().Before(c.expires) {
+        return c.region, nil
+    }
+
+    st, err := s.Get(ctx, tenantID)
+    if err != nil {
+        return "", err
+    }
+    s.mu.Lock()
+    s.cache[tenantID] = cachedRegion{region: st.Region, expires: s.now().Add(s.ttl)}
+    s.mu.Unlock()
+    return st.Region, nil
+}
+
+// SetRegion pins the tenant's data to region, or lifts the requirement when
+// region is empty, and returns the saved settings.
+func (s *SettingsService) SetRegion(ctx context.Context, tenantID, region string) (*domaintenant.TenantSettings, error) {
+    if region != "" && !domaintenant.ValidRegion(region) {
+        return nil, ErrInvalidRegion
+    }
+    st := &domaintenant.TenantSettings{TenantID: tenantID, Region: region, UpdatedAt: s.now().UTC()}
+    if err := s.repo.SaveSettings(ctx, st); err != nil {
+        return nil, err
+    }
+    s.mu.Lock()
+    delete(s.cache, tenantID)
+    s.mu.Unlock()
+    return st, nil
+}
@@ -0,0 +1,33 @@
+package tenant_test
+
+import (
+    "context"
+    "errors"
+    "testing"
+
+    apptenant "backend/internal/application/tenant"
+    "backend/internal/infrastructure/memory"
+)
+
+// Test that a tenant without settings has no region, that a set region is
+// reported at once, and that unknown regions are refused.
+func TestSettingsService_Region(t *testing.T) {
+    ctx := context.Background()
+    svc := apptenant.NewSettingsService(memory.NewTenantSettingsRepository())
+
+    if region, err := svc.GetRegion(ctx, "t1"); err != nil || region != "" {
+        t.Fatalf("expected no region, got %q, %v", region, err)
+    }
+    if _, err := svc.SetRegion(ctx, "t1", "eu-west-1"); err != nil {
+        t.Fatalf("set region: %v", err)
+    }
+    if region, err := svc.GetRegion(ctx, "t1"); err != nil || region != "eu-west-1" {
+        t.Fatalf("expected eu-west-1, got %q, %v", region, err)
+    }
+    if region, _ := svc.GetRegion(ctx, "t2"); region != "" {
+        t.Fatalf("expected other tenants to have no region, got %q", region)
+    }
+    if _, err := svc.SetRegion(ctx, "t1", "mars-north-1"); !errors.Is(err, apptenant.ErrInvalidRegion) {
+        t.Fatalf("expected ErrInvalidRegion, got %v", err)
+    }
+}
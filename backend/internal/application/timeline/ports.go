@@ -0,0 +1,26 @@
+package timeline
+
+import (
+    "context"
+    "errors"
+
+    domaintask "backend/internal/domain/task"
+)
+
+// ErrTaskNotFound is returned when the task does not exist for the tenant.
+var ErrTaskNotFound = errors.New("task not found")
+
+// TaskReader loads the task a timeline is built for.
+type TaskReader interface {
+    Get(ctx context.Context, tenantID, id string) (*domaintask.Task, error)
+}
+
+// CommentLister lists a task's comments, oldest first.
+type CommentLister interface {
+    ListByTask(ctx context.Context, tenantID, taskID string) ([]domaintask.TaskComment, error)
+}
+
+// AttachmentLister lists a task's attachments, oldest first.
+type AttachmentLister interface {
+    ListByTask(ctx context.Context, tenantID, taskID string) ([]domaintask.TaskAttachment, error)
+}
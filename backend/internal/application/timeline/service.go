@@ -0,0 +1,63 @@
+package timeline
+
+import (
+    "context"
+    "errors"
+    "sort"
+
+    apptask "backend/internal/application/task"
+    domaintask "backend/internal/domain/task"
+)
+
+// Service assembles a task's activity timeline from the stores that record
+// its creation, comments and attachments.
+type Service struct {
+    tasks       TaskReader
+    comments    CommentLister
+    attachments AttachmentLister
+}
+
+// NewService returns a timeline service; attachments may be nil when
+// attachments are not enabled.
+func NewService(tasks TaskReader, comments CommentLister, attachments AttachmentLister) *Service {
+    return &Service{tasks: tasks, comments: comments, attachments: attachments}
+}
+
+// Get returns the task's creation, comments and attachments as one feed,
+// oldest first; entries with the same time keep that order. Internal
+// comments are left out unless the caller is an admin.
+func (s *Service) Get(ctx context.Context, tenantID, taskID string, isAdmin bool) ([]domaintask.TimelineEntry, error) {
+    t, err := s.tasks.Get(ctx, tenantID, taskID)
+    if errors.Is(err, apptask.ErrNotFound) {
+        return nil, ErrTaskNotFound
+    }
+    if err != nil {
+        return nil, err
+    }
+    out := []domaintask.TimelineEntry{{Type: domaintask.TimelineCreated, At: t.CreatedAt, ActorID: t.UserID}}
+
+    comments, err := s.comments.ListByTask(ctx, tenantID, taskID)
+    if err != nil {
+        return nil, err
+    }
+    for i := range comments {
+        c := &comments[i]
+        if c.Internal && !isAdmin {
+            continue
+        }
+        out = append(out, domaintask.TimelineEntry{Type: domaintask.TimelineComment, At: c.CreatedAt, ActorID: c.Author, Comment: c})
+    }
+
+    if s.attachments != nil {
+        attachments, err := s.attachments.ListByTask(ctx, tenantID, taskID)
+        if err != nil {
+            return nil, err
+        }
+        for i := range attachments {
+            out = append(out, domaintask.TimelineEntry{Type: domaintask.TimelineAttachment, At: attachments[i].CreatedAt, Attachment: &attachments[i]})
+        }
+    }
+
+    sort.SliceStable(out, func(i, j int) bool { return out[i].At.Before(out[j].At) })
+    return out, nil
+}
@@ -0,0 +1,105 @@
+package timeline_test
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    apptimeline "backend/internal/application/timeline"
+    domaintask "backend/internal/domain/task"
+    "backend/internal/infrastructure/memory"
+)
+
+// Test that the timeline interleaves the task's creation, comments and
+// attachments by time, hiding internal comments from non-admins.
+func TestService_Get_Interleaves(t *testing.T) {
+    ctx := context.Background()
+    tasks := memory.NewTaskRepository()
+    comments := memory.NewCommentRepository(tasks)
+    attachments := memory.NewAttachmentRepository(tasks)
+    base := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+    tk := domaintask.New("t1", "u1", "task", "", 5)
+    tk.CreatedAt = base
+    if err := tasks.Create(ctx, tk); err != nil {
+        t.Fatalf("create task: %v", err)
+    }
+    at := func(minutes int) time.Time { return base.Add(time.Duration(minutes) * time.Minute) }
+
+    // Stored out of order so the feed has to sort across sources
+    late := domaintask.NewComment("t1", tk.ID, "u2", "done", false)
+    late.CreatedAt = at(50)
+    early := domaintask.NewComment("t1", tk.ID, "u2", "started", false)
+    early.CreatedAt = at(10)
+    note := domaintask.NewComment("t1", tk.ID, "admin", "internal note", true)
+    note.CreatedAt = at(30)
+    for _, c := range []*domaintask.TaskComment{late, early, note} {
+        if err := comments.Create(ctx, c); err != nil {
+            t.Fatalf("create comment: %v", err)
+        }
+    }
+    spec := domaintask.NewAttachment("t1", tk.ID, "spec.pdf", "/uploads/spec.pdf", "application/pdf", 10)
+    spec.CreatedAt = at(40)
+    draft := domaintask.NewAttachment("t1", tk.ID, "draft.txt", "/uploads/draft.txt", "text/plain", 5)
+    draft.CreatedAt = at(5)
+    for _, a := range []*domaintask.TaskAttachment{spec, draft} {
+        if err := attachments.Create(ctx, a); err != nil {
+            t.Fatalf("create attachment: %v", err)
+        }
+    }
+    svc := apptimeline.NewService(tasks, comments, attachments)
+
+    for _, tc := range []struct {
+        isAdmin bool
+        want    []string
+    }{
+        {false, []string{"created", "draft.txt", "started", "spec.pdf", "done"}},
+        {true, []string{"created", "draft.txt", "started", "internal note", "spec.pdf", "done"}},
+    } {
+        got, err := svc.Get(ctx, "t1", tk.ID, tc.isAdmin)
+        if err != nil {
+            t.Fatalf("get: %v", err)
+        }
+        var labels []string
+        for i, e := range got {
+            if i > 0 && e.At.Before(got[i-1].At) {
+                t.Fatalf("expected entries oldest first, got %v before %v", got[i-1].At, e.At)
+            }
+            switch e.Type {
+            case domaintask.TimelineCreated:
+                labels = append(labels, "created")
+            case domaintask.TimelineComment:
+                labels = append(labels, e.Comment.Content)
+            case domaintask.TimelineAttachment:
+                labels = append(labels, e.Attachment.Name)
+            }
+        }
+        if len(labels) != len(tc.want) {
+            t.Fatalf("admin=%v: expected %v, got %v", tc.isAdmin, tc.want, labels)
+        }
+        for i := range labels {
+            if labels[i] != tc.want[i] {
+                t.Fatalf("admin=%v: expected %v, got %v", tc.isAdmin, tc.want, labels)
+            }
+        }
+    }
+}
+
+// Test that the timeline of a task the tenant does not have is
+// ErrTaskNotFound, and that attachments are optional.
+func TestService_Get_TaskNotFound(t *testing.T) {
+    tasks := memory.NewTaskRepository()
+    tk := domaintask.New("t2", "u2", "foreign", "", 5)
+    if err := tasks.Create(context.Background(), tk); err != nil {
+        t.Fatalf("create task: %v", err)
+    }
+    svc := apptimeline.NewService(tasks, memory.NewCommentRepository(tasks), nil)
+
+    if _, err := svc.Get(context.Background(), "t1", tk.ID, false); !errors.Is(err, apptimeline.ErrTaskNotFound) {
+        t.Fatalf("expected ErrTaskNotFound, got %v", err)
+    }
+    got, err := svc.Get(context.Background(), "t2", tk.ID, false)
+    if err != nil || len(got) != 1 || got[0].Type != domaintask.TimelineCreated || got[0].ActorID != "u2" {
+        t.Fatalf("expected only the created entry, got %+v (%v)", got, err)
+    }
+}
@@ -0,0 +1,32 @@
+package user
+
+import (
+    "context"
+    "errors"
+
+    domainaccount "backend/internal/domain/account"
+)
+
+var (
+    // ErrNotFound is returned when a user does not exist in the tenant.
+    ErrNotFound = errors.New("user not found")
+    // ErrEmailTaken is returned when another user of the tenant already has
+    // the email.
+    ErrEmailTaken = errors.New("email is already used in this tenant")
+)
+
+// Repository defines persistence operations for the users of a tenant.
+// Emails are compared normalized, see domainaccount.NormalizeEmail.
+type Repository interface {
+    // GetByID returns the tenant's user, or ErrNotFound.
+    GetByID(ctx context.Context, tenantID, id string) (*domainaccount.User, error)
+    // GetByEmail returns the tenant's user with the email, or ErrNotFound.
+    GetByEmail(ctx context.Context, tenantID, email string) (*domainaccount.User, error)
+    // ListByTenant returns the tenant's users ordered by display name.
+    ListByTenant(ctx context.Context, tenantID string) ([]domainaccount.User, error)
+    // Create stores u, or returns ErrEmailTaken.
+    Create(ctx context.Context, u *domainaccount.User) error
+    // Update replaces the email, display name and role of an existing user,
+    // or returns ErrNotFound or ErrEmailTaken.
+    Update(ctx context.Context, u *domainaccount.User) error
+}
@@ -0,0 +1,26 @@
+package user
+
+import (
+    "context"
+
+    domainaccount "backend/internal/domain/account"
+)
+
+// Service implements user directory use cases.
+type Service struct {
+    repo Repository
+}
+
+func NewService(repo Repository) *Service {
+    return &Service{repo: repo}
+}
+
+// List returns the tenant's users, for example to pick an assignee from.
+func (s *Service) List(ctx context.Context, tenantID string) ([]domainaccount.User, error) {
+    return s.repo.ListByTenant(ctx, tenantID)
+}
+
+// Get returns the tenant's user, or ErrNotFound.
+func (s *Service) Get(ctx context.Context, tenantID, id string) (*domainaccount.User, error) {
+    return s.repo.GetByID(ctx, tenantID, id)
+}
@@ -0,0 +1,77 @@
+package user_test
+
+import (
+    "context"
+    "errors"
+    "testing"
+
+    appuser "backend/internal/application/user"
+    domainaccount "backend/internal/domain/account"
+    "backend/internal/infrastructure/memory"
+)
+
+// Test that List returns only the tenant's users, ordered by display name,
+// and that Get does not reach into other tenants.
+func TestService_ListAndGet(t *testing.T) {
+    ctx := context.Background()
+    repo := memory.NewUserRepository(memory.NewAccountRepository())
+    svc := appuser.NewService(repo)
+    bob := domainaccount.NewUser("t1", "bob@example.com", "hash")
+    ada := domainaccount.NewUser("t1", "ada@example.com", "hash")
+    eve := domainaccount.NewUser("t2", "eve@example.com", "hash")
+    for _, u := range []*domainaccount.User{bob, ada, eve} {
+        if err := repo.Create(ctx, u); err != nil {
+            t.Fatalf("create: %v", err)
+        }
+    }
+
+    users, err := svc.List(ctx, "t1")
+    if err != nil {
+        t.Fatalf("list: %v", err)
+    }
+    if len(users) != 2 || users[0].ID != ada.ID || users[1].ID != bob.ID {
+        t.Fatalf("expected ada and bob, got %+v", users)
+    }
+    if u, err := svc.Get(ctx, "t1", ada.ID); err != nil || u.DisplayName != "ada" || u.Role != domainaccount.RoleMember {
+        t.Fatalf("expected ada as a member, got %+v (%v)", u, err)
+    }
+    if _, err := svc.Get(ctx, "t1", eve.ID); !errors.Is(err, appuser.ErrNotFound) {
+        t.Fatalf("expected appuser.ErrNotFound, got %v", err)
+    }
+}
+
+// Test that emails are unique within a tenant regardless of case, but may
+// repeat across tenants.
+func TestRepository_EmailUniquePerTenant(t *testing.T) {
+    ctx := context.Background()
+    repo := memory.NewUserRepository(memory.NewAccountRepository())
+    ada := domainaccount.NewUser("t1", "ada@example.com", "hash")
+    if err := repo.Create(ctx, ada); err != nil {
+        t.Fatalf("create: %v", err)
+    }
+    if err := repo.Create(ctx, domainaccount.NewUser("t1", "ADA@example.com", "hash")); !errors.Is(err, appuser.ErrEmailTaken) {
+        t.Fatalf("expected appuser.ErrEmailTaken, got %v", err)
+    }
+    if err := repo.Create(ctx, domainaccount.NewUser("t2", "ada@example.com", "hash")); err != nil {
+        t.Fatalf("expected another tenant to reuse the email, got %v", err)
+    }
+    if u, err := repo.GetByEmail(ctx, "t1", " Ada@Example.com"); err != nil || u.ID != ada.ID {
+        t.Fatalf("expected ada by email, got %+v (%v)", u, err)
+    }
+
+    bob := domainaccount.NewUser("t1", "bob@example.com", "hash")
+    if err := repo.Create(ctx, bob); err != nil {
+        t.Fatalf("create: %v", err)
+    }
+    bob.Email = ada.Email
+    if err := repo.Update(ctx, bob); !errors.Is(err, appuser.ErrEmailTaken) {
+        t.Fatalf("expected appuser.ErrEmailTaken, got %v", err)
+    }
+    bob.Email, bob.DisplayName = "bob@example.com", "Bob"
+    if err := repo.Update(ctx, bob); err != nil {
+        t.Fatalf("update: %v", err)
+    }
+    if u, _ := repo.GetByID(ctx, "t1", bob.ID); u.DisplayName != "Bob" {
+        t.Fatalf("expected the new display name, got %q", u.DisplayName)
+    }
+}
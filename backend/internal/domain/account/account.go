@@ -0,0 +1,123 @@
+// Package account describes the tenants that sign up to MauFlow and the
+// users that sign in to them.
+package account
+
+import (
+    "crypto/rand"
+    "crypto/sha256"
+    "encoding/hex"
+    "strings"
+    "time"
+
+    "github.com/google/uuid"
+)
+
+// Tenant is an organization whose users share tasks and projects.
+type Tenant struct {
+    ID        string    `json:"id"`
+    Name      string    `json:"name"`
+    CreatedAt time.Time `json:"createdAt"`
+}
+
+// User roles within their tenant.
+const (
+    // RoleOwner is held by the user who registered the tenant.
+    RoleOwner = "owner"
+    // RoleMember is held by every other user.
+    RoleMember = "member"
+)
+
+// User signs in to one tenant with an email and password. Email is stored
+// normalized, see NormalizeEmail; only the bcrypt hash of the password is
+// kept. DisplayName is what other users see, for example when picking an
+// assignee.
+type User struct {
+    ID           string    `json:"id"`
+    TenantID     string    `json:"tenantId"`
+    Email        string    `json:"email"`
+    DisplayName  string    `json:"displayName"`
+    Role         string    `json:"role"`
+    PasswordHash string    `json:"-"`
+    CreatedAt    time.Time `json:"createdAt"`
+}
+
+func NewTenant(name string) *Tenant {
+    return &Tenant{ID: uuid.NewString(), Name: name, CreatedAt: time.Now().UTC()}
+}
+
+// NewUser creates a member of the tenant, displayed by the part of their
+// email before the @ until they pick a name.
+func NewUser(tenantID, email, passwordHash string) *User {
+    email = NormalizeEmail(email)
+    name, _, _ := strings.Cut(email, "@")
+    return &User{
+        ID:           uuid.NewString(),
+        TenantID:     tenantID,
+        Email:        email,
+        DisplayName:  name,
+        Role:         RoleMember,
+        PasswordHash: passwordHash,
+        CreatedAt:    time.Now().UTC(),
+    }
+}
+
+// NormalizeEmail trims and lower-cases email, so addresses differing only in
+// case belong to the same user.
+func NormalizeEmail(email string) string {
+    return strings.ToLower(strings.TrimSpace(email))
+}
+
+// refreshSecretPrefix marks MauFlow refresh tokens so they are recognizable
+// in logs and secret scanners.
+const refreshSecretPrefix = "mfr_"
+
+// RefreshToken lets a client get a new access token without the password.
+// Each refresh replaces the token with a new one of the same family; only
+// the SHA-256 hash of the secret is kept. RotatedAt is set once the token
+// has been exchanged and RevokedAt once it, or its family, was revoked.
+type RefreshToken struct {
+    ID        string
+    FamilyID  string
+    TenantID  string
+    UserID    string
+    Hash      string
+    CreatedAt time.Time
+    ExpiresAt time.Time
+    RotatedAt *time.Time
+    RevokedAt *time.Time
+}
+
+// NewRefreshToken creates a token for the user valid from now for ttl and
+// returns it with its plaintext secret. An empty familyID starts a new
+// family.
+func NewRefreshToken(tenantID, userID, familyID string, now time.Time, ttl time.Duration) (*RefreshToken, string, error) {
+    b := make([]byte, 32)
+    if _, err := rand.Read(b); err != nil {
+        return nil, "", err
+    }
+    secret := refreshSecretPrefix + hex.EncodeToString(b)
+    t := &RefreshToken{
+        ID:        uuid.NewString(),
+        FamilyID:  familyID,
+        TenantID:  tenantID,
+        UserID:    userID,
+        Hash:      HashRefreshToken(secret),
+        CreatedAt: now.UTC(),
+        ExpiresAt: now.Add(ttl).UTC(),
+    }
+    if t.FamilyID == "" {
+        t.FamilyID = t.ID
+    }
+    return t, secret, nil
+}
+
+// HashRefreshToken returns the stored form of a refresh token secret.
+func HashRefreshToken(secret string) string {
+    sum := sha256.Sum256([]byte(secret))
+    return hex.EncodeToString(sum[:])
+}
+
+// Usable reports whether the token may still be exchanged at now.
+func (t RefreshToken) Usable(now time.Time) bool {
+    return t.RotatedAt == nil && t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
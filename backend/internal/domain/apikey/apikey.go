@@ -0,0 +1,70 @@
+package apikey
+
+import (
+    "crypto/rand"
+    "crypto/sha256"
+    "encoding/hex"
+    "time"
+
+    "github.com/google/uuid"
+)
+
+// secretPrefix marks MauFlow API keys so they are recognizable in configs
+// and secret scanners.
+const secretPrefix = "mf_"
+
+// APIKey lets a script act within one tenant without a user token, as
+// UserID when set and otherwise as the key's own service user. Only the
+// SHA-256 hash of the secret is kept; Name labels the key and Prefix
+// identifies it to humans.
+type APIKey struct {
+    ID         string     `json:"id"`
+    TenantID   string     `json:"tenantId"`
+    UserID     string     `json:"userId,omitempty"`
+    Name       string     `json:"name"`
+    Prefix     string     `json:"prefix"`
+    Hash       string     `json:"-"`
+    CreatedAt  time.Time  `json:"createdAt"`
+    LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+    RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+}
+
+// New creates a key for userID, which may be empty, in the tenant and
+// returns it with its plaintext secret, which is not recoverable afterwards.
+func New(tenantID, userID, name string) (*APIKey, string, error) {
+    b := make([]byte, 32)
+    if _, err := rand.Read(b); err != nil {
+        return nil, "", err
+    }
+    secret := secretPrefix + hex.EncodeToString(b)
+    return &APIKey{
+        ID:        uuid.NewString(),
+        TenantID:  tenantID,
+        UserID:    userID,
+        Name:      name,
+        Prefix:    secret[:len(secretPrefix)+8],
+        Hash:      Hash(secret),
+        CreatedAt: time.Now().UTC(),
+    }, secret, nil
+}
+
+// Hash returns the stored form of a secret.
+func Hash(secret string) string {
+    sum := sha256.Sum256([]byte(secret))
+    return hex.EncodeToString(sum[:])
+}
+
+// Revoked reports whether the key may no longer authenticate.
+func (k APIKey) Revoked() bool { return k.RevokedAt != nil }
+
+// ServiceUserID is the synthetic user requests made with a key without a
+// UserID act as.
+func (k APIKey) ServiceUserID() string { return "apikey:" + k.ID }
+
+// ActingUserID is the user requests made with the key act as.
+func (k APIKey) ActingUserID() string {
+    if k.UserID != "" {
+        return k.UserID
+    }
+    return k.ServiceUserID()
+}
@@ -0,0 +1,12 @@
+package featureflag
+
+import "time"
+
+// FeatureFlag switches an in-progress feature on or off for one tenant. A
+// flag that was never saved is off.
+type FeatureFlag struct {
+    Name      string    `json:"name"`
+    TenantID  string    `json:"tenantId"`
+    Enabled   bool      `json:"enabled"`
+    UpdatedAt time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,30 @@
+package project
+
+import (
+    "time"
+
+    "github.com/google/uuid"
+)
+
+// Project groups tasks within a tenant.
+type Project struct {
+    ID          string    `json:"id"`
+    TenantID    string    `json:"tenantId"`
+    Name        string    `json:"name"`
+    Description string    `json:"description,omitempty"`
+    Position    float64   `json:"position"`
+    CreatedAt   time.Time `json:"createdAt"`
+    UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+func New(tenantID, name, description string) *Project {
+    now := time.Now().UTC()
+    return &Project{
+        ID:          uuid.NewString(),
+        TenantID:    tenantID,
+        Name:        name,
+        Description: description,
+        CreatedAt:   now,
+        UpdatedAt:   now,
+    }
+}
@@ -0,0 +1,83 @@
+package task
+
+import "time"
+
+// Event is implemented by the domain events raised by task use cases.
+type Event interface {
+    EventName() string
+    EventTenantID() string
+}
+
+// TaskAssigned is raised when a task's assignee changes. A nil AssigneeID
+// means the task was unassigned.
+type TaskAssigned struct {
+    TenantID   string    `json:"tenantId"`
+    TaskID     string    `json:"taskId"`
+    AssigneeID *string   `json:"assigneeId"`
+    OccurredAt time.Time `json:"occurredAt"`
+}
+
+func (TaskAssigned) EventName() string { return "task.assigned" }
+
+func (e TaskAssigned) EventTenantID() string { return e.TenantID }
+
+// TaskCreated is raised after a task is created.
+type TaskCreated struct {
+    TenantID   string    `json:"tenantId"`
+    Task       Task      `json:"task"`
+    OccurredAt time.Time `json:"occurredAt"`
+}
+
+func (TaskCreated) EventName() string { return "task.created" }
+
+func (e TaskCreated) EventTenantID() string { return e.TenantID }
+
+// TaskUpdated is raised after a task's fields change; Task is the new state.
+type TaskUpdated struct {
+    TenantID   string    `json:"tenantId"`
+    Task       Task      `json:"task"`
+    OccurredAt time.Time `json:"occurredAt"`
+}
+
+func (TaskUpdated) EventName() string { return "task.updated" }
+
+func (e TaskUpdated) EventTenantID() string { return e.TenantID }
+
+// TaskMoved is raised after a task moves to another project. A nil
+// FromProjectID means the task was in no project.
+type TaskMoved struct {
+    TenantID      string    `json:"tenantId"`
+    TaskID        string    `json:"taskId"`
+    FromProjectID *string   `json:"fromProjectId"`
+    ToProjectID   string    `json:"toProjectId"`
+    OccurredAt    time.Time `json:"occurredAt"`
+}
+
+func (TaskMoved) EventName() string { return "task.moved" }
+
+func (e TaskMoved) EventTenantID() string { return e.TenantID }
+
+// TaskReopened is raised after a done or archived task, FromStatus, is
+// moved back to the open Status.
+type TaskReopened struct {
+    TenantID   string    `json:"tenantId"`
+    TaskID     string    `json:"taskId"`
+    FromStatus string    `json:"fromStatus"`
+    Status     string    `json:"status"`
+    OccurredAt time.Time `json:"occurredAt"`
+}
+
+func (TaskReopened) EventName() string { return "task.reopened" }
+
+func (e TaskReopened) EventTenantID() string { return e.TenantID }
+
+// TaskDeleted is raised after a task is deleted.
+type TaskDeleted struct {
+    TenantID   string    `json:"tenantId"`
+    TaskID     string    `json:"taskId"`
+    OccurredAt time.Time `json:"occurredAt"`
+}
+
+func (TaskDeleted) EventName() string { return "task.deleted" }
+
+func (e TaskDeleted) EventTenantID() string { return e.TenantID }
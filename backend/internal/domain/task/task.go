@@ -6,6 +6,14 @@ import (
     "github.com/google/uuid"
 )
 
+// Task statuses.
+const (
+    StatusTodo       = "todo"
+    StatusInProgress = "in_progress"
+    StatusDone       = "done"
+    StatusArchived   = "archived"
+)
+
 // Task is the core domain entity, independent of persistence concerns.
 type Task struct {
     ID          string         `json:"id"`
@@ -13,11 +21,23 @@ type Task struct {
     UserID      string         `json:"userId"`
     Title       string         `json:"title"`
     Description string         `json:"description,omitempty"`
+    Summary     string         `json:"summary,omitempty"`
     Status      string         `json:"status"`
     Priority    int            `json:"priority"`
     DueDate     *time.Time     `json:"dueDate,omitempty"`
     AiScore     *float64       `json:"aiScore,omitempty"`
     ProjectID   *string        `json:"projectId,omitempty"`
+    // ParentID is set on subtasks and names the task they belong to.
+    ParentID    *string        `json:"parentId,omitempty"`
+    AssigneeID  *string        `json:"assigneeId,omitempty"`
+    // Tags are lower-case labels such as "billing", without the leading #.
+    Tags        []string       `json:"tags,omitempty"`
+    // Position is the task's place within its project. Moving a task to
+    // another project resets it to 0, the end of the target project.
+    Position    int            `json:"position"`
+    // CompletedAt is when the task was last marked done; reopening it
+    // clears the timestamp.
+    CompletedAt *time.Time     `json:"completedAt,omitempty"`
     Comments    []TaskComment  `json:"comments,omitempty"`
     Attachments []TaskAttachment `json:"attachments,omitempty"`
     CreatedAt   time.Time      `json:"createdAt"`
@@ -32,9 +52,31 @@ func New(tenantID, userID, title, description string, priority int) *Task {
         UserID:      userID,
         Title:       title,
         Description: description,
-        Status:      "todo",
+        Status:      StatusTodo,
         Priority:    priority,
         CreatedAt:   now,
         UpdatedAt:   now,
     }
 }
+
+// SetStatus changes the task's status, stamping CompletedAt with now when the
+// task becomes done and clearing it when the task is open again. Archiving
+// keeps the timestamp of a task that was done.
+func (t *Task) SetStatus(status string, now time.Time) {
+    switch {
+    case status == StatusDone && t.Status != StatusDone:
+        t.CompletedAt = &now
+    case status == StatusTodo || status == StatusInProgress:
+        t.CompletedAt = nil
+    }
+    t.Status = status
+}
+
+// IsOverdue reports whether the task's due date lies before now while the
+// task is still open. Tasks without a due date are never overdue.
+func (t Task) IsOverdue(now time.Time) bool {
+    if t.DueDate == nil || t.Status == StatusDone || t.Status == StatusArchived {
+        return false
+    }
+    return t.DueDate.Before(now)
+}
@@ -1,12 +1,34 @@
 package task
 
-import "time"
+import (
+    "time"
+
+    "github.com/google/uuid"
+)
 
 // TaskAttachment is a domain value object; storage annotations are not included here.
+// CreatedAt is set by the server when the attachment is stored. FileType is
+// the media type detected from the file's content.
 type TaskAttachment struct {
-    ID        string
-    TaskID    string
-    URL       string
-    FileType  string
-    CreatedAt time.Time
+    ID        string    `json:"id"`
+    TenantID  string    `json:"tenantId"`
+    TaskID    string    `json:"taskId"`
+    Name      string    `json:"name,omitempty"`
+    URL       string    `json:"url"`
+    FileType  string    `json:"fileType"`
+    Size      int64     `json:"size,omitempty"`
+    CreatedAt time.Time `json:"createdAt"`
+}
+
+func NewAttachment(tenantID, taskID, name, url, fileType string, size int64) *TaskAttachment {
+    return &TaskAttachment{
+        ID:        uuid.NewString(),
+        TenantID:  tenantID,
+        TaskID:    taskID,
+        Name:      name,
+        URL:       url,
+        FileType:  fileType,
+        Size:      size,
+        CreatedAt: time.Now().UTC(),
+    }
 }
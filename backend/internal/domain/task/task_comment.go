@@ -1,12 +1,48 @@
 package task
 
-import "time"
+import (
+    "strings"
+    "time"
+
+    "github.com/google/uuid"
+)
+
+// MaxCommentLength bounds a comment's content, counted in runes.
+const MaxCommentLength = 5000
 
 // TaskComment is a domain value object; storage annotations are not included here.
+// CreatedAt and EditedAt are set by the comment service, in UTC, and never
+// taken from the client. Internal comments are notes for admins, hidden from
+// other callers.
 type TaskComment struct {
-    ID        string
-    TaskID    string
-    Content   string
-    Author    string
-    CreatedAt time.Time
+    ID        string    `json:"id"`
+    TenantID  string    `json:"tenantId"`
+    TaskID    string    `json:"taskId"`
+    Content   string    `json:"content"`
+    Author    string    `json:"author"`
+    Internal  bool      `json:"internal"`
+    CreatedAt time.Time `json:"createdAt"`
+    // EditedAt is set when the content is changed after creation.
+    EditedAt *time.Time `json:"editedAt,omitempty"`
+}
+
+func NewComment(tenantID, taskID, author, content string, internal bool) *TaskComment {
+    return &TaskComment{
+        ID:        uuid.NewString(),
+        TenantID:  tenantID,
+        TaskID:    taskID,
+        Content:   content,
+        Author:    author,
+        Internal:  internal,
+        CreatedAt: time.Now().UTC(),
+    }
+}
+
+// ValidateCommentContent checks that s is non-blank and at most
+// MaxCommentLength runes.
+func ValidateCommentContent(s string) error {
+    if strings.TrimSpace(s) == "" {
+        return &FieldError{Field: "content", Err: ErrRequired}
+    }
+    return validateLength("content", s, MaxCommentLength)
 }
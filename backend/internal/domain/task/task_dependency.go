@@ -0,0 +1,21 @@
+package task
+
+import "time"
+
+// TaskDependency records that a task cannot be finished before another:
+// DependsOnID blocks TaskID.
+type TaskDependency struct {
+    TenantID    string    `json:"tenantId"`
+    TaskID      string    `json:"taskId"`
+    DependsOnID string    `json:"dependsOnId"`
+    CreatedAt   time.Time `json:"createdAt"`
+}
+
+func NewDependency(tenantID, taskID, dependsOnID string) *TaskDependency {
+    return &TaskDependency{
+        TenantID:    tenantID,
+        TaskID:      taskID,
+        DependsOnID: dependsOnID,
+        CreatedAt:   time.Now().UTC(),
+    }
+}
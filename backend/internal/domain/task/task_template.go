@@ -0,0 +1,59 @@
+package task
+
+import (
+    "strings"
+    "time"
+
+    "github.com/google/uuid"
+)
+
+// MaxTemplateNameLength bounds a template's name, counted in runes.
+const MaxTemplateNameLength = 100
+
+// TemplateDatePlaceholder is replaced by the creation date, as YYYY-MM-DD,
+// in the title of a task created from a template.
+const TemplateDatePlaceholder = "{date}"
+
+// TaskTemplate is the reusable shape of tasks a team creates repeatedly,
+// such as a "Bug report" with a checklist description. A zero Priority
+// leaves tasks at the default priority.
+type TaskTemplate struct {
+    ID           string    `json:"id"`
+    TenantID     string    `json:"tenantId"`
+    Name         string    `json:"name"`
+    TitlePattern string    `json:"titlePattern"`
+    Description  string    `json:"description,omitempty"`
+    Priority     int       `json:"priority,omitempty"`
+    Tags         []string  `json:"tags,omitempty"`
+    CreatedAt    time.Time `json:"createdAt"`
+    UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+func NewTemplate(tenantID, name, titlePattern, description string, priority int, tags []string) *TaskTemplate {
+    now := time.Now().UTC()
+    return &TaskTemplate{
+        ID:           uuid.NewString(),
+        TenantID:     tenantID,
+        Name:         name,
+        TitlePattern: titlePattern,
+        Description:  description,
+        Priority:     priority,
+        Tags:         tags,
+        CreatedAt:    now,
+        UpdatedAt:    now,
+    }
+}
+
+// Title returns the title of a task created from the template on now's day.
+func (t TaskTemplate) Title(now time.Time) string {
+    return strings.ReplaceAll(t.TitlePattern, TemplateDatePlaceholder, now.Format(time.DateOnly))
+}
+
+// ValidateTemplateName checks that s is non-blank and at most
+// MaxTemplateNameLength runes.
+func ValidateTemplateName(s string) error {
+    if strings.TrimSpace(s) == "" {
+        return &FieldError{Field: "name", Err: ErrRequired}
+    }
+    return validateLength("name", s, MaxTemplateNameLength)
+}
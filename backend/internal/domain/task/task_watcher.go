@@ -0,0 +1,20 @@
+package task
+
+import "time"
+
+// TaskWatcher records that a user wants to hear about changes to a task.
+type TaskWatcher struct {
+    TaskID    string    `json:"taskId"`
+    UserID    string    `json:"userId"`
+    TenantID  string    `json:"tenantId"`
+    CreatedAt time.Time `json:"createdAt"`
+}
+
+func NewWatcher(tenantID, taskID, userID string) *TaskWatcher {
+    return &TaskWatcher{
+        TaskID:    taskID,
+        UserID:    userID,
+        TenantID:  tenantID,
+        CreatedAt: time.Now().UTC(),
+    }
+}
@@ -0,0 +1,21 @@
+package task
+
+import "time"
+
+// Timeline entry types.
+const (
+    TimelineCreated    = "created"
+    TimelineComment    = "comment"
+    TimelineAttachment = "attachment"
+)
+
+// TimelineEntry is one item of a task's activity feed. Comment is set on
+// comment entries and Attachment on attachment entries; a created entry
+// carries only the time and the task's creator.
+type TimelineEntry struct {
+    Type       string          `json:"type"`
+    At         time.Time       `json:"at"`
+    ActorID    string          `json:"actorId,omitempty"`
+    Comment    *TaskComment    `json:"comment,omitempty"`
+    Attachment *TaskAttachment `json:"attachment,omitempty"`
+}
@@ -0,0 +1,102 @@
+package task
+
+import (
+    "errors"
+    "fmt"
+    "strings"
+    "unicode/utf8"
+)
+
+// Priority bounds accepted for a task.
+const (
+    MinPriority     = 1
+    MaxPriority     = 10
+    DefaultPriority = 5
+)
+
+// Default length limits for free-text fields, counted in runes.
+// MaxTitleLength matches the varchar(255) column backing the title.
+const (
+    MaxTitleLength       = 255
+    MaxDescriptionLength = 10000
+)
+
+// Limits are the length limits enforced on a task's free-text fields,
+// counted in runes. They are configurable so a widened column can be used.
+type Limits struct {
+    Title       int
+    Description int
+}
+
+// DefaultLimits returns MaxTitleLength and MaxDescriptionLength.
+func DefaultLimits() Limits {
+    return Limits{Title: MaxTitleLength, Description: MaxDescriptionLength}
+}
+
+var (
+    // ErrInvalidPriority is returned when a priority falls outside [MinPriority, MaxPriority].
+    ErrInvalidPriority = errors.New("invalid priority")
+    // ErrInvalidStatus is returned for a status that is not one of the task statuses.
+    ErrInvalidStatus = errors.New("invalid status")
+    // ErrRequired is wrapped by a FieldError when a mandatory field is blank.
+    ErrRequired = errors.New("is required")
+    // ErrTooLong is wrapped by a FieldError when a field exceeds its length limit.
+    ErrTooLong = errors.New("too long")
+)
+
+// FieldError reports which task field failed validation.
+type FieldError struct {
+    Field string
+    Err   error
+}
+
+func (e *FieldError) Error() string { return e.Field + " " + e.Err.Error() }
+
+func (e *FieldError) Unwrap() error { return e.Err }
+
+// ValidatePriority checks that p lies within the accepted priority range.
+func ValidatePriority(p int) error {
+    if p < MinPriority || p > MaxPriority {
+        return fmt.Errorf("%w: must be between %d and %d, got %d", ErrInvalidPriority, MinPriority, MaxPriority, p)
+    }
+    return nil
+}
+
+// ValidateStatus checks that s is one of the task statuses.
+func ValidateStatus(s string) error {
+    switch s {
+    case StatusTodo, StatusInProgress, StatusDone, StatusArchived:
+        return nil
+    }
+    return fmt.Errorf("%w: must be one of %s, %s, %s or %s, got %q", ErrInvalidStatus, StatusTodo, StatusInProgress, StatusDone, StatusArchived, s)
+}
+
+// ValidateTitle checks that s is non-blank and at most MaxTitleLength runes.
+func ValidateTitle(s string) error {
+    return DefaultLimits().ValidateTitle(s)
+}
+
+// ValidateDescription checks that s is at most MaxDescriptionLength runes.
+func ValidateDescription(s string) error {
+    return DefaultLimits().ValidateDescription(s)
+}
+
+// ValidateTitle checks that s is non-blank and at most l.Title runes.
+func (l Limits) ValidateTitle(s string) error {
+    if strings.TrimSpace(s) == "" {
+        return &FieldError{Field: "title", Err: ErrRequired}
+    }
+    return validateLength("title", s, l.Title)
+}
+
+// ValidateDescription checks that s is at most l.Description runes.
+func (l Limits) ValidateDescription(s string) error {
+    return validateLength("description", s, l.Description)
+}
+
+func validateLength(field, s string, max int) error {
+    if n := utf8.RuneCountInString(s); n > max {
+        return &FieldError{Field: field, Err: fmt.Errorf("%w: must be at most %d characters, got %d", ErrTooLong, max, n)}
+    }
+    return nil
+}
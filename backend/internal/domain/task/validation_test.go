@@ -0,0 +1,83 @@
+package task
+
+import (
+    "errors"
+    "strings"
+    "testing"
+)
+
+// Test that ValidatePriority accepts the inclusive range and rejects values
+// on either side of it.
+func TestValidatePriority(t *testing.T) {
+    cases := []struct {
+        priority int
+        valid    bool
+    }{
+        {-1, false},
+        {0, false},
+        {1, true},
+        {5, true},
+        {10, true},
+        {11, false},
+    }
+    for _, tc := range cases {
+        err := ValidatePriority(tc.priority)
+        if tc.valid && err != nil {
+            t.Fatalf("priority %d: unexpected error %v", tc.priority, err)
+        }
+        if !tc.valid && !errors.Is(err, ErrInvalidPriority) {
+            t.Fatalf("priority %d: expected ErrInvalidPriority, got %v", tc.priority, err)
+        }
+    }
+}
+
+// Test that ValidateStatus accepts the task statuses, spelled exactly, and
+// rejects anything else.
+func TestValidateStatus(t *testing.T) {
+    for _, s := range []string{StatusTodo, StatusInProgress, StatusDone, StatusArchived} {
+        if err := ValidateStatus(s); err != nil {
+            t.Fatalf("status %q: unexpected error %v", s, err)
+        }
+    }
+    for _, s := range []string{"", "cancelled", "Done", "in progress"} {
+        if err := ValidateStatus(s); !errors.Is(err, ErrInvalidStatus) {
+            t.Fatalf("status %q: expected ErrInvalidStatus, got %v", s, err)
+        }
+    }
+}
+
+// Test that title length is counted in runes, so a multi-byte title at the
+// limit is accepted and one rune over is rejected.
+func TestValidateTitle(t *testing.T) {
+    if err := ValidateTitle(strings.Repeat("é", MaxTitleLength)); err != nil {
+        t.Fatalf("title at limit: unexpected error %v", err)
+    }
+    err := ValidateTitle(strings.Repeat("é", MaxTitleLength+1))
+    if !errors.Is(err, ErrTooLong) {
+        t.Fatalf("title over limit: expected ErrTooLong, got %v", err)
+    }
+    var fe *FieldError
+    if !errors.As(err, &fe) || fe.Field != "title" {
+        t.Fatalf("expected FieldError for title, got %v", err)
+    }
+    for _, s := range []string{"", "   "} {
+        if err := ValidateTitle(s); !errors.Is(err, ErrRequired) {
+            t.Fatalf("title %q: expected ErrRequired, got %v", s, err)
+        }
+    }
+}
+
+// Test the description limit at and just beyond MaxDescriptionLength.
+func TestValidateDescription(t *testing.T) {
+    if err := ValidateDescription(""); err != nil {
+        t.Fatalf("empty description: unexpected error %v", err)
+    }
+    if err := ValidateDescription(strings.Repeat("日", MaxDescriptionLength)); err != nil {
+        t.Fatalf("description at limit: unexpected error %v", err)
+    }
+    err := ValidateDescription(strings.Repeat("日", MaxDescriptionLength+1))
+    var fe *FieldError
+    if !errors.As(err, &fe) || fe.Field != "description" || !errors.Is(err, ErrTooLong) {
+        t.Fatalf("description over limit: expected FieldError wrapping ErrTooLong, got %v", err)
+    }
+}
@@ -0,0 +1,30 @@
+// Package tenant describes per-tenant configuration.
+package tenant
+
+import (
+    "slices"
+    "time"
+)
+
+// Data residency regions a tenant may be pinned to.
+const (
+    RegionUSEast1      = "us-east-1"
+    RegionEUWest1      = "eu-west-1"
+    RegionAPSoutheast1 = "ap-southeast-1"
+)
+
+// Regions lists the supported data residency regions.
+var Regions = []string{RegionUSEast1, RegionEUWest1, RegionAPSoutheast1}
+
+// ValidRegion reports whether region is one of Regions.
+func ValidRegion(region string) bool {
+    return slices.Contains(Regions, region)
+}
+
+// TenantSettings holds a tenant's configuration. An empty Region means the
+// tenant has no data residency requirement.
+type TenantSettings struct {
+    TenantID  string    `json:"tenantId"`
+    Region    string    `json:"region"`
+    UpdatedAt time.Time `json:"updatedAt"`
+}
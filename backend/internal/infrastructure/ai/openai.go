@@ -0,0 +1,210 @@
+package ai
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "net"
+    "net/http"
+    "strings"
+    "time"
+
+    apphealth "backend/internal/application/health"
+    appprioritize "backend/internal/application/prioritize"
+    apptask "backend/internal/application/task"
+)
+
+// systemPrompt tells the model what to return; the schema mirrors
+// appprioritize.ScoredTask.
+const systemPrompt = `You prioritize work items. For each task in the user's JSON array, ` +
+    `return a score from 0 (ignore) to 100 (do now) and a one-sentence reason. ` +
+    `Respond with JSON only: {"scores":[{"taskId":"...","score":0,"reason":"..."}]}`
+
+// subtasksPrompt asks for a task broken into steps, as JSON.
+const subtasksPrompt = `You plan work. Break the task given as JSON (title and description) into ` +
+    `3 to 8 short, actionable steps, each usable as a task title. ` +
+    `Respond with JSON only: {"steps":["..."]}`
+
+// summarizePrompt asks for a summary short enough for a task card.
+const summarizePrompt = `You summarize work items. Given a task's title and description as JSON, ` +
+    `reply with a plain-text summary of at most 280 characters and nothing else.`
+
+// OpenAIClient scores, summarizes and plans tasks through an
+// OpenAI-compatible chat/completions endpoint.
+type OpenAIClient struct {
+    BaseURL string
+    APIKey  string
+    Model   string
+    HTTP    *http.Client
+}
+
+var (
+    _ appprioritize.AIProvider = (*OpenAIClient)(nil)
+    _ apptask.Summarizer       = (*OpenAIClient)(nil)
+    _ apptask.SubtaskGenerator = (*OpenAIClient)(nil)
+    _ apphealth.Checker        = (*OpenAIClient)(nil)
+)
+
+// NewOpenAIClient returns a client for baseURL (e.g. https://api.openai.com/v1)
+// whose requests give up after timeout.
+func NewOpenAIClient(baseURL, apiKey, model string, timeout time.Duration) *OpenAIClient {
+    return &OpenAIClient{
+        BaseURL: strings.TrimRight(baseURL, "/"),
+        APIKey:  apiKey,
+        Model:   model,
+        HTTP:    &http.Client{Timeout: timeout},
+    }
+}
+
+type chatMessage struct {
+    Role    string `json:"role"`
+    Content string `json:"content"`
+}
+
+type chatRequest struct {
+    Model          string        `json:"model"`
+    Messages       []chatMessage `json:"messages"`
+    Temperature    float64       `json:"temperature"`
+    ResponseFormat struct {
+        Type string `json:"type"`
+    } `json:"response_format"`
+}
+
+type chatResponse struct {
+    Choices []struct {
+        Message chatMessage `json:"message"`
+    } `json:"choices"`
+}
+
+type scoresPayload struct {
+    Scores []appprioritize.ScoredTask `json:"scores"`
+}
+
+// ScoreTasks sends the summaries as a single chat completion and parses the
+// model's JSON answer. Range checks are left to the caller.
+func (c *OpenAIClient) ScoreTasks(ctx context.Context, tasks []appprioritize.TaskSummary) ([]appprioritize.ScoredTask, error) {
+    input, err := json.Marshal(tasks)
+    if err != nil {
+        return nil, err
+    }
+    content, err := c.complete(ctx, systemPrompt, string(input), true)
+    if err != nil {
+        return nil, err
+    }
+    var payload scoresPayload
+    if err := json.Unmarshal([]byte(content), &payload); err != nil {
+        return nil, fmt.Errorf("ai response content: %w", err)
+    }
+    return payload.Scores, nil
+}
+
+// Summarize asks the model for a short plain-text summary of a task. Length
+// checks are left to the caller.
+func (c *OpenAIClient) Summarize(ctx context.Context, title, description string) (string, error) {
+    input, err := json.Marshal(map[string]string{"title": title, "description": description})
+    if err != nil {
+        return "", err
+    }
+    content, err := c.complete(ctx, summarizePrompt, string(input), false)
+    if err != nil {
+        return "", err
+    }
+    return strings.TrimSpace(content), nil
+}
+
+// GenerateSubtasks asks the model to break a task into steps. An answer
+// that is not the expected JSON is reported as an error. Cleaning and
+// capping the steps is left to the caller.
+func (c *OpenAIClient) GenerateSubtasks(ctx context.Context, title, description string) ([]string, error) {
+    input, err := json.Marshal(map[string]string{"title": title, "description": description})
+    if err != nil {
+        return nil, err
+    }
+    content, err := c.complete(ctx, subtasksPrompt, string(input), true)
+    if err != nil {
+        return nil, err
+    }
+    var payload struct {
+        Steps []string `json:"steps"`
+    }
+    if err := json.Unmarshal([]byte(content), &payload); err != nil {
+        return nil, fmt.Errorf("ai response content: %w", err)
+    }
+    if len(payload.Steps) == 0 {
+        return nil, errors.New("ai response content: no steps")
+    }
+    return payload.Steps, nil
+}
+
+// Check lists the provider's models, which costs no tokens, to tell whether
+// the endpoint is reachable and accepts the API key.
+func (c *OpenAIClient) Check(ctx context.Context) error {
+    req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+"/models", nil)
+    if err != nil {
+        return err
+    }
+    req.Header.Set("Authorization", "Bearer "+c.APIKey)
+    resp, err := c.HTTP.Do(req)
+    if err != nil {
+        return fmt.Errorf("ai request: %w", err)
+    }
+    defer resp.Body.Close()
+    if resp.StatusCode != http.StatusOK {
+        return fmt.Errorf("ai request: status %d", resp.StatusCode)
+    }
+    return nil
+}
+
+// complete sends one system and one user message and returns the content of
+// the first choice. jsonOnly asks the model for a JSON object. Timeouts are
+// reported as context.DeadlineExceeded.
+func (c *OpenAIClient) complete(ctx context.Context, system, user string, jsonOnly bool) (string, error) {
+    body := chatRequest{
+        Model: c.Model,
+        Messages: []chatMessage{
+            {Role: "system", Content: system},
+            {Role: "user", Content: user},
+        },
+    }
+    body.ResponseFormat.Type = "text"
+    if jsonOnly {
+        body.ResponseFormat.Type = "json_object"
+    }
+    buf, err := json.Marshal(body)
+    if err != nil {
+        return "", err
+    }
+
+    req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+"/chat/completions", bytes.NewReader(buf))
+    if err != nil {
+        return "", err
+    }
+    req.Header.Set("Content-Type", "application/json")
+    req.Header.Set("Authorization", "Bearer "+c.APIKey)
+
+    resp, err := c.HTTP.Do(req)
+    if err != nil {
+        var ne net.Error
+        if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &ne) && ne.Timeout()) {
+            return "", fmt.Errorf("ai request: %w", context.DeadlineExceeded)
+        }
+        return "", fmt.Errorf("ai request: %w", err)
+    }
+    defer resp.Body.Close()
+    if resp.StatusCode != http.StatusOK {
+        msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+        return "", fmt.Errorf("ai request: status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+    }
+
+    var out chatResponse
+    if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+        return "", fmt.Errorf("ai response: %w", err)
+    }
+    if len(out.Choices) == 0 {
+        return "", errors.New("ai response: no choices")
+    }
+    return out.Choices[0].Message.Content, nil
+}
@@ -0,0 +1,142 @@
+package ai
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+    "time"
+
+    appprioritize "backend/internal/application/prioritize"
+)
+
+// Test that the client posts the tasks with the configured model and key and
+// parses the scores out of the message content.
+func TestOpenAIClient_ScoreTasks(t *testing.T) {
+    srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if r.URL.Path != "/v1/chat/completions" {
+            t.Errorf("unexpected path %s", r.URL.Path)
+        }
+        if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+            t.Errorf("expected bearer key, got %q", got)
+        }
+        var req chatRequest
+        if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+            t.Errorf("decode request: %v", err)
+        }
+        if req.Model != "m1" || len(req.Messages) != 2 {
+            t.Errorf("unexpected request %+v", req)
+        }
+        content := `{"scores":[{"taskId":"a","score":87.5,"reason":"blocks release"}]}`
+        json.NewEncoder(w).Encode(map[string]any{
+            "choices": []any{map[string]any{"message": map[string]string{"role": "assistant", "content": content}}},
+        })
+    }))
+    defer srv.Close()
+
+    c := NewOpenAIClient(srv.URL+"/v1/", "secret", "m1", time.Second)
+    got, err := c.ScoreTasks(context.Background(), []appprioritize.TaskSummary{{ID: "a", Title: "ship"}})
+    if err != nil {
+        t.Fatalf("ScoreTasks: %v", err)
+    }
+    if len(got) != 1 || got[0].TaskID != "a" || got[0].Score != 87.5 || got[0].Reason != "blocks release" {
+        t.Fatalf("unexpected scores %+v", got)
+    }
+}
+
+// Test that a non-200 answer is reported as an error.
+func TestOpenAIClient_ScoreTasks_HTTPError(t *testing.T) {
+    srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        http.Error(w, "rate limited", http.StatusTooManyRequests)
+    }))
+    defer srv.Close()
+
+    c := NewOpenAIClient(srv.URL, "k", "m", time.Second)
+    if _, err := c.ScoreTasks(context.Background(), []appprioritize.TaskSummary{{ID: "a"}}); err == nil {
+        t.Fatalf("expected error for status 429")
+    }
+}
+
+// Test that Check lists the models with the API key and reports a rejected
+// key as an error.
+func TestOpenAIClient_Check(t *testing.T) {
+    srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if r.Method != http.MethodGet || r.URL.Path != "/models" {
+            t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+        }
+        if r.Header.Get("Authorization") != "Bearer good" {
+            http.Error(w, "bad key", http.StatusUnauthorized)
+            return
+        }
+        w.Write([]byte(`{"data":[]}`))
+    }))
+    defer srv.Close()
+
+    if err := NewOpenAIClient(srv.URL, "good", "m", time.Second).Check(context.Background()); err != nil {
+        t.Fatalf("check: %v", err)
+    }
+    if err := NewOpenAIClient(srv.URL, "bad", "m", time.Second).Check(context.Background()); err == nil {
+        t.Fatalf("expected error for status 401")
+    }
+}
+
+// Test that Summarize returns the trimmed message content and reports a
+// client timeout as context.DeadlineExceeded.
+func TestOpenAIClient_Summarize(t *testing.T) {
+    block := make(chan struct{})
+    srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if strings.HasPrefix(r.URL.Path, "/slow/") {
+            <-block
+            return
+        }
+        json.NewEncoder(w).Encode(map[string]any{
+            "choices": []any{map[string]any{"message": map[string]string{"role": "assistant", "content": "  Ship the release.\n"}}},
+        })
+    }))
+    defer srv.Close()
+    defer close(block)
+
+    got, err := NewOpenAIClient(srv.URL, "k", "m", time.Second).Summarize(context.Background(), "ship", "long text")
+    if err != nil {
+        t.Fatalf("Summarize: %v", err)
+    }
+    if got != "Ship the release." {
+        t.Fatalf("expected trimmed summary, got %q", got)
+    }
+
+    slow := NewOpenAIClient(srv.URL+"/slow", "k", "m", 50*time.Millisecond)
+    if _, err := slow.Summarize(context.Background(), "ship", ""); !errors.Is(err, context.DeadlineExceeded) {
+        t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+    }
+}
+
+// Test that GenerateSubtasks returns the steps of a well-formed answer and
+// an error, not a panic, for malformed or empty ones.
+func TestOpenAIClient_GenerateSubtasks(t *testing.T) {
+    contents := map[string]string{
+        "/ok":    `{"steps":["write notes","tag build"]}`,
+        "/bad":   `{"steps":"write notes"`,
+        "/wrong": `{"steps":[1,2]}`,
+        "/empty": `{"steps":[]}`,
+    }
+    srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        content := contents[strings.TrimSuffix(r.URL.Path, "/chat/completions")]
+        json.NewEncoder(w).Encode(map[string]any{
+            "choices": []any{map[string]any{"message": map[string]string{"role": "assistant", "content": content}}},
+        })
+    }))
+    defer srv.Close()
+
+    got, err := NewOpenAIClient(srv.URL+"/ok", "k", "m", time.Second).GenerateSubtasks(context.Background(), "ship", "")
+    if err != nil || len(got) != 2 || got[0] != "write notes" {
+        t.Fatalf("expected 2 steps, got %q (%v)", got, err)
+    }
+    for _, path := range []string{"/bad", "/wrong", "/empty"} {
+        if _, err := NewOpenAIClient(srv.URL+path, "k", "m", time.Second).GenerateSubtasks(context.Background(), "ship", ""); err == nil {
+            t.Fatalf("%s: expected an error", path)
+        }
+    }
+}
@@ -0,0 +1,26 @@
+package auth
+
+import (
+    "context"
+
+    appapikey "backend/internal/application/apikey"
+    "backend/internal/pkg/identity"
+)
+
+// APIKeyAuthService verifies tenant API keys. The returned user is the one
+// the key acts as (see APIKey.ActingUserID), with the service role.
+type APIKeyAuthService struct {
+    keys *appapikey.Service
+}
+
+func NewAPIKeyAuthService(keys *appapikey.Service) APIKeyAuthService {
+    return APIKeyAuthService{keys: keys}
+}
+
+func (s APIKeyAuthService) VerifyToken(token string) (identity.Claims, error) {
+    k, err := s.keys.Authenticate(context.Background(), token)
+    if err != nil {
+        return identity.Claims{}, err
+    }
+    return identity.Claims{UserID: k.ActingUserID(), TenantID: k.TenantID, Roles: []string{identity.RoleService}}, nil
+}
@@ -0,0 +1,40 @@
+package auth
+
+import (
+    "errors"
+
+    "backend/internal/pkg/identity"
+)
+
+// TokenVerifier is implemented by every service in this package.
+type TokenVerifier interface {
+    VerifyToken(token string) (identity.Claims, error)
+}
+
+// ChainAuthService tries each of its services in turn and returns the claims
+// of the first that accepts the token, so JWTs and API keys can share one
+// Authorization header.
+type ChainAuthService struct {
+    services []TokenVerifier
+}
+
+func NewChainAuthService(services ...TokenVerifier) ChainAuthService {
+    return ChainAuthService{services: services}
+}
+
+// VerifyToken returns the first service's claims that verify token, or all
+// their errors joined.
+func (s ChainAuthService) VerifyToken(token string) (identity.Claims, error) {
+    errs := make([]error, 0, len(s.services))
+    for _, svc := range s.services {
+        claims, err := svc.VerifyToken(token)
+        if err == nil {
+            return claims, nil
+        }
+        errs = append(errs, err)
+    }
+    if len(errs) == 0 {
+        return identity.Claims{}, errors.New("no auth service configured")
+    }
+    return identity.Claims{}, errors.Join(errs...)
+}
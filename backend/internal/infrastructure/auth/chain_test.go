@@ -0,0 +1,49 @@
+package auth
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    appapikey "backend/internal/application/apikey"
+    "backend/internal/infrastructure/memory"
+    "backend/internal/pkg/identity"
+)
+
+// Test that a chain of JWT then API key verification accepts either
+// credential, and that a revoked key fails at once.
+func TestChainAuthService(t *testing.T) {
+    ctx := context.Background()
+    jwt := newTestJWTService("secret")
+    keys := appapikey.NewService(memory.NewAPIKeyRepository())
+    s := NewChainAuthService(jwt, NewAPIKeyAuthService(keys))
+
+    token, err := jwt.Mint("u7", "t3", time.Hour)
+    if err != nil {
+        t.Fatalf("mint token: %v", err)
+    }
+    if claims, err := s.VerifyToken(token); err != nil || claims.UserID != "u7" {
+        t.Fatalf("expected the JWT to verify as u7, got %+v (%v)", claims, err)
+    }
+
+    k, secret, err := keys.Mint(ctx, "t1", "u2", "ci")
+    if err != nil {
+        t.Fatalf("mint key: %v", err)
+    }
+    claims, err := s.VerifyToken(secret)
+    if err != nil {
+        t.Fatalf("verify key: %v", err)
+    }
+    if claims.UserID != "u2" || claims.TenantID != "t1" || !claims.HasRole(identity.RoleService) {
+        t.Fatalf("expected service u2 in t1, got %+v", claims)
+    }
+
+    if err := keys.Revoke(ctx, "t1", k.ID); err != nil {
+        t.Fatalf("revoke: %v", err)
+    }
+    if _, err := s.VerifyToken(secret); !errors.Is(err, appapikey.ErrRevoked) {
+        t.Fatalf("expected ErrRevoked, got %v", err)
+    }
+    keys.Wait()
+}
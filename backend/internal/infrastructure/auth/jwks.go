@@ -0,0 +1,345 @@
+package auth
+
+import (
+    "context"
+    "crypto"
+    "crypto/rsa"
+    "crypto/sha256"
+    "encoding/base64"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "log/slog"
+    "math/big"
+    "net/http"
+    "strings"
+    "sync"
+    "time"
+
+    "backend/internal/pkg/identity"
+)
+
+// Errors returned by JWKSService besides the ErrToken* ones it shares with
+// JWTService. All but ErrKeysUnavailable are identity.ErrTokenInvalid too.
+var (
+    // ErrTokenIssuer is returned for a token whose iss is not the configured
+    // issuer.
+    ErrTokenIssuer = fmt.Errorf("%w: unexpected issuer", identity.ErrTokenInvalid)
+    // ErrTokenAudience is returned for a token whose aud does not include
+    // the configured audience.
+    ErrTokenAudience = fmt.Errorf("%w: unexpected audience", identity.ErrTokenInvalid)
+    // ErrUnknownKey is returned for a token whose kid is not in the key set,
+    // even after refetching it.
+    ErrUnknownKey = fmt.Errorf("%w: unknown signing key", identity.ErrTokenInvalid)
+    // ErrKeysUnavailable is returned while no key set could be fetched, or
+    // the cached one has outlived KeyTTL.
+    ErrKeysUnavailable = errors.New("signing keys unavailable")
+)
+
+// Defaults for the JWKSConfig durations.
+const (
+    DefaultJWKSRefreshInterval = 15 * time.Minute
+    DefaultJWKSKeyTTL          = 24 * time.Hour
+    // DefaultJWKSMinRefetch spaces out the refetches an unknown kid
+    // triggers, so forged kids cannot hammer the identity provider.
+    DefaultJWKSMinRefetch = 30 * time.Second
+    // DefaultTenantClaim is the claim read as the tenant when none is set.
+    DefaultTenantClaim = "tenant_id"
+)
+
+// JWKSConfig configures a JWKSService. Zero durations take the defaults
+// above; a nil HTTPClient gets one with a 10s timeout.
+type JWKSConfig struct {
+    // URL is the identity provider's JSON Web Key Set.
+    URL string
+    // Issuer must equal the token's iss, and Audience be one of its aud;
+    // either check is skipped when empty.
+    Issuer   string
+    Audience string
+    // TenantClaim names the string claim holding the tenant ID.
+    TenantClaim string
+    // RefreshInterval is how often Run refetches the key set.
+    RefreshInterval time.Duration
+    // KeyTTL is how long a fetched key set keeps being used while refetches
+    // fail.
+    KeyTTL time.Duration
+    // MinRefetch is the shortest gap between fetches an unknown kid
+    // triggers.
+    MinRefetch time.Duration
+    // Leeway is how far exp and nbf may be off; ClockSkew when zero, and
+    // none when negative.
+    Leeway     time.Duration
+    HTTPClient *http.Client
+    Logger     *slog.Logger
+    // Now is the clock tokens and key ages are checked against; time.Now
+    // when nil.
+    Now func() time.Time
+}
+
+// JWKSService verifies RS256 tokens issued by an external identity provider
+// against the keys it publishes as a JWKS. Keys are looked up by the token's
+// kid and refetched in the background by Run, and on demand when a token
+// names a kid not seen yet, so rotated keys are picked up without a restart.
+// A failed refetch keeps the cached keys until they are KeyTTL old. The
+// token's sub becomes the user; every token user has the member role.
+type JWKSService struct {
+    cfg JWKSConfig
+    now func() time.Time
+
+    mu        sync.RWMutex
+    keys      map[string]*rsa.PublicKey
+    fetchedAt time.Time
+    // fetching serializes fetches; lastAttempt is guarded by it.
+    fetching    sync.Mutex
+    lastAttempt time.Time
+}
+
+func NewJWKSService(cfg JWKSConfig) *JWKSService {
+    if cfg.TenantClaim == "" {
+        cfg.TenantClaim = DefaultTenantClaim
+    }
+    if cfg.RefreshInterval <= 0 {
+        cfg.RefreshInterval = DefaultJWKSRefreshInterval
+    }
+    if cfg.KeyTTL <= 0 {
+        cfg.KeyTTL = DefaultJWKSKeyTTL
+    }
+    if cfg.MinRefetch <= 0 {
+        cfg.MinRefetch = DefaultJWKSMinRefetch
+    }
+    switch {
+    case cfg.Leeway == 0:
+        cfg.Leeway = ClockSkew
+    case cfg.Leeway < 0:
+        cfg.Leeway = 0
+    }
+    if cfg.HTTPClient == nil {
+        cfg.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+    }
+    if cfg.Now == nil {
+        cfg.Now = time.Now
+    }
+    if cfg.Logger == nil {
+        cfg.Logger = slog.Default()
+    }
+    return &JWKSService{cfg: cfg, now: cfg.Now}
+}
+
+// Run refetches the key set every RefreshInterval until ctx is done.
+// Failures are logged and the cached keys stay in use.
+func (s *JWKSService) Run(ctx context.Context) {
+    ticker := time.NewTicker(s.cfg.RefreshInterval)
+    defer ticker.Stop()
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+            if err := s.Refresh(ctx); err != nil {
+                s.cfg.Logger.WarnContext(ctx, "jwks refresh failed, keeping cached keys", "url", s.cfg.URL, "error", err)
+            }
+        }
+    }
+}
+
+// Refresh fetches the key set and replaces the cached one. On error the
+// cached keys are left as they were.
+func (s *JWKSService) Refresh(ctx context.Context) error {
+    s.fetching.Lock()
+    defer s.fetching.Unlock()
+    return s.fetch(ctx)
+}
+
+// fetch must be called with s.fetching held.
+func (s *JWKSService) fetch(ctx context.Context) error {
+    s.lastAttempt = s.now()
+    req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.cfg.URL, nil)
+    if err != nil {
+        return err
+    }
+    req.Header.Set("Accept", "application/json")
+    resp, err := s.cfg.HTTPClient.Do(req)
+    if err != nil {
+        return err
+    }
+    defer resp.Body.Close()
+    if resp.StatusCode != http.StatusOK {
+        return fmt.Errorf("jwks: unexpected status %d", resp.StatusCode)
+    }
+    var set struct {
+        Keys []jwk `json:"keys"`
+    }
+    if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+        return fmt.Errorf("jwks: %w", err)
+    }
+    keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+    for _, k := range set.Keys {
+        pub, ok := k.rsaKey()
+        if !ok {
+            continue
+        }
+        keys[k.Kid] = pub
+    }
+    if len(keys) == 0 {
+        return errors.New("jwks: no usable RS256 keys")
+    }
+    s.mu.Lock()
+    s.keys, s.fetchedAt = keys, s.now()
+    s.mu.Unlock()
+    return nil
+}
+
+// key returns the cached key for kid. A kid not in the cache, or no usable
+// cache at all, triggers one refetch, at most every MinRefetch.
+func (s *JWKSService) key(kid string) (*rsa.PublicKey, error) {
+    if pub, err := s.cachedKey(kid); err == nil {
+        return pub, nil
+    }
+    s.fetching.Lock()
+    defer s.fetching.Unlock()
+    // Another caller may have fetched while this one waited.
+    if pub, err := s.cachedKey(kid); err == nil {
+        return pub, nil
+    }
+    if s.now().Sub(s.lastAttempt) >= s.cfg.MinRefetch {
+        if err := s.fetch(context.Background()); err != nil {
+            s.cfg.Logger.Warn("jwks refetch failed", "url", s.cfg.URL, "kid", kid, "error", err)
+        }
+    }
+    return s.cachedKey(kid)
+}
+
+func (s *JWKSService) cachedKey(kid string) (*rsa.PublicKey, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+    if s.keys == nil || s.now().Sub(s.fetchedAt) > s.cfg.KeyTTL {
+        return nil, ErrKeysUnavailable
+    }
+    pub, ok := s.keys[kid]
+    if !ok {
+        return nil, fmt.Errorf("%w: %q", ErrUnknownKey, kid)
+    }
+    return pub, nil
+}
+
+type jwksHeader struct {
+    Alg string `json:"alg"`
+    Kid string `json:"kid"`
+}
+
+type jwksClaims struct {
+    Subject   string   `json:"sub"`
+    Issuer    string   `json:"iss"`
+    Audience  audience `json:"aud"`
+    ExpiresAt int64    `json:"exp"`
+    NotBefore int64    `json:"nbf"`
+}
+
+// VerifyToken checks an RS256 token against the key set and returns its
+// claims. Tokens must carry sub, exp and the tenant claim; nbf is optional.
+func (s *JWKSService) VerifyToken(token string) (identity.Claims, error) {
+    parts := strings.Split(token, ".")
+    if len(parts) != 3 {
+        return identity.Claims{}, fmt.Errorf("%w: expected 3 segments, got %d", ErrTokenMalformed, len(parts))
+    }
+    var header jwksHeader
+    if err := decodeSegment(parts[0], &header); err != nil {
+        return identity.Claims{}, fmt.Errorf("%w: header: %v", ErrTokenMalformed, err)
+    }
+    if header.Alg != "RS256" {
+        return identity.Claims{}, fmt.Errorf("%w: unsupported alg %q", ErrTokenSignature, header.Alg)
+    }
+    sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+    if err != nil {
+        return identity.Claims{}, fmt.Errorf("%w: signature: %v", ErrTokenMalformed, err)
+    }
+    pub, err := s.key(header.Kid)
+    if err != nil {
+        return identity.Claims{}, err
+    }
+    digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+    if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig); err != nil {
+        return identity.Claims{}, ErrTokenSignature
+    }
+
+    var claims jwksClaims
+    if err := decodeSegment(parts[1], &claims); err != nil {
+        return identity.Claims{}, fmt.Errorf("%w: claims: %v", ErrTokenMalformed, err)
+    }
+    var extra map[string]any
+    if err := decodeSegment(parts[1], &extra); err != nil {
+        return identity.Claims{}, fmt.Errorf("%w: claims: %v", ErrTokenMalformed, err)
+    }
+    tenantID, _ := extra[s.cfg.TenantClaim].(string)
+    switch {
+    case claims.Subject == "":
+        return identity.Claims{}, fmt.Errorf("%w: missing sub", ErrTokenMalformed)
+    case tenantID == "":
+        return identity.Claims{}, fmt.Errorf("%w: missing %s", ErrTokenMalformed, s.cfg.TenantClaim)
+    case claims.ExpiresAt == 0:
+        return identity.Claims{}, fmt.Errorf("%w: missing exp", ErrTokenMalformed)
+    case s.cfg.Issuer != "" && claims.Issuer != s.cfg.Issuer:
+        return identity.Claims{}, fmt.Errorf("%w: %q", ErrTokenIssuer, claims.Issuer)
+    case s.cfg.Audience != "" && !claims.Audience.contains(s.cfg.Audience):
+        return identity.Claims{}, ErrTokenAudience
+    }
+    if err := checkTimes(s.now(), s.cfg.Leeway, claims.ExpiresAt, claims.NotBefore); err != nil {
+        return identity.Claims{}, err
+    }
+    return identity.Claims{UserID: claims.Subject, TenantID: tenantID, Roles: []string{identity.RoleMember}}, nil
+}
+
+// audience is the aud claim, which may be a single string or an array.
+type audience []string
+
+func (a *audience) UnmarshalJSON(b []byte) error {
+    var one string
+    if err := json.Unmarshal(b, &one); err == nil {
+        *a = audience{one}
+        return nil
+    }
+    var many []string
+    if err := json.Unmarshal(b, &many); err != nil {
+        return err
+    }
+    *a = many
+    return nil
+}
+
+func (a audience) contains(want string) bool {
+    for _, v := range a {
+        if v == want {
+            return true
+        }
+    }
+    return false
+}
+
+// jwk is one entry of a JSON Web Key Set; only RSA signing keys are used.
+type jwk struct {
+    Kty string `json:"kty"`
+    Kid string `json:"kid"`
+    Use string `json:"use"`
+    Alg string `json:"alg"`
+    N   string `json:"n"`
+    E   string `json:"e"`
+}
+
+func (k jwk) rsaKey() (*rsa.PublicKey, bool) {
+    if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") || (k.Alg != "" && k.Alg != "RS256") {
+        return nil, false
+    }
+    n, err := base64.RawURLEncoding.DecodeString(k.N)
+    if err != nil || len(n) == 0 {
+        return nil, false
+    }
+    e, err := base64.RawURLEncoding.DecodeString(k.E)
+    if err != nil || len(e) == 0 || len(e) > 4 {
+        return nil, false
+    }
+    exp := int(new(big.Int).SetBytes(e).Int64())
+    if exp < 3 {
+        return nil, false
+    }
+    return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: exp}, true
+}
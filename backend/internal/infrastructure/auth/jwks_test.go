@@ -0,0 +1,280 @@
+package auth
+
+import (
+    "context"
+    "crypto"
+    "crypto/rand"
+    "crypto/rsa"
+    "crypto/sha256"
+    "encoding/base64"
+    "encoding/json"
+    "errors"
+    "math/big"
+    "net/http"
+    "net/http/httptest"
+    "sync"
+    "testing"
+    "time"
+)
+
+// testIdP serves a JWKS and signs RS256 tokens with its keys. fail makes
+// the JWKS endpoint answer 500.
+type testIdP struct {
+    t      *testing.T
+    mu     sync.Mutex
+    keys   map[string]*rsa.PrivateKey
+    fail   bool
+    hits   int
+    server *httptest.Server
+}
+
+func newTestIdP(t *testing.T, kids ...string) *testIdP {
+    idp := &testIdP{t: t, keys: map[string]*rsa.PrivateKey{}}
+    for _, kid := range kids {
+        idp.addKey(kid)
+    }
+    idp.server = httptest.NewServer(http.HandlerFunc(idp.serveJWKS))
+    t.Cleanup(idp.server.Close)
+    return idp
+}
+
+func (idp *testIdP) addKey(kid string) {
+    key, err := rsa.GenerateKey(rand.Reader, 2048)
+    if err != nil {
+        idp.t.Fatalf("generate key: %v", err)
+    }
+    idp.mu.Lock()
+    idp.keys[kid] = key
+    idp.mu.Unlock()
+}
+
+func (idp *testIdP) setKeys(kids ...string) {
+    idp.mu.Lock()
+    defer idp.mu.Unlock()
+    keep := map[string]*rsa.PrivateKey{}
+    for _, kid := range kids {
+        keep[kid] = idp.keys[kid]
+    }
+    idp.keys = keep
+}
+
+func (idp *testIdP) setFail(fail bool) {
+    idp.mu.Lock()
+    idp.fail = fail
+    idp.mu.Unlock()
+}
+
+func (idp *testIdP) serveJWKS(w http.ResponseWriter, _ *http.Request) {
+    idp.mu.Lock()
+    defer idp.mu.Unlock()
+    idp.hits++
+    if idp.fail {
+        w.WriteHeader(http.StatusInternalServerError)
+        return
+    }
+    set := struct {
+        Keys []jwk `json:"keys"`
+    }{}
+    for kid, key := range idp.keys {
+        set.Keys = append(set.Keys, jwk{
+            Kty: "RSA", Kid: kid, Use: "sig", Alg: "RS256",
+            N: base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+            E: base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+        })
+    }
+    _ = json.NewEncoder(w).Encode(set)
+}
+
+func (idp *testIdP) sign(kid string, claims map[string]any) string {
+    idp.mu.Lock()
+    key := idp.keys[kid]
+    idp.mu.Unlock()
+    if key == nil {
+        idp.t.Fatalf("no key %q", kid)
+    }
+    header, _ := json.Marshal(jwksHeader{Alg: "RS256", Kid: kid})
+    payload, _ := json.Marshal(claims)
+    signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+    digest := sha256.Sum256([]byte(signed))
+    sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+    if err != nil {
+        idp.t.Fatalf("sign: %v", err)
+    }
+    return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+// clock is a settable time source for JWKSConfig.Now.
+type clock struct {
+    mu  sync.Mutex
+    now time.Time
+}
+
+func (c *clock) Now() time.Time {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+    c.mu.Lock()
+    c.now = c.now.Add(d)
+    c.mu.Unlock()
+}
+
+func newTestJWKSService(idp *testIdP, clk *clock) *JWKSService {
+    return NewJWKSService(JWKSConfig{
+        URL:         idp.server.URL,
+        Issuer:      "https://idp.example.com/",
+        Audience:    "mauflow",
+        TenantClaim: "org_id",
+        KeyTTL:      time.Hour,
+        MinRefetch:  time.Minute,
+        Now:         clk.Now,
+    })
+}
+
+func validClaims(overrides map[string]any) map[string]any {
+    c := map[string]any{
+        "sub":    "u7",
+        "org_id": "t3",
+        "iss":    "https://idp.example.com/",
+        "aud":    []string{"other", "mauflow"},
+        "exp":    testNow.Add(time.Hour).Unix(),
+    }
+    for k, v := range overrides {
+        if v == nil {
+            delete(c, k)
+            continue
+        }
+        c[k] = v
+    }
+    return c
+}
+
+// Test that an RS256 token signed by a published key verifies, with the
+// tenant read from the configured claim.
+func TestJWKSService_VerifyToken_Valid(t *testing.T) {
+    idp := newTestIdP(t, "k1")
+    s := newTestJWKSService(idp, &clock{now: testNow})
+
+    claims, err := s.VerifyToken(idp.sign("k1", validClaims(map[string]any{"aud": "mauflow"})))
+    if err != nil {
+        t.Fatalf("verify: %v", err)
+    }
+    if claims.UserID != "u7" || claims.TenantID != "t3" {
+        t.Fatalf("expected u7 in t3, got %+v", claims)
+    }
+}
+
+// Test that tokens with the wrong issuer, audience, times, key or claims are
+// refused with their own errors.
+func TestJWKSService_VerifyToken_Rejects(t *testing.T) {
+    idp := newTestIdP(t, "k1")
+    s := newTestJWKSService(idp, &clock{now: testNow})
+    other := newTestIdP(t, "k1")
+    hs, _ := newTestJWTService("secret").Mint("u7", "t3", time.Hour)
+
+    for _, tc := range []struct {
+        name  string
+        token string
+        want  error
+    }{
+        {"malformed", "abc", ErrTokenMalformed},
+        {"hs256", hs, ErrTokenSignature},
+        {"other key same kid", other.sign("k1", validClaims(nil)), ErrTokenSignature},
+        {"unknown kid", signWithKid(t, other, "k9"), ErrUnknownKey},
+        {"issuer", idp.sign("k1", validClaims(map[string]any{"iss": "https://evil.example.com/"})), ErrTokenIssuer},
+        {"audience", idp.sign("k1", validClaims(map[string]any{"aud": "other"})), ErrTokenAudience},
+        {"no tenant", idp.sign("k1", validClaims(map[string]any{"org_id": nil})), ErrTokenMalformed},
+        {"no exp", idp.sign("k1", validClaims(map[string]any{"exp": nil})), ErrTokenMalformed},
+        {"expired", idp.sign("k1", validClaims(map[string]any{"exp": testNow.Add(-time.Minute).Unix()})), ErrTokenExpired},
+        {"not yet valid", idp.sign("k1", validClaims(map[string]any{"nbf": testNow.Add(time.Minute).Unix()})), ErrTokenNotYetValid},
+    } {
+        if _, err := s.VerifyToken(tc.token); !errors.Is(err, tc.want) {
+            t.Fatalf("%s: expected %v, got %v", tc.name, tc.want, err)
+        }
+    }
+}
+
+// signWithKid adds a key kid to idp and signs valid claims with it.
+func signWithKid(t *testing.T, idp *testIdP, kid string) string {
+    idp.addKey(kid)
+    return idp.sign(kid, validClaims(nil))
+}
+
+// Test that a token signed with a newly rotated-in key verifies without a
+// restart, and that a retired key stops verifying after the next refresh.
+func TestJWKSService_KeyRotation(t *testing.T) {
+    idp := newTestIdP(t, "k1")
+    clk := &clock{now: testNow}
+    s := newTestJWKSService(idp, clk)
+    old := idp.sign("k1", validClaims(nil))
+    if _, err := s.VerifyToken(old); err != nil {
+        t.Fatalf("verify with k1: %v", err)
+    }
+
+    idp.addKey("k2")
+    idp.setKeys("k2")
+    clk.Advance(2 * time.Minute)
+    if _, err := s.VerifyToken(idp.sign("k2", validClaims(nil))); err != nil {
+        t.Fatalf("expected the rotated-in key to verify, got %v", err)
+    }
+    if _, err := s.VerifyToken(old); !errors.Is(err, ErrUnknownKey) {
+        t.Fatalf("expected ErrUnknownKey for the retired key, got %v", err)
+    }
+}
+
+// Test that unknown kids do not refetch the key set more than once per
+// MinRefetch.
+func TestJWKSService_UnknownKidThrottled(t *testing.T) {
+    idp := newTestIdP(t, "k1")
+    s := newTestJWKSService(idp, &clock{now: testNow})
+    if _, err := s.VerifyToken(idp.sign("k1", validClaims(nil))); err != nil {
+        t.Fatalf("verify: %v", err)
+    }
+    forged := signWithKid(t, newTestIdP(t), "k9")
+    for i := 0; i < 5; i++ {
+        if _, err := s.VerifyToken(forged); !errors.Is(err, ErrUnknownKey) {
+            t.Fatalf("expected ErrUnknownKey, got %v", err)
+        }
+    }
+    idp.mu.Lock()
+    hits := idp.hits
+    idp.mu.Unlock()
+    if hits != 1 {
+        t.Fatalf("expected 1 JWKS fetch, got %d", hits)
+    }
+}
+
+// Test that failed refreshes keep the cached keys in use until they are
+// KeyTTL old.
+func TestJWKSService_RefreshFailureKeepsKeys(t *testing.T) {
+    idp := newTestIdP(t, "k1")
+    clk := &clock{now: testNow}
+    s := newTestJWKSService(idp, clk)
+    if err := s.Refresh(context.Background()); err != nil {
+        t.Fatalf("refresh: %v", err)
+    }
+
+    idp.setFail(true)
+    clk.Advance(30 * time.Minute)
+    if err := s.Refresh(context.Background()); err == nil {
+        t.Fatalf("expected the refresh to fail")
+    }
+    // Tokens are minted against testNow, so keep them valid past the clock.
+    token := idp.sign("k1", validClaims(map[string]any{"exp": testNow.Add(3 * time.Hour).Unix()}))
+    if _, err := s.VerifyToken(token); err != nil {
+        t.Fatalf("expected cached keys to keep verifying, got %v", err)
+    }
+
+    clk.Advance(time.Hour)
+    if _, err := s.VerifyToken(token); !errors.Is(err, ErrKeysUnavailable) {
+        t.Fatalf("expected ErrKeysUnavailable once the keys expired, got %v", err)
+    }
+
+    idp.setFail(false)
+    clk.Advance(2 * time.Minute)
+    if _, err := s.VerifyToken(token); err != nil {
+        t.Fatalf("expected verification to recover once the JWKS is back, got %v", err)
+    }
+}
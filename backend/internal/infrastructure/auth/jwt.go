@@ -0,0 +1,195 @@
+package auth
+
+import (
+    "crypto/hmac"
+    "crypto/sha256"
+    "encoding/base64"
+    "encoding/json"
+    "fmt"
+    "strings"
+    "time"
+
+    "backend/internal/pkg/identity"
+
+    "github.com/google/uuid"
+)
+
+// Errors returned by JWTService.VerifyToken. Each is wrapped with detail, so
+// compare with errors.Is. ErrTokenExpired is identity.ErrTokenExpired; all
+// the others are identity.ErrTokenInvalid as well.
+var (
+    // ErrTokenMalformed is returned for a token that is not a well-formed
+    // JWT or lacks the sub, tenant_id or exp claim.
+    ErrTokenMalformed = fmt.Errorf("%w: malformed", identity.ErrTokenInvalid)
+    // ErrTokenSignature is returned for a token not signed with HS256 and
+    // the configured secret.
+    ErrTokenSignature = fmt.Errorf("%w: bad signature", identity.ErrTokenInvalid)
+    // ErrTokenExpired is returned once the token's exp has passed.
+    ErrTokenExpired = identity.ErrTokenExpired
+    // ErrTokenNotYetValid is returned before the token's nbf.
+    ErrTokenNotYetValid = fmt.Errorf("%w: not yet valid", identity.ErrTokenInvalid)
+    // ErrTokenRevoked is returned for a token revoked before its expiry.
+    ErrTokenRevoked = fmt.Errorf("%w: revoked", identity.ErrTokenInvalid)
+)
+
+// RevocationChecker reports whether a verified token was revoked, by its jti
+// or along with every token its user was issued until some time.
+type RevocationChecker interface {
+    Revoked(tenantID, userID, tokenID string, issuedAt time.Time) (bool, error)
+}
+
+// ClockSkew is the default leeway: how far exp and nbf may be off before a
+// token is refused, to tolerate clocks drifting between the issuer and this
+// service.
+const ClockSkew = 30 * time.Second
+
+// TokenClaims are the JWT claims JWTService reads and Sign writes. Times are
+// whole seconds since the epoch on the wire; zero IssuedAt and NotBefore, and
+// an empty ID, are left out.
+type TokenClaims struct {
+    ID        string `json:"jti,omitempty"`
+    Subject   string `json:"sub"`
+    TenantID  string `json:"tenant_id"`
+    ExpiresAt int64  `json:"exp"`
+    NotBefore int64  `json:"nbf,omitempty"`
+    IssuedAt  int64  `json:"iat,omitempty"`
+}
+
+// JWTService verifies HS256 JSON Web Tokens signed with a shared secret. The
+// token's sub becomes the user and tenant_id the tenant; every token user has
+// the member role.
+type JWTService struct {
+    secret      []byte
+    now         func() time.Time
+    leeway      time.Duration
+    revocations RevocationChecker
+}
+
+func NewJWTService(secret []byte) JWTService {
+    return JWTService{secret: secret, now: time.Now, leeway: ClockSkew}
+}
+
+// WithLeeway returns a copy of s that tolerates exp and nbf being off by up
+// to d; negative values count as zero.
+func (s JWTService) WithLeeway(d time.Duration) JWTService {
+    s.leeway = max(d, 0)
+    return s
+}
+
+// WithClock returns a copy of s that reads the time from now, when
+// verifying and minting tokens.
+func (s JWTService) WithClock(now func() time.Time) JWTService {
+    s.now = now
+    return s
+}
+
+// WithRevocations returns a copy of s that refuses tokens r reports
+// revoked. When r cannot tell, the token is refused as well.
+func (s JWTService) WithRevocations(r RevocationChecker) JWTService {
+    s.revocations = r
+    return s
+}
+
+type jwtHeader struct {
+    Alg string `json:"alg"`
+    Typ string `json:"typ,omitempty"`
+}
+
+// VerifyToken checks token and returns its claims. Tokens must carry exp;
+// nbf is optional.
+func (s JWTService) VerifyToken(token string) (identity.Claims, error) {
+    parts := strings.Split(token, ".")
+    if len(parts) != 3 {
+        return identity.Claims{}, fmt.Errorf("%w: expected 3 segments, got %d", ErrTokenMalformed, len(parts))
+    }
+    var header jwtHeader
+    if err := decodeSegment(parts[0], &header); err != nil {
+        return identity.Claims{}, fmt.Errorf("%w: header: %v", ErrTokenMalformed, err)
+    }
+    if header.Alg != "HS256" {
+        return identity.Claims{}, fmt.Errorf("%w: unsupported alg %q", ErrTokenSignature, header.Alg)
+    }
+    sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+    if err != nil {
+        return identity.Claims{}, fmt.Errorf("%w: signature: %v", ErrTokenMalformed, err)
+    }
+    if !hmac.Equal(sig, s.sign(parts[0]+"."+parts[1])) {
+        return identity.Claims{}, ErrTokenSignature
+    }
+    var claims TokenClaims
+    if err := decodeSegment(parts[1], &claims); err != nil {
+        return identity.Claims{}, fmt.Errorf("%w: claims: %v", ErrTokenMalformed, err)
+    }
+    switch {
+    case claims.Subject == "":
+        return identity.Claims{}, fmt.Errorf("%w: missing sub", ErrTokenMalformed)
+    case claims.TenantID == "":
+        return identity.Claims{}, fmt.Errorf("%w: missing tenant_id", ErrTokenMalformed)
+    case claims.ExpiresAt == 0:
+        return identity.Claims{}, fmt.Errorf("%w: missing exp", ErrTokenMalformed)
+    }
+    if err := checkTimes(s.now(), s.leeway, claims.ExpiresAt, claims.NotBefore); err != nil {
+        return identity.Claims{}, err
+    }
+    if s.revocations != nil {
+        var issuedAt time.Time
+        if claims.IssuedAt != 0 {
+            issuedAt = time.Unix(claims.IssuedAt, 0)
+        }
+        revoked, err := s.revocations.Revoked(claims.TenantID, claims.Subject, claims.ID, issuedAt)
+        if err != nil {
+            return identity.Claims{}, fmt.Errorf("%w: revocation check: %v", identity.ErrTokenInvalid, err)
+        }
+        if revoked {
+            return identity.Claims{}, ErrTokenRevoked
+        }
+    }
+    return identity.Claims{UserID: claims.Subject, TenantID: claims.TenantID, Roles: []string{identity.RoleMember}, TokenID: claims.ID}, nil
+}
+
+// checkTimes refuses a token past exp or before nbf (when set), in seconds
+// since the epoch, at now give or take leeway.
+func checkTimes(now time.Time, leeway time.Duration, exp, nbf int64) error {
+    if now.Add(-leeway).After(time.Unix(exp, 0)) {
+        return ErrTokenExpired
+    }
+    if nbf != 0 && now.Add(leeway).Before(time.Unix(nbf, 0)) {
+        return ErrTokenNotYetValid
+    }
+    return nil
+}
+
+// Sign mints an HS256 token carrying claims, for tests and local tooling.
+func (s JWTService) Sign(claims TokenClaims) (string, error) {
+    header, err := json.Marshal(jwtHeader{Alg: "HS256", Typ: "JWT"})
+    if err != nil {
+        return "", err
+    }
+    payload, err := json.Marshal(claims)
+    if err != nil {
+        return "", err
+    }
+    signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+    return signed + "." + base64.RawURLEncoding.EncodeToString(s.sign(signed)), nil
+}
+
+// Mint signs a token for userID in tenantID that is valid from now for ttl,
+// with a random jti by which it can be revoked.
+func (s JWTService) Mint(userID, tenantID string, ttl time.Duration) (string, error) {
+    now := s.now()
+    return s.Sign(TokenClaims{ID: uuid.NewString(), Subject: userID, TenantID: tenantID, IssuedAt: now.Unix(), ExpiresAt: now.Add(ttl).Unix()})
+}
+
+func (s JWTService) sign(signed string) []byte {
+    mac := hmac.New(sha256.New, s.secret)
+    mac.Write([]byte(signed))
+    return mac.Sum(nil)
+}
+
+func decodeSegment(seg string, v any) error {
+    b, err := base64.RawURLEncoding.DecodeString(seg)
+    if err != nil {
+        return err
+    }
+    return json.Unmarshal(b, v)
+}
@@ -0,0 +1,173 @@
+package auth
+
+import (
+    "errors"
+    "strings"
+    "testing"
+    "time"
+
+    "backend/internal/pkg/identity"
+)
+
+var testNow = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestJWTService(secret string) JWTService {
+    return NewJWTService([]byte(secret)).WithClock(func() time.Time { return testNow })
+}
+
+// Test that a token minted with the secret verifies as a member of its
+// tenant, carrying its jti as the token id.
+func TestJWTService_VerifyToken_Valid(t *testing.T) {
+    s := newTestJWTService("secret")
+    token, err := s.Mint("u7", "t3", time.Hour)
+    if err != nil {
+        t.Fatalf("mint: %v", err)
+    }
+    claims, err := s.VerifyToken(token)
+    if err != nil {
+        t.Fatalf("verify: %v", err)
+    }
+    if claims.UserID != "u7" || claims.TenantID != "t3" || !claims.HasRole(identity.RoleMember) || claims.TokenID == "" {
+        t.Fatalf("expected member u7 in t3 with a token id, got %+v", claims)
+    }
+}
+
+// Test that each way a token can be refused gets its own error.
+func TestJWTService_VerifyToken_Rejects(t *testing.T) {
+    s := newTestJWTService("secret")
+    sign := func(c TokenClaims) string {
+        token, err := s.Sign(c)
+        if err != nil {
+            t.Fatalf("sign: %v", err)
+        }
+        return token
+    }
+    exp := testNow.Add(time.Hour).Unix()
+    other, _ := newTestJWTService("other").Mint("u1", "t1", time.Hour)
+    valid := sign(TokenClaims{Subject: "u1", TenantID: "t1", ExpiresAt: exp})
+    parts := strings.Split(valid, ".")
+
+    for _, tc := range []struct {
+        name  string
+        token string
+        want  error
+    }{
+        {"empty", "", ErrTokenMalformed},
+        {"two segments", parts[0] + "." + parts[1], ErrTokenMalformed},
+        {"bad signature encoding", parts[0] + "." + parts[1] + ".!!", ErrTokenMalformed},
+        {"alg none", "eyJhbGciOiJub25lIn0." + parts[1] + ".", ErrTokenSignature},
+        {"other secret", other, ErrTokenSignature},
+        {"tampered claims", parts[0] + "." + strings.Split(sign(TokenClaims{Subject: "admin", TenantID: "t1", ExpiresAt: exp}), ".")[1] + "." + parts[2], ErrTokenSignature},
+        {"no sub", sign(TokenClaims{TenantID: "t1", ExpiresAt: exp}), ErrTokenMalformed},
+        {"no tenant", sign(TokenClaims{Subject: "u1", ExpiresAt: exp}), ErrTokenMalformed},
+        {"no exp", sign(TokenClaims{Subject: "u1", TenantID: "t1"}), ErrTokenMalformed},
+        {"expired", sign(TokenClaims{Subject: "u1", TenantID: "t1", ExpiresAt: testNow.Add(-time.Minute).Unix()}), ErrTokenExpired},
+        {"not yet valid", sign(TokenClaims{Subject: "u1", TenantID: "t1", ExpiresAt: exp, NotBefore: testNow.Add(time.Minute).Unix()}), ErrTokenNotYetValid},
+    } {
+        if _, err := s.VerifyToken(tc.token); !errors.Is(err, tc.want) {
+            t.Fatalf("%s: expected %v, got %v", tc.name, tc.want, err)
+        }
+    }
+}
+
+// Test that exp and nbf tolerate ClockSkew.
+func TestJWTService_VerifyToken_ClockSkew(t *testing.T) {
+    s := newTestJWTService("secret")
+    token, err := s.Sign(TokenClaims{Subject: "u1", TenantID: "t1", ExpiresAt: testNow.Add(-ClockSkew / 2).Unix(), NotBefore: testNow.Add(ClockSkew / 2).Unix()})
+    if err != nil {
+        t.Fatalf("sign: %v", err)
+    }
+    if _, err := s.VerifyToken(token); err != nil {
+        t.Fatalf("expected a token within the skew to verify, got %v", err)
+    }
+}
+
+// Test that WithLeeway widens or narrows how far past exp a token verifies.
+func TestJWTService_VerifyToken_Leeway(t *testing.T) {
+    s := newTestJWTService("secret")
+    token, err := s.Sign(TokenClaims{Subject: "u1", TenantID: "t1", ExpiresAt: testNow.Add(-time.Minute).Unix()})
+    if err != nil {
+        t.Fatalf("sign: %v", err)
+    }
+    if _, err := s.VerifyToken(token); !errors.Is(err, ErrTokenExpired) {
+        t.Fatalf("expected ErrTokenExpired with the default leeway, got %v", err)
+    }
+    if _, err := s.WithLeeway(2 * time.Minute).VerifyToken(token); err != nil {
+        t.Fatalf("expected a two minute leeway to accept the token, got %v", err)
+    }
+    fresh, err := s.Sign(TokenClaims{Subject: "u1", TenantID: "t1", ExpiresAt: testNow.Add(-time.Second).Unix()})
+    if err != nil {
+        t.Fatalf("sign: %v", err)
+    }
+    if _, err := s.WithLeeway(0).VerifyToken(fresh); !errors.Is(err, ErrTokenExpired) {
+        t.Fatalf("expected ErrTokenExpired without leeway, got %v", err)
+    }
+}
+
+// Test that verification errors map onto the identity sentinels, so an
+// expired token can be told apart from a forged one.
+func TestJWTService_VerifyToken_IdentityErrors(t *testing.T) {
+    s := newTestJWTService("secret")
+    expired, err := s.Sign(TokenClaims{Subject: "u1", TenantID: "t1", ExpiresAt: testNow.Add(-time.Hour).Unix()})
+    if err != nil {
+        t.Fatalf("sign: %v", err)
+    }
+    if _, err := s.VerifyToken(expired); !errors.Is(err, identity.ErrTokenExpired) || errors.Is(err, identity.ErrTokenInvalid) {
+        t.Fatalf("expected only identity.ErrTokenExpired, got %v", err)
+    }
+    forged, err := newTestJWTService("other").Mint("u1", "t1", time.Hour)
+    if err != nil {
+        t.Fatalf("mint: %v", err)
+    }
+    if _, err := s.VerifyToken(forged); !errors.Is(err, identity.ErrTokenInvalid) || errors.Is(err, identity.ErrTokenExpired) {
+        t.Fatalf("expected only identity.ErrTokenInvalid, got %v", err)
+    }
+}
+
+// revocationFunc adapts a function to RevocationChecker.
+type revocationFunc func(tenantID, userID, tokenID string, issuedAt time.Time) (bool, error)
+
+func (f revocationFunc) Revoked(tenantID, userID, tokenID string, issuedAt time.Time) (bool, error) {
+    return f(tenantID, userID, tokenID, issuedAt)
+}
+
+// Test that a minted token carries a jti that the revocation check sees,
+// that a revoked token is refused once presented, and that a failed check
+// refuses the token too.
+func TestJWTService_VerifyToken_Revoked(t *testing.T) {
+    revoked := map[string]bool{}
+    var checkErr error
+    s := newTestJWTService("secret").WithRevocations(revocationFunc(func(tenantID, userID, tokenID string, issuedAt time.Time) (bool, error) {
+        if tenantID != "t3" || userID != "u7" || !issuedAt.Equal(testNow) {
+            t.Fatalf("expected u7 in t3 issued at %v, got %s %s %v", testNow, userID, tenantID, issuedAt)
+        }
+        return revoked[tokenID], checkErr
+    }))
+    token, _ := s.Mint("u7", "t3", time.Hour)
+    other, _ := s.Mint("u7", "t3", time.Hour)
+    if _, err := s.VerifyToken(token); err != nil {
+        t.Fatalf("expected the token to verify before revocation, got %v", err)
+    }
+
+    var seen string
+    s.WithRevocations(revocationFunc(func(_, _, tokenID string, _ time.Time) (bool, error) {
+        seen = tokenID
+        return false, nil
+    })).VerifyToken(token)
+    if seen == "" {
+        t.Fatalf("expected the minted token to carry a jti")
+    }
+    revoked[seen] = true
+    _, err := s.VerifyToken(token)
+    if !errors.Is(err, ErrTokenRevoked) || !errors.Is(err, identity.ErrTokenInvalid) {
+        t.Fatalf("expected ErrTokenRevoked, got %v", err)
+    }
+    if _, err := s.VerifyToken(other); err != nil {
+        t.Fatalf("expected another token of the user to keep working, got %v", err)
+    }
+
+    checkErr = errors.New("revocation list unavailable")
+    if _, err := s.VerifyToken(other); !errors.Is(err, identity.ErrTokenInvalid) {
+        t.Fatalf("expected a failed check to refuse the token, got %v", err)
+    }
+}
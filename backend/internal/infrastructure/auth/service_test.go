@@ -0,0 +1,26 @@
+package auth
+
+import (
+    "errors"
+    "testing"
+
+    "backend/internal/pkg/identity"
+)
+
+// Test that the service token verifies as the system principal in no tenant
+// and that any other token is refused.
+func TestServiceTokenAuthService(t *testing.T) {
+    s := NewServiceTokenAuthService("service-token-of-at-least-32-bytes")
+    claims, err := s.VerifyToken("service-token-of-at-least-32-bytes")
+    if err != nil {
+        t.Fatalf("verify: %v", err)
+    }
+    if claims.UserID != identity.SystemUserID || claims.TenantID != "" || !claims.HasRole(identity.RoleSystem) {
+        t.Fatalf("expected the system principal, got %+v", claims)
+    }
+    for _, token := range []string{"", "service-token-of-at-least-32-byteS", "user-jwt"} {
+        if _, err := s.VerifyToken(token); !errors.Is(err, identity.ErrTokenInvalid) {
+            t.Fatalf("%q: expected ErrTokenInvalid, got %v", token, err)
+        }
+    }
+}
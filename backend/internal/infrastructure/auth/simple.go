@@ -1,6 +1,10 @@
 package auth
 
-import "errors"
+import (
+    "errors"
+
+    "backend/internal/pkg/identity"
+)
 
 // SimpleAuthService is a minimal auth service suitable for dev/testing.
 // It accepts any non-empty token and returns static identifiers.
@@ -8,11 +12,10 @@ type SimpleAuthService struct{}
 
 func NewSimpleAuthService() SimpleAuthService { return SimpleAuthService{} }
 
-func (SimpleAuthService) VerifyToken(token string) (string, string, error) {
+func (SimpleAuthService) VerifyToken(token string) (identity.Claims, error) {
     if token == "" {
-        return "", "", errors.New("missing token")
+        return identity.Claims{}, errors.New("missing token")
     }
-    // Allow either raw token or Bearer token
-    return "u1", "t1", nil
+    return identity.Claims{UserID: "u1", TenantID: "t1", Roles: []string{identity.RoleMember}}, nil
 }
 
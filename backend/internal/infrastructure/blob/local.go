@@ -0,0 +1,85 @@
+package blob
+
+import (
+    "context"
+    "errors"
+    "io"
+    "io/fs"
+    "os"
+    "path/filepath"
+    "strings"
+
+    appattachment "backend/internal/application/attachment"
+)
+
+// ErrInvalidKey is returned for keys that would leave the store's directory.
+var ErrInvalidKey = errors.New("invalid blob key")
+
+// LocalStore keeps files in a directory on the local filesystem and serves
+// them below a base URL, for development.
+type LocalStore struct {
+    dir     string
+    baseURL string
+}
+
+// NewLocalStore stores files under dir, creating it as needed, and returns
+// their URLs below baseURL (e.g. /uploads).
+func NewLocalStore(dir, baseURL string) *LocalStore {
+    return &LocalStore{dir: dir, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+var _ appattachment.BlobStore = (*LocalStore)(nil)
+
+func (s *LocalStore) Put(ctx context.Context, key, contentType string, r io.Reader) (string, error) {
+    name, err := s.path(key)
+    if err != nil {
+        return "", err
+    }
+    if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+        return "", err
+    }
+    f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+    if err != nil {
+        return "", err
+    }
+    if _, err := io.Copy(f, r); err != nil {
+        f.Close()
+        os.Remove(name)
+        return "", err
+    }
+    if err := f.Close(); err != nil {
+        return "", err
+    }
+    return s.baseURL + "/" + filepath.ToSlash(filepath.Clean(key)), nil
+}
+
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+    name, err := s.path(key)
+    if err != nil {
+        return err
+    }
+    if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+        return err
+    }
+    return nil
+}
+
+func (s *LocalStore) DeletePrefix(ctx context.Context, prefix string) error {
+    // The store's directory itself is never a prefix to delete.
+    if filepath.Clean(filepath.FromSlash(prefix)) == "." {
+        return ErrInvalidKey
+    }
+    name, err := s.path(prefix)
+    if err != nil {
+        return err
+    }
+    return os.RemoveAll(name)
+}
+
+// path resolves key inside the store's directory.
+func (s *LocalStore) path(key string) (string, error) {
+    if !filepath.IsLocal(filepath.FromSlash(key)) {
+        return "", ErrInvalidKey
+    }
+    return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,77 @@
+package blob
+
+import (
+    "context"
+    "errors"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+)
+
+// Test that Put writes the file below the directory and returns its URL,
+// that Delete removes it, and that keys leaving the directory are refused.
+func TestLocalStore(t *testing.T) {
+    dir := t.TempDir()
+    s := NewLocalStore(dir, "/uploads/")
+    ctx := context.Background()
+
+    url, err := s.Put(ctx, "t1/task/a.txt", "text/plain", strings.NewReader("hello"))
+    if err != nil {
+        t.Fatalf("put: %v", err)
+    }
+    if url != "/uploads/t1/task/a.txt" {
+        t.Fatalf("expected /uploads/t1/task/a.txt, got %q", url)
+    }
+    got, err := os.ReadFile(filepath.Join(dir, "t1", "task", "a.txt"))
+    if err != nil || string(got) != "hello" {
+        t.Fatalf("expected the stored content, got %q (%v)", got, err)
+    }
+
+    if err := s.Delete(ctx, "t1/task/a.txt"); err != nil {
+        t.Fatalf("delete: %v", err)
+    }
+    if _, err := os.Stat(filepath.Join(dir, "t1", "task", "a.txt")); !errors.Is(err, os.ErrNotExist) {
+        t.Fatalf("expected the file to be gone, got %v", err)
+    }
+    if err := s.Delete(ctx, "t1/task/a.txt"); err != nil {
+        t.Fatalf("expected deleting a missing file to succeed, got %v", err)
+    }
+
+    for _, key := range []string{"../escape.txt", "/etc/passwd", ""} {
+        if _, err := s.Put(ctx, key, "text/plain", strings.NewReader("x")); !errors.Is(err, ErrInvalidKey) {
+            t.Fatalf("%q: expected ErrInvalidKey, got %v", key, err)
+        }
+    }
+}
+
+// Test that DeletePrefix removes every file below the prefix, leaves other
+// prefixes alone, and refuses to empty the whole store.
+func TestLocalStore_DeletePrefix(t *testing.T) {
+    dir := t.TempDir()
+    s := NewLocalStore(dir, "/uploads")
+    ctx := context.Background()
+    for _, key := range []string{"t1/a/x.txt", "t1/b/y.txt", "t2/a/z.txt"} {
+        if _, err := s.Put(ctx, key, "text/plain", strings.NewReader("x")); err != nil {
+            t.Fatalf("put %s: %v", key, err)
+        }
+    }
+
+    if err := s.DeletePrefix(ctx, "t1/"); err != nil {
+        t.Fatalf("delete prefix: %v", err)
+    }
+    if _, err := os.Stat(filepath.Join(dir, "t1")); !errors.Is(err, os.ErrNotExist) {
+        t.Fatalf("expected t1's files to be gone, got %v", err)
+    }
+    if _, err := os.Stat(filepath.Join(dir, "t2", "a", "z.txt")); err != nil {
+        t.Fatalf("expected t2's file to stay, got %v", err)
+    }
+    if err := s.DeletePrefix(ctx, "t1/"); err != nil {
+        t.Fatalf("expected deleting a missing prefix to succeed, got %v", err)
+    }
+    for _, prefix := range []string{"", "/", ".", "t2/..", "../"} {
+        if err := s.DeletePrefix(ctx, prefix); !errors.Is(err, ErrInvalidKey) {
+            t.Fatalf("%q: expected ErrInvalidKey, got %v", prefix, err)
+        }
+    }
+}
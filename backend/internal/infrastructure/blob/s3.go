@@ -0,0 +1,40 @@
+package blob
+
+import (
+    "context"
+    "errors"
+    "io"
+
+    appattachment "backend/internal/application/attachment"
+)
+
+// ErrS3NotImplemented is returned by every S3Store operation until the
+// store is implemented.
+var ErrS3NotImplemented = errors.New("s3 blob store is not implemented")
+
+// S3Store is the placeholder for keeping files in an S3-compatible bucket.
+// It carries its configuration but stores nothing yet.
+type S3Store struct {
+    Endpoint string
+    Bucket   string
+}
+
+func NewS3Store(endpoint, bucket string) *S3Store {
+    return &S3Store{Endpoint: endpoint, Bucket: bucket}
+}
+
+var _ appattachment.BlobStore = (*S3Store)(nil)
+
+func (s *S3Store) Put(ctx context.Context, key, contentType string, r io.Reader) (string, error) {
+    return "", ErrS3NotImplemented
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+    return ErrS3NotImplemented
+}
+
+// DeletePrefix succeeds without doing anything: Put never stores a file, so
+// there is nothing to remove.
+func (s *S3Store) DeletePrefix(ctx context.Context, prefix string) error {
+    return nil
+}
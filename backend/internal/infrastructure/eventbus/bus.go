@@ -0,0 +1,79 @@
+package eventbus
+
+import (
+    "context"
+    "log/slog"
+    "sync"
+
+    apptask "backend/internal/application/task"
+    domaintask "backend/internal/domain/task"
+)
+
+// subscriberBuffer is how many events a subscriber may fall behind before
+// further events are dropped for it.
+const subscriberBuffer = 64
+
+// Bus is an in-process publish/subscribe hub for task domain events. Events
+// are delivered only to subscribers of the event's tenant. Publishing never
+// blocks: a subscriber whose buffer is full misses the event.
+type Bus struct {
+    mu     sync.RWMutex
+    subs   map[string]map[chan domaintask.Event]struct{} // tenantID -> subscribers
+    logger *slog.Logger
+}
+
+func New(logger *slog.Logger) *Bus {
+    if logger == nil {
+        logger = slog.Default()
+    }
+    return &Bus{subs: make(map[string]map[chan domaintask.Event]struct{}), logger: logger}
+}
+
+var (
+    _ apptask.EventPublisher  = (*Bus)(nil)
+    _ apptask.EventSubscriber = (*Bus)(nil)
+)
+
+func (b *Bus) Publish(ctx context.Context, e domaintask.Event) {
+    b.mu.RLock()
+    defer b.mu.RUnlock()
+    for ch := range b.subs[e.EventTenantID()] {
+        select {
+        case ch <- e:
+        default:
+            b.logger.WarnContext(ctx, "dropping event for slow subscriber", "event", e.EventName(), "tenant_id", e.EventTenantID())
+        }
+    }
+}
+
+// Subscribe registers a listener for the tenant's events. unsubscribe is
+// safe to call more than once.
+func (b *Bus) Subscribe(tenantID string) (<-chan domaintask.Event, func()) {
+    ch := make(chan domaintask.Event, subscriberBuffer)
+    b.mu.Lock()
+    if _, ok := b.subs[tenantID]; !ok {
+        b.subs[tenantID] = make(map[chan domaintask.Event]struct{})
+    }
+    b.subs[tenantID][ch] = struct{}{}
+    b.mu.Unlock()
+
+    var once sync.Once
+    return ch, func() {
+        once.Do(func() {
+            b.mu.Lock()
+            defer b.mu.Unlock()
+            delete(b.subs[tenantID], ch)
+            if len(b.subs[tenantID]) == 0 {
+                delete(b.subs, tenantID)
+            }
+            close(ch)
+        })
+    }
+}
+
+// Subscribers reports how many listeners the tenant currently has.
+func (b *Bus) Subscribers(tenantID string) int {
+    b.mu.RLock()
+    defer b.mu.RUnlock()
+    return len(b.subs[tenantID])
+}
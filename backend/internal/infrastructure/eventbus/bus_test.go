@@ -0,0 +1,35 @@
+package eventbus
+
+import (
+    "context"
+    "testing"
+
+    domaintask "backend/internal/domain/task"
+)
+
+// Test that events reach only the tenant's subscribers, that a full buffer
+// drops instead of blocking, and that unsubscribe closes the channel.
+func TestBus(t *testing.T) {
+    b := New(nil)
+    t1, unsubscribe := b.Subscribe("t1")
+    t2, unsubscribe2 := b.Subscribe("t2")
+    defer unsubscribe2()
+
+    for i := 0; i < subscriberBuffer+5; i++ {
+        b.Publish(context.Background(), domaintask.TaskDeleted{TenantID: "t1", TaskID: "x"})
+    }
+    if len(t1) != subscriberBuffer {
+        t.Fatalf("expected %d buffered events, got %d", subscriberBuffer, len(t1))
+    }
+    if len(t2) != 0 {
+        t.Fatalf("expected no events for t2, got %d", len(t2))
+    }
+
+    unsubscribe()
+    unsubscribe()
+    for range t1 {
+    }
+    if n := b.Subscribers("t1"); n != 0 {
+        t.Fatalf("expected no t1 subscribers, got %d", n)
+    }
+}
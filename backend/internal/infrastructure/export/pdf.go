@@ -0,0 +1,159 @@
+package export
+
+import (
+    "fmt"
+    "io"
+    "strconv"
+    "strings"
+    "time"
+
+    apptask "backend/internal/application/task"
+
+    "github.com/jung-kurt/gofpdf"
+)
+
+// pdfTitleLimit is the longest title, in characters, printed in the detail
+// table; longer ones are cut with an ellipsis so rows stay one line high.
+const pdfTitleLimit = 48
+
+// pdfColumn is one column of the detail table.
+type pdfColumn struct {
+    header string
+    width  float64 // mm
+}
+
+var pdfColumns = []pdfColumn{
+    {"ID", 22},
+    {"Title", 78},
+    {"Status", 24},
+    {"Priority", 16},
+    {"Due", 22},
+    {"Assignee", 28},
+}
+
+// PDFExporter renders task reports as A4 PDF documents: a title page with
+// the tenant's name and the export date, a summary of tasks per status and a
+// table of the tasks themselves.
+type PDFExporter struct{}
+
+func NewPDFExporter() *PDFExporter {
+    return &PDFExporter{}
+}
+
+var _ apptask.ReportWriter = (*PDFExporter)(nil)
+
+func (e *PDFExporter) WriteReport(w io.Writer, r apptask.Report) error {
+    loc := r.Location
+    if loc == nil {
+        loc = time.UTC
+    }
+    pdf := gofpdf.New("P", "mm", "A4", "")
+    pdf.SetTitle("MauFlow task report", true)
+    pdf.SetCreator("MauFlow", true)
+    // The core fonts only cover cp1252; anything else is dropped.
+    tr := pdf.UnicodeTranslatorFromDescriptor("")
+    pdf.SetFooterFunc(func() {
+        pdf.SetY(-15)
+        pdf.SetFont("Helvetica", "", 8)
+        pdf.CellFormat(0, 10, fmt.Sprintf("Page %d", pdf.PageNo()), "", 0, "C", false, 0, "")
+    })
+
+    // Title page
+    pdf.AddPage()
+    pdf.SetY(90)
+    pdf.SetFont("Helvetica", "B", 24)
+    pdf.CellFormat(0, 12, "Task report", "", 1, "C", false, 0, "")
+    pdf.SetFont("Helvetica", "", 16)
+    pdf.CellFormat(0, 10, tr(r.TenantName), "", 1, "C", false, 0, "")
+    pdf.SetFont("Helvetica", "", 11)
+    pdf.CellFormat(0, 8, "Exported "+r.GeneratedAt.In(loc).Format("2 January 2006 15:04 MST"), "", 1, "C", false, 0, "")
+
+    // Summary
+    pdf.AddPage()
+    pdf.SetFont("Helvetica", "B", 14)
+    pdf.CellFormat(0, 10, "Summary", "", 1, "L", false, 0, "")
+    pdf.SetFont("Helvetica", "B", 10)
+    pdf.SetFillColor(230, 230, 230)
+    pdf.CellFormat(50, 7, "Status", "1", 0, "L", true, 0, "")
+    pdf.CellFormat(25, 7, "Tasks", "1", 1, "R", true, 0, "")
+    pdf.SetFont("Helvetica", "", 10)
+    total := 0
+    for _, sc := range r.StatusCounts {
+        pdf.CellFormat(50, 7, statusLabel(sc.Status), "1", 0, "L", false, 0, "")
+        pdf.CellFormat(25, 7, strconv.Itoa(sc.Count), "1", 1, "R", false, 0, "")
+        total += sc.Count
+    }
+    pdf.SetFont("Helvetica", "B", 10)
+    pdf.CellFormat(50, 7, "Total", "1", 0, "L", false, 0, "")
+    pdf.CellFormat(25, 7, strconv.Itoa(total), "1", 1, "R", false, 0, "")
+
+    // Details
+    pdf.Ln(8)
+    pdf.SetFont("Helvetica", "B", 14)
+    pdf.CellFormat(0, 10, "Tasks", "", 1, "L", false, 0, "")
+    writeHeader := func() {
+        pdf.SetFont("Helvetica", "B", 9)
+        for _, col := range pdfColumns {
+            pdf.CellFormat(col.width, 7, col.header, "1", 0, "L", true, 0, "")
+        }
+        pdf.Ln(-1)
+        pdf.SetFont("Helvetica", "", 9)
+    }
+    writeHeader()
+    _, pageHeight := pdf.GetPageSize()
+    _, _, _, bottom := pdf.GetMargins()
+    for _, t := range r.Tasks {
+        // Repeat the header on every page the table runs onto.
+        if pdf.GetY()+6 > pageHeight-bottom-15 {
+            pdf.AddPage()
+            writeHeader()
+        }
+        due, assignee := "", ""
+        if t.DueDate != nil {
+            due = t.DueDate.In(loc).Format("2006-01-02")
+        }
+        if t.AssigneeID != nil {
+            assignee = shorten(*t.AssigneeID, 14)
+        }
+        // The first block of a UUID is enough to find the task again.
+        id := t.ID
+        if len(id) > 8 {
+            id = id[:8]
+        }
+        cells := []string{
+            id,
+            tr(shorten(t.Title, pdfTitleLimit)),
+            statusLabel(t.Status),
+            strconv.Itoa(t.Priority),
+            due,
+            tr(assignee),
+        }
+        for i, col := range pdfColumns {
+            pdf.CellFormat(col.width, 6, cells[i], "1", 0, "L", false, 0, "")
+        }
+        pdf.Ln(-1)
+    }
+    if len(r.Tasks) == 0 {
+        pdf.SetFont("Helvetica", "I", 9)
+        pdf.CellFormat(0, 6, "No tasks.", "", 1, "L", false, 0, "")
+    }
+    return pdf.Output(w)
+}
+
+// statusLabel turns a status such as "in_progress" into "In progress".
+func statusLabel(status string) string {
+    if status == "" {
+        return ""
+    }
+    label := strings.ReplaceAll(status, "_", " ")
+    return strings.ToUpper(label[:1]) + label[1:]
+}
+
+// shorten cuts s to at most n characters, marking the cut with "...".
+func shorten(s string, n int) string {
+    runes := []rune(s)
+    if len(runes) <= n {
+        return s
+    }
+    return string(runes[:n-3]) + "..."
+}
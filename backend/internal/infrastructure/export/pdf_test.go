@@ -0,0 +1,54 @@
+package export
+
+import (
+    "bytes"
+    "strings"
+    "testing"
+    "time"
+
+    apptask "backend/internal/application/task"
+    domaintask "backend/internal/domain/task"
+)
+
+// Test that a report renders as a PDF document, including tasks with long
+// titles, characters outside Latin-1 and enough rows to span pages.
+func TestPDFExporter_WriteReport(t *testing.T) {
+    due := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+    assignee := "u2"
+    r := apptask.Report{
+        TenantID:     "t1",
+        TenantName:   "Acme Ünited",
+        GeneratedAt:  time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+        StatusCounts: []apptask.StatusCount{{Status: domaintask.StatusTodo, Count: 100}},
+    }
+    for i := 0; i < 100; i++ {
+        tk := domaintask.New("t1", "u1", strings.Repeat("Ship v2 ✓ ", 10), "", 5)
+        tk.DueDate = &due
+        tk.AssigneeID = &assignee
+        r.Tasks = append(r.Tasks, *tk)
+    }
+
+    var buf bytes.Buffer
+    if err := NewPDFExporter().WriteReport(&buf, r); err != nil {
+        t.Fatalf("write: %v", err)
+    }
+    if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")) {
+        t.Fatalf("expected output starting with %%PDF-, got %q", buf.Bytes()[:min(buf.Len(), 16)])
+    }
+    if !bytes.Contains(buf.Bytes(), []byte("%%EOF")) {
+        t.Fatalf("expected a complete document")
+    }
+}
+
+// Test that statuses are labelled for display and long values are cut.
+func TestPDFExporter_Labels(t *testing.T) {
+    if got := statusLabel(domaintask.StatusInProgress); got != "In progress" {
+        t.Fatalf("expected In progress, got %q", got)
+    }
+    if got := shorten("abcdefghij", 6); got != "abc..." {
+        t.Fatalf("expected abc..., got %q", got)
+    }
+    if got := shorten("abc", 6); got != "abc" {
+        t.Fatalf("expected abc, got %q", got)
+    }
+}
@@ -0,0 +1,115 @@
+package importer
+
+import (
+    "encoding/csv"
+    "errors"
+    "fmt"
+    "io"
+    "strings"
+    "time"
+
+    apptask "backend/internal/application/task"
+    domaintask "backend/internal/domain/task"
+)
+
+// FormatJira names Jira CSV issue exports in import requests.
+const FormatJira = "jira"
+
+// jiraPriorities maps Jira's default priority scheme onto task priorities.
+var jiraPriorities = map[string]int{
+    "highest": 10,
+    "high":    8,
+    "medium":  5,
+    "low":     3,
+    "lowest":  1,
+}
+
+// jiraStatuses maps Jira's default workflow onto task statuses.
+var jiraStatuses = map[string]string{
+    "to do":       domaintask.StatusTodo,
+    "in progress": domaintask.StatusInProgress,
+    "done":        domaintask.StatusDone,
+}
+
+// jiraDateLayouts are the due date formats read, Jira's own first.
+var jiraDateLayouts = []string{"02/Jan/06 3:04 PM", "02/Jan/06", time.RFC3339, time.DateOnly}
+
+// JiraImporter turns the rows of a Jira CSV issue export into tasks: Summary
+// becomes the title, Description the description, Due Date the due date,
+// Assignee the assignee and Issue key a tag, while Priority and Status are
+// mapped through Jira's default schemes. Columns are matched without regard
+// to case; missing ones, like unknown priorities and statuses, leave the
+// task's default. Due dates without a zone are read as UTC.
+type JiraImporter struct{}
+
+func NewJiraImporter() *JiraImporter {
+    return &JiraImporter{}
+}
+
+var _ apptask.TaskDecoder = (*JiraImporter)(nil)
+
+func (i *JiraImporter) DecodeTasks(r io.Reader) ([]apptask.CreateTaskInput, error) {
+    cr := csv.NewReader(r)
+    cr.FieldsPerRecord = -1
+    header, err := cr.Read()
+    if errors.Is(err, io.EOF) {
+        return nil, errors.New("missing header row")
+    }
+    if err != nil {
+        return nil, err
+    }
+    // Jira repeats some columns, such as Labels; the first one wins.
+    columns := map[string]int{}
+    for i, name := range header {
+        name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+        if _, seen := columns[name]; !seen {
+            columns[name] = i
+        }
+    }
+
+    var out []apptask.CreateTaskInput
+    for row := 2; ; row++ {
+        record, err := cr.Read()
+        if errors.Is(err, io.EOF) {
+            return out, nil
+        }
+        if err != nil {
+            return nil, err
+        }
+        field := func(col string) string {
+            if i, ok := columns[col]; ok && i < len(record) {
+                return strings.TrimSpace(record[i])
+            }
+            return ""
+        }
+        in := apptask.CreateTaskInput{
+            Title:       field("summary"),
+            Description: field("description"),
+            Priority:    jiraPriorities[strings.ToLower(field("priority"))],
+            Status:      jiraStatuses[strings.ToLower(field("status"))],
+        }
+        if v := field("due date"); v != "" {
+            due, err := parseJiraDate(v)
+            if err != nil {
+                return nil, fmt.Errorf("row %d: unreadable Due Date %q", row, v)
+            }
+            in.DueDate = &due
+        }
+        if v := field("assignee"); v != "" {
+            in.AssigneeID = &v
+        }
+        if v := field("issue key"); v != "" {
+            in.Tags = []string{strings.ToLower(v)}
+        }
+        out = append(out, in)
+    }
+}
+
+func parseJiraDate(v string) (time.Time, error) {
+    for _, layout := range jiraDateLayouts {
+        if t, err := time.Parse(layout, v); err == nil {
+            return t, nil
+        }
+    }
+    return time.Time{}, errors.New("unknown date format")
+}
@@ -0,0 +1,81 @@
+package importer
+
+import (
+    "os"
+    "strings"
+    "testing"
+    "time"
+
+    domaintask "backend/internal/domain/task"
+)
+
+// Test that the rows of an issue export map to task inputs, with Jira
+// priorities and statuses translated and unknown ones left to the defaults.
+func TestJiraImporter_DecodeTasks(t *testing.T) {
+    f, err := os.Open("testdata/jira_issues.csv")
+    if err != nil {
+        t.Fatalf("open fixture: %v", err)
+    }
+    defer f.Close()
+    inputs, err := NewJiraImporter().DecodeTasks(f)
+    if err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+    if len(inputs) != 4 {
+        t.Fatalf("expected 4 tasks, got %d", len(inputs))
+    }
+
+    login := inputs[0]
+    if login.Title != "Fix login redirect" || login.Description != "Users land on a blank page after SSO." {
+        t.Fatalf("expected the issue's summary and description, got %q and %q", login.Title, login.Description)
+    }
+    if login.Priority != 10 || login.Status != domaintask.StatusInProgress {
+        t.Fatalf("expected priority 10 in progress, got %d %q", login.Priority, login.Status)
+    }
+    if want := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC); login.DueDate == nil || !login.DueDate.Equal(want) {
+        t.Fatalf("expected due %v, got %v", want, login.DueDate)
+    }
+    if login.AssigneeID == nil || *login.AssigneeID != "alex" {
+        t.Fatalf("expected assignee alex, got %v", login.AssigneeID)
+    }
+    if len(login.Tags) != 1 || login.Tags[0] != "web-101" {
+        t.Fatalf("expected the issue key as a tag, got %v", login.Tags)
+    }
+
+    cases := []struct {
+        priority int
+        status   string
+    }{
+        {3, domaintask.StatusDone},
+        {5, domaintask.StatusTodo},
+        {0, ""},
+    }
+    for i, tc := range cases {
+        in := inputs[i+1]
+        if in.Priority != tc.priority || in.Status != tc.status {
+            t.Fatalf("row %d: expected priority %d and status %q, got %d and %q", i+3, tc.priority, tc.status, in.Priority, in.Status)
+        }
+    }
+    if inputs[2].Title != "" {
+        t.Fatalf("expected the blank summary to stay blank, got %q", inputs[2].Title)
+    }
+}
+
+// Test that an export without the optional columns still decodes.
+func TestJiraImporter_DecodeTasks_MissingColumns(t *testing.T) {
+    inputs, err := NewJiraImporter().DecodeTasks(strings.NewReader("Summary\nShip it\n"))
+    if err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+    if len(inputs) != 1 || inputs[0].Title != "Ship it" || inputs[0].DueDate != nil || inputs[0].AssigneeID != nil {
+        t.Fatalf("expected one bare task, got %+v", inputs)
+    }
+}
+
+// Test that an unreadable due date rejects the export, naming its row.
+func TestJiraImporter_DecodeTasks_BadDate(t *testing.T) {
+    _, err := NewJiraImporter().DecodeTasks(strings.NewReader("Summary,Due Date\nShip it,next week\n"))
+    if err == nil || !strings.Contains(err.Error(), "row 2") {
+        t.Fatalf("expected an error for row 2, got %v", err)
+    }
+}
@@ -0,0 +1,88 @@
+package importer
+
+import (
+    "encoding/json"
+    "io"
+    "strings"
+    "time"
+
+    apptask "backend/internal/application/task"
+    domaintask "backend/internal/domain/task"
+)
+
+// FormatTrello names Trello board exports in import requests.
+const FormatTrello = "trello"
+
+// trelloBoard is the part of Trello's "Export as JSON" board document that
+// is imported. Everything else, such as lists, checklists and actions, is
+// ignored.
+type trelloBoard struct {
+    Cards []trelloCard `json:"cards"`
+}
+
+type trelloCard struct {
+    Name   string        `json:"name"`
+    Desc   string        `json:"desc"`
+    Due    *time.Time    `json:"due"`
+    Closed bool          `json:"closed"`
+    Labels []trelloLabel `json:"labels"`
+}
+
+// trelloLabel is a card label. Labels may be left unnamed, leaving only
+// their colour.
+type trelloLabel struct {
+    Name  string `json:"name"`
+    Color string `json:"color"`
+}
+
+// TrelloImporter turns the cards of a Trello board export into tasks: the
+// name becomes the title, desc the description, due the due date and labels
+// the tags, while closed (archived) cards are imported done.
+type TrelloImporter struct{}
+
+func NewTrelloImporter() *TrelloImporter {
+    return &TrelloImporter{}
+}
+
+var _ apptask.TaskDecoder = (*TrelloImporter)(nil)
+
+func (i *TrelloImporter) DecodeTasks(r io.Reader) ([]apptask.CreateTaskInput, error) {
+    var board trelloBoard
+    if err := json.NewDecoder(r).Decode(&board); err != nil {
+        return nil, err
+    }
+    out := make([]apptask.CreateTaskInput, 0, len(board.Cards))
+    for _, card := range board.Cards {
+        in := apptask.CreateTaskInput{
+            Title:       strings.TrimSpace(card.Name),
+            Description: card.Desc,
+            DueDate:     card.Due,
+            Tags:        trelloTags(card.Labels),
+        }
+        if card.Closed {
+            in.Status = domaintask.StatusDone
+        }
+        out = append(out, in)
+    }
+    return out, nil
+}
+
+// trelloTags turns labels into lower-case tags, with spaces replaced by
+// dashes. Unnamed labels use their colour; duplicates are dropped.
+func trelloTags(labels []trelloLabel) []string {
+    var tags []string
+    seen := map[string]bool{}
+    for _, l := range labels {
+        name := l.Name
+        if strings.TrimSpace(name) == "" {
+            name = l.Color
+        }
+        tag := strings.ToLower(strings.Join(strings.Fields(name), "-"))
+        if tag == "" || seen[tag] {
+            continue
+        }
+        seen[tag] = true
+        tags = append(tags, tag)
+    }
+    return tags
+}
@@ -0,0 +1,54 @@
+package importer
+
+import (
+    "os"
+    "reflect"
+    "strings"
+    "testing"
+    "time"
+
+    domaintask "backend/internal/domain/task"
+)
+
+// Test that the cards of a board export map to task inputs: name, desc, due
+// and labels carry over and closed cards become done.
+func TestTrelloImporter_DecodeTasks(t *testing.T) {
+    f, err := os.Open("testdata/trello_board.json")
+    if err != nil {
+        t.Fatalf("open fixture: %v", err)
+    }
+    defer f.Close()
+    inputs, err := NewTrelloImporter().DecodeTasks(f)
+    if err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+    if len(inputs) != 2 {
+        t.Fatalf("expected 2 tasks, got %d", len(inputs))
+    }
+
+    open := inputs[0]
+    if open.Title != "Draft new landing page" || open.Description != "Hero section, pricing table and **testimonials**." {
+        t.Fatalf("expected the card's name and desc, got %q and %q", open.Title, open.Description)
+    }
+    if want := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC); open.DueDate == nil || !open.DueDate.Equal(want) {
+        t.Fatalf("expected due %v, got %v", want, open.DueDate)
+    }
+    if !reflect.DeepEqual(open.Tags, []string{"design", "blue"}) {
+        t.Fatalf("expected tags [design blue], got %v", open.Tags)
+    }
+    if open.Status != "" {
+        t.Fatalf("expected an open card to keep the default status, got %q", open.Status)
+    }
+
+    closed := inputs[1]
+    if closed.Status != domaintask.StatusDone || closed.DueDate != nil || !reflect.DeepEqual(closed.Tags, []string{"bug"}) {
+        t.Fatalf("expected a done task tagged bug without due date, got %+v", closed)
+    }
+}
+
+// Test that a body that is not JSON is rejected.
+func TestTrelloImporter_DecodeTasks_Invalid(t *testing.T) {
+    if _, err := NewTrelloImporter().DecodeTasks(strings.NewReader("title\nnot json")); err == nil {
+        t.Fatalf("expected an error")
+    }
+}
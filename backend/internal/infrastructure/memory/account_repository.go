@@ -0,0 +1,178 @@
+package memory
+
+import (
+    "context"
+    "sync"
+    "time"
+
+    appaccount "backend/internal/application/account"
+    apptask "backend/internal/application/task"
+    apptenant "backend/internal/application/tenant"
+    domainaccount "backend/internal/domain/account"
+)
+
+// AccountRepository is an in-memory store of tenants, their users and the
+// users' refresh tokens.
+type AccountRepository struct {
+    mu      sync.RWMutex
+    tenants map[string]domainaccount.Tenant       // id -> tenant
+    users   map[string]domainaccount.User         // id -> user
+    refresh map[string]domainaccount.RefreshToken // id -> token
+}
+
+func NewAccountRepository() *AccountRepository {
+    return &AccountRepository{
+        tenants: make(map[string]domainaccount.Tenant),
+        users:   make(map[string]domainaccount.User),
+        refresh: make(map[string]domainaccount.RefreshToken),
+    }
+}
+
+var (
+    _ appaccount.Repository = (*AccountRepository)(nil)
+    _ apptask.TenantNamer   = (*AccountRepository)(nil)
+)
+
+func (r *AccountRepository) CreateTenant(ctx context.Context, t *domainaccount.Tenant, owner *domainaccount.User) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if _, ok := r.userByEmail("", owner.Email); ok {
+        return appaccount.ErrEmailTaken
+    }
+    r.tenants[t.ID] = *t
+    r.users[owner.ID] = *owner
+    return nil
+}
+
+func (r *AccountRepository) FindUserByEmail(ctx context.Context, email string) (*domainaccount.User, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    u, ok := r.userByEmail("", email)
+    if !ok {
+        return nil, appaccount.ErrNotFound
+    }
+    return &u, nil
+}
+
+func (r *AccountRepository) FindUserByID(ctx context.Context, tenantID, id string) (*domainaccount.User, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    u, ok := r.users[id]
+    if !ok || u.TenantID != tenantID {
+        return nil, appaccount.ErrNotFound
+    }
+    return &u, nil
+}
+
+func (r *AccountRepository) SetPassword(ctx context.Context, tenantID, userID, hash string, at time.Time) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    u, ok := r.users[userID]
+    if !ok || u.TenantID != tenantID {
+        return appaccount.ErrNotFound
+    }
+    u.PasswordHash = hash
+    r.users[userID] = u
+    for id, t := range r.refresh {
+        if t.UserID == userID && t.RevokedAt == nil {
+            t.RevokedAt = &at
+            r.refresh[id] = t
+        }
+    }
+    return nil
+}
+
+// userByEmail finds the user with email in the tenant, or in any tenant
+// when tenantID is empty. The caller holds the lock.
+func (r *AccountRepository) userByEmail(tenantID, email string) (domainaccount.User, bool) {
+    for _, u := range r.users {
+        if u.Email == email && (tenantID == "" || u.TenantID == tenantID) {
+            return u, true
+        }
+    }
+    return domainaccount.User{}, false
+}
+
+func (r *AccountRepository) TenantName(ctx context.Context, tenantID string) (string, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    t, ok := r.tenants[tenantID]
+    if !ok {
+        return "", appaccount.ErrNotFound
+    }
+    return t.Name, nil
+}
+
+func (r *AccountRepository) CreateRefreshToken(ctx context.Context, t *domainaccount.RefreshToken) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    r.refresh[t.ID] = *t
+    return nil
+}
+
+func (r *AccountRepository) FindRefreshToken(ctx context.Context, hash string) (*domainaccount.RefreshToken, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    for _, t := range r.refresh {
+        if t.Hash == hash {
+            return &t, nil
+        }
+    }
+    return nil, appaccount.ErrInvalidRefreshToken
+}
+
+func (r *AccountRepository) RotateRefreshToken(ctx context.Context, oldID string, next *domainaccount.RefreshToken, at time.Time) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    old, ok := r.refresh[oldID]
+    if !ok || old.RotatedAt != nil || old.RevokedAt != nil {
+        return appaccount.ErrRefreshTokenReused
+    }
+    old.RotatedAt = &at
+    r.refresh[oldID] = old
+    r.refresh[next.ID] = *next
+    return nil
+}
+
+func (r *AccountRepository) RevokeRefreshToken(ctx context.Context, id string, at time.Time) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if t, ok := r.refresh[id]; ok && t.RevokedAt == nil {
+        t.RevokedAt = &at
+        r.refresh[id] = t
+    }
+    return nil
+}
+
+func (r *AccountRepository) RevokeRefreshFamily(ctx context.Context, familyID string, at time.Time) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    for id, t := range r.refresh {
+        if t.FamilyID == familyID && t.RevokedAt == nil {
+            t.RevokedAt = &at
+            r.refresh[id] = t
+        }
+    }
+    return nil
+}
+
+func (r *AccountRepository) purgeTenant(tenantID string, res *apptenant.PurgeResult) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    for id, t := range r.refresh {
+        if t.TenantID == tenantID {
+            res.RefreshTokens++
+            delete(r.refresh, id)
+        }
+    }
+    for id, u := range r.users {
+        if u.TenantID == tenantID {
+            res.Users++
+            delete(r.users, id)
+        }
+    }
+    if _, ok := r.tenants[tenantID]; ok {
+        res.Tenants++
+        delete(r.tenants, tenantID)
+    }
+}
@@ -0,0 +1,99 @@
+package memory
+
+import (
+    "context"
+    "sort"
+    "sync"
+    "time"
+
+    appapikey "backend/internal/application/apikey"
+    apptenant "backend/internal/application/tenant"
+    domainapikey "backend/internal/domain/apikey"
+)
+
+// APIKeyRepository is an in-memory store of API keys.
+type APIKeyRepository struct {
+    mu   sync.RWMutex
+    data map[string]domainapikey.APIKey // hash -> key
+}
+
+func NewAPIKeyRepository() *APIKeyRepository {
+    return &APIKeyRepository{data: make(map[string]domainapikey.APIKey)}
+}
+
+var _ appapikey.Repository = (*APIKeyRepository)(nil)
+
+func (r *APIKeyRepository) Create(ctx context.Context, k *domainapikey.APIKey) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    r.data[k.Hash] = *k
+    return nil
+}
+
+func (r *APIKeyRepository) FindByHash(ctx context.Context, hash string) (*domainapikey.APIKey, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    k, ok := r.data[hash]
+    if !ok {
+        return nil, appapikey.ErrNotFound
+    }
+    return &k, nil
+}
+
+func (r *APIKeyRepository) ListByTenant(ctx context.Context, tenantID string) ([]domainapikey.APIKey, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    out := []domainapikey.APIKey{}
+    for _, k := range r.data {
+        if k.TenantID == tenantID {
+            out = append(out, k)
+        }
+    }
+    sort.Slice(out, func(i, j int) bool {
+        if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+            return out[i].CreatedAt.Before(out[j].CreatedAt)
+        }
+        return out[i].ID < out[j].ID
+    })
+    return out, nil
+}
+
+func (r *APIKeyRepository) Revoke(ctx context.Context, tenantID, id string, at time.Time) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    for hash, k := range r.data {
+        if k.TenantID != tenantID || k.ID != id {
+            continue
+        }
+        if k.RevokedAt == nil {
+            k.RevokedAt = &at
+            r.data[hash] = k
+        }
+        return nil
+    }
+    return appapikey.ErrNotFound
+}
+
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, id string, at time.Time) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    for hash, k := range r.data {
+        if k.ID == id {
+            k.LastUsedAt = &at
+            r.data[hash] = k
+            return nil
+        }
+    }
+    return nil
+}
+
+func (r *APIKeyRepository) purgeTenant(tenantID string, res *apptenant.PurgeResult) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    for hash, k := range r.data {
+        if k.TenantID == tenantID {
+            res.APIKeys++
+            delete(r.data, hash)
+        }
+    }
+}
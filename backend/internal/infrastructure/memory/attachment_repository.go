@@ -0,0 +1,55 @@
+package memory
+
+import (
+    "context"
+    "sort"
+
+    appattachment "backend/internal/application/attachment"
+    domaintask "backend/internal/domain/task"
+)
+
+// AttachmentRepository is an in-memory attachment store layered on a
+// TaskRepository, whose lock and task map it shares.
+type AttachmentRepository struct {
+    tasks *TaskRepository
+}
+
+func NewAttachmentRepository(tasks *TaskRepository) *AttachmentRepository {
+    return &AttachmentRepository{tasks: tasks}
+}
+
+var _ appattachment.Repository = (*AttachmentRepository)(nil)
+
+func (r *AttachmentRepository) Create(ctx context.Context, a *domaintask.TaskAttachment) error {
+    r.tasks.mu.Lock()
+    defer r.tasks.mu.Unlock()
+    if _, ok := r.tasks.data[a.TenantID][a.TaskID]; !ok {
+        return appattachment.ErrTaskNotFound
+    }
+    if _, ok := r.tasks.attachments[a.TenantID]; !ok {
+        r.tasks.attachments[a.TenantID] = make(map[string]domaintask.TaskAttachment)
+    }
+    r.tasks.attachments[a.TenantID][a.ID] = *a
+    return nil
+}
+
+func (r *AttachmentRepository) ListByTask(ctx context.Context, tenantID, taskID string) ([]domaintask.TaskAttachment, error) {
+    r.tasks.mu.RLock()
+    defer r.tasks.mu.RUnlock()
+    if _, ok := r.tasks.data[tenantID][taskID]; !ok {
+        return nil, appattachment.ErrTaskNotFound
+    }
+    out := []domaintask.TaskAttachment{}
+    for _, a := range r.tasks.attachments[tenantID] {
+        if a.TaskID == taskID {
+            out = append(out, a)
+        }
+    }
+    sort.Slice(out, func(i, j int) bool {
+        if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+            return out[i].CreatedAt.Before(out[j].CreatedAt)
+        }
+        return out[i].ID < out[j].ID
+    })
+    return out, nil
+}
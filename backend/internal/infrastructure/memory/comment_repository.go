@@ -0,0 +1,78 @@
+package memory
+
+import (
+    "context"
+    "sort"
+
+    appcomment "backend/internal/application/comment"
+    domaintask "backend/internal/domain/task"
+)
+
+// CommentRepository is an in-memory comment store layered on a
+// TaskRepository, whose lock and task map it shares.
+type CommentRepository struct {
+    tasks *TaskRepository
+}
+
+func NewCommentRepository(tasks *TaskRepository) *CommentRepository {
+    return &CommentRepository{tasks: tasks}
+}
+
+var _ appcomment.Repository = (*CommentRepository)(nil)
+
+func (r *CommentRepository) Create(ctx context.Context, c *domaintask.TaskComment) error {
+    r.tasks.mu.Lock()
+    defer r.tasks.mu.Unlock()
+    if _, ok := r.tasks.data[c.TenantID][c.TaskID]; !ok {
+        return appcomment.ErrTaskNotFound
+    }
+    if _, ok := r.tasks.comments[c.TenantID]; !ok {
+        r.tasks.comments[c.TenantID] = make(map[string]domaintask.TaskComment)
+    }
+    r.tasks.comments[c.TenantID][c.ID] = *c
+    return nil
+}
+
+func (r *CommentRepository) ListByTask(ctx context.Context, tenantID, taskID string) ([]domaintask.TaskComment, error) {
+    r.tasks.mu.RLock()
+    defer r.tasks.mu.RUnlock()
+    if _, ok := r.tasks.data[tenantID][taskID]; !ok {
+        return nil, appcomment.ErrTaskNotFound
+    }
+    out := []domaintask.TaskComment{}
+    for _, c := range r.tasks.comments[tenantID] {
+        if c.TaskID == taskID {
+            out = append(out, c)
+        }
+    }
+    sort.Slice(out, func(i, j int) bool {
+        if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+            return out[i].CreatedAt.Before(out[j].CreatedAt)
+        }
+        return out[i].ID < out[j].ID
+    })
+    return out, nil
+}
+
+func (r *CommentRepository) Get(ctx context.Context, tenantID, taskID, id string) (*domaintask.TaskComment, error) {
+    r.tasks.mu.RLock()
+    defer r.tasks.mu.RUnlock()
+    c, ok := r.tasks.comments[tenantID][id]
+    if !ok || c.TaskID != taskID {
+        return nil, appcomment.ErrNotFound
+    }
+    return &c, nil
+}
+
+func (r *CommentRepository) UpdateContent(ctx context.Context, c *domaintask.TaskComment) error {
+    r.tasks.mu.Lock()
+    defer r.tasks.mu.Unlock()
+    cur, ok := r.tasks.comments[c.TenantID][c.ID]
+    if !ok || cur.TaskID != c.TaskID {
+        return appcomment.ErrNotFound
+    }
+    cur.Content = c.Content
+    cur.EditedAt = c.EditedAt
+    r.tasks.comments[c.TenantID][c.ID] = cur
+    return nil
+}
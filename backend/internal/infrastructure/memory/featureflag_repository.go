@@ -0,0 +1,62 @@
+package memory
+
+import (
+    "context"
+    "slices"
+    "strings"
+    "sync"
+
+    appfeatureflag "backend/internal/application/featureflag"
+    apptenant "backend/internal/application/tenant"
+    domainfeatureflag "backend/internal/domain/featureflag"
+)
+
+// FeatureFlagRepository is an in-memory store of feature flags.
+type FeatureFlagRepository struct {
+    mu   sync.RWMutex
+    data map[string]map[string]domainfeatureflag.FeatureFlag // tenantID -> name -> flag
+}
+
+func NewFeatureFlagRepository() *FeatureFlagRepository {
+    return &FeatureFlagRepository{data: make(map[string]map[string]domainfeatureflag.FeatureFlag)}
+}
+
+var _ appfeatureflag.Repository = (*FeatureFlagRepository)(nil)
+
+func (r *FeatureFlagRepository) Get(ctx context.Context, tenantID, name string) (*domainfeatureflag.FeatureFlag, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    f, ok := r.data[tenantID][name]
+    if !ok {
+        return nil, appfeatureflag.ErrNotFound
+    }
+    return &f, nil
+}
+
+func (r *FeatureFlagRepository) ListByTenant(ctx context.Context, tenantID string) ([]domainfeatureflag.FeatureFlag, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    out := []domainfeatureflag.FeatureFlag{}
+    for _, f := range r.data[tenantID] {
+        out = append(out, f)
+    }
+    slices.SortFunc(out, func(a, b domainfeatureflag.FeatureFlag) int { return strings.Compare(a.Name, b.Name) })
+    return out, nil
+}
+
+func (r *FeatureFlagRepository) Save(ctx context.Context, f *domainfeatureflag.FeatureFlag) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if _, ok := r.data[f.TenantID]; !ok {
+        r.data[f.TenantID] = make(map[string]domainfeatureflag.FeatureFlag)
+    }
+    r.data[f.TenantID][f.Name] = *f
+    return nil
+}
+
+func (r *FeatureFlagRepository) purgeTenant(tenantID string, res *apptenant.PurgeResult) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    res.FeatureFlags += int64(len(r.data[tenantID]))
+    delete(r.data, tenantID)
+}
@@ -0,0 +1,76 @@
+package memory
+
+import (
+    "context"
+    "slices"
+    "sync"
+    "time"
+
+    appprioritize "backend/internal/application/prioritize"
+    apptenant "backend/internal/application/tenant"
+)
+
+// PrioritizeSettingsRepository is an in-memory store of tenant
+// prioritization settings.
+type PrioritizeSettingsRepository struct {
+    mu   sync.RWMutex
+    data map[string]appprioritize.Settings // tenantID -> settings
+}
+
+func NewPrioritizeSettingsRepository() *PrioritizeSettingsRepository {
+    return &PrioritizeSettingsRepository{data: make(map[string]appprioritize.Settings)}
+}
+
+var _ appprioritize.SettingsRepository = (*PrioritizeSettingsRepository)(nil)
+
+func (r *PrioritizeSettingsRepository) GetSettings(ctx context.Context, tenantID string) (appprioritize.Settings, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    st, ok := r.data[tenantID]
+    if !ok {
+        return appprioritize.Settings{}, appprioritize.ErrSettingsNotFound
+    }
+    return st, nil
+}
+
+func (r *PrioritizeSettingsRepository) SaveSettings(ctx context.Context, tenantID string, st appprioritize.Settings) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    st.LastAutoRunAt = r.data[tenantID].LastAutoRunAt
+    r.data[tenantID] = st
+    return nil
+}
+
+func (r *PrioritizeSettingsRepository) ListAutoPrioritized(ctx context.Context) ([]string, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    out := []string{}
+    for tenantID, st := range r.data {
+        if st.AutoPrioritize {
+            out = append(out, tenantID)
+        }
+    }
+    slices.Sort(out)
+    return out, nil
+}
+
+func (r *PrioritizeSettingsRepository) SaveLastAutoRun(ctx context.Context, tenantID string, at time.Time) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    st, ok := r.data[tenantID]
+    if !ok {
+        return appprioritize.ErrSettingsNotFound
+    }
+    st.LastAutoRunAt = &at
+    r.data[tenantID] = st
+    return nil
+}
+
+func (r *PrioritizeSettingsRepository) purgeTenant(tenantID string, res *apptenant.PurgeResult) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if _, ok := r.data[tenantID]; ok {
+        res.PrioritizeSettings++
+        delete(r.data, tenantID)
+    }
+}
@@ -0,0 +1,159 @@
+package memory
+
+import (
+    "context"
+    "sync"
+    "time"
+
+    appproject "backend/internal/application/project"
+    apptask "backend/internal/application/task"
+    domainproject "backend/internal/domain/project"
+)
+
+// ProjectRepository is an in-memory implementation of the project repository.
+// It shares state with a TaskRepository so project deletion can update tasks.
+type ProjectRepository struct {
+    mu        sync.RWMutex
+    data      map[string]map[string]domainproject.Project // tenantID -> projectID -> Project
+    favorites map[string]map[string]map[string]bool       // tenantID -> userID -> projectID
+    tasks     *TaskRepository
+}
+
+func NewProjectRepository(tasks *TaskRepository) *ProjectRepository {
+    return &ProjectRepository{
+        data:      make(map[string]map[string]domainproject.Project),
+        favorites: make(map[string]map[string]map[string]bool),
+        tasks:     tasks,
+    }
+}
+
+var (
+    _ appproject.Repository = (*ProjectRepository)(nil)
+    _ apptask.ProjectLookup = (*ProjectRepository)(nil)
+)
+
+func (r *ProjectRepository) ListByTenant(ctx context.Context, tenantID string) ([]domainproject.Project, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    m := r.data[tenantID]
+    out := make([]domainproject.Project, 0, len(m))
+    for _, p := range m {
+        out = append(out, p)
+    }
+    return out, nil
+}
+
+func (r *ProjectRepository) Get(ctx context.Context, tenantID, id string) (*domainproject.Project, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    if m, ok := r.data[tenantID]; ok {
+        if p, ok := m[id]; ok {
+            pp := p
+            return &pp, nil
+        }
+    }
+    return nil, appproject.ErrNotFound
+}
+
+func (r *ProjectRepository) ProjectExists(ctx context.Context, tenantID, id string) (bool, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    _, ok := r.data[tenantID][id]
+    return ok, nil
+}
+
+func (r *ProjectRepository) Create(ctx context.Context, p *domainproject.Project) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if _, ok := r.data[p.TenantID]; !ok {
+        r.data[p.TenantID] = make(map[string]domainproject.Project)
+    }
+    r.data[p.TenantID][p.ID] = *p
+    return nil
+}
+
+func (r *ProjectRepository) UpdatePosition(ctx context.Context, tenantID, id string, position float64) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    p, ok := r.data[tenantID][id]
+    if !ok {
+        return appproject.ErrNotFound
+    }
+    p.Position = position
+    p.UpdatedAt = time.Now().UTC()
+    r.data[tenantID][id] = p
+    return nil
+}
+
+func (r *ProjectRepository) SetFavorite(ctx context.Context, tenantID, userID, projectID string, favorite bool) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if !favorite {
+        delete(r.favorites[tenantID][userID], projectID)
+        return nil
+    }
+    if _, ok := r.favorites[tenantID]; !ok {
+        r.favorites[tenantID] = make(map[string]map[string]bool)
+    }
+    if _, ok := r.favorites[tenantID][userID]; !ok {
+        r.favorites[tenantID][userID] = make(map[string]bool)
+    }
+    r.favorites[tenantID][userID][projectID] = true
+    return nil
+}
+
+func (r *ProjectRepository) ListFavoriteIDs(ctx context.Context, tenantID, userID string) ([]string, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    m := r.favorites[tenantID][userID]
+    out := make([]string, 0, len(m))
+    for id := range m {
+        out = append(out, id)
+    }
+    return out, nil
+}
+
+// DeleteWithTasks holds both repository locks for the duration of the call,
+// which stands in for the transaction used by the postgres implementation.
+// Cascaded tasks are removed outright since there is no soft-delete state here.
+func (r *ProjectRepository) DeleteWithTasks(ctx context.Context, tenantID, id string, mode appproject.DeleteMode) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    r.tasks.mu.Lock()
+    defer r.tasks.mu.Unlock()
+
+    if _, ok := r.data[tenantID][id]; !ok {
+        return appproject.ErrNotFound
+    }
+
+    tasks := r.tasks.data[tenantID]
+    var contained []string
+    for taskID, t := range tasks {
+        if t.ProjectID != nil && *t.ProjectID == id {
+            contained = append(contained, taskID)
+        }
+    }
+
+    if len(contained) > 0 {
+        switch mode {
+        case appproject.DeleteModeDetach:
+            for _, taskID := range contained {
+                t := tasks[taskID]
+                t.ProjectID = nil
+                tasks[taskID] = t
+            }
+        case appproject.DeleteModeCascade:
+            for _, taskID := range contained {
+                delete(tasks, taskID)
+            }
+        default:
+            return &appproject.HasTasksError{Count: int64(len(contained))}
+        }
+    }
+
+    for _, favs := range r.favorites[tenantID] {
+        delete(favs, id)
+    }
+    delete(r.data[tenantID], id)
+    return nil
+}
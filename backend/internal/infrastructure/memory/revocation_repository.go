@@ -0,0 +1,69 @@
+package memory
+
+import (
+    "context"
+    "time"
+
+    apprevocation "backend/internal/application/revocation"
+)
+
+// RevocationRepository is an in-memory store of revoked access tokens. It
+// shares the AccountRepository's lock and data, so revoking a user revokes
+// their refresh tokens too.
+type RevocationRepository struct {
+    accounts *AccountRepository
+    tokens   map[string]apprevocation.TokenRevocation // tenantID/tokenID -> revocation
+    users    map[string]apprevocation.UserRevocation  // tenantID/userID -> revocation
+}
+
+func NewRevocationRepository(accounts *AccountRepository) *RevocationRepository {
+    return &RevocationRepository{
+        accounts: accounts,
+        tokens:   make(map[string]apprevocation.TokenRevocation),
+        users:    make(map[string]apprevocation.UserRevocation),
+    }
+}
+
+var _ apprevocation.Repository = (*RevocationRepository)(nil)
+
+func (r *RevocationRepository) RevokeToken(ctx context.Context, rev *apprevocation.TokenRevocation) error {
+    r.accounts.mu.Lock()
+    defer r.accounts.mu.Unlock()
+    key := rev.TenantID + "/" + rev.TokenID
+    if _, ok := r.tokens[key]; !ok {
+        r.tokens[key] = *rev
+    }
+    return nil
+}
+
+func (r *RevocationRepository) RevokeUser(ctx context.Context, rev *apprevocation.UserRevocation) error {
+    r.accounts.mu.Lock()
+    defer r.accounts.mu.Unlock()
+    r.users[rev.TenantID+"/"+rev.UserID] = *rev
+    at := rev.RevokedBefore
+    for id, t := range r.accounts.refresh {
+        if t.TenantID == rev.TenantID && t.UserID == rev.UserID && t.RevokedAt == nil {
+            t.RevokedAt = &at
+            r.accounts.refresh[id] = t
+        }
+    }
+    return nil
+}
+
+func (r *RevocationRepository) ListActive(ctx context.Context, now time.Time) ([]apprevocation.TokenRevocation, []apprevocation.UserRevocation, error) {
+    r.accounts.mu.RLock()
+    defer r.accounts.mu.RUnlock()
+    tokens := []apprevocation.TokenRevocation{}
+    for _, t := range r.tokens {
+        if t.ExpiresAt.After(now) {
+            tokens = append(tokens, t)
+        }
+    }
+    users := []apprevocation.UserRevocation{}
+    for _, u := range r.users {
+        if u.ExpiresAt.After(now) {
+            users = append(users, u)
+        }
+    }
+    return tokens, users, nil
+}
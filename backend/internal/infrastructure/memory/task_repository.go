@@ -2,7 +2,8 @@ package memory
 
 import (
     "context"
-    "errors"
+    "slices"
+    "sort"
     "sync"
     "time"
 
@@ -14,23 +15,46 @@ import (
 type TaskRepository struct {
     mu   sync.RWMutex
     data map[string]map[string]domaintask.Task // tenantID -> taskID -> Task
+    // comments is owned by CommentRepository but guarded by mu, so deleting
+    // a task or purging a tenant can drop its comments atomically.
+    comments map[string]map[string]domaintask.TaskComment // tenantID -> commentID -> comment
+    // attachments is owned by AttachmentRepository, like comments.
+    attachments map[string]map[string]domaintask.TaskAttachment         // tenantID -> attachmentID -> attachment
+    watchers    map[string]map[string]map[string]domaintask.TaskWatcher // tenantID -> taskID -> userID -> watcher
+    // dependencies holds each tenant's dependencies in insertion order.
+    dependencies map[string][]domaintask.TaskDependency
 }
 
 func NewTaskRepository() *TaskRepository {
-    return &TaskRepository{data: make(map[string]map[string]domaintask.Task)}
+    return &TaskRepository{
+        data:         make(map[string]map[string]domaintask.Task),
+        comments:     make(map[string]map[string]domaintask.TaskComment),
+        attachments:  make(map[string]map[string]domaintask.TaskAttachment),
+        watchers:     make(map[string]map[string]map[string]domaintask.TaskWatcher),
+        dependencies: make(map[string][]domaintask.TaskDependency),
+    }
 }
 
 var _ apptask.Repository = (*TaskRepository)(nil)
 
+// ListByTenant returns every task of the tenant, oldest first.
 func (r *TaskRepository) ListByTenant(ctx context.Context, tenantID string) ([]domaintask.Task, error) {
+    items, _, err := r.List(ctx, tenantID, apptask.FilterOptions{}, apptask.SortOptions{}, apptask.ListOptions{})
+    return items, err
+}
+
+// List filters, sorts and pages the tenant's tasks the way the postgres
+// repository does, so tests against memory double as its specification.
+func (r *TaskRepository) List(ctx context.Context, tenantID string, f apptask.FilterOptions, s apptask.SortOptions, page apptask.ListOptions) ([]domaintask.Task, int64, error) {
     r.mu.RLock()
-    defer r.mu.RUnlock()
-    m := r.data[tenantID]
-    out := make([]domaintask.Task, 0, len(m))
-    for _, t := range m {
+    out := make([]domaintask.Task, 0, len(r.data[tenantID]))
+    for _, t := range r.data[tenantID] {
         out = append(out, t)
     }
-    return out, nil
+    r.mu.RUnlock()
+    out = slices.DeleteFunc(out, func(t domaintask.Task) bool { return !f.Matches(t) })
+    slices.SortFunc(out, s.Compare)
+    return page.Window(out), int64(len(out)), nil
 }
 
 func (r *TaskRepository) Get(ctx context.Context, tenantID, id string) (*domaintask.Task, error) {
@@ -42,7 +66,21 @@ func (r *TaskRepository) Get(ctx context.Context, tenantID, id string) (*domaint
             return &tt, nil
         }
     }
-    return nil, errors.New("task not found")
+    return nil, apptask.ErrNotFound
+}
+
+func (r *TaskRepository) GetMany(ctx context.Context, tenantID string, ids []string) ([]domaintask.Task, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    out := make([]domaintask.Task, 0, len(ids))
+    seen := make(map[string]bool, len(ids))
+    for _, id := range ids {
+        if t, ok := r.data[tenantID][id]; ok && !seen[id] {
+            seen[id] = true
+            out = append(out, t)
+        }
+    }
+    return out, nil
 }
 
 func (r *TaskRepository) Create(ctx context.Context, t *domaintask.Task) error {
@@ -55,14 +93,37 @@ func (r *TaskRepository) Create(ctx context.Context, t *domaintask.Task) error {
     return nil
 }
 
-func (r *TaskRepository) Update(ctx context.Context, t *domaintask.Task) error {
+func (r *TaskRepository) Update(ctx context.Context, t *domaintask.Task, fields ...apptask.TaskField) error {
     r.mu.Lock()
     defer r.mu.Unlock()
-    if _, ok := r.data[t.TenantID]; !ok {
-        return errors.New("task not found")
+    stored, ok := r.data[t.TenantID][t.ID]
+    if !ok {
+        return apptask.ErrNotFound
     }
     t.UpdatedAt = time.Now().UTC()
-    r.data[t.TenantID][t.ID] = *t
+    stored.UpdatedAt = t.UpdatedAt
+    for _, f := range fields {
+        switch f {
+        case apptask.FieldTitle:
+            stored.Title = t.Title
+        case apptask.FieldDescription:
+            stored.Description = t.Description
+        case apptask.FieldStatus:
+            stored.Status = t.Status
+            stored.CompletedAt = t.CompletedAt
+        case apptask.FieldPriority:
+            stored.Priority = t.Priority
+        case apptask.FieldDueDate:
+            stored.DueDate = t.DueDate
+        case apptask.FieldSummary:
+            stored.Summary = t.Summary
+        case apptask.FieldProjectID:
+            stored.ProjectID = t.ProjectID
+        case apptask.FieldPosition:
+            stored.Position = t.Position
+        }
+    }
+    r.data[t.TenantID][t.ID] = stored
     return nil
 }
 
@@ -72,9 +133,156 @@ func (r *TaskRepository) Delete(ctx context.Context, tenantID, id string) error
     if m, ok := r.data[tenantID]; ok {
         if _, ok := m[id]; ok {
             delete(m, id)
+            for cid, c := range r.comments[tenantID] {
+                if c.TaskID == id {
+                    delete(r.comments[tenantID], cid)
+                }
+            }
+            for aid, a := range r.attachments[tenantID] {
+                if a.TaskID == id {
+                    delete(r.attachments[tenantID], aid)
+                }
+            }
+            delete(r.watchers[tenantID], id)
+            r.dependencies[tenantID] = slices.DeleteFunc(r.dependencies[tenantID], func(d domaintask.TaskDependency) bool {
+                return d.TaskID == id || d.DependsOnID == id
+            })
+            return nil
+        }
+    }
+    return apptask.ErrNotFound
+}
+
+
+func (r *TaskRepository) BulkAssign(ctx context.Context, tenantID string, ids []string, assigneeID *string) ([]string, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    m := r.data[tenantID]
+    now := time.Now().UTC()
+    updated := make([]string, 0, len(ids))
+    for _, id := range ids {
+        t, ok := m[id]
+        if !ok {
+            continue
+        }
+        t.AssigneeID = assigneeID
+        t.UpdatedAt = now
+        m[id] = t
+        updated = append(updated, id)
+    }
+    return updated, nil
+}
+
+func (r *TaskRepository) UpdateAIScores(ctx context.Context, tenantID string, scores map[string]float64) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    m := r.data[tenantID]
+    for id, score := range scores {
+        t, ok := m[id]
+        if !ok {
+            continue
+        }
+        score := score
+        t.AiScore = &score
+        m[id] = t
+    }
+    return nil
+}
+
+func (r *TaskRepository) ListOpenPage(ctx context.Context, tenantID, afterID string, limit int) ([]domaintask.Task, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    var open []domaintask.Task
+    for _, t := range r.data[tenantID] {
+        if t.Status == domaintask.StatusDone || t.Status == domaintask.StatusArchived || t.ID <= afterID {
+            continue
+        }
+        open = append(open, t)
+    }
+    sort.Slice(open, func(i, j int) bool { return open[i].ID < open[j].ID })
+    if len(open) > limit {
+        open = open[:limit]
+    }
+    return open, nil
+}
+
+func (r *TaskRepository) Watch(ctx context.Context, w *domaintask.TaskWatcher) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if _, ok := r.watchers[w.TenantID]; !ok {
+        r.watchers[w.TenantID] = make(map[string]map[string]domaintask.TaskWatcher)
+    }
+    byUser, ok := r.watchers[w.TenantID][w.TaskID]
+    if !ok {
+        byUser = make(map[string]domaintask.TaskWatcher)
+        r.watchers[w.TenantID][w.TaskID] = byUser
+    }
+    if _, ok := byUser[w.UserID]; !ok {
+        byUser[w.UserID] = *w
+    }
+    return nil
+}
+
+func (r *TaskRepository) Unwatch(ctx context.Context, tenantID, taskID, userID string) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    delete(r.watchers[tenantID][taskID], userID)
+    return nil
+}
+
+func (r *TaskRepository) ListWatchers(ctx context.Context, tenantID, taskID string) ([]domaintask.TaskWatcher, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    out := make([]domaintask.TaskWatcher, 0, len(r.watchers[tenantID][taskID]))
+    for _, w := range r.watchers[tenantID][taskID] {
+        out = append(out, w)
+    }
+    sort.Slice(out, func(i, j int) bool {
+        if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+            return out[i].CreatedAt.Before(out[j].CreatedAt)
+        }
+        return out[i].UserID < out[j].UserID
+    })
+    return out, nil
+}
+
+func (r *TaskRepository) AddDependency(ctx context.Context, d *domaintask.TaskDependency) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    for _, existing := range r.dependencies[d.TenantID] {
+        if existing.TaskID == d.TaskID && existing.DependsOnID == d.DependsOnID {
             return nil
         }
     }
-    return errors.New("task not found")
+    r.dependencies[d.TenantID] = append(r.dependencies[d.TenantID], *d)
+    return nil
+}
+
+func (r *TaskRepository) RemoveDependency(ctx context.Context, tenantID, taskID, dependsOnID string) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    r.dependencies[tenantID] = slices.DeleteFunc(r.dependencies[tenantID], func(d domaintask.TaskDependency) bool {
+        return d.TaskID == taskID && d.DependsOnID == dependsOnID
+    })
+    return nil
+}
+
+func (r *TaskRepository) ListDependencies(ctx context.Context, tenantID, taskID string) ([]domaintask.TaskDependency, error) {
+    return r.filterDependencies(tenantID, func(d domaintask.TaskDependency) bool { return d.TaskID == taskID }), nil
+}
+
+func (r *TaskRepository) ListDependents(ctx context.Context, tenantID, taskID string) ([]domaintask.TaskDependency, error) {
+    return r.filterDependencies(tenantID, func(d domaintask.TaskDependency) bool { return d.DependsOnID == taskID }), nil
 }
 
+func (r *TaskRepository) filterDependencies(tenantID string, keep func(domaintask.TaskDependency) bool) []domaintask.TaskDependency {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    out := []domaintask.TaskDependency{}
+    for _, d := range r.dependencies[tenantID] {
+        if keep(d) {
+            out = append(out, d)
+        }
+    }
+    return out
+}
@@ -0,0 +1,63 @@
+package memory
+
+import (
+    "slices"
+
+    domaintask "backend/internal/domain/task"
+)
+
+// Export returns a deep copy of every stored task, by tenant id and task
+// id, for tests that snapshot the repository or compare it to a golden file
+// without going through List. Changing the copy does not change the
+// repository.
+func (r *TaskRepository) Export() map[string]map[string]domaintask.Task {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    return cloneTaskData(r.data)
+}
+
+// Import replaces every stored task with a deep copy of data, shaped like
+// Export's result, in one step. Comments, attachments, watchers and
+// dependencies are left alone.
+func (r *TaskRepository) Import(data map[string]map[string]domaintask.Task) {
+    data = cloneTaskData(data)
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    r.data = data
+}
+
+func cloneTaskData(data map[string]map[string]domaintask.Task) map[string]map[string]domaintask.Task {
+    out := make(map[string]map[string]domaintask.Task, len(data))
+    for tenantID, tasks := range data {
+        m := make(map[string]domaintask.Task, len(tasks))
+        for id, t := range tasks {
+            m[id] = cloneTask(t)
+        }
+        out[tenantID] = m
+    }
+    return out
+}
+
+// cloneTask copies t, including what its pointer and slice fields point to.
+func cloneTask(t domaintask.Task) domaintask.Task {
+    t.DueDate = clonePtr(t.DueDate)
+    t.AiScore = clonePtr(t.AiScore)
+    t.ProjectID = clonePtr(t.ProjectID)
+    t.ParentID = clonePtr(t.ParentID)
+    t.AssigneeID = clonePtr(t.AssigneeID)
+    t.Tags = slices.Clone(t.Tags)
+    t.Comments = slices.Clone(t.Comments)
+    for i := range t.Comments {
+        t.Comments[i].EditedAt = clonePtr(t.Comments[i].EditedAt)
+    }
+    t.Attachments = slices.Clone(t.Attachments)
+    return t
+}
+
+func clonePtr[T any](p *T) *T {
+    if p == nil {
+        return nil
+    }
+    v := *p
+    return &v
+}
@@ -0,0 +1,67 @@
+package memory_test
+
+import (
+    "context"
+    "testing"
+
+    domaintask "backend/internal/domain/task"
+    "backend/internal/infrastructure/memory"
+)
+
+// Test that Export returns a copy: changing its maps, tasks, tags or
+// pointed-to values leaves the repository as it was.
+func TestTaskRepository_Export_Copies(t *testing.T) {
+    ctx := context.Background()
+    repo := memory.NewTaskRepository()
+    task := domaintask.New("t1", "u1", "write docs", "", 3)
+    project := "p1"
+    task.ProjectID = &project
+    task.Tags = []string{"docs"}
+    if err := repo.Create(ctx, task); err != nil {
+        t.Fatalf("create: %v", err)
+    }
+
+    snap := repo.Export()
+    got := snap["t1"][task.ID]
+    if got.Title != "write docs" || got.ProjectID == nil || *got.ProjectID != "p1" {
+        t.Fatalf("expected the stored task, got %+v", got)
+    }
+    got.Title = "changed"
+    *got.ProjectID = "p2"
+    got.Tags[0] = "changed"
+    snap["t1"][task.ID] = got
+    snap["t2"] = map[string]domaintask.Task{}
+
+    stored, err := repo.Get(ctx, "t1", task.ID)
+    if err != nil {
+        t.Fatalf("get: %v", err)
+    }
+    if stored.Title != "write docs" || *stored.ProjectID != "p1" || stored.Tags[0] != "docs" {
+        t.Fatalf("expected the repository to be unchanged, got %+v", stored)
+    }
+    if _, ok := repo.Export()["t2"]; ok {
+        t.Fatalf("expected tenants added to the export not to reach the repository")
+    }
+}
+
+// Test that Import replaces the stored tasks with a copy of the snapshot.
+func TestTaskRepository_Import(t *testing.T) {
+    ctx := context.Background()
+    repo := memory.NewTaskRepository()
+    old := domaintask.New("t1", "u1", "old", "", 3)
+    repo.Create(ctx, old)
+    task := domaintask.New("t1", "u1", "imported", "", 3)
+    snap := map[string]map[string]domaintask.Task{"t1": {task.ID: *task}}
+
+    repo.Import(snap)
+    task.Title = "changed"
+    snap["t1"][task.ID] = *task
+
+    if _, err := repo.Get(ctx, "t1", old.ID); err == nil {
+        t.Fatalf("expected the old task to be replaced")
+    }
+    got, err := repo.Get(ctx, "t1", task.ID)
+    if err != nil || got.Title != "imported" {
+        t.Fatalf("expected the imported task, got %+v, %v", got, err)
+    }
+}
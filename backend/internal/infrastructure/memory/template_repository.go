@@ -0,0 +1,92 @@
+package memory
+
+import (
+    "context"
+    "slices"
+    "strings"
+    "sync"
+
+    apptemplate "backend/internal/application/template"
+    apptenant "backend/internal/application/tenant"
+    domaintask "backend/internal/domain/task"
+)
+
+// TemplateRepository is an in-memory store of task templates.
+type TemplateRepository struct {
+    mu   sync.RWMutex
+    data map[string]domaintask.TaskTemplate // id -> template
+}
+
+func NewTemplateRepository() *TemplateRepository {
+    return &TemplateRepository{data: make(map[string]domaintask.TaskTemplate)}
+}
+
+var _ apptemplate.Repository = (*TemplateRepository)(nil)
+
+func (r *TemplateRepository) Create(ctx context.Context, t *domaintask.TaskTemplate) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    r.data[t.ID] = cloneTemplate(*t)
+    return nil
+}
+
+func (r *TemplateRepository) ListByTenant(ctx context.Context, tenantID string) ([]domaintask.TaskTemplate, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    out := []domaintask.TaskTemplate{}
+    for _, t := range r.data {
+        if t.TenantID == tenantID {
+            out = append(out, cloneTemplate(t))
+        }
+    }
+    slices.SortFunc(out, func(a, b domaintask.TaskTemplate) int { return strings.Compare(a.Name, b.Name) })
+    return out, nil
+}
+
+func (r *TemplateRepository) Get(ctx context.Context, tenantID, id string) (*domaintask.TaskTemplate, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    t, ok := r.data[id]
+    if !ok || t.TenantID != tenantID {
+        return nil, apptemplate.ErrNotFound
+    }
+    t = cloneTemplate(t)
+    return &t, nil
+}
+
+func (r *TemplateRepository) Update(ctx context.Context, t *domaintask.TaskTemplate) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if cur, ok := r.data[t.ID]; !ok || cur.TenantID != t.TenantID {
+        return apptemplate.ErrNotFound
+    }
+    r.data[t.ID] = cloneTemplate(*t)
+    return nil
+}
+
+func (r *TemplateRepository) Delete(ctx context.Context, tenantID, id string) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if t, ok := r.data[id]; !ok || t.TenantID != tenantID {
+        return apptemplate.ErrNotFound
+    }
+    delete(r.data, id)
+    return nil
+}
+
+// cloneTemplate copies t so callers cannot change stored tags.
+func cloneTemplate(t domaintask.TaskTemplate) domaintask.TaskTemplate {
+    t.Tags = slices.Clone(t.Tags)
+    return t
+}
+
+func (r *TemplateRepository) purgeTenant(tenantID string, res *apptenant.PurgeResult) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    for id, t := range r.data {
+        if t.TenantID == tenantID {
+            res.Templates++
+            delete(r.data, id)
+        }
+    }
+}
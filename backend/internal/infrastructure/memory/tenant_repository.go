@@ -0,0 +1,64 @@
+package memory
+
+import (
+    "context"
+
+    apptenant "backend/internal/application/tenant"
+)
+
+// TenantRepository purges tenant data held by the other in-memory repositories.
+type TenantRepository struct {
+    tasks    *TaskRepository
+    projects *ProjectRepository
+    others   []tenantPurger
+}
+
+// tenantPurger is implemented by the in-memory repositories, other than the
+// task and project ones, whose records a tenant purge also removes.
+type tenantPurger interface {
+    // purgeTenant deletes the tenant's records and adds their counts to res.
+    purgeTenant(tenantID string, res *apptenant.PurgeResult)
+}
+
+func NewTenantRepository(tasks *TaskRepository, projects *ProjectRepository) *TenantRepository {
+    return &TenantRepository{tasks: tasks, projects: projects}
+}
+
+// With makes purges also remove the tenant's records from repos.
+func (r *TenantRepository) With(repos ...tenantPurger) *TenantRepository {
+    r.others = append(r.others, repos...)
+    return r
+}
+
+var _ apptenant.Repository = (*TenantRepository)(nil)
+
+func (r *TenantRepository) PurgeData(ctx context.Context, tenantID string) (apptenant.PurgeResult, error) {
+    r.projects.mu.Lock()
+    defer r.projects.mu.Unlock()
+    r.tasks.mu.Lock()
+    defer r.tasks.mu.Unlock()
+
+    var res apptenant.PurgeResult
+    res.Tasks = int64(len(r.tasks.data[tenantID]))
+    res.Comments = int64(len(r.tasks.comments[tenantID]))
+    res.Attachments = int64(len(r.tasks.attachments[tenantID]))
+    for _, byUser := range r.tasks.watchers[tenantID] {
+        res.Watchers += int64(len(byUser))
+    }
+    res.Dependencies = int64(len(r.tasks.dependencies[tenantID]))
+    res.Projects = int64(len(r.projects.data[tenantID]))
+    for _, favs := range r.projects.favorites[tenantID] {
+        res.ProjectFavorites += int64(len(favs))
+    }
+    delete(r.tasks.data, tenantID)
+    delete(r.tasks.comments, tenantID)
+    delete(r.tasks.attachments, tenantID)
+    delete(r.tasks.watchers, tenantID)
+    delete(r.tasks.dependencies, tenantID)
+    delete(r.projects.data, tenantID)
+    delete(r.projects.favorites, tenantID)
+    for _, o := range r.others {
+        o.purgeTenant(tenantID, &res)
+    }
+    return res, nil
+}
@@ -0,0 +1,47 @@
+package memory
+
+import (
+    "context"
+    "sync"
+
+    apptenant "backend/internal/application/tenant"
+    domaintenant "backend/internal/domain/tenant"
+)
+
+// TenantSettingsRepository is an in-memory store of tenant settings.
+type TenantSettingsRepository struct {
+    mu   sync.RWMutex
+    data map[string]domaintenant.TenantSettings // tenantID -> settings
+}
+
+func NewTenantSettingsRepository() *TenantSettingsRepository {
+    return &TenantSettingsRepository{data: make(map[string]domaintenant.TenantSettings)}
+}
+
+var _ apptenant.SettingsRepository = (*TenantSettingsRepository)(nil)
+
+func (r *TenantSettingsRepository) GetSettings(ctx context.Context, tenantID string) (*domaintenant.TenantSettings, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    st, ok := r.data[tenantID]
+    if !ok {
+        return nil, apptenant.ErrSettingsNotFound
+    }
+    return &st, nil
+}
+
+func (r *TenantSettingsRepository) SaveSettings(ctx context.Context, st *domaintenant.TenantSettings) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    r.data[st.TenantID] = *st
+    return nil
+}
+
+func (r *TenantSettingsRepository) purgeTenant(tenantID string, res *apptenant.PurgeResult) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if _, ok := r.data[tenantID]; ok {
+        res.TenantSettings++
+        delete(r.data, tenantID)
+    }
+}
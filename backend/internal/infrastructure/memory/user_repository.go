@@ -0,0 +1,85 @@
+package memory
+
+import (
+    "context"
+    "sort"
+
+    appuser "backend/internal/application/user"
+    domainaccount "backend/internal/domain/account"
+)
+
+// UserRepository is an in-memory user directory layered on an
+// AccountRepository, whose lock and user map it shares, so registered users
+// are listed too.
+type UserRepository struct {
+    accounts *AccountRepository
+}
+
+func NewUserRepository(accounts *AccountRepository) *UserRepository {
+    return &UserRepository{accounts: accounts}
+}
+
+var _ appuser.Repository = (*UserRepository)(nil)
+
+func (r *UserRepository) GetByID(ctx context.Context, tenantID, id string) (*domainaccount.User, error) {
+    r.accounts.mu.RLock()
+    defer r.accounts.mu.RUnlock()
+    u, ok := r.accounts.users[id]
+    if !ok || u.TenantID != tenantID {
+        return nil, appuser.ErrNotFound
+    }
+    return &u, nil
+}
+
+func (r *UserRepository) GetByEmail(ctx context.Context, tenantID, email string) (*domainaccount.User, error) {
+    r.accounts.mu.RLock()
+    defer r.accounts.mu.RUnlock()
+    u, ok := r.accounts.userByEmail(tenantID, domainaccount.NormalizeEmail(email))
+    if !ok {
+        return nil, appuser.ErrNotFound
+    }
+    return &u, nil
+}
+
+func (r *UserRepository) ListByTenant(ctx context.Context, tenantID string) ([]domainaccount.User, error) {
+    r.accounts.mu.RLock()
+    defer r.accounts.mu.RUnlock()
+    var out []domainaccount.User
+    for _, u := range r.accounts.users {
+        if u.TenantID == tenantID {
+            out = append(out, u)
+        }
+    }
+    sort.Slice(out, func(i, j int) bool {
+        if out[i].DisplayName != out[j].DisplayName {
+            return out[i].DisplayName < out[j].DisplayName
+        }
+        return out[i].ID < out[j].ID
+    })
+    return out, nil
+}
+
+func (r *UserRepository) Create(ctx context.Context, u *domainaccount.User) error {
+    r.accounts.mu.Lock()
+    defer r.accounts.mu.Unlock()
+    if _, ok := r.accounts.userByEmail(u.TenantID, u.Email); ok {
+        return appuser.ErrEmailTaken
+    }
+    r.accounts.users[u.ID] = *u
+    return nil
+}
+
+func (r *UserRepository) Update(ctx context.Context, u *domainaccount.User) error {
+    r.accounts.mu.Lock()
+    defer r.accounts.mu.Unlock()
+    cur, ok := r.accounts.users[u.ID]
+    if !ok || cur.TenantID != u.TenantID {
+        return appuser.ErrNotFound
+    }
+    if other, ok := r.accounts.userByEmail(u.TenantID, u.Email); ok && other.ID != u.ID {
+        return appuser.ErrEmailTaken
+    }
+    cur.Email, cur.DisplayName, cur.Role = u.Email, u.DisplayName, u.Role
+    r.accounts.users[u.ID] = cur
+    return nil
+}
@@ -0,0 +1,33 @@
+package notify
+
+import (
+    "context"
+    "log/slog"
+
+    apptask "backend/internal/application/task"
+)
+
+// LogNotifier writes watcher notifications to the log. It stands in until a
+// delivery channel such as email or push is available.
+type LogNotifier struct {
+    logger *slog.Logger
+}
+
+func NewLogNotifier(logger *slog.Logger) *LogNotifier {
+    if logger == nil {
+        logger = slog.Default()
+    }
+    return &LogNotifier{logger: logger}
+}
+
+var _ apptask.Notifier = (*LogNotifier)(nil)
+
+func (n *LogNotifier) Enqueue(ctx context.Context, note apptask.Notification) error {
+    n.logger.InfoContext(ctx, "watcher notification",
+        "tenant_id", note.TenantID,
+        "task_id", note.TaskID,
+        "user_id", note.UserID,
+        "event", note.Event,
+    )
+    return nil
+}
@@ -0,0 +1,174 @@
+package postgres
+
+import (
+    "context"
+    "errors"
+    "time"
+
+    appaccount "backend/internal/application/account"
+    apptask "backend/internal/application/task"
+    domainaccount "backend/internal/domain/account"
+
+    "gorm.io/gorm"
+)
+
+type AccountRepository struct {
+    db *gorm.DB
+}
+
+func NewAccountRepository(db *gorm.DB) *AccountRepository {
+    return &AccountRepository{db: db}
+}
+
+var (
+    _ appaccount.Repository = (*AccountRepository)(nil)
+    _ apptask.TenantNamer   = (*AccountRepository)(nil)
+)
+
+// CreateTenant inserts the tenant and its owner in one transaction,
+// refusing an email used in any tenant.
+func (r *AccountRepository) CreateTenant(ctx context.Context, t *domainaccount.Tenant, owner *domainaccount.User) error {
+    err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        var n int64
+        if err := tx.Model(&UserRecord{}).Where("email = ?", owner.Email).Count(&n).Error; err != nil {
+            return err
+        }
+        if n > 0 {
+            return appaccount.ErrEmailTaken
+        }
+        if err := tx.Create(&TenantRecord{ID: t.ID, Name: t.Name, CreatedAt: t.CreatedAt}).Error; err != nil {
+            return err
+        }
+        rec := toUserRecord(owner)
+        return tx.Create(&rec).Error
+    })
+    if isUniqueViolation(err) {
+        return appaccount.ErrEmailTaken
+    }
+    return err
+}
+
+func (r *AccountRepository) FindUserByEmail(ctx context.Context, email string) (*domainaccount.User, error) {
+    var rec UserRecord
+    err := r.db.WithContext(ctx).Where("email = ?", email).First(&rec).Error
+    if errors.Is(err, gorm.ErrRecordNotFound) {
+        return nil, appaccount.ErrNotFound
+    }
+    if err != nil {
+        return nil, err
+    }
+    return rec.toDomain(), nil
+}
+
+func (r *AccountRepository) FindUserByID(ctx context.Context, tenantID, id string) (*domainaccount.User, error) {
+    var rec UserRecord
+    err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).First(&rec).Error
+    if errors.Is(err, gorm.ErrRecordNotFound) {
+        return nil, appaccount.ErrNotFound
+    }
+    if err != nil {
+        return nil, err
+    }
+    return rec.toDomain(), nil
+}
+
+// SetPassword updates the hash and revokes the user's refresh tokens in one
+// transaction, so a failed revocation leaves the old password in place.
+func (r *AccountRepository) SetPassword(ctx context.Context, tenantID, userID, hash string, at time.Time) error {
+    return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        res := tx.Model(&UserRecord{}).
+            Where("tenant_id = ? AND id = ?", tenantID, userID).
+            Update("password_hash", hash)
+        if res.Error != nil {
+            return res.Error
+        }
+        if res.RowsAffected == 0 {
+            return appaccount.ErrNotFound
+        }
+        return tx.Model(&RefreshTokenRecord{}).
+            Where("tenant_id = ? AND user_id = ? AND revoked_at IS NULL", tenantID, userID).
+            Update("revoked_at", at).Error
+    })
+}
+
+// TenantName returns the name the tenant registered with, or
+// appaccount.ErrNotFound for tenants created outside registration.
+func (r *AccountRepository) TenantName(ctx context.Context, tenantID string) (string, error) {
+    var rec TenantRecord
+    err := r.db.WithContext(ctx).Select("name").Where("id = ?", tenantID).First(&rec).Error
+    if errors.Is(err, gorm.ErrRecordNotFound) {
+        return "", appaccount.ErrNotFound
+    }
+    if err != nil {
+        return "", err
+    }
+    return rec.Name, nil
+}
+
+func (r *AccountRepository) CreateRefreshToken(ctx context.Context, t *domainaccount.RefreshToken) error {
+    rec := RefreshTokenRecord{
+        ID:        t.ID,
+        FamilyID:  t.FamilyID,
+        TenantID:  t.TenantID,
+        UserID:    t.UserID,
+        TokenHash: t.Hash,
+        CreatedAt: t.CreatedAt,
+        ExpiresAt: t.ExpiresAt,
+        RotatedAt: t.RotatedAt,
+        RevokedAt: t.RevokedAt,
+    }
+    return r.db.WithContext(ctx).Create(&rec).Error
+}
+
+func (r *AccountRepository) FindRefreshToken(ctx context.Context, hash string) (*domainaccount.RefreshToken, error) {
+    var rec RefreshTokenRecord
+    err := r.db.WithContext(ctx).Where("token_hash = ?", hash).First(&rec).Error
+    if errors.Is(err, gorm.ErrRecordNotFound) {
+        return nil, appaccount.ErrInvalidRefreshToken
+    }
+    if err != nil {
+        return nil, err
+    }
+    t := domainaccount.RefreshToken{
+        ID:        rec.ID,
+        FamilyID:  rec.FamilyID,
+        TenantID:  rec.TenantID,
+        UserID:    rec.UserID,
+        Hash:      rec.TokenHash,
+        CreatedAt: rec.CreatedAt.UTC(),
+        ExpiresAt: rec.ExpiresAt.UTC(),
+        RotatedAt: rec.RotatedAt,
+        RevokedAt: rec.RevokedAt,
+    }
+    return &t, nil
+}
+
+// RotateRefreshToken only marks oldID rotated while it is neither rotated
+// nor revoked, so of two concurrent exchanges of one token only the first
+// succeeds.
+func (r *AccountRepository) RotateRefreshToken(ctx context.Context, oldID string, next *domainaccount.RefreshToken, at time.Time) error {
+    return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        res := tx.Model(&RefreshTokenRecord{}).
+            Where("id = ? AND rotated_at IS NULL AND revoked_at IS NULL", oldID).
+            Update("rotated_at", at)
+        if res.Error != nil {
+            return res.Error
+        }
+        if res.RowsAffected == 0 {
+            return appaccount.ErrRefreshTokenReused
+        }
+        return NewAccountRepository(tx).CreateRefreshToken(ctx, next)
+    })
+}
+
+func (r *AccountRepository) RevokeRefreshToken(ctx context.Context, id string, at time.Time) error {
+    return r.db.WithContext(ctx).Model(&RefreshTokenRecord{}).
+        Where("id = ? AND revoked_at IS NULL", id).
+        Update("revoked_at", at).Error
+}
+
+func (r *AccountRepository) RevokeRefreshFamily(ctx context.Context, familyID string, at time.Time) error {
+    return r.db.WithContext(ctx).Model(&RefreshTokenRecord{}).
+        Where("family_id = ? AND revoked_at IS NULL", familyID).
+        Update("revoked_at", at).Error
+}
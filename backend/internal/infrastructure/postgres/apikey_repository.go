@@ -0,0 +1,98 @@
+package postgres
+
+import (
+    "context"
+    "errors"
+    "time"
+
+    appapikey "backend/internal/application/apikey"
+    domainapikey "backend/internal/domain/apikey"
+
+    "github.com/google/uuid"
+    "gorm.io/gorm"
+)
+
+type APIKeyRepository struct {
+    db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) *APIKeyRepository {
+    return &APIKeyRepository{db: db}
+}
+
+var _ appapikey.Repository = (*APIKeyRepository)(nil)
+
+func (r *APIKeyRepository) Create(ctx context.Context, k *domainapikey.APIKey) error {
+    rec := APIKeyRecord{
+        ID:         k.ID,
+        TenantID:   k.TenantID,
+        UserID:     k.UserID,
+        Name:       k.Name,
+        Prefix:     k.Prefix,
+        KeyHash:    k.Hash,
+        CreatedAt:  k.CreatedAt,
+        LastUsedAt: k.LastUsedAt,
+        RevokedAt:  k.RevokedAt,
+    }
+    return r.db.WithContext(ctx).Create(&rec).Error
+}
+
+func (r *APIKeyRepository) FindByHash(ctx context.Context, hash string) (*domainapikey.APIKey, error) {
+    var rec APIKeyRecord
+    err := r.db.WithContext(ctx).Where("key_hash = ?", hash).First(&rec).Error
+    if errors.Is(err, gorm.ErrRecordNotFound) {
+        return nil, appapikey.ErrNotFound
+    }
+    if err != nil {
+        return nil, err
+    }
+    k := apiKeyToDomain(rec)
+    return &k, nil
+}
+
+func (r *APIKeyRepository) ListByTenant(ctx context.Context, tenantID string) ([]domainapikey.APIKey, error) {
+    var recs []APIKeyRecord
+    if err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).Order("created_at, id").Find(&recs).Error; err != nil {
+        return nil, err
+    }
+    out := make([]domainapikey.APIKey, 0, len(recs))
+    for _, rec := range recs {
+        out = append(out, apiKeyToDomain(rec))
+    }
+    return out, nil
+}
+
+func (r *APIKeyRepository) Revoke(ctx context.Context, tenantID, id string, at time.Time) error {
+    if _, err := uuid.Parse(id); err != nil {
+        return appapikey.ErrNotFound
+    }
+    return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        var rec APIKeyRecord
+        err := tx.Where("tenant_id = ? AND id = ?", tenantID, id).First(&rec).Error
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            return appapikey.ErrNotFound
+        }
+        if err != nil || rec.RevokedAt != nil {
+            return err
+        }
+        return tx.Model(&APIKeyRecord{}).Where("id = ?", id).Update("revoked_at", at).Error
+    })
+}
+
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, id string, at time.Time) error {
+    return r.db.WithContext(ctx).Model(&APIKeyRecord{}).Where("id = ?", id).Update("last_used_at", at).Error
+}
+
+func apiKeyToDomain(rec APIKeyRecord) domainapikey.APIKey {
+    return domainapikey.APIKey{
+        ID:         rec.ID,
+        TenantID:   rec.TenantID,
+        UserID:     rec.UserID,
+        Name:       rec.Name,
+        Prefix:     rec.Prefix,
+        Hash:       rec.KeyHash,
+        CreatedAt:  rec.CreatedAt,
+        LastUsedAt: rec.LastUsedAt,
+        RevokedAt:  rec.RevokedAt,
+    }
+}
@@ -0,0 +1,72 @@
+package postgres
+
+import (
+    "context"
+
+    appattachment "backend/internal/application/attachment"
+    domaintask "backend/internal/domain/task"
+
+    "gorm.io/gorm"
+)
+
+type AttachmentRepository struct {
+    db *gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) *AttachmentRepository {
+    return &AttachmentRepository{db: db}
+}
+
+var _ appattachment.Repository = (*AttachmentRepository)(nil)
+
+func (r *AttachmentRepository) Create(ctx context.Context, a *domaintask.TaskAttachment) error {
+    return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        ok, err := taskExists(tx, a.TenantID, a.TaskID)
+        if err != nil {
+            return err
+        }
+        if !ok {
+            return appattachment.ErrTaskNotFound
+        }
+        rec := TaskAttachmentRecord{
+            ID:        a.ID,
+            TenantID:  a.TenantID,
+            TaskID:    a.TaskID,
+            Name:      a.Name,
+            URL:       a.URL,
+            FileType:  a.FileType,
+            Size:      a.Size,
+            CreatedAt: a.CreatedAt,
+        }
+        return tx.Create(&rec).Error
+    })
+}
+
+func (r *AttachmentRepository) ListByTask(ctx context.Context, tenantID, taskID string) ([]domaintask.TaskAttachment, error) {
+    db := r.db.WithContext(ctx)
+    ok, err := taskExists(db, tenantID, taskID)
+    if err != nil {
+        return nil, err
+    }
+    if !ok {
+        return nil, appattachment.ErrTaskNotFound
+    }
+    var recs []TaskAttachmentRecord
+    if err := db.Where("tenant_id = ? AND task_id = ?", tenantID, taskID).Order("created_at, id").Find(&recs).Error; err != nil {
+        return nil, err
+    }
+    out := make([]domaintask.TaskAttachment, 0, len(recs))
+    for _, rec := range recs {
+        out = append(out, domaintask.TaskAttachment{
+            ID:        rec.ID,
+            TenantID:  rec.TenantID,
+            TaskID:    rec.TaskID,
+            Name:      rec.Name,
+            URL:       rec.URL,
+            FileType:  rec.FileType,
+            Size:      rec.Size,
+            CreatedAt: rec.CreatedAt.UTC(),
+        })
+    }
+    return out, nil
+}
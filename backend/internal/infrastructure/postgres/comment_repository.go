@@ -0,0 +1,148 @@
+package postgres
+
+import (
+    "context"
+    "errors"
+
+    appcomment "backend/internal/application/comment"
+    domaintask "backend/internal/domain/task"
+
+    "github.com/google/uuid"
+    "gorm.io/gorm"
+)
+
+type CommentRepository struct {
+    db *gorm.DB
+}
+
+func NewCommentRepository(db *gorm.DB) *CommentRepository {
+    return &CommentRepository{db: db}
+}
+
+var _ appcomment.Repository = (*CommentRepository)(nil)
+
+func toCommentRecord(c *domaintask.TaskComment) TaskCommentRecord {
+    return TaskCommentRecord{
+        ID:        c.ID,
+        TenantID:  c.TenantID,
+        TaskID:    c.TaskID,
+        Author:    c.Author,
+        Internal:  c.Internal,
+        Content:   c.Content,
+        CreatedAt: c.CreatedAt,
+        EditedAt:  c.EditedAt,
+    }
+}
+
+// toCommentDomain maps a record to a comment. Timestamps come back in the
+// session time zone and are normalized to UTC, as the service sets them.
+func toCommentDomain(r TaskCommentRecord) domaintask.TaskComment {
+    c := domaintask.TaskComment{
+        ID:        r.ID,
+        TenantID:  r.TenantID,
+        TaskID:    r.TaskID,
+        Author:    r.Author,
+        Internal:  r.Internal,
+        Content:   r.Content,
+        CreatedAt: r.CreatedAt.UTC(),
+    }
+    if r.EditedAt != nil {
+        edited := r.EditedAt.UTC()
+        c.EditedAt = &edited
+    }
+    return c
+}
+
+// taskExists reports whether taskID is a live task of the tenant. Invalid
+// uuids are reported as missing rather than failing the cast.
+func taskExists(tx *gorm.DB, tenantID, taskID string) (bool, error) {
+    if _, err := uuid.Parse(taskID); err != nil {
+        return false, nil
+    }
+    var n int64
+    err := tx.Model(&TaskRecord{}).Where("tenant_id = ? AND id = ?", tenantID, taskID).Count(&n).Error
+    return n > 0, err
+}
+
+func (r *CommentRepository) Create(ctx context.Context, c *domaintask.TaskComment) error {
+    return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        ok, err := taskExists(tx, c.TenantID, c.TaskID)
+        if err != nil {
+            return err
+        }
+        if !ok {
+            return appcomment.ErrTaskNotFound
+        }
+        rec := toCommentRecord(c)
+        return tx.Create(&rec).Error
+    })
+}
+
+func (r *CommentRepository) ListByTask(ctx context.Context, tenantID, taskID string) ([]domaintask.TaskComment, error) {
+    db := r.db.WithContext(ctx)
+    ok, err := taskExists(db, tenantID, taskID)
+    if err != nil {
+        return nil, err
+    }
+    if !ok {
+        return nil, appcomment.ErrTaskNotFound
+    }
+    var recs []TaskCommentRecord
+    if err := db.Where("tenant_id = ? AND task_id = ?", tenantID, taskID).Order("created_at, id").Find(&recs).Error; err != nil {
+        return nil, err
+    }
+    out := make([]domaintask.TaskComment, 0, len(recs))
+    for _, rec := range recs {
+        out = append(out, toCommentDomain(rec))
+    }
+    return out, nil
+}
+
+// Get returns a comment of a live task; the comments of a soft-deleted task
+// are kept but reported as not found, as the memory store drops them.
+func (r *CommentRepository) Get(ctx context.Context, tenantID, taskID, id string) (*domaintask.TaskComment, error) {
+    if len(validUUIDs([]string{taskID, id})) != 2 {
+        return nil, appcomment.ErrNotFound
+    }
+    db := r.db.WithContext(ctx)
+    ok, err := taskExists(db, tenantID, taskID)
+    if err != nil {
+        return nil, err
+    }
+    if !ok {
+        return nil, appcomment.ErrNotFound
+    }
+    var rec TaskCommentRecord
+    err = db.Where("tenant_id = ? AND task_id = ? AND id = ?", tenantID, taskID, id).First(&rec).Error
+    if errors.Is(err, gorm.ErrRecordNotFound) {
+        return nil, appcomment.ErrNotFound
+    }
+    if err != nil {
+        return nil, err
+    }
+    c := toCommentDomain(rec)
+    return &c, nil
+}
+
+// UpdateContent edits a comment of a live task, like Get.
+func (r *CommentRepository) UpdateContent(ctx context.Context, c *domaintask.TaskComment) error {
+    return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        ok, err := taskExists(tx, c.TenantID, c.TaskID)
+        if err != nil {
+            return err
+        }
+        if !ok {
+            return appcomment.ErrNotFound
+        }
+        res := tx.Model(&TaskCommentRecord{}).
+            Where("tenant_id = ? AND task_id = ? AND id = ?", c.TenantID, c.TaskID, c.ID).
+            Updates(map[string]any{"content": c.Content, "edited_at": c.EditedAt})
+        if res.Error != nil {
+            return res.Error
+        }
+        if res.RowsAffected == 0 {
+            return appcomment.ErrNotFound
+        }
+        return nil
+    })
+}
@@ -0,0 +1,34 @@
+package postgres
+
+import (
+    "context"
+    "errors"
+    "strings"
+    "testing"
+
+    appcomment "backend/internal/application/comment"
+
+    "gorm.io/gorm"
+)
+
+// Test that Get only finds comments of live tasks: it checks the task with
+// soft-deleted rows excluded, and reports the comment missing when the task
+// is gone.
+func TestCommentRepository_Get_ChecksLiveTask(t *testing.T) {
+    db := newDryRunDB(t)
+    var stmts []string
+    if err := db.Callback().Query().After("gorm:query").Register("test:capture", func(tx *gorm.DB) {
+        stmts = append(stmts, tx.Statement.SQL.String())
+    }); err != nil {
+        t.Fatalf("register: %v", err)
+    }
+
+    const taskID, id = "11111111-1111-1111-1111-111111111111", "22222222-2222-2222-2222-222222222222"
+    // A dry run counts no rows, as if the task had been deleted.
+    if _, err := NewCommentRepository(db).Get(context.Background(), "t1", taskID, id); !errors.Is(err, appcomment.ErrNotFound) {
+        t.Fatalf("expected ErrNotFound, got %v", err)
+    }
+    if len(stmts) != 1 || !strings.HasPrefix(stmts[0], "SELECT count(*)") || !strings.Contains(stmts[0], `"deleted_at" IS NULL`) {
+        t.Fatalf("expected one live-task check, got %q", stmts)
+    }
+}
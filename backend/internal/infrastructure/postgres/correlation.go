@@ -0,0 +1,52 @@
+package postgres
+
+import (
+    "backend/internal/pkg/ctxkeys"
+    "backend/internal/pkg/requestid"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+)
+
+// correlationClauses are the leading clauses a comment can be attached to,
+// one per statement kind GORM builds.
+var correlationClauses = []string{"SELECT", "INSERT", "UPDATE", "DELETE"}
+
+// registerCorrelationComment prefixes every statement issued with a context
+// carrying a correlation ID with /* correlation_id=... */, so the ID shows up
+// in pg_stat_activity and the server's statement log next to the query.
+func registerCorrelationComment(db *gorm.DB) error {
+    cb := db.Callback()
+    const name = "mauflow:correlation_comment"
+    if err := cb.Query().Before("gorm:query").Register(name, tagCorrelation); err != nil {
+        return err
+    }
+    if err := cb.Create().Before("gorm:create").Register(name, tagCorrelation); err != nil {
+        return err
+    }
+    if err := cb.Update().Before("gorm:update").Register(name, tagCorrelation); err != nil {
+        return err
+    }
+    if err := cb.Delete().Before("gorm:delete").Register(name, tagCorrelation); err != nil {
+        return err
+    }
+    return cb.Row().Before("gorm:row").Register(name, tagCorrelation)
+}
+
+func tagCorrelation(db *gorm.DB) {
+    if db.Statement == nil || db.Statement.Context == nil {
+        return
+    }
+    // Comments can't be parameterised, so IDs that could close one, or
+    // anything else outside the request ID alphabet, get no comment at all.
+    id := ctxkeys.CorrelationIDFromCtx(db.Statement.Context)
+    if !requestid.Valid(id) {
+        return
+    }
+    comment := clause.Expr{SQL: "/* correlation_id=" + id + " */"}
+    for _, name := range correlationClauses {
+        c := db.Statement.Clauses[name]
+        c.BeforeExpression = comment
+        db.Statement.Clauses[name] = c
+    }
+}
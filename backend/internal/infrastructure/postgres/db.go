@@ -3,17 +3,47 @@ package postgres
 import (
     "backend/internal/pkg/config"
     "fmt"
+    "log/slog"
+    "net/url"
+    "regexp"
+    "strings"
+    "time"
 
     "gorm.io/driver/postgres"
     "gorm.io/gorm"
 )
 
+// maxConnectBackoff caps the wait between connection attempts at startup.
+const maxConnectBackoff = 30 * time.Second
+
+// Connect opens the database, waiting for it to accept connections for up
+// to DB_CONNECT_ATTEMPTS tries, and migrates the schema.
 func Connect(cfg config.Config) (*gorm.DB, error) {
     dsn := cfg.DatabaseDSN()
+    if strings.TrimSpace(cfg.DatabaseURL) != "" {
+        slog.Info("connecting to database", "url", MaskDSN(dsn))
+    } else {
+        slog.Info("connecting to database", "host", cfg.DBHost, "port", cfg.DBPort, "db", cfg.DBName)
+    }
 
-    db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+    // gorm.Open pings the database, so an attempt only succeeds once it
+    // accepts connections
+    policy := RetryPolicy{Attempts: cfg.DBConnectAttempts, Backoff: time.Duration(cfg.DBConnectBackoffMS) * time.Millisecond}
+    db, err := openWithRetry(policy, func() (*gorm.DB, error) {
+        db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+        if err != nil && db != nil {
+            if sqlDB, derr := db.DB(); derr == nil {
+                _ = sqlDB.Close()
+            }
+        }
+        return db, err
+    }, time.Sleep)
     if err != nil {
-        return nil, fmt.Errorf("open db: %w", err)
+        return nil, fmt.Errorf("open db %s: %w", MaskDSN(dsn), err)
+    }
+
+    if err := registerCorrelationComment(db); err != nil {
+        return nil, fmt.Errorf("register callbacks: %w", err)
     }
 
 	sqlDB, err := db.DB()
@@ -23,9 +53,66 @@ func Connect(cfg config.Config) (*gorm.DB, error) {
 	sqlDB.SetMaxIdleConns(5)
 	sqlDB.SetMaxOpenConns(20)
 
-    if err := db.AutoMigrate(&TaskRecord{}); err != nil {
+    if err := db.AutoMigrate(&TaskRecord{}, &TaskCommentRecord{}, &TaskAttachmentRecord{}, &TaskWatcherRecord{}, &TaskDependencyRecord{}, &TaskTemplateRecord{}, &ProjectRecord{}, &ProjectFavoriteRecord{}, &PrioritizeSettingsRecord{}, &APIKeyRecord{}, &FeatureFlagRecord{}, &TenantSettingsRecord{}, &TenantRecord{}, &UserRecord{}, &RefreshTokenRecord{}, &RevokedTokenRecord{}, &RevokedUserRecord{}); err != nil {
         return nil, fmt.Errorf("automigrate: %w", err)
     }
 
     return db, nil
 }
+
+// openWithRetry calls open until it succeeds or p.Attempts are used up,
+// sleeping p.Backoff after the first failure and twice as long after each
+// further one, up to maxConnectBackoff. Every failed attempt is logged.
+func openWithRetry(p RetryPolicy, open func() (*gorm.DB, error), sleep func(time.Duration)) (*gorm.DB, error) {
+    wait := p.Backoff
+    for attempt := 1; ; attempt++ {
+        db, err := open()
+        if err == nil {
+            if attempt > 1 {
+                slog.Info("database reachable", "attempt", attempt)
+            }
+            return db, nil
+        }
+        if attempt >= p.Attempts {
+            return nil, fmt.Errorf("after %d attempts: %w", attempt, err)
+        }
+        slog.Warn("database not reachable, retrying", "attempt", attempt, "attempts", p.Attempts, "retry_in", wait, "error", err)
+        sleep(wait)
+        wait = min(wait*2, maxConnectBackoff)
+    }
+}
+
+// maskedPassword replaces passwords in DSNs passed to MaskDSN.
+const maskedPassword = "***"
+
+var (
+    // dsnUserinfo matches the user:password@ part of a URL that url.Parse
+    // rejects; the greedy match runs to the last @, which ends the userinfo
+    // even when the password itself contains one.
+    dsnUserinfo = regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9+.-]*://[^:/@]*:).*@`)
+    // dsnKeyword matches password=... in a keyword/value DSN, quoted or not.
+    dsnKeyword = regexp.MustCompile(`(?i)\b(password\s*=\s*)(?:'(?:[^'\\]|\\.)*'|[^\s&]*)`)
+)
+
+// MaskDSN returns dsn with its password replaced by ***, so it can be logged
+// or put in an error. It handles postgres:// URLs, including a password in
+// the query string, and keyword/value strings such as
+// "host=db password=secret"; a DSN without a password is returned as is.
+func MaskDSN(dsn string) string {
+    if !strings.Contains(dsn, "://") {
+        return dsnKeyword.ReplaceAllString(dsn, "${1}"+maskedPassword)
+    }
+    u, err := url.Parse(dsn)
+    if err != nil {
+        masked := dsnUserinfo.ReplaceAllString(dsn, "${1}"+maskedPassword+"@")
+        return dsnKeyword.ReplaceAllString(masked, "${1}"+maskedPassword)
+    }
+    u.RawQuery = dsnKeyword.ReplaceAllString(u.RawQuery, "${1}"+maskedPassword)
+    if _, ok := u.User.Password(); !ok {
+        return u.String()
+    }
+    // Rebuild the userinfo by hand: url.UserPassword would escape the mask.
+    user := url.User(u.User.Username()).String()
+    u.User = nil
+    return u.Scheme + "://" + user + ":" + maskedPassword + "@" + strings.TrimPrefix(u.String(), u.Scheme+"://")
+}
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+    "errors"
+    "strings"
+    "testing"
+    "time"
+
+    "gorm.io/gorm"
+)
+
+// Test that MaskDSN hides the password of URL and keyword DSNs, including
+// passwords with special characters, and leaves DSNs without one alone.
+func TestMaskDSN(t *testing.T) {
+    for _, tc := range []struct {
+        name, dsn, want string
+    }{
+        {"url", "postgres://app:s3cret@db:5432/tasks?sslmode=disable", "postgres://app:***@db:5432/tasks?sslmode=disable"},
+        {"url without password", "postgres://app@db:5432/tasks", "postgres://app@db:5432/tasks"},
+        {"url without userinfo", "postgres://db:5432/tasks?sslmode=disable", "postgres://db:5432/tasks?sslmode=disable"},
+        {"escaped special characters", "postgres://app:p%40ss%3Aw%2Frd@db/tasks", "postgres://app:***@db/tasks"},
+        {"unescaped special characters", "postgresql://app:p@ss#w/rd%zz@db/tasks", "postgresql://app:***@db/tasks"},
+        {"password in query", "postgres://db/tasks?user=app&password=s3cret", "postgres://db/tasks?user=app&password=***"},
+        {"keyword", "host=db user=app password=s3cret dbname=tasks", "host=db user=app password=*** dbname=tasks"},
+        {"quoted keyword", `host=db password='it\'s a secret' dbname=tasks`, "host=db password=*** dbname=tasks"},
+        {"keyword without password", "host=db user=app dbname=tasks", "host=db user=app dbname=tasks"},
+    } {
+        got := MaskDSN(tc.dsn)
+        if got != tc.want {
+            t.Fatalf("%s: expected %q, got %q", tc.name, tc.want, got)
+        }
+        if strings.Contains(got, "s3cret") || strings.Contains(got, "secret") {
+            t.Fatalf("%s: password leaked in %q", tc.name, got)
+        }
+    }
+}
+
+// Test that opening is retried while the database is not up, with doubling
+// waits, and that it gives up after the configured attempts.
+func TestOpenWithRetry(t *testing.T) {
+    failing := func(n int) (func() (*gorm.DB, error), *int) {
+        calls := 0
+        return func() (*gorm.DB, error) {
+            calls++
+            if calls <= n {
+                return nil, errors.New("connection refused")
+            }
+            return &gorm.DB{}, nil
+        }, &calls
+    }
+    var waits []time.Duration
+    sleep := func(d time.Duration) { waits = append(waits, d) }
+
+    open, calls := failing(3)
+    db, err := openWithRetry(RetryPolicy{Attempts: 5, Backoff: 20 * time.Second}, open, sleep)
+    if err != nil || db == nil {
+        t.Fatalf("expected to connect on the fourth attempt, got %v", err)
+    }
+    if *calls != 4 {
+        t.Fatalf("expected 4 attempts, got %d", *calls)
+    }
+    if want := []time.Duration{20 * time.Second, 30 * time.Second, 30 * time.Second}; len(waits) != 3 || waits[0] != want[0] || waits[1] != want[1] || waits[2] != want[2] {
+        t.Fatalf("expected waits %v, got %v", want, waits)
+    }
+
+    open, calls = failing(3)
+    if _, err := openWithRetry(RetryPolicy{Attempts: 3, Backoff: time.Millisecond}, open, sleep); err == nil || !strings.Contains(err.Error(), "connection refused") {
+        t.Fatalf("expected the last error after 3 attempts, got %v", err)
+    }
+    if *calls != 3 {
+        t.Fatalf("expected 3 attempts, got %d", *calls)
+    }
+}
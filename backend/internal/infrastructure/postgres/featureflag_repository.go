@@ -0,0 +1,59 @@
+package postgres
+
+import (
+    "context"
+    "errors"
+
+    appfeatureflag "backend/internal/application/featureflag"
+    domainfeatureflag "backend/internal/domain/featureflag"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+)
+
+type FeatureFlagRepository struct {
+    db *gorm.DB
+}
+
+func NewFeatureFlagRepository(db *gorm.DB) *FeatureFlagRepository {
+    return &FeatureFlagRepository{db: db}
+}
+
+var _ appfeatureflag.Repository = (*FeatureFlagRepository)(nil)
+
+func (r *FeatureFlagRepository) Get(ctx context.Context, tenantID, name string) (*domainfeatureflag.FeatureFlag, error) {
+    var rec FeatureFlagRecord
+    err := r.db.WithContext(ctx).Where("tenant_id = ? AND name = ?", tenantID, name).First(&rec).Error
+    if errors.Is(err, gorm.ErrRecordNotFound) {
+        return nil, appfeatureflag.ErrNotFound
+    }
+    if err != nil {
+        return nil, err
+    }
+    f := featureFlagToDomain(rec)
+    return &f, nil
+}
+
+func (r *FeatureFlagRepository) ListByTenant(ctx context.Context, tenantID string) ([]domainfeatureflag.FeatureFlag, error) {
+    var recs []FeatureFlagRecord
+    if err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).Order("name").Find(&recs).Error; err != nil {
+        return nil, err
+    }
+    out := make([]domainfeatureflag.FeatureFlag, 0, len(recs))
+    for _, rec := range recs {
+        out = append(out, featureFlagToDomain(rec))
+    }
+    return out, nil
+}
+
+func (r *FeatureFlagRepository) Save(ctx context.Context, f *domainfeatureflag.FeatureFlag) error {
+    rec := FeatureFlagRecord{TenantID: f.TenantID, Name: f.Name, Enabled: f.Enabled, UpdatedAt: f.UpdatedAt}
+    return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+        Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "name"}},
+        DoUpdates: clause.AssignmentColumns([]string{"enabled", "updated_at"}),
+    }).Create(&rec).Error
+}
+
+func featureFlagToDomain(rec FeatureFlagRecord) domainfeatureflag.FeatureFlag {
+    return domainfeatureflag.FeatureFlag{Name: rec.Name, TenantID: rec.TenantID, Enabled: rec.Enabled, UpdatedAt: rec.UpdatedAt}
+}
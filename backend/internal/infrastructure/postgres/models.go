@@ -2,6 +2,8 @@ package postgres
 
 import (
     "time"
+
+    "gorm.io/gorm"
 )
 
 // TaskRecord is the GORM persistence model for tasks.
@@ -11,12 +13,241 @@ type TaskRecord struct {
     TenantID string `gorm:"type:varchar(64);index;not null"`
     UserID   string `gorm:"type:varchar(64);index;not null"`
 
-    Title       string `gorm:"type:varchar(255);not null"`
-    Description string `gorm:"type:text"`
-    Status      string `gorm:"type:varchar(20);not null;default:'todo'"`
-    Priority    int    `gorm:"not null;default:0"`
+    Title       string     `gorm:"type:varchar(255);not null"`
+    Description string     `gorm:"type:text"`
+    Summary     string     `gorm:"type:varchar(280)"`
+    Status      string     `gorm:"type:varchar(20);not null;default:'todo'"`
+    Priority    int        `gorm:"not null;default:0"`
+    DueDate     *time.Time `gorm:"index"`
+    AiScore     *float64   `gorm:"column:ai_score;index"`
+    ProjectID   *string    `gorm:"type:uuid;index"`
+    ParentID    *string    `gorm:"type:uuid;index"`
+    AssigneeID  *string    `gorm:"type:varchar(64);index"`
+    Tags        []string   `gorm:"type:jsonb;serializer:json"`
+    Position    int        `gorm:"not null;default:0"`
+    CompletedAt *time.Time
+
+    CreatedAt time.Time `gorm:"not null"`
+    UpdatedAt time.Time `gorm:"not null"`
+    // DeletedAt is only set by deleting a project with mode=cascade. GORM
+    // then leaves the row out of every query, count and update; deleting a
+    // task and purging a tenant remove rows outright with Unscoped.
+    DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+// TaskCommentRecord is the GORM persistence model for task comments.
+type TaskCommentRecord struct {
+    ID       string `gorm:"type:uuid;primaryKey"`
+    TenantID string `gorm:"type:varchar(64);index;not null"`
+    TaskID   string `gorm:"type:uuid;index;not null"`
+    Author   string `gorm:"type:varchar(64);not null"`
+    Internal bool   `gorm:"not null;default:false"`
+
+    Content string `gorm:"type:text;not null"`
+
+    CreatedAt time.Time  `gorm:"not null"`
+    EditedAt  *time.Time
+}
+
+func (TaskCommentRecord) TableName() string { return "task_comments" }
+
+// TaskAttachmentRecord is the GORM persistence model for task attachments;
+// the files themselves live in a blob store.
+type TaskAttachmentRecord struct {
+    ID       string `gorm:"type:uuid;primaryKey"`
+    TenantID string `gorm:"type:varchar(64);index;not null"`
+    TaskID   string `gorm:"type:uuid;index;not null"`
+
+    Name     string `gorm:"type:text;not null;default:''"`
+    URL      string `gorm:"type:text;not null"`
+    FileType string `gorm:"type:varchar(255);not null"`
+    Size     int64  `gorm:"not null;default:0"`
+
+    CreatedAt time.Time `gorm:"not null"`
+}
+
+func (TaskAttachmentRecord) TableName() string { return "task_attachments" }
+
+// TaskWatcherRecord subscribes one user to changes of a task.
+type TaskWatcherRecord struct {
+    TenantID string `gorm:"type:varchar(64);primaryKey"`
+    TaskID   string `gorm:"type:uuid;primaryKey;index"`
+    UserID   string `gorm:"type:varchar(64);primaryKey"`
+
+    CreatedAt time.Time `gorm:"not null"`
+}
+
+func (TaskWatcherRecord) TableName() string { return "task_watchers" }
+
+// TaskDependencyRecord records that the task DependsOnID blocks TaskID.
+type TaskDependencyRecord struct {
+    TenantID    string `gorm:"type:varchar(64);primaryKey"`
+    TaskID      string `gorm:"type:uuid;primaryKey"`
+    DependsOnID string `gorm:"type:uuid;primaryKey;index"`
+
+    CreatedAt time.Time `gorm:"not null"`
+}
+
+func (TaskDependencyRecord) TableName() string { return "task_dependencies" }
+
+// ProjectRecord is the GORM persistence model for projects.
+type ProjectRecord struct {
+    ID       string `gorm:"type:uuid;primaryKey"`
+    TenantID string `gorm:"type:varchar(64);index;not null"`
+
+    Name        string  `gorm:"type:varchar(255);not null"`
+    Description string  `gorm:"type:text"`
+    Position    float64 `gorm:"not null;default:0;index"`
 
     CreatedAt time.Time `gorm:"not null"`
     UpdatedAt time.Time `gorm:"not null"`
 }
 
+// ProjectFavoriteRecord marks a project as a favorite of one user.
+type ProjectFavoriteRecord struct {
+    TenantID  string `gorm:"type:varchar(64);primaryKey"`
+    UserID    string `gorm:"type:varchar(64);primaryKey"`
+    ProjectID string `gorm:"type:uuid;primaryKey;index"`
+
+    CreatedAt time.Time `gorm:"not null"`
+}
+
+func (ProjectFavoriteRecord) TableName() string { return "project_favorites" }
+
+// PrioritizeSettingsRecord holds one tenant's prioritization weights and
+// Eisenhower matrix thresholds.
+type PrioritizeSettingsRecord struct {
+    TenantID string `gorm:"type:varchar(64);primaryKey"`
+
+    PriorityWeight float64 `gorm:"not null"`
+    DueDateWeight  float64 `gorm:"not null"`
+    AgeWeight      float64 `gorm:"not null"`
+    StatusWeight   float64 `gorm:"not null"`
+    AIWeight       float64 `gorm:"column:ai_weight;not null"`
+
+    UrgentWithinMinutes int `gorm:"not null;default:2880"`
+    ImportantPriority   int `gorm:"not null;default:7"`
+
+    AutoPrioritize bool `gorm:"not null;default:false;index"`
+    LastAutoRunAt  *time.Time
+
+    UpdatedAt time.Time `gorm:"not null"`
+}
+
+func (PrioritizeSettingsRecord) TableName() string { return "prioritize_settings" }
+
+// APIKeyRecord stores a tenant API key. Only the SHA-256 hash of the secret
+// is persisted.
+type APIKeyRecord struct {
+    ID         string     `gorm:"type:uuid;primaryKey"`
+    TenantID   string     `gorm:"type:varchar(64);index;not null"`
+    UserID     string     `gorm:"type:varchar(64)"`
+    Name       string     `gorm:"type:varchar(100);not null"`
+    Prefix     string     `gorm:"type:varchar(16);not null"`
+    KeyHash    string     `gorm:"type:char(64);uniqueIndex;not null"`
+    CreatedAt  time.Time  `gorm:"not null"`
+    LastUsedAt *time.Time `gorm:"column:last_used_at"`
+    RevokedAt  *time.Time `gorm:"index"`
+}
+
+func (APIKeyRecord) TableName() string { return "api_keys" }
+
+// TaskTemplateRecord stores a tenant's task template.
+type TaskTemplateRecord struct {
+    ID           string   `gorm:"type:uuid;primaryKey"`
+    TenantID     string   `gorm:"type:varchar(64);index;not null"`
+    Name         string   `gorm:"type:varchar(100);not null"`
+    TitlePattern string   `gorm:"type:varchar(255);not null"`
+    Description  string   `gorm:"type:text"`
+    Priority     int      `gorm:"not null;default:0"`
+    Tags         []string `gorm:"type:jsonb;serializer:json"`
+
+    CreatedAt time.Time `gorm:"not null"`
+    UpdatedAt time.Time `gorm:"not null"`
+}
+
+func (TaskTemplateRecord) TableName() string { return "task_templates" }
+
+// FeatureFlagRecord stores one tenant's setting of a feature flag.
+type FeatureFlagRecord struct {
+    TenantID  string    `gorm:"type:varchar(64);primaryKey"`
+    Name      string    `gorm:"type:varchar(64);primaryKey"`
+    Enabled   bool      `gorm:"not null;default:false"`
+    UpdatedAt time.Time `gorm:"not null"`
+}
+
+func (FeatureFlagRecord) TableName() string { return "feature_flags" }
+
+// TenantSettingsRecord stores one tenant's settings, such as its data
+// residency region.
+type TenantSettingsRecord struct {
+    TenantID  string    `gorm:"type:varchar(64);primaryKey"`
+    Region    string    `gorm:"type:varchar(32);not null;default:''"`
+    UpdatedAt time.Time `gorm:"not null"`
+}
+
+func (TenantSettingsRecord) TableName() string { return "tenant_settings" }
+
+// TenantRecord stores a tenant signed up through registration.
+type TenantRecord struct {
+    ID        string    `gorm:"type:uuid;primaryKey"`
+    Name      string    `gorm:"type:varchar(100);not null"`
+    CreatedAt time.Time `gorm:"not null"`
+}
+
+func (TenantRecord) TableName() string { return "tenants" }
+
+// UserRecord stores a user who signs in with an email and password. Emails
+// are unique per tenant regardless of case; registration additionally
+// refuses an email any tenant uses, as login looks users up by email alone.
+// Only the bcrypt hash of the password is persisted.
+type UserRecord struct {
+    ID           string    `gorm:"type:uuid;primaryKey"`
+    TenantID     string    `gorm:"type:varchar(64);uniqueIndex:idx_users_tenant_email,priority:1;not null"`
+    Email        string    `gorm:"type:varchar(254);uniqueIndex:idx_users_tenant_email,priority:2,expression:lower(email);not null"`
+    DisplayName  string    `gorm:"type:varchar(100);not null;default:''"`
+    Role         string    `gorm:"type:varchar(16);not null;default:'member'"`
+    PasswordHash string    `gorm:"type:varchar(72);not null"`
+    CreatedAt    time.Time `gorm:"not null"`
+}
+
+func (UserRecord) TableName() string { return "users" }
+
+// RefreshTokenRecord stores a refresh token issued at login. Only the SHA-256
+// hash of the secret is persisted; tokens refreshed from the same login share
+// a FamilyID.
+type RefreshTokenRecord struct {
+    ID        string     `gorm:"type:uuid;primaryKey"`
+    FamilyID  string     `gorm:"type:uuid;index;not null"`
+    TenantID  string     `gorm:"type:varchar(64);not null"`
+    UserID    string     `gorm:"type:varchar(64);index;not null"`
+    TokenHash string     `gorm:"type:char(64);uniqueIndex;not null"`
+    CreatedAt time.Time  `gorm:"not null"`
+    ExpiresAt time.Time  `gorm:"not null"`
+    RotatedAt *time.Time
+    RevokedAt *time.Time
+}
+
+func (RefreshTokenRecord) TableName() string { return "refresh_tokens" }
+
+// RevokedTokenRecord stores an access token revoked before its expiry, by
+// its jti. Rows past ExpiresAt are no longer loaded.
+type RevokedTokenRecord struct {
+    TenantID  string    `gorm:"type:varchar(64);primaryKey"`
+    TokenID   string    `gorm:"type:varchar(128);primaryKey"`
+    RevokedAt time.Time `gorm:"not null"`
+    ExpiresAt time.Time `gorm:"index;not null"`
+}
+
+func (RevokedTokenRecord) TableName() string { return "revoked_tokens" }
+
+// RevokedUserRecord stores the cutoff before which all of a user's access
+// tokens are revoked. Rows past ExpiresAt are no longer loaded.
+type RevokedUserRecord struct {
+    TenantID      string    `gorm:"type:varchar(64);primaryKey"`
+    UserID        string    `gorm:"type:varchar(64);primaryKey"`
+    RevokedBefore time.Time `gorm:"not null"`
+    ExpiresAt     time.Time `gorm:"index;not null"`
+}
+
+func (RevokedUserRecord) TableName() string { return "revoked_users" }
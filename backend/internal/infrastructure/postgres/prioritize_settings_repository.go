@@ -0,0 +1,90 @@
+package postgres
+
+import (
+    "context"
+    "errors"
+    "time"
+
+    appprioritize "backend/internal/application/prioritize"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+)
+
+type PrioritizeSettingsRepository struct {
+    db *gorm.DB
+}
+
+func NewPrioritizeSettingsRepository(db *gorm.DB) *PrioritizeSettingsRepository {
+    return &PrioritizeSettingsRepository{db: db}
+}
+
+var _ appprioritize.SettingsRepository = (*PrioritizeSettingsRepository)(nil)
+
+func (r *PrioritizeSettingsRepository) GetSettings(ctx context.Context, tenantID string) (appprioritize.Settings, error) {
+    var rec PrioritizeSettingsRecord
+    err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).First(&rec).Error
+    if errors.Is(err, gorm.ErrRecordNotFound) {
+        return appprioritize.Settings{}, appprioritize.ErrSettingsNotFound
+    }
+    if err != nil {
+        return appprioritize.Settings{}, err
+    }
+    return appprioritize.Settings{
+        Weights: appprioritize.Weights{
+            Priority: rec.PriorityWeight,
+            DueDate:  rec.DueDateWeight,
+            Age:      rec.AgeWeight,
+            Status:   rec.StatusWeight,
+            AI:       rec.AIWeight,
+        },
+        Thresholds: appprioritize.Thresholds{
+            UrgentWithin:      time.Duration(rec.UrgentWithinMinutes) * time.Minute,
+            ImportantPriority: rec.ImportantPriority,
+        },
+        AutoPrioritize: rec.AutoPrioritize,
+        LastAutoRunAt:  rec.LastAutoRunAt,
+    }, nil
+}
+
+func (r *PrioritizeSettingsRepository) SaveSettings(ctx context.Context, tenantID string, st appprioritize.Settings) error {
+    rec := PrioritizeSettingsRecord{
+        TenantID:            tenantID,
+        PriorityWeight:      st.Weights.Priority,
+        DueDateWeight:       st.Weights.DueDate,
+        AgeWeight:           st.Weights.Age,
+        StatusWeight:        st.Weights.Status,
+        AIWeight:            st.Weights.AI,
+        UrgentWithinMinutes: int(st.Thresholds.UrgentWithin / time.Minute),
+        ImportantPriority:   st.Thresholds.ImportantPriority,
+        AutoPrioritize:      st.AutoPrioritize,
+        UpdatedAt:           time.Now().UTC(),
+    }
+    // last_auto_run_at belongs to the scheduler and survives a save.
+    return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+        Columns: []clause.Column{{Name: "tenant_id"}},
+        DoUpdates: clause.AssignmentColumns([]string{
+            "priority_weight", "due_date_weight", "age_weight", "status_weight", "ai_weight",
+            "urgent_within_minutes", "important_priority", "auto_prioritize", "updated_at",
+        }),
+    }).Create(&rec).Error
+}
+
+func (r *PrioritizeSettingsRepository) ListAutoPrioritized(ctx context.Context) ([]string, error) {
+    var ids []string
+    err := r.db.WithContext(ctx).Model(&PrioritizeSettingsRecord{}).
+        Where("auto_prioritize = ?", true).Order("tenant_id").Pluck("tenant_id", &ids).Error
+    return ids, err
+}
+
+func (r *PrioritizeSettingsRepository) SaveLastAutoRun(ctx context.Context, tenantID string, at time.Time) error {
+    res := r.db.WithContext(ctx).Model(&PrioritizeSettingsRecord{}).
+        Where("tenant_id = ?", tenantID).Update("last_auto_run_at", at)
+    if res.Error != nil {
+        return res.Error
+    }
+    if res.RowsAffected == 0 {
+        return appprioritize.ErrSettingsNotFound
+    }
+    return nil
+}
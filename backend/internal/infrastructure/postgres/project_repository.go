@@ -0,0 +1,157 @@
+package postgres
+
+import (
+    "context"
+    "errors"
+    "time"
+
+    appproject "backend/internal/application/project"
+    apptask "backend/internal/application/task"
+    domainproject "backend/internal/domain/project"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+)
+
+type ProjectRepository struct {
+    db *gorm.DB
+}
+
+func NewProjectRepository(db *gorm.DB) *ProjectRepository {
+    return &ProjectRepository{db: db}
+}
+
+var (
+    _ appproject.Repository = (*ProjectRepository)(nil)
+    _ apptask.ProjectLookup = (*ProjectRepository)(nil)
+)
+
+func toProjectRecord(p *domainproject.Project) ProjectRecord {
+    return ProjectRecord{
+        ID:          p.ID,
+        TenantID:    p.TenantID,
+        Name:        p.Name,
+        Description: p.Description,
+        Position:    p.Position,
+        CreatedAt:   p.CreatedAt,
+        UpdatedAt:   p.UpdatedAt,
+    }
+}
+
+func toProjectDomain(r ProjectRecord) domainproject.Project {
+    return domainproject.Project{
+        ID:          r.ID,
+        TenantID:    r.TenantID,
+        Name:        r.Name,
+        Description: r.Description,
+        Position:    r.Position,
+        CreatedAt:   r.CreatedAt,
+        UpdatedAt:   r.UpdatedAt,
+    }
+}
+
+func (r *ProjectRepository) ListByTenant(ctx context.Context, tenantID string) ([]domainproject.Project, error) {
+    var recs []ProjectRecord
+    if err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).Find(&recs).Error; err != nil {
+        return nil, err
+    }
+    out := make([]domainproject.Project, 0, len(recs))
+    for _, rec := range recs {
+        out = append(out, toProjectDomain(rec))
+    }
+    return out, nil
+}
+
+func (r *ProjectRepository) Get(ctx context.Context, tenantID, id string) (*domainproject.Project, error) {
+    var rec ProjectRecord
+    err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).First(&rec).Error
+    if errors.Is(err, gorm.ErrRecordNotFound) {
+        return nil, appproject.ErrNotFound
+    }
+    if err != nil {
+        return nil, err
+    }
+    p := toProjectDomain(rec)
+    return &p, nil
+}
+
+func (r *ProjectRepository) ProjectExists(ctx context.Context, tenantID, id string) (bool, error) {
+    var n int64
+    err := r.db.WithContext(ctx).Model(&ProjectRecord{}).Where("tenant_id = ? AND id = ?", tenantID, id).Count(&n).Error
+    return n > 0, err
+}
+
+func (r *ProjectRepository) Create(ctx context.Context, p *domainproject.Project) error {
+    rec := toProjectRecord(p)
+    return r.db.WithContext(ctx).Create(&rec).Error
+}
+
+func (r *ProjectRepository) UpdatePosition(ctx context.Context, tenantID, id string, position float64) error {
+    res := r.db.WithContext(ctx).Model(&ProjectRecord{}).
+        Where("tenant_id = ? AND id = ?", tenantID, id).
+        Updates(map[string]any{"position": position, "updated_at": time.Now().UTC()})
+    if res.Error != nil {
+        return res.Error
+    }
+    if res.RowsAffected == 0 {
+        return appproject.ErrNotFound
+    }
+    return nil
+}
+
+func (r *ProjectRepository) SetFavorite(ctx context.Context, tenantID, userID, projectID string, favorite bool) error {
+    if !favorite {
+        return r.db.WithContext(ctx).
+            Where("tenant_id = ? AND user_id = ? AND project_id = ?", tenantID, userID, projectID).
+            Delete(&ProjectFavoriteRecord{}).Error
+    }
+    rec := ProjectFavoriteRecord{TenantID: tenantID, UserID: userID, ProjectID: projectID, CreatedAt: time.Now().UTC()}
+    return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&rec).Error
+}
+
+func (r *ProjectRepository) ListFavoriteIDs(ctx context.Context, tenantID, userID string) ([]string, error) {
+    var ids []string
+    err := r.db.WithContext(ctx).Model(&ProjectFavoriteRecord{}).
+        Where("tenant_id = ? AND user_id = ?", tenantID, userID).
+        Pluck("project_id", &ids).Error
+    return ids, err
+}
+
+func (r *ProjectRepository) DeleteWithTasks(ctx context.Context, tenantID, id string, mode appproject.DeleteMode) error {
+    return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        var rec ProjectRecord
+        err := tx.Where("tenant_id = ? AND id = ?", tenantID, id).First(&rec).Error
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            return appproject.ErrNotFound
+        }
+        if err != nil {
+            return err
+        }
+
+        tasks := tx.Model(&TaskRecord{}).Where("tenant_id = ? AND project_id = ?", tenantID, id)
+        var count int64
+        if err := tasks.Session(&gorm.Session{}).Count(&count).Error; err != nil {
+            return err
+        }
+
+        if count > 0 {
+            switch mode {
+            case appproject.DeleteModeDetach:
+                err = tasks.Session(&gorm.Session{}).Update("project_id", nil).Error
+            case appproject.DeleteModeCascade:
+                // TaskRecord carries gorm.DeletedAt, so this is a soft delete.
+                err = tx.Where("tenant_id = ? AND project_id = ?", tenantID, id).Delete(&TaskRecord{}).Error
+            default:
+                return &appproject.HasTasksError{Count: count}
+            }
+            if err != nil {
+                return err
+            }
+        }
+
+        if err := tx.Where("tenant_id = ? AND project_id = ?", tenantID, id).Delete(&ProjectFavoriteRecord{}).Error; err != nil {
+            return err
+        }
+        return tx.Delete(&rec).Error
+    })
+}
@@ -0,0 +1,23 @@
+package postgres
+
+import (
+    "context"
+    "database/sql"
+
+    apphealth "backend/internal/application/health"
+)
+
+// ReadinessChecker pings the database for GET /readyz.
+type ReadinessChecker struct {
+    db *sql.DB
+}
+
+var _ apphealth.ReadinessChecker = ReadinessChecker{}
+
+func NewReadinessChecker(db *sql.DB) ReadinessChecker {
+    return ReadinessChecker{db: db}
+}
+
+func (r ReadinessChecker) Check(ctx context.Context) error {
+    return r.db.PingContext(ctx)
+}
@@ -0,0 +1,24 @@
+package postgres
+
+import (
+    "context"
+    "database/sql"
+    "testing"
+    "time"
+
+    _ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// Test that the readiness check fails while the database cannot be reached.
+func TestReadinessChecker_Unreachable(t *testing.T) {
+    db, err := sql.Open("pgx", "postgres://app@127.0.0.1:1/tasks?sslmode=disable&connect_timeout=1")
+    if err != nil {
+        t.Fatalf("open: %v", err)
+    }
+    defer db.Close()
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+    if err := NewReadinessChecker(db).Check(ctx); err == nil {
+        t.Fatalf("expected an error for an unreachable database")
+    }
+}
@@ -0,0 +1,199 @@
+package postgres
+
+import (
+    "context"
+    "database/sql/driver"
+    "errors"
+    "io"
+    "net"
+    "strings"
+    "time"
+
+    appproject "backend/internal/application/project"
+    apptask "backend/internal/application/task"
+    domainproject "backend/internal/domain/project"
+    domaintask "backend/internal/domain/task"
+
+    "github.com/jackc/pgx/v5/pgconn"
+)
+
+// RetryPolicy controls how idempotent reads are retried on transient errors.
+type RetryPolicy struct {
+    // Attempts is the total number of tries, including the first; values
+    // below one mean a single try.
+    Attempts int
+    // Backoff is the wait before the first retry; it doubles on each retry.
+    Backoff time.Duration
+}
+
+// IsTransient reports whether err is worth retrying: serialization failures,
+// deadlocks, connection exceptions and dropped connections. Context
+// cancellation is never transient.
+func IsTransient(err error) bool {
+    if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+        return false
+    }
+    var pgErr *pgconn.PgError
+    if errors.As(err, &pgErr) {
+        switch {
+        case pgErr.Code == "40001", // serialization_failure
+            pgErr.Code == "40P01", // deadlock_detected
+            pgErr.Code == "57P01", // admin_shutdown
+            strings.HasPrefix(pgErr.Code, "08"): // connection_exception class
+            return true
+        }
+        return false
+    }
+    if pgconn.SafeToRetry(err) || errors.Is(err, driver.ErrBadConn) || errors.Is(err, io.ErrUnexpectedEOF) {
+        return true
+    }
+    var netErr net.Error
+    return errors.As(err, &netErr)
+}
+
+// retry runs fn until it succeeds, fails with a non-transient error, the
+// attempts are used up or ctx is done.
+func retry(ctx context.Context, p RetryPolicy, fn func() error) error {
+    wait := p.Backoff
+    var err error
+    for attempt := 1; ; attempt++ {
+        if err = fn(); err == nil || attempt >= p.Attempts || !IsTransient(err) {
+            return err
+        }
+        select {
+        case <-ctx.Done():
+            return err
+        case <-time.After(wait):
+        }
+        wait *= 2
+    }
+}
+
+// RetryingTaskRepository retries the read methods of a task repository on
+// transient errors. Writes are passed through untouched since they are not
+// safe to repeat blindly.
+type RetryingTaskRepository struct {
+    apptask.Repository
+    policy RetryPolicy
+}
+
+func NewRetryingTaskRepository(inner apptask.Repository, p RetryPolicy) *RetryingTaskRepository {
+    return &RetryingTaskRepository{Repository: inner, policy: p}
+}
+
+var _ apptask.Repository = (*RetryingTaskRepository)(nil)
+
+func (r *RetryingTaskRepository) List(ctx context.Context, tenantID string, f apptask.FilterOptions, s apptask.SortOptions, page apptask.ListOptions) ([]domaintask.Task, int64, error) {
+    var out []domaintask.Task
+    var total int64
+    err := retry(ctx, r.policy, func() (err error) {
+        out, total, err = r.Repository.List(ctx, tenantID, f, s, page)
+        return err
+    })
+    return out, total, err
+}
+
+func (r *RetryingTaskRepository) Get(ctx context.Context, tenantID, id string) (*domaintask.Task, error) {
+    var out *domaintask.Task
+    err := retry(ctx, r.policy, func() (err error) {
+        out, err = r.Repository.Get(ctx, tenantID, id)
+        return err
+    })
+    return out, err
+}
+
+func (r *RetryingTaskRepository) GetMany(ctx context.Context, tenantID string, ids []string) ([]domaintask.Task, error) {
+    var out []domaintask.Task
+    err := retry(ctx, r.policy, func() (err error) {
+        out, err = r.Repository.GetMany(ctx, tenantID, ids)
+        return err
+    })
+    return out, err
+}
+
+func (r *RetryingTaskRepository) ListOpenPage(ctx context.Context, tenantID, afterID string, limit int) ([]domaintask.Task, error) {
+    var out []domaintask.Task
+    err := retry(ctx, r.policy, func() (err error) {
+        out, err = r.Repository.ListOpenPage(ctx, tenantID, afterID, limit)
+        return err
+    })
+    return out, err
+}
+
+func (r *RetryingTaskRepository) ListWatchers(ctx context.Context, tenantID, taskID string) ([]domaintask.TaskWatcher, error) {
+    var out []domaintask.TaskWatcher
+    err := retry(ctx, r.policy, func() (err error) {
+        out, err = r.Repository.ListWatchers(ctx, tenantID, taskID)
+        return err
+    })
+    return out, err
+}
+
+func (r *RetryingTaskRepository) ListDependencies(ctx context.Context, tenantID, taskID string) ([]domaintask.TaskDependency, error) {
+    var out []domaintask.TaskDependency
+    err := retry(ctx, r.policy, func() (err error) {
+        out, err = r.Repository.ListDependencies(ctx, tenantID, taskID)
+        return err
+    })
+    return out, err
+}
+
+func (r *RetryingTaskRepository) ListDependents(ctx context.Context, tenantID, taskID string) ([]domaintask.TaskDependency, error) {
+    var out []domaintask.TaskDependency
+    err := retry(ctx, r.policy, func() (err error) {
+        out, err = r.Repository.ListDependents(ctx, tenantID, taskID)
+        return err
+    })
+    return out, err
+}
+
+// RetryingProjectRepository is RetryingTaskRepository for projects.
+type RetryingProjectRepository struct {
+    appproject.Repository
+    policy RetryPolicy
+}
+
+func NewRetryingProjectRepository(inner appproject.Repository, p RetryPolicy) *RetryingProjectRepository {
+    return &RetryingProjectRepository{Repository: inner, policy: p}
+}
+
+var (
+    _ appproject.Repository = (*RetryingProjectRepository)(nil)
+    _ apptask.ProjectLookup = (*RetryingProjectRepository)(nil)
+)
+
+func (r *RetryingProjectRepository) ListByTenant(ctx context.Context, tenantID string) ([]domainproject.Project, error) {
+    var out []domainproject.Project
+    err := retry(ctx, r.policy, func() (err error) {
+        out, err = r.Repository.ListByTenant(ctx, tenantID)
+        return err
+    })
+    return out, err
+}
+
+func (r *RetryingProjectRepository) Get(ctx context.Context, tenantID, id string) (*domainproject.Project, error) {
+    var out *domainproject.Project
+    err := retry(ctx, r.policy, func() (err error) {
+        out, err = r.Repository.Get(ctx, tenantID, id)
+        return err
+    })
+    return out, err
+}
+
+func (r *RetryingProjectRepository) ListFavoriteIDs(ctx context.Context, tenantID, userID string) ([]string, error) {
+    var out []string
+    err := retry(ctx, r.policy, func() (err error) {
+        out, err = r.Repository.ListFavoriteIDs(ctx, tenantID, userID)
+        return err
+    })
+    return out, err
+}
+
+// ProjectExists looks the project up with Get, retried like it.
+func (r *RetryingProjectRepository) ProjectExists(ctx context.Context, tenantID, id string) (bool, error) {
+    _, err := r.Get(ctx, tenantID, id)
+    if errors.Is(err, appproject.ErrNotFound) {
+        return false, nil
+    }
+    return err == nil, err
+}
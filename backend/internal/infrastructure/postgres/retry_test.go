@@ -0,0 +1,73 @@
+package postgres
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    apptask "backend/internal/application/task"
+    domaintask "backend/internal/domain/task"
+
+    "github.com/jackc/pgx/v5/pgconn"
+)
+
+// flakyTaskRepository fails Get with errs in order, then succeeds.
+type flakyTaskRepository struct {
+    apptask.Repository
+    errs  []error
+    calls int
+}
+
+func (f *flakyTaskRepository) Get(ctx context.Context, tenantID, id string) (*domaintask.Task, error) {
+    f.calls++
+    if f.calls <= len(f.errs) {
+        return nil, f.errs[f.calls-1]
+    }
+    return &domaintask.Task{ID: id, TenantID: tenantID}, nil
+}
+
+var testPolicy = RetryPolicy{Attempts: 3, Backoff: time.Millisecond}
+
+// Test that two serialization failures are retried through to success.
+func TestRetryingTaskRepository_RecoversFromTransient(t *testing.T) {
+    serialization := &pgconn.PgError{Code: "40001"}
+    inner := &flakyTaskRepository{errs: []error{serialization, serialization}}
+    repo := NewRetryingTaskRepository(inner, testPolicy)
+
+    got, err := repo.Get(context.Background(), "t1", "x")
+    if err != nil {
+        t.Fatalf("expected success after retries, got %v", err)
+    }
+    if got.ID != "x" || inner.calls != 3 {
+        t.Fatalf("expected 3 calls and task x, got %d calls and %+v", inner.calls, got)
+    }
+}
+
+// Test that a non-transient error is returned without retrying.
+func TestRetryingTaskRepository_PermanentError(t *testing.T) {
+    notFound := errors.New("task not found")
+    inner := &flakyTaskRepository{errs: []error{notFound}}
+    repo := NewRetryingTaskRepository(inner, testPolicy)
+
+    if _, err := repo.Get(context.Background(), "t1", "x"); !errors.Is(err, notFound) {
+        t.Fatalf("expected the original error, got %v", err)
+    }
+    if inner.calls != 1 {
+        t.Fatalf("expected 1 call, got %d", inner.calls)
+    }
+}
+
+// Test that retries stop once the attempts are used up.
+func TestRetryingTaskRepository_GivesUp(t *testing.T) {
+    connReset := &pgconn.PgError{Code: "08006"}
+    inner := &flakyTaskRepository{errs: []error{connReset, connReset, connReset, connReset}}
+    repo := NewRetryingTaskRepository(inner, testPolicy)
+
+    if _, err := repo.Get(context.Background(), "t1", "x"); !errors.Is(err, connReset) {
+        t.Fatalf("expected the last transient error, got %v", err)
+    }
+    if inner.calls != 3 {
+        t.Fatalf("expected 3 calls, got %d", inner.calls)
+    }
+}
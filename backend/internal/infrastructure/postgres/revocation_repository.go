@@ -0,0 +1,64 @@
+package postgres
+
+import (
+    "context"
+    "time"
+
+    apprevocation "backend/internal/application/revocation"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+)
+
+type RevocationRepository struct {
+    db *gorm.DB
+}
+
+func NewRevocationRepository(db *gorm.DB) *RevocationRepository {
+    return &RevocationRepository{db: db}
+}
+
+var _ apprevocation.Repository = (*RevocationRepository)(nil)
+
+func (r *RevocationRepository) RevokeToken(ctx context.Context, rev *apprevocation.TokenRevocation) error {
+    rec := RevokedTokenRecord{TenantID: rev.TenantID, TokenID: rev.TokenID, RevokedAt: rev.RevokedAt, ExpiresAt: rev.ExpiresAt}
+    return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&rec).Error
+}
+
+// RevokeUser records the revocation and revokes the user's refresh tokens
+// in one transaction, so the user is never left able to refresh.
+func (r *RevocationRepository) RevokeUser(ctx context.Context, rev *apprevocation.UserRevocation) error {
+    return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        rec := RevokedUserRecord{TenantID: rev.TenantID, UserID: rev.UserID, RevokedBefore: rev.RevokedBefore, ExpiresAt: rev.ExpiresAt}
+        err := tx.Clauses(clause.OnConflict{
+            Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "user_id"}},
+            DoUpdates: clause.AssignmentColumns([]string{"revoked_before", "expires_at"}),
+        }).Create(&rec).Error
+        if err != nil {
+            return err
+        }
+        return tx.Model(&RefreshTokenRecord{}).
+            Where("tenant_id = ? AND user_id = ? AND revoked_at IS NULL", rev.TenantID, rev.UserID).
+            Update("revoked_at", rev.RevokedBefore).Error
+    })
+}
+
+func (r *RevocationRepository) ListActive(ctx context.Context, now time.Time) ([]apprevocation.TokenRevocation, []apprevocation.UserRevocation, error) {
+    var tokenRecs []RevokedTokenRecord
+    if err := r.db.WithContext(ctx).Where("expires_at > ?", now).Find(&tokenRecs).Error; err != nil {
+        return nil, nil, err
+    }
+    var userRecs []RevokedUserRecord
+    if err := r.db.WithContext(ctx).Where("expires_at > ?", now).Find(&userRecs).Error; err != nil {
+        return nil, nil, err
+    }
+    tokens := make([]apprevocation.TokenRevocation, 0, len(tokenRecs))
+    for _, rec := range tokenRecs {
+        tokens = append(tokens, apprevocation.TokenRevocation{TenantID: rec.TenantID, TokenID: rec.TokenID, RevokedAt: rec.RevokedAt, ExpiresAt: rec.ExpiresAt})
+    }
+    users := make([]apprevocation.UserRevocation, 0, len(userRecs))
+    for _, rec := range userRecs {
+        users = append(users, apprevocation.UserRevocation{TenantID: rec.TenantID, UserID: rec.UserID, RevokedBefore: rec.RevokedBefore, ExpiresAt: rec.ExpiresAt})
+    }
+    return tokens, users, nil
+}
@@ -3,12 +3,16 @@ package postgres
 import (
     "context"
     "errors"
+    "sort"
+    "strings"
     "time"
 
     apptask "backend/internal/application/task"
     domaintask "backend/internal/domain/task"
 
+    "github.com/google/uuid"
     "gorm.io/gorm"
+    "gorm.io/gorm/clause"
 )
 
 type TaskRepository struct {
@@ -28,8 +32,17 @@ func toRecord(t *domaintask.Task) TaskRecord {
         UserID:      t.UserID,
         Title:       t.Title,
         Description: t.Description,
+        Summary:     t.Summary,
         Status:      t.Status,
         Priority:    t.Priority,
+        DueDate:     t.DueDate,
+        AiScore:     t.AiScore,
+        ProjectID:   t.ProjectID,
+        ParentID:    t.ParentID,
+        AssigneeID:  t.AssigneeID,
+        Tags:        t.Tags,
+        Position:    t.Position,
+        CompletedAt: t.CompletedAt,
         CreatedAt:   t.CreatedAt,
         UpdatedAt:   t.UpdatedAt,
     }
@@ -42,30 +55,113 @@ func toDomain(r TaskRecord) domaintask.Task {
         UserID:      r.UserID,
         Title:       r.Title,
         Description: r.Description,
+        Summary:     r.Summary,
         Status:      r.Status,
         Priority:    r.Priority,
+        DueDate:     r.DueDate,
+        AiScore:     r.AiScore,
+        ProjectID:   r.ProjectID,
+        ParentID:    r.ParentID,
+        AssigneeID:  r.AssigneeID,
+        Tags:        r.Tags,
+        Position:    r.Position,
+        CompletedAt: r.CompletedAt,
         CreatedAt:   r.CreatedAt,
         UpdatedAt:   r.UpdatedAt,
     }
 }
 
+// ListByTenant returns every task of the tenant, oldest first.
 func (r *TaskRepository) ListByTenant(ctx context.Context, tenantID string) ([]domaintask.Task, error) {
+    items, _, err := r.List(ctx, tenantID, apptask.FilterOptions{}, apptask.SortOptions{}, apptask.ListOptions{})
+    return items, err
+}
+
+// List counts the matching tasks only when a limit or offset is set;
+// otherwise the total is the number of tasks returned.
+func (r *TaskRepository) List(ctx context.Context, tenantID string, f apptask.FilterOptions, s apptask.SortOptions, page apptask.ListOptions) ([]domaintask.Task, int64, error) {
+    q := filterTasks(r.db.WithContext(ctx).Model(&TaskRecord{}).Where("tenant_id = ?", tenantID), f)
+    paged := page.Limit > 0 || page.Offset > 0
+    var total int64
+    if paged {
+        if err := q.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+            return nil, 0, err
+        }
+    }
+    q = q.Order(taskOrder(s))
+    if page.Limit > 0 {
+        q = q.Limit(page.Limit)
+    }
+    if page.Offset > 0 {
+        q = q.Offset(page.Offset)
+    }
     var recs []TaskRecord
-    if err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).Find(&recs).Error; err != nil {
-        return nil, err
+    if err := q.Find(&recs).Error; err != nil {
+        return nil, 0, err
     }
     out := make([]domaintask.Task, 0, len(recs))
     for _, rec := range recs {
         out = append(out, toDomain(rec))
     }
-    return out, nil
+    if !paged {
+        total = int64(len(out))
+    }
+    return out, total, nil
+}
+
+// filterTasks adds the conditions of f to q, mirroring
+// apptask.FilterOptions.Matches.
+func filterTasks(q *gorm.DB, f apptask.FilterOptions) *gorm.DB {
+    if u := f.UserID; u != nil {
+        q = q.Where("(user_id = ? OR assignee_id = ?)", *u, *u)
+    }
+    if p := f.ParentID; p != nil {
+        q = q.Where("parent_id = ?", *p)
+    }
+    if len(f.Statuses) > 0 {
+        q = q.Where("status IN ?", f.Statuses)
+    }
+    if f.Open {
+        q = q.Where("status NOT IN ?", []string{domaintask.StatusDone, domaintask.StatusArchived})
+    }
+    if f.DueFrom != nil {
+        q = q.Where("due_date >= ?", *f.DueFrom)
+    }
+    if f.DueBefore != nil {
+        q = q.Where("due_date < ?", *f.DueBefore)
+    }
+    return q
+}
+
+// sortColumns maps sort fields to their columns.
+var sortColumns = map[string]string{
+    apptask.SortAIScore:  "ai_score",
+    apptask.SortDueDate:  "due_date",
+    apptask.SortPriority: "priority",
+}
+
+// taskOrder is the ORDER BY clause for s, mirroring apptask.SortOptions.Compare: NULLs
+// last in either direction, ties by creation time and ID.
+func taskOrder(s apptask.SortOptions) string {
+    if s.Field == apptask.SortCreatedAt && s.Desc {
+        return "created_at DESC, id DESC"
+    }
+    col, ok := sortColumns[s.Field]
+    if !ok {
+        return "created_at, id"
+    }
+    dir := "ASC"
+    if s.Desc {
+        dir = "DESC"
+    }
+    return col + " " + dir + " NULLS LAST, created_at, id"
 }
 
 func (r *TaskRepository) Get(ctx context.Context, tenantID, id string) (*domaintask.Task, error) {
     var rec TaskRecord
     err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).First(&rec).Error
     if errors.Is(err, gorm.ErrRecordNotFound) {
-        return nil, errors.New("task not found")
+        return nil, apptask.ErrNotFound
     }
     if err != nil {
         return nil, err
@@ -74,21 +170,237 @@ func (r *TaskRepository) Get(ctx context.Context, tenantID, id string) (*domaint
     return &t, nil
 }
 
+// GetMany loads the tasks with one WHERE id IN query and puts them back in
+// the order of ids.
+func (r *TaskRepository) GetMany(ctx context.Context, tenantID string, ids []string) ([]domaintask.Task, error) {
+    ids = validUUIDs(ids)
+    if len(ids) == 0 {
+        return []domaintask.Task{}, nil
+    }
+    var recs []TaskRecord
+    if err := r.db.WithContext(ctx).Where("tenant_id = ? AND id IN ?", tenantID, ids).Find(&recs).Error; err != nil {
+        return nil, err
+    }
+    byID := make(map[string]TaskRecord, len(recs))
+    for _, rec := range recs {
+        byID[rec.ID] = rec
+    }
+    out := make([]domaintask.Task, 0, len(recs))
+    for _, id := range ids {
+        if rec, ok := byID[id]; ok {
+            out = append(out, toDomain(rec))
+            delete(byID, id)
+        }
+    }
+    return out, nil
+}
+
 func (r *TaskRepository) Create(ctx context.Context, t *domaintask.Task) error {
     rec := toRecord(t)
     return r.db.WithContext(ctx).Create(&rec).Error
 }
 
-func (r *TaskRepository) Update(ctx context.Context, t *domaintask.Task) error {
+func (r *TaskRepository) Update(ctx context.Context, t *domaintask.Task, fields ...apptask.TaskField) error {
     t.UpdatedAt = time.Now().UTC()
-    rec := toRecord(t)
-    // Ensure we only update the matching row
+    // A map, unlike a struct, makes GORM write zero values, so cleared
+    // fields become "" or NULL instead of being skipped.
     return r.db.WithContext(ctx).Model(&TaskRecord{}).
         Where("tenant_id = ? AND id = ?", t.TenantID, t.ID).
-        Updates(rec).Error
+        Updates(updateColumns(toRecord(t), fields)).Error
 }
 
+// updateColumns maps the changed fields of rec to their columns.
+func updateColumns(rec TaskRecord, fields []apptask.TaskField) map[string]any {
+    cols := map[string]any{"updated_at": rec.UpdatedAt}
+    for _, f := range fields {
+        switch f {
+        case apptask.FieldTitle:
+            cols["title"] = rec.Title
+        case apptask.FieldDescription:
+            cols["description"] = rec.Description
+        case apptask.FieldStatus:
+            // CompletedAt follows the status, see domaintask.Task.SetStatus.
+            cols["status"] = rec.Status
+            cols["completed_at"] = rec.CompletedAt
+        case apptask.FieldPriority:
+            cols["priority"] = rec.Priority
+        case apptask.FieldDueDate:
+            cols["due_date"] = rec.DueDate
+        case apptask.FieldSummary:
+            cols["summary"] = rec.Summary
+        case apptask.FieldProjectID:
+            cols["project_id"] = rec.ProjectID
+        case apptask.FieldPosition:
+            cols["position"] = rec.Position
+        }
+    }
+    return cols
+}
+
+// Delete removes the task's row; only project cascades soft-delete tasks.
 func (r *TaskRepository) Delete(ctx context.Context, tenantID, id string) error {
-    return r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Delete(&TaskRecord{}).Error
+    res := r.db.WithContext(ctx).Unscoped().Where("tenant_id = ? AND id = ?", tenantID, id).Delete(&TaskRecord{})
+    if res.Error == nil && res.RowsAffected == 0 {
+        return apptask.ErrNotFound
+    }
+    return res.Error
+}
+
+// validUUIDs drops ids that would fail the uuid cast on the id column.
+func validUUIDs(ids []string) []string {
+    out := make([]string, 0, len(ids))
+    for _, id := range ids {
+        if _, err := uuid.Parse(id); err == nil {
+            out = append(out, id)
+        }
+    }
+    return out
 }
 
+func (r *TaskRepository) BulkAssign(ctx context.Context, tenantID string, ids []string, assigneeID *string) ([]string, error) {
+    ids = validUUIDs(ids)
+    if len(ids) == 0 {
+        return nil, nil
+    }
+    var updated []string
+    err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        if err := tx.Model(&TaskRecord{}).
+            Where("tenant_id = ? AND id IN ?", tenantID, ids).
+            Pluck("id", &updated).Error; err != nil {
+            return err
+        }
+        if len(updated) == 0 {
+            return nil
+        }
+        return tx.Model(&TaskRecord{}).
+            Where("tenant_id = ? AND id IN ?", tenantID, updated).
+            Updates(map[string]any{"assignee_id": assigneeID, "updated_at": time.Now().UTC()}).Error
+    })
+    if err != nil {
+        return nil, err
+    }
+    return updated, nil
+}
+
+// aiScoreBatch bounds the rows per UPDATE so the statement stays well under
+// postgres' bind parameter limit.
+const aiScoreBatch = 500
+
+// UpdateAIScores writes scores with one UPDATE ... FROM (VALUES ...) per
+// batch. Rows outside the tenant, soft-deleted rows and unknown ids simply do
+// not match.
+func (r *TaskRepository) UpdateAIScores(ctx context.Context, tenantID string, scores map[string]float64) error {
+    ids := make([]string, 0, len(scores))
+    for id := range scores {
+        ids = append(ids, id)
+    }
+    ids = validUUIDs(ids)
+    if len(ids) == 0 {
+        return nil
+    }
+    // A stable order keeps concurrent runs from deadlocking on row locks.
+    sort.Strings(ids)
+    return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        for start := 0; start < len(ids); start += aiScoreBatch {
+            batch := ids[start:min(start+aiScoreBatch, len(ids))]
+            values := make([]string, 0, len(batch))
+            args := make([]any, 0, 2*len(batch)+1)
+            for _, id := range batch {
+                values = append(values, "(?::uuid, ?::double precision)")
+                args = append(args, id, scores[id])
+            }
+            args = append(args, tenantID)
+            err := tx.Exec(`UPDATE task_records AS t SET ai_score = v.score
+                FROM (VALUES `+strings.Join(values, ", ")+`) AS v(id, score)
+                WHERE t.id = v.id AND t.tenant_id = ? AND t.deleted_at IS NULL`, args...).Error
+            if err != nil {
+                return err
+            }
+        }
+        return nil
+    })
+}
+
+func (r *TaskRepository) ListOpenPage(ctx context.Context, tenantID, afterID string, limit int) ([]domaintask.Task, error) {
+    q := r.db.WithContext(ctx).
+        Where("tenant_id = ? AND status NOT IN ?", tenantID, []string{domaintask.StatusDone, domaintask.StatusArchived})
+    if afterID != "" {
+        q = q.Where("id > ?", afterID)
+    }
+    var recs []TaskRecord
+    if err := q.Order("id").Limit(limit).Find(&recs).Error; err != nil {
+        return nil, err
+    }
+    out := make([]domaintask.Task, 0, len(recs))
+    for _, rec := range recs {
+        out = append(out, toDomain(rec))
+    }
+    return out, nil
+}
+
+func (r *TaskRepository) Watch(ctx context.Context, w *domaintask.TaskWatcher) error {
+    rec := TaskWatcherRecord{TenantID: w.TenantID, TaskID: w.TaskID, UserID: w.UserID, CreatedAt: w.CreatedAt}
+    return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&rec).Error
+}
+
+func (r *TaskRepository) Unwatch(ctx context.Context, tenantID, taskID, userID string) error {
+    return r.db.WithContext(ctx).
+        Where("tenant_id = ? AND task_id = ? AND user_id = ?", tenantID, taskID, userID).
+        Delete(&TaskWatcherRecord{}).Error
+}
+
+func (r *TaskRepository) ListWatchers(ctx context.Context, tenantID, taskID string) ([]domaintask.TaskWatcher, error) {
+    if _, err := uuid.Parse(taskID); err != nil {
+        return []domaintask.TaskWatcher{}, nil
+    }
+    var recs []TaskWatcherRecord
+    err := r.db.WithContext(ctx).
+        Where("tenant_id = ? AND task_id = ?", tenantID, taskID).
+        Order("created_at, user_id").
+        Find(&recs).Error
+    if err != nil {
+        return nil, err
+    }
+    out := make([]domaintask.TaskWatcher, 0, len(recs))
+    for _, rec := range recs {
+        out = append(out, domaintask.TaskWatcher{TaskID: rec.TaskID, UserID: rec.UserID, TenantID: rec.TenantID, CreatedAt: rec.CreatedAt})
+    }
+    return out, nil
+}
+
+func (r *TaskRepository) AddDependency(ctx context.Context, d *domaintask.TaskDependency) error {
+    rec := TaskDependencyRecord{TenantID: d.TenantID, TaskID: d.TaskID, DependsOnID: d.DependsOnID, CreatedAt: d.CreatedAt}
+    return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&rec).Error
+}
+
+func (r *TaskRepository) RemoveDependency(ctx context.Context, tenantID, taskID, dependsOnID string) error {
+    if _, err := uuid.Parse(dependsOnID); err != nil {
+        return nil
+    }
+    return r.db.WithContext(ctx).
+        Where("tenant_id = ? AND task_id = ? AND depends_on_id = ?", tenantID, taskID, dependsOnID).
+        Delete(&TaskDependencyRecord{}).Error
+}
+
+func (r *TaskRepository) ListDependencies(ctx context.Context, tenantID, taskID string) ([]domaintask.TaskDependency, error) {
+    return r.listDependencies(ctx, "tenant_id = ? AND task_id = ?", tenantID, taskID)
+}
+
+func (r *TaskRepository) ListDependents(ctx context.Context, tenantID, taskID string) ([]domaintask.TaskDependency, error) {
+    return r.listDependencies(ctx, "tenant_id = ? AND depends_on_id = ?", tenantID, taskID)
+}
+
+func (r *TaskRepository) listDependencies(ctx context.Context, where, tenantID, taskID string) ([]domaintask.TaskDependency, error) {
+    if _, err := uuid.Parse(taskID); err != nil {
+        return []domaintask.TaskDependency{}, nil
+    }
+    var recs []TaskDependencyRecord
+    if err := r.db.WithContext(ctx).Where(where, tenantID, taskID).Order("created_at, task_id, depends_on_id").Find(&recs).Error; err != nil {
+        return nil, err
+    }
+    out := make([]domaintask.TaskDependency, 0, len(recs))
+    for _, rec := range recs {
+        out = append(out, domaintask.TaskDependency{TenantID: rec.TenantID, TaskID: rec.TaskID, DependsOnID: rec.DependsOnID, CreatedAt: rec.CreatedAt})
+    }
+    return out, nil
+}
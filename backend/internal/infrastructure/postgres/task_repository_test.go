@@ -0,0 +1,171 @@
+package postgres
+
+import (
+    "context"
+    "strings"
+    "testing"
+    "time"
+
+    apptask "backend/internal/application/task"
+    domaintask "backend/internal/domain/task"
+
+    "gorm.io/gorm"
+)
+
+// Test that Update writes exactly the listed fields, including cleared ones,
+// and never columns it was not asked to change.
+func TestTaskRepository_Update_WritesListedFields(t *testing.T) {
+    // Without the default transaction nothing needs a live connection.
+    db := newDryRunDB(t).Session(&gorm.Session{SkipDefaultTransaction: true})
+    var stmt *gorm.Statement
+    if err := db.Callback().Update().After("gorm:update").Register("test:capture", func(tx *gorm.DB) {
+        stmt = tx.Statement
+    }); err != nil {
+        t.Fatalf("register: %v", err)
+    }
+
+    tk := domaintask.New("t1", "u1", "title", "", 5)
+    err := NewTaskRepository(db).Update(context.Background(), tk, apptask.FieldDescription, apptask.FieldDueDate)
+    if err != nil {
+        t.Fatalf("update: %v", err)
+    }
+    sql := stmt.SQL.String()
+    for _, col := range []string{`"description"=`, `"due_date"=`, `"updated_at"=`} {
+        if !strings.Contains(sql, col) {
+            t.Fatalf("expected %s to be written, got %s", col, sql)
+        }
+    }
+    for _, col := range []string{`"title"=`, `"assignee_id"=`, `"ai_score"=`, `"tenant_id"=`, `"created_at"=`} {
+        if strings.Contains(sql, col) {
+            t.Fatalf("expected %s not to be set, got %s", col, sql)
+        }
+    }
+    var sawEmpty, sawNil bool
+    for _, v := range stmt.Vars {
+        switch v := v.(type) {
+        case string:
+            sawEmpty = sawEmpty || v == ""
+        case *time.Time:
+            sawNil = sawNil || v == nil
+        }
+    }
+    if !sawEmpty || !sawNil {
+        t.Fatalf("expected an empty description and a NULL due date among %v", stmt.Vars)
+    }
+}
+
+// Test that a status change also writes completed_at, which follows the
+// status, and a move writes the reset position.
+func TestTaskRepository_Update_StatusAndPosition(t *testing.T) {
+    db := newDryRunDB(t).Session(&gorm.Session{SkipDefaultTransaction: true})
+    var stmt *gorm.Statement
+    if err := db.Callback().Update().After("gorm:update").Register("test:capture", func(tx *gorm.DB) {
+        stmt = tx.Statement
+    }); err != nil {
+        t.Fatalf("register: %v", err)
+    }
+
+    tk := domaintask.New("t1", "u1", "title", "", 5)
+    if err := NewTaskRepository(db).Update(context.Background(), tk, apptask.FieldStatus, apptask.FieldPosition); err != nil {
+        t.Fatalf("update: %v", err)
+    }
+    sql := stmt.SQL.String()
+    for _, col := range []string{`"status"=`, `"completed_at"=`, `"position"=`} {
+        if !strings.Contains(sql, col) {
+            t.Fatalf("expected %s to be written, got %s", col, sql)
+        }
+    }
+}
+
+// Test that List turns filters, sort and page into one WHERE, ORDER BY and
+// LIMIT/OFFSET, and counts the matches with the same conditions.
+func TestTaskRepository_List_BuildsQuery(t *testing.T) {
+    db := newDryRunDB(t)
+    var stmts []string
+    if err := db.Callback().Query().After("gorm:query").Register("test:capture", func(tx *gorm.DB) {
+        stmts = append(stmts, tx.Statement.SQL.String())
+    }); err != nil {
+        t.Fatalf("register: %v", err)
+    }
+    repo := NewTaskRepository(db)
+    u, p := "u1", "p1"
+    now := time.Now()
+
+    cases := []struct {
+        name   string
+        filter apptask.FilterOptions
+        sort   apptask.SortOptions
+        page   apptask.ListOptions
+        want   []string
+        count  bool
+    }{
+        {
+            name: "zero options",
+            want: []string{`WHERE tenant_id = $1`, `ORDER BY created_at, id`},
+        },
+        {
+            name:   "every filter",
+            filter: apptask.FilterOptions{UserID: &u, ParentID: &p, Statuses: []string{"todo", "done"}, Open: true, DueFrom: &now, DueBefore: &now},
+            want: []string{
+                `(user_id = $2 OR assignee_id = $3)`, `parent_id = $4`, `status IN ($5,$6)`,
+                `status NOT IN ($7,$8)`, `due_date >= $9`, `due_date < $10`,
+            },
+        },
+        {
+            name:  "score descending, paged",
+            sort:  apptask.SortOptions{Field: apptask.SortAIScore, Desc: true},
+            page:  apptask.ListOptions{Limit: 20, Offset: 40},
+            want:  []string{`ORDER BY ai_score DESC NULLS LAST, created_at, id LIMIT $2 OFFSET $3`},
+            count: true,
+        },
+        {
+            name: "due date ascending",
+            sort: apptask.SortOptions{Field: apptask.SortDueDate},
+            want: []string{`ORDER BY due_date ASC NULLS LAST, created_at, id`},
+        },
+        {
+            name:  "newest first",
+            sort:  apptask.SortOptions{Field: apptask.SortCreatedAt, Desc: true},
+            page:  apptask.ListOptions{Limit: 5},
+            want:  []string{`ORDER BY created_at DESC, id DESC LIMIT $2`},
+            count: true,
+        },
+    }
+    for _, tc := range cases {
+        stmts = nil
+        if _, _, err := repo.List(context.Background(), "t1", tc.filter, tc.sort, tc.page); err != nil {
+            t.Fatalf("%s: %v", tc.name, err)
+        }
+        sql := stmts[len(stmts)-1]
+        for _, w := range tc.want {
+            if !strings.Contains(sql, w) {
+                t.Fatalf("%s: expected %q in %s", tc.name, w, sql)
+            }
+        }
+        if !strings.Contains(sql, `"deleted_at" IS NULL`) {
+            t.Fatalf("%s: expected deleted tasks to be excluded, got %s", tc.name, sql)
+        }
+        if counted := len(stmts) == 2 && strings.HasPrefix(stmts[0], "SELECT count(*)"); counted != tc.count {
+            t.Fatalf("%s: expected count query %v, got %q", tc.name, tc.count, stmts)
+        }
+        if tc.count && strings.Contains(stmts[0], "ORDER BY") {
+            t.Fatalf("%s: count query must not be ordered, got %s", tc.name, stmts[0])
+        }
+    }
+}
+
+// Test that deleting a task removes its row rather than soft-deleting it.
+func TestTaskRepository_Delete_IsHard(t *testing.T) {
+    db := newDryRunDB(t).Session(&gorm.Session{SkipDefaultTransaction: true})
+    var sql string
+    if err := db.Callback().Delete().After("gorm:delete").Register("test:capture", func(tx *gorm.DB) {
+        sql = tx.Statement.SQL.String()
+    }); err != nil {
+        t.Fatalf("register: %v", err)
+    }
+    // A dry run affects no rows, so the task reads as missing.
+    _ = NewTaskRepository(db).Delete(context.Background(), "t1", "11111111-1111-1111-1111-111111111111")
+    if !strings.HasPrefix(sql, "DELETE FROM") || strings.Contains(sql, "deleted_at") {
+        t.Fatalf("expected a hard delete, got %s", sql)
+    }
+}
@@ -0,0 +1,105 @@
+package postgres
+
+import (
+    "context"
+    "errors"
+
+    apptemplate "backend/internal/application/template"
+    domaintask "backend/internal/domain/task"
+
+    "gorm.io/gorm"
+)
+
+type TemplateRepository struct {
+    db *gorm.DB
+}
+
+func NewTemplateRepository(db *gorm.DB) *TemplateRepository {
+    return &TemplateRepository{db: db}
+}
+
+var _ apptemplate.Repository = (*TemplateRepository)(nil)
+
+func (r *TemplateRepository) Create(ctx context.Context, t *domaintask.TaskTemplate) error {
+    rec := toTemplateRecord(t)
+    return r.db.WithContext(ctx).Create(&rec).Error
+}
+
+func (r *TemplateRepository) ListByTenant(ctx context.Context, tenantID string) ([]domaintask.TaskTemplate, error) {
+    var recs []TaskTemplateRecord
+    if err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).Order("name, created_at").Find(&recs).Error; err != nil {
+        return nil, err
+    }
+    out := make([]domaintask.TaskTemplate, 0, len(recs))
+    for _, rec := range recs {
+        out = append(out, toTemplateDomain(rec))
+    }
+    return out, nil
+}
+
+func (r *TemplateRepository) Get(ctx context.Context, tenantID, id string) (*domaintask.TaskTemplate, error) {
+    var rec TaskTemplateRecord
+    err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).First(&rec).Error
+    if errors.Is(err, gorm.ErrRecordNotFound) {
+        return nil, apptemplate.ErrNotFound
+    }
+    if err != nil {
+        return nil, err
+    }
+    t := toTemplateDomain(rec)
+    return &t, nil
+}
+
+func (r *TemplateRepository) Update(ctx context.Context, t *domaintask.TaskTemplate) error {
+    rec := toTemplateRecord(t)
+    res := r.db.WithContext(ctx).Model(&TaskTemplateRecord{}).
+        Where("tenant_id = ? AND id = ?", t.TenantID, t.ID).
+        Select("name", "title_pattern", "description", "priority", "tags", "updated_at").
+        Updates(&rec)
+    if res.Error != nil {
+        return res.Error
+    }
+    if res.RowsAffected == 0 {
+        return apptemplate.ErrNotFound
+    }
+    return nil
+}
+
+func (r *TemplateRepository) Delete(ctx context.Context, tenantID, id string) error {
+    res := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Delete(&TaskTemplateRecord{})
+    if res.Error != nil {
+        return res.Error
+    }
+    if res.RowsAffected == 0 {
+        return apptemplate.ErrNotFound
+    }
+    return nil
+}
+
+func toTemplateRecord(t *domaintask.TaskTemplate) TaskTemplateRecord {
+    return TaskTemplateRecord{
+        ID:           t.ID,
+        TenantID:     t.TenantID,
+        Name:         t.Name,
+        TitlePattern: t.TitlePattern,
+        Description:  t.Description,
+        Priority:     t.Priority,
+        Tags:         t.Tags,
+        CreatedAt:    t.CreatedAt,
+        UpdatedAt:    t.UpdatedAt,
+    }
+}
+
+func toTemplateDomain(rec TaskTemplateRecord) domaintask.TaskTemplate {
+    return domaintask.TaskTemplate{
+        ID:           rec.ID,
+        TenantID:     rec.TenantID,
+        Name:         rec.Name,
+        TitlePattern: rec.TitlePattern,
+        Description:  rec.Description,
+        Priority:     rec.Priority,
+        Tags:         rec.Tags,
+        CreatedAt:    rec.CreatedAt.UTC(),
+        UpdatedAt:    rec.UpdatedAt.UTC(),
+    }
+}
@@ -0,0 +1,65 @@
+package postgres
+
+import (
+    "context"
+
+    apptenant "backend/internal/application/tenant"
+
+    "github.com/google/uuid"
+    "gorm.io/gorm"
+)
+
+type TenantRepository struct {
+    db *gorm.DB
+}
+
+func NewTenantRepository(db *gorm.DB) *TenantRepository {
+    return &TenantRepository{db: db}
+}
+
+var _ apptenant.Repository = (*TenantRepository)(nil)
+
+func (r *TenantRepository) PurgeData(ctx context.Context, tenantID string) (apptenant.PurgeResult, error) {
+    var res apptenant.PurgeResult
+    err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        // Unscoped so soft-deleted rows are removed as well.
+        steps := []struct {
+            model any
+            count *int64
+        }{
+            {&ProjectFavoriteRecord{}, &res.ProjectFavorites},
+            {&TaskCommentRecord{}, &res.Comments},
+            {&TaskAttachmentRecord{}, &res.Attachments},
+            {&TaskWatcherRecord{}, &res.Watchers},
+            {&TaskDependencyRecord{}, &res.Dependencies},
+            {&TaskRecord{}, &res.Tasks},
+            {&ProjectRecord{}, &res.Projects},
+            {&PrioritizeSettingsRecord{}, &res.PrioritizeSettings},
+            {&APIKeyRecord{}, &res.APIKeys},
+            {&FeatureFlagRecord{}, &res.FeatureFlags},
+            {&TaskTemplateRecord{}, &res.Templates},
+            {&TenantSettingsRecord{}, &res.TenantSettings},
+            {&RefreshTokenRecord{}, &res.RefreshTokens},
+            {&UserRecord{}, &res.Users},
+        }
+        for _, s := range steps {
+            del := tx.Unscoped().Where("tenant_id = ?", tenantID).Delete(s.model)
+            if del.Error != nil {
+                return del.Error
+            }
+            *s.count = del.RowsAffected
+        }
+        // The tenant record goes last, once nothing refers to it. Only
+        // registered tenants have one, and its id is a UUID.
+        if _, err := uuid.Parse(tenantID); err != nil {
+            return nil
+        }
+        del := tx.Unscoped().Where("id = ?", tenantID).Delete(&TenantRecord{})
+        res.Tenants = del.RowsAffected
+        return del.Error
+    })
+    if err != nil {
+        return apptenant.PurgeResult{}, err
+    }
+    return res, nil
+}
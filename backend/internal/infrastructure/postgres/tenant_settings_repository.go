@@ -0,0 +1,42 @@
+package postgres
+
+import (
+    "context"
+    "errors"
+
+    apptenant "backend/internal/application/tenant"
+    domaintenant "backend/internal/domain/tenant"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+)
+
+type TenantSettingsRepository struct {
+    db *gorm.DB
+}
+
+func NewTenantSettingsRepository(db *gorm.DB) *TenantSettingsRepository {
+    return &TenantSettingsRepository{db: db}
+}
+
+var _ apptenant.SettingsRepository = (*TenantSettingsRepository)(nil)
+
+func (r *TenantSettingsRepository) GetSettings(ctx context.Context, tenantID string) (*domaintenant.TenantSettings, error) {
+    var rec TenantSettingsRecord
+    err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).First(&rec).Error
+    if errors.Is(err, gorm.ErrRecordNotFound) {
+        return nil, apptenant.ErrSettingsNotFound
+    }
+    if err != nil {
+        return nil, err
+    }
+    return &domaintenant.TenantSettings{TenantID: rec.TenantID, Region: rec.Region, UpdatedAt: rec.UpdatedAt}, nil
+}
+
+func (r *TenantSettingsRepository) SaveSettings(ctx context.Context, st *domaintenant.TenantSettings) error {
+    rec := TenantSettingsRecord{TenantID: st.TenantID, Region: st.Region, UpdatedAt: st.UpdatedAt}
+    return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+        Columns:   []clause.Column{{Name: "tenant_id"}},
+        DoUpdates: clause.AssignmentColumns([]string{"region", "updated_at"}),
+    }).Create(&rec).Error
+}
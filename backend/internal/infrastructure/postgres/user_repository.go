@@ -0,0 +1,110 @@
+package postgres
+
+import (
+    "context"
+    "errors"
+
+    appuser "backend/internal/application/user"
+    domainaccount "backend/internal/domain/account"
+
+    "github.com/jackc/pgx/v5/pgconn"
+    "gorm.io/gorm"
+)
+
+type UserRepository struct {
+    db *gorm.DB
+}
+
+func NewUserRepository(db *gorm.DB) *UserRepository {
+    return &UserRepository{db: db}
+}
+
+var _ appuser.Repository = (*UserRepository)(nil)
+
+func toUserRecord(u *domainaccount.User) UserRecord {
+    return UserRecord{
+        ID:           u.ID,
+        TenantID:     u.TenantID,
+        Email:        u.Email,
+        DisplayName:  u.DisplayName,
+        Role:         u.Role,
+        PasswordHash: u.PasswordHash,
+        CreatedAt:    u.CreatedAt,
+    }
+}
+
+func (rec UserRecord) toDomain() *domainaccount.User {
+    return &domainaccount.User{
+        ID:           rec.ID,
+        TenantID:     rec.TenantID,
+        Email:        rec.Email,
+        DisplayName:  rec.DisplayName,
+        Role:         rec.Role,
+        PasswordHash: rec.PasswordHash,
+        CreatedAt:    rec.CreatedAt.UTC(),
+    }
+}
+
+// isUniqueViolation reports whether err is Postgres refusing a duplicate
+// key (unique_violation).
+func isUniqueViolation(err error) bool {
+    var pgErr *pgconn.PgError
+    return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+func (r *UserRepository) GetByID(ctx context.Context, tenantID, id string) (*domainaccount.User, error) {
+    return r.first(r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id))
+}
+
+func (r *UserRepository) GetByEmail(ctx context.Context, tenantID, email string) (*domainaccount.User, error) {
+    return r.first(r.db.WithContext(ctx).Where("tenant_id = ? AND lower(email) = ?", tenantID, domainaccount.NormalizeEmail(email)))
+}
+
+func (r *UserRepository) first(q *gorm.DB) (*domainaccount.User, error) {
+    var rec UserRecord
+    err := q.First(&rec).Error
+    if errors.Is(err, gorm.ErrRecordNotFound) {
+        return nil, appuser.ErrNotFound
+    }
+    if err != nil {
+        return nil, err
+    }
+    return rec.toDomain(), nil
+}
+
+func (r *UserRepository) ListByTenant(ctx context.Context, tenantID string) ([]domainaccount.User, error) {
+    var recs []UserRecord
+    if err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).Order("display_name, id").Find(&recs).Error; err != nil {
+        return nil, err
+    }
+    out := make([]domainaccount.User, 0, len(recs))
+    for _, rec := range recs {
+        out = append(out, *rec.toDomain())
+    }
+    return out, nil
+}
+
+func (r *UserRepository) Create(ctx context.Context, u *domainaccount.User) error {
+    rec := toUserRecord(u)
+    err := r.db.WithContext(ctx).Create(&rec).Error
+    if isUniqueViolation(err) {
+        return appuser.ErrEmailTaken
+    }
+    return err
+}
+
+func (r *UserRepository) Update(ctx context.Context, u *domainaccount.User) error {
+    res := r.db.WithContext(ctx).Model(&UserRecord{}).
+        Where("tenant_id = ? AND id = ?", u.TenantID, u.ID).
+        Updates(map[string]any{"email": u.Email, "display_name": u.DisplayName, "role": u.Role})
+    if isUniqueViolation(res.Error) {
+        return appuser.ErrEmailTaken
+    }
+    if res.Error != nil {
+        return res.Error
+    }
+    if res.RowsAffected == 0 {
+        return appuser.ErrNotFound
+    }
+    return nil
+}
@@ -0,0 +1,24 @@
+package redisjob
+
+import (
+    "context"
+
+    apphealth "backend/internal/application/health"
+
+    "github.com/redis/go-redis/v9"
+)
+
+// ReadinessChecker pings Redis for GET /readyz.
+type ReadinessChecker struct {
+    client redis.UniversalClient
+}
+
+var _ apphealth.ReadinessChecker = ReadinessChecker{}
+
+func NewReadinessChecker(client redis.UniversalClient) ReadinessChecker {
+    return ReadinessChecker{client: client}
+}
+
+func (r ReadinessChecker) Check(ctx context.Context) error {
+    return r.client.Ping(ctx).Err()
+}
@@ -0,0 +1,20 @@
+package redisjob
+
+import (
+    "context"
+    "testing"
+)
+
+// Test that the readiness check passes while Redis answers and fails once
+// it is gone.
+func TestReadinessChecker(t *testing.T) {
+    mr, client := newTestClient(t)
+    r := NewReadinessChecker(client)
+    if err := r.Check(context.Background()); err != nil {
+        t.Fatalf("expected Redis to be ready, got %v", err)
+    }
+    mr.Close()
+    if err := r.Check(context.Background()); err == nil {
+        t.Fatalf("expected an error once Redis is gone")
+    }
+}
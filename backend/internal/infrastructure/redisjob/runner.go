@@ -0,0 +1,180 @@
+// Package redisjob runs background jobs through Redis: RedisJobRunner pushes
+// jobs onto a list, Worker pops and dispatches them, and each job's status
+// lives in its own hash until JobTTL after its last update.
+package redisjob
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "strconv"
+    "time"
+
+    appjob "backend/internal/application/job"
+    apptenant "backend/internal/application/tenant"
+
+    "github.com/google/uuid"
+    "github.com/redis/go-redis/v9"
+)
+
+const (
+    // QueueKey is the list jobs wait on, pushed left and popped right.
+    QueueKey = "mauflow:jobs"
+    // JobTTL is how long a job's status is kept after its last update.
+    JobTTL = 24 * time.Hour
+)
+
+// statusKey is the hash holding a job's status. Each job gets a hash of its
+// own, rather than a field in one shared jobs hash, so that its status can
+// expire on its own JobTTL after its last update.
+func statusKey(id string) string { return QueueKey + ":" + id }
+
+// message is what is pushed onto QueueKey.
+type message struct {
+    ID      string `json:"id"`
+    Type    string `json:"type"`
+    Payload []byte `json:"payload"`
+}
+
+// RedisJobRunner enqueues jobs with LPUSH and reads their status.
+type RedisJobRunner struct {
+    client redis.UniversalClient
+    now    func() time.Time
+}
+
+func NewRedisJobRunner(client redis.UniversalClient) *RedisJobRunner {
+    return &RedisJobRunner{client: client, now: func() time.Time { return time.Now().UTC() }}
+}
+
+var (
+    _ appjob.JobRunner   = (*RedisJobRunner)(nil)
+    _ appjob.Store       = (*RedisJobRunner)(nil)
+    _ apptenant.JobStore = (*RedisJobRunner)(nil)
+)
+
+// Enqueue records the job as queued and pushes it onto QueueKey in one
+// transaction.
+func (r *RedisJobRunner) Enqueue(ctx context.Context, jobType string, payload []byte, opts ...appjob.EnqueueOption) (string, error) {
+    now := r.now()
+    j := appjob.Job{ID: uuid.NewString(), Type: jobType, Status: appjob.StatusQueued, CreatedAt: now, UpdatedAt: now}
+    for _, opt := range opts {
+        opt(&j)
+    }
+    msg, err := json.Marshal(message{ID: j.ID, Type: j.Type, Payload: payload})
+    if err != nil {
+        return "", err
+    }
+    _, err = r.client.TxPipelined(ctx, func(p redis.Pipeliner) error {
+        p.HSet(ctx, statusKey(j.ID), toFields(j))
+        p.Expire(ctx, statusKey(j.ID), JobTTL)
+        p.LPush(ctx, QueueKey, msg)
+        return nil
+    })
+    if err != nil {
+        return "", err
+    }
+    return j.ID, nil
+}
+
+// Get returns the job's status, or appjob.ErrNotFound.
+func (r *RedisJobRunner) Get(ctx context.Context, id string) (*appjob.Job, error) {
+    return getJob(ctx, r.client, id)
+}
+
+// DeleteTenant removes the status hash of every job of the tenant. Messages
+// still queued are left for the worker.
+func (r *RedisJobRunner) DeleteTenant(ctx context.Context, tenantID string) (int64, error) {
+    var n int64
+    iter := r.client.Scan(ctx, 0, statusKey("*"), 100).Iterator()
+    for iter.Next(ctx) {
+        key := iter.Val()
+        owner, err := r.client.HGet(ctx, key, "tenantId").Result()
+        if err != nil && !errors.Is(err, redis.Nil) {
+            return n, err
+        }
+        if owner != tenantID {
+            continue
+        }
+        deleted, err := r.client.Del(ctx, key).Result()
+        if err != nil {
+            return n, err
+        }
+        n += deleted
+    }
+    return n, iter.Err()
+}
+
+func getJob(ctx context.Context, client redis.UniversalClient, id string) (*appjob.Job, error) {
+    fields, err := client.HGetAll(ctx, statusKey(id)).Result()
+    if err != nil {
+        return nil, err
+    }
+    if len(fields) == 0 {
+        return nil, appjob.ErrNotFound
+    }
+    return fromFields(fields)
+}
+
+// update writes fields to the job's status hash and renews its TTL.
+func update(ctx context.Context, client redis.UniversalClient, id string, fields map[string]any) error {
+    _, err := client.TxPipelined(ctx, func(p redis.Pipeliner) error {
+        p.HSet(ctx, statusKey(id), fields)
+        p.Expire(ctx, statusKey(id), JobTTL)
+        return nil
+    })
+    return err
+}
+
+func toFields(j appjob.Job) map[string]any {
+    return map[string]any{
+        "id":        j.ID,
+        "type":      j.Type,
+        "tenantId":  j.TenantID,
+        "status":    string(j.Status),
+        "processed": j.Processed,
+        "total":     j.Total,
+        "result":    string(j.Result),
+        "error":     j.Error,
+        "createdAt": j.CreatedAt.Format(time.RFC3339Nano),
+        "updatedAt": j.UpdatedAt.Format(time.RFC3339Nano),
+    }
+}
+
+func fromFields(f map[string]string) (*appjob.Job, error) {
+    j := appjob.Job{
+        ID:       f["id"],
+        Type:     f["type"],
+        TenantID: f["tenantId"],
+        Status:   appjob.Status(f["status"]),
+        Error:    f["error"],
+    }
+    var err error
+    if j.Processed, err = atoiField(f, "processed"); err != nil {
+        return nil, err
+    }
+    if j.Total, err = atoiField(f, "total"); err != nil {
+        return nil, err
+    }
+    if r := f["result"]; r != "" {
+        j.Result = json.RawMessage(r)
+    }
+    if j.CreatedAt, err = time.Parse(time.RFC3339Nano, f["createdAt"]); err != nil {
+        return nil, fmt.Errorf("job %s: createdAt: %w", j.ID, err)
+    }
+    if j.UpdatedAt, err = time.Parse(time.RFC3339Nano, f["updatedAt"]); err != nil {
+        return nil, fmt.Errorf("job %s: updatedAt: %w", j.ID, err)
+    }
+    return &j, nil
+}
+
+func atoiField(f map[string]string, name string) (int, error) {
+    if f[name] == "" {
+        return 0, nil
+    }
+    n, err := strconv.Atoi(f[name])
+    if err != nil {
+        return 0, fmt.Errorf("job %s: %s: %w", f["id"], name, err)
+    }
+    return n, nil
+}
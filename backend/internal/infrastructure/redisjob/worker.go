@@ -0,0 +1,138 @@
+package redisjob
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "log/slog"
+    "sync"
+    "time"
+
+    appjob "backend/internal/application/job"
+
+    "github.com/redis/go-redis/v9"
+)
+
+// Worker pops jobs off QueueKey and runs them with the handler registered
+// for their type, recording each job's status as it goes.
+type Worker struct {
+    client redis.UniversalClient
+    logger *slog.Logger
+    now    func() time.Time
+    // PollTimeout bounds each BRPOP, and so how long Start takes to notice
+    // that its context is done.
+    PollTimeout time.Duration
+
+    mu       sync.RWMutex
+    handlers map[string]appjob.JobHandler
+}
+
+// NewWorker returns a worker without handlers. A nil logger means
+// slog.Default().
+func NewWorker(client redis.UniversalClient, logger *slog.Logger) *Worker {
+    if logger == nil {
+        logger = slog.Default()
+    }
+    return &Worker{
+        client:      client,
+        logger:      logger,
+        now:         func() time.Time { return time.Now().UTC() },
+        PollTimeout: time.Second,
+        handlers:    make(map[string]appjob.JobHandler),
+    }
+}
+
+// Register makes h run the jobs of jobType, replacing any earlier handler.
+func (w *Worker) Register(jobType string, h appjob.JobHandler) {
+    w.mu.Lock()
+    defer w.mu.Unlock()
+    w.handlers[jobType] = h
+}
+
+// Start processes jobs one at a time until ctx is done, and returns once the
+// job in progress, if any, has finished; that job is not cancelled with ctx.
+func (w *Worker) Start(ctx context.Context) {
+    for ctx.Err() == nil {
+        if _, err := w.ProcessOne(ctx); err != nil && ctx.Err() == nil {
+            w.logger.ErrorContext(ctx, "job queue: pop failed", "error", err)
+            select {
+            case <-ctx.Done():
+            case <-time.After(w.PollTimeout):
+            }
+        }
+    }
+}
+
+// ProcessOne pops and runs one job, waiting at most PollTimeout for it, and
+// reports whether a job was run. Only the wait is cut short by ctx.
+func (w *Worker) ProcessOne(ctx context.Context) (bool, error) {
+    res, err := w.client.BRPop(ctx, w.PollTimeout, QueueKey).Result()
+    if errors.Is(err, redis.Nil) {
+        return false, nil
+    }
+    if err != nil {
+        return false, err
+    }
+    w.process(context.WithoutCancel(ctx), res[1])
+    return true, nil
+}
+
+func (w *Worker) process(ctx context.Context, raw string) {
+    var msg message
+    if err := json.Unmarshal([]byte(raw), &msg); err != nil {
+        w.logger.ErrorContext(ctx, "job queue: dropping malformed message", "error", err)
+        return
+    }
+    logger := w.logger.With("job_id", msg.ID, "job_type", msg.Type)
+    j, err := getJob(ctx, w.client, msg.ID)
+    if err != nil {
+        logger.ErrorContext(ctx, "job queue: reading job status failed", "error", err)
+        return
+    }
+    w.mu.RLock()
+    h, ok := w.handlers[msg.Type]
+    w.mu.RUnlock()
+    if !ok {
+        w.finish(ctx, logger, j.ID, nil, fmt.Errorf("no handler for job type %q", msg.Type))
+        return
+    }
+
+    start := time.Now()
+    if err := update(ctx, w.client, j.ID, map[string]any{"status": string(appjob.StatusProcessing), "updatedAt": w.stamp()}); err != nil {
+        logger.WarnContext(ctx, "job queue: recording start failed", "error", err)
+    }
+    j.Status = appjob.StatusProcessing
+    progress := func(ctx context.Context, processed, total int) error {
+        return update(ctx, w.client, j.ID, map[string]any{"processed": processed, "total": total, "updatedAt": w.stamp()})
+    }
+    result, err := w.run(ctx, h, *j, msg.Payload, progress)
+    w.finish(ctx, logger, j.ID, result, err)
+    logger.InfoContext(ctx, "job finished", "failed", err != nil, "duration_ms", time.Since(start).Milliseconds())
+}
+
+// run calls h, turning a panic into an error so one bad job cannot stop the
+// worker.
+func (w *Worker) run(ctx context.Context, h appjob.JobHandler, j appjob.Job, payload []byte, progress appjob.ProgressFunc) (result json.RawMessage, err error) {
+    defer func() {
+        if p := recover(); p != nil {
+            err = fmt.Errorf("job handler panicked: %v", p)
+        }
+    }()
+    return h.Handle(ctx, j, payload, progress)
+}
+
+func (w *Worker) finish(ctx context.Context, logger *slog.Logger, id string, result json.RawMessage, jobErr error) {
+    fields := map[string]any{"status": string(appjob.StatusDone), "result": string(result), "updatedAt": w.stamp()}
+    if jobErr != nil {
+        fields = map[string]any{"status": string(appjob.StatusFailed), "error": jobErr.Error(), "updatedAt": w.stamp()}
+        logger.WarnContext(ctx, "job failed", "error", jobErr)
+    }
+    if err := update(ctx, w.client, id, fields); err != nil {
+        logger.ErrorContext(ctx, "job queue: recording result failed", "error", err)
+    }
+}
+
+func (w *Worker) stamp() string {
+    return w.now().Format(time.RFC3339Nano)
+}
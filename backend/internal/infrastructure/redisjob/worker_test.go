@@ -0,0 +1,210 @@
+package redisjob
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "testing"
+    "time"
+
+    appjob "backend/internal/application/job"
+
+    "github.com/alicebob/miniredis/v2"
+    "github.com/redis/go-redis/v9"
+)
+
+func newTestClient(t *testing.T) (*miniredis.Miniredis, redis.UniversalClient) {
+    mr := miniredis.RunT(t)
+    client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+    t.Cleanup(func() { _ = client.Close() })
+    return mr, client
+}
+
+func newTestWorker(client redis.UniversalClient) *Worker {
+    w := NewWorker(client, nil)
+    w.PollTimeout = 50 * time.Millisecond
+    return w
+}
+
+// Test that an enqueued job is queued on the list with its status hash, and
+// that unknown ids are not found.
+func TestRedisJobRunner_Enqueue(t *testing.T) {
+    ctx := context.Background()
+    mr, client := newTestClient(t)
+    runner := NewRedisJobRunner(client)
+
+    id, err := runner.Enqueue(ctx, "export", []byte(`{"format":"csv"}`), appjob.ForTenant("t1"))
+    if err != nil {
+        t.Fatalf("enqueue: %v", err)
+    }
+    j, err := runner.Get(ctx, id)
+    if err != nil {
+        t.Fatalf("get: %v", err)
+    }
+    if j.Status != appjob.StatusQueued || j.Type != "export" || j.TenantID != "t1" {
+        t.Fatalf("expected a queued export job of t1, got %+v", j)
+    }
+    if n, _ := client.LLen(ctx, QueueKey).Result(); n != 1 {
+        t.Fatalf("expected 1 queued message, got %d", n)
+    }
+    if ttl := mr.TTL(statusKey(id)); ttl != JobTTL {
+        t.Fatalf("expected the status to expire after %v, got %v", JobTTL, ttl)
+    }
+    if _, err := runner.Get(ctx, "missing"); !errors.Is(err, appjob.ErrNotFound) {
+        t.Fatalf("expected ErrNotFound, got %v", err)
+    }
+}
+
+// Test that DeleteTenant removes the status of the tenant's jobs only,
+// leaving other tenants' jobs and the queue itself.
+func TestRedisJobRunner_DeleteTenant(t *testing.T) {
+    ctx := context.Background()
+    _, client := newTestClient(t)
+    runner := NewRedisJobRunner(client)
+
+    ids := map[string][]string{}
+    for _, tenantID := range []string{"t1", "t1", "t2"} {
+        id, err := runner.Enqueue(ctx, "export", nil, appjob.ForTenant(tenantID))
+        if err != nil {
+            t.Fatalf("enqueue: %v", err)
+        }
+        ids[tenantID] = append(ids[tenantID], id)
+    }
+
+    n, err := runner.DeleteTenant(ctx, "t1")
+    if err != nil {
+        t.Fatalf("delete tenant: %v", err)
+    }
+    if n != 2 {
+        t.Fatalf("expected 2 jobs removed, got %d", n)
+    }
+    for _, id := range ids["t1"] {
+        if _, err := runner.Get(ctx, id); !errors.Is(err, appjob.ErrNotFound) {
+            t.Fatalf("expected t1's job %s to be gone, got %v", id, err)
+        }
+    }
+    if _, err := runner.Get(ctx, ids["t2"][0]); err != nil {
+        t.Fatalf("expected t2's job to remain, got %v", err)
+    }
+    if n, _ := client.LLen(ctx, QueueKey).Result(); n != 3 {
+        t.Fatalf("expected the 3 queued messages to remain, got %d", n)
+    }
+}
+
+// Test that the worker hands a job's payload to the handler for its type,
+// records progress while it runs and its result when done.
+func TestWorker_ProcessOne(t *testing.T) {
+    ctx := context.Background()
+    _, client := newTestClient(t)
+    runner := NewRedisJobRunner(client)
+    w := newTestWorker(client)
+
+    var during *appjob.Job
+    w.Register("export", appjob.HandlerFunc(func(ctx context.Context, j appjob.Job, payload []byte, progress appjob.ProgressFunc) (json.RawMessage, error) {
+        if string(payload) != "rows" || j.Status != appjob.StatusProcessing {
+            t.Errorf("expected payload rows while processing, got %q (%s)", payload, j.Status)
+        }
+        if err := progress(ctx, 5, 10); err != nil {
+            return nil, err
+        }
+        during, _ = runner.Get(ctx, j.ID)
+        return json.RawMessage(`{"rows":10}`), nil
+    }))
+
+    id, err := runner.Enqueue(ctx, "export", []byte("rows"))
+    if err != nil {
+        t.Fatalf("enqueue: %v", err)
+    }
+    if ran, err := w.ProcessOne(ctx); err != nil || !ran {
+        t.Fatalf("expected a job to run, got %v (%v)", ran, err)
+    }
+    if during == nil || during.Status != appjob.StatusProcessing || during.Processed != 5 || during.Total != 10 {
+        t.Fatalf("expected processing 5/10 mid-run, got %+v", during)
+    }
+    j, err := runner.Get(ctx, id)
+    if err != nil {
+        t.Fatalf("get: %v", err)
+    }
+    if j.Status != appjob.StatusDone || string(j.Result) != `{"rows":10}` {
+        t.Fatalf("expected done with the result, got %+v", j)
+    }
+
+    if ran, err := w.ProcessOne(ctx); err != nil || ran {
+        t.Fatalf("expected an empty queue, got %v (%v)", ran, err)
+    }
+}
+
+// Test that handler errors, panics and unknown job types mark the job
+// failed without stopping the worker.
+func TestWorker_Failures(t *testing.T) {
+    ctx := context.Background()
+    _, client := newTestClient(t)
+    runner := NewRedisJobRunner(client)
+    w := newTestWorker(client)
+    w.Register("fails", appjob.HandlerFunc(func(context.Context, appjob.Job, []byte, appjob.ProgressFunc) (json.RawMessage, error) {
+        return nil, errors.New("smtp down")
+    }))
+    w.Register("panics", appjob.HandlerFunc(func(context.Context, appjob.Job, []byte, appjob.ProgressFunc) (json.RawMessage, error) {
+        panic("boom")
+    }))
+
+    for _, tc := range []struct{ jobType, wantErr string }{
+        {"fails", "smtp down"},
+        {"panics", "job handler panicked: boom"},
+        {"unknown", `no handler for job type "unknown"`},
+    } {
+        id, err := runner.Enqueue(ctx, tc.jobType, nil)
+        if err != nil {
+            t.Fatalf("%s: enqueue: %v", tc.jobType, err)
+        }
+        if _, err := w.ProcessOne(ctx); err != nil {
+            t.Fatalf("%s: process: %v", tc.jobType, err)
+        }
+        j, err := runner.Get(ctx, id)
+        if err != nil {
+            t.Fatalf("%s: get: %v", tc.jobType, err)
+        }
+        if j.Status != appjob.StatusFailed || j.Error != tc.wantErr {
+            t.Fatalf("%s: expected failed with %q, got %s with %q", tc.jobType, tc.wantErr, j.Status, j.Error)
+        }
+    }
+}
+
+// Test that Start works through the queue and returns once its context is
+// done.
+func TestWorker_Start(t *testing.T) {
+    ctx, cancel := context.WithCancel(context.Background())
+    _, client := newTestClient(t)
+    runner := NewRedisJobRunner(client)
+    w := newTestWorker(client)
+    handled := make(chan string, 1)
+    w.Register("ping", appjob.HandlerFunc(func(_ context.Context, j appjob.Job, _ []byte, _ appjob.ProgressFunc) (json.RawMessage, error) {
+        handled <- j.ID
+        return nil, nil
+    }))
+
+    stopped := make(chan struct{})
+    go func() {
+        w.Start(ctx)
+        close(stopped)
+    }()
+    id, err := runner.Enqueue(context.Background(), "ping", nil)
+    if err != nil {
+        t.Fatalf("enqueue: %v", err)
+    }
+    select {
+    case got := <-handled:
+        if got != id {
+            t.Fatalf("expected job %s, got %s", id, got)
+        }
+    case <-time.After(2 * time.Second):
+        t.Fatalf("expected the job to be handled")
+    }
+
+    cancel()
+    select {
+    case <-stopped:
+    case <-time.After(2 * time.Second):
+        t.Fatalf("expected Start to return after cancel")
+    }
+}
@@ -0,0 +1,25 @@
+// Package telemetry sets up OpenTelemetry exporters.
+package telemetry
+
+import (
+    "fmt"
+    "net/http"
+
+    "github.com/prometheus/client_golang/prometheus"
+    "github.com/prometheus/client_golang/prometheus/promhttp"
+    otelprom "go.opentelemetry.io/otel/exporters/prometheus"
+    sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// NewPrometheusMeterProvider returns a MeterProvider whose instruments are
+// exposed in Prometheus text format by the returned handler. It uses its own
+// registry so nothing leaks into prometheus.DefaultRegisterer.
+func NewPrometheusMeterProvider() (*sdkmetric.MeterProvider, http.Handler, error) {
+    reg := prometheus.NewRegistry()
+    exporter, err := otelprom.New(otelprom.WithRegisterer(reg))
+    if err != nil {
+        return nil, nil, fmt.Errorf("prometheus exporter: %w", err)
+    }
+    mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(exporter))
+    return mp, promhttp.HandlerFor(reg, promhttp.HandlerOpts{}), nil
+}
@@ -0,0 +1,125 @@
+package account
+
+import (
+    "errors"
+
+    appaccount "backend/internal/application/account"
+    "backend/internal/interface/http/middleware"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+type Handlers struct {
+    svc *appaccount.Service
+}
+
+func NewHandlers(svc *appaccount.Service) *Handlers {
+    return &Handlers{svc: svc}
+}
+
+type registerRequest struct {
+    Email      string `json:"email"`
+    Password   string `json:"password"`
+    TenantName string `json:"tenantName"`
+}
+
+type loginRequest struct {
+    Email    string `json:"email"`
+    Password string `json:"password"`
+}
+
+type refreshRequest struct {
+    RefreshToken string `json:"refreshToken"`
+}
+
+type changePasswordRequest struct {
+    CurrentPassword string `json:"currentPassword"`
+    NewPassword     string `json:"newPassword"`
+}
+
+// register creates a tenant and its first user and signs that user in.
+func (h *Handlers) register(c *fiber.Ctx) error {
+    var req registerRequest
+    if err := c.BodyParser(&req); err != nil {
+        return fiber.ErrBadRequest
+    }
+    s, err := h.svc.Register(c.UserContext(), req.Email, req.Password, req.TenantName)
+    switch {
+    case errors.Is(err, appaccount.ErrInvalidEmail), errors.Is(err, appaccount.ErrInvalidPassword), errors.Is(err, appaccount.ErrInvalidTenantName):
+        return fiber.NewError(fiber.StatusBadRequest, err.Error())
+    case errors.Is(err, appaccount.ErrEmailTaken):
+        return fiber.NewError(fiber.StatusConflict, err.Error())
+    case err != nil:
+        return fiber.ErrInternalServerError
+    }
+    return c.Status(fiber.StatusCreated).JSON(s)
+}
+
+// login exchanges an email and password for a token. Every failure to sign
+// in answers the same 401.
+func (h *Handlers) login(c *fiber.Ctx) error {
+    var req loginRequest
+    if err := c.BodyParser(&req); err != nil {
+        return fiber.ErrBadRequest
+    }
+    s, err := h.svc.Login(c.UserContext(), req.Email, req.Password)
+    if errors.Is(err, appaccount.ErrInvalidCredentials) {
+        return fiber.NewError(fiber.StatusUnauthorized, err.Error())
+    }
+    if err != nil {
+        return fiber.ErrInternalServerError
+    }
+    return c.JSON(s)
+}
+
+// refresh exchanges a refresh token for a new access token and refresh
+// token. Unknown, expired, revoked and reused tokens all answer 401.
+func (h *Handlers) refresh(c *fiber.Ctx) error {
+    var req refreshRequest
+    if err := c.BodyParser(&req); err != nil {
+        return fiber.ErrBadRequest
+    }
+    tok, err := h.svc.Refresh(c.UserContext(), req.RefreshToken)
+    if errors.Is(err, appaccount.ErrInvalidRefreshToken) || errors.Is(err, appaccount.ErrRefreshTokenReused) {
+        return fiber.NewError(fiber.StatusUnauthorized, appaccount.ErrInvalidRefreshToken.Error())
+    }
+    if err != nil {
+        return fiber.ErrInternalServerError
+    }
+    return c.JSON(tok)
+}
+
+// logout revokes the presented refresh token and answers 204, whether or not
+// the token was known.
+func (h *Handlers) logout(c *fiber.Ctx) error {
+    var req refreshRequest
+    if err := c.BodyParser(&req); err != nil {
+        return fiber.ErrBadRequest
+    }
+    if err := h.svc.Logout(c.UserContext(), req.RefreshToken); err != nil {
+        return fiber.ErrInternalServerError
+    }
+    return c.SendStatus(fiber.StatusNoContent)
+}
+
+// changePassword replaces the caller's password and answers 204. A wrong
+// current password answers 403 and a weak new one 400.
+func (h *Handlers) changePassword(c *fiber.Ctx) error {
+    var req changePasswordRequest
+    if err := c.BodyParser(&req); err != nil {
+        return fiber.ErrBadRequest
+    }
+    claims := middleware.ClaimsOf(c)
+    err := h.svc.ChangePassword(c.UserContext(), claims.TenantID, claims.UserID, req.CurrentPassword, req.NewPassword)
+    switch {
+    case errors.Is(err, appaccount.ErrWrongPassword):
+        return fiber.NewError(fiber.StatusForbidden, err.Error())
+    case errors.Is(err, appaccount.ErrWeakPassword):
+        return fiber.NewError(fiber.StatusBadRequest, err.Error())
+    case errors.Is(err, appaccount.ErrNotFound):
+        return fiber.ErrNotFound
+    case err != nil:
+        return fiber.ErrInternalServerError
+    }
+    return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,168 @@
+package account
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "errors"
+    "io"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+    "time"
+
+    appaccount "backend/internal/application/account"
+    "backend/internal/infrastructure/auth"
+    "backend/internal/infrastructure/memory"
+    "backend/internal/interface/http/middleware"
+    "backend/internal/pkg/identity"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+func newTestApp() *fiber.App {
+    app := fiber.New()
+    svc := appaccount.NewService(memory.NewAccountRepository(), auth.NewJWTService([]byte(strings.Repeat("s", 32))), time.Hour, 24*time.Hour)
+    RegisterRoutes(app.Group("/auth"), svc)
+    return app
+}
+
+func post(t *testing.T, app *fiber.App, path string, body map[string]string) *http.Response {
+    t.Helper()
+    b, _ := json.Marshal(body)
+    req := httptest.NewRequest("POST", path, bytes.NewReader(b))
+    req.Header.Set("Content-Type", "application/json")
+    resp, err := app.Test(req, -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    return resp
+}
+
+// Test that registering answers 201 with a token and its expiry, a taken
+// email 409 and an invalid one 400.
+func TestHandlers_Register(t *testing.T) {
+    app := newTestApp()
+    resp := post(t, app, "/auth/register", map[string]string{"email": "ada@example.com", "password": "correct horse", "tenantName": "Acme"})
+    if resp.StatusCode != fiber.StatusCreated {
+        t.Fatalf("expected status %d, got %d", fiber.StatusCreated, resp.StatusCode)
+    }
+    var body map[string]any
+    if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+    if body["token"] == "" || body["expiresAt"] == nil || body["tenant"] == nil {
+        t.Fatalf("expected token, expiresAt and tenant, got %v", body)
+    }
+    if user, _ := body["user"].(map[string]any); user["email"] != "ada@example.com" || user["passwordHash"] != nil {
+        t.Fatalf("expected the user without its hash, got %v", body["user"])
+    }
+
+    if resp := post(t, app, "/auth/register", map[string]string{"email": "Ada@Example.com", "password": "correct horse", "tenantName": "Acme"}); resp.StatusCode != fiber.StatusConflict {
+        t.Fatalf("expected status %d, got %d", fiber.StatusConflict, resp.StatusCode)
+    }
+    if resp := post(t, app, "/auth/register", map[string]string{"email": "nope", "password": "correct horse", "tenantName": "Acme"}); resp.StatusCode != fiber.StatusBadRequest {
+        t.Fatalf("expected status %d, got %d", fiber.StatusBadRequest, resp.StatusCode)
+    }
+}
+
+// Test that a wrong password and an unknown email get the same 401, so the
+// response does not reveal whether the email exists.
+func TestHandlers_Login(t *testing.T) {
+    app := newTestApp()
+    post(t, app, "/auth/register", map[string]string{"email": "ada@example.com", "password": "correct horse", "tenantName": "Acme"})
+
+    if resp := post(t, app, "/auth/login", map[string]string{"email": "ADA@example.com", "password": "correct horse"}); resp.StatusCode != fiber.StatusOK {
+        t.Fatalf("expected status %d, got %d", fiber.StatusOK, resp.StatusCode)
+    }
+    wrong := post(t, app, "/auth/login", map[string]string{"email": "ada@example.com", "password": "wrong horse"})
+    unknown := post(t, app, "/auth/login", map[string]string{"email": "bob@example.com", "password": "correct horse"})
+    wrongBody, _ := io.ReadAll(wrong.Body)
+    unknownBody, _ := io.ReadAll(unknown.Body)
+    if wrong.StatusCode != fiber.StatusUnauthorized || unknown.StatusCode != fiber.StatusUnauthorized || string(wrongBody) != string(unknownBody) {
+        t.Fatalf("expected identical 401s, got %d %q and %d %q", wrong.StatusCode, wrongBody, unknown.StatusCode, unknownBody)
+    }
+}
+
+// Test that refresh rotates the token, that reusing the old one answers 401,
+// and that logout answers 204 and revokes the token.
+func TestHandlers_RefreshAndLogout(t *testing.T) {
+    app := newTestApp()
+    resp := post(t, app, "/auth/register", map[string]string{"email": "ada@example.com", "password": "correct horse", "tenantName": "Acme"})
+    var login struct {
+        RefreshToken string `json:"refreshToken"`
+    }
+    if err := json.NewDecoder(resp.Body).Decode(&login); err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+
+    resp = post(t, app, "/auth/refresh", map[string]string{"refreshToken": login.RefreshToken})
+    if resp.StatusCode != fiber.StatusOK {
+        t.Fatalf("expected status %d, got %d", fiber.StatusOK, resp.StatusCode)
+    }
+    var next struct {
+        Token        string `json:"token"`
+        RefreshToken string `json:"refreshToken"`
+    }
+    if err := json.NewDecoder(resp.Body).Decode(&next); err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+    if next.Token == "" || next.RefreshToken == "" || next.RefreshToken == login.RefreshToken {
+        t.Fatalf("expected new tokens, got %+v", next)
+    }
+    if resp := post(t, app, "/auth/refresh", map[string]string{"refreshToken": login.RefreshToken}); resp.StatusCode != fiber.StatusUnauthorized {
+        t.Fatalf("expected status %d for a reused token, got %d", fiber.StatusUnauthorized, resp.StatusCode)
+    }
+
+    resp = post(t, app, "/auth/register", map[string]string{"email": "bob@example.com", "password": "correct horse", "tenantName": "Bobco"})
+    json.NewDecoder(resp.Body).Decode(&login)
+    if resp := post(t, app, "/auth/logout", map[string]string{"refreshToken": login.RefreshToken}); resp.StatusCode != fiber.StatusNoContent {
+        t.Fatalf("expected status %d, got %d", fiber.StatusNoContent, resp.StatusCode)
+    }
+    if resp := post(t, app, "/auth/refresh", map[string]string{"refreshToken": login.RefreshToken}); resp.StatusCode != fiber.StatusUnauthorized {
+        t.Fatalf("expected status %d after logout, got %d", fiber.StatusUnauthorized, resp.StatusCode)
+    }
+}
+
+// Test that changing the password answers 204, 403 for a wrong current
+// password and 400 for a weak new one, and that attempts beyond the limit
+// answer 429.
+func TestHandlers_ChangePassword(t *testing.T) {
+    svc := appaccount.NewService(memory.NewAccountRepository(), auth.NewJWTService([]byte(strings.Repeat("s", 32))), time.Hour, 24*time.Hour)
+    reg, err := svc.Register(context.Background(), "ada@example.com", "correct horse", "Acme")
+    if err != nil {
+        t.Fatalf("register: %v", err)
+    }
+    app := fiber.New()
+    app.Use(func(c *fiber.Ctx) error {
+        middleware.SetClaims(c, identity.Claims{UserID: reg.User.ID, TenantID: reg.User.TenantID})
+        return c.Next()
+    })
+    RegisterPasswordRoutes(app.Group("/users/me"), svc)
+
+    cases := []struct {
+        current, next string
+        status        int
+    }{
+        {"wrong horse", "battery staple", fiber.StatusForbidden},
+        {"correct horse", "short", fiber.StatusBadRequest},
+        {"correct horse", "battery staple", fiber.StatusNoContent},
+    }
+    for _, tc := range cases {
+        resp := post(t, app, "/users/me/password", map[string]string{"currentPassword": tc.current, "newPassword": tc.next})
+        if resp.StatusCode != tc.status {
+            t.Fatalf("expected status %d, got %d", tc.status, resp.StatusCode)
+        }
+    }
+    if _, err := svc.Refresh(context.Background(), reg.RefreshToken); !errors.Is(err, appaccount.ErrInvalidRefreshToken) {
+        t.Fatalf("expected the session to be revoked, got %v", err)
+    }
+
+    for i := len(cases); i < passwordAttempts; i++ {
+        post(t, app, "/users/me/password", map[string]string{"currentPassword": "wrong horse", "newPassword": "battery staple"})
+    }
+    if resp := post(t, app, "/users/me/password", map[string]string{"currentPassword": "battery staple", "newPassword": "battery staple 2"}); resp.StatusCode != fiber.StatusTooManyRequests {
+        t.Fatalf("expected status %d, got %d", fiber.StatusTooManyRequests, resp.StatusCode)
+    }
+}
@@ -0,0 +1,58 @@
+package account
+
+import (
+    "time"
+
+    appaccount "backend/internal/application/account"
+    "backend/internal/interface/http/middleware"
+
+    "github.com/gofiber/fiber/v2"
+    "github.com/gofiber/fiber/v2/middleware/limiter"
+)
+
+const (
+    // passwordAttempts is how many password changes a user may attempt per
+    // passwordWindow, to slow down guessing the current password with a
+    // stolen access token.
+    passwordAttempts = 5
+    passwordWindow   = 15 * time.Minute
+)
+
+// RegisterRoutes wires the sign-up, sign-in and token refresh routes to a router mounted at
+// /auth. The router must not require authentication.
+func RegisterRoutes(r fiber.Router, svc *appaccount.Service) {
+    NewHandlers(svc).Register(r)
+}
+
+// RegisterPasswordRoutes wires the password change route to an authenticated
+// router mounted at /users/me.
+func RegisterPasswordRoutes(r fiber.Router, svc *appaccount.Service) {
+    NewHandlers(svc).RegisterPassword(r)
+}
+
+// Register wires h's routes to the provided router.
+func (h *Handlers) Register(r fiber.Router) {
+    json := middleware.RequireContentType(middleware.ContentTypeJSON)
+    r.Post("/register", json, h.register)
+    r.Post("/login", json, h.login)
+    r.Post("/refresh", json, h.refresh)
+    r.Post("/logout", json, h.logout)
+}
+
+// RegisterPassword wires h's password route to the provided router, limited
+// per user to passwordAttempts per passwordWindow.
+func (h *Handlers) RegisterPassword(r fiber.Router) {
+    json := middleware.RequireContentType(middleware.ContentTypeJSON)
+    limit := limiter.New(limiter.Config{
+        Max:        passwordAttempts,
+        Expiration: passwordWindow,
+        KeyGenerator: func(c *fiber.Ctx) string {
+            claims := middleware.ClaimsOf(c)
+            return claims.TenantID + "/" + claims.UserID
+        },
+        LimitReached: func(*fiber.Ctx) error {
+            return fiber.NewError(fiber.StatusTooManyRequests, "too many password change attempts")
+        },
+    })
+    r.Post("/password", limit, json, h.changePassword)
+}
@@ -0,0 +1,142 @@
+package admin
+
+import (
+    "errors"
+    "strings"
+
+    appfeatureflag "backend/internal/application/featureflag"
+    appprioritize "backend/internal/application/prioritize"
+    apptask "backend/internal/application/task"
+    "backend/internal/interface/http/middleware"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+type Handlers struct {
+    prioritize *appprioritize.Service
+    tasks      *apptask.Service
+    flags      *appfeatureflag.Service
+    // maxTasks bounds how many tasks one reprioritize call scores.
+    maxTasks int
+    pageSize int
+}
+
+// NewHandlers returns handlers whose reprioritize call scores at most
+// maxTasks tasks (appprioritize.DefaultMaxTasks when maxTasks <= 0).
+func NewHandlers(prioritize *appprioritize.Service, tasks *apptask.Service, flags *appfeatureflag.Service, maxTasks int) *Handlers {
+    if maxTasks <= 0 {
+        maxTasks = appprioritize.DefaultMaxTasks
+    }
+    return &Handlers{prioritize: prioritize, tasks: tasks, flags: flags, maxTasks: maxTasks, pageSize: appprioritize.DefaultPageSize}
+}
+
+// targetTenant is the tenant an admin request acts on: tenantID when set,
+// else the caller's own. Callers in no tenant, such as the service token,
+// must name one.
+func targetTenant(c *fiber.Ctx, tenantID string) (string, error) {
+    if tenantID = strings.TrimSpace(tenantID); tenantID != "" {
+        return tenantID, nil
+    }
+    if tenantID = middleware.ClaimsOf(c).TenantID; tenantID == "" {
+        return "", fiber.NewError(fiber.StatusBadRequest, "tenantId is required")
+    }
+    return tenantID, nil
+}
+
+type reprioritizeRequest struct {
+    // TenantID defaults to the caller's tenant.
+    TenantID string `json:"tenantId"`
+    // AfterID resumes from a previous call's nextAfterId.
+    AfterID string `json:"afterId"`
+    // Limit lowers the per-call cap; zero or a value above it means the cap.
+    Limit int `json:"limit"`
+}
+
+type reprioritizeResponse struct {
+    TenantID string `json:"tenantId"`
+    Updated  int    `json:"updated"`
+    // NextAfterID, when set, is the afterId that continues the run; it is
+    // null once every open task has been rescored.
+    NextAfterID *string `json:"nextAfterId"`
+    DurationMS  int64   `json:"durationMs"`
+}
+
+// reprioritize recomputes and stores the aiScore of a tenant's open tasks in
+// id order, one transaction per page. A call scores at most limit tasks;
+// when more remain the response carries nextAfterId to resume from. Calls
+// only overwrite scores, so repeating or resuming one is safe. A tenant with
+// a prioritization run in progress gets 409.
+func (h *Handlers) reprioritize(c *fiber.Ctx) error {
+    var req reprioritizeRequest
+    if len(c.Body()) > 0 {
+        if err := c.BodyParser(&req); err != nil {
+            return fiber.ErrBadRequest
+        }
+    }
+    if req.Limit < 0 {
+        return fiber.NewError(fiber.StatusBadRequest, "limit must not be negative")
+    }
+    limit := h.maxTasks
+    if req.Limit > 0 {
+        limit = min(req.Limit, h.maxTasks)
+    }
+    tenantID, err := targetTenant(c, req.TenantID)
+    if err != nil {
+        return err
+    }
+    res, err := h.prioritize.RunAll(c.UserContext(), tenantID, h.tasks, appprioritize.RunOptions{MaxTasks: limit, PageSize: h.pageSize, AfterID: req.AfterID})
+    switch {
+    case errors.Is(err, appprioritize.ErrRunInProgress):
+        return fiber.NewError(fiber.StatusConflict, err.Error())
+    case err != nil:
+        return fiber.ErrInternalServerError
+    }
+    out := reprioritizeResponse{TenantID: tenantID, Updated: res.Scored, DurationMS: res.DurationMS}
+    if res.Truncated {
+        out.NextAfterID = &res.LastID
+    }
+    return c.JSON(out)
+}
+
+// listFeatureFlags returns the saved flags of ?tenantId=, by default the
+// caller's tenant. Flags that were never saved are off and not listed.
+func (h *Handlers) listFeatureFlags(c *fiber.Ctx) error {
+    tenantID, err := targetTenant(c, c.Query("tenantId"))
+    if err != nil {
+        return err
+    }
+    flags, err := h.flags.List(c.UserContext(), tenantID)
+    if err != nil {
+        return fiber.ErrInternalServerError
+    }
+    return c.JSON(flags)
+}
+
+type setFeatureFlagRequest struct {
+    // TenantID defaults to the caller's tenant.
+    TenantID string `json:"tenantId"`
+    Enabled  *bool  `json:"enabled"`
+}
+
+// setFeatureFlag turns :name on or off for a tenant.
+func (h *Handlers) setFeatureFlag(c *fiber.Ctx) error {
+    var req setFeatureFlagRequest
+    if err := c.BodyParser(&req); err != nil {
+        return fiber.ErrBadRequest
+    }
+    if req.Enabled == nil {
+        return fiber.NewError(fiber.StatusBadRequest, "enabled is required")
+    }
+    tenantID, err := targetTenant(c, req.TenantID)
+    if err != nil {
+        return err
+    }
+    f, err := h.flags.Set(c.UserContext(), tenantID, c.Params("name"), *req.Enabled)
+    switch {
+    case errors.Is(err, appfeatureflag.ErrInvalidName):
+        return fiber.NewError(fiber.StatusBadRequest, err.Error())
+    case err != nil:
+        return fiber.ErrInternalServerError
+    }
+    return c.JSON(f)
+}
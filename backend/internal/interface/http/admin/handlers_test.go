@@ -0,0 +1,184 @@
+package admin
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+
+    appfeatureflag "backend/internal/application/featureflag"
+    appprioritize "backend/internal/application/prioritize"
+    apptask "backend/internal/application/task"
+    domainfeatureflag "backend/internal/domain/featureflag"
+    domaintask "backend/internal/domain/task"
+    "backend/internal/infrastructure/memory"
+    "backend/internal/interface/http/middleware"
+    "backend/internal/pkg/identity"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// newTestApp mounts the admin routes behind RequireAdmin("admin") over an
+// in-memory repository, with the caller's claims set by a stub.
+func newTestApp(t *testing.T, userID string, repo *memory.TaskRepository) *fiber.App {
+    t.Helper()
+    app := fiber.New()
+    app.Use(func(c *fiber.Ctx) error {
+        middleware.SetClaims(c, identity.Claims{TenantID: "t1", UserID: userID})
+        return c.Next()
+    })
+    svc := appprioritize.NewService().WithSettings(memory.NewPrioritizeSettingsRepository())
+    RegisterRoutes(app.Group("/admin", middleware.RequireAdmin([]string{"admin"})), svc, apptask.NewService(repo), appfeatureflag.NewService(memory.NewFeatureFlagRepository()), 0)
+    return app
+}
+
+func postReprioritize(t *testing.T, app *fiber.App, body any) (int, reprioritizeResponse) {
+    t.Helper()
+    b, _ := json.Marshal(body)
+    req := httptest.NewRequest("POST", "/admin/reprioritize", bytes.NewReader(b))
+    req.Header.Set("Content-Type", "application/json")
+    resp, err := app.Test(req, -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    var out reprioritizeResponse
+    if resp.StatusCode == fiber.StatusOK {
+        if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+            t.Fatalf("decode: %v", err)
+        }
+    }
+    return resp.StatusCode, out
+}
+
+// Test that reprioritize overwrites stale stored scores of open tasks in
+// batches resumed through nextAfterId, and leaves closed tasks alone.
+func TestHandlers_Reprioritize(t *testing.T) {
+    ctx := context.Background()
+    repo := memory.NewTaskRepository()
+    stale := -1.0
+    var open []*domaintask.Task
+    for i := 0; i < 3; i++ {
+        tk := domaintask.New("t1", "u1", "task", "", 5)
+        tk.AiScore = &stale
+        open = append(open, tk)
+    }
+    done := domaintask.New("t1", "u1", "done", "", 5)
+    done.Status, done.AiScore = domaintask.StatusDone, &stale
+    for _, tk := range append(open, done) {
+        if err := repo.Create(ctx, tk); err != nil {
+            t.Fatalf("seed: %v", err)
+        }
+    }
+    app := newTestApp(t, "admin", repo)
+
+    status, first := postReprioritize(t, app, map[string]any{"limit": 2})
+    if status != fiber.StatusOK || first.Updated != 2 || first.NextAfterID == nil {
+        t.Fatalf("expected 2 tasks updated and a resume point, got %d %+v", status, first)
+    }
+    _, second := postReprioritize(t, app, map[string]any{"afterId": *first.NextAfterID, "limit": 2})
+    if second.Updated != 1 || second.NextAfterID != nil || second.TenantID != "t1" {
+        t.Fatalf("expected the last task updated and no resume point, got %+v", second)
+    }
+
+    for _, tk := range open {
+        got, err := repo.Get(ctx, "t1", tk.ID)
+        if err != nil {
+            t.Fatalf("get: %v", err)
+        }
+        if got.AiScore == nil || *got.AiScore == stale {
+            t.Fatalf("expected task %s rescored, got %v", tk.ID, got.AiScore)
+        }
+    }
+    got, err := repo.Get(ctx, "t1", done.ID)
+    if err != nil {
+        t.Fatalf("get: %v", err)
+    }
+    if got.AiScore == nil || *got.AiScore != stale {
+        t.Fatalf("expected the done task to keep its score, got %v", got.AiScore)
+    }
+}
+
+// Test that only administrators may reprioritize, and a negative limit is
+// rejected.
+func TestHandlers_Reprioritize_Rejects(t *testing.T) {
+    if status, _ := postReprioritize(t, newTestApp(t, "u1", memory.NewTaskRepository()), map[string]any{}); status != fiber.StatusForbidden {
+        t.Fatalf("expected status %d, got %d", fiber.StatusForbidden, status)
+    }
+    if status, _ := postReprioritize(t, newTestApp(t, "admin", memory.NewTaskRepository()), map[string]any{"limit": -1}); status != fiber.StatusBadRequest {
+        t.Fatalf("expected status %d, got %d", fiber.StatusBadRequest, status)
+    }
+}
+
+// Test that an administrator can turn a flag on for another tenant, list it,
+// and that bad names or a missing enabled get 400.
+func TestHandlers_FeatureFlags(t *testing.T) {
+    app := newTestApp(t, "admin", memory.NewTaskRepository())
+    put := func(name, body string) int {
+        req := httptest.NewRequest("PUT", "/admin/feature-flags/"+name, strings.NewReader(body))
+        req.Header.Set("Content-Type", "application/json")
+        resp, err := app.Test(req, -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        return resp.StatusCode
+    }
+
+    if status := put("graphql", `{"tenantId":"t2","enabled":true}`); status != fiber.StatusOK {
+        t.Fatalf("expected status %d, got %d", fiber.StatusOK, status)
+    }
+    if status := put("Not%20Valid", `{"enabled":true}`); status != fiber.StatusBadRequest {
+        t.Fatalf("expected status %d for a bad name, got %d", fiber.StatusBadRequest, status)
+    }
+    if status := put("graphql", `{}`); status != fiber.StatusBadRequest {
+        t.Fatalf("expected status %d without enabled, got %d", fiber.StatusBadRequest, status)
+    }
+
+    for query, want := range map[string]int{"?tenantId=t2": 1, "": 0} {
+        resp, err := app.Test(httptest.NewRequest("GET", "/admin/feature-flags"+query, nil), -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        var flags []domainfeatureflag.FeatureFlag
+        if err := json.NewDecoder(resp.Body).Decode(&flags); err != nil {
+            t.Fatalf("decode: %v", err)
+        }
+        if len(flags) != want || (want == 1 && (flags[0].Name != "graphql" || !flags[0].Enabled)) {
+            t.Fatalf("%q: expected %d flags, got %+v", query, want, flags)
+        }
+    }
+}
+
+// Test that the service token, which has no tenant, must name the tenant it
+// acts on.
+func TestHandlers_ServiceTokenNeedsTenant(t *testing.T) {
+    app := fiber.New()
+    app.Use(func(c *fiber.Ctx) error {
+        middleware.SetClaims(c, identity.Claims{UserID: "system", Roles: []string{identity.RoleSystem}})
+        return c.Next()
+    })
+    svc := appprioritize.NewService().WithSettings(memory.NewPrioritizeSettingsRepository())
+    RegisterRoutes(app.Group("/admin", middleware.RequireAdmin(nil, identity.RoleSystem)), svc, apptask.NewService(memory.NewTaskRepository()), appfeatureflag.NewService(memory.NewFeatureFlagRepository()), 0)
+
+    if status, _ := postReprioritize(t, app, map[string]any{}); status != fiber.StatusBadRequest {
+        t.Fatalf("expected status %d without tenantId, got %d", fiber.StatusBadRequest, status)
+    }
+    if status, _ := postReprioritize(t, app, map[string]any{"tenantId": "t1"}); status != fiber.StatusOK {
+        t.Fatalf("expected status %d with tenantId, got %d", fiber.StatusOK, status)
+    }
+    for _, req := range []*http.Request{
+        httptest.NewRequest("GET", "/admin/feature-flags", nil),
+        httptest.NewRequest("PUT", "/admin/feature-flags/graphql", strings.NewReader(`{"enabled":true}`)),
+    } {
+        req.Header.Set("Content-Type", "application/json")
+        resp, err := app.Test(req, -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        if resp.StatusCode != fiber.StatusBadRequest {
+            t.Fatalf("%s %s: expected status %d without tenantId, got %d", req.Method, req.URL.Path, fiber.StatusBadRequest, resp.StatusCode)
+        }
+    }
+}
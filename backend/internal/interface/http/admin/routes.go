@@ -0,0 +1,28 @@
+package admin
+
+import (
+    appfeatureflag "backend/internal/application/featureflag"
+    appprioritize "backend/internal/application/prioritize"
+    apptask "backend/internal/application/task"
+    "backend/internal/interface/http/middleware"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// RegisterRoutes wires operator routes to the provided router. The router is
+// expected to be restricted to administrators. maxTasks caps how many tasks
+// one reprioritize call scores. Feature flag routes are only registered when
+// flags is non-nil.
+func RegisterRoutes(r fiber.Router, prioritize *appprioritize.Service, tasks *apptask.Service, flags *appfeatureflag.Service, maxTasks int) {
+    NewHandlers(prioritize, tasks, flags, maxTasks).Register(r)
+}
+
+// Register wires h's routes to the provided router.
+func (h *Handlers) Register(r fiber.Router) {
+    jsonBody := middleware.RequireContentType(middleware.ContentTypeJSON)
+    r.Post("/reprioritize", jsonBody, h.reprioritize)
+    if h.flags != nil {
+        r.Get("/feature-flags", h.listFeatureFlags)
+        r.Put("/feature-flags/:name", jsonBody, h.setFeatureFlag)
+    }
+}
@@ -0,0 +1,25 @@
+package http
+
+import (
+    "backend/internal/pkg/config"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// AppConfig returns the Fiber settings derived from cfg. Client IPs are taken
+// from X-Forwarded-For only when the immediate peer is one of the configured
+// trusted proxies; otherwise the connection's remote address is used. The
+// request body limit follows the maximum attachment size, so no request can
+// be larger than the largest allowed upload.
+func AppConfig(cfg config.Config) fiber.Config {
+    fc := fiber.Config{ErrorHandler: ErrorHandler}
+    if n := cfg.MaxAttachmentBytes(); n > 0 {
+        fc.BodyLimit = n
+    }
+    if len(cfg.TrustedProxies) > 0 {
+        fc.EnableTrustedProxyCheck = true
+        fc.TrustedProxies = cfg.TrustedProxies
+        fc.ProxyHeader = fiber.HeaderXForwardedFor
+    }
+    return fc
+}
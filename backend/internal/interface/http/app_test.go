@@ -0,0 +1,52 @@
+package http
+
+import (
+    "io"
+    "net/http/httptest"
+    "testing"
+
+    "backend/internal/pkg/config"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// clientIP returns the IP Fiber resolves for a request sent through app.Test,
+// whose peer address is always 0.0.0.0.
+func clientIP(t *testing.T, cfg config.Config) string {
+    t.Helper()
+    app := fiber.New(AppConfig(cfg))
+    app.Get("/", func(c *fiber.Ctx) error { return c.SendString(c.IP()) })
+
+    req := httptest.NewRequest("GET", "/", nil)
+    req.Header.Set(fiber.HeaderXForwardedFor, "203.0.113.7")
+    resp, err := app.Test(req, -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    body, _ := io.ReadAll(resp.Body)
+    return string(body)
+}
+
+// Test that X-Forwarded-For is honoured only for a trusted peer.
+func TestAppConfig_TrustedProxies(t *testing.T) {
+    if ip := clientIP(t, config.Config{TrustedProxies: []string{"0.0.0.0"}}); ip != "203.0.113.7" {
+        t.Fatalf("trusted proxy: expected forwarded IP, got %q", ip)
+    }
+    if ip := clientIP(t, config.Config{TrustedProxies: []string{"10.0.0.1"}}); ip != "0.0.0.0" {
+        t.Fatalf("untrusted proxy: expected peer IP, got %q", ip)
+    }
+    if ip := clientIP(t, config.Config{}); ip != "0.0.0.0" {
+        t.Fatalf("no proxies configured: expected peer IP, got %q", ip)
+    }
+}
+
+// Test that the server body limit follows the attachment size, keeping
+// Fiber's default when none is configured.
+func TestAppConfig_BodyLimit(t *testing.T) {
+    if got := AppConfig(config.Config{MaxAttachmentSizeMB: 10}).BodyLimit; got != 10*1024*1024 {
+        t.Fatalf("expected body limit of 10 MiB, got %d", got)
+    }
+    if got := AppConfig(config.Config{}).BodyLimit; got != 0 {
+        t.Fatalf("expected Fiber's default body limit, got %d", got)
+    }
+}
@@ -0,0 +1,94 @@
+package attachment
+
+import (
+    "errors"
+    "os"
+    "path"
+    "path/filepath"
+    "strings"
+
+    appattachment "backend/internal/application/attachment"
+    "backend/internal/interface/http/middleware"
+    "backend/internal/interface/http/paging"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// FormField is the multipart field carrying the uploaded file.
+const FormField = "file"
+
+type Handlers struct {
+    svc *appattachment.Service
+}
+
+func NewHandlers(svc *appattachment.Service) *Handlers {
+    return &Handlers{svc: svc}
+}
+
+// toHTTPError maps attachment service errors to HTTP errors.
+func toHTTPError(err error) error {
+    switch {
+    case errors.Is(err, appattachment.ErrTaskNotFound):
+        return fiber.NewError(fiber.StatusNotFound, err.Error())
+    case errors.Is(err, appattachment.ErrEmptyFile):
+        return fiber.NewError(fiber.StatusBadRequest, err.Error())
+    case errors.Is(err, appattachment.ErrTooLarge):
+        return fiber.NewError(fiber.StatusRequestEntityTooLarge, err.Error())
+    case errors.Is(err, appattachment.ErrTypeNotAllowed):
+        return fiber.NewError(fiber.StatusUnsupportedMediaType, err.Error())
+    }
+    return fiber.ErrInternalServerError
+}
+
+// list pages through the task's attachments, oldest first.
+func (h *Handlers) list(c *fiber.Ctx) error {
+    page, err := paging.FromQuery(c)
+    if err != nil {
+        return err
+    }
+    items, err := h.svc.List(c.UserContext(), middleware.ClaimsOf(c).TenantID, c.Params("id"))
+    if err != nil {
+        return toHTTPError(err)
+    }
+    return paging.Send(c, paging.Slice(items, page))
+}
+
+// upload stores the file sent in the "file" field of a multipart form and
+// answers 201 with the attachment recorded for it.
+func (h *Handlers) upload(c *fiber.Ctx) error {
+    fh, err := c.FormFile(FormField)
+    if err != nil {
+        return fiber.NewError(fiber.StatusBadRequest, `multipart field "file" is required`)
+    }
+    f, err := fh.Open()
+    if err != nil {
+        return fiber.ErrBadRequest
+    }
+    defer f.Close()
+    a, err := h.svc.Upload(c.UserContext(), middleware.ClaimsOf(c).TenantID, c.Params("id"), appattachment.File{Name: fh.Filename, Size: fh.Size, Content: f})
+    if err != nil {
+        return toHTTPError(err)
+    }
+    return c.Status(fiber.StatusCreated).JSON(a)
+}
+
+// serveFile sends a file of the local blob store in dir. Keys start with
+// the tenant id, so a caller only gets files below their own tenant and a
+// 404 for anything else, as for a file that does not exist.
+func serveFile(dir string) fiber.Handler {
+    return func(c *fiber.Ctx) error {
+        p, err := middleware.FromCtx(c)
+        if err != nil || c.Params("tenantId") != p.TenantID {
+            return fiber.ErrNotFound
+        }
+        key := path.Clean(p.TenantID + "/" + c.Params("*"))
+        if !strings.HasPrefix(key, p.TenantID+"/") || !filepath.IsLocal(filepath.FromSlash(key)) {
+            return fiber.ErrNotFound
+        }
+        name := filepath.Join(dir, filepath.FromSlash(key))
+        if info, err := os.Stat(name); err != nil || info.IsDir() {
+            return fiber.ErrNotFound
+        }
+        return c.SendFile(name)
+    }
+}
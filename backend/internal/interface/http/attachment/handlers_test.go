@@ -0,0 +1,148 @@
+package attachment
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "io"
+    "mime/multipart"
+    "net/http"
+    "net/http/httptest"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+
+    appattachment "backend/internal/application/attachment"
+    domaintask "backend/internal/domain/task"
+    "backend/internal/infrastructure/blob"
+    "backend/internal/infrastructure/memory"
+    "backend/internal/interface/http/middleware"
+    "backend/internal/interface/http/paging"
+    "backend/internal/pkg/identity"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// upload posts content as a multipart file named name.
+func upload(t *testing.T, app *fiber.App, path, name string, content []byte) *http.Response {
+    t.Helper()
+    var body bytes.Buffer
+    w := multipart.NewWriter(&body)
+    part, err := w.CreateFormFile(FormField, name)
+    if err != nil {
+        t.Fatalf("form file: %v", err)
+    }
+    part.Write(content)
+    w.Close()
+    req := httptest.NewRequest("POST", path, &body)
+    req.Header.Set("Content-Type", w.FormDataContentType())
+    resp, err := app.Test(req, -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    return resp
+}
+
+// Test that an uploaded file lands in the local store and is recorded as an
+// attachment of the task, while disallowed files and unknown tasks are
+// refused.
+func TestHandlers_Upload(t *testing.T) {
+    tasks := memory.NewTaskRepository()
+    tk := domaintask.New("t1", "u1", "task", "", 5)
+    if err := tasks.Create(context.Background(), tk); err != nil {
+        t.Fatalf("seed: %v", err)
+    }
+    dir := t.TempDir()
+    svc := appattachment.NewService(memory.NewAttachmentRepository(tasks), blob.NewLocalStore(dir, "/uploads"))
+    app := fiber.New()
+    app.Use(func(c *fiber.Ctx) error {
+        middleware.SetClaims(c, identity.Claims{TenantID: "t1", UserID: "u1"})
+        return c.Next()
+    })
+    RegisterRoutes(app.Group("/tasks/:id/attachments"), svc)
+    base := "/tasks/" + tk.ID + "/attachments"
+
+    resp := upload(t, app, base+"/upload", "notes.txt", []byte("meeting notes"))
+    if resp.StatusCode != fiber.StatusCreated {
+        t.Fatalf("expected status %d, got %d", fiber.StatusCreated, resp.StatusCode)
+    }
+    var a domaintask.TaskAttachment
+    if err := json.NewDecoder(resp.Body).Decode(&a); err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+    if a.TaskID != tk.ID || a.Name != "notes.txt" || a.FileType != "text/plain" {
+        t.Fatalf("unexpected attachment %+v", a)
+    }
+    stored, err := os.ReadFile(filepath.Join(dir, strings.TrimPrefix(a.URL, "/uploads/")))
+    if err != nil || string(stored) != "meeting notes" {
+        t.Fatalf("expected the file in the store, got %q (%v)", stored, err)
+    }
+
+    resp, err = app.Test(httptest.NewRequest("GET", base, nil), -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    var page paging.PagedResponse[domaintask.TaskAttachment]
+    if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+    if len(page.Data) != 1 || page.Data[0].ID != a.ID {
+        t.Fatalf("expected the attachment listed, got %+v", page.Data)
+    }
+
+    if resp := upload(t, app, base+"/upload", "tool.exe", []byte("MZ\x90\x00\x03\x00\x00\x00")); resp.StatusCode != fiber.StatusUnsupportedMediaType {
+        t.Fatalf("expected status %d for a binary, got %d", fiber.StatusUnsupportedMediaType, resp.StatusCode)
+    }
+    missing := "/tasks/00000000-0000-0000-0000-000000000000/attachments/upload"
+    if resp := upload(t, app, missing, "notes.txt", []byte("notes")); resp.StatusCode != fiber.StatusNotFound {
+        t.Fatalf("expected status %d for an unknown task, got %d", fiber.StatusNotFound, resp.StatusCode)
+    }
+    req := httptest.NewRequest("POST", base+"/upload", strings.NewReader(`{"url":"x"}`))
+    req.Header.Set("Content-Type", "application/json")
+    if resp, _ := app.Test(req, -1); resp.StatusCode != fiber.StatusUnsupportedMediaType {
+        t.Fatalf("expected status %d for a JSON body, got %d", fiber.StatusUnsupportedMediaType, resp.StatusCode)
+    }
+}
+
+// Test that stored files are served to their own tenant only: another
+// tenant's files, paths climbing out of the tenant and missing files all
+// answer 404.
+func TestHandlers_ServeFile(t *testing.T) {
+    dir := t.TempDir()
+    store := blob.NewLocalStore(dir, "/uploads")
+    for key, content := range map[string]string{"t1/task/a.txt": "mine", "t2/task/b.txt": "theirs"} {
+        if _, err := store.Put(context.Background(), key, "text/plain", strings.NewReader(content)); err != nil {
+            t.Fatalf("put: %v", err)
+        }
+    }
+    app := fiber.New()
+    app.Use(func(c *fiber.Ctx) error {
+        middleware.SetClaims(c, identity.Claims{TenantID: "t1", UserID: "u1"})
+        return c.Next()
+    })
+    RegisterFileRoutes(app.Group("/uploads"), dir)
+
+    for _, tc := range []struct {
+        path   string
+        status int
+    }{
+        {"/uploads/t1/task/a.txt", fiber.StatusOK},
+        {"/uploads/t2/task/b.txt", fiber.StatusNotFound},
+        {"/uploads/t1/../t2/task/b.txt", fiber.StatusNotFound},
+        {"/uploads/t1/..%2ft2/task/b.txt", fiber.StatusNotFound},
+        {"/uploads/t1/task", fiber.StatusNotFound},
+        {"/uploads/t1/task/missing.txt", fiber.StatusNotFound},
+    } {
+        resp, err := app.Test(httptest.NewRequest("GET", tc.path, nil), -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        if resp.StatusCode != tc.status {
+            t.Fatalf("%s: expected status %d, got %d", tc.path, tc.status, resp.StatusCode)
+        }
+        if body, _ := io.ReadAll(resp.Body); tc.status == fiber.StatusOK && string(body) != "mine" {
+            t.Fatalf("%s: expected the file content, got %q", tc.path, body)
+        }
+    }
+}
@@ -0,0 +1,26 @@
+package attachment
+
+import (
+    appattachment "backend/internal/application/attachment"
+    "backend/internal/interface/http/middleware"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// ContentTypeMultipart is the media type of upload bodies.
+const ContentTypeMultipart = "multipart/form-data"
+
+// RegisterRoutes wires attachment routes to a router mounted at
+// /tasks/:id/attachments.
+func RegisterRoutes(r fiber.Router, svc *appattachment.Service) {
+    h := NewHandlers(svc)
+    id := middleware.RequireUUIDParams("id")
+    r.Get("/", id, h.list)
+    r.Post("/upload", id, middleware.RequireContentType(ContentTypeMultipart), h.upload)
+}
+
+// RegisterFileRoutes serves the files of the local blob store kept in dir
+// to a router mounted at the store's base URL, such as /uploads.
+func RegisterFileRoutes(r fiber.Router, dir string) {
+    r.Get("/:tenantId/*", serveFile(dir))
+}
@@ -0,0 +1,92 @@
+package comment
+
+import (
+    "errors"
+
+    appcomment "backend/internal/application/comment"
+    domaintask "backend/internal/domain/task"
+    "backend/internal/interface/http/middleware"
+    "backend/internal/interface/http/paging"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+type Handlers struct {
+    svc    *appcomment.Service
+    admins map[string]bool
+}
+
+// NewHandlers returns comment handlers; adminUserIDs may edit any comment.
+func NewHandlers(svc *appcomment.Service, adminUserIDs []string) *Handlers {
+    admins := make(map[string]bool, len(adminUserIDs))
+    for _, id := range adminUserIDs {
+        admins[id] = true
+    }
+    return &Handlers{svc: svc, admins: admins}
+}
+
+type commentRequest struct {
+    Content  string `json:"content"`
+    Internal bool   `json:"internal"`
+}
+
+func tenantAndUser(c *fiber.Ctx) (tenantID, userID string) {
+    claims := middleware.ClaimsOf(c)
+    return claims.TenantID, claims.UserID
+}
+
+// toHTTPError maps comment service errors to HTTP errors.
+func toHTTPError(err error) error {
+    switch {
+    case errors.Is(err, appcomment.ErrTaskNotFound), errors.Is(err, appcomment.ErrNotFound):
+        return fiber.NewError(fiber.StatusNotFound, err.Error())
+    case errors.Is(err, appcomment.ErrForbidden), errors.Is(err, appcomment.ErrInternalForbidden):
+        return fiber.NewError(fiber.StatusForbidden, err.Error())
+    case errors.Is(err, domaintask.ErrTooLong):
+        return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+    case errors.Is(err, domaintask.ErrRequired):
+        return fiber.NewError(fiber.StatusBadRequest, err.Error())
+    }
+    return fiber.ErrInternalServerError
+}
+
+// list pages through the task's comments; only admins see internal ones.
+func (h *Handlers) list(c *fiber.Ctx) error {
+    tenantID, userID := tenantAndUser(c)
+    page, err := paging.FromQuery(c)
+    if err != nil {
+        return err
+    }
+    items, err := h.svc.List(c.UserContext(), tenantID, c.Params("id"), h.admins[userID])
+    if err != nil {
+        return toHTTPError(err)
+    }
+    return paging.Send(c, paging.Slice(items, page))
+}
+
+func (h *Handlers) create(c *fiber.Ctx) error {
+    tenantID, userID := tenantAndUser(c)
+    var req commentRequest
+    if err := c.BodyParser(&req); err != nil {
+        return fiber.ErrBadRequest
+    }
+    cm, err := h.svc.Add(c.UserContext(), tenantID, c.Params("id"), userID, h.admins[userID], req.Content, req.Internal)
+    if err != nil {
+        return toHTTPError(err)
+    }
+    return c.Status(fiber.StatusCreated).JSON(cm)
+}
+
+// edit replaces a comment's content; only its author or an admin may do so.
+func (h *Handlers) edit(c *fiber.Ctx) error {
+    tenantID, userID := tenantAndUser(c)
+    var req commentRequest
+    if err := c.BodyParser(&req); err != nil {
+        return fiber.ErrBadRequest
+    }
+    cm, err := h.svc.Edit(c.UserContext(), tenantID, c.Params("id"), c.Params("commentId"), userID, h.admins[userID], req.Content)
+    if err != nil {
+        return toHTTPError(err)
+    }
+    return c.JSON(cm)
+}
@@ -0,0 +1,183 @@
+package comment
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+    "time"
+
+    appcomment "backend/internal/application/comment"
+    domaintask "backend/internal/domain/task"
+    "backend/internal/infrastructure/memory"
+    "backend/internal/interface/http/middleware"
+    "backend/internal/pkg/identity"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// newTestApp mounts the comment routes for a seeded task behind a stub that
+// takes the user from the X-Test-User header.
+func newTestApp(t *testing.T, adminUserIDs ...string) (*fiber.App, string) {
+    t.Helper()
+    tasks := memory.NewTaskRepository()
+    tk := domaintask.New("t1", "u1", "task", "", 5)
+    if err := tasks.Create(context.Background(), tk); err != nil {
+        t.Fatalf("seed: %v", err)
+    }
+    app := fiber.New()
+    app.Use(func(c *fiber.Ctx) error {
+        // Clone: Fiber reuses the header buffer once the request ends.
+        middleware.SetClaims(c, identity.Claims{TenantID: "t1", UserID: strings.Clone(c.Get("X-Test-User"))})
+        return c.Next()
+    })
+    RegisterRoutes(app.Group("/tasks/:id/comments"), appcomment.NewService(memory.NewCommentRepository(tasks)), adminUserIDs)
+    return app, tk.ID
+}
+
+// send issues a JSON request with content as user and returns the response.
+func send(t *testing.T, app *fiber.App, method, path, user, content string) *http.Response {
+    t.Helper()
+    body, _ := json.Marshal(map[string]string{"content": content})
+    req := httptest.NewRequest(method, path, bytes.NewReader(body))
+    req.Header.Set("Content-Type", "application/json")
+    req.Header.Set("X-Test-User", user)
+    resp, err := app.Test(req, -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    return resp
+}
+
+func decodeComment(t *testing.T, resp *http.Response) domaintask.TaskComment {
+    t.Helper()
+    var c domaintask.TaskComment
+    if err := json.NewDecoder(resp.Body).Decode(&c); err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+    return c
+}
+
+// Test that the author's PATCH succeeds and someone else's gets 403.
+func TestHandlers_Edit(t *testing.T) {
+    app, taskID := newTestApp(t)
+    base := "/tasks/" + taskID + "/comments/"
+    resp := send(t, app, "POST", base, "u1", "first draft")
+    if resp.StatusCode != fiber.StatusCreated {
+        t.Fatalf("expected status %d, got %d", fiber.StatusCreated, resp.StatusCode)
+    }
+    created := decodeComment(t, resp)
+
+    if resp := send(t, app, "PATCH", base+created.ID, "u2", "stolen"); resp.StatusCode != fiber.StatusForbidden {
+        t.Fatalf("expected status %d, got %d", fiber.StatusForbidden, resp.StatusCode)
+    }
+
+    resp = send(t, app, "PATCH", base+created.ID, "u1", "final")
+    if resp.StatusCode != fiber.StatusOK {
+        t.Fatalf("expected status %d, got %d", fiber.StatusOK, resp.StatusCode)
+    }
+    edited := decodeComment(t, resp)
+    if edited.Content != "final" || edited.EditedAt == nil || !edited.CreatedAt.Equal(created.CreatedAt) {
+        t.Fatalf("unexpected edit result %+v", edited)
+    }
+}
+
+// Test that createdAt and editedAt sent by the client are ignored: the
+// server sets them and returns them as RFC3339 UTC timestamps.
+func TestHandlers_IgnoresClientTimestamps(t *testing.T) {
+    app, taskID := newTestApp(t)
+    base := "/tasks/" + taskID + "/comments/"
+    forged := "2001-02-03T04:05:06Z"
+    sendRaw := func(method, path string) map[string]any {
+        body, _ := json.Marshal(map[string]string{"content": "hello", "createdAt": forged, "editedAt": forged})
+        req := httptest.NewRequest(method, path, bytes.NewReader(body))
+        req.Header.Set("Content-Type", "application/json")
+        req.Header.Set("X-Test-User", "u1")
+        resp, err := app.Test(req, -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        if resp.StatusCode >= 300 {
+            t.Fatalf("%s %s: unexpected status %d", method, path, resp.StatusCode)
+        }
+        var out map[string]any
+        if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+            t.Fatalf("decode: %v", err)
+        }
+        return out
+    }
+    serverTime := func(v any) time.Time {
+        s, _ := v.(string)
+        ts, err := time.Parse(time.RFC3339, s)
+        if err != nil || !strings.HasSuffix(s, "Z") {
+            t.Fatalf("expected an RFC3339 UTC timestamp, got %v", v)
+        }
+        if s == forged || time.Since(ts) > time.Minute {
+            t.Fatalf("expected the server time, got %s", s)
+        }
+        return ts
+    }
+
+    start := time.Now().Add(-time.Second)
+    created := sendRaw("POST", base)
+    createdAt := serverTime(created["createdAt"])
+    if _, ok := created["editedAt"]; ok || createdAt.Before(start) {
+        t.Fatalf("expected a new unedited comment, got %v", created)
+    }
+
+    edited := sendRaw("PATCH", base+created["id"].(string))
+    serverTime(edited["editedAt"])
+    if edited["createdAt"] != created["createdAt"] {
+        t.Fatalf("expected createdAt %v kept, got %v", created["createdAt"], edited["createdAt"])
+    }
+}
+
+// Test that an internal comment posted by an admin is left out of a regular
+// user's listing and shown to the admin.
+func TestHandlers_InternalComments(t *testing.T) {
+    app, taskID := newTestApp(t, "root")
+    base := "/tasks/" + taskID + "/comments/"
+    post := func(user string) *http.Response {
+        body, _ := json.Marshal(map[string]any{"content": "internal note", "internal": true})
+        req := httptest.NewRequest("POST", base, bytes.NewReader(body))
+        req.Header.Set("Content-Type", "application/json")
+        req.Header.Set("X-Test-User", user)
+        resp, err := app.Test(req, -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        return resp
+    }
+    if resp := post("u1"); resp.StatusCode != fiber.StatusForbidden {
+        t.Fatalf("expected status %d, got %d", fiber.StatusForbidden, resp.StatusCode)
+    }
+    if resp := post("root"); resp.StatusCode != fiber.StatusCreated {
+        t.Fatalf("expected status %d, got %d", fiber.StatusCreated, resp.StatusCode)
+    }
+    send(t, app, "POST", base, "u1", "public note")
+
+    list := func(user string) []domaintask.TaskComment {
+        req := httptest.NewRequest("GET", base, nil)
+        req.Header.Set("X-Test-User", user)
+        resp, err := app.Test(req, -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        var page struct {
+            Data []domaintask.TaskComment `json:"data"`
+        }
+        if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+            t.Fatalf("decode: %v", err)
+        }
+        return page.Data
+    }
+    if items := list("u1"); len(items) != 1 || items[0].Internal {
+        t.Fatalf("expected only the public comment, got %+v", items)
+    }
+    if items := list("root"); len(items) != 2 {
+        t.Fatalf("expected the admin to see 2 comments, got %+v", items)
+    }
+}
@@ -0,0 +1,18 @@
+package comment
+
+import (
+    appcomment "backend/internal/application/comment"
+    "backend/internal/interface/http/middleware"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// RegisterRoutes wires comment routes to a router mounted at
+// /tasks/:id/comments.
+func RegisterRoutes(r fiber.Router, svc *appcomment.Service, adminUserIDs []string) {
+    h := NewHandlers(svc, adminUserIDs)
+    id := middleware.RequireUUIDParams("id")
+    r.Get("/", id, h.list)
+    r.Post("/", id, middleware.RequireContentType(middleware.ContentTypeJSON), h.create)
+    r.Patch("/:commentId", middleware.RequireUUIDParams("id", "commentId"), middleware.RequireContentType(), h.edit)
+}
@@ -0,0 +1,64 @@
+package http
+
+import (
+    "bytes"
+    "context"
+    "net/http/httptest"
+    "testing"
+
+    appcomment "backend/internal/application/comment"
+    appprioritize "backend/internal/application/prioritize"
+    appproject "backend/internal/application/project"
+    apptask "backend/internal/application/task"
+    apptenant "backend/internal/application/tenant"
+    domaintask "backend/internal/domain/task"
+    "backend/internal/infrastructure/auth"
+    "backend/internal/infrastructure/memory"
+    "backend/internal/pkg/config"
+    "backend/internal/pkg/ctxkeys"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// recordingTaskRepository remembers the correlation ID seen by Create.
+type recordingTaskRepository struct {
+    *memory.TaskRepository
+    correlationID string
+}
+
+func (r *recordingTaskRepository) Create(ctx context.Context, t *domaintask.Task) error {
+    r.correlationID = ctxkeys.CorrelationIDFromCtx(ctx)
+    return r.TaskRepository.Create(ctx, t)
+}
+
+// Test that the request ID reaches the repository as the correlation ID.
+func TestCorrelationID_ReachesRepository(t *testing.T) {
+    tasks := memory.NewTaskRepository()
+    repo := &recordingTaskRepository{TaskRepository: tasks}
+    projects := memory.NewProjectRepository(tasks)
+    deps := NewDependencies(
+        auth.NewSimpleAuthService(),
+        apptask.NewService(repo),
+        appcomment.NewService(memory.NewCommentRepository(tasks)),
+        appproject.NewService(projects),
+        appprioritize.NewService(),
+        apptenant.NewService(memory.NewTenantRepository(tasks, projects)),
+    )
+    app := fiber.New(AppConfig(config.Config{}))
+    Build(app, deps)
+
+    req := httptest.NewRequest("POST", "/api/v1/tasks/", bytes.NewReader([]byte(`{"title":"write report"}`)))
+    req.Header.Set("Content-Type", "application/json")
+    req.Header.Set("Authorization", "Bearer token")
+    req.Header.Set(fiber.HeaderXRequestID, "corr-42")
+    resp, err := app.Test(req, -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    if resp.StatusCode != fiber.StatusCreated {
+        t.Fatalf("expected status %d, got %d", fiber.StatusCreated, resp.StatusCode)
+    }
+    if repo.correlationID != "corr-42" {
+        t.Fatalf("expected correlation id %q, got %q", "corr-42", repo.correlationID)
+    }
+}
@@ -1,9 +1,30 @@
 package http
 
 import (
+    "log/slog"
+    "net/http"
+
+    appaccount "backend/internal/application/account"
+    appapikey "backend/internal/application/apikey"
+    appattachment "backend/internal/application/attachment"
+    appcomment "backend/internal/application/comment"
+    appfeatureflag "backend/internal/application/featureflag"
+    apphealth "backend/internal/application/health"
+    appjob "backend/internal/application/job"
     appprioritize "backend/internal/application/prioritize"
+    appproject "backend/internal/application/project"
+    apprevocation "backend/internal/application/revocation"
     apptask "backend/internal/application/task"
+    apptemplate "backend/internal/application/template"
+    apptenant "backend/internal/application/tenant"
+    apptimeline "backend/internal/application/timeline"
+    appuser "backend/internal/application/user"
     "backend/internal/interface/http/middleware"
+    "backend/internal/pkg/config"
+
+    "github.com/gofiber/fiber/v2"
+    "go.opentelemetry.io/otel"
+    "go.opentelemetry.io/otel/metric"
 )
 
 // Dependencies groups services required by HTTP routes.
@@ -13,15 +34,71 @@ import (
 type Dependencies struct {
     auth              middleware.AuthService
     TaskService       *apptask.Service
+    CommentService    *appcomment.Service
+    ProjectService    *appproject.Service
     PrioritizeService *appprioritize.Service
+    TenantService     *apptenant.Service
+    // APIKeyService, when set, enables the tenant API key admin routes.
+    APIKeyService *appapikey.Service
+    // APIKeyAuth, when set, verifies X-API-Key headers on API routes.
+    APIKeyAuth middleware.AuthService
+    // TenantSettings, when set, enables the tenant settings admin routes and
+    // holds task writes of tenants with a data residency region to that
+    // region.
+    TenantSettings *apptenant.SettingsService
+    // Accounts, when set, enables registration and login at /auth.
+    Accounts *appaccount.Service
+    // Revocations, when set, enables revoking access tokens at
+    // /auth/revoke.
+    Revocations *apprevocation.Service
+    // AttachmentService, when set, enables listing and uploading task
+    // attachments.
+    AttachmentService *appattachment.Service
+    // TimelineService, when set, enables a task's activity feed at
+    // /tasks/:id/timeline.
+    TimelineService *apptimeline.Service
+    // UploadDir, when set, is served at UploadsPath, for attachments kept
+    // in the local blob store.
+    UploadDir string
+    // TemplateService, when set, enables task templates at /task-templates
+    // and creating tasks from them.
+    TemplateService *apptemplate.Service
+    // UserService, when set, enables the tenant's user directory at /users.
+    UserService *appuser.Service
+    // FeatureFlags, when set, enables the feature flag admin routes and
+    // gates the experimental routes per tenant.
+    FeatureFlags *appfeatureflag.Service
+    // Health, when set, reports its subsystems at /health.
+    Health *apphealth.Service
+    // ReadyDB and ReadyCache, when set, must answer for GET /readyz to
+    // report the instance ready.
+    ReadyDB    apphealth.ReadinessChecker
+    ReadyCache apphealth.ReadinessChecker
+    // Jobs, when set, enables polling background jobs at /jobs/:id.
+    Jobs appjob.Store
+    // TaskEvents, when set, feeds the task event stream.
+    TaskEvents apptask.EventSubscriber
+    // AIProvider, when set, is consulted by the prioritize endpoints.
+    AIProvider appprioritize.AIProvider
+    // Logger is used by the request logging middleware; slog.Default() when nil.
+    Logger *slog.Logger
+    // Config carries settings consumed by the global middleware.
+    Config config.Config
+    // MeterProvider receives HTTP request metrics; the global provider when nil.
+    MeterProvider metric.MeterProvider
+    // MetricsHandler, when set, is served at /metrics to the service token.
+    MetricsHandler http.Handler
 }
 
 // NewDependencies creates a new Dependencies instance.
-func NewDependencies(a middleware.AuthService, t *apptask.Service, p *appprioritize.Service) Dependencies {
+func NewDependencies(a middleware.AuthService, t *apptask.Service, cm *appcomment.Service, pr *appproject.Service, p *appprioritize.Service, tn *apptenant.Service) Dependencies {
     return Dependencies{
         auth:              a,
         TaskService:       t,
+        CommentService:    cm,
+        ProjectService:    pr,
         PrioritizeService: p,
+        TenantService:     tn,
     }
 }
 
@@ -29,3 +106,36 @@ func NewDependencies(a middleware.AuthService, t *apptask.Service, p *apppriorit
 func (d Dependencies) Auth() middleware.AuthService {
     return d.auth
 }
+
+// authMiddleware lets publicPaths through and accepts API keys alongside the
+// Authorization header when APIKeyAuth is set.
+func (d Dependencies) authMiddleware() fiber.Handler {
+    raw := middleware.AllowRawTokens(d.Config.AuthAllowRawTokens)
+    public := middleware.SkipPaths(publicPaths...)
+    if d.APIKeyAuth == nil {
+        return middleware.AuthMiddleware(d.Auth(), raw, public)
+    }
+    return middleware.AuthMiddlewareWithAPIKeys(d.Auth(), d.APIKeyAuth, raw, public)
+}
+
+// prioritizeService returns PrioritizeService wired to AIProvider, if any.
+func (d Dependencies) prioritizeService() *appprioritize.Service {
+    if d.AIProvider == nil || d.PrioritizeService == nil {
+        return d.PrioritizeService
+    }
+    return d.PrioritizeService.WithProvider(d.AIProvider)
+}
+
+func (d Dependencies) meterProvider() metric.MeterProvider {
+    if d.MeterProvider == nil {
+        return otel.GetMeterProvider()
+    }
+    return d.MeterProvider
+}
+
+func (d Dependencies) logger() *slog.Logger {
+    if d.Logger == nil {
+        return slog.Default()
+    }
+    return d.Logger
+}
@@ -0,0 +1,130 @@
+package http
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "net/http/httptest"
+    "strings"
+    "testing"
+    "time"
+
+    appaccount "backend/internal/application/account"
+    appcomment "backend/internal/application/comment"
+    appprioritize "backend/internal/application/prioritize"
+    appproject "backend/internal/application/project"
+    apptask "backend/internal/application/task"
+    apptenant "backend/internal/application/tenant"
+    domaintask "backend/internal/domain/task"
+    "backend/internal/infrastructure/auth"
+    "backend/internal/infrastructure/memory"
+    "backend/internal/pkg/config"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// constantProvider scores every task 42.
+type constantProvider struct{}
+
+func (constantProvider) ScoreTasks(_ context.Context, tasks []appprioritize.TaskSummary) ([]appprioritize.ScoredTask, error) {
+    out := make([]appprioritize.ScoredTask, 0, len(tasks))
+    for _, t := range tasks {
+        out = append(out, appprioritize.ScoredTask{TaskID: t.ID, Score: 42, Reason: "fake"})
+    }
+    return out, nil
+}
+
+// Test that an AIProvider set on Dependencies is used by the prioritize route.
+func TestDependencies_AIProvider(t *testing.T) {
+    tasks := memory.NewTaskRepository()
+    tk := domaintask.New("t1", "u1", "task", "", 5)
+    if err := tasks.Create(context.Background(), tk); err != nil {
+        t.Fatalf("seed: %v", err)
+    }
+    projects := memory.NewProjectRepository(tasks)
+    deps := NewDependencies(
+        auth.NewSimpleAuthService(),
+        apptask.NewService(tasks),
+        appcomment.NewService(memory.NewCommentRepository(tasks)),
+        appproject.NewService(projects),
+        appprioritize.NewService(),
+        apptenant.NewService(memory.NewTenantRepository(tasks, projects)),
+    )
+    deps.AIProvider = constantProvider{}
+    app := fiber.New(AppConfig(config.Config{}))
+    Build(app, deps)
+
+    req := httptest.NewRequest("POST", "/api/v1/prioritize/", bytes.NewReader([]byte(`{"taskIds":[]}`)))
+    req.Header.Set("Content-Type", "application/json")
+    req.Header.Set("Authorization", "Bearer token")
+    resp, err := app.Test(req, -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    var body struct {
+        Results []struct {
+            Score   float64  `json:"score"`
+            Reasons []string `json:"reasons"`
+        } `json:"results"`
+    }
+    if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+    if len(body.Results) != 1 || body.Results[0].Score != 42 || body.Results[0].Reasons[0] != "fake" {
+        t.Fatalf("expected the fake provider's score, got %+v", body.Results)
+    }
+}
+
+// Test that registration is reachable without a token and that the token it
+// returns authenticates API requests.
+func TestDependencies_Accounts(t *testing.T) {
+    tasks := memory.NewTaskRepository()
+    projects := memory.NewProjectRepository(tasks)
+    jwt := auth.NewJWTService([]byte(strings.Repeat("s", 32)))
+    deps := NewDependencies(
+        jwt,
+        apptask.NewService(tasks),
+        appcomment.NewService(memory.NewCommentRepository(tasks)),
+        appproject.NewService(projects),
+        appprioritize.NewService(),
+        apptenant.NewService(memory.NewTenantRepository(tasks, projects)),
+    )
+    deps.Accounts = appaccount.NewService(memory.NewAccountRepository(), jwt, time.Hour, 24*time.Hour)
+    app := fiber.New(AppConfig(config.Config{}))
+    Build(app, deps)
+
+    req := httptest.NewRequest("POST", "/api/v1/auth/register", bytes.NewReader([]byte(`{"email":"ada@example.com","password":"correct horse","tenantName":"Acme"}`)))
+    req.Header.Set("Content-Type", "application/json")
+    resp, err := app.Test(req, -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    if resp.StatusCode != fiber.StatusCreated {
+        t.Fatalf("expected status %d, got %d", fiber.StatusCreated, resp.StatusCode)
+    }
+    var session struct {
+        Token string `json:"token"`
+        User  struct {
+            ID string `json:"id"`
+        } `json:"user"`
+    }
+    if err := json.NewDecoder(resp.Body).Decode(&session); err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+
+    req = httptest.NewRequest("GET", "/api/v1/me/", nil)
+    req.Header.Set("Authorization", "Bearer "+session.Token)
+    resp, err = app.Test(req, -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    var me struct {
+        UserID string `json:"userId"`
+    }
+    if err := json.NewDecoder(resp.Body).Decode(&me); err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+    if resp.StatusCode != fiber.StatusOK || me.UserID != session.User.ID {
+        t.Fatalf("expected 200 for user %s, got %d for %q", session.User.ID, resp.StatusCode, me.UserID)
+    }
+}
@@ -0,0 +1,27 @@
+package http
+
+import (
+    "errors"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// errorResponse is the JSON envelope returned for every failed request.
+type errorResponse struct {
+    Error     string `json:"error"`
+    RequestID string `json:"requestId,omitempty"`
+}
+
+// ErrorHandler renders errors as a JSON envelope that carries the request ID,
+// giving users a token to quote when reporting a failure.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+    code := fiber.StatusInternalServerError
+    msg := fiber.ErrInternalServerError.Message
+    var fe *fiber.Error
+    if errors.As(err, &fe) {
+        code = fe.Code
+        msg = fe.Message
+    }
+    rid, _ := c.Locals("requestid").(string)
+    return c.Status(code).JSON(errorResponse{Error: msg, RequestID: rid})
+}
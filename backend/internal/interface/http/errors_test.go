@@ -0,0 +1,84 @@
+package http
+
+import (
+    "encoding/json"
+    "net/http/httptest"
+    "testing"
+
+    appcomment "backend/internal/application/comment"
+    appprioritize "backend/internal/application/prioritize"
+    appproject "backend/internal/application/project"
+    apptask "backend/internal/application/task"
+    apptenant "backend/internal/application/tenant"
+    "backend/internal/infrastructure/auth"
+    "backend/internal/infrastructure/memory"
+    "backend/internal/pkg/config"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+func newTestApp() *fiber.App {
+    tasks := memory.NewTaskRepository()
+    projects := memory.NewProjectRepository(tasks)
+    deps := NewDependencies(
+        auth.NewSimpleAuthService(),
+        apptask.NewService(tasks),
+        appcomment.NewService(memory.NewCommentRepository(tasks)),
+        appproject.NewService(projects),
+        appprioritize.NewService(),
+        apptenant.NewService(memory.NewTenantRepository(tasks, projects)),
+    )
+    app := fiber.New(AppConfig(config.Config{}))
+    Build(app, deps)
+    return app
+}
+
+// Test that a failing request's error envelope carries the same request ID
+// as the X-Request-Id response header.
+func TestErrorHandler_IncludesRequestID(t *testing.T) {
+    app := newTestApp()
+
+    req := httptest.NewRequest("GET", "/api/v1/tasks/00000000-0000-0000-0000-000000000000", nil)
+    req.Header.Set("Authorization", "Bearer token")
+    resp, err := app.Test(req, -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    if resp.StatusCode != fiber.StatusNotFound {
+        t.Fatalf("expected status %d, got %d", fiber.StatusNotFound, resp.StatusCode)
+    }
+
+    var body errorResponse
+    if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+        t.Fatalf("decode body: %v", err)
+    }
+    header := resp.Header.Get(fiber.HeaderXRequestID)
+    if header == "" {
+        t.Fatalf("missing %s header", fiber.HeaderXRequestID)
+    }
+    if body.RequestID != header {
+        t.Fatalf("expected requestId %q, got %q", header, body.RequestID)
+    }
+    if body.Error == "" {
+        t.Fatalf("expected error message in body")
+    }
+}
+
+// Test that security headers survive the JSON error envelope.
+func TestErrorHandler_KeepsSecurityHeaders(t *testing.T) {
+    app := newTestApp()
+
+    resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/tasks/", nil), -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    if resp.StatusCode != fiber.StatusUnauthorized {
+        t.Fatalf("expected status %d, got %d", fiber.StatusUnauthorized, resp.StatusCode)
+    }
+    if got := resp.Header.Get(fiber.HeaderXFrameOptions); got != "DENY" {
+        t.Fatalf("expected X-Frame-Options DENY, got %q", got)
+    }
+    if got := resp.Header.Get(fiber.HeaderContentSecurityPolicy); got == "" {
+        t.Fatalf("expected a Content-Security-Policy header")
+    }
+}
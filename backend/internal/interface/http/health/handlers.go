@@ -0,0 +1,24 @@
+package health
+
+import (
+    apphealth "backend/internal/application/health"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// RegisterRoutes wires the health report to a router mounted at /health,
+// outside authentication.
+func RegisterRoutes(r fiber.Router, svc *apphealth.Service) {
+    r.Get("/", func(c *fiber.Ctx) error { return get(c, svc) })
+}
+
+// get reports every subsystem, answering 503 when any of them is down so
+// load balancers and orchestrators can act on the status code alone.
+func get(c *fiber.Ctx, svc *apphealth.Service) error {
+    rep := svc.Check(c.UserContext())
+    status := fiber.StatusOK
+    if rep.Status != apphealth.StatusUp {
+        status = fiber.StatusServiceUnavailable
+    }
+    return c.Status(status).JSON(rep)
+}
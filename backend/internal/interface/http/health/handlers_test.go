@@ -0,0 +1,55 @@
+package health
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "net/http/httptest"
+    "testing"
+    "time"
+
+    apphealth "backend/internal/application/health"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// Test that GET /health answers 200 while every subsystem is up and 503,
+// with the failing subsystem marked down, once one is not.
+func TestHandlers_Health(t *testing.T) {
+    var prioritizeErr error
+    svc := apphealth.NewService(time.Second)
+    svc.Register("db", apphealth.CheckerFunc(func(context.Context) error { return nil }))
+    svc.Register("prioritize", apphealth.CheckerFunc(func(context.Context) error { return prioritizeErr }))
+    app := fiber.New()
+    RegisterRoutes(app.Group("/health"), svc)
+    get := func() (int, apphealth.Report) {
+        resp, err := app.Test(httptest.NewRequest("GET", "/health", nil), -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        var rep apphealth.Report
+        if err := json.NewDecoder(resp.Body).Decode(&rep); err != nil {
+            t.Fatalf("decode: %v", err)
+        }
+        return resp.StatusCode, rep
+    }
+
+    if code, rep := get(); code != fiber.StatusOK || rep.Status != apphealth.StatusUp {
+        t.Fatalf("expected 200 up, got %d %+v", code, rep)
+    }
+
+    prioritizeErr = errors.New("status 502")
+    code, rep := get()
+    if code != fiber.StatusServiceUnavailable {
+        t.Fatalf("expected status %d, got %d", fiber.StatusServiceUnavailable, code)
+    }
+    if rep.Status != apphealth.StatusDegraded {
+        t.Fatalf("expected status %q, got %q", apphealth.StatusDegraded, rep.Status)
+    }
+    if got := rep.Checks["prioritize"]; got.Status != apphealth.StatusDown {
+        t.Fatalf("expected prioritize down, got %+v", got)
+    }
+    if got := rep.Checks["db"]; got.Status != apphealth.StatusUp {
+        t.Fatalf("expected db up, got %+v", got)
+    }
+}
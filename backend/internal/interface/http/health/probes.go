@@ -0,0 +1,32 @@
+package health
+
+import (
+    apphealth "backend/internal/application/health"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// RegisterHealthRoutes adds the probes orchestrators poll: GET /healthz
+// answers while the process runs, GET /readyz only while db and, when not
+// nil, cache answer, so traffic is routed to the instance only then.
+func RegisterHealthRoutes(app fiber.Router, db, cache apphealth.ReadinessChecker) {
+    ready := apphealth.NewService(0)
+    if db != nil {
+        ready.Register("db", db)
+    }
+    if cache != nil {
+        ready.Register("cache", cache)
+    }
+    app.Get("/healthz", func(c *fiber.Ctx) error { return c.SendString("ok") })
+    app.Get("/readyz", func(c *fiber.Ctx) error { return readyz(c, ready) })
+}
+
+// readyz answers 503 with the failing dependencies while any is down.
+func readyz(c *fiber.Ctx, svc *apphealth.Service) error {
+    r := svc.Ready(c.UserContext())
+    status := fiber.StatusOK
+    if !r.Ready {
+        status = fiber.StatusServiceUnavailable
+    }
+    return c.Status(status).JSON(r)
+}
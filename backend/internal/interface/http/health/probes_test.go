@@ -0,0 +1,70 @@
+package health
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "net/http/httptest"
+    "testing"
+
+    apphealth "backend/internal/application/health"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// Test that GET /readyz answers 200 while the database and cache answer,
+// and 503 naming whichever of them does not.
+func TestRegisterHealthRoutes_Readyz(t *testing.T) {
+    up := apphealth.CheckerFunc(func(context.Context) error { return nil })
+    down := apphealth.CheckerFunc(func(context.Context) error { return errors.New("connection refused") })
+    for _, tc := range []struct {
+        name      string
+        db, cache apphealth.ReadinessChecker
+        want      int
+        checks    map[string]string
+    }{
+        {"all up", up, up, fiber.StatusOK, map[string]string{"db": "ok", "cache": "ok"}},
+        {"no cache", up, nil, fiber.StatusOK, map[string]string{"db": "ok"}},
+        {"db down", down, up, fiber.StatusServiceUnavailable, map[string]string{"db": "connection refused", "cache": "ok"}},
+        {"cache down", up, down, fiber.StatusServiceUnavailable, map[string]string{"db": "ok", "cache": "connection refused"}},
+    } {
+        app := fiber.New()
+        RegisterHealthRoutes(app, tc.db, tc.cache)
+        resp, err := app.Test(httptest.NewRequest("GET", "/readyz", nil), -1)
+        if err != nil {
+            t.Fatalf("%s: app.Test: %v", tc.name, err)
+        }
+        if resp.StatusCode != tc.want {
+            t.Fatalf("%s: expected status %d, got %d", tc.name, tc.want, resp.StatusCode)
+        }
+        var r apphealth.Readiness
+        if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+            t.Fatalf("%s: decode: %v", tc.name, err)
+        }
+        if r.Ready != (tc.want == fiber.StatusOK) {
+            t.Fatalf("%s: expected ready %v, got %v", tc.name, tc.want == fiber.StatusOK, r.Ready)
+        }
+        if len(r.Checks) != len(tc.checks) {
+            t.Fatalf("%s: expected checks %v, got %v", tc.name, tc.checks, r.Checks)
+        }
+        for name, want := range tc.checks {
+            if r.Checks[name] != want {
+                t.Fatalf("%s: expected %s %q, got %q", tc.name, name, want, r.Checks[name])
+            }
+        }
+    }
+}
+
+// Test that GET /healthz answers without checking any dependency.
+func TestRegisterHealthRoutes_Healthz(t *testing.T) {
+    down := apphealth.CheckerFunc(func(context.Context) error { return errors.New("connection refused") })
+    app := fiber.New()
+    RegisterHealthRoutes(app, down, down)
+    resp, err := app.Test(httptest.NewRequest("GET", "/healthz", nil), -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    if resp.StatusCode != fiber.StatusOK {
+        t.Fatalf("expected status %d, got %d", fiber.StatusOK, resp.StatusCode)
+    }
+}
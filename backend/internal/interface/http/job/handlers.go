@@ -0,0 +1,35 @@
+package job
+
+import (
+    "errors"
+
+    appjob "backend/internal/application/job"
+    "backend/internal/interface/http/middleware"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+type Handlers struct {
+    store appjob.Store
+}
+
+func NewHandlers(store appjob.Store) *Handlers {
+    return &Handlers{store: store}
+}
+
+// get returns the status of job :id. Jobs of other tenants are reported as
+// not found, and so are jobs in no tenant and every job to callers in no
+// tenant, so an empty tenant never matches another.
+func (h *Handlers) get(c *fiber.Ctx) error {
+    j, err := h.store.Get(c.UserContext(), c.Params("id"))
+    if errors.Is(err, appjob.ErrNotFound) {
+        return fiber.ErrNotFound
+    }
+    if err != nil {
+        return fiber.ErrInternalServerError
+    }
+    if tenantID := middleware.ClaimsOf(c).TenantID; tenantID == "" || j.TenantID != tenantID {
+        return fiber.ErrNotFound
+    }
+    return c.JSON(j)
+}
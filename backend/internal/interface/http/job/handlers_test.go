@@ -0,0 +1,95 @@
+package job
+
+import (
+    "context"
+    "encoding/json"
+    "net/http/httptest"
+    "testing"
+
+    appjob "backend/internal/application/job"
+    "backend/internal/interface/http/middleware"
+    "backend/internal/pkg/identity"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// jobStore is a Store over a fixed set of jobs.
+type jobStore map[string]appjob.Job
+
+func (s jobStore) Get(_ context.Context, id string) (*appjob.Job, error) {
+    j, ok := s[id]
+    if !ok {
+        return nil, appjob.ErrNotFound
+    }
+    return &j, nil
+}
+
+// Test that a tenant can poll its own jobs while other tenants' jobs,
+// unknown ids and malformed ids are refused.
+func TestHandlers_Get(t *testing.T) {
+    const (
+        own     = "11111111-1111-1111-1111-111111111111"
+        foreign = "22222222-2222-2222-2222-222222222222"
+        absent  = "00000000-0000-0000-0000-000000000000"
+    )
+    store := jobStore{
+        own:     {ID: own, Type: "export", TenantID: "t1", Status: appjob.StatusProcessing, Processed: 5, Total: 10},
+        foreign: {ID: foreign, Type: "export", TenantID: "t2", Status: appjob.StatusDone},
+    }
+    app := fiber.New()
+    app.Use(func(c *fiber.Ctx) error {
+        middleware.SetClaims(c, identity.Claims{TenantID: "t1", UserID: "u1"})
+        return c.Next()
+    })
+    RegisterRoutes(app.Group("/jobs"), store)
+
+    for _, tc := range []struct {
+        id     string
+        status int
+    }{
+        {own, fiber.StatusOK},
+        {foreign, fiber.StatusNotFound},
+        {absent, fiber.StatusNotFound},
+        {"not-a-uuid", fiber.StatusBadRequest},
+    } {
+        resp, err := app.Test(httptest.NewRequest("GET", "/jobs/"+tc.id, nil), -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        if resp.StatusCode != tc.status {
+            t.Fatalf("%s: expected %d, got %d", tc.id, tc.status, resp.StatusCode)
+        }
+        if tc.status != fiber.StatusOK {
+            continue
+        }
+        var got appjob.Job
+        if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+            t.Fatalf("decode: %v", err)
+        }
+        if got.Status != appjob.StatusProcessing || got.Processed != 5 || got.Total != 10 {
+            t.Fatalf("expected processing 5/10, got %+v", got)
+        }
+    }
+}
+
+// Test that a job in no tenant is not found, whether or not the caller has a
+// tenant.
+func TestHandlers_Get_NoTenant(t *testing.T) {
+    const id = "33333333-3333-3333-3333-333333333333"
+    store := jobStore{id: {ID: id, Type: "export", Status: appjob.StatusDone}}
+    for _, tenantID := range []string{"t1", ""} {
+        app := fiber.New()
+        app.Use(func(c *fiber.Ctx) error {
+            middleware.SetClaims(c, identity.Claims{TenantID: tenantID, UserID: "u1"})
+            return c.Next()
+        })
+        RegisterRoutes(app.Group("/jobs"), store)
+        resp, err := app.Test(httptest.NewRequest("GET", "/jobs/"+id, nil), -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        if resp.StatusCode != fiber.StatusNotFound {
+            t.Fatalf("caller tenant %q: expected %d, got %d", tenantID, fiber.StatusNotFound, resp.StatusCode)
+        }
+    }
+}
@@ -0,0 +1,18 @@
+package job
+
+import (
+    appjob "backend/internal/application/job"
+    "backend/internal/interface/http/middleware"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// RegisterRoutes wires the job status endpoint to a router mounted at /jobs.
+func RegisterRoutes(r fiber.Router, store appjob.Store) {
+    NewHandlers(store).Register(r)
+}
+
+// Register wires h's routes to the provided router.
+func (h *Handlers) Register(r fiber.Router) {
+    r.Get("/:id", middleware.RequireUUIDParams("id"), h.get)
+}
@@ -0,0 +1,25 @@
+package me
+
+import (
+    "backend/internal/interface/http/middleware"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// RegisterRoutes wires the caller's identity endpoint to a router mounted at
+// /me behind the auth middleware.
+func RegisterRoutes(r fiber.Router) {
+    r.Get("/", get)
+}
+
+// get returns the claims the auth middleware verified for the caller.
+func get(c *fiber.Ctx) error {
+    claims := middleware.ClaimsOf(c)
+    if claims.UserID == "" {
+        return fiber.ErrUnauthorized
+    }
+    if claims.Roles == nil {
+        claims.Roles = []string{}
+    }
+    return c.JSON(claims)
+}
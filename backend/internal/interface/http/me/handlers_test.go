@@ -0,0 +1,69 @@
+package me
+
+import (
+    "encoding/json"
+    "errors"
+    "net/http/httptest"
+    "testing"
+
+    "backend/internal/interface/http/middleware"
+    "backend/internal/pkg/identity"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// tokenAuth maps tokens to the claims they carry.
+type tokenAuth map[string]identity.Claims
+
+func (a tokenAuth) VerifyToken(token string) (identity.Claims, error) {
+    claims, ok := a[token]
+    if !ok {
+        return identity.Claims{}, errors.New("invalid token")
+    }
+    return claims, nil
+}
+
+// Test that /me returns the identity carried by the caller's token and that
+// requests without a valid token get 401.
+func TestMe(t *testing.T) {
+    app := fiber.New()
+    api := app.Group("/api/v1", middleware.AuthMiddleware(tokenAuth{
+        "alice": {UserID: "alice", TenantID: "t1", Roles: []string{identity.RoleMember}},
+        "bob":   {UserID: "bob", TenantID: "t2", Roles: []string{identity.RoleMember, "admin"}},
+    }))
+    RegisterRoutes(api.Group("/me"))
+
+    cases := []struct {
+        token  string
+        status int
+        want   identity.Claims
+    }{
+        {"Bearer alice", fiber.StatusOK, identity.Claims{UserID: "alice", TenantID: "t1", Roles: []string{"member"}}},
+        {"Bearer bob", fiber.StatusOK, identity.Claims{UserID: "bob", TenantID: "t2", Roles: []string{"member", "admin"}}},
+        {"Bearer mallory", fiber.StatusUnauthorized, identity.Claims{}},
+        {"", fiber.StatusUnauthorized, identity.Claims{}},
+    }
+    for _, tc := range cases {
+        req := httptest.NewRequest("GET", "/api/v1/me", nil)
+        if tc.token != "" {
+            req.Header.Set("Authorization", tc.token)
+        }
+        resp, err := app.Test(req, -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        if resp.StatusCode != tc.status {
+            t.Fatalf("%q: expected status %d, got %d", tc.token, tc.status, resp.StatusCode)
+        }
+        if tc.status != fiber.StatusOK {
+            continue
+        }
+        var got identity.Claims
+        if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+            t.Fatalf("decode: %v", err)
+        }
+        if got.UserID != tc.want.UserID || got.TenantID != tc.want.TenantID || len(got.Roles) != len(tc.want.Roles) || got.Roles[0] != tc.want.Roles[0] {
+            t.Fatalf("%q: expected %+v, got %+v", tc.token, tc.want, got)
+        }
+    }
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequireAdmin allows the request through only when the authenticated user is
+// one of adminUserIDs, or holds one of roles, such as the system role of
+// internal services calling maintenance endpoints. It must run after
+// AuthMiddleware.
+func RequireAdmin(adminUserIDs []string, roles ...string) fiber.Handler {
+	admins := make(map[string]bool, len(adminUserIDs))
+	for _, id := range adminUserIDs {
+		admins[id] = true
+	}
+	return func(c *fiber.Ctx) error {
+		claims := ClaimsOf(c)
+		if claims.UserID != "" && admins[claims.UserID] {
+			return c.Next()
+		}
+		if slices.ContainsFunc(roles, claims.HasRole) {
+			return c.Next()
+		}
+		return fiber.ErrForbidden
+	}
+}
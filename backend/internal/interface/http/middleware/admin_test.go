@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"backend/internal/pkg/identity"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Test that only listed users pass the admin guard.
+func TestRequireAdmin(t *testing.T) {
+	cases := map[string]int{
+		"root": fiber.StatusOK,
+		"u1":   fiber.StatusForbidden,
+		"":     fiber.StatusForbidden,
+	}
+	for user, want := range cases {
+		app := fiber.New()
+		app.Use(func(c *fiber.Ctx) error {
+			SetClaims(c, identity.Claims{UserID: user})
+			return c.Next()
+		})
+		app.Use(RequireAdmin([]string{"root"}))
+		app.Get("/", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/", nil), -1)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		if resp.StatusCode != want {
+			t.Fatalf("user %q: expected status %d, got %d", user, want, resp.StatusCode)
+		}
+	}
+}
+
+// Test that callers holding one of the listed roles pass the admin guard
+// without being listed, and that other roles do not.
+func TestRequireAdmin_Roles(t *testing.T) {
+	cases := map[string]int{
+		identity.RoleSystem:  fiber.StatusOK,
+		identity.RoleService: fiber.StatusForbidden,
+		identity.RoleMember:  fiber.StatusForbidden,
+	}
+	for role, want := range cases {
+		app := fiber.New()
+		app.Use(func(c *fiber.Ctx) error {
+			SetClaims(c, identity.Claims{UserID: "worker", Roles: []string{role}})
+			return c.Next()
+		})
+		app.Use(RequireAdmin([]string{"root"}, identity.RoleSystem))
+		app.Get("/", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/", nil), -1)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		if resp.StatusCode != want {
+			t.Fatalf("role %q: expected status %d, got %d", role, want, resp.StatusCode)
+		}
+	}
+}
@@ -1,30 +1,162 @@
 package middleware
 
-import "github.com/gofiber/fiber/v2"
+import (
+	"errors"
+	"strings"
 
+	"backend/internal/pkg/ctxkeys"
+	"backend/internal/pkg/identity"
+
+	"github.com/gofiber/fiber/v2"
+)
 
 // AuthService defines the behaviour required by the authentication middleware.
-// VerifyToken should validate the provided token and return identifiers for the
-// authenticated user and tenant. An error should be returned if the token is
-// invalid or cannot be verified.
+// VerifyToken should validate the provided token and return the claims of the
+// authenticated user. An error should be returned if the token is invalid or
+// cannot be verified.
 type AuthService interface {
-	VerifyToken(token string) (userID string, tenantID string, err error)
+	VerifyToken(token string) (identity.Claims, error)
+}
+
+// AuthOption configures AuthMiddleware and AuthMiddlewareWithAPIKeys.
+type AuthOption func(*authOptions)
+
+type authOptions struct {
+	allowRawTokens bool
+	skip           []func(*fiber.Ctx) bool
+}
+
+// AllowRawTokens also accepts an Authorization header holding only a token,
+// without the Bearer scheme. It is meant for local development.
+func AllowRawTokens(allow bool) AuthOption {
+	return func(o *authOptions) { o.allowRawTokens = allow }
+}
+
+// SkipPaths lets requests under any of prefixes through unauthenticated. A
+// prefix matches its own path and the paths below it, so "/api/v1/auth"
+// covers "/api/v1/auth/login" but not "/api/v1/authors".
+func SkipPaths(prefixes ...string) AuthOption {
+	return Skip(func(c *fiber.Ctx) bool {
+		path := c.Path()
+		for _, p := range prefixes {
+			p = strings.TrimRight(p, "/")
+			if path == p || strings.HasPrefix(path, p+"/") {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Skip lets requests for which skip returns true through unauthenticated.
+// Their handlers see zero claims.
+func Skip(skip func(c *fiber.Ctx) bool) AuthOption {
+	return func(o *authOptions) { o.skip = append(o.skip, skip) }
+}
+
+// skipped reports whether c may go through without authentication.
+func (o authOptions) skipped(c *fiber.Ctx) bool {
+	for _, skip := range o.skip {
+		if skip(c) {
+			return true
+		}
+	}
+	return false
+}
+
+func newAuthOptions(opts []AuthOption) authOptions {
+	var o authOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
 }
 
 // AuthMiddleware creates a Fiber middleware that validates the incoming
-// request's Authorization header. When the token is valid the user and tenant
-// identifiers are stored in the request context so that subsequent handlers can
-// access them. If verification fails an Unauthorized error is returned.
-func AuthMiddleware(authSvc AuthService) fiber.Handler {
+// request's Authorization header, which must be "Bearer <token>" or
+// "ApiKey <key>" with the scheme in any case. Missing and malformed headers get 401 before the
+// service is asked; the token alone is passed to VerifyToken. When the token
+// is valid its claims are stored in the request context, where handlers read
+// them with ClaimsOf. The request ID, when present, becomes the correlation
+// ID on the request's user context so it reaches services and repositories.
+// Every 401 carries a WWW-Authenticate: Bearer challenge. SkipPaths and Skip
+// exempt public routes.
+func AuthMiddleware(authSvc AuthService, opts ...AuthOption) fiber.Handler {
+	o := newAuthOptions(opts)
 	return func(c *fiber.Ctx) error {
-		token := c.Get("Authorization")
-		user, tenant, err := authSvc.VerifyToken(token)
+		if o.skipped(c) {
+			return c.Next()
+		}
+		return authenticateBearer(c, authSvc, o)
+	}
+}
 
-		if err != nil {
-			return fiber.ErrUnauthorized
+// AuthMiddlewareWithAPIKeys behaves like AuthMiddleware but also accepts an
+// X-API-Key header, verified by apiKeys. A request carrying X-API-Key is
+// authenticated by the key alone, even when an Authorization header is also
+// present.
+func AuthMiddlewareWithAPIKeys(authSvc, apiKeys AuthService, opts ...AuthOption) fiber.Handler {
+	o := newAuthOptions(opts)
+	return func(c *fiber.Ctx) error {
+		if o.skipped(c) {
+			return c.Next()
+		}
+		if key := c.Get("X-API-Key"); key != "" {
+			return authenticate(c, apiKeys, key)
 		}
-		c.Locals("user", user)
-		c.Locals("tenant", tenant)
-		return c.Next()
+		return authenticateBearer(c, authSvc, o)
+	}
+}
+
+// bearerChallenge is the WWW-Authenticate value sent with every 401.
+const bearerChallenge = "Bearer"
+
+// errTokenExpired is answered for an expired token, so the body reads
+// {"error": "expired_token"}.
+var errTokenExpired = fiber.NewError(fiber.StatusUnauthorized, "expired_token")
+
+func authenticateBearer(c *fiber.Ctx, svc AuthService, o authOptions) error {
+	token, ok := bearerToken(c.Get(fiber.HeaderAuthorization), o.allowRawTokens)
+	if !ok {
+		c.Set(fiber.HeaderWWWAuthenticate, bearerChallenge)
+		return fiber.ErrUnauthorized
+	}
+	return authenticate(c, svc, token)
+}
+
+// bearerToken extracts the token from an Authorization header of the form
+// "Bearer <token>" or "ApiKey <key>": the scheme in any case, one space, and
+// a token without spaces. With allowRaw a header that is only a token is
+// accepted as well.
+func bearerToken(header string, allowRaw bool) (string, bool) {
+	scheme, token, found := strings.Cut(header, " ")
+	if !found {
+		return header, allowRaw && header != "" && !isAuthScheme(header)
+	}
+	if !isAuthScheme(scheme) || token == "" || strings.ContainsAny(token, " \t") {
+		return "", false
+	}
+	return token, true
+}
+
+func isAuthScheme(s string) bool {
+	return strings.EqualFold(s, "bearer") || strings.EqualFold(s, "apikey")
+}
+
+func authenticate(c *fiber.Ctx, svc AuthService, token string) error {
+	claims, err := svc.VerifyToken(token)
+	if errors.Is(err, identity.ErrTokenExpired) {
+		// A distinct error lets clients refresh rather than sign out
+		c.Set(fiber.HeaderWWWAuthenticate, bearerChallenge+` error="invalid_token", error_description="token expired"`)
+		return errTokenExpired
+	}
+	if err != nil {
+		c.Set(fiber.HeaderWWWAuthenticate, bearerChallenge+` error="invalid_token"`)
+		return fiber.ErrUnauthorized
+	}
+	SetClaims(c, claims)
+	if rid, ok := c.Locals("requestid").(string); ok && rid != "" {
+		c.SetUserContext(ctxkeys.WithCorrelationID(c.UserContext(), rid))
 	}
+	return c.Next()
 }
@@ -2,9 +2,14 @@ package middleware
 
 import (
 	"errors"
+	"fmt"
+	"io"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"backend/internal/pkg/identity"
+
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -14,25 +19,29 @@ type mockAuthService struct {
 	err    error
 }
 
-func (m mockAuthService) VerifyToken(token string) (string, string, error) {
-	return m.user, m.tenant, m.err
+func (m mockAuthService) VerifyToken(token string) (identity.Claims, error) {
+	if m.err != nil {
+		return identity.Claims{}, m.err
+	}
+	return identity.Claims{UserID: m.user, TenantID: m.tenant, Roles: []string{identity.RoleMember}}, nil
 }
 
 // Test that the middleware allows requests with a valid token and stores
-// the returned identifiers in the context.
+// the returned claims in the context.
 func TestAuthMiddleware_Success(t *testing.T) {
 	svc := mockAuthService{user: "u1", tenant: "t1"}
 	app := fiber.New()
 	app.Use(AuthMiddleware(svc))
 	app.Get("/", func(c *fiber.Ctx) error {
-		if c.Locals("user") != "u1" || c.Locals("tenant") != "t1" {
-			t.Fatalf("locals not set")
+		claims := ClaimsOf(c)
+		if claims.UserID != "u1" || claims.TenantID != "t1" || !claims.HasRole(identity.RoleMember) {
+			t.Fatalf("claims not set: %+v", claims)
 		}
 		return c.SendStatus(fiber.StatusOK)
 	})
 
 	req := httptest.NewRequest("GET", "/", nil)
-	req.Header.Set("Authorization", "token")
+	req.Header.Set("Authorization", "Bearer token")
 	resp, err := app.Test(req, -1)
 	if err != nil {
 		t.Fatalf("app.Test: %v", err)
@@ -42,6 +51,55 @@ func TestAuthMiddleware_Success(t *testing.T) {
 	}
 }
 
+// Test that FromCtx refuses requests that carry no claims, or claims
+// without a user or tenant, instead of handing out empty identifiers.
+func TestFromCtx(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		claims *identity.Claims
+		err    error
+	}{
+		{"complete", &identity.Claims{UserID: "u1", TenantID: "t1"}, nil},
+		{"none stored", nil, ErrNoPrincipal},
+		{"no tenant", &identity.Claims{UserID: identity.SystemUserID, Roles: []string{identity.RoleSystem}}, ErrIncompletePrincipal},
+		{"no user", &identity.Claims{TenantID: "t1"}, ErrIncompletePrincipal},
+	} {
+		app := fiber.New()
+		app.Get("/", func(c *fiber.Ctx) error {
+			if tc.claims != nil {
+				SetClaims(c, *tc.claims)
+			}
+			p, err := FromCtx(c)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("%s: expected %v, got %v", tc.name, tc.err, err)
+			}
+			if err == nil && (p.TenantID != "t1" || p.UserID != "u1") {
+				t.Fatalf("%s: expected tenant t1 and user u1, got %+v", tc.name, p)
+			}
+			return nil
+		})
+		if _, err := app.Test(httptest.NewRequest("GET", "/", nil), -1); err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+	}
+}
+
+// Test that FromCtx only reads the typed key AuthMiddleware writes, not a
+// string-keyed local another handler happened to set.
+func TestFromCtx_IgnoresStringKeys(t *testing.T) {
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		c.Locals("claims", identity.Claims{UserID: "u1", TenantID: "t1"})
+		if _, err := FromCtx(c); !errors.Is(err, ErrNoPrincipal) {
+			t.Fatalf("expected %v, got %v", ErrNoPrincipal, err)
+		}
+		return nil
+	})
+	if _, err := app.Test(httptest.NewRequest("GET", "/", nil), -1); err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+}
+
 // Test that the middleware blocks requests when token verification fails.
 func TestAuthMiddleware_Unauthorized(t *testing.T) {
 	svc := mockAuthService{err: errors.New("invalid token")}
@@ -52,7 +110,7 @@ func TestAuthMiddleware_Unauthorized(t *testing.T) {
 	})
 
 	req := httptest.NewRequest("GET", "/", nil)
-	req.Header.Set("Authorization", "bad")
+	req.Header.Set("Authorization", "Bearer bad")
 	resp, err := app.Test(req, -1)
 	if err != nil {
 		t.Fatalf("app.Test: %v", err)
@@ -60,4 +118,198 @@ func TestAuthMiddleware_Unauthorized(t *testing.T) {
 	if resp.StatusCode != fiber.StatusUnauthorized {
 		t.Fatalf("expected status %d, got %d", fiber.StatusUnauthorized, resp.StatusCode)
 	}
+	if got := resp.Header.Get("WWW-Authenticate"); got != `Bearer error="invalid_token"` {
+		t.Fatalf("expected an invalid_token challenge, got %q", got)
+	}
+}
+
+// Test that an expired token is answered with expired_token, so clients
+// can refresh it instead of signing out.
+func TestAuthMiddleware_Expired(t *testing.T) {
+	svc := mockAuthService{err: fmt.Errorf("verify: %w", identity.ErrTokenExpired)}
+	app := fiber.New()
+	app.Use(AuthMiddleware(svc))
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Authorization", "Bearer old")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusUnauthorized {
+		t.Fatalf("expected status %d, got %d", fiber.StatusUnauthorized, resp.StatusCode)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "expired_token" {
+		t.Fatalf("expected expired_token, got %q", body)
+	}
+	if got := resp.Header.Get("WWW-Authenticate"); !strings.Contains(got, `error_description="token expired"`) {
+		t.Fatalf("expected an expired token challenge, got %q", got)
+	}
+}
+
+// tokenEcho accepts any token as the user of that name, so tests can see
+// what reached the service.
+type tokenEcho struct{}
+
+func (tokenEcho) VerifyToken(token string) (identity.Claims, error) {
+	return identity.Claims{UserID: token, TenantID: "t1"}, nil
+}
+
+// Test that only "Bearer <token>" and "ApiKey <key>" headers reach the
+// service, with the token alone, that raw tokens pass only when allowed, and
+// that every refused header gets 401 with a Bearer challenge.
+func TestAuthMiddleware_HeaderShapes(t *testing.T) {
+	cases := []struct {
+		name     string
+		header   string
+		allowRaw bool
+		status   int
+		user     string
+	}{
+		{"bearer", "Bearer abc.def", false, fiber.StatusOK, "abc.def"},
+		{"lower-case scheme", "bearer abc", false, fiber.StatusOK, "abc"},
+		{"upper-case scheme", "BEARER abc", false, fiber.StatusOK, "abc"},
+		{"api key", "ApiKey mf_abc", false, fiber.StatusOK, "mf_abc"},
+		{"lower-case api key", "apikey mf_abc", false, fiber.StatusOK, "mf_abc"},
+		{"api key scheme only", "ApiKey", true, fiber.StatusUnauthorized, ""},
+		{"missing", "", false, fiber.StatusUnauthorized, ""},
+		{"scheme only", "Bearer", false, fiber.StatusUnauthorized, ""},
+		{"scheme and space", "Bearer ", false, fiber.StatusUnauthorized, ""},
+		{"two spaces", "Bearer  abc", false, fiber.StatusUnauthorized, ""},
+		{"extra part", "Bearer abc def", false, fiber.StatusUnauthorized, ""},
+		{"tab", "Bearer\tabc", false, fiber.StatusUnauthorized, ""},
+		{"other scheme", "Basic dTE6cHc=", false, fiber.StatusUnauthorized, ""},
+		{"raw token", "abc", false, fiber.StatusUnauthorized, ""},
+		{"raw token allowed", "abc", true, fiber.StatusOK, "abc"},
+		{"bearer with raw allowed", "Bearer abc", true, fiber.StatusOK, "abc"},
+		{"scheme only with raw allowed", "Bearer", true, fiber.StatusUnauthorized, ""},
+		{"other scheme with raw allowed", "Basic dTE6cHc=", true, fiber.StatusUnauthorized, ""},
+	}
+	for _, tc := range cases {
+		app := fiber.New()
+		app.Use(AuthMiddleware(tokenEcho{}, AllowRawTokens(tc.allowRaw)))
+		app.Get("/", func(c *fiber.Ctx) error { return c.SendString(ClaimsOf(c).UserID) })
+
+		req := httptest.NewRequest("GET", "/", nil)
+		if tc.header != "" {
+			req.Header.Set("Authorization", tc.header)
+		}
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("%s: app.Test: %v", tc.name, err)
+		}
+		if resp.StatusCode != tc.status {
+			t.Fatalf("%s: expected status %d, got %d", tc.name, tc.status, resp.StatusCode)
+		}
+		if tc.status == fiber.StatusUnauthorized {
+			if got := resp.Header.Get("WWW-Authenticate"); got != "Bearer" {
+				t.Fatalf("%s: expected a Bearer challenge, got %q", tc.name, got)
+			}
+			continue
+		}
+		if body, _ := io.ReadAll(resp.Body); string(body) != tc.user {
+			t.Fatalf("%s: expected the service to get %q, got %q", tc.name, tc.user, body)
+		}
+	}
+}
+
+type mockKeyService map[string]mockAuthService
+
+func (m mockKeyService) VerifyToken(token string) (identity.Claims, error) {
+	k, ok := m[token]
+	if !ok {
+		return identity.Claims{}, errors.New("unknown key")
+	}
+	return k.VerifyToken(token)
+}
+
+// Test that an X-API-Key header is verified by the key service alone: a valid
+// key authenticates as its service user while revoked and unknown keys get 401
+// even alongside a valid Authorization header.
+func TestAuthMiddlewareWithAPIKeys(t *testing.T) {
+	keys := mockKeyService{
+		"valid":   {user: "apikey:k1", tenant: "t2"},
+		"revoked": {err: errors.New("api key revoked")},
+	}
+	app := fiber.New()
+	app.Use(AuthMiddlewareWithAPIKeys(mockAuthService{user: "u1", tenant: "t1"}, keys))
+	app.Get("/", func(c *fiber.Ctx) error {
+		claims := ClaimsOf(c)
+		return c.SendString(claims.UserID + "@" + claims.TenantID)
+	})
+
+	cases := []struct {
+		name, auth, key string
+		status          int
+		body            string
+	}{
+		{name: "valid key", key: "valid", status: fiber.StatusOK, body: "apikey:k1@t2"},
+		{name: "bearer token", auth: "Bearer jwt", status: fiber.StatusOK, body: "u1@t1"},
+		{name: "revoked key", auth: "Bearer jwt", key: "revoked", status: fiber.StatusUnauthorized},
+		{name: "unknown key", auth: "Bearer jwt", key: "nope", status: fiber.StatusUnauthorized},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest("GET", "/", nil)
+		if tc.auth != "" {
+			req.Header.Set("Authorization", tc.auth)
+		}
+		if tc.key != "" {
+			req.Header.Set("X-API-Key", tc.key)
+		}
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("%s: app.Test: %v", tc.name, err)
+		}
+		if resp.StatusCode != tc.status {
+			t.Fatalf("%s: expected status %d, got %d", tc.name, tc.status, resp.StatusCode)
+		}
+		if tc.body != "" {
+			body, _ := io.ReadAll(resp.Body)
+			if string(body) != tc.body {
+				t.Fatalf("%s: expected %q, got %q", tc.name, tc.body, body)
+			}
+		}
+	}
+}
+
+// Test that SkipPaths and Skip let matching requests through without a token
+// while every other path still needs one, and that a prefix only matches
+// whole path segments.
+func TestAuthMiddleware_Skip(t *testing.T) {
+	svc := mockAuthService{err: errors.New("invalid token")}
+	app := fiber.New()
+	app.Use(AuthMiddleware(svc,
+		SkipPaths("/api/v1/auth/", "/metrics"),
+		Skip(func(c *fiber.Ctx) bool { return c.Get("X-Test-Public") == "1" }),
+	))
+	app.Use(func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+	cases := []struct {
+		path, header string
+		status       int
+	}{
+		{path: "/api/v1/auth/login", status: fiber.StatusOK},
+		{path: "/api/v1/auth", status: fiber.StatusOK},
+		{path: "/metrics", status: fiber.StatusOK},
+		{path: "/api/v1/authors", status: fiber.StatusUnauthorized},
+		{path: "/api/v1/tasks", status: fiber.StatusUnauthorized},
+		{path: "/api/v1/tasks", header: "1", status: fiber.StatusOK},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest("GET", tc.path, nil)
+		if tc.header != "" {
+			req.Header.Set("X-Test-Public", tc.header)
+		}
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("%s: app.Test: %v", tc.path, err)
+		}
+		if resp.StatusCode != tc.status {
+			t.Fatalf("%s: expected status %d, got %d", tc.path, tc.status, resp.StatusCode)
+		}
+	}
 }
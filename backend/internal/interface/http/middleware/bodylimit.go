@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// BodyLimit rejects requests whose body exceeds maxBytes with 413 Request
+// Entity Too Large. The declared Content-Length is checked first so an
+// oversized upload is refused without touching its body; the actual length
+// is checked as well for chunked requests. maxBytes <= 0 disables the check.
+func BodyLimit(maxBytes int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if maxBytes <= 0 {
+			return c.Next()
+		}
+		if c.Request().Header.ContentLength() > maxBytes || len(c.Body()) > maxBytes {
+			return fiber.NewError(fiber.StatusRequestEntityTooLarge, fmt.Sprintf("request body exceeds %d bytes", maxBytes))
+		}
+		return c.Next()
+	}
+}
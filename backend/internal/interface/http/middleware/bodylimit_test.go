@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Test that bodies over the limit get 413 and bodies at the limit pass.
+func TestBodyLimit(t *testing.T) {
+	app := fiber.New()
+	app.Post("/upload", BodyLimit(8), func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusCreated) })
+
+	cases := []struct {
+		body   string
+		status int
+	}{
+		{"12345678", fiber.StatusCreated},
+		{"123456789", fiber.StatusRequestEntityTooLarge},
+	}
+	for _, tc := range cases {
+		resp, err := app.Test(httptest.NewRequest("POST", "/upload", bytes.NewReader([]byte(tc.body))), -1)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		if resp.StatusCode != tc.status {
+			t.Fatalf("body of %d bytes: expected status %d, got %d", len(tc.body), tc.status, resp.StatusCode)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package middleware
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"backend/internal/pkg/ctxkeys"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// redacted replaces sensitive header values and body fields in body logs.
+const redacted = "[REDACTED]"
+
+// sensitiveFields are the words that, found anywhere in a JSON field or
+// form field name, get its value redacted.
+var sensitiveFields = []string{"password", "token", "secret", "key"}
+
+// sensitiveHeaders are logged as redacted, whatever they hold.
+var sensitiveHeaders = []string{fiber.HeaderAuthorization, fiber.HeaderCookie, fiber.HeaderSetCookie, "X-Api-Key"}
+
+// BodyLogger logs the headers and bodies of every request and its response
+// for debugging, with credentials redacted: the Authorization, Cookie and
+// X-API-Key headers, and any JSON or form field whose name contains
+// "password", "token", "secret" or "key". JSON that does not parse is not
+// logged at all, since it cannot be redacted. Bodies are cut to maxBytes. Streamed responses are not read, so
+// they keep streaming. Like RequestLogger it renders errors itself; mount it
+// after RequestLogger, and only in development.
+func BodyLogger(logger *slog.Logger, maxBytes int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		// Fiber reuses the request buffer once the handler returns
+		reqBody := logBody(c.Get(fiber.HeaderContentType), c.Body(), maxBytes)
+		if err := c.Next(); err != nil {
+			if herr := c.App().ErrorHandler(c, err); herr != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+
+		resp := c.Response()
+		respBody := "<stream>"
+		if !resp.IsBodyStream() {
+			respBody = logBody(string(resp.Header.ContentType()), resp.Body(), maxBytes)
+		}
+		logger.LogAttrs(c.UserContext(), slog.LevelInfo, "request body",
+			slog.String("method", c.Method()),
+			slog.String("path", c.Path()),
+			slog.Int("status", resp.StatusCode()),
+			slog.Any("request_headers", redactHeaders(c.GetReqHeaders())),
+			slog.String("request_body", reqBody),
+			slog.String("response_body", respBody),
+			slog.String("correlation_id", ctxkeys.CorrelationIDFromCtx(c.UserContext())),
+		)
+		return nil
+	}
+}
+
+// redactHeaders flattens headers for logging, hiding sensitiveHeaders.
+func redactHeaders(h map[string][]string) map[string]string {
+	out := make(map[string]string, len(h))
+	for name, values := range h {
+		out[name] = strings.Join(values, ", ")
+		for _, s := range sensitiveHeaders {
+			if strings.EqualFold(name, s) {
+				out[name] = redacted
+			}
+		}
+	}
+	return out
+}
+
+// logBody renders body for logging: JSON and forms with their sensitive
+// fields redacted, anything else as is, cut to maxBytes either way.
+func logBody(contentType string, body []byte, maxBytes int) string {
+	if len(body) == 0 {
+		return ""
+	}
+	s := string(body)
+	contentType = strings.ToLower(contentType)
+	switch {
+	case strings.HasPrefix(contentType, fiber.MIMEApplicationJSON):
+		var v any
+		if err := json.Unmarshal(body, &v); err != nil {
+			return "<invalid JSON>"
+		}
+		b, err := json.Marshal(redactJSON(v))
+		if err != nil {
+			return "<invalid JSON>"
+		}
+		s = string(b)
+	case strings.HasPrefix(contentType, fiber.MIMEApplicationForm):
+		s = redactForm(s)
+	}
+	if maxBytes > 0 && len(s) > maxBytes {
+		return strings.ToValidUTF8(s[:maxBytes], "") + "...(" + strconv.Itoa(len(s)-maxBytes) + " more bytes)"
+	}
+	return s
+}
+
+// sensitiveField reports whether name contains one of sensitiveFields,
+// ignoring case.
+func sensitiveField(name string) bool {
+	name = strings.ToLower(name)
+	for _, f := range sensitiveFields {
+		if strings.Contains(name, f) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactForm hides the value of every sensitive field of a URL-encoded form,
+// keeping the fields in their order.
+func redactForm(s string) string {
+	pairs := strings.Split(s, "&")
+	for i, pair := range pairs {
+		raw, _, _ := strings.Cut(pair, "=")
+		name := raw
+		if unescaped, err := url.QueryUnescape(raw); err == nil {
+			name = unescaped
+		}
+		if sensitiveField(name) {
+			pairs[i] = raw + "=" + redacted
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// redactJSON hides the value of every sensitive field, at any depth.
+// Objects and arrays under a sensitive name, such as a minted key's "apiKey"
+// metadata, are searched rather than hidden whole.
+func redactJSON(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, val := range v {
+			if !isContainer(val) && sensitiveField(k) {
+				v[k] = redacted
+				continue
+			}
+			v[k] = redactJSON(val)
+		}
+	case []any:
+		for i, val := range v {
+			v[i] = redactJSON(val)
+		}
+	}
+	return v
+}
+
+// isContainer reports whether a decoded JSON value is an object or array.
+func isContainer(v any) bool {
+	switch v.(type) {
+	case map[string]any, []any:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type bodyLogEntry struct {
+	Msg            string            `json:"msg"`
+	Status         int               `json:"status"`
+	RequestHeaders map[string]string `json:"request_headers"`
+	RequestBody    string            `json:"request_body"`
+	ResponseBody   string            `json:"response_body"`
+}
+
+func newBodyLogApp(buf *bytes.Buffer, maxBytes int) *fiber.App {
+	app := fiber.New()
+	app.Use(BodyLogger(slog.New(slog.NewJSONHandler(buf, nil)), maxBytes))
+	app.Post("/login", func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{"token": "jwt-secret", "user": fiber.Map{"id": "u1"}})
+	})
+	app.Post("/keys", func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"apiKey": fiber.Map{"id": "k1", "name": "ci"}, "key": "mfk_secret"})
+	})
+	app.Post("/form", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) })
+	app.Get("/big", func(c *fiber.Ctx) error { return c.SendString(strings.Repeat("a", 100)) })
+	app.Get("/stream", func(c *fiber.Ctx) error {
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			_, _ = w.WriteString("data: one\n\n")
+		})
+		return nil
+	})
+	return app
+}
+
+func decodeBodyLog(t *testing.T, buf *bytes.Buffer) bodyLogEntry {
+	t.Helper()
+	var entry bodyLogEntry
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("decode log %q: %v", buf.String(), err)
+	}
+	return entry
+}
+
+// Test that the Authorization header and password and token fields are
+// redacted in both bodies, while the rest is logged.
+func TestBodyLogger_Redacts(t *testing.T) {
+	var buf bytes.Buffer
+	app := newBodyLogApp(&buf, 1024)
+	req := httptest.NewRequest("POST", "/login", strings.NewReader(`{"email":"a@example.com","password":"hunter2","nested":{"refreshToken":"r1"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer jwt-secret")
+	if _, err := app.Test(req, -1); err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if strings.Contains(buf.String(), "hunter2") || strings.Contains(buf.String(), "jwt-secret") || strings.Contains(buf.String(), "r1") {
+		t.Fatalf("expected credentials to be redacted, got %s", buf.String())
+	}
+	entry := decodeBodyLog(t, &buf)
+	if entry.RequestHeaders["Authorization"] != redacted {
+		t.Fatalf("expected the Authorization header redacted, got %q", entry.RequestHeaders["Authorization"])
+	}
+	if !strings.Contains(entry.RequestBody, `"email":"a@example.com"`) || !strings.Contains(entry.RequestBody, `"password":"[REDACTED]"`) {
+		t.Fatalf("unexpected request body %s", entry.RequestBody)
+	}
+	if !strings.Contains(entry.ResponseBody, `"token":"[REDACTED]"`) || !strings.Contains(entry.ResponseBody, `"id":"u1"`) {
+		t.Fatalf("unexpected response body %s", entry.ResponseBody)
+	}
+}
+
+// Test that a minted API key in the response is redacted, while the key's
+// metadata is logged.
+func TestBodyLogger_RedactsMintedKey(t *testing.T) {
+	var buf bytes.Buffer
+	app := newBodyLogApp(&buf, 1024)
+	if _, err := app.Test(httptest.NewRequest("POST", "/keys", nil), -1); err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if strings.Contains(buf.String(), "mfk_secret") {
+		t.Fatalf("expected the key to be redacted, got %s", buf.String())
+	}
+	body := decodeBodyLog(t, &buf).ResponseBody
+	if !strings.Contains(body, `"key":"[REDACTED]"`) || !strings.Contains(body, `"name":"ci"`) {
+		t.Fatalf("unexpected response body %s", body)
+	}
+}
+
+// Test that password and token fields of a URL-encoded form are redacted,
+// while the rest is logged in order.
+func TestBodyLogger_RedactsForm(t *testing.T) {
+	var buf bytes.Buffer
+	app := newBodyLogApp(&buf, 1024)
+	req := httptest.NewRequest("POST", "/form", strings.NewReader("email=a%40example.com&password=hunter2&refresh%5Ftoken=r1"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if _, err := app.Test(req, -1); err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if got := decodeBodyLog(t, &buf).RequestBody; got != "email=a%40example.com&password=[REDACTED]&refresh%5Ftoken=[REDACTED]" {
+		t.Fatalf("unexpected request body %q", got)
+	}
+}
+
+// Test that a JSON body that does not parse is logged as a placeholder
+// rather than as is.
+func TestBodyLogger_InvalidJSON(t *testing.T) {
+	var buf bytes.Buffer
+	app := newBodyLogApp(&buf, 1024)
+	req := httptest.NewRequest("POST", "/form", strings.NewReader(`{"password":"hunter2"`))
+	req.Header.Set("Content-Type", "application/json")
+	if _, err := app.Test(req, -1); err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if got := decodeBodyLog(t, &buf).RequestBody; got != "<invalid JSON>" {
+		t.Fatalf("expected a placeholder, got %q", got)
+	}
+}
+
+// Test that bodies longer than the limit are cut, saying by how much.
+func TestBodyLogger_Truncates(t *testing.T) {
+	var buf bytes.Buffer
+	app := newBodyLogApp(&buf, 10)
+	resp, err := app.Test(httptest.NewRequest("GET", "/big", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if body, _ := io.ReadAll(resp.Body); len(body) != 100 {
+		t.Fatalf("expected the client to get all 100 bytes, got %d", len(body))
+	}
+	if got := decodeBodyLog(t, &buf).ResponseBody; got != "aaaaaaaaaa...(90 more bytes)" {
+		t.Fatalf("expected a truncated body, got %q", got)
+	}
+}
+
+// Test that streamed responses still reach the client and are not logged.
+func TestBodyLogger_Stream(t *testing.T) {
+	var buf bytes.Buffer
+	app := newBodyLogApp(&buf, 1024)
+	resp, err := app.Test(httptest.NewRequest("GET", "/stream", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if body, _ := io.ReadAll(resp.Body); string(body) != "data: one\n\n" {
+		t.Fatalf("expected the stream to reach the client, got %q", body)
+	}
+	if got := decodeBodyLog(t, &buf).ResponseBody; got != "<stream>" {
+		t.Fatalf("expected the stream not to be logged, got %q", got)
+	}
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ConcurrencyLimit bounds how many requests are processed at once, to keep a
+// burst from exhausting the database pool. It is a process-wide cap, unlike
+// per-tenant rate limiting.
+type ConcurrencyLimit struct {
+	// Limit is the most requests processed at once; <= 0 disables the limit.
+	Limit int
+	// QueueSize requests beyond Limit may wait for a slot, each for at most
+	// QueueTimeout or until its own deadline. Without a queue, or once it is
+	// full, excess requests are refused at once.
+	QueueSize    int
+	QueueTimeout time.Duration
+}
+
+// ConcurrencyLimitMiddleware enforces l with a semaphore. Refused requests get
+// 503 Service Unavailable with a Retry-After header.
+func ConcurrencyLimitMiddleware(l ConcurrencyLimit) fiber.Handler {
+	if l.Limit <= 0 {
+		return func(c *fiber.Ctx) error { return c.Next() }
+	}
+	slots := make(chan struct{}, l.Limit)
+	var waiting atomic.Int64
+	retryAfter := strconv.Itoa(max(1, int((l.QueueTimeout+time.Second-1)/time.Second)))
+	busy := func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderRetryAfter, retryAfter)
+		return fiber.NewError(fiber.StatusServiceUnavailable, "server busy, retry later")
+	}
+
+	return func(c *fiber.Ctx) error {
+		select {
+		case slots <- struct{}{}:
+		default:
+			if l.QueueSize <= 0 || l.QueueTimeout <= 0 {
+				return busy(c)
+			}
+			if waiting.Add(1) > int64(l.QueueSize) {
+				waiting.Add(-1)
+				return busy(c)
+			}
+			timer := time.NewTimer(l.QueueTimeout)
+			select {
+			case slots <- struct{}{}:
+				waiting.Add(-1)
+				timer.Stop()
+			case <-timer.C:
+				waiting.Add(-1)
+				return busy(c)
+			case <-c.UserContext().Done():
+				waiting.Add(-1)
+				timer.Stop()
+				return fiber.ErrGatewayTimeout
+			}
+		}
+		defer func() { <-slots }()
+		return c.Next()
+	}
+}
@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// newBlockingApp returns an app behind l whose handler signals entered and
+// then waits for release.
+func newBlockingApp(l ConcurrencyLimit) (app *fiber.App, entered chan struct{}, release chan struct{}) {
+	entered, release = make(chan struct{}, 8), make(chan struct{})
+	app = fiber.New()
+	app.Use(ConcurrencyLimitMiddleware(l))
+	app.Get("/", func(c *fiber.Ctx) error {
+		entered <- struct{}{}
+		<-release
+		return c.SendStatus(fiber.StatusOK)
+	})
+	return app, entered, release
+}
+
+// goTest runs a request in the background and delivers its response.
+func goTest(t *testing.T, app *fiber.App) <-chan *http.Response {
+	t.Helper()
+	out := make(chan *http.Response, 1)
+	go func() {
+		resp, err := app.Test(httptest.NewRequest("GET", "/", nil), -1)
+		if err != nil {
+			t.Errorf("app.Test: %v", err)
+		}
+		out <- resp
+	}()
+	return out
+}
+
+// Test that without a queue a request arriving while every slot is taken is
+// refused at once with 503 and Retry-After, and that freed slots are reused.
+func TestConcurrencyLimit_Rejects(t *testing.T) {
+	app, entered, release := newBlockingApp(ConcurrencyLimit{Limit: 1})
+	first := goTest(t, app)
+	<-entered
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusServiceUnavailable || resp.Header.Get("Retry-After") != "1" {
+		t.Fatalf("expected 503 with Retry-After 1, got %d %q", resp.StatusCode, resp.Header.Get("Retry-After"))
+	}
+
+	release <- struct{}{}
+	if resp := <-first; resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected the first request to finish, got %d", resp.StatusCode)
+	}
+	next := goTest(t, app)
+	<-entered
+	release <- struct{}{}
+	if resp := <-next; resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected the freed slot to be reused, got %d", resp.StatusCode)
+	}
+}
+
+// Test that a queued request waits for a slot, that requests beyond the
+// queue are refused, and that a request queued past the timeout gets 503.
+func TestConcurrencyLimit_Queue(t *testing.T) {
+	app, entered, release := newBlockingApp(ConcurrencyLimit{Limit: 1, QueueSize: 1, QueueTimeout: time.Second})
+	first := goTest(t, app)
+	<-entered
+	queued := goTest(t, app)
+	time.Sleep(20 * time.Millisecond)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusServiceUnavailable {
+		t.Fatalf("expected a full queue to refuse with %d, got %d", fiber.StatusServiceUnavailable, resp.StatusCode)
+	}
+
+	release <- struct{}{}
+	<-entered
+	release <- struct{}{}
+	for _, ch := range []<-chan *http.Response{first, queued} {
+		if resp := <-ch; resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("expected status %d, got %d", fiber.StatusOK, resp.StatusCode)
+		}
+	}
+
+	app, entered, release = newBlockingApp(ConcurrencyLimit{Limit: 1, QueueSize: 1, QueueTimeout: 20 * time.Millisecond})
+	first = goTest(t, app)
+	<-entered
+	resp, err = app.Test(httptest.NewRequest("GET", "/", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusServiceUnavailable {
+		t.Fatalf("expected the queue timeout to refuse with %d, got %d", fiber.StatusServiceUnavailable, resp.StatusCode)
+	}
+	release <- struct{}{}
+	<-first
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"mime"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Media types accepted on write routes by default.
+const (
+	ContentTypeJSON       = "application/json"
+	ContentTypeMergePatch = "application/merge-patch+json"
+)
+
+// RequireContentType rejects POST, PUT and PATCH requests whose body is not
+// declared as one of types with 415 Unsupported Media Type, so form or XML
+// bodies fail clearly instead of being half-parsed by BodyParser. Parameters
+// such as charset are ignored. Requests without a body pass, which keeps
+// endpoints whose body is optional usable. Without types, JSON and JSON merge
+// patch are accepted.
+func RequireContentType(types ...string) fiber.Handler {
+	if len(types) == 0 {
+		types = []string{ContentTypeJSON, ContentTypeMergePatch}
+	}
+	accepted := make(map[string]bool, len(types))
+	for _, t := range types {
+		accepted[strings.ToLower(t)] = true
+	}
+	message := "Content-Type must be " + strings.Join(types, " or ")
+	return func(c *fiber.Ctx) error {
+		switch c.Method() {
+		case fiber.MethodPost, fiber.MethodPut, fiber.MethodPatch:
+		default:
+			return c.Next()
+		}
+		if c.Request().Header.ContentLength() <= 0 && len(c.Body()) == 0 {
+			return c.Next()
+		}
+		mediaType, _, err := mime.ParseMediaType(c.Get(fiber.HeaderContentType))
+		if err != nil || !accepted[mediaType] {
+			return fiber.NewError(fiber.StatusUnsupportedMediaType, message)
+		}
+		return c.Next()
+	}
+}
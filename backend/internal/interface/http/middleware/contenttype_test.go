@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Test that bodies without a Content-Type or with an unaccepted one get 415
+// while JSON, its parameters and bodyless requests pass.
+func TestRequireContentType(t *testing.T) {
+	app := fiber.New()
+	ok := func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) }
+	app.Post("/tasks", RequireContentType(), ok)
+	app.Patch("/tasks", RequireContentType(), ok)
+	app.Put("/settings", RequireContentType(ContentTypeJSON), ok)
+
+	cases := []struct {
+		method      string
+		path        string
+		contentType string
+		body        string
+		status      int
+	}{
+		{"POST", "/tasks", "", `{"title":"a"}`, fiber.StatusUnsupportedMediaType},
+		{"POST", "/tasks", "application/x-www-form-urlencoded", "title=a", fiber.StatusUnsupportedMediaType},
+		{"POST", "/tasks", "application/xml", "<task/>", fiber.StatusUnsupportedMediaType},
+		{"POST", "/tasks", "application/json", `{"title":"a"}`, fiber.StatusNoContent},
+		{"POST", "/tasks", "Application/JSON; charset=utf-8", `{"title":"a"}`, fiber.StatusNoContent},
+		{"POST", "/tasks", "", "", fiber.StatusNoContent},
+		{"PATCH", "/tasks", "application/merge-patch+json", `{"title":"a"}`, fiber.StatusNoContent},
+		{"PUT", "/settings", "application/merge-patch+json", `{}`, fiber.StatusUnsupportedMediaType},
+		{"PUT", "/settings", "application/json", `{}`, fiber.StatusNoContent},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+		if tc.contentType != "" {
+			req.Header.Set("Content-Type", tc.contentType)
+		}
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		if resp.StatusCode != tc.status {
+			t.Fatalf("%s %s with %q: expected status %d, got %d", tc.method, tc.path, tc.contentType, tc.status, resp.StatusCode)
+		}
+	}
+}
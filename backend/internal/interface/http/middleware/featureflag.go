@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// FeatureService reports whether a feature flag is on for a tenant.
+type FeatureService interface {
+	IsEnabled(ctx context.Context, tenantID, flagName string) (bool, error)
+}
+
+// RequireFlag lets the request through only when flagName is on for the
+// caller's tenant. Otherwise it answers 404, as if the route did not exist,
+// so features that are not yet advertised stay hidden. It must run after
+// AuthMiddleware.
+func RequireFlag(svc FeatureService, flagName string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		on, err := svc.IsEnabled(c.UserContext(), ClaimsOf(c).TenantID, flagName)
+		if err != nil {
+			return fiber.ErrInternalServerError
+		}
+		if !on {
+			return fiber.ErrNotFound
+		}
+		return c.Next()
+	}
+}
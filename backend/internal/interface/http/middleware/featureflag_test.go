@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"backend/internal/pkg/identity"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// flagFunc adapts a function to FeatureService.
+type flagFunc func(tenantID, flagName string) (bool, error)
+
+func (f flagFunc) IsEnabled(_ context.Context, tenantID, flagName string) (bool, error) {
+	return f(tenantID, flagName)
+}
+
+// Test that a gated route answers only for tenants with the flag on, is
+// hidden with 404 for the others and fails with 500 when flags cannot be read.
+func TestRequireFlag(t *testing.T) {
+	flags := flagFunc(func(tenantID, flagName string) (bool, error) {
+		if tenantID == "broken" {
+			return false, errors.New("database down")
+		}
+		return tenantID == "beta" && flagName == "graphql", nil
+	})
+	cases := map[string]int{
+		"beta":   fiber.StatusOK,
+		"t1":     fiber.StatusNotFound,
+		"broken": fiber.StatusInternalServerError,
+	}
+	for tenantID, want := range cases {
+		app := fiber.New()
+		app.Use(func(c *fiber.Ctx) error {
+			SetClaims(c, identity.Claims{TenantID: tenantID})
+			return c.Next()
+		})
+		app.Get("/graphql", RequireFlag(flags, "graphql"), func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/graphql", nil), -1)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		if resp.StatusCode != want {
+			t.Fatalf("tenant %q: expected status %d, got %d", tenantID, want, resp.StatusCode)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"mime"
+	"strings"
+
+	"backend/internal/pkg/jsoncase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// JSONKeyCase rewrites the keys of JSON responses to snake_case when the
+// request asks for it with a case parameter on the Accept header, e.g.
+// "Accept: application/json; case=snake". Other responses, and requests
+// without the parameter, keep the camelCase keys of the structs they are
+// marshaled from. Responses vary by Accept so caches keep both shapes apart.
+func JSONKeyCase() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Vary(fiber.HeaderAccept)
+		if !wantsSnakeCase(c.Get(fiber.HeaderAccept)) {
+			return c.Next()
+		}
+		if err := c.Next(); err != nil {
+			return err
+		}
+		ct := string(c.Response().Header.ContentType())
+		if !strings.HasPrefix(ct, fiber.MIMEApplicationJSON) {
+			return nil
+		}
+		body, err := jsoncase.Rewrite(c.Response().Body(), jsoncase.Snake)
+		if err != nil {
+			// Leave bodies that are not valid JSON as the handler wrote them.
+			return nil
+		}
+		c.Response().SetBodyRaw(body)
+		return nil
+	}
+}
+
+// wantsSnakeCase reports whether any media range of accept has case=snake.
+func wantsSnakeCase(accept string) bool {
+	if !strings.Contains(accept, "case=") {
+		return false
+	}
+	for _, part := range strings.Split(accept, ",") {
+		_, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err == nil && strings.EqualFold(params["case"], "snake") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	domaintask "backend/internal/domain/task"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Test that a task is returned with tenant_id when snake_case is requested
+// through the Accept header and with tenantId by default.
+func TestJSONKeyCase(t *testing.T) {
+	app := fiber.New()
+	app.Use(JSONKeyCase())
+	app.Get("/task", func(c *fiber.Ctx) error {
+		return c.JSON(domaintask.New("t1", "u1", "title", "", 5))
+	})
+	app.Get("/text", func(c *fiber.Ctx) error { return c.SendString(`{"tenantId":"t1"}`) })
+
+	get := func(path, accept string) string {
+		req := httptest.NewRequest("GET", path, nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+
+	if body := get("/task", ""); !strings.Contains(body, `"tenantId":"t1"`) || strings.Contains(body, "tenant_id") {
+		t.Fatalf("expected camelCase by default, got %s", body)
+	}
+	body := get("/task", "text/html, application/json; case=snake")
+	if !strings.Contains(body, `"tenant_id":"t1"`) || !strings.Contains(body, `"created_at"`) || strings.Contains(body, "tenantId") {
+		t.Fatalf("expected snake_case, got %s", body)
+	}
+	if body := get("/text", "application/json; case=snake"); body != `{"tenantId":"t1"}` {
+		t.Fatalf("expected non-JSON response untouched, got %s", body)
+	}
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"log/slog"
+	"time"
+
+	"backend/internal/pkg/ctxkeys"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequestLogger logs one structured line per request using the given logger.
+// Server errors are logged at error level, everything else at info, so the
+// configured level decides how much request traffic ends up in the logs.
+func RequestLogger(logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+		if err != nil {
+			// Let the app's error handler render the response so the
+			// logged status matches what the client receives.
+			if herr := c.App().ErrorHandler(c, err); herr != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+
+		status := c.Response().StatusCode()
+		level := slog.LevelInfo
+		if status >= fiber.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		rid, _ := c.Locals("requestid").(string)
+		logger.LogAttrs(c.UserContext(), level, "request",
+			slog.String("method", c.Method()),
+			slog.String("path", c.Path()),
+			slog.String("ip", c.IP()),
+			slog.Int("status", status),
+			slog.Duration("duration", time.Since(start)),
+			slog.String("request_id", rid),
+			slog.String("correlation_id", ctxkeys.CorrelationIDFromCtx(c.UserContext())),
+		)
+		return nil
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// Test that requests are recorded under their route pattern and final status.
+func TestHTTPMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	app := fiber.New()
+	app.Use(HTTPMetrics(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))))
+	app.Get("/tasks/:id", func(c *fiber.Ctx) error {
+		if c.Params("id") == "missing" {
+			return fiber.ErrNotFound
+		}
+		return c.SendString("ok")
+	})
+
+	for _, path := range []string{"/tasks/1", "/tasks/2", "/tasks/missing"} {
+		if _, err := app.Test(httptest.NewRequest("GET", path, nil), -1); err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	counts := map[int64]uint64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			hist, ok := m.Data.(metricdata.Histogram[float64])
+			if m.Name != "http.server.request.duration" || !ok {
+				continue
+			}
+			for _, dp := range hist.DataPoints {
+				if route, _ := dp.Attributes.Value(attribute.Key("http.route")); route.AsString() != "/tasks/:id" {
+					t.Fatalf("expected route pattern, got %q", route.AsString())
+				}
+				status, _ := dp.Attributes.Value(attribute.Key("http.response.status_code"))
+				counts[status.AsInt64()] += dp.Count
+			}
+		}
+	}
+	if counts[200] != 2 || counts[404] != 1 {
+		t.Fatalf("expected 2x200 and 1x404, got %v", counts)
+	}
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"errors"
+
+	"backend/internal/pkg/identity"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// principalKey is the Fiber local holding the caller's identity.Claims. Its
+// unexported type means no other package can read or overwrite the value
+// under a string key by mistake.
+type principalKey struct{}
+
+// Principal is the authenticated caller of a request, as FromCtx returns it.
+type Principal struct {
+	UserID   string
+	TenantID string
+	Roles    []string
+	// TokenID is the jti of the access token the caller presented, if any.
+	TokenID string
+}
+
+// HasRole reports whether the principal holds role.
+func (p Principal) HasRole(role string) bool {
+	return identity.Claims{Roles: p.Roles}.HasRole(role)
+}
+
+// Errors returned by FromCtx.
+var (
+	// ErrNoPrincipal means the request reached a handler without passing
+	// AuthMiddleware, which is a routing mistake rather than the caller's.
+	ErrNoPrincipal = errors.New("request has no authenticated caller")
+	// ErrIncompletePrincipal means the caller was authenticated but names no
+	// user or no tenant, as the service token does.
+	ErrIncompletePrincipal = errors.New("caller has no user or tenant")
+)
+
+// FromCtx returns the caller AuthMiddleware stored for the request. It never
+// hands out empty identifiers: it returns ErrNoPrincipal when nothing was
+// stored and ErrIncompletePrincipal when the user or tenant is missing, so
+// tenant-scoped handlers cannot query with an empty tenant.
+func FromCtx(c *fiber.Ctx) (Principal, error) {
+	claims, ok := c.Locals(principalKey{}).(identity.Claims)
+	if !ok {
+		return Principal{}, ErrNoPrincipal
+	}
+	if claims.UserID == "" || claims.TenantID == "" {
+		return Principal{}, ErrIncompletePrincipal
+	}
+	return Principal{
+		UserID:   claims.UserID,
+		TenantID: claims.TenantID,
+		Roles:    claims.Roles,
+		TokenID:  claims.TokenID,
+	}, nil
+}
+
+// ClaimsOf returns the claims AuthMiddleware stored for the request, or zero
+// claims when the request was not authenticated. Handlers that must not run
+// without a tenant use FromCtx instead.
+func ClaimsOf(c *fiber.Ctx) identity.Claims {
+	claims, _ := c.Locals(principalKey{}).(identity.Claims)
+	return claims
+}
+
+// SetClaims stores claims for the request as AuthMiddleware does.
+func SetClaims(c *fiber.Ctx, claims identity.Claims) {
+	c.Locals(principalKey{}, claims)
+}
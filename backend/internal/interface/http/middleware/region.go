@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// HeaderRegion names the region a request was routed through.
+const HeaderRegion = "X-Region"
+
+// errWrongRegion answers requests sent through a region other than the
+// tenant's.
+var errWrongRegion = fiber.NewError(fiber.StatusUnavailableForLegalReasons, "request region does not match the tenant's data residency region")
+
+// TenantSettingsService reports a tenant's data residency region, "" when
+// it has none.
+type TenantSettingsService interface {
+	GetRegion(ctx context.Context, tenantID string) (string, error)
+}
+
+// RegionMiddleware lets the request through only when its X-Region header
+// names the caller's tenant's data residency region, and answers 451
+// otherwise. Tenants without a region are not checked. Until requests are
+// routed geographically this keeps writes of pinned tenants to their
+// region. It must run after AuthMiddleware.
+func RegionMiddleware(settingsSvc TenantSettingsService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		region, err := settingsSvc.GetRegion(c.UserContext(), ClaimsOf(c).TenantID)
+		if err != nil {
+			return fiber.ErrInternalServerError
+		}
+		if region != "" && !strings.EqualFold(strings.TrimSpace(c.Get(HeaderRegion)), region) {
+			return errWrongRegion
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"backend/internal/pkg/identity"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// regionFunc adapts a function to TenantSettingsService.
+type regionFunc func(tenantID string) (string, error)
+
+func (f regionFunc) GetRegion(_ context.Context, tenantID string) (string, error) {
+	return f(tenantID)
+}
+
+// Test that requests through the tenant's region pass, that requests through
+// another region or none answer 451, that tenants without a region are not
+// checked, and that a failed lookup answers 500.
+func TestRegionMiddleware(t *testing.T) {
+	regions := regionFunc(func(tenantID string) (string, error) {
+		switch tenantID {
+		case "eu":
+			return "eu-west-1", nil
+		case "broken":
+			return "", errors.New("database down")
+		}
+		return "", nil
+	})
+	cases := []struct {
+		tenantID, header string
+		want             int
+	}{
+		{"eu", "eu-west-1", fiber.StatusOK},
+		{"eu", "EU-WEST-1", fiber.StatusOK},
+		{"eu", "us-east-1", fiber.StatusUnavailableForLegalReasons},
+		{"eu", "", fiber.StatusUnavailableForLegalReasons},
+		{"unconfigured", "us-east-1", fiber.StatusOK},
+		{"unconfigured", "", fiber.StatusOK},
+		{"broken", "eu-west-1", fiber.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		app := fiber.New()
+		app.Use(func(c *fiber.Ctx) error {
+			SetClaims(c, identity.Claims{TenantID: tc.tenantID})
+			return c.Next()
+		})
+		app.Post("/tasks", RegionMiddleware(regions), func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+		req := httptest.NewRequest("POST", "/tasks", nil)
+		if tc.header != "" {
+			req.Header.Set(HeaderRegion, tc.header)
+		}
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		if resp.StatusCode != tc.want {
+			t.Fatalf("tenant %q, region %q: expected status %d, got %d", tc.tenantID, tc.header, tc.want, resp.StatusCode)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"backend/internal/pkg/requestid"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequestIDContext copies the request ID produced by Fiber's requestid
+// middleware into the request's user context, so services receiving
+// c.UserContext() can include it in their logs. It must be registered after
+// requestid.New().
+func RequestIDContext() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if id, ok := c.Locals("requestid").(string); ok && id != "" {
+			c.SetUserContext(requestid.NewContext(c.UserContext(), id))
+		}
+		return c.Next()
+	}
+}
+
+// RequestIDHeader drops an X-Request-Id header that requestid.Valid refuses,
+// so Fiber's requestid middleware generates a fresh ID in its place rather
+// than passing the client's value on to logs and SQL comments. It must be
+// registered before requestid.New().
+func RequestIDHeader() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if id := c.Get(fiber.HeaderXRequestID); id != "" && !requestid.Valid(id) {
+			c.Request().Header.Del(fiber.HeaderXRequestID)
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+)
+
+// Test that a safe client request ID is kept, while one with characters
+// outside the allowed set, or too long, is replaced by a generated ID.
+func TestRequestIDHeader(t *testing.T) {
+	app := fiber.New()
+	app.Use(RequestIDHeader())
+	app.Use(requestid.New())
+	app.Get("/", func(c *fiber.Ctx) error {
+		id, _ := c.Locals("requestid").(string)
+		return c.SendString(id)
+	})
+
+	for in, keep := range map[string]bool{
+		"req-123_a.b":                  true,
+		"x**// ; DELETE FROM tasks --": false,
+		"/*/":                          false,
+		"a b":                          false,
+		strings.Repeat("a", 65):        false,
+	} {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set(fiber.HeaderXRequestID, in)
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		got := resp.Header.Get(fiber.HeaderXRequestID)
+		if keep && got != in {
+			t.Fatalf("%q: expected the ID kept, got %q", in, got)
+		}
+		if !keep && (got == in || got == "" || strings.ContainsAny(got, "*/ ;")) {
+			t.Fatalf("%q: expected a generated ID, got %q", in, got)
+		}
+	}
+}
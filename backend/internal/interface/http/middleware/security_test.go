@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Test that every security header is present on success, handler errors and
+// unknown routes, and that the CSP can be overridden.
+func TestSecurityHeadersMiddleware(t *testing.T) {
+	want := map[string]string{
+		fiber.HeaderStrictTransportSecurity: "max-age=31536000; includeSubDomains",
+		fiber.HeaderXContentTypeOptions:     "nosniff",
+		fiber.HeaderXFrameOptions:           "DENY",
+		fiber.HeaderReferrerPolicy:          "strict-origin-when-cross-origin",
+		fiber.HeaderContentSecurityPolicy:   DefaultCSP,
+	}
+	app := fiber.New()
+	app.Use(SecurityHeadersMiddleware(""))
+	app.Get("/ok", func(c *fiber.Ctx) error { return c.SendString("ok") })
+	app.Get("/fail", func(c *fiber.Ctx) error { return fiber.ErrBadRequest })
+
+	for _, path := range []string{"/ok", "/fail", "/missing"} {
+		resp, err := app.Test(httptest.NewRequest("GET", path, nil), -1)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		for h, v := range want {
+			if got := resp.Header.Get(h); got != v {
+				t.Fatalf("%s: expected %s %q, got %q", path, h, v, got)
+			}
+		}
+	}
+
+	custom := fiber.New()
+	custom.Use(SecurityHeadersMiddleware("default-src 'none'"))
+	resp, _ := custom.Test(httptest.NewRequest("GET", "/", nil), -1)
+	if got := resp.Header.Get(fiber.HeaderContentSecurityPolicy); got != "default-src 'none'" {
+		t.Fatalf("expected overridden CSP, got %q", got)
+	}
+}
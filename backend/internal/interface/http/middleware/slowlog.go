@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"backend/internal/pkg/ctxkeys"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SlowRequestLogger logs a warning for every request that takes longer than
+// threshold, naming the matched route rather than the path so slow endpoints
+// group together. It leaves errors to the handlers before it; mount it after
+// RequestLogger. A non-positive threshold disables it.
+func SlowRequestLogger(logger *slog.Logger, threshold time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if threshold <= 0 {
+			return c.Next()
+		}
+		start := time.Now()
+		err := c.Next()
+		duration := time.Since(start)
+		if duration <= threshold {
+			return err
+		}
+		status := c.Response().StatusCode()
+		var ferr *fiber.Error
+		switch {
+		case errors.As(err, &ferr):
+			status = ferr.Code
+		case err != nil:
+			status = fiber.StatusInternalServerError
+		}
+		logger.LogAttrs(c.UserContext(), slog.LevelWarn, "slow request",
+			slog.String("method", c.Method()),
+			slog.String("route", c.Route().Path),
+			slog.Int("status", status),
+			slog.Duration("duration", duration),
+			slog.Duration("threshold", threshold),
+			slog.String("correlation_id", ctxkeys.CorrelationIDFromCtx(c.UserContext())),
+		)
+		return err
+	}
+}
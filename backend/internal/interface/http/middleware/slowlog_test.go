@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Test that a request slower than the threshold is logged at warn level with
+// its route, method, status and duration, and a fast one is not logged.
+func TestSlowRequestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	app := fiber.New()
+	app.Use(SlowRequestLogger(logger, 20*time.Millisecond))
+	app.Get("/tasks/:id", func(c *fiber.Ctx) error {
+		if c.Params("id") == "slow" {
+			time.Sleep(50 * time.Millisecond)
+			return fiber.ErrNotFound
+		}
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	if _, err := app.Test(httptest.NewRequest("GET", "/tasks/fast", nil), -1); err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("expected no log for a fast request, got %s", buf.String())
+	}
+
+	if _, err := app.Test(httptest.NewRequest("GET", "/tasks/slow", nil), -1); err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	var entry struct {
+		Level    string `json:"level"`
+		Msg      string `json:"msg"`
+		Method   string `json:"method"`
+		Route    string `json:"route"`
+		Status   int    `json:"status"`
+		Duration int64  `json:"duration"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("decode log %q: %v", buf.String(), err)
+	}
+	if entry.Level != "WARN" || entry.Msg != "slow request" || entry.Method != "GET" || entry.Route != "/tasks/:id" || entry.Status != fiber.StatusNotFound {
+		t.Fatalf("unexpected log entry %+v", entry)
+	}
+	if time.Duration(entry.Duration) < 50*time.Millisecond {
+		t.Fatalf("expected a duration of at least 50ms, got %v", time.Duration(entry.Duration))
+	}
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"strings"
+
+	"backend/internal/pkg/identity"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// errSystemNotAllowed answers internal services calling a user route.
+var errSystemNotAllowed = fiber.NewError(fiber.StatusForbidden, "route not available to internal services")
+
+// RestrictSystem keeps callers with the system role to the routes under
+// allowedPrefixes, such as maintenance endpoints, and answers 403 on every
+// other route, so an internal service cannot create or change what users
+// see. Other callers are let through. It must run after AuthMiddleware.
+func RestrictSystem(allowedPrefixes ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !ClaimsOf(c).HasRole(identity.RoleSystem) {
+			return c.Next()
+		}
+		path := c.Path()
+		for _, p := range allowedPrefixes {
+			p = strings.TrimRight(p, "/")
+			if path == p || strings.HasPrefix(path, p+"/") {
+				return c.Next()
+			}
+		}
+		return errSystemNotAllowed
+	}
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	"backend/internal/pkg/identity"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// authFunc adapts a function to AuthService.
+type authFunc func(token string) (identity.Claims, error)
+
+func (f authFunc) VerifyToken(token string) (identity.Claims, error) {
+	return f(token)
+}
+
+// Test that a service token is kept off the standard task create route, so
+// it cannot create tasks users see, while it reaches the allowed maintenance
+// routes and user tokens are unaffected.
+func TestRestrictSystem(t *testing.T) {
+	tokens := authFunc(func(token string) (identity.Claims, error) {
+		if token == "service-token" {
+			return identity.Claims{UserID: identity.SystemUserID, Roles: []string{identity.RoleSystem}}, nil
+		}
+		return identity.Claims{UserID: "u1", TenantID: "t1", Roles: []string{identity.RoleMember}}, nil
+	})
+	created := 0
+	app := fiber.New()
+	app.Use(AuthMiddleware(tokens), RestrictSystem("/api/v1/admin"))
+	app.Post("/api/v1/tasks", func(c *fiber.Ctx) error {
+		created++
+		return c.SendStatus(fiber.StatusCreated)
+	})
+	app.Post("/api/v1/admin/prioritize/all", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+	app.Get("/api/v1/administrators", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+	cases := []struct {
+		token, method, path string
+		want                int
+	}{
+		{"service-token", "POST", "/api/v1/tasks", fiber.StatusForbidden},
+		{"service-token", "GET", "/api/v1/administrators", fiber.StatusForbidden},
+		{"service-token", "POST", "/api/v1/admin/prioritize/all", fiber.StatusOK},
+		{"user-token", "POST", "/api/v1/tasks", fiber.StatusCreated},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, tc.path, bytes.NewReader([]byte(`{"title":"from a worker"}`)))
+		req.Header.Set("Authorization", "Bearer "+tc.token)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		if resp.StatusCode != tc.want {
+			t.Fatalf("%s %s %s: expected status %d, got %d", tc.token, tc.method, tc.path, tc.want, resp.StatusCode)
+		}
+	}
+	if created != 1 {
+		t.Fatalf("expected only the user's task to be created, got %d", created)
+	}
+}
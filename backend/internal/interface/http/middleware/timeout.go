@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// HeaderRequestTimeout lets a client state its own deadline in milliseconds.
+const HeaderRequestTimeout = "X-Request-Timeout"
+
+// RequestTimeoutMiddleware honours a client-supplied X-Request-Timeout header
+// by attaching a deadline to the request's user context, capped at maxMs
+// (no cap when maxMs <= 0). Handlers must pass c.UserContext() to services
+// for the deadline to take effect. If the deadline has passed once the
+// handler returns, the response is replaced with 504 Gateway Timeout.
+func RequestTimeoutMiddleware(maxMs int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		raw := strings.TrimSpace(c.Get(HeaderRequestTimeout))
+		if raw == "" {
+			return c.Next()
+		}
+		ms, err := strconv.Atoi(raw)
+		if err != nil || ms <= 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid "+HeaderRequestTimeout+" header")
+		}
+		if maxMs > 0 && ms > maxMs {
+			ms = maxMs
+		}
+
+		ctx, cancel := context.WithTimeout(c.UserContext(), time.Duration(ms)*time.Millisecond)
+		defer cancel()
+		c.SetUserContext(ctx)
+
+		err = c.Next()
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fiber.ErrGatewayTimeout
+		}
+		return err
+	}
+}
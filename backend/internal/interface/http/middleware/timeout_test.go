@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func newTimeoutApp(maxMs int, sleep time.Duration) *fiber.App {
+	app := fiber.New()
+	app.Use(RequestTimeoutMiddleware(maxMs))
+	app.Get("/", func(c *fiber.Ctx) error {
+		time.Sleep(sleep)
+		return c.SendStatus(fiber.StatusOK)
+	})
+	return app
+}
+
+func doTimeoutRequest(t *testing.T, app *fiber.App, header string) int {
+	t.Helper()
+	req := httptest.NewRequest("GET", "/", nil)
+	if header != "" {
+		req.Header.Set(HeaderRequestTimeout, header)
+	}
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	return resp.StatusCode
+}
+
+// Test that a slow handler yields 504 when the client's timeout is shorter.
+func TestRequestTimeoutMiddleware_Exceeded(t *testing.T) {
+	app := newTimeoutApp(30000, 50*time.Millisecond)
+	if code := doTimeoutRequest(t, app, "10"); code != fiber.StatusGatewayTimeout {
+		t.Fatalf("expected status %d, got %d", fiber.StatusGatewayTimeout, code)
+	}
+}
+
+// Test that the request succeeds when the client's timeout is long enough,
+// and when no header is sent at all.
+func TestRequestTimeoutMiddleware_WithinDeadline(t *testing.T) {
+	app := newTimeoutApp(30000, 10*time.Millisecond)
+	for _, header := range []string{"1000", ""} {
+		if code := doTimeoutRequest(t, app, header); code != fiber.StatusOK {
+			t.Fatalf("header %q: expected status %d, got %d", header, fiber.StatusOK, code)
+		}
+	}
+}
+
+// Test that the client's timeout is capped at the configured maximum.
+func TestRequestTimeoutMiddleware_Capped(t *testing.T) {
+	app := newTimeoutApp(10, 50*time.Millisecond)
+	if code := doTimeoutRequest(t, app, "5000"); code != fiber.StatusGatewayTimeout {
+		t.Fatalf("expected status %d, got %d", fiber.StatusGatewayTimeout, code)
+	}
+}
+
+// Test that the handler's context carries the derived deadline.
+func TestRequestTimeoutMiddleware_SetsDeadline(t *testing.T) {
+	app := fiber.New()
+	app.Use(RequestTimeoutMiddleware(30000))
+	app.Get("/", func(c *fiber.Ctx) error {
+		if _, ok := c.UserContext().Deadline(); !ok {
+			t.Fatalf("expected a deadline on the user context")
+		}
+		return c.SendStatus(fiber.StatusOK)
+	})
+	if code := doTimeoutRequest(t, app, "500"); code != fiber.StatusOK {
+		t.Fatalf("expected status %d, got %d", fiber.StatusOK, code)
+	}
+}
+
+// Test that a malformed header is rejected.
+func TestRequestTimeoutMiddleware_Invalid(t *testing.T) {
+	app := newTimeoutApp(30000, 0)
+	for _, header := range []string{"soon", "-5", "0"} {
+		if code := doTimeoutRequest(t, app, header); code != fiber.StatusBadRequest {
+			t.Fatalf("header %q: expected status %d, got %d", header, fiber.StatusBadRequest, code)
+		}
+	}
+}
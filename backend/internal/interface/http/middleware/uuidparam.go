@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// RequireUUIDParams rejects a request with 400 Bad Request when any of the
+// named route params is not a UUID in its canonical 36-character form, so
+// malformed ids never reach a repository, where postgres would fail the uuid
+// cast. Well-formed ids that match nothing are left for the handler to 404.
+func RequireUUIDParams(names ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		for _, name := range names {
+			if !IsUUID(c.Params(name)) {
+				return fiber.NewError(fiber.StatusBadRequest, name+" must be a UUID")
+			}
+		}
+		return c.Next()
+	}
+}
+
+// IsUUID reports whether s is a UUID in its canonical 36-character form.
+func IsUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	_, err := uuid.Parse(s)
+	return err == nil
+}
@@ -0,0 +1,116 @@
+// Package paging holds the response envelope shared by list endpoints, so
+// every module pages the same way and can add metadata without breaking
+// clients.
+package paging
+
+import (
+    "net/url"
+    "strconv"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// HeaderTotalCount carries PagedResponse.Total, see Send.
+const HeaderTotalCount = "X-Total-Count"
+
+const (
+    // DefaultLimit is the page size when ?limit is absent.
+    DefaultLimit = 50
+    // MaxLimit caps ?limit.
+    MaxLimit = 200
+)
+
+// PagedResponse is one page of a list endpoint. NextCursor is the offset of
+// the following page, or null on the last page; it can be sent back as
+// ?cursor= in place of ?offset=.
+type PagedResponse[T any] struct {
+    Data       []T     `json:"data"`
+    Total      int64   `json:"total"`
+    Limit      int     `json:"limit"`
+    Offset     int     `json:"offset"`
+    NextCursor *string `json:"nextCursor"`
+}
+
+// Params is the requested window of a list.
+type Params struct {
+    Limit  int
+    Offset int
+}
+
+// FromQuery reads ?limit and ?offset (or ?cursor). A missing limit is
+// DefaultLimit; limits above MaxLimit are clamped. Non-numeric or negative
+// values are a 400.
+func FromQuery(c *fiber.Ctx) (Params, error) {
+    p := Params{Limit: DefaultLimit}
+    if v := c.Query("limit"); v != "" {
+        n, err := strconv.Atoi(v)
+        if err != nil || n < 1 {
+            return Params{}, fiber.NewError(fiber.StatusBadRequest, "limit must be a positive integer")
+        }
+        p.Limit = min(n, MaxLimit)
+    }
+    offset := c.Query("offset")
+    if cursor := c.Query("cursor"); cursor != "" {
+        offset = cursor
+    }
+    if offset != "" {
+        n, err := strconv.Atoi(offset)
+        if err != nil || n < 0 {
+            return Params{}, fiber.NewError(fiber.StatusBadRequest, "offset must be a non-negative integer")
+        }
+        p.Offset = n
+    }
+    return p, nil
+}
+
+// New wraps the page of items found at p in a list of total items.
+func New[T any](items []T, total int64, p Params) PagedResponse[T] {
+    res := PagedResponse[T]{Data: items, Total: total, Limit: p.Limit, Offset: p.Offset}
+    if res.Data == nil {
+        res.Data = []T{}
+    }
+    if next := p.Offset + len(items); len(items) > 0 && int64(next) < total {
+        cursor := strconv.Itoa(next)
+        res.NextCursor = &cursor
+    }
+    return res
+}
+
+// Slice pages an already loaded list.
+func Slice[T any](all []T, p Params) PagedResponse[T] {
+    start := min(p.Offset, len(all))
+    end := min(start+p.Limit, len(all))
+    return New(all[start:end], int64(len(all)), p)
+}
+
+// Send writes res as JSON together with an X-Total-Count header and an RFC
+// 8288 Link header, so generic clients can page without reading the body.
+// The Link header points at the first page and, where they exist, at the
+// previous and next ones; the URLs are the current request's with its
+// offset and limit replaced.
+func Send[T any](c *fiber.Ctx, res PagedResponse[T]) error {
+    c.Set(HeaderTotalCount, strconv.FormatInt(res.Total, 10))
+    links := []string{pageURL(c, 0, res.Limit), "first"}
+    if res.Offset > 0 {
+        links = append(links, pageURL(c, max(res.Offset-res.Limit, 0), res.Limit), "prev")
+    }
+    if res.NextCursor != nil {
+        next, _ := strconv.Atoi(*res.NextCursor)
+        links = append(links, pageURL(c, next, res.Limit), "next")
+    }
+    c.Links(links...)
+    return c.JSON(res)
+}
+
+// pageURL returns the current request's URL with its window replaced by
+// offset and limit.
+func pageURL(c *fiber.Ctx, offset, limit int) string {
+    q := url.Values{}
+    c.Context().QueryArgs().VisitAll(func(k, v []byte) {
+        q.Add(string(k), string(v))
+    })
+    q.Del("cursor")
+    q.Set("offset", strconv.Itoa(offset))
+    q.Set("limit", strconv.Itoa(limit))
+    return c.BaseURL() + c.Path() + "?" + q.Encode()
+}
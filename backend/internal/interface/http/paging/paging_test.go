@@ -0,0 +1,138 @@
+package paging
+
+import (
+    "encoding/json"
+    "net/http/httptest"
+    "strings"
+    "testing"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// Test that walking a five-item list two at a time fills every envelope
+// field and ends with a null cursor.
+func TestSlice_MultiPage(t *testing.T) {
+    all := []int{1, 2, 3, 4, 5}
+    cases := []struct {
+        offset int
+        data   []int
+        next   string
+    }{
+        {0, []int{1, 2}, "2"},
+        {2, []int{3, 4}, "4"},
+        {4, []int{5}, ""},
+        {9, []int{}, ""},
+    }
+    for _, tc := range cases {
+        got := Slice(all, Params{Limit: 2, Offset: tc.offset})
+        if got.Total != 5 || got.Limit != 2 || got.Offset != tc.offset {
+            t.Fatalf("offset %d: expected total 5, limit 2, offset %d, got %+v", tc.offset, tc.offset, got)
+        }
+        if len(got.Data) != len(tc.data) {
+            t.Fatalf("offset %d: expected data %v, got %v", tc.offset, tc.data, got.Data)
+        }
+        for i := range tc.data {
+            if got.Data[i] != tc.data[i] {
+                t.Fatalf("offset %d: expected data %v, got %v", tc.offset, tc.data, got.Data)
+            }
+        }
+        switch {
+        case tc.next == "" && got.NextCursor != nil:
+            t.Fatalf("offset %d: expected no next cursor, got %q", tc.offset, *got.NextCursor)
+        case tc.next != "" && (got.NextCursor == nil || *got.NextCursor != tc.next):
+            t.Fatalf("offset %d: expected next cursor %q, got %v", tc.offset, tc.next, got.NextCursor)
+        }
+    }
+}
+
+// Test that an empty page serializes data as [] and nextCursor as null.
+func TestNew_EmptyJSON(t *testing.T) {
+    b, err := json.Marshal(New[string](nil, 0, Params{Limit: DefaultLimit}))
+    if err != nil {
+        t.Fatalf("marshal: %v", err)
+    }
+    want := `{"data":[],"total":0,"limit":50,"offset":0,"nextCursor":null}`
+    if string(b) != want {
+        t.Fatalf("expected %s, got %s", want, b)
+    }
+}
+
+// Test the query parsing: defaults, clamping, cursor as offset and 400s.
+func TestFromQuery(t *testing.T) {
+    cases := []struct {
+        query  string
+        want   Params
+        status int
+    }{
+        {"", Params{Limit: DefaultLimit}, fiber.StatusOK},
+        {"?limit=10&offset=20", Params{Limit: 10, Offset: 20}, fiber.StatusOK},
+        {"?limit=1000", Params{Limit: MaxLimit}, fiber.StatusOK},
+        {"?limit=10&cursor=30", Params{Limit: 10, Offset: 30}, fiber.StatusOK},
+        {"?limit=0", Params{}, fiber.StatusBadRequest},
+        {"?offset=-1", Params{}, fiber.StatusBadRequest},
+        {"?limit=abc", Params{}, fiber.StatusBadRequest},
+    }
+    for _, tc := range cases {
+        var got Params
+        app := fiber.New()
+        app.Get("/", func(c *fiber.Ctx) error {
+            p, err := FromQuery(c)
+            if err != nil {
+                return err
+            }
+            got = p
+            return c.SendStatus(fiber.StatusOK)
+        })
+        resp, err := app.Test(httptest.NewRequest("GET", "/"+tc.query, nil), -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        if resp.StatusCode != tc.status {
+            t.Fatalf("%q: expected status %d, got %d", tc.query, tc.status, resp.StatusCode)
+        }
+        if tc.status == fiber.StatusOK && got != tc.want {
+            t.Fatalf("%q: expected %+v, got %+v", tc.query, tc.want, got)
+        }
+    }
+}
+
+// Test that Send sets X-Total-Count and a Link header with first, prev and
+// next URLs built from the request, and leaves out next on the last page.
+func TestSend_LinkHeader(t *testing.T) {
+    all := []int{1, 2, 3, 4, 5}
+    app := fiber.New()
+    app.Get("/items", func(c *fiber.Ctx) error {
+        p, err := FromQuery(c)
+        if err != nil {
+            return err
+        }
+        return Send(c, Slice(all, p))
+    })
+
+    resp, err := app.Test(httptest.NewRequest("GET", "http://api.test/items?status=todo&limit=2&cursor=2", nil), -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    if got := resp.Header.Get(HeaderTotalCount); got != "5" {
+        t.Fatalf("expected X-Total-Count 5, got %q", got)
+    }
+    link := resp.Header.Get(fiber.HeaderLink)
+    for _, want := range []string{
+        `<http://api.test/items?limit=2&offset=0&status=todo>; rel="first"`,
+        `<http://api.test/items?limit=2&offset=0&status=todo>; rel="prev"`,
+        `<http://api.test/items?limit=2&offset=4&status=todo>; rel="next"`,
+    } {
+        if !strings.Contains(link, want) {
+            t.Fatalf("expected Link to contain %s, got %s", want, link)
+        }
+    }
+
+    resp, err = app.Test(httptest.NewRequest("GET", "http://api.test/items?limit=2&offset=4", nil), -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    link = resp.Header.Get(fiber.HeaderLink)
+    if strings.Contains(link, `rel="next"`) || !strings.Contains(link, `<http://api.test/items?limit=2&offset=2>; rel="prev"`) {
+        t.Fatalf("expected prev and no next on the last page, got %s", link)
+    }
+}
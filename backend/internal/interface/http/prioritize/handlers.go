@@ -0,0 +1,436 @@
+package prioritize
+
+import (
+    "context"
+    "errors"
+    "math"
+    "slices"
+    "strconv"
+    "strings"
+    "time"
+
+    appprioritize "backend/internal/application/prioritize"
+    apptask "backend/internal/application/task"
+    domaintask "backend/internal/domain/task"
+    "backend/internal/interface/http/middleware"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// maxTasks bounds how many tasks a single prioritize request scores.
+const maxTasks = 500
+
+type Handlers struct {
+    svc   *appprioritize.Service
+    tasks *apptask.Service
+    // maxAll is the hard cap on tasks scored by one /all run.
+    maxAll   int
+    pageSize int
+}
+
+// NewHandlers returns handlers whose /all run scores at most maxAll tasks
+// (appprioritize.DefaultMaxTasks when maxAll <= 0).
+func NewHandlers(svc *appprioritize.Service, tasks *apptask.Service, maxAll int) *Handlers {
+    if maxAll <= 0 {
+        maxAll = appprioritize.DefaultMaxTasks
+    }
+    if svc != nil {
+        svc = svc.WithTasks(tasks)
+    }
+    return &Handlers{svc: svc, tasks: tasks, maxAll: maxAll, pageSize: appprioritize.DefaultPageSize}
+}
+
+type prioritizeRequest struct {
+    TaskIDs []string `json:"taskIds"`
+    // Weights, when set, rank by RankByDeadlineAndPriority instead.
+    Weights *appprioritize.PriorityWeights `json:"weights"`
+}
+
+// weightedResponse is the answer to a prioritize request with weights.
+type weightedResponse struct {
+    Results []appprioritize.RankedTask `json:"results"`
+    Missing []string                   `json:"missing"`
+}
+
+type scoredTask struct {
+    TaskID       string                      `json:"taskId"`
+    Score        float64                     `json:"score"`
+    Reasons      []string                    `json:"reasons"`
+    Explanations []appprioritize.Explanation `json:"explanations"`
+    Rationale    string                      `json:"rationale,omitempty"`
+}
+
+type prioritizeResponse struct {
+    Results []scoredTask `json:"results"`
+    Missing []string     `json:"missing"`
+    // Scoring reports AI provider batches and the tasks that fell back to
+    // the rules.
+    Scoring appprioritize.ScoringReport `json:"scoring"`
+    // ComputedAt is when the scores were computed; cached responses keep
+    // the time of the original run.
+    ComputedAt time.Time `json:"computedAt"`
+}
+
+type prioritizeAllResponse struct {
+    appprioritize.RunSummary
+    ComputedAt time.Time `json:"computedAt"`
+}
+
+// settingsBody carries a tenant's weights, matrix thresholds and scheduling.
+// On PUT, omitted fields keep their current value and lastAutoRunAt is
+// ignored.
+type settingsBody struct {
+    PriorityWeight    *float64 `json:"priorityWeight"`
+    DueDateWeight     *float64 `json:"dueDateWeight"`
+    AgeWeight         *float64 `json:"ageWeight"`
+    StatusWeight      *float64 `json:"statusWeight"`
+    AIWeight          *float64 `json:"aiWeight"`
+    UrgentWithinHours *int     `json:"urgentWithinHours"`
+    ImportantPriority *int     `json:"importantPriority"`
+    AutoPrioritize    *bool    `json:"autoPrioritize"`
+    // LastAutoRunAt is when the scheduler last re-prioritized the tenant.
+    LastAutoRunAt *time.Time `json:"lastAutoRunAt"`
+}
+
+func toSettingsBody(st appprioritize.Settings) settingsBody {
+    hours := int(st.Thresholds.UrgentWithin / time.Hour)
+    return settingsBody{
+        PriorityWeight:    &st.Weights.Priority,
+        DueDateWeight:     &st.Weights.DueDate,
+        AgeWeight:         &st.Weights.Age,
+        StatusWeight:      &st.Weights.Status,
+        AIWeight:          &st.Weights.AI,
+        UrgentWithinHours: &hours,
+        ImportantPriority: &st.Thresholds.ImportantPriority,
+        AutoPrioritize:    &st.AutoPrioritize,
+        LastAutoRunAt:     st.LastAutoRunAt,
+    }
+}
+
+type matrixResponse struct {
+    appprioritize.Matrix
+    // Truncated is true when only the first maxAll open tasks were classified.
+    Truncated bool `json:"truncated"`
+}
+
+func tenantOf(c *fiber.Ctx) string {
+    return middleware.ClaimsOf(c).TenantID
+}
+
+// prioritize scores the requested tasks, or every open task (up to maxTasks)
+// when no ids are given, stores each score on its task and returns them
+// sorted by descending score. A cached result for the same ids is returned
+// instead while fresh, unless ?refresh=true. With weights in the body the
+// tasks are ranked by deadline, priority and status alone, and the scores
+// are neither cached nor stored. With ?dryRun=true the scores are compared
+// with the stored ones instead of replacing them (see dryRunResponse).
+func (h *Handlers) prioritize(c *fiber.Ctx) error {
+    var req prioritizeRequest
+    if err := c.BodyParser(&req); err != nil {
+        return fiber.ErrBadRequest
+    }
+    if len(req.TaskIDs) > maxTasks {
+        return fiber.NewError(fiber.StatusBadRequest, "too many taskIds")
+    }
+    if req.Weights != nil {
+        return h.prioritizeWeighted(c, req)
+    }
+    if c.QueryBool("dryRun") {
+        return h.prioritizeDryRun(c, req)
+    }
+
+    ctx := c.UserContext()
+    tenantID := tenantOf(c)
+    v, computedAt, err := h.svc.Cached(tenantID, rankKey(req.TaskIDs), c.QueryBool("refresh"), func() (any, error) {
+        ranked, missing, report, err := h.rank(ctx, tenantID, req.TaskIDs)
+        if err != nil {
+            return nil, err
+        }
+        res := prioritizeResponse{Results: make([]scoredTask, 0, len(ranked)), Missing: missing, Scoring: report}
+        scores := make(map[string]float64, len(ranked))
+        for _, r := range ranked {
+            res.Results = append(res.Results, scoredTask{TaskID: r.Task.ID, Score: r.Score, Reasons: r.Reasons, Explanations: r.Explanations, Rationale: r.Rationale})
+            scores[r.Task.ID] = r.Score
+        }
+        // Persisting is best effort: the ranking is still useful if the write
+        // fails, and the service logs the failure.
+        _ = h.tasks.UpdateAIScores(ctx, tenantID, scores)
+        return res, nil
+    })
+    if err != nil {
+        return fiber.ErrInternalServerError
+    }
+    res := v.(prioritizeResponse)
+    res.ComputedAt = computedAt
+    return c.JSON(res)
+}
+
+// rank scores the tasks a prioritize request selects with the tenant's
+// settings.
+func (h *Handlers) rank(ctx context.Context, tenantID string, ids []string) ([]appprioritize.Ranked, []string, appprioritize.ScoringReport, error) {
+    svc, err := h.svc.ForTenant(ctx, tenantID)
+    if err != nil {
+        return nil, nil, appprioritize.ScoringReport{}, err
+    }
+    selected, missing, err := h.selectTasks(ctx, tenantID, ids)
+    if err != nil {
+        return nil, nil, appprioritize.ScoringReport{}, err
+    }
+    ranked, report := svc.RankWithReport(ctx, selected)
+    return ranked, missing, report, nil
+}
+
+// dryRunResponse previews a prioritize request: how each task's stored score
+// would change, biggest change first, and how many tasks would move by more
+// than the threshold.
+type dryRunResponse struct {
+    Diffs      []appprioritize.ScoreDiff   `json:"diffs"`
+    Summary    appprioritize.DiffSummary   `json:"summary"`
+    Missing    []string                    `json:"missing"`
+    Scoring    appprioritize.ScoringReport `json:"scoring"`
+    ComputedAt time.Time                   `json:"computedAt"`
+}
+
+// prioritizeDryRun scores like prioritize but stores and caches nothing.
+func (h *Handlers) prioritizeDryRun(c *fiber.Ctx, req prioritizeRequest) error {
+    threshold, err := diffThreshold(c)
+    if err != nil {
+        return err
+    }
+    ranked, missing, report, err := h.rank(c.UserContext(), tenantOf(c), req.TaskIDs)
+    if err != nil {
+        return fiber.ErrInternalServerError
+    }
+    diffs := appprioritize.Diff(ranked)
+    appprioritize.SortDiffs(diffs)
+    return c.JSON(dryRunResponse{
+        Diffs:      diffs,
+        Summary:    appprioritize.SummarizeDiffs(diffs, threshold),
+        Missing:    missing,
+        Scoring:    report,
+        ComputedAt: time.Now().UTC(),
+    })
+}
+
+// diffThreshold reads ?threshold=, the move in points a dry run's summary
+// counts, defaulting to appprioritize.DefaultDiffThreshold.
+func diffThreshold(c *fiber.Ctx) (float64, error) {
+    v := c.Query("threshold")
+    if v == "" {
+        return appprioritize.DefaultDiffThreshold, nil
+    }
+    threshold, err := strconv.ParseFloat(v, 64)
+    if err != nil || threshold < 0 || math.IsNaN(threshold) || math.IsInf(threshold, 0) {
+        return 0, fiber.NewError(fiber.StatusBadRequest, "threshold must be a non-negative number")
+    }
+    return threshold, nil
+}
+
+// prioritizeWeighted answers a prioritize request that carries weights.
+func (h *Handlers) prioritizeWeighted(c *fiber.Ctx, req prioritizeRequest) error {
+    ranked, err := h.svc.RankByDeadlineAndPriority(c.UserContext(), tenantOf(c), req.TaskIDs, *req.Weights)
+    if errors.Is(err, appprioritize.ErrInvalidWeights) {
+        return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+    }
+    if err != nil {
+        return fiber.ErrInternalServerError
+    }
+    found := make(map[string]bool, len(ranked))
+    for _, r := range ranked {
+        found[r.TaskID] = true
+    }
+    res := weightedResponse{Results: ranked, Missing: []string{}}
+    for _, id := range req.TaskIDs {
+        if !found[id] && !slices.Contains(res.Missing, id) {
+            res.Missing = append(res.Missing, id)
+        }
+    }
+    return c.JSON(res)
+}
+
+// rankKey is the cache key of a prioritize request: its distinct ids in
+// sorted order, so the same selection hits regardless of order.
+func rankKey(ids []string) string {
+    sorted := slices.Clone(ids)
+    slices.Sort(sorted)
+    return "rank:" + strings.Join(slices.Compact(sorted), ",")
+}
+
+// prioritizeAll scores every open task of the tenant page by page, storing
+// each page's scores before loading the next, and returns summary statistics.
+// At most maxAll tasks are scored, and a tenant can only have one run at a
+// time, scheduled or not; a second concurrent call gets 409. While the last
+// run's summary is fresh in the cache it is returned without rescoring,
+// unless ?refresh=true. With ?dryRun=true nothing is stored or cached and the
+// response adds the per-task diffs and their summary.
+func (h *Handlers) prioritizeAll(c *fiber.Ctx) error {
+    tenantID := tenantOf(c)
+    if c.QueryBool("dryRun") {
+        threshold, err := diffThreshold(c)
+        if err != nil {
+            return err
+        }
+        res, err := h.runAll(c.UserContext(), tenantID, true, threshold)
+        if err != nil {
+            return err
+        }
+        res.ComputedAt = time.Now().UTC()
+        return c.JSON(res)
+    }
+    v, computedAt, err := h.svc.Cached(tenantID, "all", c.QueryBool("refresh"), func() (any, error) {
+        return h.runAll(c.UserContext(), tenantID, false, 0)
+    })
+    if err != nil {
+        return err
+    }
+    res := v.(prioritizeAllResponse)
+    res.ComputedAt = computedAt
+    return c.JSON(res)
+}
+
+// runAll does the work of prioritizeAll, returning Fiber errors.
+func (h *Handlers) runAll(ctx context.Context, tenantID string, dryRun bool, threshold float64) (prioritizeAllResponse, error) {
+    res, err := h.svc.RunAll(ctx, tenantID, h.tasks, appprioritize.RunOptions{MaxTasks: h.maxAll, PageSize: h.pageSize, DryRun: dryRun, DiffThreshold: threshold})
+    switch {
+    case errors.Is(err, appprioritize.ErrRunInProgress):
+        return prioritizeAllResponse{}, fiber.NewError(fiber.StatusConflict, err.Error())
+    case err != nil:
+        return prioritizeAllResponse{}, fiber.ErrInternalServerError
+    }
+    return prioritizeAllResponse{RunSummary: res}, nil
+}
+
+// matrix buckets the tenant's open tasks into the Eisenhower quadrants using
+// the tenant's thresholds, which the urgentWithinHours and importantPriority
+// query parameters override. Open tasks are loaded page by page, at most
+// maxAll of them.
+func (h *Handlers) matrix(c *fiber.Ctx) error {
+    ctx := c.UserContext()
+    tenantID := tenantOf(c)
+    svc, err := h.svc.ForTenant(ctx, tenantID)
+    if err != nil {
+        return fiber.ErrInternalServerError
+    }
+    if v := c.Query("urgentWithinHours"); v != "" {
+        hours, err := strconv.Atoi(v)
+        if err != nil {
+            return fiber.NewError(fiber.StatusBadRequest, "urgentWithinHours must be an integer")
+        }
+        svc.Thresholds.UrgentWithin = time.Duration(hours) * time.Hour
+    }
+    if v := c.Query("importantPriority"); v != "" {
+        p, err := strconv.Atoi(v)
+        if err != nil {
+            return fiber.NewError(fiber.StatusBadRequest, "importantPriority must be an integer")
+        }
+        svc.Thresholds.ImportantPriority = p
+    }
+    if err := svc.Thresholds.Validate(); err != nil {
+        return fiber.NewError(fiber.StatusBadRequest, err.Error())
+    }
+
+    var open []domaintask.Task
+    truncated := false
+    afterID := ""
+    for {
+        limit := min(h.pageSize, h.maxAll-len(open))
+        if limit <= 0 {
+            more, err := h.tasks.ListOpenPage(ctx, tenantID, afterID, 1)
+            if err != nil {
+                return fiber.ErrInternalServerError
+            }
+            truncated = len(more) > 0
+            break
+        }
+        page, err := h.tasks.ListOpenPage(ctx, tenantID, afterID, limit)
+        if err != nil {
+            return fiber.ErrInternalServerError
+        }
+        open = append(open, page...)
+        if len(page) < limit {
+            break
+        }
+        afterID = page[len(page)-1].ID
+    }
+    return c.JSON(matrixResponse{Matrix: svc.Matrix(open), Truncated: truncated})
+}
+
+// getSettings returns the tenant's settings, or the defaults when it has none.
+func (h *Handlers) getSettings(c *fiber.Ctx) error {
+    st, err := h.svc.TenantSettings(c.UserContext(), tenantOf(c))
+    if err != nil {
+        return fiber.ErrInternalServerError
+    }
+    return c.JSON(toSettingsBody(st))
+}
+
+// putSettings stores the tenant's settings with the weights normalized so the
+// rule weights sum to one. Stored aiScore values change only on the next
+// prioritization run.
+func (h *Handlers) putSettings(c *fiber.Ctx) error {
+    var req settingsBody
+    if err := c.BodyParser(&req); err != nil {
+        return fiber.ErrBadRequest
+    }
+    st, err := h.svc.TenantSettings(c.UserContext(), tenantOf(c))
+    if err != nil {
+        return fiber.ErrInternalServerError
+    }
+    w := &st.Weights
+    for dst, src := range map[*float64]*float64{
+        &w.Priority: req.PriorityWeight,
+        &w.DueDate:  req.DueDateWeight,
+        &w.Age:      req.AgeWeight,
+        &w.Status:   req.StatusWeight,
+        &w.AI:       req.AIWeight,
+    } {
+        if src != nil {
+            *dst = *src
+        }
+    }
+    if req.UrgentWithinHours != nil {
+        st.Thresholds.UrgentWithin = time.Duration(*req.UrgentWithinHours) * time.Hour
+    }
+    if req.ImportantPriority != nil {
+        st.Thresholds.ImportantPriority = *req.ImportantPriority
+    }
+    if req.AutoPrioritize != nil {
+        st.AutoPrioritize = *req.AutoPrioritize
+    }
+    saved, err := h.svc.SaveTenantSettings(c.UserContext(), tenantOf(c), st)
+    switch {
+    case errors.Is(err, appprioritize.ErrInvalidWeights), errors.Is(err, appprioritize.ErrInvalidThresholds):
+        return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+    case errors.Is(err, appprioritize.ErrSettingsUnavailable):
+        return fiber.NewError(fiber.StatusNotImplemented, err.Error())
+    case err != nil:
+        return fiber.ErrInternalServerError
+    }
+    return c.JSON(toSettingsBody(saved))
+}
+
+// selectTasks loads the tasks named by ids in one query, reporting unknown
+// ids as missing. With no ids it loads the first maxTasks open tasks.
+func (h *Handlers) selectTasks(ctx context.Context, tenantID string, ids []string) (selected []domaintask.Task, missing []string, err error) {
+    missing = []string{}
+    if len(ids) == 0 {
+        selected, err = h.tasks.ListOpenPage(ctx, tenantID, "", maxTasks)
+        return selected, missing, err
+    }
+    selected, err = h.tasks.GetMany(ctx, tenantID, ids)
+    if err != nil {
+        return nil, nil, err
+    }
+    found := make(map[string]bool, len(selected))
+    for _, t := range selected {
+        found[t.ID] = true
+    }
+    for _, id := range ids {
+        if !found[id] {
+            // Mark reported ids found so duplicates are listed once
+            found[id] = true
+            missing = append(missing, id)
+        }
+    }
+    return selected, missing, nil
+}
@@ -0,0 +1,585 @@
+package prioritize
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "errors"
+    "math"
+    "net/http/httptest"
+    "testing"
+    "time"
+
+    appprioritize "backend/internal/application/prioritize"
+    apptask "backend/internal/application/task"
+    domaintask "backend/internal/domain/task"
+    "backend/internal/infrastructure/memory"
+    "backend/internal/interface/http/middleware"
+    "backend/internal/pkg/identity"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// newTestApp mounts the prioritize routes over an in-memory repository seeded
+// with tasks, behind a stub that sets the tenant local.
+func newTestApp(t *testing.T, seed ...*domaintask.Task) *fiber.App {
+    t.Helper()
+    app, _ := newTestAppWithRepo(t, seed...)
+    return app
+}
+
+// newTestAppWithRepo is newTestApp that also returns the backing repository.
+func newTestAppWithRepo(t *testing.T, seed ...*domaintask.Task) (*fiber.App, *memory.TaskRepository) {
+    t.Helper()
+    repo := memory.NewTaskRepository()
+    for _, tk := range seed {
+        if err := repo.Create(context.Background(), tk); err != nil {
+            t.Fatalf("seed: %v", err)
+        }
+    }
+    app := fiber.New()
+    app.Use(func(c *fiber.Ctx) error {
+        middleware.SetClaims(c, identity.Claims{TenantID: "t1"})
+        return c.Next()
+    })
+    svc := appprioritize.NewService().WithSettings(memory.NewPrioritizeSettingsRepository())
+    RegisterRoutes(app.Group("/prioritize"), svc, apptask.NewService(repo), maxTasks)
+    return app, repo
+}
+
+func postPrioritize(t *testing.T, app *fiber.App, body any) prioritizeResponse {
+    t.Helper()
+    b, _ := json.Marshal(body)
+    req := httptest.NewRequest("POST", "/prioritize", bytes.NewReader(b))
+    req.Header.Set("Content-Type", "application/json")
+    resp, err := app.Test(req, -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    if resp.StatusCode != fiber.StatusOK {
+        t.Fatalf("expected status %d, got %d", fiber.StatusOK, resp.StatusCode)
+    }
+    var out prioritizeResponse
+    if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+    return out
+}
+
+func newTask(tenantID string, priority int, status string) *domaintask.Task {
+    tk := domaintask.New(tenantID, "u1", "task", "", priority)
+    tk.Status = status
+    return tk
+}
+
+// Test that requested tasks come back sorted by score, unknown and
+// cross-tenant ids are reported as missing.
+func TestHandlers_Prioritize_ByIDs(t *testing.T) {
+    low := newTask("t1", 2, domaintask.StatusTodo)
+    high := newTask("t1", 9, domaintask.StatusTodo)
+    due := time.Now().Add(-time.Hour)
+    high.DueDate = &due
+    foreign := newTask("t2", 10, domaintask.StatusTodo)
+    app := newTestApp(t, low, high, foreign)
+
+    out := postPrioritize(t, app, map[string]any{"taskIds": []string{low.ID, high.ID, foreign.ID, "nope"}})
+    if len(out.Results) != 2 {
+        t.Fatalf("expected 2 results, got %+v", out.Results)
+    }
+    if out.Results[0].TaskID != high.ID || out.Results[1].TaskID != low.ID {
+        t.Fatalf("expected high before low, got %+v", out.Results)
+    }
+    if out.Results[0].Score <= out.Results[1].Score || len(out.Results[0].Reasons) == 0 || len(out.Results[0].Explanations) == 0 {
+        t.Fatalf("unexpected scores %+v", out.Results)
+    }
+    if len(out.Missing) != 2 || out.Missing[0] != foreign.ID || out.Missing[1] != "nope" {
+        t.Fatalf("expected foreign and unknown ids missing, got %v", out.Missing)
+    }
+}
+
+// Test that an empty id list scores every open task and skips finished ones.
+func TestHandlers_Prioritize_AllOpen(t *testing.T) {
+    open := newTask("t1", 5, domaintask.StatusTodo)
+    doing := newTask("t1", 5, domaintask.StatusInProgress)
+    done := newTask("t1", 5, domaintask.StatusDone)
+    app := newTestApp(t, open, doing, done)
+
+    out := postPrioritize(t, app, map[string]any{"taskIds": []string{}})
+    if len(out.Results) != 2 {
+        t.Fatalf("expected 2 open tasks scored, got %+v", out.Results)
+    }
+    for _, r := range out.Results {
+        if r.TaskID == done.ID {
+            t.Fatalf("done task should not be scored")
+        }
+    }
+    if len(out.Missing) != 0 {
+        t.Fatalf("expected no missing ids, got %v", out.Missing)
+    }
+}
+
+// Test that a prioritization run stores each score on its task.
+func TestHandlers_Prioritize_PersistsScores(t *testing.T) {
+    tk := newTask("t1", 7, domaintask.StatusTodo)
+    app, repo := newTestAppWithRepo(t, tk)
+
+    out := postPrioritize(t, app, map[string]any{"taskIds": []string{tk.ID}})
+    got, err := repo.Get(context.Background(), "t1", tk.ID)
+    if err != nil {
+        t.Fatalf("get: %v", err)
+    }
+    if got.AiScore == nil || *got.AiScore != out.Results[0].Score {
+        t.Fatalf("expected stored score %v, got %v", out.Results[0].Score, got.AiScore)
+    }
+}
+
+// Test that a repeated prioritize call is served from cache with the
+// original computedAt, that ?refresh=true and a task change recompute, and
+// that /all caches its summary the same way.
+func TestHandlers_Prioritize_Cached(t *testing.T) {
+    repo := memory.NewTaskRepository()
+    tk := newTask("t1", 3, domaintask.StatusTodo)
+    repo.Create(context.Background(), tk)
+    cache := appprioritize.NewCache(time.Hour)
+    tasks := apptask.NewService(repo, apptask.WithScoreCache(cache))
+    now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+    svc := appprioritize.NewService().WithCache(cache)
+    svc.Now = func() time.Time { return now }
+    app := fiber.New()
+    app.Use(func(c *fiber.Ctx) error {
+        middleware.SetClaims(c, identity.Claims{TenantID: "t1"})
+        return c.Next()
+    })
+    RegisterRoutes(app.Group("/prioritize"), svc, tasks, maxTasks)
+    post := func(query string) prioritizeResponse {
+        req := httptest.NewRequest("POST", "/prioritize"+query, bytes.NewReader([]byte(`{}`)))
+        req.Header.Set("Content-Type", "application/json")
+        resp, err := app.Test(req, -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        var out prioritizeResponse
+        if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+            t.Fatalf("decode: %v", err)
+        }
+        return out
+    }
+
+    first := post("")
+    computed := now
+    now = now.Add(time.Minute)
+    if got := post(""); !got.ComputedAt.Equal(computed) || got.Results[0].Score != first.Results[0].Score {
+        t.Fatalf("expected cached result from %v, got %+v", computed, got)
+    }
+    if got := post("?refresh=true"); !got.ComputedAt.Equal(now) {
+        t.Fatalf("expected refresh to recompute at %v, got %v", now, got.ComputedAt)
+    }
+
+    now = now.Add(time.Minute)
+    high := 10
+    if _, err := tasks.Update(context.Background(), "t1", tk.ID, apptask.Actor{UserID: "u1"}, apptask.UpdateTaskInput{Priority: &high}); err != nil {
+        t.Fatalf("update: %v", err)
+    }
+    got := post("")
+    if !got.ComputedAt.Equal(now) || got.Results[0].Score <= first.Results[0].Score {
+        t.Fatalf("expected a recomputed, higher score after the update, got %+v", got)
+    }
+
+    _, all := postAll(t, app)
+    now = now.Add(time.Minute)
+    if _, again := postAll(t, app); !again.ComputedAt.Equal(all.ComputedAt) || again.Scored != all.Scored {
+        t.Fatalf("expected cached /all summary %+v, got %+v", all, again)
+    }
+}
+
+// Test that weights in the body rank by deadline and priority without
+// storing scores, and that weights not summing to 1 get 422.
+func TestHandlers_Prioritize_Weights(t *testing.T) {
+    low := newTask("t1", 2, domaintask.StatusTodo)
+    high := newTask("t1", 9, domaintask.StatusTodo)
+    app, repo := newTestAppWithRepo(t, low, high)
+
+    out := postPrioritize(t, app, map[string]any{
+        "taskIds": []string{low.ID, high.ID, "ghost"},
+        "weights": map[string]float64{"priority": 0.5, "dueDate": 0.3, "status": 0.2},
+    })
+    if len(out.Results) != 2 || out.Results[0].TaskID != high.ID || out.Results[0].Score <= out.Results[1].Score {
+        t.Fatalf("expected high before low, got %+v", out.Results)
+    }
+    if len(out.Missing) != 1 || out.Missing[0] != "ghost" {
+        t.Fatalf("expected ghost missing, got %v", out.Missing)
+    }
+    if got, _ := repo.Get(context.Background(), "t1", high.ID); got.AiScore != nil {
+        t.Fatalf("expected weighted ranking not to store scores, got %v", *got.AiScore)
+    }
+
+    b, _ := json.Marshal(map[string]any{"weights": map[string]float64{"priority": 0.5}})
+    req := httptest.NewRequest("POST", "/prioritize", bytes.NewReader(b))
+    req.Header.Set("Content-Type", "application/json")
+    resp, err := app.Test(req, -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    if resp.StatusCode != fiber.StatusUnprocessableEntity {
+        t.Fatalf("expected status %d, got %d", fiber.StatusUnprocessableEntity, resp.StatusCode)
+    }
+}
+
+// failingProvider always fails.
+type failingProvider struct{}
+
+func (failingProvider) ScoreTasks(context.Context, []appprioritize.TaskSummary) ([]appprioritize.ScoredTask, error) {
+    return nil, errors.New("rate limited")
+}
+
+// Test that tasks whose AI batch failed are listed in the scoring report.
+func TestHandlers_Prioritize_ReportsFallback(t *testing.T) {
+    tk := newTask("t1", 5, domaintask.StatusTodo)
+    repo := memory.NewTaskRepository()
+    repo.Create(context.Background(), tk)
+    svc := appprioritize.NewService().WithProvider(failingProvider{}).WithBatching(appprioritize.Batching{Size: 10, Concurrency: 1})
+    app := fiber.New()
+    app.Use(func(c *fiber.Ctx) error {
+        middleware.SetClaims(c, identity.Claims{TenantID: "t1"})
+        return c.Next()
+    })
+    RegisterRoutes(app.Group("/prioritize"), svc, apptask.NewService(repo), maxTasks)
+
+    out := postPrioritize(t, app, map[string]any{})
+    if out.Scoring.FailedBatches != 1 || len(out.Scoring.Fallback) != 1 || out.Scoring.Fallback[0] != tk.ID {
+        t.Fatalf("expected %s reported as fallback, got %+v", tk.ID, out.Scoring)
+    }
+}
+
+// newAllApp mounts handlers with a small page size so /all spans pages.
+func newAllApp(svc *appprioritize.Service, repo *memory.TaskRepository, maxAll int) *fiber.App {
+    h := NewHandlers(svc, apptask.NewService(repo), maxAll)
+    h.pageSize = 2
+    app := fiber.New()
+    app.Use(func(c *fiber.Ctx) error {
+        middleware.SetClaims(c, identity.Claims{TenantID: "t1"})
+        return c.Next()
+    })
+    h.Register(app.Group("/prioritize"))
+    return app
+}
+
+func postAll(t *testing.T, app *fiber.App) (int, prioritizeAllResponse) {
+    t.Helper()
+    resp, err := app.Test(httptest.NewRequest("POST", "/prioritize/all", nil), -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    var out prioritizeAllResponse
+    if resp.StatusCode == fiber.StatusOK {
+        if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+            t.Fatalf("decode: %v", err)
+        }
+    }
+    return resp.StatusCode, out
+}
+
+// Test that /all walks every page, stores scores, reports statistics and
+// stops at the cap.
+func TestHandlers_PrioritizeAll(t *testing.T) {
+    repo := memory.NewTaskRepository()
+    for _, tk := range []*domaintask.Task{
+        newTask("t1", 1, domaintask.StatusTodo),
+        newTask("t1", 4, domaintask.StatusTodo),
+        newTask("t1", 6, domaintask.StatusInProgress),
+        newTask("t1", 8, domaintask.StatusTodo),
+        newTask("t1", 10, domaintask.StatusTodo),
+        newTask("t1", 10, domaintask.StatusDone),
+    } {
+        repo.Create(context.Background(), tk)
+    }
+
+    status, out := postAll(t, newAllApp(appprioritize.NewService(), repo, 10))
+    if status != fiber.StatusOK {
+        t.Fatalf("expected status %d, got %d", fiber.StatusOK, status)
+    }
+    if out.Scored != 5 || out.Truncated {
+        t.Fatalf("expected 5 scored and not truncated, got %+v", out)
+    }
+    if out.Min <= 0 || out.Max < out.Mean || out.Mean < out.Min {
+        t.Fatalf("inconsistent statistics %+v", out)
+    }
+    items, _ := repo.ListByTenant(context.Background(), "t1")
+    for _, tk := range items {
+        if (tk.AiScore == nil) != (tk.Status == domaintask.StatusDone) {
+            t.Fatalf("task %s (%s): unexpected aiScore %v", tk.ID, tk.Status, tk.AiScore)
+        }
+    }
+
+    _, out = postAll(t, newAllApp(appprioritize.NewService(), repo, 3))
+    if out.Scored != 3 || !out.Truncated {
+        t.Fatalf("expected 3 scored and truncated, got %+v", out)
+    }
+}
+
+// blockingProvider holds ScoreTasks until release is closed. entered is
+// buffered so runs after the release don't block on it.
+type blockingProvider struct {
+    entered chan struct{}
+    release chan struct{}
+}
+
+func (p *blockingProvider) ScoreTasks(context.Context, []appprioritize.TaskSummary) ([]appprioritize.ScoredTask, error) {
+    p.entered <- struct{}{}
+    <-p.release
+    return nil, nil
+}
+
+// Test that a second /all for the same tenant is refused while one runs.
+func TestHandlers_PrioritizeAll_Concurrent(t *testing.T) {
+    repo := memory.NewTaskRepository()
+    repo.Create(context.Background(), newTask("t1", 5, domaintask.StatusTodo))
+    p := &blockingProvider{entered: make(chan struct{}, 2), release: make(chan struct{})}
+    app := newAllApp(appprioritize.NewService().WithProvider(p), repo, 10)
+
+    done := make(chan int)
+    go func() {
+        status, _ := postAll(t, app)
+        done <- status
+    }()
+    <-p.entered
+
+    if status, _ := postAll(t, app); status != fiber.StatusConflict {
+        t.Fatalf("expected status %d, got %d", fiber.StatusConflict, status)
+    }
+    close(p.release)
+    if status := <-done; status != fiber.StatusOK {
+        t.Fatalf("expected first run to finish with %d, got %d", fiber.StatusOK, status)
+    }
+    if status, _ := postAll(t, app); status != fiber.StatusOK {
+        t.Fatalf("expected a new run to be allowed, got %d", status)
+    }
+}
+
+// Test that dry runs of both endpoints report diffs biggest first without
+// storing scores, and reject a bad threshold.
+func TestHandlers_Prioritize_DryRun(t *testing.T) {
+    low, high := 10.0, 90.0
+    a := newTask("t1", 9, domaintask.StatusTodo)
+    a.AiScore = &low
+    b := newTask("t1", 2, domaintask.StatusTodo)
+    b.AiScore = &high
+    app, repo := newTestAppWithRepo(t, a, b)
+
+    post := func(path string) (int, dryRunResponse) {
+        req := httptest.NewRequest("POST", path, bytes.NewReader([]byte(`{}`)))
+        req.Header.Set("Content-Type", "application/json")
+        resp, err := app.Test(req, -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        var out dryRunResponse
+        if resp.StatusCode == fiber.StatusOK {
+            if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+                t.Fatalf("decode: %v", err)
+            }
+        }
+        return resp.StatusCode, out
+    }
+
+    for _, path := range []string{"/prioritize?dryRun=true&threshold=1", "/prioritize/all?dryRun=true&threshold=1"} {
+        status, out := post(path)
+        if status != fiber.StatusOK {
+            t.Fatalf("%s: expected status %d, got %d", path, fiber.StatusOK, status)
+        }
+        if len(out.Diffs) != 2 || math.Abs(out.Diffs[0].Delta) < math.Abs(out.Diffs[1].Delta) {
+            t.Fatalf("%s: expected 2 diffs biggest first, got %+v", path, out.Diffs)
+        }
+        for _, d := range out.Diffs {
+            if math.Abs(d.Delta-(d.ProposedScore-*d.CurrentScore)) > 0.01 {
+                t.Fatalf("%s: inconsistent diff %+v", path, d)
+            }
+        }
+        if out.Summary.MovedUp != 1 || out.Summary.MovedDown != 1 || out.Summary.Threshold != 1 {
+            t.Fatalf("%s: expected one task up and one down, got %+v", path, out.Summary)
+        }
+        for _, tk := range []*domaintask.Task{a, b} {
+            stored, _ := repo.Get(context.Background(), "t1", tk.ID)
+            if *stored.AiScore != *tk.AiScore {
+                t.Fatalf("%s: expected stored score %v kept, got %v", path, *tk.AiScore, *stored.AiScore)
+            }
+        }
+    }
+    if status, _ := post("/prioritize?dryRun=true&threshold=-1"); status != fiber.StatusBadRequest {
+        t.Fatalf("expected status %d, got %d", fiber.StatusBadRequest, status)
+    }
+}
+
+func putSettings(t *testing.T, app *fiber.App, body any) (int, settingsBody) {
+    t.Helper()
+    b, _ := json.Marshal(body)
+    req := httptest.NewRequest("PUT", "/prioritize/settings", bytes.NewReader(b))
+    req.Header.Set("Content-Type", "application/json")
+    resp, err := app.Test(req, -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    var out settingsBody
+    if resp.StatusCode == fiber.StatusOK {
+        if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+            t.Fatalf("decode: %v", err)
+        }
+    }
+    return resp.StatusCode, out
+}
+
+// Test that settings default until saved, are normalized on save, reject
+// negative weights, and leave stored scores alone until the next run.
+func TestHandlers_Settings(t *testing.T) {
+    tk := newTask("t1", 10, domaintask.StatusTodo)
+    app, repo := newTestAppWithRepo(t, tk)
+
+    resp, err := app.Test(httptest.NewRequest("GET", "/prioritize/settings", nil), -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    var got settingsBody
+    if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+    def := appprioritize.DefaultWeights()
+    if *got.PriorityWeight != def.Priority || *got.DueDateWeight != def.DueDate || *got.AIWeight != def.AI {
+        t.Fatalf("expected default weights, got %+v", got)
+    }
+
+    first := postPrioritize(t, app, map[string]any{"taskIds": []string{tk.ID}})
+
+    status, saved := putSettings(t, app, map[string]any{"priorityWeight": 0, "dueDateWeight": 2, "ageWeight": 1, "statusWeight": 1})
+    if status != fiber.StatusOK {
+        t.Fatalf("expected status %d, got %d", fiber.StatusOK, status)
+    }
+    if *saved.PriorityWeight != 0 || *saved.DueDateWeight != 0.5 || *saved.AgeWeight != 0.25 || *saved.AIWeight != def.AI {
+        t.Fatalf("expected normalized weights with AI kept, got %+v", saved)
+    }
+    stored, _ := repo.Get(context.Background(), "t1", tk.ID)
+    if *stored.AiScore != first.Results[0].Score {
+        t.Fatalf("expected stored score %v to be unchanged, got %v", first.Results[0].Score, *stored.AiScore)
+    }
+
+    second := postPrioritize(t, app, map[string]any{"taskIds": []string{tk.ID}})
+    if second.Results[0].Score >= first.Results[0].Score {
+        t.Fatalf("expected a lower score without the priority weight, got %v then %v", first.Results[0].Score, second.Results[0].Score)
+    }
+
+    if status, _ := putSettings(t, app, map[string]any{"ageWeight": -1}); status != fiber.StatusUnprocessableEntity {
+        t.Fatalf("expected status %d, got %d", fiber.StatusUnprocessableEntity, status)
+    }
+}
+
+// Test that autoPrioritize is off until turned on, survives saves that omit
+// it, and that lastAutoRunAt stays null until the scheduler has run.
+func TestHandlers_Settings_AutoPrioritize(t *testing.T) {
+    app := newTestApp(t)
+
+    status, saved := putSettings(t, app, map[string]any{"autoPrioritize": true, "lastAutoRunAt": "2026-01-01T00:00:00Z"})
+    if status != fiber.StatusOK {
+        t.Fatalf("expected status %d, got %d", fiber.StatusOK, status)
+    }
+    if !*saved.AutoPrioritize || saved.LastAutoRunAt != nil {
+        t.Fatalf("expected autoPrioritize on and no run yet, got %+v", saved)
+    }
+    _, saved = putSettings(t, app, map[string]any{"ageWeight": 0.2})
+    if !*saved.AutoPrioritize {
+        t.Fatalf("expected autoPrioritize to survive a partial save, got %+v", saved)
+    }
+}
+
+func getMatrix(t *testing.T, app *fiber.App, query string) (int, matrixResponse) {
+    t.Helper()
+    resp, err := app.Test(httptest.NewRequest("GET", "/prioritize/matrix"+query, nil), -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    var out matrixResponse
+    if resp.StatusCode == fiber.StatusOK {
+        if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+            t.Fatalf("decode: %v", err)
+        }
+    }
+    return resp.StatusCode, out
+}
+
+// Test that the matrix uses the tenant's saved thresholds unless the query
+// overrides them, skips closed tasks and rejects invalid thresholds.
+func TestHandlers_Matrix(t *testing.T) {
+    soon := time.Now().Add(24 * time.Hour)
+    urgent := newTask("t1", 6, domaintask.StatusTodo)
+    urgent.DueDate = &soon
+    important := newTask("t1", 8, domaintask.StatusTodo)
+    done := newTask("t1", 10, domaintask.StatusDone)
+    app := newTestApp(t, urgent, important, done)
+
+    status, out := getMatrix(t, app, "")
+    if status != fiber.StatusOK {
+        t.Fatalf("expected status %d, got %d", fiber.StatusOK, status)
+    }
+    if len(out.UrgentNotImportant) != 1 || out.UrgentNotImportant[0].ID != urgent.ID ||
+        len(out.NotUrgentImportant) != 1 || out.NotUrgentImportant[0].ID != important.ID ||
+        len(out.UrgentImportant) != 0 || len(out.Neither) != 0 {
+        t.Fatalf("unexpected default matrix %+v", out)
+    }
+
+    if status, _ := putSettings(t, app, map[string]any{"importantPriority": 6, "urgentWithinHours": 12}); status != fiber.StatusOK {
+        t.Fatalf("expected status %d, got %d", fiber.StatusOK, status)
+    }
+    _, out = getMatrix(t, app, "")
+    if len(out.NotUrgentImportant) != 2 {
+        t.Fatalf("expected both open tasks important and not urgent with saved thresholds, got %+v", out)
+    }
+    _, out = getMatrix(t, app, "?urgentWithinHours=48")
+    if len(out.UrgentImportant) != 1 || out.UrgentImportant[0].ID != urgent.ID {
+        t.Fatalf("expected the query to widen the urgency window, got %+v", out)
+    }
+
+    if status, _ := getMatrix(t, app, "?importantPriority=99"); status != fiber.StatusBadRequest {
+        t.Fatalf("expected status %d, got %d", fiber.StatusBadRequest, status)
+    }
+}
+
+// noListRepo is a task repository that refuses to list every task, so only
+// the batched lookups can serve a request.
+type noListRepo struct {
+    *memory.TaskRepository
+}
+
+func (noListRepo) List(context.Context, string, apptask.FilterOptions, apptask.SortOptions, apptask.ListOptions) ([]domaintask.Task, int64, error) {
+    return nil, 0, errors.New("full listing not allowed")
+}
+
+// Test that prioritizing loads the requested tasks by id, or a bounded page
+// of open tasks, without listing the whole tenant.
+func TestHandlers_Prioritize_BatchedLookup(t *testing.T) {
+    repo := memory.NewTaskRepository()
+    open := newTask("t1", 5, domaintask.StatusTodo)
+    done := newTask("t1", 9, domaintask.StatusDone)
+    for _, tk := range []*domaintask.Task{open, done} {
+        if err := repo.Create(context.Background(), tk); err != nil {
+            t.Fatalf("seed: %v", err)
+        }
+    }
+    app := fiber.New()
+    app.Use(func(c *fiber.Ctx) error {
+        middleware.SetClaims(c, identity.Claims{TenantID: "t1"})
+        return c.Next()
+    })
+    svc := appprioritize.NewService().WithSettings(memory.NewPrioritizeSettingsRepository())
+    RegisterRoutes(app.Group("/prioritize"), svc, apptask.NewService(noListRepo{repo}), maxTasks)
+
+    const absent = "00000000-0000-0000-0000-000000000000"
+    res := postPrioritize(t, app, map[string]any{"taskIds": []string{done.ID, absent, absent}})
+    if len(res.Results) != 1 || res.Results[0].TaskID != done.ID {
+        t.Fatalf("expected the requested task, got %+v", res.Results)
+    }
+    if len(res.Missing) != 1 || res.Missing[0] != absent {
+        t.Fatalf("expected %s missing once, got %v", absent, res.Missing)
+    }
+    res = postPrioritize(t, app, map[string]any{})
+    if len(res.Results) != 1 || res.Results[0].TaskID != open.ID {
+        t.Fatalf("expected only the open task, got %+v", res.Results)
+    }
+}
@@ -2,11 +2,24 @@ package prioritize
 
 import (
     appprioritize "backend/internal/application/prioritize"
+    apptask "backend/internal/application/task"
+    "backend/internal/interface/http/middleware"
 
     "github.com/gofiber/fiber/v2"
 )
 
-// RegisterRoutes wires prioritization routes to the provided router.
-func RegisterRoutes(r fiber.Router, svc *appprioritize.Service) {
-    r.Get("/ping", func(c *fiber.Ctx) error { return c.SendString("pong") })
+// RegisterRoutes wires prioritization routes to the provided router. maxAll
+// caps how many tasks POST /all scores.
+func RegisterRoutes(r fiber.Router, svc *appprioritize.Service, tasks *apptask.Service, maxAll int) {
+    NewHandlers(svc, tasks, maxAll).Register(r)
+}
+
+// Register wires h's routes to the provided router.
+func (h *Handlers) Register(r fiber.Router) {
+    jsonBody := middleware.RequireContentType(middleware.ContentTypeJSON)
+    r.Post("/", jsonBody, h.prioritize)
+    r.Post("/all", h.prioritizeAll)
+    r.Get("/matrix", h.matrix)
+    r.Get("/settings", h.getSettings)
+    r.Put("/settings", jsonBody, h.putSettings)
 }
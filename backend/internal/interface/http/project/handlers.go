@@ -0,0 +1,130 @@
+package project
+
+import (
+    "errors"
+
+    appproject "backend/internal/application/project"
+    "backend/internal/interface/http/middleware"
+    "backend/internal/interface/http/paging"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+type Handlers struct {
+    svc *appproject.Service
+}
+
+func NewHandlers(svc *appproject.Service) *Handlers { return &Handlers{svc: svc} }
+
+type createProjectRequest struct {
+    Name        string `json:"name"`
+    Description string `json:"description"`
+}
+
+type moveProjectRequest struct {
+    BeforeID string `json:"beforeId"`
+    AfterID  string `json:"afterId"`
+}
+
+func tenantOf(c *fiber.Ctx) string {
+    return middleware.ClaimsOf(c).TenantID
+}
+
+func userOf(c *fiber.Ctx) string {
+    return middleware.ClaimsOf(c).UserID
+}
+
+func (h *Handlers) list(c *fiber.Ctx) error {
+    page, err := paging.FromQuery(c)
+    if err != nil {
+        return err
+    }
+    items, err := h.svc.List(c.UserContext(), tenantOf(c), userOf(c))
+    if err != nil {
+        return fiber.ErrInternalServerError
+    }
+    return paging.Send(c, paging.Slice(items, page))
+}
+
+func (h *Handlers) create(c *fiber.Ctx) error {
+    var req createProjectRequest
+    if err := c.BodyParser(&req); err != nil {
+        return fiber.ErrBadRequest
+    }
+    p, err := h.svc.Create(c.UserContext(), tenantOf(c), req.Name, req.Description)
+    if err != nil {
+        return fiber.NewError(fiber.StatusBadRequest, err.Error())
+    }
+    return c.Status(fiber.StatusCreated).JSON(p)
+}
+
+func (h *Handlers) get(c *fiber.Ctx) error {
+    p, err := h.svc.Get(c.UserContext(), tenantOf(c), c.Params("id"))
+    if err != nil {
+        return fiber.ErrNotFound
+    }
+    return c.JSON(p)
+}
+
+func (h *Handlers) favorite(c *fiber.Ctx) error {
+    return h.setFavorite(c, true)
+}
+
+func (h *Handlers) unfavorite(c *fiber.Ctx) error {
+    return h.setFavorite(c, false)
+}
+
+func (h *Handlers) setFavorite(c *fiber.Ctx, favorite bool) error {
+    err := h.svc.SetFavorite(c.UserContext(), tenantOf(c), userOf(c), c.Params("id"), favorite)
+    if errors.Is(err, appproject.ErrNotFound) {
+        return fiber.ErrNotFound
+    }
+    if err != nil {
+        return fiber.ErrInternalServerError
+    }
+    return c.SendStatus(fiber.StatusNoContent)
+}
+
+// position moves a project before or after another one in the tenant's
+// manual ordering.
+func (h *Handlers) position(c *fiber.Ctx) error {
+    var req moveProjectRequest
+    if err := c.BodyParser(&req); err != nil {
+        return fiber.ErrBadRequest
+    }
+    p, err := h.svc.Move(c.UserContext(), tenantOf(c), c.Params("id"), req.BeforeID, req.AfterID)
+    switch {
+    case err == nil:
+        return c.JSON(p)
+    case errors.Is(err, appproject.ErrInvalidMove):
+        return fiber.NewError(fiber.StatusBadRequest, err.Error())
+    case errors.Is(err, appproject.ErrNotFound):
+        return fiber.ErrNotFound
+    default:
+        return fiber.ErrInternalServerError
+    }
+}
+
+// delete removes a project. The mode query parameter decides what happens to
+// its tasks; without one, a non-empty project yields 409 and the task count.
+func (h *Handlers) delete(c *fiber.Ctx) error {
+    mode, err := appproject.ParseDeleteMode(c.Query("mode"))
+    if err != nil {
+        return fiber.NewError(fiber.StatusBadRequest, err.Error())
+    }
+    err = h.svc.Delete(c.UserContext(), tenantOf(c), c.Params("id"), mode)
+    var hasTasks *appproject.HasTasksError
+    switch {
+    case err == nil:
+        return c.SendStatus(fiber.StatusNoContent)
+    case errors.As(err, &hasTasks):
+        return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+            "error":     hasTasks.Error(),
+            "taskCount": hasTasks.Count,
+        })
+    case errors.Is(err, appproject.ErrNotFound):
+        return fiber.ErrNotFound
+    default:
+        return fiber.ErrInternalServerError
+    }
+}
@@ -0,0 +1,21 @@
+package project
+
+import (
+    appproject "backend/internal/application/project"
+    "backend/internal/interface/http/middleware"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// RegisterRoutes wires project routes to the provided router.
+func RegisterRoutes(r fiber.Router, svc *appproject.Service) {
+    h := NewHandlers(svc)
+    id := middleware.RequireUUIDParams("id")
+    r.Get("/", h.list)
+    r.Post("/", middleware.RequireContentType(middleware.ContentTypeJSON), h.create)
+    r.Get("/:id", id, h.get)
+    r.Delete("/:id", id, h.delete)
+    r.Post("/:id/favorite", id, h.favorite)
+    r.Delete("/:id/favorite", id, h.unfavorite)
+    r.Patch("/:id/position", id, middleware.RequireContentType(), h.position)
+}
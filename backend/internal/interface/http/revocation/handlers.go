@@ -0,0 +1,55 @@
+package revocation
+
+import (
+    "errors"
+
+    apprevocation "backend/internal/application/revocation"
+    "backend/internal/interface/http/middleware"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+type Handlers struct {
+    svc *apprevocation.Service
+}
+
+func NewHandlers(svc *apprevocation.Service) *Handlers {
+    return &Handlers{svc: svc}
+}
+
+type revokeRequest struct {
+    TenantID string `json:"tenantId"`
+    JTI      string `json:"jti"`
+    UserID   string `json:"userId"`
+}
+
+// revoke revokes one access token by its jti, or every token of a user, in
+// tenantId (default: the caller's) and answers 204. When the revocation
+// cannot be stored it answers 503, so a revocation is never reported done
+// without being in force.
+func (h *Handlers) revoke(c *fiber.Ctx) error {
+    var req revokeRequest
+    if err := c.BodyParser(&req); err != nil {
+        return fiber.ErrBadRequest
+    }
+    if (req.JTI == "") == (req.UserID == "") {
+        return fiber.NewError(fiber.StatusBadRequest, apprevocation.ErrTargetRequired.Error())
+    }
+    tenantID := req.TenantID
+    if tenantID == "" {
+        tenantID = middleware.ClaimsOf(c).TenantID
+    }
+    var err error
+    if req.JTI != "" {
+        err = h.svc.RevokeToken(c.UserContext(), tenantID, req.JTI)
+    } else {
+        err = h.svc.RevokeUser(c.UserContext(), tenantID, req.UserID)
+    }
+    if errors.Is(err, apprevocation.ErrTargetRequired) {
+        return fiber.NewError(fiber.StatusBadRequest, err.Error())
+    }
+    if err != nil {
+        return fiber.ErrServiceUnavailable
+    }
+    return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,72 @@
+package revocation
+
+import (
+    "bytes"
+    "net/http/httptest"
+    "strings"
+    "testing"
+    "time"
+
+    apprevocation "backend/internal/application/revocation"
+    "backend/internal/infrastructure/auth"
+    "backend/internal/infrastructure/memory"
+    "backend/internal/interface/http/middleware"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// Test that an admin can revoke a token by jti and a user by id, after which
+// the revoked tokens get 401 when presented while others keep working, and
+// that a request naming neither answers 400.
+func TestHandlers_Revoke(t *testing.T) {
+    svc := apprevocation.NewService(memory.NewRevocationRepository(memory.NewAccountRepository()), time.Hour)
+    jwt := auth.NewJWTService([]byte(strings.Repeat("s", 32))).WithRevocations(svc)
+    app := fiber.New()
+    app.Use(middleware.AuthMiddleware(jwt))
+    app.Get("/me", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+    RegisterRoutes(app.Group("/auth/revoke", middleware.RequireAdmin([]string{"admin"})), svc)
+
+    send := func(token, method, path, body string) int {
+        req := httptest.NewRequest(method, path, bytes.NewReader([]byte(body)))
+        req.Header.Set("Authorization", "Bearer "+token)
+        req.Header.Set("Content-Type", "application/json")
+        resp, err := app.Test(req, -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        return resp.StatusCode
+    }
+    mint := func(userID, jti string) string {
+        token, err := jwt.Sign(auth.TokenClaims{ID: jti, Subject: userID, TenantID: "t1", IssuedAt: time.Now().Add(-time.Minute).Unix(), ExpiresAt: time.Now().Add(time.Hour).Unix()})
+        if err != nil {
+            t.Fatalf("sign: %v", err)
+        }
+        return token
+    }
+    admin := mint("admin", "jti-admin")
+    leaked := mint("u1", "jti-leaked")
+    kept := mint("u1", "jti-kept")
+    leaver := mint("u2", "jti-leaver")
+
+    if got := send(leaked, "POST", "/auth/revoke", `{"jti":"jti-leaked"}`); got != fiber.StatusForbidden {
+        t.Fatalf("expected non-admins to get %d, got %d", fiber.StatusForbidden, got)
+    }
+    if got := send(leaked, "GET", "/me", ""); got != fiber.StatusOK {
+        t.Fatalf("expected the token to work before revocation, got %d", got)
+    }
+    if got := send(admin, "POST", "/auth/revoke", `{"jti":"jti-leaked"}`); got != fiber.StatusNoContent {
+        t.Fatalf("expected status %d, got %d", fiber.StatusNoContent, got)
+    }
+    if got := send(admin, "POST", "/auth/revoke", `{"tenantId":"t1","userId":"u2"}`); got != fiber.StatusNoContent {
+        t.Fatalf("expected status %d, got %d", fiber.StatusNoContent, got)
+    }
+    if got := send(admin, "POST", "/auth/revoke", `{}`); got != fiber.StatusBadRequest {
+        t.Fatalf("expected status %d without a target, got %d", fiber.StatusBadRequest, got)
+    }
+
+    for token, want := range map[string]int{leaked: fiber.StatusUnauthorized, leaver: fiber.StatusUnauthorized, kept: fiber.StatusOK} {
+        if got := send(token, "GET", "/me", ""); got != want {
+            t.Fatalf("expected status %d when presented, got %d", want, got)
+        }
+    }
+}
@@ -0,0 +1,19 @@
+package revocation
+
+import (
+    apprevocation "backend/internal/application/revocation"
+    "backend/internal/interface/http/middleware"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// RegisterRoutes wires the token revocation route to a router mounted at
+// /auth/revoke. The router is expected to be restricted to administrators.
+func RegisterRoutes(r fiber.Router, svc *apprevocation.Service) {
+    NewHandlers(svc).Register(r)
+}
+
+// Register wires h's routes to the provided router.
+func (h *Handlers) Register(r fiber.Router) {
+    r.Post("/", middleware.RequireContentType(middleware.ContentTypeJSON), h.revoke)
+}
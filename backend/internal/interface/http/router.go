@@ -1,33 +1,169 @@
 package http
 
 import (
+    "time"
+
+    httpaccount "backend/internal/interface/http/account"
+    httpadmin "backend/internal/interface/http/admin"
+    httpattachment "backend/internal/interface/http/attachment"
+    httpcomment "backend/internal/interface/http/comment"
+    httphealth "backend/internal/interface/http/health"
+    httpjob "backend/internal/interface/http/job"
+    httpme "backend/internal/interface/http/me"
     "backend/internal/interface/http/middleware"
+    "backend/internal/interface/http/paging"
     httpprioritize "backend/internal/interface/http/prioritize"
+    httpproject "backend/internal/interface/http/project"
+    httprevocation "backend/internal/interface/http/revocation"
     httptask "backend/internal/interface/http/task"
+    httptemplate "backend/internal/interface/http/template"
+    httptenant "backend/internal/interface/http/tenant"
+    httptimeline "backend/internal/interface/http/timeline"
+    httpuser "backend/internal/interface/http/user"
+    "backend/internal/pkg/config"
+    "backend/internal/pkg/identity"
 
     "github.com/gofiber/fiber/v2"
+    "github.com/gofiber/fiber/v2/middleware/adaptor"
     "github.com/gofiber/fiber/v2/middleware/cors"
-    "github.com/gofiber/fiber/v2/middleware/logger"
     "github.com/gofiber/fiber/v2/middleware/recover"
     "github.com/gofiber/fiber/v2/middleware/requestid"
 )
 
+// publicPaths are served without authentication: the liveness and readiness
+// probes, and sign-up and sign-in, which are how callers get a token. The
+// detailed /health report is not among them, since it shows subsystem errors
+// and calls the AI provider, and neither is revoking tokens, also under
+// /api/v1/auth.
+var publicPaths = []string{
+    "/healthz", "/readyz",
+    "/api/v1/auth/register", "/api/v1/auth/login", "/api/v1/auth/refresh", "/api/v1/auth/logout",
+}
+
+// systemPaths are the maintenance routes internal services may call with
+// the service token, and the metrics and health report they monitor; every
+// other route refuses them.
+var systemPaths = []string{"/api/v1/admin", "/metrics", "/health"}
+
+// UploadsPath serves the files of the local blob store to authenticated
+// callers of the tenant that owns them.
+const UploadsPath = "/uploads"
+
 // Build configures application routes and attaches middleware.
 func Build(app *fiber.App, deps Dependencies) {
     // Global middleware
+    app.Use(middleware.SecurityHeadersMiddleware(deps.Config.CSP))
+    app.Use(middleware.RequestIDHeader())
     app.Use(requestid.New())
-    app.Use(logger.New())
+    app.Use(middleware.RequestIDContext())
+    app.Use(middleware.HTTPMetrics(deps.meterProvider()))
+    app.Use(middleware.RequestLogger(deps.logger()))
+    app.Use(middleware.SlowRequestLogger(deps.logger(), time.Duration(deps.Config.SlowRequestMS)*time.Millisecond))
+    if deps.Config.LogBodies {
+        app.Use(middleware.BodyLogger(deps.logger(), deps.Config.LogBodyMaxBytes))
+    }
     app.Use(recover.New())
-    app.Use(cors.New())
+    app.Use(middleware.JSONKeyCase())
+    app.Use(middleware.RequestTimeoutMiddleware(deps.Config.MaxRequestTimeoutMS))
+    // Browsers only show paging headers to scripts when told to
+    app.Use(cors.New(cors.Config{ExposeHeaders: fiber.HeaderLink + ", " + paging.HeaderTotalCount}))
+    // Every route needs authentication except those under publicPaths
+    app.Use(deps.authMiddleware())
+    app.Use(middleware.RestrictSystem(systemPaths...))
 
     // Health
-    app.Get("/healthz", func(c *fiber.Ctx) error { return c.SendString("ok") })
+    httphealth.RegisterHealthRoutes(app, deps.ReadyDB, deps.ReadyCache)
+    if deps.Health != nil {
+        httphealth.RegisterRoutes(app.Group("/health"), deps.Health)
+    }
+
+    // Metrics, for the service token only since series carry tenant ids
+    if deps.MetricsHandler != nil {
+        app.Get("/metrics", middleware.RequireAdmin(nil, identity.RoleSystem), adaptor.HTTPHandler(deps.MetricsHandler))
+    }
+
+    if deps.UploadDir != "" {
+        httpattachment.RegisterFileRoutes(app.Group(UploadsPath), deps.UploadDir)
+    }
 
     // Protected API routes
     api := app.Group("/api/v1")
-    api.Use(middleware.AuthMiddleware(deps.Auth()))
+    api.Use(middleware.ConcurrencyLimitMiddleware(middleware.ConcurrencyLimit{
+        Limit:        deps.Config.MaxConcurrentRequests,
+        QueueSize:    deps.Config.ConcurrencyQueueSize,
+        QueueTimeout: time.Duration(deps.Config.ConcurrencyQueueTimeoutMS) * time.Millisecond,
+    }))
+    if deps.Accounts != nil {
+        httpaccount.RegisterRoutes(api.Group("/auth"), deps.Accounts)
+    }
+
+    // Modules; experimental ones only when their feature is enabled, and
+    // then only for tenants with its flag on
+    httpme.RegisterRoutes(api.Group("/me"))
+    if !deps.Config.FeatureEnabled(config.FeatureSSE) {
+        // Registered ahead of the task routes, which would read "stream" as
+        // a task id
+        api.Get("/tasks/stream", func(*fiber.Ctx) error { return fiber.ErrNotFound })
+    } else if deps.FeatureFlags != nil {
+        api.Use("/tasks/stream", middleware.RequireFlag(deps.FeatureFlags, config.FeatureSSE))
+    }
+    tasks := httptask.NewHandlers(deps.TaskService)
+    tasks.Events = deps.TaskEvents
+    tasks.AdminUserIDs = deps.Config.AdminUserIDs
+    tasks.Templates = deps.TemplateService
+    if deps.TenantSettings != nil {
+        tasks.Regions = deps.TenantSettings
+    }
+    tasks.Register(api.Group("/tasks"))
+    httpcomment.RegisterRoutes(api.Group("/tasks/:id/comments"), deps.CommentService, deps.Config.AdminUserIDs)
+    if deps.AttachmentService != nil {
+        httpattachment.RegisterRoutes(api.Group("/tasks/:id/attachments"), deps.AttachmentService)
+    }
+    if deps.TimelineService != nil {
+        httptimeline.RegisterRoutes(api.Group("/tasks/:id/timeline"), deps.TimelineService, deps.Config.AdminUserIDs)
+    }
+    httpproject.RegisterRoutes(api.Group("/projects"), deps.ProjectService)
+    if deps.TemplateService != nil {
+        httptemplate.RegisterRoutes(api.Group("/task-templates"), deps.TemplateService)
+    }
+    if deps.UserService != nil {
+        httpuser.RegisterRoutes(api.Group("/users"), deps.UserService)
+    }
+    if deps.Accounts != nil {
+        httpaccount.RegisterPasswordRoutes(api.Group("/users/me"), deps.Accounts)
+    }
+    withFeature(deps, config.FeaturePrioritize, func() {
+        httpprioritize.RegisterRoutes(api.Group("/prioritize", flagGate(deps, config.FeaturePrioritize)...), deps.prioritizeService(), deps.TaskService, deps.Config.PrioritizeAllMaxTasks)
+    })
+    if deps.Jobs != nil {
+        withFeature(deps, config.FeatureJobs, func() {
+            httpjob.RegisterRoutes(api.Group("/jobs", flagGate(deps, config.FeatureJobs)...), deps.Jobs)
+        })
+    }
+
+    // Administration
+    if deps.Revocations != nil {
+        httprevocation.RegisterRoutes(api.Group("/auth/revoke", middleware.RequireAdmin(deps.Config.AdminUserIDs)), deps.Revocations)
+    }
+    httptenant.RegisterRoutes(api.Group("/tenants", middleware.RequireAdmin(deps.Config.AdminUserIDs)), deps.TenantService, deps.APIKeyService, deps.TenantSettings)
+    httpadmin.RegisterRoutes(api.Group("/admin", middleware.RequireAdmin(deps.Config.AdminUserIDs, identity.RoleSystem)), deps.prioritizeService(), deps.TaskService, deps.FeatureFlags, deps.Config.PrioritizeAllMaxTasks)
+}
+
+// flagGate returns the middleware that hides an experimental feature's
+// routes from tenants without its feature flag, which is named like the
+// feature, or none when deps has no feature flags.
+func flagGate(deps Dependencies, feature string) []fiber.Handler {
+    if deps.FeatureFlags == nil {
+        return nil
+    }
+    return []fiber.Handler{middleware.RequireFlag(deps.FeatureFlags, feature)}
+}
 
-    // Modules
-    httptask.RegisterRoutes(api.Group("/tasks"), deps.TaskService)
-    httpprioritize.RegisterRoutes(api.Group("/prioritize"), deps.PrioritizeService)
+// withFeature calls register, which mounts a feature's routes, only when
+// feature is enabled in deps.Config; the routes of disabled features are
+// never registered and so answer 404.
+func withFeature(deps Dependencies, feature string, register func()) {
+    if deps.Config.FeatureEnabled(feature) {
+        register()
+    }
 }
@@ -0,0 +1,198 @@
+package http
+
+import (
+    "context"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+
+    appcomment "backend/internal/application/comment"
+    appfeatureflag "backend/internal/application/featureflag"
+    appprioritize "backend/internal/application/prioritize"
+    appproject "backend/internal/application/project"
+    apptask "backend/internal/application/task"
+    apptenant "backend/internal/application/tenant"
+    appuser "backend/internal/application/user"
+    "backend/internal/infrastructure/auth"
+    "backend/internal/infrastructure/memory"
+    "backend/internal/pkg/config"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// newFeatureTestApp builds the full router with features enabled. Without
+// task events the stream, when served, answers 501.
+func newFeatureTestApp(features map[string]bool) *fiber.App {
+    tasks := memory.NewTaskRepository()
+    projects := memory.NewProjectRepository(tasks)
+    deps := NewDependencies(
+        auth.NewSimpleAuthService(),
+        apptask.NewService(tasks),
+        appcomment.NewService(memory.NewCommentRepository(tasks)),
+        appproject.NewService(projects),
+        appprioritize.NewService(),
+        apptenant.NewService(memory.NewTenantRepository(tasks, projects)),
+    )
+    deps.UserService = appuser.NewService(memory.NewUserRepository(memory.NewAccountRepository()))
+    deps.Config.Features = features
+    app := fiber.New(AppConfig(config.Config{}))
+    Build(app, deps)
+    return app
+}
+
+// status sends an authenticated request and returns the response status.
+func status(t *testing.T, app *fiber.App, method, path string) int {
+    t.Helper()
+    req := httptest.NewRequest(method, path, nil)
+    req.Header.Set("Authorization", "Bearer token")
+    resp, err := app.Test(req, -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    return resp.StatusCode
+}
+
+// Test that the routes of features left out of FEATURES are not registered
+// and answer 404, while listed features and other routes are served.
+func TestBuild_Features(t *testing.T) {
+    app := newFeatureTestApp(map[string]bool{config.FeaturePrioritize: true})
+    if got := status(t, app, "GET", "/api/v1/tasks/stream"); got != fiber.StatusNotFound {
+        t.Fatalf("expected the disabled stream to answer %d, got %d", fiber.StatusNotFound, got)
+    }
+    if got := status(t, app, "GET", "/api/v1/prioritize/settings"); got != fiber.StatusOK {
+        t.Fatalf("expected the enabled prioritize routes to answer %d, got %d", fiber.StatusOK, got)
+    }
+    if got := status(t, app, "GET", "/api/v1/tasks/"); got != fiber.StatusOK {
+        t.Fatalf("expected task routes to be unaffected, got %d", got)
+    }
+
+    app = newFeatureTestApp(map[string]bool{config.FeatureSSE: true})
+    if got := status(t, app, "GET", "/api/v1/prioritize/settings"); got != fiber.StatusNotFound {
+        t.Fatalf("expected the disabled prioritize routes to answer %d, got %d", fiber.StatusNotFound, got)
+    }
+    if got := status(t, app, "GET", "/api/v1/tasks/stream"); got != fiber.StatusNotImplemented {
+        t.Fatalf("expected the enabled stream to answer %d, got %d", fiber.StatusNotImplemented, got)
+    }
+}
+
+// Test that only publicPaths are served without a token: every API module
+// answers 401 to an unauthenticated request, so a skip list that grows to
+// cover one of them fails here.
+func TestBuild_PublicPaths(t *testing.T) {
+    app := newFeatureTestApp(map[string]bool{config.FeaturePrioritize: true, config.FeatureSSE: true, config.FeatureJobs: true})
+    anonymous := func(method, path string) int {
+        resp, err := app.Test(httptest.NewRequest(method, path, nil), -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        return resp.StatusCode
+    }
+
+    protected := []string{
+        "/api/v1/me",
+        "/api/v1/tasks/",
+        "/api/v1/tasks/stream",
+        "/api/v1/projects/",
+        "/api/v1/prioritize/settings",
+        "/api/v1/task-templates/",
+        "/api/v1/users/",
+        "/api/v1/tenants/",
+        "/api/v1/admin/feature-flags",
+        "/api/v1/authors",
+        "/api/v1/auth/revoke",
+        "/api/v1/unknown",
+        "/uploads/t1/file.png",
+        "/metrics",
+        "/health",
+    }
+    for _, path := range protected {
+        if got := anonymous("GET", path); got != fiber.StatusUnauthorized {
+            t.Fatalf("%s: expected status %d without a token, got %d", path, fiber.StatusUnauthorized, got)
+        }
+    }
+    for _, path := range []string{"/healthz", "/readyz", "/api/v1/auth/login"} {
+        if got := anonymous("GET", path); got == fiber.StatusUnauthorized {
+            t.Fatalf("%s: expected the public path to skip authentication", path)
+        }
+    }
+    if got := anonymous("GET", "/healthz"); got != fiber.StatusOK {
+        t.Fatalf("expected /healthz to answer %d, got %d", fiber.StatusOK, got)
+    }
+}
+
+// Test that metrics, whose series carry tenant ids, are served to the
+// service token only.
+func TestBuild_Metrics(t *testing.T) {
+    const serviceToken = "service-token-service-token-service-token"
+    tasks := memory.NewTaskRepository()
+    projects := memory.NewProjectRepository(tasks)
+    deps := NewDependencies(
+        auth.NewChainAuthService(auth.NewServiceTokenAuthService(serviceToken), auth.NewSimpleAuthService()),
+        apptask.NewService(tasks),
+        appcomment.NewService(memory.NewCommentRepository(tasks)),
+        appproject.NewService(projects),
+        appprioritize.NewService(),
+        apptenant.NewService(memory.NewTenantRepository(tasks, projects)),
+    )
+    deps.MetricsHandler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+        _, _ = w.Write([]byte(`tasks_created_total{tenantID="t1"} 1`))
+    })
+    app := fiber.New(AppConfig(config.Config{}))
+    Build(app, deps)
+
+    for token, want := range map[string]int{"": fiber.StatusUnauthorized, "token": fiber.StatusForbidden, serviceToken: fiber.StatusOK} {
+        req := httptest.NewRequest("GET", "/metrics", nil)
+        if token != "" {
+            req.Header.Set("Authorization", "Bearer "+token)
+        }
+        resp, err := app.Test(req, -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        if resp.StatusCode != want {
+            t.Fatalf("token %q: expected status %d, got %d", token, want, resp.StatusCode)
+        }
+    }
+}
+
+// Test that experimental routes answer 404 to a tenant until its feature
+// flag is turned on.
+func TestBuild_FeatureFlags(t *testing.T) {
+    tasks := memory.NewTaskRepository()
+    projects := memory.NewProjectRepository(tasks)
+    deps := NewDependencies(
+        auth.NewSimpleAuthService(),
+        apptask.NewService(tasks),
+        appcomment.NewService(memory.NewCommentRepository(tasks)),
+        appproject.NewService(projects),
+        appprioritize.NewService(),
+        apptenant.NewService(memory.NewTenantRepository(tasks, projects)),
+    )
+    flags := appfeatureflag.NewService(memory.NewFeatureFlagRepository())
+    deps.FeatureFlags = flags
+    app := fiber.New(AppConfig(config.Config{}))
+    Build(app, deps)
+
+    routes := map[string]struct {
+        path string
+        on   int
+    }{
+        config.FeaturePrioritize: {"/api/v1/prioritize/settings", fiber.StatusOK},
+        // Without task events the stream answers 501 once served
+        config.FeatureSSE: {"/api/v1/tasks/stream", fiber.StatusNotImplemented},
+    }
+    for name, r := range routes {
+        if got := status(t, app, "GET", r.path); got != fiber.StatusNotFound {
+            t.Fatalf("%s: expected %d with the flag off, got %d", name, fiber.StatusNotFound, got)
+        }
+        if _, err := flags.Set(context.Background(), "t1", name, true); err != nil {
+            t.Fatalf("set flag: %v", err)
+        }
+        if got := status(t, app, "GET", r.path); got != r.on {
+            t.Fatalf("%s: expected %d with the flag on, got %d", name, r.on, got)
+        }
+    }
+    if got := status(t, app, "GET", "/api/v1/tasks/"); got != fiber.StatusOK {
+        t.Fatalf("expected task routes to be ungated, got %d", got)
+    }
+}
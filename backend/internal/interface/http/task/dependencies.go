@@ -0,0 +1,66 @@
+package task
+
+import (
+    "errors"
+    "strings"
+
+    apptask "backend/internal/application/task"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+type addDependencyRequest struct {
+    DependsOnID string `json:"dependsOnId"`
+}
+
+// dependencies lists the tasks blocking the task and the tasks it blocks.
+func (h *Handlers) dependencies(c *fiber.Ctx) error {
+    tenantID, _, err := tenantAndUser(c)
+    if err != nil {
+        return err
+    }
+    dependsOn, blocks, err := h.svc.ListDependencies(c.UserContext(), tenantID, c.Params("id"))
+    if err != nil {
+        return fiber.ErrNotFound
+    }
+    return c.JSON(fiber.Map{"dependsOn": h.toResponses(dependsOn), "blocks": h.toResponses(blocks)})
+}
+
+// addDependency makes the task depend on (be blocked by) another task.
+func (h *Handlers) addDependency(c *fiber.Ctx) error {
+    tenantID, _, err := tenantAndUser(c)
+    if err != nil {
+        return err
+    }
+    var req addDependencyRequest
+    if err := c.BodyParser(&req); err != nil {
+        return fiber.ErrBadRequest
+    }
+    if req.DependsOnID == "" {
+        return fiber.NewError(fiber.StatusBadRequest, "dependsOnId is required")
+    }
+    // Params alias the request buffer; the ID outlives the request.
+    taskID := strings.Clone(c.Params("id"))
+    d, err := h.svc.AddDependency(c.UserContext(), tenantID, taskID, req.DependsOnID)
+    switch {
+    case errors.Is(err, apptask.ErrSelfDependency), errors.Is(err, apptask.ErrDependencyNotFound):
+        return fiber.NewError(fiber.StatusBadRequest, err.Error())
+    case errors.Is(err, apptask.ErrDependencyCycle):
+        return fiber.NewError(fiber.StatusConflict, err.Error())
+    case err != nil:
+        return fiber.ErrNotFound
+    }
+    return c.Status(fiber.StatusCreated).JSON(d)
+}
+
+// removeDependency drops the task's dependency on another task.
+func (h *Handlers) removeDependency(c *fiber.Ctx) error {
+    tenantID, _, err := tenantAndUser(c)
+    if err != nil {
+        return err
+    }
+    if err := h.svc.RemoveDependency(c.UserContext(), tenantID, c.Params("id"), c.Params("dependsOnId")); err != nil {
+        return fiber.ErrNotFound
+    }
+    return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,53 @@
+package task
+
+import (
+    "bytes"
+    "errors"
+
+    apptask "backend/internal/application/task"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+const (
+    // ContentTypeICal is the media type of calendar exports.
+    ContentTypeICal = "text/calendar; charset=utf-8"
+    // ContentTypePDF is the media type of report exports.
+    ContentTypePDF = "application/pdf"
+)
+
+// export writes the tenant's tasks, or with ?mine=true the caller's, in the
+// ?format= requested: ical for the dated tasks as a calendar feed apps can
+// subscribe to, pdf for a printable report of all of them.
+func (h *Handlers) export(c *fiber.Ctx) error {
+    tenantID, userID, err := tenantAndUser(c)
+    if err != nil {
+        return err
+    }
+    var f apptask.FilterOptions
+    if c.QueryBool("mine") {
+        f.UserID = &userID
+    }
+    var buf bytes.Buffer
+    switch c.Query("format") {
+    case "ical":
+        if err := h.svc.ExportIcal(c.UserContext(), tenantID, f, &buf); err != nil {
+            return fiber.ErrInternalServerError
+        }
+        c.Set(fiber.HeaderContentType, ContentTypeICal)
+        c.Set(fiber.HeaderContentDisposition, `inline; filename="tasks.ics"`)
+    case "pdf":
+        err := h.svc.ExportReport(c.UserContext(), tenantID, f, &buf)
+        if errors.Is(err, apptask.ErrReportWriterUnavailable) {
+            return fiber.NewError(fiber.StatusNotImplemented, err.Error())
+        }
+        if err != nil {
+            return fiber.ErrInternalServerError
+        }
+        c.Set(fiber.HeaderContentType, ContentTypePDF)
+        c.Set(fiber.HeaderContentDisposition, `attachment; filename="tasks.pdf"`)
+    default:
+        return fiber.NewError(fiber.StatusBadRequest, "format must be ical or pdf")
+    }
+    return c.Send(buf.Bytes())
+}
@@ -1,95 +1,465 @@
 package task
 
 import (
-    "context"
+    "encoding/json"
+    "errors"
+    "slices"
     "strconv"
+    "strings"
+    "time"
 
     apptask "backend/internal/application/task"
+    apptemplate "backend/internal/application/template"
+    domaintask "backend/internal/domain/task"
+    "backend/internal/interface/http/middleware"
+    "backend/internal/interface/http/paging"
+    "backend/internal/pkg/markdown"
 
     "github.com/gofiber/fiber/v2"
 )
 
 type Handlers struct {
     svc *apptask.Service
+    // Now is the clock used for derived response fields such as overdue.
+    Now func() time.Time
+    // Events feeds GET /stream; the endpoint returns 501 when nil.
+    Events apptask.EventSubscriber
+    // AdminUserIDs may list a task's watchers and change or delete any task.
+    AdminUserIDs []string
+    // Heartbeat is the idle interval between stream keep-alive comments
+    // (defaultHeartbeat when zero).
+    Heartbeat time.Duration
+    // Templates, when set, enables POST /from-template/:templateId.
+    Templates *apptemplate.Service
+    // Regions, when set, limits creating and changing tasks to requests
+    // through the tenant's data residency region; see
+    // middleware.RegionMiddleware.
+    Regions middleware.TenantSettingsService
 }
 
-func NewHandlers(svc *apptask.Service) *Handlers { return &Handlers{svc: svc} }
+func NewHandlers(svc *apptask.Service) *Handlers {
+    return &Handlers{svc: svc, Now: func() time.Time { return time.Now().UTC() }}
+}
+
+// taskResponse is a task as returned by the API, with fields derived at
+// serialization time. Derived fields are never stored.
+type taskResponse struct {
+    domaintask.Task
+    Overdue bool `json:"overdue"`
+}
+
+func (h *Handlers) toResponse(t domaintask.Task) taskResponse {
+    return taskResponse{Task: t, Overdue: t.IsOverdue(h.Now())}
+}
+
+func (h *Handlers) toResponses(items []domaintask.Task) []taskResponse {
+    out := make([]taskResponse, 0, len(items))
+    for _, t := range items {
+        out = append(out, h.toResponse(t))
+    }
+    return out
+}
 
 type createTaskRequest struct {
-    Title       string `json:"title"`
-    Description string `json:"description"`
-    Priority    int    `json:"priority"`
+    Title       string     `json:"title"`
+    Description string     `json:"description"`
+    Priority    int        `json:"priority"`
+    DueDate     *time.Time `json:"dueDate"`
+    ParentID    *string    `json:"parentId"`
 }
 
 type updateTaskRequest struct {
-    Title       *string `json:"title"`
-    Description *string `json:"description"`
-    Status      *string `json:"status"`
-    Priority    *int    `json:"priority"`
+    Title       *string      `json:"title"`
+    Description *string      `json:"description"`
+    Status      *string      `json:"status"`
+    Priority    *int         `json:"priority"`
+    DueDate     optionalTime `json:"dueDate"`
 }
 
-func tenantAndUser(c *fiber.Ctx) (tenantID, userID string) {
-    t, _ := c.Locals("tenant").(string)
-    u, _ := c.Locals("user").(string)
-    return t, u
+// optionalTime tells an absent JSON field from an explicit null.
+type optionalTime struct {
+    Set   bool
+    Value *time.Time
 }
 
+func (o *optionalTime) UnmarshalJSON(b []byte) error {
+    o.Set = true
+    return json.Unmarshal(b, &o.Value)
+}
+
+type bulkAssignRequest struct {
+    IDs        []string `json:"ids"`
+    AssigneeID *string  `json:"assigneeId"`
+}
+
+// tenantAndUser returns the caller's tenant and user. A request that reached
+// the task routes without authentication is refused with 500, since only a
+// routing mistake can cause it, and one authenticated without a user or
+// tenant with 401; neither may go on to query with empty identifiers.
+func tenantAndUser(c *fiber.Ctx) (tenantID, userID string, err error) {
+    p, err := middleware.FromCtx(c)
+    switch {
+    case errors.Is(err, middleware.ErrIncompletePrincipal):
+        return "", "", fiber.NewError(fiber.StatusUnauthorized, err.Error())
+    case err != nil:
+        return "", "", fiber.ErrInternalServerError
+    }
+    return p.TenantID, p.UserID, nil
+}
+
+// actor returns the caller, userID, as the task service's policy sees them.
+func (h *Handlers) actor(userID string) apptask.Actor {
+    return apptask.Actor{UserID: userID, Admin: slices.Contains(h.AdminUserIDs, userID)}
+}
+
+// list returns a page of the tenant's tasks; with ?mine=true only those the
+// caller created or is assigned to.
 func (h *Handlers) list(c *fiber.Ctx) error {
-    tenantID, _ := tenantAndUser(c)
-    items, err := h.svc.List(context.Background(), tenantID)
+    tenantID, userID, err := tenantAndUser(c)
+    if err != nil {
+        return err
+    }
+    page, err := paging.FromQuery(c)
+    if err != nil {
+        return err
+    }
+    var f apptask.FilterOptions
+    if c.QueryBool("mine") {
+        f.UserID = &userID
+    }
+    items, total, err := h.svc.ListFiltered(c.UserContext(), tenantID, f, c.Query("sort"), apptask.ListOptions{Limit: page.Limit, Offset: page.Offset})
+    if errors.Is(err, apptask.ErrInvalidSort) {
+        return fiber.NewError(fiber.StatusBadRequest, "sort must be one of aiScore, dueDate, priority or createdAt, optionally prefixed with -")
+    }
     if err != nil {
         return fiber.ErrInternalServerError
     }
-    return c.JSON(items)
+    return paging.Send(c, paging.New(h.toResponses(items), total, page))
+}
+
+// mine lists a page of the caller's tasks: assigned to them, or created by
+// them and unassigned.
+func (h *Handlers) mine(c *fiber.Ctx) error {
+    tenantID, userID, err := tenantAndUser(c)
+    if err != nil {
+        return err
+    }
+    page, err := paging.FromQuery(c)
+    if err != nil {
+        return err
+    }
+    items, err := h.svc.ListMine(c.UserContext(), tenantID, userID)
+    if err != nil {
+        return fiber.ErrInternalServerError
+    }
+    return paging.Send(c, paging.Slice(h.toResponses(items), page))
+}
+
+// headerTimezone names the IANA zone a request's days are read in.
+const headerTimezone = "X-Timezone"
+
+// location resolves the request's zone: the X-Timezone header, else the
+// tenant's configured zone, else UTC.
+func (h *Handlers) location(c *fiber.Ctx, tenantID string) (*time.Location, error) {
+    loc, err := h.svc.Location(tenantID, c.Get(headerTimezone))
+    if err != nil {
+        return nil, fiber.NewError(fiber.StatusBadRequest, headerTimezone+" must be an IANA time zone such as Asia/Jakarta")
+    }
+    return loc, nil
+}
+
+type agendaResponse struct {
+    Timezone  string         `json:"timezone"`
+    Overdue   []taskResponse `json:"overdue"`
+    Today     []taskResponse `json:"today"`
+    Tomorrow  []taskResponse `json:"tomorrow"`
+    Upcoming  []taskResponse `json:"upcoming"`
+    Later     []taskResponse `json:"later"`
+    NoDueDate []taskResponse `json:"noDueDate"`
+}
+
+// agenda groups the tenant's open tasks, or with ?mine=true the caller's, by
+// due day in the request's zone.
+func (h *Handlers) agenda(c *fiber.Ctx) error {
+    tenantID, userID, err := tenantAndUser(c)
+    if err != nil {
+        return err
+    }
+    loc, err := h.location(c, tenantID)
+    if err != nil {
+        return err
+    }
+    var f apptask.FilterOptions
+    if c.QueryBool("mine") {
+        f.UserID = &userID
+    }
+    a, err := h.svc.Agenda(c.UserContext(), tenantID, f, h.Now().In(loc))
+    if err != nil {
+        return fiber.ErrInternalServerError
+    }
+    return c.JSON(agendaResponse{
+        Timezone:  a.Timezone,
+        Overdue:   h.toResponses(a.Overdue),
+        Today:     h.toResponses(a.Today),
+        Tomorrow:  h.toResponses(a.Tomorrow),
+        Upcoming:  h.toResponses(a.Upcoming),
+        Later:     h.toResponses(a.Later),
+        NoDueDate: h.toResponses(a.NoDueDate),
+    })
 }
 
 func (h *Handlers) create(c *fiber.Ctx) error {
-    tenantID, userID := tenantAndUser(c)
+    tenantID, userID, err := tenantAndUser(c)
+    if err != nil {
+        return err
+    }
     var req createTaskRequest
     if err := c.BodyParser(&req); err != nil {
         return fiber.ErrBadRequest
     }
-    t, err := h.svc.Create(context.Background(), tenantID, userID, req.Title, req.Description, req.Priority)
+    // Zero means "unset" and is defaulted by the service.
+    if req.Priority != 0 {
+        if err := domaintask.ValidatePriority(req.Priority); err != nil {
+            return fiber.NewError(fiber.StatusBadRequest, err.Error())
+        }
+    }
+    in := apptask.CreateTaskInput{Title: req.Title, Description: req.Description, Priority: req.Priority, DueDate: req.DueDate, ParentID: req.ParentID}
+    t, err := h.svc.CreateTask(c.UserContext(), tenantID, userID, in)
+    if err != nil {
+        switch {
+        case errors.Is(err, domaintask.ErrTooLong):
+            return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+        case errors.Is(err, domaintask.ErrInvalidPriority), errors.Is(err, domaintask.ErrRequired),
+            errors.Is(err, apptask.ErrParentNotFound):
+            return fiber.NewError(fiber.StatusBadRequest, err.Error())
+        }
+        // Anything else is a storage failure, not the caller's fault
+        return err
+    }
+    return c.Status(fiber.StatusCreated).JSON(h.toResponse(*t))
+}
+
+type quickAddRequest struct {
+    Text string `json:"text"`
+}
+
+// quickAdd creates a task from one line of text such as
+// "Ship report by friday 5pm #billing p1 @alex" and echoes how the line was
+// read; with ?dryRun=true only the interpretation is returned. Relative
+// dates are read in the request's zone.
+func (h *Handlers) quickAdd(c *fiber.Ctx) error {
+    tenantID, userID, err := tenantAndUser(c)
+    if err != nil {
+        return err
+    }
+    loc, err := h.location(c, tenantID)
+    if err != nil {
+        return err
+    }
+    var req quickAddRequest
+    if err := c.BodyParser(&req); err != nil {
+        return fiber.ErrBadRequest
+    }
+    if strings.TrimSpace(req.Text) == "" {
+        return fiber.NewError(fiber.StatusBadRequest, "text is required")
+    }
+    parsed, t, err := h.svc.QuickAdd(c.UserContext(), tenantID, userID, req.Text, loc, c.QueryBool("dryRun"))
     if err != nil {
+        if errors.Is(err, domaintask.ErrTooLong) {
+            return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+        }
         return fiber.NewError(fiber.StatusBadRequest, err.Error())
     }
-    return c.Status(fiber.StatusCreated).JSON(t)
+    if t == nil {
+        return c.JSON(fiber.Map{"parsed": parsed})
+    }
+    return c.Status(fiber.StatusCreated).JSON(fiber.Map{"parsed": parsed, "task": h.toResponse(*t)})
+}
+
+// notFoundOr500 maps an error of a task operation that has no other
+// expected failure: a missing task is 404, anything else, such as the
+// database being down, 500.
+func notFoundOr500(err error) error {
+    if errors.Is(err, apptask.ErrNotFound) {
+        return fiber.ErrNotFound
+    }
+    return fiber.ErrInternalServerError
 }
 
 func (h *Handlers) get(c *fiber.Ctx) error {
-    tenantID, _ := tenantAndUser(c)
+    tenantID, _, err := tenantAndUser(c)
+    if err != nil {
+        return err
+    }
     id := c.Params("id")
-    t, err := h.svc.Get(context.Background(), tenantID, id)
+    t, err := h.svc.Get(c.UserContext(), tenantID, id)
     if err != nil {
-        return fiber.ErrNotFound
+        return notFoundOr500(err)
     }
-    return c.JSON(t)
+    return c.JSON(h.toResponse(*t))
+}
+
+// descriptionHTML renders the task's Markdown description as sanitized HTML.
+func (h *Handlers) descriptionHTML(c *fiber.Ctx) error {
+    tenantID, _, err := tenantAndUser(c)
+    if err != nil {
+        return err
+    }
+    t, err := h.svc.Get(c.UserContext(), tenantID, c.Params("id"))
+    if err != nil {
+        return notFoundOr500(err)
+    }
+    out, err := markdown.RenderMarkdown(t.Description)
+    if err != nil {
+        return fiber.ErrInternalServerError
+    }
+    c.Type("html", "utf-8")
+    return c.SendString(out)
+}
+
+// summarize returns an AI summary of the task's title and description and,
+// with ?persist=true, stores it on the task.
+func (h *Handlers) summarize(c *fiber.Ctx) error {
+    tenantID, _, err := tenantAndUser(c)
+    if err != nil {
+        return err
+    }
+    t, err := h.svc.Get(c.UserContext(), tenantID, c.Params("id"))
+    if err != nil {
+        return notFoundOr500(err)
+    }
+    summary, err := h.svc.Summarize(c.UserContext(), t, c.QueryBool("persist"))
+    switch {
+    case errors.Is(err, apptask.ErrSummarizerUnavailable):
+        return fiber.NewError(fiber.StatusNotImplemented, err.Error())
+    case errors.Is(err, apptask.ErrSummarizeTimeout):
+        return fiber.NewError(fiber.StatusGatewayTimeout, err.Error())
+    case err != nil:
+        return fiber.ErrBadGateway
+    }
+    return c.JSON(fiber.Map{"taskId": t.ID, "summary": summary})
+}
+
+type generateSubtasksRequest struct {
+    Apply bool `json:"apply"`
+}
+
+// generateSubtasks previews AI-generated steps for the task or, with
+// "apply": true, creates them as its subtasks.
+func (h *Handlers) generateSubtasks(c *fiber.Ctx) error {
+    tenantID, userID, err := tenantAndUser(c)
+    if err != nil {
+        return err
+    }
+    var req generateSubtasksRequest
+    if len(c.Body()) > 0 {
+        if err := c.BodyParser(&req); err != nil {
+            return fiber.ErrBadRequest
+        }
+    }
+    t, err := h.svc.Get(c.UserContext(), tenantID, c.Params("id"))
+    if err != nil {
+        return notFoundOr500(err)
+    }
+    steps, created, err := h.svc.GenerateSubtasks(c.UserContext(), userID, t, req.Apply)
+    switch {
+    case errors.Is(err, apptask.ErrSubtaskGeneratorUnavailable):
+        return fiber.NewError(fiber.StatusNotImplemented, err.Error())
+    case errors.Is(err, apptask.ErrSubtaskGeneratorTimeout):
+        return fiber.NewError(fiber.StatusGatewayTimeout, err.Error())
+    case errors.Is(err, domaintask.ErrTooLong):
+        return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+    case errors.Is(err, apptask.ErrSubtaskGeneratorFailed):
+        return fiber.ErrBadGateway
+    case err != nil:
+        return fiber.ErrInternalServerError
+    }
+    res := fiber.Map{"taskId": t.ID, "steps": steps}
+    if req.Apply {
+        items := make([]domaintask.Task, 0, len(created))
+        for _, sub := range created {
+            items = append(items, *sub)
+        }
+        res["created"] = h.toResponses(items)
+        return c.Status(fiber.StatusCreated).JSON(res)
+    }
+    return c.JSON(res)
 }
 
 func (h *Handlers) patch(c *fiber.Ctx) error {
-    tenantID, _ := tenantAndUser(c)
+    tenantID, userID, err := tenantAndUser(c)
+    if err != nil {
+        return err
+    }
     id := c.Params("id")
     var req updateTaskRequest
     if err := c.BodyParser(&req); err != nil {
         return fiber.ErrBadRequest
     }
     in := apptask.UpdateTaskInput{Title: req.Title, Description: req.Description, Status: req.Status, Priority: req.Priority}
-    t, err := h.svc.Update(context.Background(), tenantID, id, in)
+    if req.DueDate.Set {
+        in.DueDate = req.DueDate.Value
+        in.ClearDueDate = req.DueDate.Value == nil
+    }
+    t, err := h.svc.Update(c.UserContext(), tenantID, id, h.actor(userID), in)
     if err != nil {
-        return fiber.ErrBadRequest
+        switch {
+        case errors.Is(err, apptask.ErrNotFound):
+            return fiber.ErrNotFound
+        case errors.Is(err, apptask.ErrForbidden):
+            return fiber.NewError(fiber.StatusForbidden, err.Error())
+        case errors.Is(err, domaintask.ErrTooLong), errors.Is(err, domaintask.ErrInvalidStatus):
+            return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+        case errors.Is(err, domaintask.ErrInvalidPriority), errors.Is(err, domaintask.ErrRequired):
+            return fiber.NewError(fiber.StatusBadRequest, err.Error())
+        case errors.Is(err, apptask.ErrBlockedByDependencies):
+            return fiber.NewError(fiber.StatusConflict, err.Error())
+        }
+        return fiber.ErrInternalServerError
     }
-    return c.JSON(t)
+    return c.JSON(h.toResponse(*t))
 }
 
 func (h *Handlers) delete(c *fiber.Ctx) error {
-    tenantID, _ := tenantAndUser(c)
+    tenantID, userID, err := tenantAndUser(c)
+    if err != nil {
+        return err
+    }
     id := c.Params("id")
-    if err := h.svc.Delete(context.Background(), tenantID, id); err != nil {
-        return fiber.ErrNotFound
+    err = h.svc.Delete(c.UserContext(), tenantID, id, h.actor(userID))
+    switch {
+    case errors.Is(err, apptask.ErrForbidden):
+        return fiber.NewError(fiber.StatusForbidden, err.Error())
+    case err != nil:
+        return notFoundOr500(err)
     }
     return c.SendStatus(fiber.StatusNoContent)
 }
 
+// bulkAssign sets or clears (assigneeId: null) the assignee on many tasks.
+// Tasks the caller may not change are left alone and listed in skippedIds.
+func (h *Handlers) bulkAssign(c *fiber.Ctx) error {
+    tenantID, userID, err := tenantAndUser(c)
+    if err != nil {
+        return err
+    }
+    var req bulkAssignRequest
+    if err := c.BodyParser(&req); err != nil {
+        return fiber.ErrBadRequest
+    }
+    if len(req.IDs) == 0 {
+        return fiber.NewError(fiber.StatusBadRequest, "ids are required")
+    }
+    updated, skipped, err := h.svc.BulkAssign(c.UserContext(), tenantID, h.actor(userID), req.IDs, req.AssigneeID)
+    if err != nil {
+        return fiber.ErrInternalServerError
+    }
+    if skipped == nil {
+        skipped = []string{}
+    }
+    return c.JSON(fiber.Map{"updatedIds": updated, "count": len(updated), "skippedIds": skipped})
+}
+
 // optional helper to parse ints with default
 func atoiDefault(s string, def int) int {
     if s == "" {
@@ -0,0 +1,1682 @@
+package task
+
+import (
+    "bufio"
+    "bytes"
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "net"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+    "time"
+
+    appjob "backend/internal/application/job"
+    apptask "backend/internal/application/task"
+    apptemplate "backend/internal/application/template"
+    domainproject "backend/internal/domain/project"
+    domaintask "backend/internal/domain/task"
+    "backend/internal/infrastructure/eventbus"
+    "backend/internal/infrastructure/importer"
+    "backend/internal/infrastructure/memory"
+    "backend/internal/interface/http/middleware"
+    "backend/internal/interface/http/paging"
+    "backend/internal/pkg/identity"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// absentID is a well-formed task id that no test creates.
+const absentID = "00000000-0000-0000-0000-000000000000"
+
+// newTestApp mounts the task routes behind a stub that sets the tenant and
+// user locals the auth middleware would normally provide.
+func newTestApp(svc *apptask.Service) *fiber.App {
+    app := fiber.New()
+    app.Use(func(c *fiber.Ctx) error {
+        middleware.SetClaims(c, identity.Claims{TenantID: "t1", UserID: "u1"})
+        return c.Next()
+    })
+    RegisterRoutes(app.Group("/tasks"), svc, nil, nil)
+    return app
+}
+
+// Test that get, patch and delete answer 400 for an id that is not a UUID
+// and 404 for a UUID that names no task.
+func TestHandlers_InvalidID(t *testing.T) {
+    app := newTestApp(apptask.NewService(memory.NewTaskRepository()))
+
+    for _, tc := range []struct {
+        method string
+        id     string
+        status int
+    }{
+        {"GET", "not-a-uuid", fiber.StatusBadRequest},
+        {"PATCH", "not-a-uuid", fiber.StatusBadRequest},
+        {"DELETE", "not-a-uuid", fiber.StatusBadRequest},
+        {"GET", "{" + absentID + "}", fiber.StatusBadRequest},
+        {"GET", absentID, fiber.StatusNotFound},
+        {"PATCH", absentID, fiber.StatusNotFound},
+        {"DELETE", absentID, fiber.StatusNotFound},
+    } {
+        req := httptest.NewRequest(tc.method, "/tasks/"+tc.id, strings.NewReader(`{"title":"b"}`))
+        req.Header.Set("Content-Type", "application/json")
+        resp, err := app.Test(req, -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        if resp.StatusCode != tc.status {
+            t.Fatalf("%s %s: expected status %d, got %d", tc.method, tc.id, tc.status, resp.StatusCode)
+        }
+    }
+}
+
+// Test that an over-long title is rejected with 422 naming the field.
+func TestHandlers_Create_TitleTooLong(t *testing.T) {
+    app := newTestApp(apptask.NewService(memory.NewTaskRepository()))
+
+    body, _ := json.Marshal(map[string]any{"title": strings.Repeat("x", domaintask.MaxTitleLength+1)})
+    req := httptest.NewRequest("POST", "/tasks/", bytes.NewReader(body))
+    req.Header.Set("Content-Type", "application/json")
+    resp, err := app.Test(req, -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    if resp.StatusCode != fiber.StatusUnprocessableEntity {
+        t.Fatalf("expected status %d, got %d", fiber.StatusUnprocessableEntity, resp.StatusCode)
+    }
+    var buf bytes.Buffer
+    buf.ReadFrom(resp.Body)
+    if !strings.Contains(buf.String(), "title") {
+        t.Fatalf("expected error to name the title field, got %q", buf.String())
+    }
+}
+
+// Test that task writes with a form body or no Content-Type get 415 and
+// create nothing, while PATCH also accepts a JSON merge patch.
+func TestHandlers_RequireJSONContentType(t *testing.T) {
+    repo := memory.NewTaskRepository()
+    svc := apptask.NewService(repo)
+    app := newTestApp(svc)
+    tk, _ := svc.Create(context.Background(), "t1", "u1", "existing", "", 5)
+
+    cases := []struct {
+        method      string
+        path        string
+        contentType string
+        body        string
+        status      int
+    }{
+        {"POST", "/tasks/", "", `{"title":"a"}`, fiber.StatusUnsupportedMediaType},
+        {"POST", "/tasks/", "application/x-www-form-urlencoded", "title=a", fiber.StatusUnsupportedMediaType},
+        {"POST", "/tasks/", "application/merge-patch+json", `{"title":"a"}`, fiber.StatusUnsupportedMediaType},
+        {"PATCH", "/tasks/" + tk.ID, "text/xml", "<title>b</title>", fiber.StatusUnsupportedMediaType},
+        {"PATCH", "/tasks/" + tk.ID, "application/merge-patch+json", `{"title":"b"}`, fiber.StatusOK},
+    }
+    for _, tc := range cases {
+        req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+        if tc.contentType != "" {
+            req.Header.Set("Content-Type", tc.contentType)
+        }
+        resp, err := app.Test(req, -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        if resp.StatusCode != tc.status {
+            t.Fatalf("%s with %q: expected status %d, got %d", tc.method, tc.contentType, tc.status, resp.StatusCode)
+        }
+    }
+    if items, _ := repo.ListByTenant(context.Background(), "t1"); len(items) != 1 {
+        t.Fatalf("expected no task to be created, got %d tasks", len(items))
+    }
+}
+
+// Test that a configured title limit is enforced with 422 one character
+// over and accepted exactly at the limit.
+func TestHandlers_Create_ConfiguredTitleLimit(t *testing.T) {
+    app := newTestApp(apptask.NewService(memory.NewTaskRepository(), apptask.WithLengthLimits(300, 0)))
+
+    for _, tc := range []struct {
+        length int
+        status int
+    }{
+        {300, fiber.StatusCreated},
+        {301, fiber.StatusUnprocessableEntity},
+    } {
+        body, _ := json.Marshal(map[string]any{"title": strings.Repeat("x", tc.length)})
+        req := httptest.NewRequest("POST", "/tasks/", bytes.NewReader(body))
+        req.Header.Set("Content-Type", "application/json")
+        resp, err := app.Test(req, -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        if resp.StatusCode != tc.status {
+            t.Fatalf("title of %d: expected status %d, got %d", tc.length, tc.status, resp.StatusCode)
+        }
+    }
+}
+
+// tenantRecordingRepo records the tenant of every task listing.
+type tenantRecordingRepo struct {
+    *memory.TaskRepository
+    tenants []string
+}
+
+func (r *tenantRecordingRepo) List(ctx context.Context, tenantID string, f apptask.FilterOptions, s apptask.SortOptions, page apptask.ListOptions) ([]domaintask.Task, int64, error) {
+    r.tenants = append(r.tenants, tenantID)
+    return r.TaskRepository.List(ctx, tenantID, f, s, page)
+}
+
+// Test that task routes mounted without the auth middleware, or reached by
+// a caller without a tenant, fail loudly instead of querying with an empty
+// tenant id.
+func TestHandlers_WithoutClaims(t *testing.T) {
+    for _, tc := range []struct {
+        name   string
+        claims *identity.Claims
+        status int
+    }{
+        {"no auth middleware", nil, fiber.StatusInternalServerError},
+        {"no tenant", &identity.Claims{UserID: identity.SystemUserID, Roles: []string{identity.RoleSystem}}, fiber.StatusUnauthorized},
+        {"no user", &identity.Claims{TenantID: "t1"}, fiber.StatusUnauthorized},
+    } {
+        repo := &tenantRecordingRepo{TaskRepository: memory.NewTaskRepository()}
+        app := fiber.New()
+        if tc.claims != nil {
+            claims := *tc.claims
+            app.Use(func(c *fiber.Ctx) error {
+                middleware.SetClaims(c, claims)
+                return c.Next()
+            })
+        }
+        RegisterRoutes(app.Group("/tasks"), apptask.NewService(repo), nil, nil)
+
+        resp, err := app.Test(httptest.NewRequest("GET", "/tasks/", nil), -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        if resp.StatusCode != tc.status {
+            t.Fatalf("%s: expected status %d, got %d", tc.name, tc.status, resp.StatusCode)
+        }
+        if len(repo.tenants) != 0 {
+            t.Fatalf("%s: expected no query, got listings for tenants %q", tc.name, repo.tenants)
+        }
+    }
+}
+
+// postTask sends body to POST /tasks/ as JSON.
+func postTask(t *testing.T, app *fiber.App, body string) *http.Response {
+    t.Helper()
+    req := httptest.NewRequest("POST", "/tasks/", strings.NewReader(body))
+    req.Header.Set("Content-Type", "application/json")
+    resp, err := app.Test(req, -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    return resp
+}
+
+// Test that a valid create answers 201 with the stored task, owned by the
+// caller's tenant and user.
+func TestHandlers_Create_Success(t *testing.T) {
+    repo := memory.NewTaskRepository()
+    app := newTestApp(apptask.NewService(repo))
+
+    resp := postTask(t, app, `{"title":"Write report","description":"Q3 numbers","priority":7}`)
+    if resp.StatusCode != fiber.StatusCreated {
+        t.Fatalf("expected status %d, got %d", fiber.StatusCreated, resp.StatusCode)
+    }
+    var got taskResponse
+    if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+    if got.ID == "" || got.Title != "Write report" || got.Description != "Q3 numbers" || got.Priority != 7 ||
+        got.Status != domaintask.StatusTodo || got.TenantID != "t1" || got.UserID != "u1" {
+        t.Fatalf("expected the created task, got %+v", got.Task)
+    }
+    if _, err := repo.Get(context.Background(), "t1", got.ID); err != nil {
+        t.Fatalf("expected task %s to be stored, got %v", got.ID, err)
+    }
+}
+
+// Test that a blank title is rejected with 400 naming the field.
+func TestHandlers_Create_EmptyTitle(t *testing.T) {
+    app := newTestApp(apptask.NewService(memory.NewTaskRepository()))
+
+    for _, body := range []string{`{"title":""}`, `{"title":"   "}`, `{"description":"no title"}`} {
+        resp := postTask(t, app, body)
+        if resp.StatusCode != fiber.StatusBadRequest {
+            t.Fatalf("%s: expected status %d, got %d", body, fiber.StatusBadRequest, resp.StatusCode)
+        }
+        msg, _ := io.ReadAll(resp.Body)
+        if !strings.Contains(string(msg), "title") {
+            t.Fatalf("%s: expected error to name the title field, got %q", body, msg)
+        }
+    }
+}
+
+// Test that a body that is not valid JSON is rejected with 400 and creates
+// nothing.
+func TestHandlers_Create_InvalidJSON(t *testing.T) {
+    repo := memory.NewTaskRepository()
+    app := newTestApp(apptask.NewService(repo))
+
+    for _, body := range []string{`{"title":`, `not json`, `{"title":42}`} {
+        if resp := postTask(t, app, body); resp.StatusCode != fiber.StatusBadRequest {
+            t.Fatalf("%s: expected status %d, got %d", body, fiber.StatusBadRequest, resp.StatusCode)
+        }
+    }
+    if items, _ := repo.ListByTenant(context.Background(), "t1"); len(items) != 0 {
+        t.Fatalf("expected no task to be created, got %d tasks", len(items))
+    }
+}
+
+// failingCreateRepo is a task repository whose writes fail as if the
+// database were unreachable.
+type failingCreateRepo struct {
+    *memory.TaskRepository
+}
+
+func (failingCreateRepo) Create(context.Context, *domaintask.Task) error {
+    return errors.New("db down")
+}
+
+// Test that a storage failure on create answers 500 rather than blaming
+// the request.
+func TestHandlers_Create_ServiceError(t *testing.T) {
+    app := newTestApp(apptask.NewService(failingCreateRepo{memory.NewTaskRepository()}))
+
+    resp := postTask(t, app, `{"title":"Write report"}`)
+    if resp.StatusCode != fiber.StatusInternalServerError {
+        t.Fatalf("expected status %d, got %d", fiber.StatusInternalServerError, resp.StatusCode)
+    }
+}
+
+// failingGetRepo is a task repository whose reads fail as if the database
+// were unreachable.
+type failingGetRepo struct {
+    *memory.TaskRepository
+}
+
+func (failingGetRepo) Get(context.Context, string, string) (*domaintask.Task, error) {
+    return nil, errors.New("db down")
+}
+
+// Test that a storage failure on get, patch or delete answers 500, while a
+// missing task is still 404.
+func TestHandlers_StorageErrors(t *testing.T) {
+    const id = "11111111-1111-1111-1111-111111111111"
+    failing := newTestApp(apptask.NewService(failingGetRepo{memory.NewTaskRepository()}))
+    healthy := newTestApp(apptask.NewService(memory.NewTaskRepository()))
+    for _, method := range []string{"GET", "PATCH", "DELETE"} {
+        for app, want := range map[*fiber.App]int{failing: fiber.StatusInternalServerError, healthy: fiber.StatusNotFound} {
+            req := httptest.NewRequest(method, "/tasks/"+id, strings.NewReader(`{"title":"x"}`))
+            req.Header.Set("Content-Type", "application/json")
+            resp, err := app.Test(req, -1)
+            if err != nil {
+                t.Fatalf("app.Test: %v", err)
+            }
+            if resp.StatusCode != want {
+                t.Fatalf("%s: expected status %d, got %d", method, want, resp.StatusCode)
+            }
+        }
+    }
+}
+
+// seedTwoTenants stores one task for t1 and one for t2 and returns the
+// repository with both.
+func seedTwoTenants(t *testing.T) (repo *memory.TaskRepository, own, foreign *domaintask.Task) {
+    t.Helper()
+    repo = memory.NewTaskRepository()
+    own = domaintask.New("t1", "u1", "own", "mine", 5)
+    foreign = domaintask.New("t2", "u2", "foreign", "theirs", 5)
+    for _, tk := range []*domaintask.Task{own, foreign} {
+        if err := repo.Create(context.Background(), tk); err != nil {
+            t.Fatalf("seed: %v", err)
+        }
+    }
+    return repo, own, foreign
+}
+
+// Test that the list only contains tasks of the tenant in c.Locals.
+func TestHandlers_List_TenantIsolation(t *testing.T) {
+    repo, own, _ := seedTwoTenants(t)
+    app := newTestApp(apptask.NewService(repo))
+
+    resp, err := app.Test(httptest.NewRequest("GET", "/tasks/", nil), -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    var page paging.PagedResponse[domaintask.Task]
+    if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+    if page.Total != 1 || len(page.Data) != 1 || page.Data[0].ID != own.ID || page.Data[0].TenantID != "t1" {
+        t.Fatalf("expected only t1's task %s, got %+v", own.ID, page)
+    }
+}
+
+// Test that get finds the tenant's own task and 404s on another tenant's.
+func TestHandlers_Get_TenantIsolation(t *testing.T) {
+    repo, own, foreign := seedTwoTenants(t)
+    app := newTestApp(apptask.NewService(repo))
+
+    resp, err := app.Test(httptest.NewRequest("GET", "/tasks/"+own.ID, nil), -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    if resp.StatusCode != fiber.StatusOK {
+        t.Fatalf("expected status %d for own task, got %d", fiber.StatusOK, resp.StatusCode)
+    }
+    resp, err = app.Test(httptest.NewRequest("GET", "/tasks/"+foreign.ID, nil), -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    if resp.StatusCode != fiber.StatusNotFound {
+        t.Fatalf("expected status %d for another tenant's task, got %d", fiber.StatusNotFound, resp.StatusCode)
+    }
+}
+
+// Test that patching another tenant's task fails and leaves it unchanged.
+func TestHandlers_Patch_TenantIsolation(t *testing.T) {
+    repo, _, foreign := seedTwoTenants(t)
+    app := newTestApp(apptask.NewService(repo))
+
+    req := httptest.NewRequest("PATCH", "/tasks/"+foreign.ID, strings.NewReader(`{"title":"hijacked"}`))
+    req.Header.Set("Content-Type", "application/json")
+    resp, err := app.Test(req, -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    if resp.StatusCode < 400 {
+        t.Fatalf("expected an error status, got %d", resp.StatusCode)
+    }
+    got, err := repo.Get(context.Background(), "t2", foreign.ID)
+    if err != nil {
+        t.Fatalf("get: %v", err)
+    }
+    if got.Title != "foreign" {
+        t.Fatalf("expected t2's task to keep its title, got %q", got.Title)
+    }
+}
+
+// patchTask sends body to PATCH /tasks/:id as JSON.
+func patchTask(t *testing.T, app *fiber.App, id, body string) *http.Response {
+    t.Helper()
+    req := httptest.NewRequest("PATCH", "/tasks/"+id, strings.NewReader(body))
+    req.Header.Set("Content-Type", "application/json")
+    resp, err := app.Test(req, -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    return resp
+}
+
+// Test that a patch changes only the fields it names: absent fields keep
+// their stored values.
+func TestHandlers_Patch_PartialUpdate(t *testing.T) {
+    for _, tc := range []struct {
+        name string
+        body string
+        want domaintask.Task
+    }{
+        {"status only", `{"status":"in_progress"}`, domaintask.Task{Title: "Write report", Description: "Q3 numbers", Status: domaintask.StatusInProgress, Priority: 7}},
+        {"title only", `{"title":"Write summary"}`, domaintask.Task{Title: "Write summary", Description: "Q3 numbers", Status: domaintask.StatusTodo, Priority: 7}},
+        {"priority only", `{"priority":2}`, domaintask.Task{Title: "Write report", Description: "Q3 numbers", Status: domaintask.StatusTodo, Priority: 2}},
+        {"empty description", `{"description":""}`, domaintask.Task{Title: "Write report", Status: domaintask.StatusTodo, Priority: 7}},
+        {"nothing", `{}`, domaintask.Task{Title: "Write report", Description: "Q3 numbers", Status: domaintask.StatusTodo, Priority: 7}},
+    } {
+        repo := memory.NewTaskRepository()
+        svc := apptask.NewService(repo)
+        app := newTestApp(svc)
+        tk, err := svc.Create(context.Background(), "t1", "u1", "Write report", "Q3 numbers", 7)
+        if err != nil {
+            t.Fatalf("seed: %v", err)
+        }
+
+        resp := patchTask(t, app, tk.ID, tc.body)
+        if resp.StatusCode != fiber.StatusOK {
+            t.Fatalf("%s: expected status %d, got %d", tc.name, fiber.StatusOK, resp.StatusCode)
+        }
+        var got taskResponse
+        if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+            t.Fatalf("%s: decode: %v", tc.name, err)
+        }
+        if got.ID != tk.ID || got.Title != tc.want.Title || got.Description != tc.want.Description ||
+            got.Status != tc.want.Status || got.Priority != tc.want.Priority {
+            t.Fatalf("%s: expected %+v, got %+v", tc.name, tc.want, got.Task)
+        }
+        stored, err := repo.Get(context.Background(), "t1", tk.ID)
+        if err != nil || stored.Title != tc.want.Title || stored.Description != tc.want.Description || stored.Status != tc.want.Status {
+            t.Fatalf("%s: expected the change to be stored, got %+v (%v)", tc.name, stored, err)
+        }
+    }
+}
+
+// Test that patching to a status that is not a task status answers 422 and
+// leaves the task alone.
+func TestHandlers_Patch_InvalidTransition(t *testing.T) {
+    repo := memory.NewTaskRepository()
+    svc := apptask.NewService(repo)
+    app := newTestApp(svc)
+    tk, err := svc.Create(context.Background(), "t1", "u1", "Write report", "", 5)
+    if err != nil {
+        t.Fatalf("seed: %v", err)
+    }
+
+    for _, body := range []string{`{"status":"cancelled"}`, `{"status":""}`, `{"status":"DONE"}`, `{"title":"renamed","status":"blocked"}`} {
+        resp := patchTask(t, app, tk.ID, body)
+        if resp.StatusCode != fiber.StatusUnprocessableEntity {
+            t.Fatalf("%s: expected status %d, got %d", body, fiber.StatusUnprocessableEntity, resp.StatusCode)
+        }
+    }
+    stored, err := repo.Get(context.Background(), "t1", tk.ID)
+    if err != nil || stored.Status != domaintask.StatusTodo || stored.Title != "Write report" {
+        t.Fatalf("expected the task unchanged, got %+v (%v)", stored, err)
+    }
+}
+
+// Test that patching a task the caller's tenant does not have answers 404,
+// whether it belongs to another tenant or does not exist.
+func TestHandlers_Patch_WrongTenant(t *testing.T) {
+    repo, _, foreign := seedTwoTenants(t)
+    app := newTestApp(apptask.NewService(repo))
+
+    for _, tc := range []struct {
+        name string
+        id   string
+        body string
+    }{
+        {"foreign title", foreign.ID, `{"title":"hijacked"}`},
+        {"foreign status", foreign.ID, `{"status":"done"}`},
+        {"absent", absentID, `{"status":"done"}`},
+    } {
+        if resp := patchTask(t, app, tc.id, tc.body); resp.StatusCode != fiber.StatusNotFound {
+            t.Fatalf("%s: expected status %d, got %d", tc.name, fiber.StatusNotFound, resp.StatusCode)
+        }
+    }
+    got, err := repo.Get(context.Background(), "t2", foreign.ID)
+    if err != nil || got.Title != "foreign" || got.Status != domaintask.StatusTodo {
+        t.Fatalf("expected t2's task unchanged, got %+v (%v)", got, err)
+    }
+}
+
+// Test that deleting another tenant's task 404s and leaves it in place.
+func TestHandlers_Delete_TenantIsolation(t *testing.T) {
+    repo, _, foreign := seedTwoTenants(t)
+    app := newTestApp(apptask.NewService(repo))
+
+    resp, err := app.Test(httptest.NewRequest("DELETE", "/tasks/"+foreign.ID, nil), -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    if resp.StatusCode != fiber.StatusNotFound {
+        t.Fatalf("expected status %d, got %d", fiber.StatusNotFound, resp.StatusCode)
+    }
+    if _, err := repo.Get(context.Background(), "t2", foreign.ID); err != nil {
+        t.Fatalf("expected t2's task to survive, got %v", err)
+    }
+}
+
+// Test that changing or deleting another user's task answers 403 unless the
+// caller is an admin, and that bulk assign reports the tasks it skipped.
+func TestHandlers_OwnerPolicy(t *testing.T) {
+    repo := memory.NewTaskRepository()
+    theirs := domaintask.New("t1", "u2", "theirs", "", 5)
+    mine := domaintask.New("t1", "u1", "mine", "", 5)
+    repo.Create(context.Background(), theirs)
+    repo.Create(context.Background(), mine)
+    svc := apptask.NewService(repo)
+    newApp := func(admins []string) *fiber.App {
+        app := fiber.New()
+        app.Use(func(c *fiber.Ctx) error {
+            middleware.SetClaims(c, identity.Claims{TenantID: "t1", UserID: "u1"})
+            return c.Next()
+        })
+        RegisterRoutes(app.Group("/tasks"), svc, nil, admins)
+        return app
+    }
+    do := func(app *fiber.App, method, path, body string) *http.Response {
+        req := httptest.NewRequest(method, path, strings.NewReader(body))
+        if body != "" {
+            req.Header.Set("Content-Type", "application/json")
+        }
+        resp, err := app.Test(req, -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        return resp
+    }
+    app := newApp(nil)
+
+    if resp := do(app, "PATCH", "/tasks/"+theirs.ID, `{"title":"hijacked"}`); resp.StatusCode != fiber.StatusForbidden {
+        t.Fatalf("expected status %d, got %d", fiber.StatusForbidden, resp.StatusCode)
+    }
+    if resp := do(app, "DELETE", "/tasks/"+theirs.ID, ""); resp.StatusCode != fiber.StatusForbidden {
+        t.Fatalf("expected status %d, got %d", fiber.StatusForbidden, resp.StatusCode)
+    }
+
+    resp := do(app, "POST", "/tasks/bulk-assign", `{"ids":["`+theirs.ID+`","`+mine.ID+`"],"assigneeId":"u3"}`)
+    var out struct {
+        UpdatedIDs []string `json:"updatedIds"`
+        SkippedIDs []string `json:"skippedIds"`
+    }
+    if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+    if len(out.UpdatedIDs) != 1 || out.UpdatedIDs[0] != mine.ID {
+        t.Fatalf("expected only %s updated, got %v", mine.ID, out.UpdatedIDs)
+    }
+    if len(out.SkippedIDs) != 1 || out.SkippedIDs[0] != theirs.ID {
+        t.Fatalf("expected %s skipped, got %v", theirs.ID, out.SkippedIDs)
+    }
+
+    if resp := do(newApp([]string{"u1"}), "DELETE", "/tasks/"+theirs.ID, ""); resp.StatusCode != fiber.StatusNoContent {
+        t.Fatalf("expected status %d for an admin, got %d", fiber.StatusNoContent, resp.StatusCode)
+    }
+}
+
+// Test that ?mine=true keeps the tasks the caller created or is assigned
+// to, and hides the other user's tasks in the same tenant.
+func TestHandlers_List_Mine(t *testing.T) {
+    repo := memory.NewTaskRepository()
+    a := domaintask.New("t1", "alice", "alice's", "", 5)
+    b := domaintask.New("t1", "bob", "bob's", "", 5)
+    assigned := domaintask.New("t1", "bob", "for alice", "", 5)
+    alice := "alice"
+    assigned.AssigneeID = &alice
+    for _, tk := range []*domaintask.Task{a, b, assigned} {
+        repo.Create(context.Background(), tk)
+    }
+    app := fiber.New()
+    app.Use(func(c *fiber.Ctx) error {
+        middleware.SetClaims(c, identity.Claims{TenantID: "t1", UserID: strings.Clone(c.Get("X-Test-User"))})
+        return c.Next()
+    })
+    RegisterRoutes(app.Group("/tasks"), apptask.NewService(repo), nil, nil)
+
+    list := func(user, query string) map[string]bool {
+        req := httptest.NewRequest("GET", "/tasks/"+query, nil)
+        req.Header.Set("X-Test-User", user)
+        resp, err := app.Test(req, -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        var page paging.PagedResponse[domaintask.Task]
+        if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+            t.Fatalf("decode: %v", err)
+        }
+        items := page.Data
+        ids := map[string]bool{}
+        for _, tk := range items {
+            ids[tk.ID] = true
+        }
+        return ids
+    }
+
+    if got := list("alice", "?mine=true"); len(got) != 2 || !got[a.ID] || !got[assigned.ID] {
+        t.Fatalf("expected alice's own and assigned tasks, got %v", got)
+    }
+    if got := list("bob", "?mine=true"); len(got) != 2 || got[a.ID] {
+        t.Fatalf("expected bob not to see alice's task, got %v", got)
+    }
+    if got := list("bob", ""); len(got) != 3 {
+        t.Fatalf("expected all 3 tenant tasks without mine, got %v", got)
+    }
+}
+
+// Test that GET /tasks/ pages through the tenant's tasks by following
+// nextCursor, with total, limit and offset set on every page.
+func TestHandlers_List_Paged(t *testing.T) {
+    svc := apptask.NewService(memory.NewTaskRepository())
+    for i := 0; i < 5; i++ {
+        if _, err := svc.Create(context.Background(), "t1", "u1", "task", "", 5); err != nil {
+            t.Fatalf("create: %v", err)
+        }
+    }
+    app := newTestApp(svc)
+
+    seen := map[string]bool{}
+    query := "?limit=2"
+    for pages := 0; ; pages++ {
+        if pages == 3 {
+            t.Fatalf("expected 3 pages, still going at %s", query)
+        }
+        resp, err := app.Test(httptest.NewRequest("GET", "/tasks/"+query, nil), -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        var page paging.PagedResponse[domaintask.Task]
+        if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+            t.Fatalf("decode: %v", err)
+        }
+        if page.Total != 5 || page.Limit != 2 || page.Offset != 2*pages {
+            t.Fatalf("page %d: expected total 5, limit 2, offset %d, got %+v", pages, 2*pages, page)
+        }
+        for _, tk := range page.Data {
+            seen[tk.ID] = true
+        }
+        if page.NextCursor == nil {
+            if pages != 2 || len(page.Data) != 1 {
+                t.Fatalf("expected the last page to be the third with 1 task, got page %d with %d", pages, len(page.Data))
+            }
+            break
+        }
+        query = "?limit=2&cursor=" + *page.NextCursor
+    }
+    if len(seen) != 5 {
+        t.Fatalf("expected 5 distinct tasks across pages, got %d", len(seen))
+    }
+}
+
+// Test that the description is served as sanitized HTML rendered from
+// Markdown, and that an unknown task is a 404.
+func TestHandlers_DescriptionHTML(t *testing.T) {
+    svc := apptask.NewService(memory.NewTaskRepository())
+    tk, err := svc.Create(context.Background(), "t1", "u1", "task", "## Plan\n\nSee [docs](https://example.com) <a href=\"javascript:alert(1)\">x</a>", 5)
+    if err != nil {
+        t.Fatalf("create: %v", err)
+    }
+    app := newTestApp(svc)
+
+    resp, err := app.Test(httptest.NewRequest("GET", "/tasks/"+tk.ID+"/description/html", nil), -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    if ct := resp.Header.Get(fiber.HeaderContentType); !strings.HasPrefix(ct, fiber.MIMETextHTML) {
+        t.Fatalf("expected text/html, got %q", ct)
+    }
+    var buf bytes.Buffer
+    buf.ReadFrom(resp.Body)
+    body := buf.String()
+    if !strings.Contains(body, "<h2>Plan</h2>") || !strings.Contains(body, `href="https://example.com"`) {
+        t.Fatalf("expected rendered heading and link, got %q", body)
+    }
+    if strings.Contains(body, "javascript:") {
+        t.Fatalf("expected javascript: link to be stripped, got %q", body)
+    }
+
+    resp, err = app.Test(httptest.NewRequest("GET", "/tasks/"+absentID+"/description/html", nil), -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    if resp.StatusCode != fiber.StatusNotFound {
+        t.Fatalf("expected status %d, got %d", fiber.StatusNotFound, resp.StatusCode)
+    }
+}
+
+// Test that overdue is derived from the due date, the handler clock and the
+// status, including at the exact boundary.
+func TestHandlers_Overdue(t *testing.T) {
+    now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+    repo := memory.NewTaskRepository()
+    due := func(d time.Duration, status string) *domaintask.Task {
+        tk := domaintask.New("t1", "u1", "task", "", 5)
+        at := now.Add(d)
+        tk.DueDate = &at
+        tk.Status = status
+        if err := repo.Create(context.Background(), tk); err != nil {
+            t.Fatalf("seed: %v", err)
+        }
+        return tk
+    }
+    past := due(-time.Nanosecond, domaintask.StatusTodo)
+    exact := due(0, domaintask.StatusTodo)
+    future := due(time.Hour, domaintask.StatusInProgress)
+    done := due(-time.Hour, domaintask.StatusDone)
+    archived := due(-time.Hour, domaintask.StatusArchived)
+
+    h := NewHandlers(apptask.NewService(repo))
+    h.Now = func() time.Time { return now }
+    app := fiber.New()
+    app.Use(func(c *fiber.Ctx) error {
+        middleware.SetClaims(c, identity.Claims{TenantID: "t1", UserID: "u1"})
+        return c.Next()
+    })
+    h.Register(app.Group("/tasks"))
+
+    resp, err := app.Test(httptest.NewRequest("GET", "/tasks/", nil), -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    var page paging.PagedResponse[struct {
+        ID      string `json:"id"`
+        Overdue bool   `json:"overdue"`
+    }]
+    if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+    got := map[string]bool{}
+    for _, it := range page.Data {
+        got[it.ID] = it.Overdue
+    }
+    want := map[string]bool{past.ID: true, exact.ID: false, future.ID: false, done.ID: false, archived.ID: false}
+    for id, w := range want {
+        if got[id] != w {
+            t.Fatalf("task %s: expected overdue=%v, got %v", id, w, got[id])
+        }
+    }
+}
+
+// Test that create stores an RFC3339 dueDate in UTC, a PATCH without
+// dueDate keeps it and an explicit null clears it.
+func TestHandlers_DueDate(t *testing.T) {
+    app := newTestApp(apptask.NewService(memory.NewTaskRepository()))
+    send := func(method, path, body string) map[string]any {
+        req := httptest.NewRequest(method, path, strings.NewReader(body))
+        req.Header.Set("Content-Type", "application/json")
+        resp, err := app.Test(req, -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        if resp.StatusCode >= 300 {
+            t.Fatalf("%s %s: unexpected status %d", method, path, resp.StatusCode)
+        }
+        var out map[string]any
+        if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+            t.Fatalf("decode: %v", err)
+        }
+        return out
+    }
+
+    created := send("POST", "/tasks/", `{"title":"ship","dueDate":"2026-03-01T14:00:00+02:00"}`)
+    if created["dueDate"] != "2026-03-01T12:00:00Z" {
+        t.Fatalf("expected dueDate 2026-03-01T12:00:00Z, got %v", created["dueDate"])
+    }
+    id := created["id"].(string)
+    if got := send("PATCH", "/tasks/"+id, `{"priority":8}`); got["dueDate"] != "2026-03-01T12:00:00Z" {
+        t.Fatalf("expected dueDate to be kept, got %v", got["dueDate"])
+    }
+    if got := send("PATCH", "/tasks/"+id, `{"dueDate":null}`); got["dueDate"] != nil {
+        t.Fatalf("expected dueDate to be cleared, got %v", got["dueDate"])
+    }
+}
+
+// Test that /mine returns only the caller's assigned or self-created
+// unassigned tasks, ordered by due date then priority.
+func TestHandlers_Mine(t *testing.T) {
+    ctx := context.Background()
+    repo := memory.NewTaskRepository()
+    now := time.Now().UTC()
+    seed := func(creator string, assignee *string, priority int, due *time.Time) *domaintask.Task {
+        tk := domaintask.New("t1", creator, "task", "", priority)
+        tk.AssigneeID = assignee
+        tk.DueDate = due
+        if err := repo.Create(ctx, tk); err != nil {
+            t.Fatalf("seed: %v", err)
+        }
+        return tk
+    }
+    u1, u2 := "u1", "u2"
+    soon, later := now.Add(time.Hour), now.Add(48*time.Hour)
+    ownUndated := seed("u1", nil, 9, nil)
+    assignedLater := seed("u2", &u1, 3, &later)
+    assignedSoon := seed("u2", &u1, 1, &soon)
+    seed("u1", &u2, 5, &soon)
+    seed("u2", nil, 5, nil)
+    foreign := domaintask.New("t2", "u1", "task", "", 5)
+    repo.Create(ctx, foreign)
+
+    app := fiber.New()
+    app.Use(func(c *fiber.Ctx) error {
+        // Clone: Fiber reuses the header buffer once the request ends.
+        middleware.SetClaims(c, identity.Claims{TenantID: "t1", UserID: strings.Clone(c.Get("X-Test-User"))})
+        return c.Next()
+    })
+    RegisterRoutes(app.Group("/tasks"), apptask.NewService(repo), nil, nil)
+
+    ids := func(user string) []string {
+        req := httptest.NewRequest("GET", "/tasks/mine", nil)
+        req.Header.Set("X-Test-User", user)
+        resp, err := app.Test(req, -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        var page paging.PagedResponse[domaintask.Task]
+        if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+            t.Fatalf("decode: %v", err)
+        }
+        items := page.Data
+        out := make([]string, 0, len(items))
+        for _, it := range items {
+            out = append(out, it.ID)
+        }
+        return out
+    }
+
+    got := ids("u1")
+    want := []string{assignedSoon.ID, assignedLater.ID, ownUndated.ID}
+    if strings.Join(got, ",") != strings.Join(want, ",") {
+        t.Fatalf("u1: expected %v, got %v", want, got)
+    }
+    if got := ids("u2"); len(got) != 2 {
+        t.Fatalf("u2: expected 2 tasks, got %v", got)
+    }
+    if got := ids("u3"); len(got) != 0 {
+        t.Fatalf("u3: expected no tasks, got %v", got)
+    }
+}
+
+// Test that a created task reaches the stream of its own tenant and not
+// others', and that the subscription ends when the client disconnects.
+func TestHandlers_Stream(t *testing.T) {
+    bus := eventbus.New(nil)
+    svc := apptask.NewService(memory.NewTaskRepository(), apptask.WithEventPublisher(bus))
+    h := NewHandlers(svc)
+    h.Events = bus
+    h.Heartbeat = 10 * time.Millisecond
+    app := fiber.New()
+    app.Use(func(c *fiber.Ctx) error {
+        middleware.SetClaims(c, identity.Claims{TenantID: "t1", UserID: "u1"})
+        return c.Next()
+    })
+    h.Register(app.Group("/tasks"))
+
+    ln, err := net.Listen("tcp", "127.0.0.1:0")
+    if err != nil {
+        t.Fatalf("listen: %v", err)
+    }
+    go app.Listener(ln)
+    defer app.ShutdownWithTimeout(time.Second)
+
+    resp, err := http.Get("http://" + ln.Addr().String() + "/tasks/stream")
+    if err != nil {
+        t.Fatalf("get stream: %v", err)
+    }
+    if ct := resp.Header.Get(fiber.HeaderContentType); ct != "text/event-stream" {
+        t.Fatalf("expected text/event-stream, got %q", ct)
+    }
+
+    if _, err := svc.Create(context.Background(), "t2", "u2", "foreign", "", 5); err != nil {
+        t.Fatalf("create: %v", err)
+    }
+    created, err := svc.Create(context.Background(), "t1", "u1", "mine", "", 5)
+    if err != nil {
+        t.Fatalf("create: %v", err)
+    }
+
+    event, data := nextEvent(t, resp)
+    if event != "task.created" {
+        t.Fatalf("expected a task.created event, got %q", event)
+    }
+    var ev domaintask.TaskCreated
+    if err := json.Unmarshal([]byte(data), &ev); err != nil {
+        t.Fatalf("decode event: %v", err)
+    }
+    if ev.Task.ID != created.ID || ev.TenantID != "t1" {
+        t.Fatalf("expected event for %s in t1, got %+v", created.ID, ev)
+    }
+
+    resp.Body.Close()
+    deadline := time.Now().Add(2 * time.Second)
+    for bus.Subscribers("t1") > 0 {
+        if time.Now().After(deadline) {
+            t.Fatalf("expected the stream to unsubscribe after disconnect")
+        }
+        time.Sleep(10 * time.Millisecond)
+    }
+}
+
+// nextEvent reads the stream up to the next event, skipping comments, and
+// returns its name and data.
+func nextEvent(t *testing.T, resp *http.Response) (event, data string) {
+    t.Helper()
+    done := make(chan struct{})
+    go func() {
+        defer close(done)
+        sc := bufio.NewScanner(resp.Body)
+        for sc.Scan() {
+            line := sc.Text()
+            switch {
+            case strings.HasPrefix(line, "event: "):
+                event = strings.TrimPrefix(line, "event: ")
+            case strings.HasPrefix(line, "data: "):
+                data = strings.TrimPrefix(line, "data: ")
+                return
+            }
+        }
+    }()
+    select {
+    case <-done:
+    case <-time.After(2 * time.Second):
+        t.Fatalf("no event received")
+    }
+    return event, data
+}
+
+// Test the watch routes: watch and unwatch as the caller, and listing
+// watchers only for admins.
+func TestHandlers_Watch(t *testing.T) {
+    repo := memory.NewTaskRepository()
+    tk := domaintask.New("t1", "u1", "task", "", 5)
+    repo.Create(context.Background(), tk)
+    svc := apptask.NewService(repo)
+    newApp := func(admins []string) *fiber.App {
+        app := fiber.New()
+        app.Use(func(c *fiber.Ctx) error {
+            middleware.SetClaims(c, identity.Claims{TenantID: "t1", UserID: "u1"})
+            return c.Next()
+        })
+        RegisterRoutes(app.Group("/tasks"), svc, nil, admins)
+        return app
+    }
+    app := newApp([]string{"u1"})
+    do := func(app *fiber.App, method, path string) *http.Response {
+        resp, err := app.Test(httptest.NewRequest(method, path, nil), -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        return resp
+    }
+
+    if resp := do(app, "POST", "/tasks/"+tk.ID+"/watch"); resp.StatusCode != fiber.StatusNoContent {
+        t.Fatalf("expected status %d, got %d", fiber.StatusNoContent, resp.StatusCode)
+    }
+    if resp := do(app, "POST", "/tasks/"+absentID+"/watch"); resp.StatusCode != fiber.StatusNotFound {
+        t.Fatalf("expected status %d, got %d", fiber.StatusNotFound, resp.StatusCode)
+    }
+
+    resp := do(app, "GET", "/tasks/"+tk.ID+"/watchers")
+    var page paging.PagedResponse[domaintask.TaskWatcher]
+    if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+    watchers := page.Data
+    if len(watchers) != 1 || watchers[0].UserID != "u1" {
+        t.Fatalf("expected u1 watching, got %+v", watchers)
+    }
+    if resp := do(newApp(nil), "GET", "/tasks/"+tk.ID+"/watchers"); resp.StatusCode != fiber.StatusForbidden {
+        t.Fatalf("expected status %d for non-admin, got %d", fiber.StatusForbidden, resp.StatusCode)
+    }
+
+    if resp := do(app, "DELETE", "/tasks/"+tk.ID+"/watch"); resp.StatusCode != fiber.StatusNoContent {
+        t.Fatalf("expected status %d, got %d", fiber.StatusNoContent, resp.StatusCode)
+    }
+    if got, _ := svc.ListWatchers(context.Background(), "t1", tk.ID); len(got) != 0 {
+        t.Fatalf("expected no watchers after unwatch, got %+v", got)
+    }
+}
+
+// failingWatchRepo is a task repository whose watcher writes fail as if the
+// database were down.
+type failingWatchRepo struct {
+    *memory.TaskRepository
+}
+
+func (failingWatchRepo) Watch(context.Context, *domaintask.TaskWatcher) error {
+    return errors.New("db down")
+}
+
+// Test that a storage failure while watching is a 500, not a 404.
+func TestHandlers_Watch_StorageError(t *testing.T) {
+    repo := memory.NewTaskRepository()
+    tk := domaintask.New("t1", "u1", "task", "", 5)
+    repo.Create(context.Background(), tk)
+    app := newTestApp(apptask.NewService(failingWatchRepo{repo}))
+    resp, err := app.Test(httptest.NewRequest("POST", "/tasks/"+tk.ID+"/watch", nil), -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    if resp.StatusCode != fiber.StatusInternalServerError {
+        t.Fatalf("expected status %d, got %d", fiber.StatusInternalServerError, resp.StatusCode)
+    }
+}
+
+// summarizerFunc adapts a function to apptask.Summarizer.
+type summarizerFunc func(ctx context.Context, title, description string) (string, error)
+
+func (f summarizerFunc) Summarize(ctx context.Context, title, description string) (string, error) {
+    return f(ctx, title, description)
+}
+
+// Test that summarize returns 501 without a summarizer, 504 on a timeout and
+// stores the summary only with persist=true.
+func TestHandlers_Summarize(t *testing.T) {
+    repo := memory.NewTaskRepository()
+    tk := domaintask.New("t1", "u1", "title", "a long description", 5)
+    if err := repo.Create(context.Background(), tk); err != nil {
+        t.Fatalf("seed: %v", err)
+    }
+    post := func(app *fiber.App, path string) *http.Response {
+        resp, err := app.Test(httptest.NewRequest("POST", path, nil), -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        return resp
+    }
+
+    if resp := post(newTestApp(apptask.NewService(repo)), "/tasks/"+tk.ID+"/summarize"); resp.StatusCode != fiber.StatusNotImplemented {
+        t.Fatalf("expected status %d, got %d", fiber.StatusNotImplemented, resp.StatusCode)
+    }
+
+    slow := apptask.NewService(repo, apptask.WithSummarizer(summarizerFunc(func(context.Context, string, string) (string, error) {
+        return "", context.DeadlineExceeded
+    })))
+    if resp := post(newTestApp(slow), "/tasks/"+tk.ID+"/summarize?persist=true"); resp.StatusCode != fiber.StatusGatewayTimeout {
+        t.Fatalf("expected status %d, got %d", fiber.StatusGatewayTimeout, resp.StatusCode)
+    }
+
+    app := newTestApp(apptask.NewService(repo, apptask.WithSummarizer(summarizerFunc(func(_ context.Context, title, _ string) (string, error) {
+        return "summary of " + title, nil
+    }))))
+    if resp := post(app, "/tasks/"+absentID+"/summarize"); resp.StatusCode != fiber.StatusNotFound {
+        t.Fatalf("expected status %d, got %d", fiber.StatusNotFound, resp.StatusCode)
+    }
+    resp := post(app, "/tasks/"+tk.ID+"/summarize")
+    var out struct {
+        Summary string `json:"summary"`
+    }
+    if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+    if resp.StatusCode != fiber.StatusOK || out.Summary != "summary of title" {
+        t.Fatalf("expected summary, got %d %+v", resp.StatusCode, out)
+    }
+    if got, _ := repo.Get(context.Background(), "t1", tk.ID); got.Summary != "" {
+        t.Fatalf("expected summary not stored without persist, got %q", got.Summary)
+    }
+    post(app, "/tasks/"+tk.ID+"/summarize?persist=true")
+    if got, _ := repo.Get(context.Background(), "t1", tk.ID); got.Summary != "summary of title" {
+        t.Fatalf("expected summary stored, got %q", got.Summary)
+    }
+}
+
+// generatorFunc adapts a function to apptask.SubtaskGenerator.
+type generatorFunc func(ctx context.Context, title, description string) ([]string, error)
+
+func (f generatorFunc) GenerateSubtasks(ctx context.Context, title, description string) ([]string, error) {
+    return f(ctx, title, description)
+}
+
+// Test that generate-subtasks returns 501 without a generator, 502 when the
+// model's answer is unusable, a preview by default and created subtasks
+// with "apply": true.
+func TestHandlers_GenerateSubtasks(t *testing.T) {
+    repo := memory.NewTaskRepository()
+    tk := domaintask.New("t1", "u1", "ship release", "", 5)
+    if err := repo.Create(context.Background(), tk); err != nil {
+        t.Fatalf("seed: %v", err)
+    }
+    post := func(app *fiber.App, body string) *http.Response {
+        req := httptest.NewRequest("POST", "/tasks/"+tk.ID+"/generate-subtasks", strings.NewReader(body))
+        req.Header.Set("Content-Type", "application/json")
+        resp, err := app.Test(req, -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        return resp
+    }
+
+    if resp := post(newTestApp(apptask.NewService(repo)), ""); resp.StatusCode != fiber.StatusNotImplemented {
+        t.Fatalf("expected status %d, got %d", fiber.StatusNotImplemented, resp.StatusCode)
+    }
+    malformed := apptask.NewService(repo, apptask.WithSubtaskGenerator(generatorFunc(func(context.Context, string, string) ([]string, error) {
+        return nil, errors.New("ai response content: invalid character")
+    })))
+    if resp := post(newTestApp(malformed), `{"apply":true}`); resp.StatusCode != fiber.StatusBadGateway {
+        t.Fatalf("expected status %d, got %d", fiber.StatusBadGateway, resp.StatusCode)
+    }
+
+    app := newTestApp(apptask.NewService(repo, apptask.WithSubtaskGenerator(generatorFunc(func(context.Context, string, string) ([]string, error) {
+        return []string{"write notes", "tag build", "announce"}, nil
+    }))))
+    var preview struct {
+        Steps []string `json:"steps"`
+    }
+    resp := post(app, "")
+    if err := json.NewDecoder(resp.Body).Decode(&preview); err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+    if resp.StatusCode != fiber.StatusOK || len(preview.Steps) != 3 {
+        t.Fatalf("expected a 3-step preview, got %d %+v", resp.StatusCode, preview)
+    }
+    if all, _ := repo.ListByTenant(context.Background(), "t1"); len(all) != 1 {
+        t.Fatalf("expected preview to create nothing, got %d tasks", len(all))
+    }
+
+    var applied struct {
+        Created []domaintask.Task `json:"created"`
+    }
+    resp = post(app, `{"apply":true}`)
+    if err := json.NewDecoder(resp.Body).Decode(&applied); err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+    if resp.StatusCode != fiber.StatusCreated || len(applied.Created) != 3 || applied.Created[0].ParentID == nil || *applied.Created[0].ParentID != tk.ID {
+        t.Fatalf("expected 3 subtasks of %s, got %d %+v", tk.ID, resp.StatusCode, applied.Created)
+    }
+}
+
+// Test that quick add echoes the parse, creates nothing on a dry run and
+// otherwise answers 201 with the created task.
+func TestHandlers_QuickAdd(t *testing.T) {
+    repo := memory.NewTaskRepository()
+    app := newTestApp(apptask.NewService(repo))
+    post := func(query, body string) *http.Response {
+        req := httptest.NewRequest("POST", "/tasks/quick"+query, strings.NewReader(body))
+        req.Header.Set("Content-Type", "application/json")
+        resp, err := app.Test(req, -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        return resp
+    }
+    type result struct {
+        Parsed apptask.QuickAdd `json:"parsed"`
+        Task   *domaintask.Task `json:"task"`
+    }
+    line := `{"text":"Ship invoices report by friday 5pm #billing p1 @alex"}`
+
+    var dry result
+    resp := post("?dryRun=true", line)
+    if err := json.NewDecoder(resp.Body).Decode(&dry); err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+    if resp.StatusCode != fiber.StatusOK || dry.Task != nil || dry.Parsed.Title != "Ship invoices report" || dry.Parsed.DueDate == nil {
+        t.Fatalf("expected a parse only, got %d %+v", resp.StatusCode, dry)
+    }
+    if all, _ := repo.ListByTenant(context.Background(), "t1"); len(all) != 0 {
+        t.Fatalf("expected dry run to create nothing, got %d tasks", len(all))
+    }
+
+    var created result
+    resp = post("", line)
+    if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+    if resp.StatusCode != fiber.StatusCreated || created.Task == nil || created.Task.Priority != 10 ||
+        len(created.Task.Tags) != 1 || created.Task.AssigneeID == nil || *created.Task.AssigneeID != "alex" {
+        t.Fatalf("expected the parsed task to be created, got %d %+v", resp.StatusCode, created.Task)
+    }
+
+    if resp := post("", `{"text":"  "}`); resp.StatusCode != fiber.StatusBadRequest {
+        t.Fatalf("expected status %d for blank text, got %d", fiber.StatusBadRequest, resp.StatusCode)
+    }
+}
+
+// Test the dependency endpoints: adding answers 201, a cycle 409, listing
+// shows both directions, a blocked PATCH to done 409 and removal 204.
+func TestHandlers_Dependencies(t *testing.T) {
+    ctx := context.Background()
+    svc := apptask.NewService(memory.NewTaskRepository(), apptask.WithDoneBlocking(true))
+    app := newTestApp(svc)
+    a, _ := svc.Create(ctx, "t1", "u1", "a", "", 5)
+    b, _ := svc.Create(ctx, "t1", "u1", "b", "", 5)
+    do := func(method, path, body string) *http.Response {
+        req := httptest.NewRequest(method, path, strings.NewReader(body))
+        if body != "" {
+            req.Header.Set("Content-Type", "application/json")
+        }
+        resp, err := app.Test(req, -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        return resp
+    }
+
+    if resp := do("POST", "/tasks/"+b.ID+"/dependencies", `{"dependsOnId":"`+a.ID+`"}`); resp.StatusCode != fiber.StatusCreated {
+        t.Fatalf("expected status %d, got %d", fiber.StatusCreated, resp.StatusCode)
+    }
+    if resp := do("POST", "/tasks/"+a.ID+"/dependencies", `{"dependsOnId":"`+b.ID+`"}`); resp.StatusCode != fiber.StatusConflict {
+        t.Fatalf("expected a cycle to get %d, got %d", fiber.StatusConflict, resp.StatusCode)
+    }
+    if resp := do("POST", "/tasks/"+a.ID+"/dependencies", `{"dependsOnId":"`+a.ID+`"}`); resp.StatusCode != fiber.StatusBadRequest {
+        t.Fatalf("expected a self-dependency to get %d, got %d", fiber.StatusBadRequest, resp.StatusCode)
+    }
+
+    var listed struct {
+        DependsOn []domaintask.Task `json:"dependsOn"`
+        Blocks    []domaintask.Task `json:"blocks"`
+    }
+    resp := do("GET", "/tasks/"+a.ID+"/dependencies", "")
+    if err := json.NewDecoder(resp.Body).Decode(&listed); err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+    if len(listed.DependsOn) != 0 || len(listed.Blocks) != 1 || listed.Blocks[0].ID != b.ID {
+        t.Fatalf("expected a to block b, got %+v", listed)
+    }
+
+    if resp := do("PATCH", "/tasks/"+b.ID, `{"status":"done"}`); resp.StatusCode != fiber.StatusConflict {
+        t.Fatalf("expected finishing a blocked task to get %d, got %d", fiber.StatusConflict, resp.StatusCode)
+    }
+    if resp := do("DELETE", "/tasks/"+b.ID+"/dependencies/"+a.ID, ""); resp.StatusCode != fiber.StatusNoContent {
+        t.Fatalf("expected status %d, got %d", fiber.StatusNoContent, resp.StatusCode)
+    }
+    if resp := do("PATCH", "/tasks/"+b.ID, `{"status":"done"}`); resp.StatusCode != fiber.StatusOK {
+        t.Fatalf("expected finishing an unblocked task to succeed, got %d", resp.StatusCode)
+    }
+}
+
+// Test that the agenda reads days in the X-Timezone zone, else the tenant's
+// zone, else UTC, and refuses unknown zones.
+func TestHandlers_Agenda(t *testing.T) {
+    now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+    due := time.Date(2026, 3, 10, 20, 0, 0, 0, time.UTC) // 03:00 the next day in Jakarta
+    jakarta, _ := time.LoadLocation("Asia/Jakarta")
+    newApp := func(opts ...apptask.Option) *fiber.App {
+        repo := memory.NewTaskRepository()
+        tk := domaintask.New("t1", "u1", "task", "", 5)
+        tk.DueDate = &due
+        repo.Create(context.Background(), tk)
+        h := NewHandlers(apptask.NewService(repo, opts...))
+        h.Now = func() time.Time { return now }
+        app := fiber.New()
+        app.Use(func(c *fiber.Ctx) error {
+            middleware.SetClaims(c, identity.Claims{TenantID: "t1", UserID: "u1"})
+            return c.Next()
+        })
+        h.Register(app.Group("/tasks"))
+        return app
+    }
+    get := func(app *fiber.App, zone string) (int, agendaResponse) {
+        req := httptest.NewRequest("GET", "/tasks/agenda", nil)
+        if zone != "" {
+            req.Header.Set("X-Timezone", zone)
+        }
+        resp, err := app.Test(req, -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        var out agendaResponse
+        if resp.StatusCode == fiber.StatusOK {
+            if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+                t.Fatalf("decode: %v", err)
+            }
+        }
+        return resp.StatusCode, out
+    }
+
+    for _, tc := range []struct {
+        name            string
+        app             *fiber.App
+        zone            string
+        wantZone        string
+        today, tomorrow int
+    }{
+        {"utc default", newApp(), "", "UTC", 1, 0},
+        {"header", newApp(), "Asia/Jakarta", "Asia/Jakarta", 0, 1},
+        {"tenant setting", newApp(apptask.WithTimezones(map[string]*time.Location{"t1": jakarta})), "", "Asia/Jakarta", 0, 1},
+        {"header over tenant", newApp(apptask.WithTimezones(map[string]*time.Location{"t1": jakarta})), "UTC", "UTC", 1, 0},
+    } {
+        status, out := get(tc.app, tc.zone)
+        if status != fiber.StatusOK {
+            t.Fatalf("%s: expected status %d, got %d", tc.name, fiber.StatusOK, status)
+        }
+        if out.Timezone != tc.wantZone || len(out.Today) != tc.today || len(out.Tomorrow) != tc.tomorrow {
+            t.Fatalf("%s: expected %s with %d today and %d tomorrow, got %+v", tc.name, tc.wantZone, tc.today, tc.tomorrow, out)
+        }
+    }
+    if status, _ := get(newApp(), "Mars/Olympus"); status != fiber.StatusBadRequest {
+        t.Fatalf("expected status %d for an unknown zone, got %d", fiber.StatusBadRequest, status)
+    }
+}
+
+// Test that an import dry run answers 200 with the report and creates
+// nothing even when every row is valid, that a real import answers 201 with
+// the tasks, and that an invalid one answers 422 with the row errors.
+func TestHandlers_Import(t *testing.T) {
+    svc := apptask.NewService(memory.NewTaskRepository())
+    app := newTestApp(svc)
+    post := func(query, body string) (int, map[string]any) {
+        req := httptest.NewRequest("POST", "/tasks/import"+query, strings.NewReader(body))
+        req.Header.Set("Content-Type", "text/csv")
+        resp, err := app.Test(req, -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        var out map[string]any
+        json.NewDecoder(resp.Body).Decode(&out)
+        return resp.StatusCode, out
+    }
+    valid := "title,priority\nOne,3\nTwo,4\n"
+
+    status, out := post("?dryRun=true", valid)
+    if status != fiber.StatusOK || out["valid"] != true || out["rowCount"] != float64(2) || out["created"] != nil {
+        t.Fatalf("expected a valid dry-run report, got %d %v", status, out)
+    }
+    if items, _ := svc.List(context.Background(), "t1"); len(items) != 0 {
+        t.Fatalf("expected the dry run to create nothing, got %d tasks", len(items))
+    }
+
+    status, out = post("?dryRun=true", "title,priority\nOne,11\n")
+    errs, _ := out["errors"].([]any)
+    if status != fiber.StatusOK || out["valid"] != false || len(errs) != 1 {
+        t.Fatalf("expected an invalid dry-run report, got %d %v", status, out)
+    }
+    if e := errs[0].(map[string]any); e["row"] != float64(2) || e["field"] != "priority" || e["message"] != "must be 1-10" {
+        t.Fatalf("expected row 2 priority must be 1-10, got %v", e)
+    }
+    if status, _ = post("", "title,priority\nOne,11\n"); status != fiber.StatusUnprocessableEntity {
+        t.Fatalf("expected status %d, got %d", fiber.StatusUnprocessableEntity, status)
+    }
+
+    status, out = post("", valid)
+    if created, _ := out["created"].([]any); status != fiber.StatusCreated || len(created) != 2 {
+        t.Fatalf("expected 2 tasks created, got %d %v", status, out)
+    }
+    if items, _ := svc.List(context.Background(), "t1"); len(items) != 2 {
+        t.Fatalf("expected 2 tasks stored, got %d", len(items))
+    }
+}
+
+// jobRunner is a JobRunner that only records the job types it was given.
+type jobRunner struct {
+    types []string
+}
+
+func (r *jobRunner) Enqueue(_ context.Context, jobType string, _ []byte, _ ...appjob.EnqueueOption) (string, error) {
+    r.types = append(r.types, jobType)
+    return "11111111-1111-1111-1111-111111111111", nil
+}
+
+// Test that an import too large to run in the request is answered with 202
+// and the queued job to poll.
+func TestHandlers_Import_Job(t *testing.T) {
+    runner := &jobRunner{}
+    app := newTestApp(apptask.NewService(memory.NewTaskRepository(), apptask.WithJobRunner(runner)))
+    body := "title\n" + strings.Repeat("Task\n", apptask.AsyncImportRows+1)
+    req := httptest.NewRequest("POST", "/tasks/import", strings.NewReader(body))
+    req.Header.Set("Content-Type", "text/csv")
+    resp, err := app.Test(req, -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    var out map[string]any
+    json.NewDecoder(resp.Body).Decode(&out)
+    if resp.StatusCode != fiber.StatusAccepted || out["jobId"] != "11111111-1111-1111-1111-111111111111" || out["status"] != "queued" {
+        t.Fatalf("expected 202 with the queued job, got %d %v", resp.StatusCode, out)
+    }
+    if loc := resp.Header.Get("Location"); loc != "/api/v1/jobs/11111111-1111-1111-1111-111111111111" {
+        t.Fatalf("expected the job's status URL as Location, got %q", loc)
+    }
+    if len(runner.types) != 1 || runner.types[0] != apptask.ImportJobType {
+        t.Fatalf("expected one import job, got %v", runner.types)
+    }
+}
+
+// Test that POST /from-template/:templateId creates a task from the template,
+// that body fields override it and that unknown templates answer 404.
+func TestHandlers_FromTemplate(t *testing.T) {
+    svc := apptask.NewService(memory.NewTaskRepository())
+    templates := apptemplate.NewService(memory.NewTemplateRepository(), svc)
+    tpl, err := templates.Create(context.Background(), "t1", apptemplate.Input{Name: "Bug report", TitlePattern: "Bug", Description: "- [ ] Steps", Priority: 8, Tags: []string{"bug"}})
+    if err != nil {
+        t.Fatalf("create template: %v", err)
+    }
+    app := fiber.New()
+    app.Use(func(c *fiber.Ctx) error {
+        middleware.SetClaims(c, identity.Claims{TenantID: "t1", UserID: "u1"})
+        return c.Next()
+    })
+    h := NewHandlers(svc)
+    h.Templates = templates
+    h.Register(app.Group("/tasks"))
+    post := func(id, body string) (int, domaintask.Task) {
+        req := httptest.NewRequest("POST", "/tasks/from-template/"+id, strings.NewReader(body))
+        if body != "" {
+            req.Header.Set("Content-Type", "application/json")
+        }
+        resp, err := app.Test(req, -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        var task domaintask.Task
+        json.NewDecoder(resp.Body).Decode(&task)
+        return resp.StatusCode, task
+    }
+
+    status, task := post(tpl.ID, "")
+    if status != fiber.StatusCreated || task.Title != "Bug" || task.Description != "- [ ] Steps" || task.Priority != 8 || len(task.Tags) != 1 {
+        t.Fatalf("expected a task with the template's fields, got %d %+v", status, task)
+    }
+    status, task = post(tpl.ID, `{"title":"Login fails","priority":2}`)
+    if status != fiber.StatusCreated || task.Title != "Login fails" || task.Priority != 2 || task.Description != "- [ ] Steps" {
+        t.Fatalf("expected the overrides applied over the template, got %d %+v", status, task)
+    }
+    if status, _ := post(tpl.ID, `{"priority":42}`); status != fiber.StatusBadRequest {
+        t.Fatalf("expected status %d, got %d", fiber.StatusBadRequest, status)
+    }
+    if status, _ := post(absentID, ""); status != fiber.StatusNotFound {
+        t.Fatalf("expected status %d, got %d", fiber.StatusNotFound, status)
+    }
+}
+
+// Test that ?format=trello imports a board export's cards as tasks, with
+// closed cards done, and that unknown formats and malformed JSON get 400.
+func TestHandlers_Import_Trello(t *testing.T) {
+    svc := apptask.NewService(memory.NewTaskRepository(), apptask.WithImportFormat(importer.FormatTrello, importer.NewTrelloImporter()))
+    app := newTestApp(svc)
+    post := func(query, body string) int {
+        req := httptest.NewRequest("POST", "/tasks/import"+query, strings.NewReader(body))
+        req.Header.Set("Content-Type", "application/json")
+        resp, err := app.Test(req, -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        return resp.StatusCode
+    }
+    board := `{"name":"Relaunch","cards":[
+        {"name":"Draft landing page","desc":"Hero","due":"2026-03-10T09:00:00.000Z","closed":false,"labels":[{"name":"Design","color":"green"}]},
+        {"name":"Fix contact form","desc":"","due":null,"closed":true,"labels":[]}
+    ]}`
+
+    if status := post("?format=trello", board); status != fiber.StatusCreated {
+        t.Fatalf("expected status %d, got %d", fiber.StatusCreated, status)
+    }
+    items, _ := svc.List(context.Background(), "t1")
+    byTitle := map[string]domaintask.Task{}
+    for _, it := range items {
+        byTitle[it.Title] = it
+    }
+    if draft := byTitle["Draft landing page"]; draft.Status != domaintask.StatusTodo || draft.DueDate == nil || len(draft.Tags) != 1 || draft.Tags[0] != "design" {
+        t.Fatalf("expected an open, dated task tagged design, got %+v", draft)
+    }
+    if fix := byTitle["Fix contact form"]; fix.Status != domaintask.StatusDone {
+        t.Fatalf("expected the closed card done, got %q", fix.Status)
+    }
+
+    if status := post("?format=asana", board); status != fiber.StatusBadRequest {
+        t.Fatalf("expected status %d for an unknown format, got %d", fiber.StatusBadRequest, status)
+    }
+    if status := post("?format=trello", `{"cards":`); status != fiber.StatusBadRequest {
+        t.Fatalf("expected status %d for malformed JSON, got %d", fiber.StatusBadRequest, status)
+    }
+}
+
+// Test that a Jira CSV export imports with its priorities mapped, and that
+// an issue without a summary rejects the import with an error on its row.
+func TestHandlers_Import_Jira(t *testing.T) {
+    svc := apptask.NewService(memory.NewTaskRepository(), apptask.WithImportFormat(importer.FormatJira, importer.NewJiraImporter()))
+    app := newTestApp(svc)
+    post := func(body string) *http.Response {
+        req := httptest.NewRequest("POST", "/tasks/import?format=jira", strings.NewReader(body))
+        req.Header.Set("Content-Type", ContentTypeCSV)
+        resp, err := app.Test(req, -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        return resp
+    }
+
+    resp := post("Issue key,Summary,Priority,Status\nWEB-1,Fix login,Highest,Done\nWEB-2,,Low,To Do\n")
+    if resp.StatusCode != fiber.StatusUnprocessableEntity {
+        t.Fatalf("expected status %d, got %d", fiber.StatusUnprocessableEntity, resp.StatusCode)
+    }
+    var res apptask.ImportResult
+    if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+    if len(res.Errors) != 1 || res.Errors[0].Row != 2 || res.Errors[0].Field != "title" {
+        t.Fatalf("expected a title error on item 2, got %+v", res.Errors)
+    }
+
+    if resp := post("Issue key,Summary,Priority,Status\nWEB-1,Fix login,Highest,Done\n"); resp.StatusCode != fiber.StatusCreated {
+        t.Fatalf("expected status %d, got %d", fiber.StatusCreated, resp.StatusCode)
+    }
+    items, _ := svc.List(context.Background(), "t1")
+    if len(items) != 1 || items[0].Priority != 10 || items[0].Status != domaintask.StatusDone {
+        t.Fatalf("expected one done task with priority 10, got %+v", items)
+    }
+}
+
+// Test that ?format=ical answers a text/calendar feed of the dated tasks and
+// that unknown formats get 400.
+func TestHandlers_ExportIcal(t *testing.T) {
+    repo := memory.NewTaskRepository()
+    due := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+    tk := domaintask.New("t1", "u1", "Ship v2", "", 5)
+    tk.DueDate = &due
+    repo.Create(context.Background(), tk)
+    app := newTestApp(apptask.NewService(repo))
+
+    resp, err := app.Test(httptest.NewRequest("GET", "/tasks/export?format=ical", nil), -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    body, _ := io.ReadAll(resp.Body)
+    if resp.StatusCode != fiber.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/calendar") {
+        t.Fatalf("expected 200 text/calendar, got %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+    }
+    if !strings.HasPrefix(string(body), "BEGIN:VCALENDAR\r\n") || !strings.Contains(string(body), "UID:"+tk.ID+"@mauflow\r\n") || !strings.HasSuffix(string(body), "END:VCALENDAR\r\n") {
+        t.Fatalf("expected a calendar with the task, got %q", body)
+    }
+
+    resp, err = app.Test(httptest.NewRequest("GET", "/tasks/export?format=docx", nil), -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    if resp.StatusCode != fiber.StatusBadRequest {
+        t.Fatalf("expected status %d, got %d", fiber.StatusBadRequest, resp.StatusCode)
+    }
+}
+
+// fakeReportWriter writes the tenant name and task count of each report.
+type fakeReportWriter struct{}
+
+func (fakeReportWriter) WriteReport(w io.Writer, r apptask.Report) error {
+    _, err := fmt.Fprintf(w, "%%PDF-fake %s %d", r.TenantName, len(r.Tasks))
+    return err
+}
+
+// Test that ?format=pdf answers the report as an application/pdf download and
+// 501 when no report writer is configured.
+func TestHandlers_ExportPDF(t *testing.T) {
+    repo := memory.NewTaskRepository()
+    repo.Create(context.Background(), domaintask.New("t1", "u1", "Ship v2", "", 5))
+    app := newTestApp(apptask.NewService(repo, apptask.WithReportWriter(fakeReportWriter{})))
+
+    resp, err := app.Test(httptest.NewRequest("GET", "/tasks/export?format=pdf", nil), -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    body, _ := io.ReadAll(resp.Body)
+    if resp.StatusCode != fiber.StatusOK || resp.Header.Get("Content-Type") != ContentTypePDF {
+        t.Fatalf("expected 200 %s, got %d %s", ContentTypePDF, resp.StatusCode, resp.Header.Get("Content-Type"))
+    }
+    if string(body) != "%PDF-fake t1 1" {
+        t.Fatalf("expected the report of t1's task, got %q", body)
+    }
+
+    app = newTestApp(apptask.NewService(repo))
+    resp, err = app.Test(httptest.NewRequest("GET", "/tasks/export?format=pdf", nil), -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    if resp.StatusCode != fiber.StatusNotImplemented {
+        t.Fatalf("expected status %d, got %d", fiber.StatusNotImplemented, resp.StatusCode)
+    }
+}
+
+// Test that PUT /tasks/:id/project moves the task, and answers 404 for an
+// unknown task or a project of another tenant and 400 without projectId.
+func TestHandlers_Move(t *testing.T) {
+    ctx := context.Background()
+    tasks := memory.NewTaskRepository()
+    projects := memory.NewProjectRepository(tasks)
+    mine, theirs := domainproject.New("t1", "Mine", ""), domainproject.New("t2", "Theirs", "")
+    for _, p := range []*domainproject.Project{mine, theirs} {
+        if err := projects.Create(ctx, p); err != nil {
+            t.Fatalf("create project: %v", err)
+        }
+    }
+    svc := apptask.NewService(tasks, apptask.WithProjectLookup(projects))
+    tk, _ := svc.Create(ctx, "t1", "u1", "a", "", 5)
+    app := newTestApp(svc)
+    put := func(id, body string) *http.Response {
+        req := httptest.NewRequest("PUT", "/tasks/"+id+"/project", strings.NewReader(body))
+        req.Header.Set("Content-Type", "application/json")
+        resp, err := app.Test(req, -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        return resp
+    }
+
+    resp := put(tk.ID, `{"projectId":"`+mine.ID+`"}`)
+    if resp.StatusCode != fiber.StatusOK {
+        t.Fatalf("expected status %d, got %d", fiber.StatusOK, resp.StatusCode)
+    }
+    var got struct {
+        ProjectID string `json:"projectId"`
+    }
+    if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+    if got.ProjectID != mine.ID {
+        t.Fatalf("expected project %s, got %q", mine.ID, got.ProjectID)
+    }
+
+    for _, tc := range []struct {
+        name, id, body string
+        want           int
+    }{
+        {"unknown task", absentID, `{"projectId":"` + mine.ID + `"}`, fiber.StatusNotFound},
+        {"other tenant's project", tk.ID, `{"projectId":"` + theirs.ID + `"}`, fiber.StatusNotFound},
+        {"unknown project", tk.ID, `{"projectId":"` + absentID + `"}`, fiber.StatusNotFound},
+        {"no project", tk.ID, `{}`, fiber.StatusBadRequest},
+    } {
+        if resp := put(tc.id, tc.body); resp.StatusCode != tc.want {
+            t.Fatalf("%s: expected status %d, got %d", tc.name, tc.want, resp.StatusCode)
+        }
+    }
+}
+
+// Test that POST /tasks/:id/reopen reopens a done task as in_progress and
+// answers 409 for a task that is still open.
+func TestHandlers_Reopen(t *testing.T) {
+    ctx := context.Background()
+    svc := apptask.NewService(memory.NewTaskRepository())
+    tk, _ := svc.Create(ctx, "t1", "u1", "a", "", 5)
+    app := newTestApp(svc)
+    reopen := func() *http.Response {
+        resp, err := app.Test(httptest.NewRequest("POST", "/tasks/"+tk.ID+"/reopen", nil), -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        return resp
+    }
+
+    if resp := reopen(); resp.StatusCode != fiber.StatusConflict {
+        t.Fatalf("expected status %d for a todo task, got %d", fiber.StatusConflict, resp.StatusCode)
+    }
+    done := domaintask.StatusDone
+    if _, err := svc.Update(ctx, "t1", tk.ID, apptask.Actor{UserID: "u1"}, apptask.UpdateTaskInput{Status: &done}); err != nil {
+        t.Fatalf("update: %v", err)
+    }
+    resp := reopen()
+    if resp.StatusCode != fiber.StatusOK {
+        t.Fatalf("expected status %d, got %d", fiber.StatusOK, resp.StatusCode)
+    }
+    var got struct {
+        Status string `json:"status"`
+    }
+    if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+    if got.Status != domaintask.StatusInProgress {
+        t.Fatalf("expected %s, got %q", domaintask.StatusInProgress, got.Status)
+    }
+}
@@ -0,0 +1,70 @@
+package task
+
+import (
+    "bytes"
+    "errors"
+
+    appjob "backend/internal/application/job"
+    apptask "backend/internal/application/task"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// ContentTypeCSV is the media type of import bodies.
+const ContentTypeCSV = "text/csv"
+
+type importResponse struct {
+    apptask.ImportResult
+    Created []taskResponse `json:"created,omitempty"`
+}
+
+// importTasks creates tasks from a CSV body (see apptask.SubmitImport) or,
+// with ?format=, another tool's JSON export such as a Trello board (see
+// apptask.ImportFormat). With ?dryRun=true every row is validated and the
+// report returned with 200, but nothing is created. Otherwise a valid import
+// answers 201 with the created tasks, and an invalid one 422 with the report,
+// unless a CSV import was large enough to become a job: then it answers 202
+// with the job to poll. YYYY-MM-DD due dates are read in the X-Timezone zone.
+func (h *Handlers) importTasks(c *fiber.Ctx) error {
+    tenantID, userID, err := tenantAndUser(c)
+    if err != nil {
+        return err
+    }
+    loc, err := h.location(c, tenantID)
+    if err != nil {
+        return err
+    }
+    dryRun := c.QueryBool("dryRun")
+    opts := apptask.ImportOptions{DryRun: dryRun, Location: loc}
+    var (
+        res   apptask.ImportResult
+        jobID string
+    )
+    if format := c.Query("format", "csv"); format == "csv" {
+        res, jobID, err = h.svc.SubmitImport(c.UserContext(), tenantID, userID, c.Body(), opts)
+    } else {
+        res, err = h.svc.ImportFormat(c.UserContext(), tenantID, userID, format, bytes.NewReader(c.Body()), opts)
+    }
+    switch {
+    case errors.Is(err, apptask.ErrUnknownImportFormat):
+        return fiber.NewError(fiber.StatusBadRequest, err.Error())
+    case errors.Is(err, apptask.ErrInvalidCSV), errors.Is(err, apptask.ErrInvalidImport):
+        return fiber.NewError(fiber.StatusBadRequest, err.Error())
+    case errors.Is(err, apptask.ErrImportTooLarge):
+        return fiber.NewError(fiber.StatusRequestEntityTooLarge, err.Error())
+    case err != nil:
+        return fiber.ErrInternalServerError
+    }
+    if jobID != "" {
+        c.Location("/api/v1/jobs/" + jobID)
+        return c.Status(fiber.StatusAccepted).JSON(fiber.Map{"jobId": jobID, "status": appjob.StatusQueued})
+    }
+    out := importResponse{ImportResult: res, Created: h.toResponses(res.Created)}
+    switch {
+    case dryRun:
+        return c.JSON(out)
+    case !res.Valid:
+        return c.Status(fiber.StatusUnprocessableEntity).JSON(out)
+    }
+    return c.Status(fiber.StatusCreated).JSON(out)
+}
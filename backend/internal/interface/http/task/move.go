@@ -0,0 +1,36 @@
+package task
+
+import (
+    "errors"
+
+    apptask "backend/internal/application/task"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+type moveRequest struct {
+    ProjectID string `json:"projectId"`
+}
+
+// move transfers the task to another project of the tenant; an unknown task
+// or project gets 404.
+func (h *Handlers) move(c *fiber.Ctx) error {
+    tenantID, userID, err := tenantAndUser(c)
+    if err != nil {
+        return err
+    }
+    var req moveRequest
+    if err := c.BodyParser(&req); err != nil || req.ProjectID == "" {
+        return fiber.NewError(fiber.StatusBadRequest, "projectId is required")
+    }
+    t, err := h.svc.Move(c.UserContext(), tenantID, c.Params("id"), h.actor(userID), req.ProjectID)
+    switch {
+    case errors.Is(err, apptask.ErrNotFound), errors.Is(err, apptask.ErrProjectNotFound):
+        return fiber.NewError(fiber.StatusNotFound, err.Error())
+    case errors.Is(err, apptask.ErrForbidden):
+        return fiber.NewError(fiber.StatusForbidden, err.Error())
+    case err != nil:
+        return fiber.ErrInternalServerError
+    }
+    return c.JSON(h.toResponse(*t))
+}
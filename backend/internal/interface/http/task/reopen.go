@@ -0,0 +1,42 @@
+package task
+
+import (
+    "errors"
+
+    apptask "backend/internal/application/task"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+type reopenRequest struct {
+    Status string `json:"status"`
+}
+
+// reopen moves a done or archived task back to in_progress, or to the
+// optional body's status; an open task gets 409.
+func (h *Handlers) reopen(c *fiber.Ctx) error {
+    tenantID, userID, err := tenantAndUser(c)
+    if err != nil {
+        return err
+    }
+    var req reopenRequest
+    if len(c.Body()) > 0 {
+        if err := c.BodyParser(&req); err != nil {
+            return fiber.ErrBadRequest
+        }
+    }
+    t, err := h.svc.Reopen(c.UserContext(), tenantID, c.Params("id"), h.actor(userID), req.Status)
+    switch {
+    case errors.Is(err, apptask.ErrNotFound):
+        return fiber.ErrNotFound
+    case errors.Is(err, apptask.ErrForbidden):
+        return fiber.NewError(fiber.StatusForbidden, err.Error())
+    case errors.Is(err, apptask.ErrNotCompleted):
+        return fiber.NewError(fiber.StatusConflict, err.Error())
+    case errors.Is(err, apptask.ErrInvalidReopenStatus):
+        return fiber.NewError(fiber.StatusBadRequest, err.Error())
+    case err != nil:
+        return fiber.ErrInternalServerError
+    }
+    return c.JSON(h.toResponse(*t))
+}
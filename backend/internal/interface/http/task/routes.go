@@ -2,16 +2,53 @@ package task
 
 import (
     apptask "backend/internal/application/task"
+    "backend/internal/interface/http/middleware"
 
     "github.com/gofiber/fiber/v2"
 )
 
-// RegisterRoutes wires task routes to the provided router.
-func RegisterRoutes(r fiber.Router, svc *apptask.Service) {
+// RegisterRoutes wires task routes to the provided router. events feeds the
+// event stream and may be nil; adminUserIDs may list a task's watchers.
+func RegisterRoutes(r fiber.Router, svc *apptask.Service, events apptask.EventSubscriber, adminUserIDs []string) {
     h := NewHandlers(svc)
+    h.Events = events
+    h.AdminUserIDs = adminUserIDs
+    h.Register(r)
+}
+
+// Register wires h's routes to the provided router.
+func (h *Handlers) Register(r fiber.Router) {
+    jsonBody := middleware.RequireContentType(middleware.ContentTypeJSON)
+    patchBody := middleware.RequireContentType()
+    id := middleware.RequireUUIDParams("id")
+    region := func(c *fiber.Ctx) error { return c.Next() }
+    if h.Regions != nil {
+        region = middleware.RegionMiddleware(h.Regions)
+    }
     r.Get("/", h.list)
-    r.Post("/", h.create)
-    r.Get("/:id", h.get)
-    r.Patch("/:id", h.patch)
-    r.Delete("/:id", h.delete)
+    r.Post("/", region, jsonBody, h.create)
+    r.Get("/mine", h.mine)
+    r.Get("/agenda", h.agenda)
+    r.Get("/export", h.export)
+    r.Get("/stream", h.stream)
+    r.Post("/bulk-assign", region, jsonBody, h.bulkAssign)
+    r.Post("/quick", region, jsonBody, h.quickAdd)
+    r.Post("/import", region, middleware.RequireContentType(ContentTypeCSV, middleware.ContentTypeJSON), h.importTasks)
+    if h.Templates != nil {
+        r.Post("/from-template/:templateId", middleware.RequireUUIDParams("templateId"), region, jsonBody, h.fromTemplate)
+    }
+    r.Get("/:id", id, h.get)
+    r.Get("/:id/description/html", id, h.descriptionHTML)
+    r.Post("/:id/summarize", id, region, h.summarize)
+    r.Post("/:id/generate-subtasks", id, region, jsonBody, h.generateSubtasks)
+    r.Patch("/:id", id, region, patchBody, h.patch)
+    r.Put("/:id/project", id, region, jsonBody, h.move)
+    r.Post("/:id/reopen", id, region, jsonBody, h.reopen)
+    r.Delete("/:id", id, h.delete)
+    r.Post("/:id/watch", id, h.watch)
+    r.Delete("/:id/watch", id, h.unwatch)
+    r.Get("/:id/watchers", id, middleware.RequireAdmin(h.AdminUserIDs), h.watchers)
+    r.Get("/:id/dependencies", id, h.dependencies)
+    r.Post("/:id/dependencies", id, jsonBody, h.addDependency)
+    r.Delete("/:id/dependencies/:dependsOnId", middleware.RequireUUIDParams("id", "dependsOnId"), h.removeDependency)
 }
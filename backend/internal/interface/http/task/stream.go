@@ -0,0 +1,83 @@
+package task
+
+import (
+    "bufio"
+    "encoding/json"
+    "fmt"
+    "time"
+
+    domaintask "backend/internal/domain/task"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// defaultHeartbeat is how often an idle stream sends a comment line so
+// proxies keep the connection open and disconnects are noticed.
+const defaultHeartbeat = 15 * time.Second
+
+// stream sends the caller's tenant task events as server-sent events, each
+// named after the event (task.created, task.updated, ...) with the event as
+// JSON data. The subscription ends when a write fails because the client
+// went away.
+func (h *Handlers) stream(c *fiber.Ctx) error {
+    if h.Events == nil {
+        return fiber.NewError(fiber.StatusNotImplemented, "task events are not available")
+    }
+    tenantID, _, err := tenantAndUser(c)
+    if err != nil {
+        return err
+    }
+    events, unsubscribe := h.Events.Subscribe(tenantID)
+    heartbeat := h.Heartbeat
+    if heartbeat <= 0 {
+        heartbeat = defaultHeartbeat
+    }
+
+    c.Set(fiber.HeaderContentType, "text/event-stream")
+    c.Set(fiber.HeaderCacheControl, "no-cache")
+    c.Set(fiber.HeaderConnection, "keep-alive")
+    c.Set("X-Accel-Buffering", "no")
+    c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+        defer unsubscribe()
+        ticker := time.NewTicker(heartbeat)
+        defer ticker.Stop()
+
+        if err := writeComment(w, "connected"); err != nil {
+            return
+        }
+        for {
+            select {
+            case e, ok := <-events:
+                if !ok {
+                    return
+                }
+                if err := writeEvent(w, e); err != nil {
+                    return
+                }
+            case <-ticker.C:
+                if err := writeComment(w, "heartbeat"); err != nil {
+                    return
+                }
+            }
+        }
+    })
+    return nil
+}
+
+func writeComment(w *bufio.Writer, text string) error {
+    if _, err := fmt.Fprintf(w, ": %s\n\n", text); err != nil {
+        return err
+    }
+    return w.Flush()
+}
+
+func writeEvent(w *bufio.Writer, e domaintask.Event) error {
+    data, err := json.Marshal(e)
+    if err != nil {
+        return err
+    }
+    if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.EventName(), data); err != nil {
+        return err
+    }
+    return w.Flush()
+}
@@ -0,0 +1,57 @@
+package task
+
+import (
+    "errors"
+    "time"
+
+    apptask "backend/internal/application/task"
+    apptemplate "backend/internal/application/template"
+    domaintask "backend/internal/domain/task"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// fromTemplateRequest overrides template fields; omitted fields keep the
+// template's value.
+type fromTemplateRequest struct {
+    Title       *string    `json:"title"`
+    Description *string    `json:"description"`
+    Priority    *int       `json:"priority"`
+    DueDate     *time.Time `json:"dueDate"`
+    AssigneeID  *string    `json:"assigneeId"`
+    Tags        []string   `json:"tags"`
+}
+
+// fromTemplate creates a task from the tenant's template :templateId, with
+// the fields of the optional body taking precedence.
+func (h *Handlers) fromTemplate(c *fiber.Ctx) error {
+    tenantID, userID, err := tenantAndUser(c)
+    if err != nil {
+        return err
+    }
+    var req fromTemplateRequest
+    if len(c.Body()) > 0 {
+        if err := c.BodyParser(&req); err != nil {
+            return fiber.ErrBadRequest
+        }
+    }
+    t, err := h.Templates.Instantiate(c.UserContext(), tenantID, userID, c.Params("templateId"), apptemplate.Overrides{
+        Title:       req.Title,
+        Description: req.Description,
+        Priority:    req.Priority,
+        DueDate:     req.DueDate,
+        AssigneeID:  req.AssigneeID,
+        Tags:        req.Tags,
+    })
+    switch {
+    case errors.Is(err, apptemplate.ErrNotFound):
+        return fiber.ErrNotFound
+    case errors.Is(err, domaintask.ErrTooLong):
+        return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+    case errors.Is(err, domaintask.ErrInvalidPriority), errors.Is(err, domaintask.ErrRequired), errors.Is(err, apptask.ErrParentNotFound):
+        return fiber.NewError(fiber.StatusBadRequest, err.Error())
+    case err != nil:
+        return fiber.ErrInternalServerError
+    }
+    return c.Status(fiber.StatusCreated).JSON(h.toResponse(*t))
+}
@@ -0,0 +1,53 @@
+package task
+
+import (
+    "strings"
+
+    "backend/internal/interface/http/paging"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// watch subscribes the caller to changes of the task.
+func (h *Handlers) watch(c *fiber.Ctx) error {
+    tenantID, userID, err := tenantAndUser(c)
+    if err != nil {
+        return err
+    }
+    // Params alias the request buffer; the ID is stored with the watcher.
+    taskID := strings.Clone(c.Params("id"))
+    if err := h.svc.WatchTask(c.UserContext(), tenantID, taskID, userID); err != nil {
+        return notFoundOr500(err)
+    }
+    return c.SendStatus(fiber.StatusNoContent)
+}
+
+// unwatch removes the caller's subscription to the task.
+func (h *Handlers) unwatch(c *fiber.Ctx) error {
+    tenantID, userID, err := tenantAndUser(c)
+    if err != nil {
+        return err
+    }
+    if err := h.svc.UnwatchTask(c.UserContext(), tenantID, c.Params("id"), userID); err != nil {
+        return notFoundOr500(err)
+    }
+    return c.SendStatus(fiber.StatusNoContent)
+}
+
+// watchers lists a page of who watches the task; the route is restricted to
+// admins.
+func (h *Handlers) watchers(c *fiber.Ctx) error {
+    tenantID, _, err := tenantAndUser(c)
+    if err != nil {
+        return err
+    }
+    page, err := paging.FromQuery(c)
+    if err != nil {
+        return err
+    }
+    items, err := h.svc.ListWatchers(c.UserContext(), tenantID, c.Params("id"))
+    if err != nil {
+        return notFoundOr500(err)
+    }
+    return paging.Send(c, paging.Slice(items, page))
+}
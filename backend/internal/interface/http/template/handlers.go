@@ -0,0 +1,102 @@
+package template
+
+import (
+    "errors"
+
+    apptemplate "backend/internal/application/template"
+    domaintask "backend/internal/domain/task"
+    "backend/internal/interface/http/middleware"
+    "backend/internal/interface/http/paging"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+type Handlers struct {
+    svc *apptemplate.Service
+}
+
+func NewHandlers(svc *apptemplate.Service) *Handlers { return &Handlers{svc: svc} }
+
+type templateRequest struct {
+    Name         string   `json:"name"`
+    TitlePattern string   `json:"titlePattern"`
+    Description  string   `json:"description"`
+    Priority     int      `json:"priority"`
+    Tags         []string `json:"tags"`
+}
+
+func (r templateRequest) input() apptemplate.Input {
+    return apptemplate.Input{Name: r.Name, TitlePattern: r.TitlePattern, Description: r.Description, Priority: r.Priority, Tags: r.Tags}
+}
+
+func tenantOf(c *fiber.Ctx) string {
+    return middleware.ClaimsOf(c).TenantID
+}
+
+func (h *Handlers) list(c *fiber.Ctx) error {
+    page, err := paging.FromQuery(c)
+    if err != nil {
+        return err
+    }
+    items, err := h.svc.List(c.UserContext(), tenantOf(c))
+    if err != nil {
+        return fiber.ErrInternalServerError
+    }
+    return paging.Send(c, paging.Slice(items, page))
+}
+
+func (h *Handlers) create(c *fiber.Ctx) error {
+    var req templateRequest
+    if err := c.BodyParser(&req); err != nil {
+        return fiber.ErrBadRequest
+    }
+    t, err := h.svc.Create(c.UserContext(), tenantOf(c), req.input())
+    if err != nil {
+        return templateError(err)
+    }
+    return c.Status(fiber.StatusCreated).JSON(t)
+}
+
+func (h *Handlers) get(c *fiber.Ctx) error {
+    t, err := h.svc.Get(c.UserContext(), tenantOf(c), c.Params("id"))
+    if err != nil {
+        return templateError(err)
+    }
+    return c.JSON(t)
+}
+
+// replace overwrites every field of the template; omitted fields are
+// cleared.
+func (h *Handlers) replace(c *fiber.Ctx) error {
+    var req templateRequest
+    if err := c.BodyParser(&req); err != nil {
+        return fiber.ErrBadRequest
+    }
+    t, err := h.svc.Update(c.UserContext(), tenantOf(c), c.Params("id"), req.input())
+    if err != nil {
+        return templateError(err)
+    }
+    return c.JSON(t)
+}
+
+func (h *Handlers) delete(c *fiber.Ctx) error {
+    if err := h.svc.Delete(c.UserContext(), tenantOf(c), c.Params("id")); err != nil {
+        return templateError(err)
+    }
+    return c.SendStatus(fiber.StatusNoContent)
+}
+
+// templateError maps service errors to responses: 404 for unknown
+// templates, 422 for fields over their length limit and 400 for other
+// invalid fields.
+func templateError(err error) error {
+    switch {
+    case errors.Is(err, apptemplate.ErrNotFound):
+        return fiber.ErrNotFound
+    case errors.Is(err, domaintask.ErrTooLong):
+        return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+    case errors.Is(err, domaintask.ErrInvalidPriority), errors.Is(err, domaintask.ErrRequired):
+        return fiber.NewError(fiber.StatusBadRequest, err.Error())
+    }
+    return fiber.ErrInternalServerError
+}
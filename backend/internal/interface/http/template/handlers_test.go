@@ -0,0 +1,100 @@
+package template
+
+import (
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+
+    apptask "backend/internal/application/task"
+    apptemplate "backend/internal/application/template"
+    domaintask "backend/internal/domain/task"
+    "backend/internal/infrastructure/memory"
+    "backend/internal/interface/http/middleware"
+    "backend/internal/pkg/identity"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+func newTestApp() *fiber.App {
+    app := fiber.New()
+    app.Use(func(c *fiber.Ctx) error {
+        middleware.SetClaims(c, identity.Claims{TenantID: "t1", UserID: "u1"})
+        return c.Next()
+    })
+    svc := apptemplate.NewService(memory.NewTemplateRepository(), apptask.NewService(memory.NewTaskRepository()))
+    RegisterRoutes(app.Group("/task-templates"), svc)
+    return app
+}
+
+func send(t *testing.T, app *fiber.App, method, path, body string) *http.Response {
+    t.Helper()
+    req := httptest.NewRequest(method, path, strings.NewReader(body))
+    if body != "" {
+        req.Header.Set("Content-Type", "application/json")
+    }
+    resp, err := app.Test(req, -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    return resp
+}
+
+// Test that templates can be created, read, replaced and deleted, and that
+// invalid fields are rejected.
+func TestHandlers_CRUD(t *testing.T) {
+    app := newTestApp()
+
+    resp := send(t, app, "POST", "/task-templates/", `{"name":"Bug report","titlePattern":"Bug: {date}","description":"- [ ] Steps","priority":8,"tags":["Bug"]}`)
+    if resp.StatusCode != fiber.StatusCreated {
+        t.Fatalf("expected status %d, got %d", fiber.StatusCreated, resp.StatusCode)
+    }
+    var tpl domaintask.TaskTemplate
+    if err := json.NewDecoder(resp.Body).Decode(&tpl); err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+    if tpl.ID == "" || tpl.TitlePattern != "Bug: {date}" || len(tpl.Tags) != 1 || tpl.Tags[0] != "bug" {
+        t.Fatalf("expected the created template, got %+v", tpl)
+    }
+
+    if resp := send(t, app, "GET", "/task-templates/"+tpl.ID, ""); resp.StatusCode != fiber.StatusOK {
+        t.Fatalf("expected status %d, got %d", fiber.StatusOK, resp.StatusCode)
+    }
+    resp = send(t, app, "PUT", "/task-templates/"+tpl.ID, `{"name":"Bug","titlePattern":"Bug"}`)
+    var replaced domaintask.TaskTemplate
+    json.NewDecoder(resp.Body).Decode(&replaced)
+    if resp.StatusCode != fiber.StatusOK || replaced.Name != "Bug" || replaced.Priority != 0 || len(replaced.Tags) != 0 {
+        t.Fatalf("expected the template replaced, got %d %+v", resp.StatusCode, replaced)
+    }
+    resp = send(t, app, "GET", "/task-templates/", "")
+    var page struct {
+        Data []domaintask.TaskTemplate `json:"data"`
+    }
+    json.NewDecoder(resp.Body).Decode(&page)
+    if len(page.Data) != 1 {
+        t.Fatalf("expected 1 template listed, got %d", len(page.Data))
+    }
+
+    cases := []struct {
+        body string
+        want int
+    }{
+        {`{"name":"","titlePattern":"x"}`, fiber.StatusBadRequest},
+        {`{"name":"x","titlePattern":"x","priority":0}`, fiber.StatusCreated},
+        {`{"name":"x","titlePattern":"x","priority":42}`, fiber.StatusBadRequest},
+        {`{"name":"` + strings.Repeat("x", 101) + `","titlePattern":"x"}`, fiber.StatusUnprocessableEntity},
+    }
+    for _, tc := range cases {
+        if resp := send(t, app, "POST", "/task-templates/", tc.body); resp.StatusCode != tc.want {
+            t.Fatalf("%s: expected status %d, got %d", tc.body, tc.want, resp.StatusCode)
+        }
+    }
+
+    if resp := send(t, app, "DELETE", "/task-templates/"+tpl.ID, ""); resp.StatusCode != fiber.StatusNoContent {
+        t.Fatalf("expected status %d, got %d", fiber.StatusNoContent, resp.StatusCode)
+    }
+    if resp := send(t, app, "GET", "/task-templates/"+tpl.ID, ""); resp.StatusCode != fiber.StatusNotFound {
+        t.Fatalf("expected status %d, got %d", fiber.StatusNotFound, resp.StatusCode)
+    }
+}
@@ -0,0 +1,21 @@
+package template
+
+import (
+    apptemplate "backend/internal/application/template"
+    "backend/internal/interface/http/middleware"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// RegisterRoutes wires task template routes to the provided router. Tasks
+// are created from templates by the task routes.
+func RegisterRoutes(r fiber.Router, svc *apptemplate.Service) {
+    h := NewHandlers(svc)
+    id := middleware.RequireUUIDParams("id")
+    jsonBody := middleware.RequireContentType(middleware.ContentTypeJSON)
+    r.Get("/", h.list)
+    r.Post("/", jsonBody, h.create)
+    r.Get("/:id", id, h.get)
+    r.Put("/:id", id, jsonBody, h.replace)
+    r.Delete("/:id", id, h.delete)
+}
@@ -0,0 +1,103 @@
+package tenant
+
+import (
+    "errors"
+
+    appapikey "backend/internal/application/apikey"
+    apptenant "backend/internal/application/tenant"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+type Handlers struct {
+    svc      *apptenant.Service
+    keys     *appapikey.Service
+    settings *apptenant.SettingsService
+}
+
+func NewHandlers(svc *apptenant.Service, keys *appapikey.Service, settings *apptenant.SettingsService) *Handlers {
+    return &Handlers{svc: svc, keys: keys, settings: settings}
+}
+
+// purgeData hard-deletes all data of :tenantId. The confirm query parameter
+// must repeat the tenant id.
+func (h *Handlers) purgeData(c *fiber.Ctx) error {
+    res, err := h.svc.PurgeData(c.UserContext(), c.Params("tenantId"), c.Query("confirm"))
+    if errors.Is(err, apptenant.ErrConfirmationMismatch) {
+        return fiber.NewError(fiber.StatusBadRequest, err.Error())
+    }
+    if err != nil {
+        return fiber.ErrInternalServerError
+    }
+    return c.JSON(fiber.Map{"deleted": res})
+}
+
+type mintKeyRequest struct {
+    Name   string `json:"name"`
+    UserID string `json:"userId"`
+}
+
+// mintKey creates an API key for :tenantId, acting as userId when given. The
+// secret is only ever returned here.
+func (h *Handlers) mintKey(c *fiber.Ctx) error {
+    var req mintKeyRequest
+    if err := c.BodyParser(&req); err != nil {
+        return fiber.ErrBadRequest
+    }
+    k, secret, err := h.keys.Mint(c.UserContext(), c.Params("tenantId"), req.UserID, req.Name)
+    if errors.Is(err, appapikey.ErrNameRequired) {
+        return fiber.NewError(fiber.StatusBadRequest, err.Error())
+    }
+    if err != nil {
+        return fiber.ErrInternalServerError
+    }
+    return c.Status(fiber.StatusCreated).JSON(fiber.Map{"apiKey": k, "key": secret})
+}
+
+func (h *Handlers) listKeys(c *fiber.Ctx) error {
+    keys, err := h.keys.List(c.UserContext(), c.Params("tenantId"))
+    if err != nil {
+        return fiber.ErrInternalServerError
+    }
+    return c.JSON(keys)
+}
+
+func (h *Handlers) revokeKey(c *fiber.Ctx) error {
+    err := h.keys.Revoke(c.UserContext(), c.Params("tenantId"), c.Params("keyId"))
+    if errors.Is(err, appapikey.ErrNotFound) {
+        return fiber.ErrNotFound
+    }
+    if err != nil {
+        return fiber.ErrInternalServerError
+    }
+    return c.SendStatus(fiber.StatusNoContent)
+}
+
+type settingsRequest struct {
+    Region string `json:"region"`
+}
+
+func (h *Handlers) getSettings(c *fiber.Ctx) error {
+    st, err := h.settings.Get(c.UserContext(), c.Params("tenantId"))
+    if err != nil {
+        return fiber.ErrInternalServerError
+    }
+    return c.JSON(st)
+}
+
+// putSettings sets the data residency region of :tenantId; an empty region
+// lifts the requirement.
+func (h *Handlers) putSettings(c *fiber.Ctx) error {
+    var req settingsRequest
+    if err := c.BodyParser(&req); err != nil {
+        return fiber.ErrBadRequest
+    }
+    st, err := h.settings.SetRegion(c.UserContext(), c.Params("tenantId"), req.Region)
+    if errors.Is(err, apptenant.ErrInvalidRegion) {
+        return fiber.NewError(fiber.StatusBadRequest, err.Error())
+    }
+    if err != nil {
+        return fiber.ErrInternalServerError
+    }
+    return c.JSON(st)
+}
@@ -0,0 +1,26 @@
+package tenant
+
+import (
+    appapikey "backend/internal/application/apikey"
+    apptenant "backend/internal/application/tenant"
+    "backend/internal/interface/http/middleware"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// RegisterRoutes wires tenant administration routes to the provided router.
+// The router is expected to be restricted to administrators. API key and
+// settings routes are only registered when keys and settings are non-nil.
+func RegisterRoutes(r fiber.Router, svc *apptenant.Service, keys *appapikey.Service, settings *apptenant.SettingsService) {
+    h := NewHandlers(svc, keys, settings)
+    r.Delete("/:tenantId/data", h.purgeData)
+    if keys != nil {
+        r.Post("/:tenantId/api-keys", middleware.RequireContentType(middleware.ContentTypeJSON), h.mintKey)
+        r.Get("/:tenantId/api-keys", h.listKeys)
+        r.Delete("/:tenantId/api-keys/:keyId", h.revokeKey)
+    }
+    if settings != nil {
+        r.Get("/:tenantId/settings", h.getSettings)
+        r.Put("/:tenantId/settings", middleware.RequireContentType(middleware.ContentTypeJSON), h.putSettings)
+    }
+}
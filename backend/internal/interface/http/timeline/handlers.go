@@ -0,0 +1,43 @@
+package timeline
+
+import (
+    "errors"
+
+    apptimeline "backend/internal/application/timeline"
+    "backend/internal/interface/http/middleware"
+    "backend/internal/interface/http/paging"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+type Handlers struct {
+    svc    *apptimeline.Service
+    admins map[string]bool
+}
+
+// NewHandlers returns timeline handlers; adminUserIDs also see internal
+// comments.
+func NewHandlers(svc *apptimeline.Service, adminUserIDs []string) *Handlers {
+    admins := make(map[string]bool, len(adminUserIDs))
+    for _, id := range adminUserIDs {
+        admins[id] = true
+    }
+    return &Handlers{svc: svc, admins: admins}
+}
+
+// list pages through the task's activity, oldest first.
+func (h *Handlers) list(c *fiber.Ctx) error {
+    claims := middleware.ClaimsOf(c)
+    page, err := paging.FromQuery(c)
+    if err != nil {
+        return err
+    }
+    items, err := h.svc.Get(c.UserContext(), claims.TenantID, c.Params("id"), h.admins[claims.UserID])
+    if errors.Is(err, apptimeline.ErrTaskNotFound) {
+        return fiber.NewError(fiber.StatusNotFound, err.Error())
+    }
+    if err != nil {
+        return fiber.ErrInternalServerError
+    }
+    return paging.Send(c, paging.Slice(items, page))
+}
@@ -0,0 +1,74 @@
+package timeline
+
+import (
+    "context"
+    "encoding/json"
+    "net/http/httptest"
+    "testing"
+    "time"
+
+    apptimeline "backend/internal/application/timeline"
+    domaintask "backend/internal/domain/task"
+    "backend/internal/infrastructure/memory"
+    "backend/internal/interface/http/middleware"
+    "backend/internal/interface/http/paging"
+    "backend/internal/pkg/identity"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// Test that GET /tasks/:id/timeline pages through the typed feed oldest
+// first, and answers 404 for an unknown task and 400 for a malformed id.
+func TestHandlers_List(t *testing.T) {
+    ctx := context.Background()
+    tasks := memory.NewTaskRepository()
+    comments := memory.NewCommentRepository(tasks)
+    attachments := memory.NewAttachmentRepository(tasks)
+    tk := domaintask.New("t1", "u1", "task", "", 5)
+    if err := tasks.Create(ctx, tk); err != nil {
+        t.Fatalf("seed: %v", err)
+    }
+    c := domaintask.NewComment("t1", tk.ID, "u2", "looks good", false)
+    c.CreatedAt = tk.CreatedAt.Add(2 * time.Minute)
+    comments.Create(ctx, c)
+    a := domaintask.NewAttachment("t1", tk.ID, "spec.pdf", "/uploads/spec.pdf", "application/pdf", 10)
+    a.CreatedAt = tk.CreatedAt.Add(time.Minute)
+    attachments.Create(ctx, a)
+
+    app := fiber.New()
+    app.Use(func(c *fiber.Ctx) error {
+        middleware.SetClaims(c, identity.Claims{TenantID: "t1", UserID: "u1"})
+        return c.Next()
+    })
+    RegisterRoutes(app.Group("/tasks/:id/timeline"), apptimeline.NewService(tasks, comments, attachments), nil)
+
+    resp, err := app.Test(httptest.NewRequest("GET", "/tasks/"+tk.ID+"/timeline", nil), -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    if resp.StatusCode != fiber.StatusOK {
+        t.Fatalf("expected status %d, got %d", fiber.StatusOK, resp.StatusCode)
+    }
+    var page paging.PagedResponse[domaintask.TimelineEntry]
+    if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+    if len(page.Data) != 3 || page.Data[0].Type != domaintask.TimelineCreated ||
+        page.Data[1].Type != domaintask.TimelineAttachment || page.Data[1].Attachment.ID != a.ID ||
+        page.Data[2].Type != domaintask.TimelineComment || page.Data[2].Comment.ID != c.ID {
+        t.Fatalf("expected created, attachment, comment, got %+v", page.Data)
+    }
+
+    for path, status := range map[string]int{
+        "/tasks/00000000-0000-0000-0000-000000000000/timeline": fiber.StatusNotFound,
+        "/tasks/not-a-uuid/timeline":                           fiber.StatusBadRequest,
+    } {
+        resp, err := app.Test(httptest.NewRequest("GET", path, nil), -1)
+        if err != nil {
+            t.Fatalf("app.Test: %v", err)
+        }
+        if resp.StatusCode != status {
+            t.Fatalf("%s: expected status %d, got %d", path, status, resp.StatusCode)
+        }
+    }
+}
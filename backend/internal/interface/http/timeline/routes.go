@@ -0,0 +1,15 @@
+package timeline
+
+import (
+    apptimeline "backend/internal/application/timeline"
+    "backend/internal/interface/http/middleware"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// RegisterRoutes wires the timeline route to a router mounted at
+// /tasks/:id/timeline.
+func RegisterRoutes(r fiber.Router, svc *apptimeline.Service, adminUserIDs []string) {
+    h := NewHandlers(svc, adminUserIDs)
+    r.Get("/", middleware.RequireUUIDParams("id"), h.list)
+}
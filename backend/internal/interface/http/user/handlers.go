@@ -0,0 +1,44 @@
+package user
+
+import (
+    "errors"
+
+    appuser "backend/internal/application/user"
+    "backend/internal/interface/http/middleware"
+    "backend/internal/interface/http/paging"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+type Handlers struct {
+    svc *appuser.Service
+}
+
+func NewHandlers(svc *appuser.Service) *Handlers { return &Handlers{svc: svc} }
+
+// list returns the caller's tenant's users, for assignee pickers.
+func (h *Handlers) list(c *fiber.Ctx) error {
+    page, err := paging.FromQuery(c)
+    if err != nil {
+        return err
+    }
+    users, err := h.svc.List(c.UserContext(), middleware.ClaimsOf(c).TenantID)
+    if err != nil {
+        return fiber.ErrInternalServerError
+    }
+    return paging.Send(c, paging.Slice(users, page))
+}
+
+// me returns the caller's profile; callers without one, such as API keys,
+// get 404.
+func (h *Handlers) me(c *fiber.Ctx) error {
+    claims := middleware.ClaimsOf(c)
+    u, err := h.svc.Get(c.UserContext(), claims.TenantID, claims.UserID)
+    if errors.Is(err, appuser.ErrNotFound) {
+        return fiber.ErrNotFound
+    }
+    if err != nil {
+        return fiber.ErrInternalServerError
+    }
+    return c.JSON(u)
+}
@@ -0,0 +1,88 @@
+package user
+
+import (
+    "context"
+    "encoding/json"
+    "net/http/httptest"
+    "testing"
+
+    appuser "backend/internal/application/user"
+    domainaccount "backend/internal/domain/account"
+    "backend/internal/infrastructure/memory"
+    "backend/internal/interface/http/middleware"
+    "backend/internal/interface/http/paging"
+    "backend/internal/pkg/identity"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// newTestApp serves the users of t1, ada and bob, and one of t2, acting as
+// the user named by the X-User header.
+func newTestApp(t *testing.T) (*fiber.App, *domainaccount.User) {
+    t.Helper()
+    repo := memory.NewUserRepository(memory.NewAccountRepository())
+    ada := domainaccount.NewUser("t1", "ada@example.com", "hash")
+    for _, u := range []*domainaccount.User{ada, domainaccount.NewUser("t1", "bob@example.com", "hash"), domainaccount.NewUser("t2", "eve@example.com", "hash")} {
+        if err := repo.Create(context.Background(), u); err != nil {
+            t.Fatalf("create: %v", err)
+        }
+    }
+    app := fiber.New()
+    app.Use(func(c *fiber.Ctx) error {
+        middleware.SetClaims(c, identity.Claims{TenantID: "t1", UserID: c.Get("X-User")})
+        return c.Next()
+    })
+    RegisterRoutes(app.Group("/users"), appuser.NewService(repo))
+    return app, ada
+}
+
+// Test that GET /users lists only the caller's tenant, without password
+// hashes.
+func TestHandlers_List(t *testing.T) {
+    app, _ := newTestApp(t)
+    resp, err := app.Test(httptest.NewRequest("GET", "/users", nil), -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    if resp.StatusCode != fiber.StatusOK {
+        t.Fatalf("expected status %d, got %d", fiber.StatusOK, resp.StatusCode)
+    }
+    var page paging.PagedResponse[map[string]any]
+    if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+    if len(page.Data) != 2 || page.Data[0]["displayName"] != "ada" || page.Data[1]["displayName"] != "bob" {
+        t.Fatalf("expected ada and bob, got %v", page.Data)
+    }
+    if _, ok := page.Data[0]["passwordHash"]; ok {
+        t.Fatalf("expected no password hash, got %v", page.Data[0])
+    }
+}
+
+// Test that GET /users/me returns the caller's profile, and 404 for callers
+// without one.
+func TestHandlers_Me(t *testing.T) {
+    app, ada := newTestApp(t)
+    req := httptest.NewRequest("GET", "/users/me", nil)
+    req.Header.Set("X-User", ada.ID)
+    resp, err := app.Test(req, -1)
+    if err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    var u domainaccount.User
+    if err := json.NewDecoder(resp.Body).Decode(&u); err != nil {
+        t.Fatalf("decode: %v", err)
+    }
+    if resp.StatusCode != fiber.StatusOK || u.ID != ada.ID || u.Email != "ada@example.com" {
+        t.Fatalf("expected ada, got %d %+v", resp.StatusCode, u)
+    }
+
+    req = httptest.NewRequest("GET", "/users/me", nil)
+    req.Header.Set("X-User", "apikey:k1")
+    if resp, err = app.Test(req, -1); err != nil {
+        t.Fatalf("app.Test: %v", err)
+    }
+    if resp.StatusCode != fiber.StatusNotFound {
+        t.Fatalf("expected status %d, got %d", fiber.StatusNotFound, resp.StatusCode)
+    }
+}
@@ -0,0 +1,14 @@
+package user
+
+import (
+    appuser "backend/internal/application/user"
+
+    "github.com/gofiber/fiber/v2"
+)
+
+// RegisterRoutes wires the tenant's user directory to the provided router.
+func RegisterRoutes(r fiber.Router, svc *appuser.Service) {
+    h := NewHandlers(svc)
+    r.Get("/", h.list)
+    r.Get("/me", h.me)
+}
@@ -2,9 +2,13 @@ package config
 
 import (
 	"fmt"
+	"log/slog"
 	"net/url"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -13,6 +17,7 @@ import (
 type Config struct {
     Port        string
     Env         string
+    LogLevel    string
     DatabaseURL string
     DBHost      string
     DBPort      string
@@ -21,15 +26,186 @@ type Config struct {
     DBName      string
     DBSSLMode   string
     DBTimezone  string
+    // RedisURL, when set, enables the Redis-backed background job queue.
+    RedisURL string
+
+    // DBRetryAttempts is how many times idempotent reads are tried when the
+    // database reports a transient error; DBRetryBackoffMS is the first wait.
+    DBRetryAttempts  int
+    DBRetryBackoffMS int
+    // DBConnectAttempts is how many times the database is tried at startup
+    // before giving up, so the server can start ahead of it;
+    // DBConnectBackoffMS is the first wait between tries.
+    DBConnectAttempts  int
+    DBConnectBackoffMS int
+
+    // MaxConcurrentRequests caps the API requests processed at once; 0
+    // disables the cap. Up to ConcurrencyQueueSize excess requests wait at
+    // most ConcurrencyQueueTimeoutMS for a slot, the rest get 503.
+    MaxConcurrentRequests     int
+    ConcurrencyQueueSize      int
+    ConcurrencyQueueTimeoutMS int
+
+    // MaxRequestTimeoutMS caps client-requested deadlines (X-Request-Timeout).
+    MaxRequestTimeoutMS int
+    // SlowRequestMS is how long a request may take before it is logged as
+    // slow; 0 disables the slow request log.
+    SlowRequestMS int
+    // LogBodies logs request and response bodies, credentials redacted and
+    // cut to LogBodyMaxBytes; it is only allowed in development.
+    LogBodies       bool
+    LogBodyMaxBytes int
+    // HealthCheckTimeoutMS bounds each subsystem check behind GET /health.
+    HealthCheckTimeoutMS int
+    // TrustedProxies lists proxy IPs or CIDRs whose X-Forwarded-For header is
+    // believed. When empty, forwarded headers are ignored.
+    TrustedProxies []string
+    // AuthMode picks how bearer tokens are verified: AuthModeJWT checks HS256
+    // tokens signed with JWTSecret, AuthModeJWKS checks RS256 tokens against
+    // an identity provider's JWKSURL, AuthModeSimple accepts any token as
+    // u1/t1 and is only meant for local development.
+    AuthMode  string
+    JWTSecret string
+    // JWTTTLMinutes is how long tokens issued by login and registration are
+    // valid, and RefreshTokenTTLHours how long the refresh tokens issued with
+    // them are. They are only issued when AuthMode is AuthModeJWT.
+    JWTTTLMinutes        int
+    RefreshTokenTTLHours int
+    // JWTLeewaySeconds is how far a token's exp and nbf may be off before
+    // it is refused, to allow for clock drift between issuer and server.
+    JWTLeewaySeconds int
+    // RevocationRefreshSeconds is how often each instance reloads the list
+    // of revoked tokens, so revocations made elsewhere apply within it.
+    // While the list cannot be loaded tokens are refused, unless
+    // RevocationFailOpen keeps the last list loaded in use.
+    RevocationRefreshSeconds int
+    RevocationFailOpen       bool
+    // BcryptCost is the bcrypt cost new passwords are hashed with; raising
+    // it slows down both sign-in and offline guessing of leaked hashes.
+    BcryptCost int
+    // JWKSURL, JWTIssuer, JWTAudience and JWTTenantClaim configure
+    // AuthModeJWKS; an empty issuer or audience is not checked. The key set
+    // is refetched every JWKSRefreshMinutes and, while refetches fail, kept
+    // for JWKSKeyTTLMinutes.
+    JWKSURL            string
+    JWTIssuer          string
+    JWTAudience        string
+    JWTTenantClaim     string
+    JWKSRefreshMinutes int
+    JWKSKeyTTLMinutes  int
+    // AuthAllowRawTokens also accepts an Authorization header without the
+    // Bearer scheme; it is only allowed in development.
+    AuthAllowRawTokens bool
+    // AdminUserIDs lists users allowed to call administrative endpoints.
+    AdminUserIDs []string
+    // ServiceToken, when set, is a bearer token internal services call
+    // maintenance endpoints with, without acting as a user.
+    ServiceToken string
+    // PrioritizeAllMaxTasks caps how many tasks one POST /prioritize/all run
+    // scores.
+    PrioritizeAllMaxTasks int
+    // PrioritizeCacheTTLMS is how long prioritization results are served
+    // from cache; 0 disables the cache.
+    PrioritizeCacheTTLMS int
+    // PrioritizeScheduleMinutes is how often tenants with autoPrioritize on
+    // are re-prioritized in the background; 0 disables the scheduler.
+    PrioritizeScheduleMinutes int
+    // MaxAttachmentSizeMB bounds uploaded attachments and, with it, the size
+    // of any request body the server accepts.
+    MaxAttachmentSizeMB int
+    // AttachmentTypes lists the media types uploads may have; empty keeps the
+    // attachment service's defaults.
+    AttachmentTypes []string
+    // BlobStore picks where uploaded files are kept: BlobStoreLocal writes
+    // them below UploadDir, BlobStoreS3 to S3Bucket at S3Endpoint.
+    BlobStore  string
+    UploadDir  string
+    S3Endpoint string
+    S3Bucket   string
+    // MaxTitleLen and MaxDescriptionLen bound task titles and descriptions in
+    // characters; the defaults match the database columns.
+    MaxTitleLen       int
+    MaxDescriptionLen int
+    // CSP is the Content-Security-Policy header sent with every response.
+    CSP string
+    // DependenciesBlockDone refuses to move a task to done while a task it
+    // depends on is still open.
+    DependenciesBlockDone bool
+    // TenantTimezones maps tenant IDs to the zone their relative dates
+    // ("friday", "5pm") are read in; other tenants use UTC.
+    TenantTimezones map[string]*time.Location
+    // Features lists the experimental endpoint groups that are served, see
+    // FeatureEnabled; nil, when FEATURES is unset, serves them all.
+    Features map[string]bool
+
+    // AI scoring via an OpenAI-compatible API; disabled when AIAPIKey is empty.
+    AIBaseURL   string
+    AIAPIKey    string
+    AIModel     string
+    AITimeoutMS int
+    // AIBatchSize tasks go in one provider call, with at most
+    // AIBatchConcurrency calls in flight.
+    AIBatchSize        int
+    AIBatchConcurrency int
 }
 
+// Auth modes accepted in AUTH_MODE.
+const (
+    AuthModeJWT    = "jwt"
+    AuthModeJWKS   = "jwks"
+    AuthModeSimple = "simple"
+)
+
+// Blob stores accepted in BLOB_STORE.
+const (
+    BlobStoreLocal = "local"
+    BlobStoreS3    = "s3"
+)
+
+// Experimental endpoint groups that FEATURES can switch on.
+const (
+    // FeatureSSE serves the task event stream, GET /tasks/stream.
+    FeatureSSE = "sse"
+    // FeaturePrioritize serves the /prioritize endpoints.
+    FeaturePrioritize = "prioritize"
+    // FeatureJobs serves background job status at /jobs.
+    FeatureJobs = "jobs"
+)
+
+// knownFeatures are the names accepted in FEATURES.
+var knownFeatures = []string{FeatureSSE, FeaturePrioritize, FeatureJobs}
+
+// MinJWTSecretLen is the shortest JWT_SECRET accepted, in bytes; HS256 keys
+// should be at least as long as the hash.
+const MinJWTSecretLen = 32
+
+// MinServiceTokenLen is the shortest SERVICE_TOKEN accepted, in bytes.
+const MinServiceTokenLen = 32
+
+// Load reads the configuration for the environment named by ENV, which may
+// itself come from .env, or development when unset. See LoadForEnv.
 func Load() (Config, error) {
-    // Automatically load .env
     _ = godotenv.Load()
+    return LoadForEnv(getEnv("ENV", "development"))
+}
+
+// LoadForEnv reads the configuration for the environment envName, which
+// becomes Env. Missing files are skipped; the rest are read from the working
+// directory in increasing precedence:
+//
+//   - .env, whose settings give way to variables already in the environment
+//   - .env.<envName>, e.g. .env.production, which overrides them
+//   - .env.local, with developer-specific settings that are never committed,
+//     which overrides everything else
+func LoadForEnv(envName string) (Config, error) {
+    _ = godotenv.Load()
+    _ = godotenv.Overload(".env." + envName)
+    _ = godotenv.Overload(".env.local")
 
     cfg := Config{
         Port:        getEnv("PORT", "8080"),
-        Env:         getEnv("ENV", "development"),
+        Env:         envName,
+        LogLevel:    getEnv("LOG_LEVEL", "info"),
         DatabaseURL: getEnv("DATABASE_URL", ""),
 
         DBHost:     getEnv("DB_HOST", "localhost"),
@@ -39,11 +215,259 @@ func Load() (Config, error) {
 		DBName:     getEnv("DB_NAME", "postgres"),
 		DBSSLMode:  getEnv("DB_SSLMODE", "disable"),
 		DBTimezone: getEnv("DB_TIMEZONE", "UTC"),
+		RedisURL:   getEnv("REDIS_URL", ""),
+
+		TrustedProxies: getEnvList("TRUSTED_PROXIES"),
+		AdminUserIDs:   getEnvList("ADMIN_USER_IDS"),
+		CSP:            getEnv("CONTENT_SECURITY_POLICY", "default-src 'self'"),
+
+		AIBaseURL: getEnv("AI_BASE_URL", "https://api.openai.com/v1"),
+		AIAPIKey:  getEnv("AI_API_KEY", ""),
+		AIModel:   getEnv("AI_MODEL", "gpt-4o-mini"),
+	}
+
+	if _, err := ParseLogLevel(cfg.LogLevel); err != nil {
+		return Config{}, err
+	}
+
+	var err error
+	if cfg.MaxRequestTimeoutMS, err = getEnvInt("MAX_REQUEST_TIMEOUT_MS", 30000); err != nil {
+		return Config{}, err
+	}
+	if cfg.SlowRequestMS, err = getEnvInt("SLOW_REQUEST_MS", 1000); err != nil {
+		return Config{}, err
+	}
+	if cfg.SlowRequestMS < 0 {
+		return Config{}, fmt.Errorf("SLOW_REQUEST_MS must not be negative")
+	}
+	if cfg.LogBodies, err = getEnvBool("LOG_BODIES", false); err != nil {
+		return Config{}, err
+	}
+	if cfg.LogBodies && cfg.Env != "development" {
+		return Config{}, fmt.Errorf("LOG_BODIES is only allowed when ENV is development")
+	}
+	if cfg.LogBodyMaxBytes, err = getEnvInt("LOG_BODY_MAX_BYTES", 2048); err != nil {
+		return Config{}, err
+	}
+	if cfg.LogBodyMaxBytes <= 0 {
+		return Config{}, fmt.Errorf("LOG_BODY_MAX_BYTES must be positive")
+	}
+	if cfg.HealthCheckTimeoutMS, err = getEnvInt("HEALTH_CHECK_TIMEOUT_MS", 2000); err != nil {
+		return Config{}, err
+	}
+	if cfg.HealthCheckTimeoutMS <= 0 {
+		return Config{}, fmt.Errorf("HEALTH_CHECK_TIMEOUT_MS must be positive")
+	}
+	if cfg.MaxConcurrentRequests, err = getEnvInt("MAX_CONCURRENT_REQUESTS", 0); err != nil {
+		return Config{}, err
+	}
+	if cfg.ConcurrencyQueueSize, err = getEnvInt("CONCURRENCY_QUEUE_SIZE", 0); err != nil {
+		return Config{}, err
+	}
+	if cfg.ConcurrencyQueueTimeoutMS, err = getEnvInt("CONCURRENCY_QUEUE_TIMEOUT_MS", 1000); err != nil {
+		return Config{}, err
+	}
+	if cfg.MaxConcurrentRequests < 0 || cfg.ConcurrencyQueueSize < 0 || cfg.ConcurrencyQueueTimeoutMS < 0 {
+		return Config{}, fmt.Errorf("MAX_CONCURRENT_REQUESTS, CONCURRENCY_QUEUE_SIZE and CONCURRENCY_QUEUE_TIMEOUT_MS must not be negative")
+	}
+	if cfg.DBRetryAttempts, err = getEnvInt("DB_RETRY_ATTEMPTS", 3); err != nil {
+		return Config{}, err
+	}
+	if cfg.DBRetryBackoffMS, err = getEnvInt("DB_RETRY_BACKOFF_MS", 50); err != nil {
+		return Config{}, err
+	}
+	if cfg.DBConnectAttempts, err = getEnvInt("DB_CONNECT_ATTEMPTS", 10); err != nil {
+		return Config{}, err
+	}
+	if cfg.DBConnectBackoffMS, err = getEnvInt("DB_CONNECT_BACKOFF_MS", 500); err != nil {
+		return Config{}, err
+	}
+	if cfg.DBConnectAttempts < 1 || cfg.DBConnectBackoffMS < 0 {
+		return Config{}, fmt.Errorf("DB_CONNECT_ATTEMPTS must be positive and DB_CONNECT_BACKOFF_MS not negative")
+	}
+	if cfg.PrioritizeAllMaxTasks, err = getEnvInt("PRIORITIZE_ALL_MAX_TASKS", 5000); err != nil {
+		return Config{}, err
+	}
+	if cfg.PrioritizeCacheTTLMS, err = getEnvInt("PRIORITIZE_CACHE_TTL_MS", 60000); err != nil {
+		return Config{}, err
+	}
+	if cfg.PrioritizeCacheTTLMS < 0 {
+		return Config{}, fmt.Errorf("PRIORITIZE_CACHE_TTL_MS must not be negative")
+	}
+	if cfg.PrioritizeScheduleMinutes, err = getEnvInt("PRIORITIZE_SCHEDULE_MINUTES", 0); err != nil {
+		return Config{}, err
+	}
+	if cfg.PrioritizeScheduleMinutes < 0 {
+		return Config{}, fmt.Errorf("PRIORITIZE_SCHEDULE_MINUTES must not be negative")
+	}
+	if cfg.MaxAttachmentSizeMB, err = getEnvInt("MAX_ATTACHMENT_MB", 10); err != nil {
+		return Config{}, err
+	}
+	if cfg.AITimeoutMS, err = getEnvInt("AI_TIMEOUT_MS", 10000); err != nil {
+		return Config{}, err
+	}
+	if cfg.AIBatchSize, err = getEnvInt("AI_BATCH_SIZE", 25); err != nil {
+		return Config{}, err
+	}
+	if cfg.AIBatchConcurrency, err = getEnvInt("AI_BATCH_CONCURRENCY", 4); err != nil {
+		return Config{}, err
+	}
+	if cfg.AIBatchSize <= 0 || cfg.AIBatchConcurrency <= 0 {
+		return Config{}, fmt.Errorf("AI_BATCH_SIZE and AI_BATCH_CONCURRENCY must be positive")
+	}
+	if cfg.MaxTitleLen, err = getEnvInt("MAX_TITLE_LEN", 255); err != nil {
+		return Config{}, err
+	}
+	if cfg.MaxDescriptionLen, err = getEnvInt("MAX_DESCRIPTION_LEN", 10000); err != nil {
+		return Config{}, err
+	}
+	if cfg.MaxTitleLen <= 0 || cfg.MaxDescriptionLen <= 0 {
+		return Config{}, fmt.Errorf("MAX_TITLE_LEN and MAX_DESCRIPTION_LEN must be positive")
+	}
+	if cfg.DependenciesBlockDone, err = getEnvBool("DEPENDENCIES_BLOCK_DONE", false); err != nil {
+		return Config{}, err
+	}
+	if cfg.Features, err = getEnvFeatures("FEATURES"); err != nil {
+		return Config{}, err
+	}
+	if cfg.TenantTimezones, err = getEnvTimezones("TENANT_TIMEZONES"); err != nil {
+		return Config{}, err
+	}
+	cfg.AttachmentTypes = getEnvList("ATTACHMENT_TYPES")
+	cfg.BlobStore = strings.ToLower(strings.TrimSpace(getEnv("BLOB_STORE", BlobStoreLocal)))
+	cfg.UploadDir = getEnv("UPLOAD_DIR", "uploads")
+	cfg.S3Endpoint = getEnv("S3_ENDPOINT", "")
+	cfg.S3Bucket = getEnv("S3_BUCKET", "")
+	switch cfg.BlobStore {
+	case BlobStoreLocal:
+		if cfg.UploadDir == "" {
+			return Config{}, fmt.Errorf("UPLOAD_DIR must not be empty when BLOB_STORE is local")
+		}
+	case BlobStoreS3:
+		if cfg.S3Bucket == "" {
+			return Config{}, fmt.Errorf("S3_BUCKET is required when BLOB_STORE is s3")
+		}
+	default:
+		return Config{}, fmt.Errorf("BLOB_STORE: expected %s or %s, got %q", BlobStoreLocal, BlobStoreS3, cfg.BlobStore)
+	}
+	cfg.AuthMode = strings.ToLower(strings.TrimSpace(os.Getenv("AUTH_MODE")))
+	if cfg.AuthMode == "" {
+		cfg.AuthMode = AuthModeJWT
+		if cfg.Env == "development" {
+			cfg.AuthMode = AuthModeSimple
+		}
+	}
+	cfg.JWTSecret = getEnv("JWT_SECRET", "")
+	cfg.ServiceToken = getEnv("SERVICE_TOKEN", "")
+	if cfg.ServiceToken != "" && len(cfg.ServiceToken) < MinServiceTokenLen {
+		return Config{}, fmt.Errorf("SERVICE_TOKEN must be at least %d bytes", MinServiceTokenLen)
+	}
+	if cfg.JWTTTLMinutes, err = getEnvInt("JWT_TTL_MINUTES", 60); err != nil {
+		return Config{}, err
+	}
+	if cfg.JWTTTLMinutes <= 0 {
+		return Config{}, fmt.Errorf("JWT_TTL_MINUTES must be positive")
+	}
+	if cfg.RefreshTokenTTLHours, err = getEnvInt("REFRESH_TOKEN_TTL_HOURS", 720); err != nil {
+		return Config{}, err
+	}
+	if cfg.RefreshTokenTTLHours <= 0 {
+		return Config{}, fmt.Errorf("REFRESH_TOKEN_TTL_HOURS must be positive")
+	}
+	if cfg.JWTLeewaySeconds, err = getEnvInt("JWT_LEEWAY_SECONDS", 30); err != nil {
+		return Config{}, err
+	}
+	if cfg.JWTLeewaySeconds < 0 {
+		return Config{}, fmt.Errorf("JWT_LEEWAY_SECONDS must not be negative")
+	}
+	if cfg.RevocationRefreshSeconds, err = getEnvInt("TOKEN_REVOCATION_REFRESH_SECONDS", 10); err != nil {
+		return Config{}, err
+	}
+	if cfg.RevocationRefreshSeconds <= 0 {
+		return Config{}, fmt.Errorf("TOKEN_REVOCATION_REFRESH_SECONDS must be positive")
+	}
+	if cfg.RevocationFailOpen, err = getEnvBool("TOKEN_REVOCATION_FAIL_OPEN", false); err != nil {
+		return Config{}, err
+	}
+	if cfg.BcryptCost, err = getEnvInt("BCRYPT_COST", 10); err != nil {
+		return Config{}, err
+	}
+	if cfg.BcryptCost < 4 || cfg.BcryptCost > 31 {
+		return Config{}, fmt.Errorf("BCRYPT_COST must be between 4 and 31")
+	}
+	cfg.JWKSURL = getEnv("JWKS_URL", "")
+	cfg.JWTIssuer = getEnv("JWT_ISSUER", "")
+	cfg.JWTAudience = getEnv("JWT_AUDIENCE", "")
+	cfg.JWTTenantClaim = strings.TrimSpace(os.Getenv("JWT_TENANT_CLAIM"))
+	if cfg.JWTTenantClaim == "" {
+		cfg.JWTTenantClaim = "tenant_id"
+	}
+	if cfg.JWKSRefreshMinutes, err = getEnvInt("JWKS_REFRESH_MINUTES", 15); err != nil {
+		return Config{}, err
+	}
+	if cfg.JWKSKeyTTLMinutes, err = getEnvInt("JWKS_KEY_TTL_MINUTES", 1440); err != nil {
+		return Config{}, err
+	}
+	switch cfg.AuthMode {
+	case AuthModeSimple:
+	case AuthModeJWT:
+		if len(cfg.JWTSecret) < MinJWTSecretLen {
+			return Config{}, fmt.Errorf("JWT_SECRET must be at least %d bytes when AUTH_MODE is jwt", MinJWTSecretLen)
+		}
+	case AuthModeJWKS:
+		if u, err := url.Parse(cfg.JWKSURL); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return Config{}, fmt.Errorf("JWKS_URL must be an http(s) URL when AUTH_MODE is jwks")
+		}
+		if cfg.JWKSRefreshMinutes <= 0 || cfg.JWKSKeyTTLMinutes < cfg.JWKSRefreshMinutes {
+			return Config{}, fmt.Errorf("JWKS_REFRESH_MINUTES must be positive and JWKS_KEY_TTL_MINUTES at least as long")
+		}
+	default:
+		return Config{}, fmt.Errorf("AUTH_MODE: expected %s, %s or %s, got %q", AuthModeJWT, AuthModeJWKS, AuthModeSimple, cfg.AuthMode)
+	}
+	if cfg.AuthAllowRawTokens, err = getEnvBool("AUTH_ALLOW_RAW_TOKENS", cfg.Env == "development"); err != nil {
+		return Config{}, err
+	}
+	if cfg.AuthAllowRawTokens && cfg.Env != "development" {
+		return Config{}, fmt.Errorf("AUTH_ALLOW_RAW_TOKENS is only allowed when ENV is development")
 	}
 
 	return cfg, nil
 }
 
+// ParseLogLevel converts one of the level names "debug", "info", "warn" or
+// "error" (case-insensitive) into a slog.Level. Other names, and slog's
+// offset syntax such as "info+2", are refused.
+func ParseLogLevel(s string) (slog.Level, error) {
+    switch strings.ToLower(strings.TrimSpace(s)) {
+    case "debug":
+        return slog.LevelDebug, nil
+    case "info":
+        return slog.LevelInfo, nil
+    case "warn":
+        return slog.LevelWarn, nil
+    case "error":
+        return slog.LevelError, nil
+    }
+    return 0, fmt.Errorf("invalid log level %q", s)
+}
+
+// MaxAttachmentBytes is MaxAttachmentSizeMB in bytes.
+func (c Config) MaxAttachmentBytes() int {
+    return c.MaxAttachmentSizeMB * 1024 * 1024
+}
+
+// FeatureEnabled reports whether the experimental endpoints of feature are
+// served: every feature is when FEATURES is unset, otherwise only those it
+// lists.
+func (c Config) FeatureEnabled(feature string) bool {
+    return c.Features == nil || c.Features[feature]
+}
+
+// AIEnabled reports whether an AI provider is configured.
+func (c Config) AIEnabled() bool {
+    return strings.TrimSpace(c.AIAPIKey) != ""
+}
+
 func (c Config) DatabaseDSN() string {
     if strings.TrimSpace(c.DatabaseURL) != "" {
         return c.DatabaseURL
@@ -71,3 +495,75 @@ func getEnv(key, def string) string {
     
     return def
 }
+
+// getEnvList splits a comma-separated variable, dropping empty entries.
+func getEnvList(key string) []string {
+    var out []string
+    for _, part := range strings.Split(os.Getenv(key), ",") {
+        if part = strings.TrimSpace(part); part != "" {
+            out = append(out, part)
+        }
+    }
+    return out
+}
+
+// getEnvFeatures reads a comma-separated list of knownFeatures, in any case.
+// It returns nil when key is unset and an empty set when it is blank.
+func getEnvFeatures(key string) (map[string]bool, error) {
+    if _, ok := os.LookupEnv(key); !ok {
+        return nil, nil
+    }
+    out := map[string]bool{}
+    for _, name := range getEnvList(key) {
+        name = strings.ToLower(name)
+        if !slices.Contains(knownFeatures, name) {
+            return nil, fmt.Errorf("%s: unknown feature %q, expected one of %s", key, name, strings.Join(knownFeatures, ", "))
+        }
+        out[name] = true
+    }
+    return out, nil
+}
+
+func getEnvBool(key string, def bool) (bool, error) {
+    v, ok := os.LookupEnv(key)
+    if !ok || strings.TrimSpace(v) == "" {
+        return def, nil
+    }
+    b, err := strconv.ParseBool(strings.TrimSpace(v))
+    if err != nil {
+        return false, fmt.Errorf("%s: invalid boolean %q", key, v)
+    }
+    return b, nil
+}
+
+// getEnvTimezones reads a comma-separated list of tenant=zone pairs, such as
+// "t1=Europe/Berlin,t2=Asia/Jakarta", checking each zone against the IANA
+// database.
+func getEnvTimezones(key string) (map[string]*time.Location, error) {
+    out := map[string]*time.Location{}
+    for _, pair := range getEnvList(key) {
+        tenant, zone, ok := strings.Cut(pair, "=")
+        tenant, zone = strings.TrimSpace(tenant), strings.TrimSpace(zone)
+        if !ok || tenant == "" || zone == "" {
+            return nil, fmt.Errorf("%s: expected tenant=zone, got %q", key, pair)
+        }
+        loc, err := time.LoadLocation(zone)
+        if err != nil {
+            return nil, fmt.Errorf("%s: unknown time zone %q", key, zone)
+        }
+        out[tenant] = loc
+    }
+    return out, nil
+}
+
+func getEnvInt(key string, def int) (int, error) {
+    v, ok := os.LookupEnv(key)
+    if !ok || strings.TrimSpace(v) == "" {
+        return def, nil
+    }
+    n, err := strconv.Atoi(strings.TrimSpace(v))
+    if err != nil {
+        return 0, fmt.Errorf("%s: invalid integer %q", key, v)
+    }
+    return n, nil
+}
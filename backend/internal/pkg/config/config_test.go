@@ -0,0 +1,476 @@
+package config
+
+import (
+    "log/slog"
+    "os"
+    "strings"
+    "testing"
+)
+
+// Test that known level names parse case-insensitively.
+func TestParseLogLevel_Valid(t *testing.T) {
+    cases := map[string]slog.Level{
+        "debug": slog.LevelDebug,
+        "INFO":  slog.LevelInfo,
+        "Warn":  slog.LevelWarn,
+        "error": slog.LevelError,
+    }
+    for in, want := range cases {
+        got, err := ParseLogLevel(in)
+        if err != nil {
+            t.Fatalf("%q: unexpected error %v", in, err)
+        }
+        if got != want {
+            t.Fatalf("%q: expected %v, got %v", in, want, got)
+        }
+    }
+}
+
+// Test that unknown level names and slog's offset syntax are rejected.
+func TestParseLogLevel_Invalid(t *testing.T) {
+    for _, in := range []string{"", "verbose", "trace", "info+2", "DEBUG-4", "warning"} {
+        if _, err := ParseLogLevel(in); err == nil {
+            t.Fatalf("%q: expected error", in)
+        }
+    }
+}
+
+// Test that the length limits default to the schema sizes and must be
+// positive.
+func TestLoad_LengthLimits(t *testing.T) {
+    t.Setenv("MAX_TITLE_LEN", "")
+    t.Setenv("MAX_DESCRIPTION_LEN", "")
+    cfg, err := Load()
+    if err != nil {
+        t.Fatalf("load: %v", err)
+    }
+    if cfg.MaxTitleLen != 255 || cfg.MaxDescriptionLen != 10000 {
+        t.Fatalf("expected defaults 255 and 10000, got %d and %d", cfg.MaxTitleLen, cfg.MaxDescriptionLen)
+    }
+
+    t.Setenv("MAX_TITLE_LEN", "0")
+    if _, err := Load(); err == nil {
+        t.Fatalf("expected a zero title limit to be rejected")
+    }
+}
+
+// Test that the prioritize cache TTL defaults to a minute, may be zero and
+// must not be negative.
+func TestLoad_PrioritizeCacheTTL(t *testing.T) {
+    t.Setenv("PRIORITIZE_CACHE_TTL_MS", "")
+    cfg, err := Load()
+    if err != nil {
+        t.Fatalf("load: %v", err)
+    }
+    if cfg.PrioritizeCacheTTLMS != 60000 {
+        t.Fatalf("expected default 60000, got %d", cfg.PrioritizeCacheTTLMS)
+    }
+
+    t.Setenv("PRIORITIZE_CACHE_TTL_MS", "0")
+    if _, err := Load(); err != nil {
+        t.Fatalf("expected zero to disable the cache, got %v", err)
+    }
+    t.Setenv("PRIORITIZE_CACHE_TTL_MS", "-1")
+    if _, err := Load(); err == nil {
+        t.Fatalf("expected a negative TTL to be rejected")
+    }
+}
+
+// Test that the prioritize scheduler is off by default and rejects a
+// negative interval.
+func TestLoad_PrioritizeSchedule(t *testing.T) {
+    t.Setenv("PRIORITIZE_SCHEDULE_MINUTES", "")
+    cfg, err := Load()
+    if err != nil {
+        t.Fatalf("load: %v", err)
+    }
+    if cfg.PrioritizeScheduleMinutes != 0 {
+        t.Fatalf("expected the scheduler disabled by default, got %d", cfg.PrioritizeScheduleMinutes)
+    }
+    t.Setenv("PRIORITIZE_SCHEDULE_MINUTES", "-5")
+    if _, err := Load(); err == nil {
+        t.Fatalf("expected a negative interval to be rejected")
+    }
+}
+
+// Test that tenant time zones are read as tenant=zone pairs and that unknown
+// zones or malformed pairs fail the load.
+func TestLoad_TenantTimezones(t *testing.T) {
+    t.Setenv("TENANT_TIMEZONES", "t1=Asia/Jakarta, t2 = Europe/Berlin")
+    cfg, err := Load()
+    if err != nil {
+        t.Fatalf("load: %v", err)
+    }
+    if got := cfg.TenantTimezones["t1"]; got == nil || got.String() != "Asia/Jakarta" {
+        t.Fatalf("expected t1 in Asia/Jakarta, got %v", got)
+    }
+    if got := cfg.TenantTimezones["t2"]; got == nil || got.String() != "Europe/Berlin" {
+        t.Fatalf("expected t2 in Europe/Berlin, got %v", got)
+    }
+
+    for _, v := range []string{"t1=Mars/Olympus", "t1", "=UTC"} {
+        t.Setenv("TENANT_TIMEZONES", v)
+        if _, err := Load(); err == nil {
+            t.Fatalf("%q: expected error", v)
+        }
+    }
+}
+
+// Test that development defaults to simple auth, other environments to JWT,
+// that JWT mode needs a long enough secret and that raw tokens are limited to
+// development.
+func TestLoad_AuthMode(t *testing.T) {
+    t.Setenv("AUTH_MODE", "")
+    t.Setenv("JWT_SECRET", "")
+    t.Setenv("AUTH_ALLOW_RAW_TOKENS", "")
+    t.Setenv("ENV", "development")
+    cfg, err := Load()
+    if err != nil {
+        t.Fatalf("load: %v", err)
+    }
+    if cfg.AuthMode != AuthModeSimple || !cfg.AuthAllowRawTokens {
+        t.Fatalf("expected %s with raw tokens in development, got %s (raw %v)", AuthModeSimple, cfg.AuthMode, cfg.AuthAllowRawTokens)
+    }
+
+    t.Setenv("ENV", "production")
+    if _, err := Load(); err == nil {
+        t.Fatalf("expected production without JWT_SECRET to be rejected")
+    }
+    t.Setenv("JWT_SECRET", strings.Repeat("s", MinJWTSecretLen))
+    if cfg, err = Load(); err != nil || cfg.AuthMode != AuthModeJWT {
+        t.Fatalf("expected %s in production, got %q (%v)", AuthModeJWT, cfg.AuthMode, err)
+    }
+
+    if cfg.AuthAllowRawTokens {
+        t.Fatalf("expected raw tokens refused in production")
+    }
+    t.Setenv("AUTH_ALLOW_RAW_TOKENS", "true")
+    if _, err := Load(); err == nil {
+        t.Fatalf("expected raw tokens outside development to be rejected")
+    }
+    t.Setenv("AUTH_ALLOW_RAW_TOKENS", "")
+
+    t.Setenv("AUTH_MODE", "oauth")
+    if _, err := Load(); err == nil {
+        t.Fatalf("expected an unknown mode to be rejected")
+    }
+}
+
+// Test that JWKS mode needs a JWKS_URL and a key TTL no shorter than the
+// refresh interval, and reads the tenant from tenant_id by default.
+func TestLoad_AuthModeJWKS(t *testing.T) {
+    t.Setenv("AUTH_MODE", "jwks")
+    t.Setenv("JWKS_URL", "")
+    t.Setenv("JWT_TENANT_CLAIM", "")
+    t.Setenv("JWKS_REFRESH_MINUTES", "")
+    t.Setenv("JWKS_KEY_TTL_MINUTES", "")
+    if _, err := Load(); err == nil {
+        t.Fatalf("expected jwks without JWKS_URL to be rejected")
+    }
+    t.Setenv("JWKS_URL", "https://idp.example.com/.well-known/jwks.json")
+    cfg, err := Load()
+    if err != nil {
+        t.Fatalf("load: %v", err)
+    }
+    if cfg.AuthMode != AuthModeJWKS || cfg.JWTTenantClaim != "tenant_id" || cfg.JWKSRefreshMinutes != 15 {
+        t.Fatalf("expected jwks defaults, got %s, %q, %d", cfg.AuthMode, cfg.JWTTenantClaim, cfg.JWKSRefreshMinutes)
+    }
+    t.Setenv("JWKS_KEY_TTL_MINUTES", "5")
+    if _, err := Load(); err == nil {
+        t.Fatalf("expected a key TTL shorter than the refresh interval to be rejected")
+    }
+}
+
+// Test that issued tokens last an hour and refresh tokens 30 days by
+// default, and that non-positive lifetimes are rejected.
+func TestLoad_JWTTTL(t *testing.T) {
+    t.Setenv("JWT_TTL_MINUTES", "")
+    t.Setenv("REFRESH_TOKEN_TTL_HOURS", "")
+    cfg, err := Load()
+    if err != nil {
+        t.Fatalf("load: %v", err)
+    }
+    if cfg.JWTTTLMinutes != 60 || cfg.RefreshTokenTTLHours != 720 {
+        t.Fatalf("expected 60 and 720, got %d and %d", cfg.JWTTTLMinutes, cfg.RefreshTokenTTLHours)
+    }
+    for _, key := range []string{"JWT_TTL_MINUTES", "REFRESH_TOKEN_TTL_HOURS"} {
+        t.Run(key, func(t *testing.T) {
+            t.Setenv(key, "0")
+            if _, err := Load(); err == nil {
+                t.Fatalf("expected a zero %s to be rejected", key)
+            }
+        })
+    }
+}
+
+// Test that every feature is enabled while FEATURES is unset, only the
+// listed ones once it is set, and that unknown names are rejected.
+func TestLoad_Features(t *testing.T) {
+    t.Setenv("FEATURES", "") // restored after the test
+    os.Unsetenv("FEATURES")
+    cfg, err := Load()
+    if err != nil {
+        t.Fatalf("load: %v", err)
+    }
+    if !cfg.FeatureEnabled(FeatureSSE) || !cfg.FeatureEnabled(FeaturePrioritize) {
+        t.Fatalf("expected all features enabled, got %v", cfg.Features)
+    }
+    t.Setenv("FEATURES", " SSE, jobs")
+    if cfg, err = Load(); err != nil {
+        t.Fatalf("load: %v", err)
+    }
+    if !cfg.FeatureEnabled(FeatureSSE) || !cfg.FeatureEnabled(FeatureJobs) || cfg.FeatureEnabled(FeaturePrioritize) {
+        t.Fatalf("expected sse and jobs only, got %v", cfg.Features)
+    }
+    t.Setenv("FEATURES", "sse,teleport")
+    if _, err := Load(); err == nil {
+        t.Fatalf("expected an unknown feature to be rejected")
+    }
+}
+
+// Test that body logging is off by default, allowed in development and
+// refused elsewhere.
+func TestLoad_LogBodies(t *testing.T) {
+    t.Setenv("ENV", "development")
+    t.Setenv("LOG_BODIES", "")
+    cfg, err := Load()
+    if err != nil {
+        t.Fatalf("load: %v", err)
+    }
+    if cfg.LogBodies || cfg.LogBodyMaxBytes != 2048 {
+        t.Fatalf("expected body logging off with a 2048 byte limit, got %v %d", cfg.LogBodies, cfg.LogBodyMaxBytes)
+    }
+    t.Setenv("LOG_BODIES", "true")
+    if cfg, err = Load(); err != nil || !cfg.LogBodies {
+        t.Fatalf("expected body logging in development, got %v (%v)", cfg.LogBodies, err)
+    }
+    t.Setenv("ENV", "production")
+    t.Setenv("JWT_SECRET", strings.Repeat("s", MinJWTSecretLen))
+    if _, err := Load(); err == nil {
+        t.Fatalf("expected LOG_BODIES to be rejected in production")
+    }
+}
+
+// Test that requests over a second are logged as slow by default and that a
+// negative threshold is rejected.
+func TestLoad_SlowRequestMS(t *testing.T) {
+    t.Setenv("SLOW_REQUEST_MS", "")
+    cfg, err := Load()
+    if err != nil {
+        t.Fatalf("load: %v", err)
+    }
+    if cfg.SlowRequestMS != 1000 {
+        t.Fatalf("expected 1000, got %d", cfg.SlowRequestMS)
+    }
+    t.Setenv("SLOW_REQUEST_MS", "-1")
+    if _, err := Load(); err == nil {
+        t.Fatalf("expected a negative SLOW_REQUEST_MS to be rejected")
+    }
+}
+
+// Test that health checks get two seconds by default and that a
+// non-positive timeout is rejected.
+func TestLoad_HealthCheckTimeoutMS(t *testing.T) {
+    t.Setenv("HEALTH_CHECK_TIMEOUT_MS", "")
+    cfg, err := Load()
+    if err != nil {
+        t.Fatalf("load: %v", err)
+    }
+    if cfg.HealthCheckTimeoutMS != 2000 {
+        t.Fatalf("expected 2000, got %d", cfg.HealthCheckTimeoutMS)
+    }
+    t.Setenv("HEALTH_CHECK_TIMEOUT_MS", "0")
+    if _, err := Load(); err == nil {
+        t.Fatalf("expected a zero HEALTH_CHECK_TIMEOUT_MS to be rejected")
+    }
+}
+
+// Test that a service token shorter than MinServiceTokenLen is rejected.
+func TestLoad_ServiceToken(t *testing.T) {
+    t.Setenv("SERVICE_TOKEN", "short")
+    if _, err := Load(); err == nil {
+        t.Fatalf("expected a short SERVICE_TOKEN to be rejected")
+    }
+    t.Setenv("SERVICE_TOKEN", strings.Repeat("x", MinServiceTokenLen))
+    if cfg, err := Load(); err != nil || cfg.ServiceToken == "" {
+        t.Fatalf("expected the service token to load, got %v", err)
+    }
+}
+
+// Test that the database is tried 10 times at startup, 500ms apart at
+// first, by default, and that fewer than one attempt is rejected.
+func TestLoad_DBConnect(t *testing.T) {
+    t.Setenv("DB_CONNECT_ATTEMPTS", "")
+    t.Setenv("DB_CONNECT_BACKOFF_MS", "")
+    cfg, err := Load()
+    if err != nil {
+        t.Fatalf("load: %v", err)
+    }
+    if cfg.DBConnectAttempts != 10 || cfg.DBConnectBackoffMS != 500 {
+        t.Fatalf("expected 10 attempts 500ms apart, got %d, %d", cfg.DBConnectAttempts, cfg.DBConnectBackoffMS)
+    }
+    t.Setenv("DB_CONNECT_ATTEMPTS", "0")
+    if _, err := Load(); err == nil {
+        t.Fatalf("expected DB_CONNECT_ATTEMPTS=0 to be rejected")
+    }
+}
+
+// Test that the revocation list is reloaded every 10 seconds and fails closed
+// by default, and that a non-positive interval is rejected.
+func TestLoad_Revocation(t *testing.T) {
+    t.Setenv("TOKEN_REVOCATION_REFRESH_SECONDS", "")
+    t.Setenv("TOKEN_REVOCATION_FAIL_OPEN", "")
+    cfg, err := Load()
+    if err != nil {
+        t.Fatalf("load: %v", err)
+    }
+    if cfg.RevocationRefreshSeconds != 10 || cfg.RevocationFailOpen {
+        t.Fatalf("expected 10s and fail closed, got %d, %v", cfg.RevocationRefreshSeconds, cfg.RevocationFailOpen)
+    }
+    t.Setenv("TOKEN_REVOCATION_REFRESH_SECONDS", "0")
+    if _, err := Load(); err == nil {
+        t.Fatalf("expected a zero TOKEN_REVOCATION_REFRESH_SECONDS to be rejected")
+    }
+}
+
+// Test that passwords are hashed at cost 10 by default and that costs bcrypt
+// does not support are rejected.
+func TestLoad_BcryptCost(t *testing.T) {
+    t.Setenv("BCRYPT_COST", "")
+    cfg, err := Load()
+    if err != nil {
+        t.Fatalf("load: %v", err)
+    }
+    if cfg.BcryptCost != 10 {
+        t.Fatalf("expected 10, got %d", cfg.BcryptCost)
+    }
+    for _, v := range []string{"3", "32"} {
+        t.Setenv("BCRYPT_COST", v)
+        if _, err := Load(); err == nil {
+            t.Fatalf("expected BCRYPT_COST=%s to be rejected", v)
+        }
+    }
+}
+
+// Test that tokens get 30 seconds of leeway by default, that none is
+// allowed, and that a negative leeway is rejected.
+func TestLoad_JWTLeewaySeconds(t *testing.T) {
+    t.Setenv("JWT_LEEWAY_SECONDS", "")
+    cfg, err := Load()
+    if err != nil {
+        t.Fatalf("load: %v", err)
+    }
+    if cfg.JWTLeewaySeconds != 30 {
+        t.Fatalf("expected 30, got %d", cfg.JWTLeewaySeconds)
+    }
+    t.Setenv("JWT_LEEWAY_SECONDS", "0")
+    if cfg, err = Load(); err != nil || cfg.JWTLeewaySeconds != 0 {
+        t.Fatalf("expected a zero leeway to be accepted, got %d, %v", cfg.JWTLeewaySeconds, err)
+    }
+    t.Setenv("JWT_LEEWAY_SECONDS", "-1")
+    if _, err := Load(); err == nil {
+        t.Fatalf("expected a negative JWT_LEEWAY_SECONDS to be rejected")
+    }
+}
+
+// Test that uploads go to the local uploads directory by default and that
+// unknown stores and an S3 store without a bucket are rejected.
+func TestLoad_BlobStore(t *testing.T) {
+    t.Setenv("BLOB_STORE", "")
+    os.Unsetenv("BLOB_STORE")
+    t.Setenv("UPLOAD_DIR", "")
+    os.Unsetenv("UPLOAD_DIR")
+    t.Setenv("S3_BUCKET", "")
+    cfg, err := Load()
+    if err != nil {
+        t.Fatalf("load: %v", err)
+    }
+    if cfg.BlobStore != BlobStoreLocal || cfg.UploadDir != "uploads" {
+        t.Fatalf("expected local store in uploads, got %q in %q", cfg.BlobStore, cfg.UploadDir)
+    }
+    t.Setenv("BLOB_STORE", "s3")
+    if _, err := Load(); err == nil {
+        t.Fatalf("expected an S3 store without S3_BUCKET to be rejected")
+    }
+    t.Setenv("S3_BUCKET", "mauflow")
+    if _, err := Load(); err != nil {
+        t.Fatalf("load with bucket: %v", err)
+    }
+    t.Setenv("BLOB_STORE", "ftp")
+    if _, err := Load(); err == nil {
+        t.Fatalf("expected an unknown BLOB_STORE to be rejected")
+    }
+}
+
+// Test that the concurrency limit is off by default and rejects negative
+// values.
+func TestLoad_ConcurrencyLimit(t *testing.T) {
+    t.Setenv("MAX_CONCURRENT_REQUESTS", "")
+    cfg, err := Load()
+    if err != nil {
+        t.Fatalf("load: %v", err)
+    }
+    if cfg.MaxConcurrentRequests != 0 || cfg.ConcurrencyQueueTimeoutMS != 1000 {
+        t.Fatalf("expected no limit and a 1000ms queue timeout, got %d and %d", cfg.MaxConcurrentRequests, cfg.ConcurrencyQueueTimeoutMS)
+    }
+    for _, key := range []string{"MAX_CONCURRENT_REQUESTS", "CONCURRENCY_QUEUE_SIZE", "CONCURRENCY_QUEUE_TIMEOUT_MS"} {
+        t.Run(key, func(t *testing.T) {
+            t.Setenv(key, "-1")
+            if _, err := Load(); err == nil {
+                t.Fatalf("expected a negative %s to be rejected", key)
+            }
+        })
+    }
+}
+
+// Test that LoadForEnv reads .env, then .env.<env>, then .env.local, each
+// overriding the one before, while .env gives way to the environment.
+func TestLoadForEnv_Precedence(t *testing.T) {
+    dir := t.TempDir()
+    files := map[string]string{
+        ".env":         "PORT=1000\nAI_MODEL=base-model\nLOG_LEVEL=debug\nDB_NAME=from-file\nJWT_SECRET=" + strings.Repeat("s", MinJWTSecretLen) + "\n",
+        ".env.staging": "PORT=2000\nAI_MODEL=staging-model\n",
+        ".env.local":   "PORT=3000\n",
+        ".env.other":   "LOG_LEVEL=error\n",
+    }
+    for name, content := range files {
+        if err := os.WriteFile(dir+"/"+name, []byte(content), 0o600); err != nil {
+            t.Fatalf("write %s: %v", name, err)
+        }
+    }
+    wd, err := os.Getwd()
+    if err != nil {
+        t.Fatalf("getwd: %v", err)
+    }
+    if err := os.Chdir(dir); err != nil {
+        t.Fatalf("chdir: %v", err)
+    }
+    t.Cleanup(func() { os.Chdir(wd) })
+    // Unset the file-provided variables so the files can set them; t.Setenv
+    // restores them after the test.
+    for _, key := range []string{"PORT", "AI_MODEL", "LOG_LEVEL", "JWT_SECRET"} {
+        t.Setenv(key, "")
+        os.Unsetenv(key)
+    }
+    t.Setenv("DB_NAME", "from-env")
+
+    cfg, err := LoadForEnv("staging")
+    if err != nil {
+        t.Fatalf("load: %v", err)
+    }
+    if cfg.Env != "staging" {
+        t.Fatalf("expected env staging, got %q", cfg.Env)
+    }
+    if cfg.Port != "3000" {
+        t.Fatalf("expected .env.local to set PORT 3000, got %q", cfg.Port)
+    }
+    if cfg.AIModel != "staging-model" {
+        t.Fatalf("expected .env.staging to override .env, got %q", cfg.AIModel)
+    }
+    if cfg.LogLevel != "debug" {
+        t.Fatalf("expected .env to fill LOG_LEVEL and .env.other to be ignored, got %q", cfg.LogLevel)
+    }
+    if cfg.DBName != "from-env" {
+        t.Fatalf("expected the environment to win over .env, got %q", cfg.DBName)
+    }
+}
@@ -0,0 +1,17 @@
+// Package ctxkeys holds typed context keys shared across layers.
+package ctxkeys
+
+import "context"
+
+type correlationKey struct{}
+
+// WithCorrelationID returns a copy of ctx carrying the correlation ID.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+    return context.WithValue(ctx, correlationKey{}, id)
+}
+
+// CorrelationIDFromCtx returns the correlation ID stored in ctx, or "".
+func CorrelationIDFromCtx(ctx context.Context) string {
+    id, _ := ctx.Value(correlationKey{}).(string)
+    return id
+}
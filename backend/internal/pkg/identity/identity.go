@@ -0,0 +1,51 @@
+// Package identity describes who an authenticated request acts as.
+package identity
+
+import "errors"
+
+// Verification errors shared by every token verifier, so callers can tell
+// them apart without knowing the verifier. Verifiers wrap them with detail;
+// compare with errors.Is.
+var (
+    // ErrTokenExpired is returned for a token whose exp has passed. Clients
+    // can trade their refresh token for a new one instead of signing in
+    // again.
+    ErrTokenExpired = errors.New("token expired")
+    // ErrTokenInvalid is returned for every other refused token.
+    ErrTokenInvalid = errors.New("invalid token")
+)
+
+// Roles granted to authenticated callers.
+const (
+    // RoleMember is held by every user signed in with a token.
+    RoleMember = "member"
+    // RoleService is held by callers authenticated with a tenant API key.
+    RoleService = "service"
+    // RoleSystem is held by internal services, such as background workers,
+    // authenticated with the service token. They act as SystemUserID in no
+    // tenant.
+    RoleSystem = "system"
+)
+
+// SystemUserID is the user internal services act as.
+const SystemUserID = "system"
+
+// Claims are the verified facts about the caller of a request.
+type Claims struct {
+    UserID   string   `json:"userId"`
+    TenantID string   `json:"tenantId"`
+    Roles    []string `json:"roles"`
+    // TokenID is the jti of the access token the caller presented, when the
+    // token carries one.
+    TokenID string `json:"tokenId,omitempty"`
+}
+
+// HasRole reports whether the claims include role.
+func (c Claims) HasRole(role string) bool {
+    for _, r := range c.Roles {
+        if r == role {
+            return true
+        }
+    }
+    return false
+}
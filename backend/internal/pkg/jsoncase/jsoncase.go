@@ -0,0 +1,113 @@
+// Package jsoncase rewrites the object keys of encoded JSON, so responses
+// can be offered in another naming convention without changing the structs
+// they are marshaled from.
+package jsoncase
+
+import (
+    "bytes"
+    "encoding/json"
+    "io"
+    "strings"
+    "unicode"
+)
+
+// Snake converts a camelCase key to snake_case: tenantId becomes tenant_id
+// and HTTPStatus becomes http_status. Keys without upper-case letters are
+// returned unchanged.
+func Snake(key string) string {
+    runes := []rune(key)
+    var b strings.Builder
+    for i, r := range runes {
+        if !unicode.IsUpper(r) {
+            b.WriteRune(r)
+            continue
+        }
+        if i > 0 {
+            prev := runes[i-1]
+            // An upper-case letter starts a word after a lower-case letter or
+            // digit, or when it ends an acronym followed by a new word.
+            nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+            if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+                b.WriteByte('_')
+            }
+        }
+        b.WriteRune(unicode.ToLower(r))
+    }
+    return b.String()
+}
+
+// frame tracks an open object or array while rewriting.
+type frame struct {
+    object bool
+    // n counts the members or elements written so far.
+    n int
+    // key is set when the next token of an object is a key.
+    key bool
+}
+
+// Rewrite returns body with every object key passed through convert. Values,
+// member order and numbers are kept as they are.
+func Rewrite(body []byte, convert func(string) string) ([]byte, error) {
+    dec := json.NewDecoder(bytes.NewReader(body))
+    dec.UseNumber()
+    var buf bytes.Buffer
+    buf.Grow(len(body))
+    var stack []frame
+    for {
+        tok, err := dec.Token()
+        if err == io.EOF {
+            if len(stack) > 0 {
+                return nil, io.ErrUnexpectedEOF
+            }
+            return buf.Bytes(), nil
+        }
+        if err != nil {
+            return nil, err
+        }
+        if d, ok := tok.(json.Delim); ok && (d == '}' || d == ']') {
+            stack = stack[:len(stack)-1]
+            buf.WriteByte(byte(d))
+            continue
+        }
+        if n := len(stack); n > 0 {
+            top := &stack[n-1]
+            switch {
+            case top.object && top.key:
+                if top.n > 0 {
+                    buf.WriteByte(',')
+                }
+                top.n++
+                top.key = false
+                if err := writeJSON(&buf, convert(tok.(string))); err != nil {
+                    return nil, err
+                }
+                buf.WriteByte(':')
+                continue
+            case top.object:
+                top.key = true
+            default:
+                if top.n > 0 {
+                    buf.WriteByte(',')
+                }
+                top.n++
+            }
+        }
+        if d, ok := tok.(json.Delim); ok {
+            stack = append(stack, frame{object: d == '{', key: d == '{'})
+            buf.WriteByte(byte(d))
+            continue
+        }
+        if err := writeJSON(&buf, tok); err != nil {
+            return nil, err
+        }
+    }
+}
+
+func writeJSON(buf *bytes.Buffer, v any) error {
+    b, err := json.Marshal(v)
+    if err != nil {
+        return err
+    }
+    buf.Write(b)
+    return nil
+}
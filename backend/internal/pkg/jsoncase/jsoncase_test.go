@@ -0,0 +1,41 @@
+package jsoncase
+
+import "testing"
+
+// Test that camelCase keys, including acronyms and digits, become
+// snake_case and that other keys are left alone.
+func TestSnake(t *testing.T) {
+    cases := map[string]string{
+        "tenantId":          "tenant_id",
+        "urgentWithinHours": "urgent_within_hours",
+        "taskIds":           "task_ids",
+        "HTTPStatus":        "http_status",
+        "p95Ms":             "p95_ms",
+        "id":                "id",
+        "already_snake":     "already_snake",
+        "5b1f0c9e-uuid":     "5b1f0c9e-uuid",
+    }
+    for in, want := range cases {
+        if got := Snake(in); got != want {
+            t.Fatalf("Snake(%q): expected %q, got %q", in, want, got)
+        }
+    }
+}
+
+// Test that Rewrite renames nested keys while keeping member order, values
+// and number formatting.
+func TestRewrite(t *testing.T) {
+    in := `{"taskId":"a","dueDate":null,"tags":["fooBar",{"aiScore":1.50}],"nested":{"isFavorite":true,"emptyList":[],"emptyObj":{}},"big":12345678901234567890}`
+    want := `{"task_id":"a","due_date":null,"tags":["fooBar",{"ai_score":1.50}],"nested":{"is_favorite":true,"empty_list":[],"empty_obj":{}},"big":12345678901234567890}`
+    got, err := Rewrite([]byte(in), Snake)
+    if err != nil {
+        t.Fatalf("Rewrite: %v", err)
+    }
+    if string(got) != want {
+        t.Fatalf("expected %s, got %s", want, got)
+    }
+
+    if _, err := Rewrite([]byte(`{"a":`), Snake); err == nil {
+        t.Fatalf("expected an error for truncated JSON")
+    }
+}
@@ -0,0 +1,37 @@
+// Package markdown renders user-supplied Markdown to HTML that is safe to
+// embed in a page.
+package markdown
+
+import (
+    "bytes"
+    "regexp"
+
+    "github.com/microcosm-cc/bluemonday"
+    "github.com/yuin/goldmark"
+    "github.com/yuin/goldmark/extension"
+    "github.com/yuin/goldmark/renderer/html"
+)
+
+// converter passes inline HTML through so the formatting kept by the
+// description sanitizer survives; policy strips anything unsafe afterwards.
+var converter = goldmark.New(
+    goldmark.WithExtensions(extension.GFM),
+    goldmark.WithRendererOptions(html.WithUnsafe()),
+)
+
+// policy is the UGC policy plus the language class on fenced code blocks,
+// which clients use for syntax highlighting.
+var policy = func() *bluemonday.Policy {
+    p := bluemonday.UGCPolicy()
+    p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#.-]+$`)).OnElements("code")
+    return p
+}()
+
+// RenderMarkdown converts md (GitHub-flavored Markdown) to sanitized HTML.
+func RenderMarkdown(md string) (string, error) {
+    var buf bytes.Buffer
+    if err := converter.Convert([]byte(md), &buf); err != nil {
+        return "", err
+    }
+    return policy.Sanitize(buf.String()), nil
+}
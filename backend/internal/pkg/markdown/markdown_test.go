@@ -0,0 +1,59 @@
+package markdown
+
+import (
+    "strings"
+    "testing"
+)
+
+// Test that common Markdown constructs render to the expected elements.
+func TestRenderMarkdown_Constructs(t *testing.T) {
+    cases := []struct {
+        name string
+        md   string
+        want []string
+    }{
+        {"heading", "# Title\n\n## Sub", []string{"<h1>Title</h1>", "<h2>Sub</h2>"}},
+        {"emphasis", "**bold** and _it_", []string{"<strong>bold</strong>", "<em>it</em>"}},
+        {"link", "[docs](https://example.com/docs)", []string{`<a href="https://example.com/docs" rel="nofollow">docs</a>`}},
+        {"fenced code", "```go\nx := 1 < 2\n```", []string{`<pre><code class="language-go">x := 1 &lt; 2`}},
+        {"inline code", "run `make`", []string{"<code>make</code>"}},
+        {"list", "- a\n- b", []string{"<ul>", "<li>a</li>", "<li>b</li>"}},
+        {"inline html", "<b>kept</b>", []string{"<b>kept</b>"}},
+    }
+    for _, tc := range cases {
+        got, err := RenderMarkdown(tc.md)
+        if err != nil {
+            t.Fatalf("%s: %v", tc.name, err)
+        }
+        for _, w := range tc.want {
+            if !strings.Contains(got, w) {
+                t.Fatalf("%s: expected %q in %q", tc.name, w, got)
+            }
+        }
+    }
+}
+
+// Test that XSS payloads in Markdown or inline HTML are stripped.
+func TestRenderMarkdown_XSS(t *testing.T) {
+    payloads := []string{
+        "<script>alert(1)</script>",
+        `<img src=x onerror="alert(1)">`,
+        "[click](javascript:alert(1))",
+        `<a href="javascript:alert(1)">click</a>`,
+        `<iframe src="https://evil.example"></iframe>`,
+        `<div style="background:url(javascript:alert(1))" onclick="alert(1)">x</div>`,
+        "![x](javascript:alert(1))",
+    }
+    for _, p := range payloads {
+        got, err := RenderMarkdown(p)
+        if err != nil {
+            t.Fatalf("%q: %v", p, err)
+        }
+        lower := strings.ToLower(got)
+        for _, bad := range []string{"<script", "onerror", "onclick", "javascript:", "<iframe", "style="} {
+            if strings.Contains(lower, bad) {
+                t.Fatalf("%q: expected %q to be stripped, got %q", p, bad, got)
+            }
+        }
+    }
+}
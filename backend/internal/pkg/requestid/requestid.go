@@ -0,0 +1,36 @@
+package requestid
+
+import "context"
+
+// MaxLen is the longest request ID accepted from a client.
+const MaxLen = 64
+
+type ctxKey struct{}
+
+// NewContext returns a copy of ctx carrying the given request ID.
+func NewContext(ctx context.Context, id string) context.Context {
+    return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext returns the request ID stored in ctx, or "" if none is set.
+func FromContext(ctx context.Context) string {
+    id, _ := ctx.Value(ctxKey{}).(string)
+    return id
+}
+
+// Valid reports whether id is safe to pass on to logs and SQL comments: 1 to
+// MaxLen characters, each a letter, digit, '.', '_' or '-'.
+func Valid(id string) bool {
+    if id == "" || len(id) > MaxLen {
+        return false
+    }
+    for i := 0; i < len(id); i++ {
+        c := id[i]
+        switch {
+        case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '.', c == '_', c == '-':
+        default:
+            return false
+        }
+    }
+    return true
+}
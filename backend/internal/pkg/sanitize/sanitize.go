@@ -0,0 +1,39 @@
+// Package sanitize cleans user-supplied rich text before it is stored.
+package sanitize
+
+import (
+    "html"
+
+    "github.com/microcosm-cc/bluemonday"
+)
+
+// policy allows basic inline and block formatting plus links, and strips
+// everything else: scripts, event handlers, images, iframes and styles.
+var policy = func() *bluemonday.Policy {
+    p := bluemonday.StrictPolicy()
+    p.AllowElements("b", "strong", "i", "em", "u", "s", "code", "pre", "p", "br", "ul", "ol", "li", "blockquote")
+    p.AllowStandardURLs()
+    p.AllowAttrs("href").OnElements("a")
+    p.RequireNoFollowOnLinks(true)
+    return p
+}()
+
+// maxPasses bounds how often HTML re-sanitizes its own unescaped output.
+const maxPasses = 4
+
+// HTML removes markup from s that could execute in a browser. The policy
+// escapes text as HTML, which would turn "Tom & Jerry" into
+// "Tom &amp; Jerry", so its output is unescaped again and re-sanitized
+// until nothing changes: that keeps "&" and "<" as typed, while escaped
+// markup such as "&lt;script&gt;" is stripped once unescaped. Input that
+// does not settle within maxPasses is returned escaped.
+func HTML(s string) string {
+    for i := 0; i < maxPasses; i++ {
+        out := html.UnescapeString(policy.Sanitize(s))
+        if out == s {
+            return out
+        }
+        s = out
+    }
+    return policy.Sanitize(s)
+}